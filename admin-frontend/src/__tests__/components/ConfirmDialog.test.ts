@@ -60,4 +60,13 @@ describe('ConfirmDialog', () => {
     })
     expect(wrapper.find('.trigger-content').exists()).toBe(true)
   })
+
+  it('renders default slot content between description and actions', () => {
+    const wrapper = mount(ConfirmDialog, {
+      slots: {
+        default: '<input class="reason-input">',
+      },
+    })
+    expect(wrapper.find('.reason-input').exists()).toBe(true)
+  })
 })
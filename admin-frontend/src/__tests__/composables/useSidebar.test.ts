@@ -27,7 +27,7 @@ describe('useSidebar', () => {
 
       // 17 базовых + Дейлики + Челленджи (геймификация, PR-322)
       // + Кредиты (реферальные кредиты, PR-347)
-      expect(sidebarItems.value).toHaveLength(20)
+      expect(sidebarItems.value).toHaveLength(21)
     })
 
     it('shows only items without requiredPermission when user has no permissions', () => {
@@ -80,7 +80,7 @@ describe('useSidebar', () => {
       const expectedPaths = [
         '/dashboard', '/mentors', '/members', '/events', '/resumes',
         '/reviews', '/mentor-reviews', '/feedback', '/points', '/credits', '/chat-activity',
-        '/chat-quests', '/raffles', '/daily-tasks', '/challenges', '/minigames',
+        '/chat-quests', '/raffles', '/shop', '/daily-tasks', '/challenges', '/minigames',
         '/subscriptions', '/moderation', '/referrals', '/audit-logs',
       ]
       const actualPaths = sidebarItems.value.map(i => i.path)
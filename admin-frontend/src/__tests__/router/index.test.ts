@@ -79,6 +79,7 @@ describe('router', () => {
       ['chat-activity', '/chat-activity'],
       ['chat-quests', '/chat-quests'],
       ['raffles', '/raffles'],
+      ['shop', '/shop'],
       ['subscriptions', '/subscriptions'],
       ['feedback', '/feedback'],
       ['moderation', '/moderation'],
@@ -5,9 +5,15 @@ vi.mock('@/services/errorService', () => ({
   handleError: (...args: any[]) => mockHandleError(...args),
 }))
 
+const mockToast = vi.fn()
+vi.mock('@/components/ui/toast', () => ({
+  useToast: () => ({ toast: mockToast }),
+}))
+
 const mockJson = vi.fn()
 const mockApi = {
   get: vi.fn(() => ({ json: mockJson })),
+  put: vi.fn(() => ({ json: mockJson })),
 }
 vi.mock('@/lib/api', () => ({ default: mockApi }))
 
@@ -21,6 +27,7 @@ describe('casinoService', () => {
     casinoService.isLoading.value = false
     casinoService.pagination.value = { limit: 20, offset: 0 }
     casinoService.filters.value = {}
+    casinoService.limits.value = null
   })
 
   describe('getStats', () => {
@@ -32,6 +39,8 @@ describe('casinoService', () => {
         houseProfit: 500,
         uniquePlayers: 15,
         gameStats: [],
+        jackpot: { amount: 640, wins: 1, totalPaid: 1200, largestWin: 1200 },
+        crash: { rounds: 12, avgCrashPoint: 1.9, maxCrashPoint: 14.2, instantCrashes: 1, avgPlayers: 2.5, cashOutRate: 60 },
       }
       mockJson.mockResolvedValueOnce(mockStats)
 
@@ -150,4 +159,46 @@ describe('casinoService', () => {
       expect(casinoService.filters.value).toEqual({})
     })
   })
+
+  describe('limits', () => {
+    const limits = {
+      dailyLossLimit: 3000,
+      dailyBetLimit: 300,
+      cooldownAfterLosses: 10,
+      cooldownMinutes: 10,
+      increaseDelayHours: 24,
+    }
+
+    it('fetches global limits', async () => {
+      mockJson.mockResolvedValueOnce(limits)
+
+      await casinoService.getLimits()
+
+      expect(mockApi.get).toHaveBeenCalledWith('minigames/limits')
+      expect(casinoService.limits.value).toEqual(limits)
+    })
+
+    it('saves global limits and shows success toast', async () => {
+      const updated = { ...limits, dailyLossLimit: 1000 }
+      mockJson.mockResolvedValueOnce(updated)
+
+      const result = await casinoService.saveLimits(updated)
+
+      expect(result).toBe(true)
+      expect(mockApi.put).toHaveBeenCalledWith('minigames/limits', { json: updated })
+      expect(casinoService.limits.value).toEqual(updated)
+      expect(mockToast).toHaveBeenCalledWith(expect.objectContaining({ title: 'Успешно' }))
+    })
+
+    it('returns false and handles error on save failure', async () => {
+      const error = new Error('Forbidden')
+      mockJson.mockRejectedValueOnce(error)
+
+      const result = await casinoService.saveLimits(limits)
+
+      expect(result).toBe(false)
+      expect(mockHandleError).toHaveBeenCalledWith(error)
+      expect(mockToast).not.toHaveBeenCalled()
+    })
+  })
 })
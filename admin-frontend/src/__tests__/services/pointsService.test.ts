@@ -14,10 +14,10 @@ vi.mock('@/services/errorService', () => ({
 
 // Mock api
 const mockJson = vi.fn()
+const mockBlob = vi.fn()
 const mockApi = {
-  get: vi.fn(() => ({ json: mockJson })),
+  get: vi.fn(() => ({ json: mockJson, blob: mockBlob })),
   post: vi.fn(() => ({ json: mockJson })),
-  delete: vi.fn(),
 }
 vi.mock('@/lib/api', () => ({ default: mockApi }))
 
@@ -131,28 +131,65 @@ describe('pointsService', () => {
     })
   })
 
-  describe('deleteTransaction', () => {
-    it('deletes transaction and shows success toast', async () => {
-      mockApi.delete.mockResolvedValueOnce(undefined)
-      mockJson.mockResolvedValueOnce({ items: [], total: 0 }) // search refresh
+  describe('reverseTransaction', () => {
+    it('posts reversal with reason and shows success toast', async () => {
+      mockJson
+        .mockResolvedValueOnce({}) // reverse response
+        .mockResolvedValueOnce({ items: [], total: 0 }) // search refresh
 
-      const result = await pointsService.deleteTransaction(42)
+      const result = await pointsService.reverseTransaction(42, 'ошибочное начисление')
 
       expect(result).toBe(true)
-      expect(mockApi.delete).toHaveBeenCalledWith('points/42')
+      expect(mockApi.post).toHaveBeenCalledWith('points/42/reverse', { json: { reason: 'ошибочное начисление' } })
       expect(mockToast).toHaveBeenCalledWith({
         title: 'Успешно',
-        description: 'Транзакция удалена',
+        description: 'Транзакция отменена',
       })
     })
 
     it('returns false on failure', async () => {
-      const error = new Error('Delete failed')
-      mockApi.delete.mockRejectedValueOnce(error)
+      const error = new Error('Reverse failed')
+      mockJson.mockRejectedValueOnce(error)
+
+      const result = await pointsService.reverseTransaction(42, '')
+
+      expect(result).toBe(false)
+      expect(mockHandleError).toHaveBeenCalledWith(error)
+    })
+  })
+
+  describe('exportLedger', () => {
+    it('downloads export with member, date and reason filters', async () => {
+      mockBlob.mockResolvedValueOnce(new Blob(['id,amount']))
+      global.URL.createObjectURL = vi.fn(() => 'blob:points')
+      global.URL.revokeObjectURL = vi.fn()
+      const link = { href: '', download: '', click: vi.fn() } as any
+      vi.spyOn(document, 'createElement').mockReturnValueOnce(link)
+
+      const result = await pointsService.exportLedger({
+        format: 'json',
+        memberId: 42,
+        from: '2026-09-01',
+        to: '2026-09-30',
+        reasons: ['casino_win'],
+      })
+
+      expect(result).toBe(true)
+      expect(mockApi.get).toHaveBeenCalledWith('points/export', {
+        searchParams: { format: 'json', member_id: 42, from: '2026-09-01', to: '2026-09-30', reason: 'casino_win' },
+      })
+      expect(link.download).toBe('points.json')
+      expect(link.click).toHaveBeenCalled()
+    })
+
+    it('omits empty filters and reports failure', async () => {
+      const error = new Error('Export failed')
+      mockBlob.mockRejectedValueOnce(error)
 
-      const result = await pointsService.deleteTransaction(42)
+      const result = await pointsService.exportLedger({ format: 'csv' })
 
       expect(result).toBe(false)
+      expect(mockApi.get).toHaveBeenCalledWith('points/export', { searchParams: { format: 'csv' } })
       expect(mockHandleError).toHaveBeenCalledWith(error)
     })
   })
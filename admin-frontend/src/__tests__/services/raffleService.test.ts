@@ -88,6 +88,10 @@ describe('raffleService', () => {
         ticketCost: 10,
         maxTickets: 100,
         endsAt: '2026-05-01T00:00:00Z',
+        prizes: [
+          { title: 'Худи', winnersCount: 1 },
+          { title: 'Стикеры', winnersCount: 3 },
+        ],
       }
       mockJson.mockResolvedValueOnce({ id: 1, ...createData }) // create response
       mockJson.mockResolvedValueOnce([]) // getAll after create
@@ -113,6 +117,7 @@ describe('raffleService', () => {
         ticketCost: 0,
         maxTickets: 0,
         endsAt: '',
+        prizes: [],
       })
 
       expect(result).toBe(false)
@@ -130,6 +135,7 @@ describe('raffleService', () => {
         ticketCost: 0,
         maxTickets: 0,
         endsAt: '',
+        prizes: [],
       })
 
       // getAll is called after create, so get is called once
@@ -137,6 +143,56 @@ describe('raffleService', () => {
     })
   })
 
+  describe('getWinners', () => {
+    it('fetches winners of a raffle', async () => {
+      const winners = [{ id: 1, raffleId: 3, place: 1, prizeTitle: 'Худи', status: 'pending' }]
+      mockJson.mockResolvedValueOnce({ items: winners })
+
+      const result = await raffleService.getWinners(3)
+
+      expect(mockApi.get).toHaveBeenCalledWith('raffles/3/winners')
+      expect(result).toEqual(winners)
+    })
+
+    it('returns empty array and calls handleError on failure', async () => {
+      const error = new Error('Network error')
+      mockJson.mockRejectedValueOnce(error)
+
+      const result = await raffleService.getWinners(3)
+
+      expect(result).toEqual([])
+      expect(mockHandleError).toHaveBeenCalledWith(error)
+    })
+  })
+
+  describe('updateFulfillment', () => {
+    it('puts status and note and shows success toast', async () => {
+      const winner = { id: 7, status: 'fulfilled', fulfillmentNote: 'отправлено почтой' }
+      mockJson.mockResolvedValueOnce(winner)
+
+      const result = await raffleService.updateFulfillment(7, 'fulfilled', 'отправлено почтой')
+
+      expect(result).toEqual(winner)
+      expect(mockApi.put).toHaveBeenCalledWith('raffles/winners/7', {
+        json: { status: 'fulfilled', note: 'отправлено почтой' },
+      })
+      expect(mockToast).toHaveBeenCalledWith({
+        title: 'Успешно',
+        description: 'Приз отмечен выданным',
+      })
+    })
+
+    it('returns null and calls handleError on failure', async () => {
+      const error = new Error('Update failed')
+      mockJson.mockRejectedValueOnce(error)
+
+      const result = await raffleService.updateFulfillment(7, 'pending', '')
+
+      expect(result).toBeNull()
+      expect(mockHandleError).toHaveBeenCalledWith(error)
+    })
+  })
+
   describe('delete', () => {
     it('deletes a raffle by id and shows success toast', async () => {
       mockApi.delete.mockResolvedValueOnce(undefined)
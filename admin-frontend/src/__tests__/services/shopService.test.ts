@@ -0,0 +1,170 @@
+import { beforeEach, describe, expect, it, vi } from 'vitest'
+
+// Mock useToast before importing the service (used at module scope)
+const mockToast = vi.fn()
+vi.mock('@/components/ui/toast', () => ({
+  useToast: () => ({ toast: mockToast }),
+}))
+
+// Mock handleError
+const mockHandleError = vi.fn()
+vi.mock('@/services/errorService', () => ({
+  handleError: (...args: any[]) => mockHandleError(...args),
+}))
+
+// Mock api
+const mockJson = vi.fn()
+const mockApi = {
+  get: vi.fn(() => ({ json: mockJson })),
+  post: vi.fn(() => ({ json: mockJson })),
+  put: vi.fn(() => ({ json: mockJson })),
+  patch: vi.fn(() => ({ json: mockJson })),
+  delete: vi.fn(),
+}
+vi.mock('@/lib/api', () => ({ default: mockApi }))
+
+// Import after mocks
+const { shopService } = await import('@/services/shopService')
+
+const itemData = {
+  kind: 'merch' as const,
+  title: 'Футболка',
+  description: '',
+  imageUrl: '',
+  price: 500,
+  quantity: 1,
+  stock: 10,
+  perMemberLimit: 1,
+  isActive: true,
+  sortOrder: 0,
+}
+
+describe('shopService', () => {
+  beforeEach(() => {
+    vi.clearAllMocks()
+    shopService.items.value = []
+    shopService.orders.value = []
+    shopService.ordersTotal.value = 0
+    shopService.isLoading.value = false
+  })
+
+  describe('getItems', () => {
+    it('fetches the catalog and updates items', async () => {
+      const items = [{ id: 1, title: 'Футболка' }, { id: 2, title: 'Стикеры' }]
+      mockJson.mockResolvedValueOnce({ items, total: 2 })
+
+      await shopService.getItems()
+
+      expect(mockApi.get).toHaveBeenCalledWith('shop/items')
+      expect(shopService.items.value).toEqual(items)
+      expect(shopService.isLoading.value).toBe(false)
+    })
+
+    it('handles errors via handleError', async () => {
+      const error = new Error('Network error')
+      mockJson.mockRejectedValueOnce(error)
+
+      await shopService.getItems()
+
+      expect(mockHandleError).toHaveBeenCalledWith(error)
+      expect(shopService.isLoading.value).toBe(false)
+    })
+  })
+
+  describe('saveItem', () => {
+    it('creates an item without id', async () => {
+      mockJson.mockResolvedValueOnce({ id: 1 }) // create
+      mockJson.mockResolvedValueOnce({ items: [] }) // getItems
+
+      const result = await shopService.saveItem(itemData)
+
+      expect(result).toBe(true)
+      expect(mockApi.post).toHaveBeenCalledWith('shop/items', { json: itemData })
+      expect(mockToast).toHaveBeenCalledWith({ title: 'Успешно', description: 'Товар добавлен' })
+    })
+
+    it('updates an item by id', async () => {
+      mockJson.mockResolvedValueOnce({ id: 4 })
+      mockJson.mockResolvedValueOnce({ items: [] })
+
+      const result = await shopService.saveItem(itemData, 4)
+
+      expect(result).toBe(true)
+      expect(mockApi.put).toHaveBeenCalledWith('shop/items/4', { json: itemData })
+      expect(mockToast).toHaveBeenCalledWith({ title: 'Успешно', description: 'Товар обновлён' })
+    })
+
+    it('returns false and calls handleError on failure', async () => {
+      const error = new Error('Validation failed')
+      mockJson.mockRejectedValueOnce(error)
+
+      const result = await shopService.saveItem(itemData)
+
+      expect(result).toBe(false)
+      expect(mockHandleError).toHaveBeenCalledWith(error)
+    })
+  })
+
+  describe('deleteItem', () => {
+    it('deletes an item and refreshes the catalog', async () => {
+      mockApi.delete.mockResolvedValueOnce(undefined)
+      mockJson.mockResolvedValueOnce({ items: [] })
+
+      const result = await shopService.deleteItem(5)
+
+      expect(result).toBe(true)
+      expect(mockApi.delete).toHaveBeenCalledWith('shop/items/5')
+      expect(mockApi.get).toHaveBeenCalledWith('shop/items')
+    })
+
+    it('returns false when the item has orders', async () => {
+      const error = new Error('has orders')
+      mockApi.delete.mockRejectedValueOnce(error)
+
+      const result = await shopService.deleteItem(5)
+
+      expect(result).toBe(false)
+      expect(mockHandleError).toHaveBeenCalledWith(error)
+    })
+  })
+
+  describe('getOrders', () => {
+    it('fetches orders with status filter and paging', async () => {
+      const orders = [{ id: 1, status: 'pending' }]
+      mockJson.mockResolvedValueOnce({ items: orders, total: 21 })
+
+      await shopService.getOrders('pending', 20, 20)
+
+      expect(mockApi.get).toHaveBeenCalledWith('shop/orders', {
+        searchParams: { status: 'pending', limit: 20, offset: 20 },
+      })
+      expect(shopService.orders.value).toEqual(orders)
+      expect(shopService.ordersTotal.value).toBe(21)
+    })
+  })
+
+  describe('setOrderStatus', () => {
+    it('patches status and note and shows success toast', async () => {
+      const order = { id: 7, status: 'shipped', adminNote: 'трек 123' }
+      mockJson.mockResolvedValueOnce(order)
+
+      const result = await shopService.setOrderStatus(7, 'shipped', 'трек 123')
+
+      expect(result).toEqual(order)
+      expect(mockApi.patch).toHaveBeenCalledWith('shop/orders/7', {
+        json: { status: 'shipped', adminNote: 'трек 123' },
+      })
+      expect(mockToast).toHaveBeenCalledWith({ title: 'Успешно', description: 'Статус заказа обновлён' })
+    })
+
+    it('returns null and calls handleError on failure', async () => {
+      const error = new Error('Bad transition')
+      mockJson.mockRejectedValueOnce(error)
+
+      const result = await shopService.setOrderStatus(7, 'delivered', '')
+
+      expect(result).toBeNull()
+      expect(mockHandleError).toHaveBeenCalledWith(error)
+    })
+  })
+})
@@ -14,6 +14,7 @@ import Home from '~icons/lucide/home'
 import Link from '~icons/lucide/link'
 import MessageSquare from '~icons/lucide/message-square'
 import ShieldAlert from '~icons/lucide/shield-alert'
+import ShoppingBag from '~icons/lucide/shopping-bag'
 import Star from '~icons/lucide/star'
 import Sword from '~icons/lucide/sword'
 import Target from '~icons/lucide/target'
@@ -133,6 +134,12 @@ export function useSidebar() {
           path: '/raffles',
           icon: Gift,
         },
+        {
+          title: 'Магазин',
+          path: '/shop',
+          icon: ShoppingBag,
+          requiredPermission: 'can_view_admin_points',
+        },
         {
           title: 'Дейлики',
           path: '/daily-tasks',
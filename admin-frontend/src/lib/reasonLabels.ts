@@ -21,4 +21,12 @@ export const reasonLabels: Record<string, string> = {
   raffle_spend: 'Розыгрыши',
   casino_bet: 'Ставки мини-игр',
   casino_win: 'Выигрыши мини-игр',
+  casino_jackpot: 'Джекпот мини-игр',
+  shop_purchase: 'Покупки в магазине',
+  shop_refund: 'Возвраты магазина',
+  streak_freeze: 'Заморозки стрика',
+  streak_repair: 'Восстановление стрика',
+  leaderboard_prize: 'Призы рейтинга',
+  clawback: 'Списания (анти-абуз)',
+  reversal: 'Отмены транзакций',
 }
@@ -1,4 +1,5 @@
 import type { ClassValue } from 'clsx'
+import type { LedgerExportParams } from '@/models/points'
 import { clsx } from 'clsx'
 import { twMerge } from 'tailwind-merge'
 
@@ -69,3 +70,24 @@ export function cleanParams(params: Record<string, any>) {
     }),
   )
 }
+
+// Сохраняет полученный файл через временную ссылку.
+export function saveBlob(blob: Blob, filename: string) {
+  const url = URL.createObjectURL(blob)
+  const a = document.createElement('a')
+  a.href = url
+  a.download = filename
+  a.click()
+  URL.revokeObjectURL(url)
+}
+
+// Параметры выгрузки журнала в query-строку бэкенда.
+export function ledgerSearchParams(params: LedgerExportParams) {
+  return cleanParams({
+    format: params.format,
+    member_id: params.memberId,
+    from: params.from,
+    to: params.to,
+    reason: params.reasons?.join(','),
+  })
+}
@@ -29,4 +29,31 @@ export interface CasinoAdminStats {
   houseProfit: number
   uniquePlayers: number
   gameStats: GameStats[]
+  jackpot: CasinoJackpotAdminStats
+  crash: CasinoCrashAdminStats
+}
+
+export interface CasinoJackpotAdminStats {
+  amount: number
+  wins: number
+  totalPaid: number
+  largestWin: number
+}
+
+// cashOutRate — доля ставок crash, выведенных до краша, в процентах.
+export interface CasinoCrashAdminStats {
+  rounds: number
+  avgCrashPoint: number
+  maxCrashPoint: number
+  instantCrashes: number
+  avgPlayers: number
+  cashOutRate: number
+}
+
+export interface CasinoGlobalLimits {
+  dailyLossLimit: number
+  dailyBetLimit: number
+  cooldownAfterLosses: number
+  cooldownMinutes: number
+  increaseDelayHours: number
 }
@@ -8,6 +8,8 @@ export interface AdminPointTransaction {
   reason: string
   sourceType: string
   description: string
+  reversesId: number | null
+  reversedById: number | null
   createdAt: string
 }
 
@@ -16,3 +18,13 @@ export interface AdminAwardRequest {
   amount: number
   description: string
 }
+
+// Фильтр выгрузки журнала баллов/кредитов: даты — YYYY-MM-DD по МСК
+// включительно, без memberId — все участники.
+export interface LedgerExportParams {
+  format: 'csv' | 'json'
+  memberId?: number
+  from?: string
+  to?: string
+  reasons?: string[]
+}
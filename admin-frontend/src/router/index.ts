@@ -16,6 +16,7 @@ const ReferralsView = () => import('@/views/ReferralsView.vue')
 const ChatActivityView = () => import('@/views/ChatActivityView.vue')
 const ChatQuestsView = () => import('@/views/ChatQuestsView.vue')
 const RafflesView = () => import('@/views/RafflesView.vue')
+const ShopView = () => import('@/views/ShopView.vue')
 const CasinoView = () => import('@/views/CasinoView.vue')
 const DailyTasksView = () => import('@/views/DailyTasksView.vue')
 const ChallengesView = () => import('@/views/ChallengesView.vue')
@@ -122,6 +123,12 @@ const router = createRouter({
       component: RafflesView,
       meta: { requiresAuth: true },
     },
+    {
+      path: '/shop',
+      name: 'shop',
+      component: ShopView,
+      meta: { requiresAuth: true },
+    },
     {
       path: '/daily-tasks',
       name: 'daily-tasks',
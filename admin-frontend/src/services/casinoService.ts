@@ -1,6 +1,7 @@
-import type { CasinoAdminStats, CasinoBet } from '@/models/casino'
+import type { CasinoAdminStats, CasinoBet, CasinoGlobalLimits } from '@/models/casino'
 import type { Registry } from '@/models/registry'
 import { ref } from 'vue'
+import { useToast } from '@/components/ui/toast'
 import api from '@/lib/api'
 import { cleanParams } from '@/lib/utils'
 import { handleError } from '@/services/errorService'
@@ -16,6 +17,9 @@ class CasinoService {
   public stats = ref<CasinoAdminStats | null>(null)
   public pagination = ref({ limit: 20, offset: 0 })
   public filters = ref<CasinoFilters>({})
+  public limits = ref<CasinoGlobalLimits | null>(null)
+
+  private toast = useToast()
 
   changePagination = (page: number) => {
     this.pagination.value.offset = (page - 1) * this.pagination.value.limit
@@ -55,6 +59,30 @@ class CasinoService {
     }
   }
 
+  getLimits = async () => {
+    try {
+      this.limits.value = await api.get('minigames/limits').json<CasinoGlobalLimits>()
+    }
+    catch (error) {
+      handleError(error)
+    }
+  }
+
+  saveLimits = async (limits: CasinoGlobalLimits): Promise<boolean> => {
+    try {
+      this.limits.value = await api.put('minigames/limits', { json: limits }).json<CasinoGlobalLimits>()
+      this.toast.toast({
+        title: 'Успешно',
+        description: 'Лимиты сохранены',
+      })
+      return true
+    }
+    catch (error) {
+      handleError(error)
+      return false
+    }
+  }
+
   applyFilters = (filters: CasinoFilters) => {
     this.filters.value = filters
     this.pagination.value.offset = 0
@@ -1,9 +1,10 @@
 import type { AdminAwardCreditsRequest, AdminCreditTransaction } from '@/models/credits'
+import type { LedgerExportParams } from '@/models/points'
 import type { Registry } from '@/models/registry'
 import { ref } from 'vue'
 import { useToast } from '@/components/ui/toast'
 import api from '@/lib/api'
-import { cleanParams } from '@/lib/utils'
+import { cleanParams, ledgerSearchParams, saveBlob } from '@/lib/utils'
 import { handleError } from '@/services/errorService'
 
 export interface CreditsFilters {
@@ -71,6 +72,19 @@ class CreditsService {
       this.isLoading.value = false
     }
   }
+
+  // Потоковая выгрузка журнала целиком, без пагинации таблицы.
+  exportLedger = async (params: LedgerExportParams): Promise<boolean> => {
+    try {
+      const blob = await api.get('credits/export', { searchParams: ledgerSearchParams(params) }).blob()
+      saveBlob(blob, `credits.${params.format}`)
+      return true
+    }
+    catch (error) {
+      handleError(error)
+      return false
+    }
+  }
 }
 
 export const creditsService = new CreditsService()
@@ -1,9 +1,9 @@
-import type { AdminAwardRequest, AdminPointTransaction } from '@/models/points'
+import type { AdminAwardRequest, AdminPointTransaction, LedgerExportParams } from '@/models/points'
 import type { Registry } from '@/models/registry'
 import { ref } from 'vue'
 import { useToast } from '@/components/ui/toast'
 import api from '@/lib/api'
-import { cleanParams } from '@/lib/utils'
+import { cleanParams, ledgerSearchParams, saveBlob } from '@/lib/utils'
 import { handleError } from '@/services/errorService'
 
 export interface PointsFilters {
@@ -74,14 +74,14 @@ class PointsService {
     }
   }
 
-  deleteTransaction = async (id: number): Promise<boolean> => {
+  reverseTransaction = async (id: number, reason: string): Promise<boolean> => {
     try {
       this.isLoading.value = true
-      await api.delete(`points/${id}`)
+      await api.post(`points/${id}/reverse`, { json: { reason } }).json()
 
       this.toast.toast({
         title: 'Успешно',
-        description: 'Транзакция удалена',
+        description: 'Транзакция отменена',
       })
 
       await this.search()
@@ -95,6 +95,19 @@ class PointsService {
       this.isLoading.value = false
     }
   }
+
+  // Потоковая выгрузка журнала целиком, без пагинации таблицы.
+  exportLedger = async (params: LedgerExportParams): Promise<boolean> => {
+    try {
+      const blob = await api.get('points/export', { searchParams: ledgerSearchParams(params) }).blob()
+      saveBlob(blob, `points.${params.format}`)
+      return true
+    }
+    catch (error) {
+      handleError(error)
+      return false
+    }
+  }
 }
 
 export const pointsService = new PointsService()
@@ -3,6 +3,32 @@ import { useToast } from '@/components/ui/toast'
 import api from '@/lib/api'
 import { handleError } from '@/services/errorService'
 
+export interface RafflePrize {
+  id?: number
+  position?: number
+  title: string
+  winnersCount: number
+}
+
+export type RaffleWinnerStatus = 'pending' | 'fulfilled'
+
+export interface RaffleWinner {
+  id: number
+  raffleId: number
+  prizeId?: number | null
+  prizeTitle: string
+  place: number
+  memberId: number
+  tickets: number
+  status: RaffleWinnerStatus
+  fulfilledAt?: string | null
+  fulfillmentNote: string
+  createdAt: string
+  memberFirstName: string
+  memberLastName: string
+  memberUsername: string
+}
+
 export interface Raffle {
   id: number
   title: string
@@ -13,6 +39,9 @@ export interface Raffle {
   endsAt: string
   status: 'ACTIVE' | 'FINISHED'
   winnerId?: number | null
+  seedHash?: string
+  drawnAt?: string | null
+  prizes?: RafflePrize[]
   createdAt: string
 }
 
@@ -23,6 +52,7 @@ export interface RaffleCreateRequest {
   ticketCost: number
   maxTickets: number
   endsAt: string
+  prizes: RafflePrize[]
 }
 
 class RaffleService {
@@ -67,6 +97,34 @@ class RaffleService {
     }
   }
 
+  getWinners = async (id: number): Promise<RaffleWinner[]> => {
+    try {
+      const response = await api.get(`raffles/${id}/winners`).json<{ items: RaffleWinner[] }>()
+      return response.items ?? []
+    }
+    catch (error) {
+      handleError(error)
+      return []
+    }
+  }
+
+  updateFulfillment = async (winnerId: number, status: RaffleWinnerStatus, note: string): Promise<RaffleWinner | null> => {
+    try {
+      const winner = await api.put(`raffles/winners/${winnerId}`, { json: { status, note } }).json<RaffleWinner>()
+
+      this.toast.toast({
+        title: 'Успешно',
+        description: status === 'fulfilled' ? 'Приз отмечен выданным' : 'Выдача приза отменена',
+      })
+
+      return winner
+    }
+    catch (error) {
+      handleError(error)
+      return null
+    }
+  }
+
   delete = async (id: number): Promise<boolean> => {
     try {
       this.isLoading.value = true
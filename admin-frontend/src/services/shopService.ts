@@ -0,0 +1,165 @@
+import { ref } from 'vue'
+import { useToast } from '@/components/ui/toast'
+import api from '@/lib/api'
+import { handleError } from '@/services/errorService'
+
+export type ShopItemKind = 'merch' | 'mentor_session' | 'subscription_days' | 'streak_freeze' | 'cosmetic'
+export type ShopOrderStatus = 'pending' | 'shipped' | 'delivered' | 'cancelled'
+
+// stock null — без ограничения, perMemberLimit 0 — сколько угодно в одни руки.
+export interface ShopItem {
+  id: number
+  kind: ShopItemKind
+  title: string
+  description: string
+  imageUrl: string
+  price: number
+  quantity: number
+  stock: number | null
+  perMemberLimit: number
+  isActive: boolean
+  sortOrder: number
+  createdAt: string
+  updatedAt: string
+}
+
+export interface ShopItemRequest {
+  kind: ShopItemKind
+  title: string
+  description: string
+  imageUrl: string
+  price: number
+  quantity: number
+  stock: number | null
+  perMemberLimit: number
+  isActive: boolean
+  sortOrder: number
+}
+
+export interface ShopOrder {
+  id: number
+  itemId: number
+  memberId: number
+  itemTitle: string
+  itemKind: ShopItemKind
+  price: number
+  status: ShopOrderStatus
+  comment: string
+  adminNote: string
+  createdAt: string
+  updatedAt: string
+  shippedAt?: string | null
+  deliveredAt?: string | null
+  cancelledAt?: string | null
+  memberFirstName: string
+  memberLastName: string
+  memberUsername: string
+  memberTelegramId: number
+}
+
+class ShopService {
+  public isLoading = ref(false)
+  public items = ref<ShopItem[]>([])
+  public orders = ref<ShopOrder[]>([])
+  public ordersTotal = ref(0)
+
+  private toast = useToast()
+
+  getItems = async () => {
+    try {
+      this.isLoading.value = true
+      const response = await api.get('shop/items').json<{ items: ShopItem[] }>()
+      this.items.value = response?.items ?? []
+    }
+    catch (error) {
+      handleError(error)
+    }
+    finally {
+      this.isLoading.value = false
+    }
+  }
+
+  saveItem = async (data: ShopItemRequest, id?: number): Promise<boolean> => {
+    try {
+      this.isLoading.value = true
+      if (id)
+        await api.put(`shop/items/${id}`, { json: data }).json()
+      else
+        await api.post('shop/items', { json: data }).json()
+
+      this.toast.toast({
+        title: 'Успешно',
+        description: id ? 'Товар обновлён' : 'Товар добавлен',
+      })
+
+      await this.getItems()
+      return true
+    }
+    catch (error) {
+      handleError(error)
+      return false
+    }
+    finally {
+      this.isLoading.value = false
+    }
+  }
+
+  deleteItem = async (id: number): Promise<boolean> => {
+    try {
+      this.isLoading.value = true
+      await api.delete(`shop/items/${id}`)
+
+      this.toast.toast({
+        title: 'Успешно',
+        description: 'Товар удалён',
+      })
+
+      await this.getItems()
+      return true
+    }
+    catch (error) {
+      handleError(error)
+      return false
+    }
+    finally {
+      this.isLoading.value = false
+    }
+  }
+
+  // status пустой — все заказы.
+  getOrders = async (status = '', limit = 20, offset = 0) => {
+    try {
+      this.isLoading.value = true
+      const response = await api.get('shop/orders', { searchParams: { status, limit, offset } })
+        .json<{ items: ShopOrder[], total: number }>()
+      this.orders.value = response?.items ?? []
+      this.ordersTotal.value = response?.total ?? 0
+    }
+    catch (error) {
+      handleError(error)
+    }
+    finally {
+      this.isLoading.value = false
+    }
+  }
+
+  // Покупатель получает уведомление; отмена возвращает баллы.
+  setOrderStatus = async (id: number, status: ShopOrderStatus, adminNote: string): Promise<ShopOrder | null> => {
+    try {
+      const order = await api.patch(`shop/orders/${id}`, { json: { status, adminNote } }).json<ShopOrder>()
+
+      this.toast.toast({
+        title: 'Успешно',
+        description: 'Статус заказа обновлён',
+      })
+
+      return order
+    }
+    catch (error) {
+      handleError(error)
+      return null
+    }
+  }
+}
+
+export const shopService = new ShopService()
@@ -148,6 +148,62 @@ func main() {
 			}
 		}()
 
+		// Рейтинги за неделю/месяц/квартал: часовой watchdog замораживает
+		// завершившийся период в снапшот и начисляет призы топу.
+		// Идемпотентен (UNIQUE period+period_key, AwardIdempotent).
+		go func() {
+			leaderboardSvc := service.NewLeaderboardService()
+			ticker := time.NewTicker(time.Hour)
+			defer ticker.Stop()
+
+			leaderboardSvc.FinalizeEnded(time.Now())
+			for range ticker.C {
+				leaderboardSvc.FinalizeEnded(time.Now())
+			}
+		}()
+
+		// Анти-абуз баллов: часовой прогон детекторов (взаимные kudos,
+		// всплески заработка, серии выигрышей казино) в очередь флагов.
+		go func() {
+			abuseSvc := service.NewPointsAbuseService()
+			ticker := time.NewTicker(time.Hour)
+			defer ticker.Stop()
+
+			abuseSvc.Scan(time.Now())
+			for range ticker.C {
+				abuseSvc.Scan(time.Now())
+			}
+		}()
+
+		// Сверка снапшотов балансов с журналом баллов (раз в сутки).
+		go func() {
+			pointsSvc := service.NewPointsService()
+			ticker := time.NewTicker(24 * time.Hour)
+			defer ticker.Stop()
+
+			reconcile := func() {
+				report, err := pointsSvc.ReconcileBalances()
+				if err != nil {
+					log.Printf("points reconcile error: %v", err)
+					return
+				}
+				if len(report.Mismatches) > 0 {
+					log.Printf("points reconcile: %d of %d balances repaired", len(report.Mismatches), report.Checked)
+				}
+			}
+			reconcile()
+			for range ticker.C {
+				reconcile()
+			}
+		}()
+
+		// Цикл раундов мультиплеерного crash: приём ставок → полёт → краш.
+		// Раунды крутит одна API-реплика — держатель lease в Redis; при её
+		// падении незавершённый раунд доигрывает следующая.
+		crashLease := service.NewReplicaLease(service.NewBotReplicaService(redisClient), service.CrashLeaderKey, "crash")
+		crashLease.Start()
+		go service.GetCrashService().Run(crashLease)
+
 		// Запускаем фоновую задачу для очистки старых сообщений чатов (раз в сутки)
 		go func() {
 			chatActivitySvc := service.NewChatActivityService()
@@ -243,6 +299,76 @@ func main() {
 			}
 		}()
 
+		// Еженедельный AI-дайджест трекаемых чатов. Часовой watchdog:
+		// генерирует дайджесты за прошлую неделю с понедельника 10:00 MSK,
+		// идемпотентен (UNIQUE chat_id+period_start). Публикует их бот.
+		go func() {
+			digestSvc := service.NewChatDigestService()
+			ticker := time.NewTicker(time.Hour)
+			defer ticker.Stop()
+
+			runOnce := func() {
+				if n, err := digestSvc.GenerateWeeklyDigests(time.Now()); err != nil {
+					log.Printf("weekly chat digests: %v", err)
+				} else if n > 0 {
+					log.Printf("weekly chat digests: created %d", n)
+				}
+			}
+
+			runOnce()
+			for range ticker.C {
+				runOnce()
+			}
+		}()
+
+		// Ежемесячная выписка по баллам. Часовой watchdog: собирает выписки
+		// за прошлый месяц с 1-го числа 12:00 MSK, идемпотентен (UNIQUE
+		// member_id+month). Рассылает их бот.
+		go func() {
+			statementSvc := service.NewPointsStatementService()
+			ticker := time.NewTicker(time.Hour)
+			defer ticker.Stop()
+
+			runOnce := func() {
+				if n, err := statementSvc.GenerateMonthly(time.Now()); err != nil {
+					log.Printf("monthly points statements: %v", err)
+				} else if n > 0 {
+					log.Printf("monthly points statements: created %d", n)
+				}
+			}
+
+			runOnce()
+			for range ticker.C {
+				runOnce()
+			}
+		}()
+
+		// Синхронизация глобальных банов с внешним бан-листом
+		// (BANLIST_SYNC_URL). Бот сам банит таких юзеров при вступлении.
+		if config.CFG.BanlistSyncURL != "" {
+			go func() {
+				moderationSvc := service.NewModerationService()
+				ticker := time.NewTicker(time.Duration(config.CFG.BanlistSyncIntervalMinutes) * time.Minute)
+				defer ticker.Stop()
+
+				runOnce := func() {
+					ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+					defer cancel()
+					res, err := moderationSvc.SyncBanlist(ctx, config.CFG.BanlistSyncURL)
+					if err != nil {
+						log.Printf("banlist sync: %v", err)
+						return
+					}
+					log.Printf("banlist sync: parsed %d, imported %d, removed %d", res.Parsed, res.Imported, res.Removed)
+				}
+
+				runOnce()
+				for range ticker.C {
+					runOnce()
+				}
+			}()
+		}
+
 		// Запускаем сервер
 		go func() {
 			log.Printf("Server starting on port %s", config.CFG.Port)
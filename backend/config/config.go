@@ -48,12 +48,29 @@ type Config struct {
 
 	AppMode string // "full", "api", "bot"
 
+	// Транспорт бота: пустой TelegramWebhookURL — long polling (одна
+	// реплика), иначе webhook на TelegramWebhookPort.
+	TelegramWebhookURL    string
+	TelegramWebhookSecret string
+	TelegramWebhookPort   string
+	TelegramUpdateWorkers int
+
+	// Скачивание коротких видео: воркеры yt-dlp, очередь к ним и
+	// дополнительные источники «имя=regex» через «;» к встроенным.
+	VideoDownloadWorkers   int
+	VideoDownloadQueueSize int
+	VideoURLPatterns       string
+
 	SubscriptionCheckIntervalHours int
 	SubscriptionAutoKickEnabled    bool
 	SubscriptionGateEnabled        bool
 
 	AlertReminderIntervalMinutes      int64
 	AlertReminderThirdIntervalMinutes int64
+
+	// Внешний бан-лист: пустой URL — синхронизация выключена.
+	BanlistSyncURL             string
+	BanlistSyncIntervalMinutes int
 }
 
 type S3Config struct {
@@ -129,11 +146,41 @@ func LoadConfig() {
 		log.Fatalf("FATAL: Invalid APP_MODE=%s. Must be 'full', 'api', or 'bot'", appMode)
 	}
 
+	// Webhook: Telegram присылает секрет в X-Telegram-Bot-Api-Secret-Token,
+	// без него любой знающий URL мог бы подсунуть боту апдейт.
+	webhookURL := viper.GetString("TELEGRAM_WEBHOOK_URL")
+	webhookSecret := viper.GetString("TELEGRAM_WEBHOOK_SECRET")
+	if webhookURL != "" && webhookSecret == "" {
+		log.Fatal("FATAL: TELEGRAM_WEBHOOK_SECRET is required when TELEGRAM_WEBHOOK_URL is set")
+	}
+	webhookPort := viper.GetString("TELEGRAM_WEBHOOK_PORT")
+	if webhookPort == "" {
+		webhookPort = "8081"
+	}
+	updateWorkers := viper.GetInt("TELEGRAM_UPDATE_WORKERS")
+	if updateWorkers <= 0 {
+		updateWorkers = 8
+	}
+
+	videoWorkers := viper.GetInt("VIDEO_DOWNLOAD_WORKERS")
+	if videoWorkers <= 0 {
+		videoWorkers = 2
+	}
+	videoQueue := viper.GetInt("VIDEO_DOWNLOAD_QUEUE_SIZE")
+	if videoQueue <= 0 {
+		videoQueue = 20
+	}
+
 	subCheckInterval := viper.GetInt("SUBSCRIPTION_CHECK_INTERVAL_HOURS")
 	if subCheckInterval == 0 {
 		subCheckInterval = 4
 	}
 
+	banlistSyncInterval := viper.GetInt("BANLIST_SYNC_INTERVAL_MINUTES")
+	if banlistSyncInterval <= 0 {
+		banlistSyncInterval = 60
+	}
+
 	// SUPER_ADMIN_TELEGRAM_ID — единственный пользователь, которому разрешено
 	// управлять чатами подписок через UI (и получать уведомления от бота).
 	superAdminID := viper.GetInt64("SUPER_ADMIN_TELEGRAM_ID")
@@ -176,7 +223,16 @@ func LoadConfig() {
 		SubscriptionGateEnabled:        viper.GetBool("SUBSCRIPTION_GATE_ENABLED"),
 		AlertReminderIntervalMinutes:      alertReminderInterval,
 		AlertReminderThirdIntervalMinutes: alertReminderThird,
+		BanlistSyncURL:                    viper.GetString("BANLIST_SYNC_URL"),
+		BanlistSyncIntervalMinutes:        banlistSyncInterval,
 		AppMode: appMode,
+		TelegramWebhookURL:    webhookURL,
+		TelegramWebhookSecret: webhookSecret,
+		TelegramWebhookPort:   webhookPort,
+		TelegramUpdateWorkers: updateWorkers,
+		VideoDownloadWorkers:   videoWorkers,
+		VideoDownloadQueueSize: videoQueue,
+		VideoURLPatterns:       viper.GetString("VIDEO_URL_PATTERNS"),
 		S3: S3Config{
 			Endpoint:  viper.GetString("S3_ENDPOINT"),
 			Region:    viper.GetString("S3_REGION"),
@@ -0,0 +1,39 @@
+-- Режим рейда: при волне вступлений (N за минуту) бот переводит чат в
+-- raid-режим — новых участников ограничивает, инвайты закрывает, модераторам
+-- шлёт алерт. Одна активная запись на чат (partial unique index).
+CREATE TABLE IF NOT EXISTS bot_raids (
+    id BIGSERIAL PRIMARY KEY,
+    chat_id BIGINT NOT NULL,
+    chat_title VARCHAR(255) NOT NULL DEFAULT '',
+    status VARCHAR(20) NOT NULL DEFAULT 'active',
+    trigger_joins INTEGER NOT NULL DEFAULT 0,
+    started_by BIGINT NOT NULL DEFAULT 0,
+    ended_by BIGINT,
+    -- Права участников чата до включения рейда (getChat.permissions),
+    -- чтобы вернуть can_invite_users и прочее ровно как было.
+    saved_permissions JSONB NOT NULL DEFAULT '{}'::jsonb,
+    expires_at TIMESTAMPTZ NOT NULL,
+    ended_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS uniq_bot_raids_active
+    ON bot_raids (chat_id)
+    WHERE status = 'active';
+CREATE INDEX IF NOT EXISTS idx_bot_raids_active_expires
+    ON bot_raids (expires_at)
+    WHERE status = 'active';
+CREATE INDEX IF NOT EXISTS idx_bot_raids_chat_created
+    ON bot_raids (chat_id, created_at DESC);
+
+-- Участники, вступившие в окно рейда, — по ним работает откат (/raid rollback).
+CREATE TABLE IF NOT EXISTS bot_raid_joins (
+    raid_id BIGINT NOT NULL REFERENCES bot_raids(id) ON DELETE CASCADE,
+    user_id BIGINT NOT NULL,
+    username VARCHAR(255) NOT NULL DEFAULT '',
+    first_name VARCHAR(255) NOT NULL DEFAULT '',
+    joined_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    restricted BOOLEAN NOT NULL DEFAULT FALSE,
+    banned BOOLEAN NOT NULL DEFAULT FALSE,
+    PRIMARY KEY (raid_id, user_id)
+);
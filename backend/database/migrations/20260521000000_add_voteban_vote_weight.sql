@@ -0,0 +1,4 @@
+-- Вес голоса в voteban по репутации голосующего (стаж, тир, баллы, история
+-- сообщений в чате). Фиксируется на момент голоса; старые голоса — вес 1.
+ALTER TABLE bot_voteban_votes
+    ADD COLUMN IF NOT EXISTS weight DOUBLE PRECISION NOT NULL DEFAULT 1;
@@ -0,0 +1,29 @@
+-- Настройки модерации по чатам (раньше — константы в internal/bot/moderation.go).
+-- Нет строки — действуют дефолты из service.DefaultChatModerationSettings.
+CREATE TABLE IF NOT EXISTS bot_chat_moderation_settings (
+    chat_id BIGINT PRIMARY KEY,
+    voteban_window_seconds INT NOT NULL,
+    voteban_kick_seconds INT NOT NULL,
+    mute_default_seconds INT NOT NULL DEFAULT 0, -- 0 — /mute без аргумента бессрочный
+    cleanup_default_seconds INT NOT NULL,
+    cleanup_max_seconds INT NOT NULL,
+    updated_by BIGINT NOT NULL DEFAULT 0, -- telegram id; 0 — из админки без привязанного TG
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Дополнительные модераторы чата (волонтёры без прав Telegram-админа) с
+-- ограниченными полномочиями: мут, бан, чистка сообщений.
+CREATE TABLE IF NOT EXISTS bot_chat_moderators (
+    chat_id BIGINT NOT NULL,
+    user_id BIGINT NOT NULL,
+    username VARCHAR(255) NOT NULL DEFAULT '',
+    can_mute BOOLEAN NOT NULL DEFAULT FALSE,
+    can_ban BOOLEAN NOT NULL DEFAULT FALSE,
+    can_cleanup BOOLEAN NOT NULL DEFAULT FALSE,
+    added_by BIGINT NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (chat_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_bot_chat_moderators_user ON bot_chat_moderators(user_id);
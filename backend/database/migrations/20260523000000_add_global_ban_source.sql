@@ -0,0 +1,11 @@
+-- Общие бан-листы: у глобального бана появляется источник.
+--   manual    — наш /globalban (применяется сразу во всех чатах);
+--   import    — загружен из файла партнёрского сообщества;
+--   blocklist — синхронизирован с внешним URL (BANLIST_SYNC_URL).
+-- Импортированные и синхронизированные записи применяются при вступлении
+-- в чат (handleChatMemberUpdated), а не массовым проходом по чатам.
+ALTER TABLE bot_global_bans
+    ADD COLUMN IF NOT EXISTS source VARCHAR(50) NOT NULL DEFAULT 'manual';
+
+CREATE INDEX IF NOT EXISTS idx_bot_global_bans_source
+    ON bot_global_bans (source);
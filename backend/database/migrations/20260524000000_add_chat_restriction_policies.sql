@@ -0,0 +1,28 @@
+-- Вступления в чаты по данным самого бота (chat_member-апдейты). Нужны,
+-- чтобы отличать новичков от старожилов: Telegram не отдаёт дату вступления.
+-- first_joined_at не сдвигается при выходе/повторном входе — иначе
+-- старожил, перезашедший в чат, снова стал бы «новичком».
+CREATE TABLE IF NOT EXISTS bot_chat_joins (
+    chat_id BIGINT NOT NULL,
+    user_id BIGINT NOT NULL,
+    first_joined_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    last_joined_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (chat_id, user_id)
+);
+
+-- Политики ограничений для трекаемых чатов. Нет строки — ограничений нет.
+-- Новички (вступили меньше new_member_days дней назад) не могут постить
+-- ссылки / медиа / форварды; rate limit действует на всех участников.
+-- Модераторы и подписчики с тиром >= exempt_tier_level освобождены.
+CREATE TABLE IF NOT EXISTS bot_chat_restriction_policies (
+    chat_id BIGINT PRIMARY KEY,
+    new_member_days INT NOT NULL DEFAULT 0, -- 0 — ограничения новичков выключены
+    block_links BOOLEAN NOT NULL DEFAULT TRUE,
+    block_media BOOLEAN NOT NULL DEFAULT TRUE,
+    block_forwards BOOLEAN NOT NULL DEFAULT TRUE,
+    rate_limit_messages INT NOT NULL DEFAULT 0, -- 0 — без rate limit
+    rate_limit_window_seconds INT NOT NULL DEFAULT 60,
+    exempt_tier_level INT NOT NULL DEFAULT 0, -- 0 — тир не освобождает
+    updated_by BIGINT NOT NULL DEFAULT 0,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
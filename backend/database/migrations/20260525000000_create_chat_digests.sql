@@ -0,0 +1,22 @@
+-- Еженедельные AI-дайджесты трекаемых чатов. Генерирует бэкенд (понедельник,
+-- за прошедшую неделю по МСК), публикует бот: забирает строки с пустым
+-- posted_at, как авто-квесты. Архив доступен на платформе.
+CREATE TABLE IF NOT EXISTS chat_digests (
+    id BIGSERIAL PRIMARY KEY,
+    chat_id BIGINT NOT NULL,
+    chat_title VARCHAR(255) NOT NULL DEFAULT '',
+    period_start TIMESTAMPTZ NOT NULL,
+    period_end TIMESTAMPTZ NOT NULL,
+    message_count INT NOT NULL DEFAULT 0,
+    active_members INT NOT NULL DEFAULT 0,
+    summary TEXT NOT NULL,
+    model VARCHAR(255) NOT NULL DEFAULT '',
+    top_members JSONB NOT NULL DEFAULT '[]', -- [{telegramUserId, username, firstName, count}]
+    highlights JSONB NOT NULL DEFAULT '[]',  -- [{id, messageId, authorUsername, authorFirstName, messageText}]
+    posted_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (chat_id, period_start)
+);
+
+CREATE INDEX IF NOT EXISTS idx_chat_digests_period ON chat_digests(period_start DESC);
+CREATE INDEX IF NOT EXISTS idx_chat_digests_unposted ON chat_digests(created_at) WHERE posted_at IS NULL;
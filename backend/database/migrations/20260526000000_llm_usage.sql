@@ -0,0 +1,18 @@
+-- Журнал обращений к LLM: одна строка на попытку модели из цепочки
+-- (fallback тоже пишется), чтобы в админке видеть токены, задержку и
+-- отказы по каждой модели.
+CREATE TABLE IF NOT EXISTS llm_usage (
+    id BIGSERIAL PRIMARY KEY,
+    feature VARCHAR(32) NOT NULL, -- summarize | digest
+    model VARCHAR(255) NOT NULL,
+    success BOOLEAN NOT NULL,
+    prompt_tokens INT NOT NULL DEFAULT 0,
+    completion_tokens INT NOT NULL DEFAULT 0,
+    total_tokens INT NOT NULL DEFAULT 0,
+    latency_ms INT NOT NULL DEFAULT 0,
+    error TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_llm_usage_created ON llm_usage(created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_llm_usage_failures ON llm_usage(created_at DESC) WHERE NOT success;
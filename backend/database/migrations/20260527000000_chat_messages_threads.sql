@@ -0,0 +1,13 @@
+-- Ответы и темы форума у трекаемых сообщений: /summarize в ответ на
+-- сообщение собирает ветку обсуждения по цепочке reply_to, в супергруппах с
+-- темами — ограничивается темой. Старые записи останутся с NULL.
+ALTER TABLE chat_messages
+    ADD COLUMN IF NOT EXISTS reply_to_message_id INTEGER,
+    ADD COLUMN IF NOT EXISTS message_thread_id INTEGER;
+
+CREATE INDEX IF NOT EXISTS idx_chat_messages_chat_tg_message
+    ON chat_messages (chat_id, telegram_message_id);
+CREATE INDEX IF NOT EXISTS idx_chat_messages_chat_reply_to
+    ON chat_messages (chat_id, reply_to_message_id) WHERE reply_to_message_id IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_chat_messages_chat_thread_sent
+    ON chat_messages (chat_id, message_thread_id, sent_at) WHERE message_thread_id IS NOT NULL;
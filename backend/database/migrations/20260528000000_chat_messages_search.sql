@@ -0,0 +1,12 @@
+-- Полнотекстовый поиск по истории чатов для подписчиков. Вектор строится
+-- по двум конфигурациям: русской (основной, вес A) и английской — в чатах
+-- много английских терминов, которые русский стеммер не нормализует.
+ALTER TABLE chat_messages
+    ADD COLUMN IF NOT EXISTS search_vector tsvector
+    GENERATED ALWAYS AS (
+        setweight(to_tsvector('russian', COALESCE(message_text, '')), 'A') ||
+        setweight(to_tsvector('english', COALESCE(message_text, '')), 'B')
+    ) STORED;
+
+CREATE INDEX IF NOT EXISTS idx_chat_messages_search_vector
+    ON chat_messages USING GIN (search_vector);
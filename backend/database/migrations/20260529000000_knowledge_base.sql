@@ -0,0 +1,36 @@
+-- База знаний из хайлайтов: модераторы собирают несколько сохранённых
+-- через /highlight сообщений в статью «вопрос — ответ» с тегами, участники
+-- ищут по ней на платформе, бот подсказывает статью на похожий вопрос.
+CREATE TABLE IF NOT EXISTS kb_entries (
+    id BIGSERIAL PRIMARY KEY,
+    title VARCHAR(200) NOT NULL,
+    question TEXT NOT NULL DEFAULT '',
+    answer TEXT NOT NULL,
+    created_by BIGINT NOT NULL, -- members.id
+    updated_by BIGINT NOT NULL,
+    suggested_count INT NOT NULL DEFAULT 0, -- сколько раз бот подсказал статью
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    search_vector tsvector GENERATED ALWAYS AS (
+        setweight(to_tsvector('russian', title), 'A') ||
+        setweight(to_tsvector('russian', question), 'B') ||
+        setweight(to_tsvector('russian', answer), 'C') ||
+        setweight(to_tsvector('english', title || ' ' || question), 'D')
+    ) STORED
+);
+
+CREATE INDEX IF NOT EXISTS idx_kb_entries_search_vector ON kb_entries USING GIN (search_vector);
+
+CREATE TABLE IF NOT EXISTS kb_entry_highlights (
+    entry_id BIGINT NOT NULL REFERENCES kb_entries(id) ON DELETE CASCADE,
+    highlight_id INTEGER NOT NULL REFERENCES chat_highlights(id) ON DELETE CASCADE,
+    PRIMARY KEY (entry_id, highlight_id)
+);
+
+CREATE TABLE IF NOT EXISTS kb_entry_tags (
+    entry_id BIGINT NOT NULL REFERENCES kb_entries(id) ON DELETE CASCADE,
+    tag_id INTEGER NOT NULL REFERENCES "profTags"(id) ON DELETE CASCADE,
+    PRIMARY KEY (entry_id, tag_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_kb_entry_tags_tag ON kb_entry_tags(tag_id);
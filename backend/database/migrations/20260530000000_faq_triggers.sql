@@ -0,0 +1,32 @@
+-- Автоответчик FAQ: админы задают триггеры (ключевые слова, regex или
+-- похожесть на образец вопроса) с шаблоном ответа и кулдауном; бот
+-- отвечает в тред сообщения. chat_id = 0 — триггер для всех трекаемых чатов.
+CREATE TABLE IF NOT EXISTS bot_faq_triggers (
+    id BIGSERIAL PRIMARY KEY,
+    chat_id BIGINT NOT NULL DEFAULT 0,
+    name VARCHAR(100) NOT NULL,
+    match_type VARCHAR(16) NOT NULL, -- keywords | regex | similarity
+    pattern TEXT NOT NULL,
+    response TEXT NOT NULL, -- Telegram HTML; {name}, {chat}
+    cooldown_seconds INT NOT NULL DEFAULT 300,
+    priority INT NOT NULL DEFAULT 0,
+    enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    created_by BIGINT NOT NULL DEFAULT 0,
+    updated_by BIGINT NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_bot_faq_triggers_chat ON bot_faq_triggers(chat_id) WHERE enabled;
+
+-- Срабатывания — для статистики «какие ответы нужны чаще всего».
+CREATE TABLE IF NOT EXISTS bot_faq_hits (
+    id BIGSERIAL PRIMARY KEY,
+    trigger_id BIGINT NOT NULL REFERENCES bot_faq_triggers(id) ON DELETE CASCADE,
+    chat_id BIGINT NOT NULL,
+    user_id BIGINT NOT NULL,
+    message_id INT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_bot_faq_hits_trigger_created ON bot_faq_hits(trigger_id, created_at);
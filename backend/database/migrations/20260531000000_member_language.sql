@@ -0,0 +1,3 @@
+-- Язык интерфейса участника (ru/en). Пусто — не выбран, бот и API берут
+-- язык из Telegram language_code / Accept-Language.
+ALTER TABLE members ADD COLUMN IF NOT EXISTS language VARCHAR(8) NOT NULL DEFAULT '';
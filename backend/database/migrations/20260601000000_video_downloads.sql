@@ -0,0 +1,28 @@
+-- Скачивание коротких видео ботом. Кэш: нормализованная ссылка → file_id
+-- уже загруженного в Telegram файла — повторная ссылка на тот же ролик
+-- отправляется мгновенно, без yt-dlp.
+CREATE TABLE IF NOT EXISTS video_file_cache (
+    source_url TEXT PRIMARY KEY,
+    source VARCHAR(32) NOT NULL,
+    file_id TEXT NOT NULL,
+    file_size BIGINT NOT NULL DEFAULT 0,
+    hits INT NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    last_used_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Журнал запросов — для статистики в админке.
+CREATE TABLE IF NOT EXISTS video_downloads (
+    id BIGSERIAL PRIMARY KEY,
+    url TEXT NOT NULL,
+    source VARCHAR(32) NOT NULL,
+    chat_id BIGINT NOT NULL,
+    user_id BIGINT NOT NULL DEFAULT 0,
+    status VARCHAR(16) NOT NULL, -- sent | cached | failed | too_large | timeout | rate_limited | queue_full
+    duration_ms INT NOT NULL DEFAULT 0,
+    file_size BIGINT NOT NULL DEFAULT 0,
+    error TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_video_downloads_created ON video_downloads(created_at);
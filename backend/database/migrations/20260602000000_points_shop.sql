@@ -0,0 +1,36 @@
+-- Магазин наград за баллы: каталог ведут админы, покупка списывает баллы
+-- и создаёт заказ, который админы доводят до выдачи.
+CREATE TABLE IF NOT EXISTS shop_items (
+    id BIGSERIAL PRIMARY KEY,
+    kind VARCHAR(32) NOT NULL, -- merch | mentor_session | subscription_days | streak_freeze | cosmetic
+    title VARCHAR(200) NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    image_url TEXT NOT NULL DEFAULT '',
+    price INT NOT NULL CHECK (price > 0),
+    quantity INT NOT NULL DEFAULT 1, -- дни подписки, число заморозок; для прочих 1
+    stock INT CHECK (stock >= 0), -- NULL — без ограничения
+    per_member_limit INT NOT NULL DEFAULT 0, -- 0 — без ограничения
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    sort_order INT NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS shop_orders (
+    id BIGSERIAL PRIMARY KEY,
+    item_id BIGINT NOT NULL REFERENCES shop_items(id),
+    member_id BIGINT NOT NULL REFERENCES members(id) ON DELETE CASCADE,
+    item_title VARCHAR(200) NOT NULL, -- на момент покупки
+    price INT NOT NULL,
+    status VARCHAR(16) NOT NULL DEFAULT 'pending', -- pending | shipped | delivered | cancelled
+    comment TEXT NOT NULL DEFAULT '', -- от покупателя: контакты, размер
+    admin_note TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    shipped_at TIMESTAMPTZ,
+    delivered_at TIMESTAMPTZ,
+    cancelled_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_shop_orders_member ON shop_orders(member_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_shop_orders_status ON shop_orders(status, created_at DESC);
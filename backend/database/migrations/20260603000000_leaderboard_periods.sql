@@ -0,0 +1,36 @@
+-- Периодические лидерборды (неделя, месяц, квартал по МСК). Текущий период
+-- считается на лету из point_transactions; по окончании периода бэкенд
+-- замораживает топ в снапшот и начисляет призы (reason leaderboard_prize,
+-- source_id = id снапшота — повторный прогон не задваивает).
+CREATE TABLE IF NOT EXISTS leaderboard_snapshots (
+    id BIGSERIAL PRIMARY KEY,
+    period VARCHAR(16) NOT NULL,     -- weekly | monthly | quarterly
+    period_key VARCHAR(16) NOT NULL, -- 2026-W41 | 2026-10 | 2026-Q4
+    starts_at TIMESTAMPTZ NOT NULL,
+    ends_at TIMESTAMPTZ NOT NULL,
+    participants INT NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (period, period_key)
+);
+
+CREATE INDEX IF NOT EXISTS idx_leaderboard_snapshots_period ON leaderboard_snapshots(period, starts_at DESC);
+
+CREATE TABLE IF NOT EXISTS leaderboard_snapshot_entries (
+    snapshot_id BIGINT NOT NULL REFERENCES leaderboard_snapshots(id) ON DELETE CASCADE,
+    member_id BIGINT NOT NULL REFERENCES members(id) ON DELETE CASCADE,
+    rank INT NOT NULL,
+    total INT NOT NULL,
+    prize INT NOT NULL DEFAULT 0,
+    PRIMARY KEY (snapshot_id, member_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_leaderboard_entries_member ON leaderboard_snapshot_entries(member_id, rank);
+
+INSERT INTO app_settings(key, value) VALUES
+    -- reason'ы, которые не идут в зачёт периода: игры и траты
+    ('leaderboard_excluded_reasons', '["casino_bet", "casino_win", "raffle_spend", "shop_purchase", "shop_refund"]'),
+    -- призы за места 1..N, пустой массив — без призов
+    ('leaderboard_prizes_weekly', '[100, 50, 25]'),
+    ('leaderboard_prizes_monthly', '[300, 150, 75]'),
+    ('leaderboard_prizes_quarterly', '[1000, 500, 250]')
+ON CONFLICT (key) DO NOTHING;
@@ -0,0 +1,43 @@
+-- Анти-абуз баллов: дневные/недельные лимиты по reason'ам (app_settings
+-- points_caps, применяет PointsService) и очередь подозрительной
+-- активности для админов. Детектор — часовой watchdog бэкенда; dedup_key
+-- не даёт завести один и тот же сигнал дважды.
+CREATE TABLE IF NOT EXISTS points_flags (
+    id BIGSERIAL PRIMARY KEY,
+    kind VARCHAR(32) NOT NULL,                 -- kudos_ring | earning_burst | casino_streak
+    member_id BIGINT NOT NULL REFERENCES members(id) ON DELETE CASCADE,
+    related_member_id BIGINT REFERENCES members(id) ON DELETE CASCADE,
+    score INT NOT NULL DEFAULT 0,              -- величина сигнала: баллы, длина серии, число kudos
+    details TEXT NOT NULL DEFAULT '',
+    dedup_key VARCHAR(128) NOT NULL UNIQUE,
+    window_start TIMESTAMPTZ NOT NULL,
+    window_end TIMESTAMPTZ NOT NULL,
+    status VARCHAR(16) NOT NULL DEFAULT 'open', -- open | dismissed | clawed_back
+    resolved_by BIGINT,
+    resolved_by_name VARCHAR(255) NOT NULL DEFAULT '',
+    resolution_note TEXT NOT NULL DEFAULT '',
+    resolved_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_points_flags_status ON points_flags(status, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_points_flags_member ON points_flags(member_id);
+
+-- Быстрый подсчёт заработанного по reason'у за день/неделю для лимитов.
+CREATE INDEX IF NOT EXISTS idx_point_transactions_member_reason_created
+    ON point_transactions(member_id, reason, created_at);
+
+INSERT INTO app_settings(key, value) VALUES
+    -- лимиты начислений: {reason: {daily, weekly}}, 0 или нет ключа — без лимита
+    ('points_caps', '{
+        "kudos_received": {"daily": 25, "weekly": 100},
+        "chat_quest": {"daily": 150},
+        "marketplace_create": {"daily": 45},
+        "referal_create": {"daily": 15},
+        "resume_upload": {"daily": 10},
+        "task_create": {"weekly": 75}
+    }'),
+    ('anomaly_kudos_ring_min', '3'),   -- взаимных kudos за 7 дней в каждую сторону
+    ('anomaly_burst_points', '300'),   -- заработано за час
+    ('anomaly_casino_streak', '8')     -- выигрышей подряд за сутки
+ON CONFLICT (key) DO NOTHING;
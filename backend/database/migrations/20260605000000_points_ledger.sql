@@ -0,0 +1,73 @@
+-- Журнал баллов становится append-only. Исправление — сторно: новая
+-- транзакция с обратной суммой и reverses_id на исходную (не больше одного
+-- сторно на транзакцию). UPDATE запрещён, DELETE — только каскадом при
+-- удалении участника.
+ALTER TABLE point_transactions
+    ADD COLUMN IF NOT EXISTS reverses_id BIGINT REFERENCES point_transactions(id);
+
+-- Списания флагов до появления reverses_id ссылались на исходную
+-- транзакцию через source_type/source_id — связываем их, чтобы они считались
+-- сторно и не давали отменить начисление второй раз. Триггер append-only
+-- ниже ещё не создан.
+UPDATE point_transactions c
+SET reverses_id = c.source_id
+WHERE c.reason = 'clawback'
+  AND c.source_type = 'point_transaction'
+  AND c.reverses_id IS NULL
+  AND EXISTS (
+      SELECT 1 FROM point_transactions o
+      WHERE o.id = c.source_id AND o.member_id = c.member_id
+  );
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_point_transactions_reverses
+    ON point_transactions(reverses_id) WHERE reverses_id IS NOT NULL;
+
+-- Снапшот баланса: ведёт триггер на INSERT в том же транзакционном
+-- контексте, что и само начисление, поэтому под advisory-lock'ом баланс
+-- читается из снапшота без SUM по журналу. Сверку с журналом делает
+-- ежесуточный reconcile. FK на последнюю транзакцию — чтобы
+-- TRUNCATE point_transactions CASCADE (тесты) чистил и снапшоты.
+CREATE TABLE IF NOT EXISTS member_point_balances (
+    member_id BIGINT PRIMARY KEY REFERENCES members(id) ON DELETE CASCADE,
+    balance INT NOT NULL DEFAULT 0,
+    last_transaction_id BIGINT REFERENCES point_transactions(id) ON DELETE CASCADE,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_member_point_balances_balance ON member_point_balances(balance DESC);
+
+INSERT INTO member_point_balances (member_id, balance, last_transaction_id)
+SELECT member_id, SUM(amount), MAX(id) FROM point_transactions GROUP BY member_id
+ON CONFLICT (member_id) DO NOTHING;
+
+CREATE OR REPLACE FUNCTION point_transactions_apply_balance() RETURNS TRIGGER AS $$
+BEGIN
+    INSERT INTO member_point_balances (member_id, balance, last_transaction_id, updated_at)
+    VALUES (NEW.member_id, NEW.amount, NEW.id, NOW())
+    ON CONFLICT (member_id) DO UPDATE
+        SET balance = member_point_balances.balance + EXCLUDED.balance,
+            last_transaction_id = GREATEST(member_point_balances.last_transaction_id, EXCLUDED.last_transaction_id),
+            updated_at = NOW();
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS trg_point_transactions_balance ON point_transactions;
+CREATE TRIGGER trg_point_transactions_balance
+    AFTER INSERT ON point_transactions
+    FOR EACH ROW EXECUTE FUNCTION point_transactions_apply_balance();
+
+CREATE OR REPLACE FUNCTION point_transactions_append_only() RETURNS TRIGGER AS $$
+BEGIN
+    -- Каскад из members: участника уже нет — журнал уходит вместе с ним.
+    IF TG_OP = 'DELETE' AND NOT EXISTS (SELECT 1 FROM members WHERE id = OLD.member_id) THEN
+        RETURN OLD;
+    END IF;
+    RAISE EXCEPTION 'point_transactions is append-only (%), use a reversal', TG_OP;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS trg_point_transactions_append_only ON point_transactions;
+CREATE TRIGGER trg_point_transactions_append_only
+    BEFORE UPDATE OR DELETE ON point_transactions
+    FOR EACH ROW EXECUTE FUNCTION point_transactions_append_only();
@@ -0,0 +1,23 @@
+-- Provably fair мини-игры. У участника одна активная пара seed'ов: хеш
+-- серверного seed'а показываем до игры, сам seed — только после ротации.
+-- Исход ставки = HMAC-SHA256(server_seed, "client_seed:nonce"), nonce
+-- растёт на каждую ставку.
+CREATE TABLE IF NOT EXISTS casino_seeds (
+    id BIGSERIAL PRIMARY KEY,
+    member_id BIGINT NOT NULL REFERENCES members(id) ON DELETE CASCADE,
+    server_seed VARCHAR(64) NOT NULL,
+    server_seed_hash VARCHAR(64) NOT NULL,
+    client_seed VARCHAR(64) NOT NULL,
+    nonce INT NOT NULL DEFAULT 0,
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    revealed_at TIMESTAMPTZ
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_casino_seeds_member_active
+    ON casino_seeds(member_id) WHERE active;
+CREATE INDEX IF NOT EXISTS idx_casino_seeds_member ON casino_seeds(member_id, created_at DESC);
+
+-- Старые ставки остаются без seed'а — проверить их нельзя.
+ALTER TABLE casino_bets ADD COLUMN IF NOT EXISTS seed_id BIGINT REFERENCES casino_seeds(id);
+ALTER TABLE casino_bets ADD COLUMN IF NOT EXISTS nonce INT;
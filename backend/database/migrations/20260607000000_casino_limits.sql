@@ -0,0 +1,44 @@
+-- Ответственная игра в мини-играх. Личные лимиты участника: NULL — лимита
+-- нет (действует только глобальный из app_settings.casino_limits).
+CREATE TABLE IF NOT EXISTS casino_limits (
+    member_id BIGINT PRIMARY KEY REFERENCES members(id) ON DELETE CASCADE,
+    daily_loss_limit INT,
+    daily_bet_limit INT,
+    cooldown_after_losses INT,
+    cooldown_minutes INT,
+    excluded_until TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- История изменений. Ужесточение применяется сразу (applied), ослабление —
+-- через increaseDelayHours (pending до effective_at), новое изменение того
+-- же поля отменяет ожидающее (cancelled).
+CREATE TABLE IF NOT EXISTS casino_limit_changes (
+    id BIGSERIAL PRIMARY KEY,
+    member_id BIGINT NOT NULL REFERENCES members(id) ON DELETE CASCADE,
+    field VARCHAR(40) NOT NULL,
+    old_value INT,
+    new_value INT,
+    status VARCHAR(20) NOT NULL,
+    effective_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_casino_limit_changes_member ON casino_limit_changes(member_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_casino_limit_changes_pending
+    ON casino_limit_changes(member_id, effective_at) WHERE status = 'pending';
+
+-- Дневная статистика ставок участника для проверки лимитов.
+CREATE INDEX IF NOT EXISTS idx_casino_bets_member_created ON casino_bets(member_id, created_at);
+
+INSERT INTO app_settings(key, value) VALUES
+    -- глобальные лимиты, 0 — выключен; increaseDelayHours — через сколько
+    -- вступает в силу ослабление личного лимита
+    ('casino_limits', '{
+        "dailyLossLimit": 3000,
+        "dailyBetLimit": 300,
+        "cooldownAfterLosses": 10,
+        "cooldownMinutes": 10,
+        "increaseDelayHours": 24
+    }')
+ON CONFLICT (key) DO NOTHING;
@@ -0,0 +1,65 @@
+-- Мультиплеерный crash. Раунд: приём ставок (betting) → рост множителя
+-- (running) → краш (crashed). Точка краша выводится из server_seed, хеш
+-- которого публикуется до начала раунда, а сам seed — после краша.
+CREATE TABLE IF NOT EXISTS casino_crash_rounds (
+    id BIGSERIAL PRIMARY KEY,
+    server_seed VARCHAR(64) NOT NULL,
+    server_seed_hash VARCHAR(64) NOT NULL,
+    crash_point NUMERIC(10, 2) NOT NULL,
+    status VARCHAR(10) NOT NULL DEFAULT 'betting',
+    betting_ends_at TIMESTAMPTZ NOT NULL,
+    started_at TIMESTAMPTZ,
+    crashed_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_casino_crash_rounds_open
+    ON casino_crash_rounds(id) WHERE status <> 'crashed';
+
+-- Ставка в раунде; сама ставка (списание, выплата, профит) — обычная строка
+-- casino_bets с game = 'crash', так что лимиты, лента и статистика видят её
+-- наравне с остальными играми.
+CREATE TABLE IF NOT EXISTS casino_crash_bets (
+    bet_id BIGINT PRIMARY KEY REFERENCES casino_bets(id) ON DELETE CASCADE,
+    round_id BIGINT NOT NULL REFERENCES casino_crash_rounds(id) ON DELETE CASCADE,
+    member_id BIGINT NOT NULL REFERENCES members(id) ON DELETE CASCADE,
+    auto_cash_out NUMERIC(10, 2),
+    cashed_out_at NUMERIC(10, 2),
+    UNIQUE (round_id, member_id)
+);
+
+-- Прогрессивный джекпот: одна строка, пул в сотых долях балла, чтобы
+-- копились и доли процента с мелких ставок.
+CREATE TABLE IF NOT EXISTS casino_jackpot (
+    id SMALLINT PRIMARY KEY DEFAULT 1 CHECK (id = 1),
+    pool_hundredths BIGINT NOT NULL DEFAULT 0,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+INSERT INTO casino_jackpot (id, pool_hundredths) VALUES (1, 50000) ON CONFLICT (id) DO NOTHING;
+
+CREATE TABLE IF NOT EXISTS casino_jackpot_wins (
+    id BIGSERIAL PRIMARY KEY,
+    member_id BIGINT NOT NULL REFERENCES members(id) ON DELETE CASCADE,
+    bet_id BIGINT REFERENCES casino_bets(id) ON DELETE SET NULL,
+    game VARCHAR(20) NOT NULL,
+    amount INT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_casino_jackpot_wins_created ON casino_jackpot_wins(created_at DESC);
+
+INSERT INTO app_settings(key, value) VALUES
+    -- contributionPercent — доля каждой ставки, уходящая в пул;
+    -- turnoverPerWin — шанс выигрыша ставки равен bet / turnoverPerWin;
+    -- seedAmount — с какой суммы пул начинается заново после выигрыша
+    ('casino_jackpot', '{
+        "contributionPercent": 1,
+        "turnoverPerWin": 200000,
+        "seedAmount": 500
+    }')
+ON CONFLICT (key) DO NOTHING;
+
+-- Джекпот, как и выигрыши казино, в рейтинг не идёт.
+UPDATE app_settings SET value = value || '["casino_jackpot"]'::jsonb, updated_at = NOW()
+WHERE key = 'leaderboard_excluded_reasons' AND NOT value ? 'casino_jackpot';
@@ -0,0 +1,71 @@
+-- Розыгрыши с несколькими призами и проверяемой жеребьёвкой.
+--
+-- raffle_prizes — призовые места: position задаёт порядок розыгрыша,
+-- winners_count — сколько победителей получают этот приз. raffles.prize
+-- остаётся краткой сводкой для старых клиентов.
+--
+-- raffle_winners — результат жеребьёвки: place — номер вытянутого слота
+-- (1 — первым), tickets — билеты победителя на момент розыгрыша. Один
+-- участник выигрывает не больше одного приза (розыгрыш без возвращения).
+-- status/fulfilled_at/fulfillment_note — выдача приза админом.
+--
+-- server_seed фиксируется при создании, наружу до розыгрыша отдаётся только
+-- seed_hash = sha256(server_seed); после розыгрыша seed раскрывается.
+-- announced_at — бот опубликовал победителей в основном чате.
+
+ALTER TABLE raffles ADD COLUMN IF NOT EXISTS server_seed TEXT;
+ALTER TABLE raffles ADD COLUMN IF NOT EXISTS seed_hash TEXT;
+ALTER TABLE raffles ADD COLUMN IF NOT EXISTS drawn_at TIMESTAMPTZ;
+ALTER TABLE raffles ADD COLUMN IF NOT EXISTS announced_at TIMESTAMPTZ;
+
+CREATE TABLE IF NOT EXISTS raffle_prizes (
+    id BIGSERIAL PRIMARY KEY,
+    raffle_id BIGINT NOT NULL REFERENCES raffles(id) ON DELETE CASCADE,
+    position INT NOT NULL,
+    title TEXT NOT NULL,
+    winners_count INT NOT NULL DEFAULT 1 CHECK (winners_count > 0),
+    UNIQUE (raffle_id, position)
+);
+
+CREATE TABLE IF NOT EXISTS raffle_winners (
+    id BIGSERIAL PRIMARY KEY,
+    raffle_id BIGINT NOT NULL REFERENCES raffles(id) ON DELETE CASCADE,
+    prize_id BIGINT REFERENCES raffle_prizes(id) ON DELETE SET NULL,
+    prize_title TEXT NOT NULL,
+    place INT NOT NULL,
+    member_id BIGINT NOT NULL REFERENCES members(id) ON DELETE CASCADE,
+    tickets INT NOT NULL,
+    status TEXT NOT NULL DEFAULT 'pending',
+    fulfilled_at TIMESTAMPTZ,
+    fulfillment_note TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (raffle_id, member_id),
+    UNIQUE (raffle_id, place)
+);
+CREATE INDEX IF NOT EXISTS idx_raffle_winners_pending ON raffle_winners(raffle_id) WHERE status = 'pending';
+
+-- Существующие розыгрыши: единственный приз из raffles.prize.
+INSERT INTO raffle_prizes (raffle_id, position, title, winners_count)
+SELECT id, 1, prize, 1 FROM raffles
+ON CONFLICT (raffle_id, position) DO NOTHING;
+
+-- Прошлые победители считаются получившими приз — очередь выдачи
+-- начинается с новых розыгрышей.
+INSERT INTO raffle_winners (raffle_id, prize_id, prize_title, place, member_id, tickets, status, fulfilled_at, created_at)
+SELECT r.id, p.id, r.prize, 1, r.winner_id,
+       (SELECT COUNT(*) FROM raffle_tickets t WHERE t.raffle_id = r.id AND t.member_id = r.winner_id),
+       'fulfilled', NOW(), r.created_at
+FROM raffles r
+JOIN raffle_prizes p ON p.raffle_id = r.id AND p.position = 1
+WHERE r.winner_id IS NOT NULL
+ON CONFLICT DO NOTHING;
+
+-- Активным розыгрышам seed фиксируем сейчас; завершённые остаются без него.
+UPDATE raffles
+   SET server_seed = md5(random()::text || clock_timestamp()::text) || md5(random()::text || id::text)
+ WHERE status = 'ACTIVE' AND server_seed IS NULL;
+UPDATE raffles
+   SET seed_hash = encode(sha256(convert_to(server_seed, 'UTF8')), 'hex')
+ WHERE server_seed IS NOT NULL AND seed_hash IS NULL;
+
+UPDATE raffles SET announced_at = NOW() WHERE status = 'FINISHED' AND announced_at IS NULL;
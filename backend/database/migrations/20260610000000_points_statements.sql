@@ -0,0 +1,23 @@
+-- Ежемесячная выписка по баллам: бэкенд собирает её за прошлый месяц,
+-- бот отправляет участнику в личку.
+--
+-- payload — models.PointsStatement (итоги и разбивка по PointReason),
+-- снимок на момент генерации: поздние сторно за прошлый месяц в уже
+-- собранную выписку не попадают. sent_at — бот взял выписку в отправку.
+
+CREATE TABLE IF NOT EXISTS points_statements (
+    id BIGSERIAL PRIMARY KEY,
+    member_id BIGINT NOT NULL REFERENCES members(id) ON DELETE CASCADE,
+    month DATE NOT NULL,
+    payload JSONB NOT NULL,
+    sent_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (member_id, month)
+);
+CREATE INDEX IF NOT EXISTS idx_points_statements_unsent ON points_statements(created_at) WHERE sent_at IS NULL;
+
+-- Выгрузка и выписка фильтруют журнал по участнику и дате (у
+-- referral_credit_transactions такой индекс уже есть).
+CREATE INDEX IF NOT EXISTS idx_point_transactions_member_created ON point_transactions(member_id, created_at);
+
+ALTER TABLE notification_settings ADD COLUMN IF NOT EXISTS monthly_statement BOOLEAN NOT NULL DEFAULT TRUE;
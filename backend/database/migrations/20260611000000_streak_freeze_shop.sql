@@ -0,0 +1,8 @@
+-- Магазин заморозок и восстановление стрика. Купленные заморозки живут
+-- отдельно от бесплатной недельной (freezes_available), которую каждую
+-- ISO-неделю сбрасывают в 1. Оборвавшийся стрик запоминается, чтобы его
+-- можно было выкупить в течение 48 часов после обрыва.
+ALTER TABLE member_streaks
+    ADD COLUMN IF NOT EXISTS purchased_freezes INT NOT NULL DEFAULT 0 CHECK (purchased_freezes >= 0),
+    ADD COLUMN IF NOT EXISTS lost_streak INT NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS lost_at TIMESTAMPTZ; -- момент обрыва; NULL — восстанавливать нечего
@@ -35,6 +35,9 @@ func (b *TelegramBot) startAutoQuestPoster() {
 // notification_posted_at до отправки в TG, чтобы при сбое до Telegram не
 // флудить чат повторными попытками — лучше потерять один пост, чем спамить.
 func (b *TelegramBot) postPendingAutoQuests() {
+	if !b.isLeader() {
+		return
+	}
 	var quests []models.ChatQuest
 	err := database.DB.
 		Where("auto_generated = ? AND notification_posted_at IS NULL AND chat_id IS NOT NULL AND ends_at > NOW()", true).
@@ -0,0 +1,61 @@
+package bot
+
+import (
+	"log"
+
+	"ithozyeva/internal/models"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// enforceBanlistOnJoin банит вступившего, если он есть в bot_global_bans
+// (ручной /globalban, импорт от партнёров или внешний бан-лист). runGlobalBan
+// проходит только по уже известным чатам — эта проверка закрывает новые чаты
+// и записи, пришедшие синхронизацией. true — юзер забанен, дальше его не
+// обрабатываем.
+func (b *TelegramBot) enforceBanlistOnJoin(update *tgbotapi.ChatMemberUpdated) bool {
+	user := update.NewChatMember.User
+	if user == nil || user.IsBot {
+		return false
+	}
+	banned, gb, err := b.moderationService.IsGloballyBanned(user.ID)
+	if err != nil {
+		log.Printf("banlist: check user %d: %v", user.ID, err)
+		return false
+	}
+	if !banned {
+		return false
+	}
+
+	until := int64(0)
+	if gb.ExpiresAt != nil {
+		until = gb.ExpiresAt.Unix()
+	}
+	if _, err := b.bot.Request(tgbotapi.BanChatMemberConfig{
+		ChatMemberConfig: tgbotapi.ChatMemberConfig{
+			ChatID: update.Chat.ID,
+			UserID: user.ID,
+		},
+		UntilDate: until,
+	}); err != nil {
+		log.Printf("banlist: ban user %d in chat %d: %v", user.ID, update.Chat.ID, err)
+		return false
+	}
+
+	meta := map[string]interface{}{"source": gb.Source}
+	if gb.Reason != nil {
+		meta["reason"] = *gb.Reason
+	}
+	if err := b.moderationService.LogActionWithMeta(&models.ModerationAction{
+		ChatID:       update.Chat.ID,
+		TargetUserID: user.ID,
+		ActorUserID:  0,
+		Action:       models.ModerationActionBanlistBan,
+		ExpiresAt:    gb.ExpiresAt,
+		Reason:       gb.Reason,
+	}, meta); err != nil {
+		log.Printf("banlist: log ban user %d: %v", user.ID, err)
+	}
+	log.Printf("banlist: banned user %d on join in chat %d (source=%s)", user.ID, update.Chat.ID, gb.Source)
+	return true
+}
@@ -0,0 +1,140 @@
+package bot
+
+import (
+	"fmt"
+	"html"
+	"log"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"ithozyeva/config"
+	"ithozyeva/internal/i18n"
+	"ithozyeva/internal/models"
+	"ithozyeva/internal/service"
+	"ithozyeva/internal/utils"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+const (
+	// digestPollInterval — как часто бот ищет неопубликованные дайджесты.
+	// Бэкенд генерирует их раз в неделю, минута задержки не важна.
+	digestPollInterval = time.Minute
+
+	// digestSummaryMaxRunes — потолок текста модели в посте: вместе с
+	// топом участников и хайлайтами сообщение должно влезть в 4096 символов.
+	digestSummaryMaxRunes = 2800
+	digestHighlightRunes  = 200
+)
+
+// startDigestPoster публикует еженедельные дайджесты, сгенерированные
+// бэкендом. Pull-based, как startAutoQuestPoster.
+func (b *TelegramBot) startDigestPoster() {
+	ticker := time.NewTicker(digestPollInterval)
+	defer ticker.Stop()
+
+	b.postPendingDigests()
+	for range ticker.C {
+		b.postPendingDigests()
+	}
+}
+
+func (b *TelegramBot) postPendingDigests() {
+	if !b.isLeader() {
+		return
+	}
+	digests, err := b.digestService.ListUnposted(20)
+	if err != nil {
+		log.Printf("digest-poster: load pending error: %v", err)
+		return
+	}
+	for i := range digests {
+		b.postOneDigest(&digests[i])
+	}
+}
+
+// postOneDigest — «mark first, then send», как у авто-квестов: лучше
+// потерять один пост, чем задублить дайджест в чате.
+func (b *TelegramBot) postOneDigest(d *models.ChatDigest) {
+	ok, err := b.digestService.MarkPosted(d.Id)
+	if err != nil {
+		log.Printf("digest-poster: mark posted error digest=%d: %v", d.Id, err)
+		return
+	}
+	if !ok {
+		return
+	}
+
+	msg := tgbotapi.NewMessage(d.ChatID, formatDigestMessage(d, platformBaseURL()))
+	msg.ParseMode = "HTML"
+	msg.DisableWebPagePreview = true
+	if _, err := b.bot.Send(msg); err != nil {
+		log.Printf("digest-poster: send error digest=%d chat=%d: %v", d.Id, d.ChatID, err)
+		return
+	}
+	log.Printf("digest-poster: posted digest=%d to chat=%d", d.Id, d.ChatID)
+}
+
+// platformBaseURL — PUBLIC_DOMAIN со схемой; "" — не задан.
+func platformBaseURL() string {
+	u := config.CFG.PublicDomain
+	if u != "" && !strings.HasPrefix(u, "http://") && !strings.HasPrefix(u, "https://") {
+		u = "https://" + u
+	}
+	return u
+}
+
+func formatDigestMessage(d *models.ChatDigest, baseURL string) string {
+	first := d.PeriodStart.In(utils.MSKLocation())
+	last := d.PeriodEnd.Add(-time.Second).In(utils.MSKLocation())
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("🗞 <b>Дайджест недели</b> · %s — %s\n", first.Format("02.01"), last.Format("02.01")))
+	sb.WriteString(i18n.Count(i18n.Default, d.MessageCount, "сообщение") + ", " +
+		i18n.Count(i18n.Default, d.ActiveMembers, "участник") + "\n\n")
+	// Текст модели — HTML по промпту, но в публичный чат пускаем только
+	// теги, которые понимает Telegram.
+	sb.WriteString(sanitizeTelegramHTML(truncateAtLine(d.Summary, digestSummaryMaxRunes)))
+
+	if members := service.DecodeDigestMembers(d); len(members) > 0 {
+		sb.WriteString("\n\n🏆 <b>Самые активные:</b>\n")
+		for i, m := range members {
+			name := m.TelegramFirstName
+			if m.TelegramUsername != "" {
+				name = "@" + m.TelegramUsername
+			}
+			sb.WriteString(fmt.Sprintf("%d. %s — %d\n", i+1, html.EscapeString(name), m.Count))
+		}
+	}
+
+	if highlights := service.DecodeDigestHighlights(d); len(highlights) > 0 {
+		sb.WriteString("\n⭐ <b>Хайлайты:</b>\n")
+		for _, h := range highlights {
+			author := h.AuthorFirstName
+			if h.AuthorUsername != "" {
+				author = "@" + h.AuthorUsername
+			}
+			sb.WriteString(fmt.Sprintf("• %s: <i>%s</i>\n", html.EscapeString(author),
+				html.EscapeString(truncateRunes(h.MessageText, digestHighlightRunes))))
+		}
+	}
+
+	if baseURL != "" {
+		sb.WriteString(fmt.Sprintf("\n<a href=\"%s/digests\">Архив дайджестов</a>", baseURL))
+	}
+	return sb.String()
+}
+
+// truncateAtLine обрезает текст до max рун по границе строки, чтобы не
+// разрезать HTML-тег посередине.
+func truncateAtLine(s string, max int) string {
+	if utf8.RuneCountInString(s) <= max {
+		return s
+	}
+	cut := string([]rune(s)[:max])
+	if i := strings.LastIndex(cut, "\n"); i > 0 {
+		cut = cut[:i]
+	}
+	return strings.TrimRight(cut, "\n") + "\n…"
+}
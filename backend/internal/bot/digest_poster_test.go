@@ -0,0 +1,49 @@
+package bot
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"ithozyeva/internal/models"
+	"ithozyeva/internal/utils"
+)
+
+func TestFormatDigestMessage(t *testing.T) {
+	msk := utils.MSKLocation()
+	d := &models.ChatDigest{
+		PeriodStart:   time.Date(2026, 5, 18, 0, 0, 0, 0, msk),
+		PeriodEnd:     time.Date(2026, 5, 25, 0, 0, 0, 0, msk),
+		MessageCount:  420,
+		ActiveMembers: 37,
+		Summary:       "• <b>Go 1.25</b> — обсуждали <script>x</script>",
+		TopMembers:    `[{"telegramUserId":1,"telegramUsername":"alice","count":120},{"telegramUserId":2,"telegramFirstName":"Боб <3","count":80}]`,
+		Highlights:    `[{"id":1,"authorUsername":"carol","messageText":"a < b"}]`,
+	}
+	got := formatDigestMessage(d, "https://example.org")
+	for _, want := range []string{
+		"18.05 — 24.05",
+		"420 сообщений, 37 участников",
+		"<b>Go 1.25</b>",
+		"&lt;script&gt;",
+		"1. @alice — 120",
+		"2. Боб &lt;3 — 80",
+		"@carol: <i>a &lt; b</i>",
+		`<a href="https://example.org/digests">`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("missing %q in:\n%s", want, got)
+		}
+	}
+}
+
+func TestTruncateAtLine(t *testing.T) {
+	s := "• <b>one</b>\n• <b>two</b>\n• <b>three</b>"
+	if got := truncateAtLine(s, 100); got != s {
+		t.Fatalf("short text changed: %q", got)
+	}
+	got := truncateAtLine(s, 20)
+	if got != "• <b>one</b>\n…" {
+		t.Fatalf("got %q", got)
+	}
+}
@@ -0,0 +1,92 @@
+package bot
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"ithozyeva/internal/service"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// faqMatchersTTL — сколько бот держит триггеры автоответчика в памяти:
+// проверка идёт на каждое сообщение, правки из админки доходят за минуту.
+const faqMatchersTTL = time.Minute
+
+type faqCache struct {
+	mu        sync.Mutex
+	matchers  []*service.FAQMatcher
+	fetchedAt time.Time
+}
+
+func (b *TelegramBot) faqMatchers() []*service.FAQMatcher {
+	c := b.faq
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if time.Since(c.fetchedAt) < faqMatchersTTL {
+		return c.matchers
+	}
+	matchers, err := b.faqService.LoadMatchers()
+	if err != nil {
+		// Отдаём прошлый набор и пробуем снова на следующем сообщении
+		// после TTL — БД моргнула, автоответчик не должен замолчать.
+		log.Printf("faq: load triggers: %v", err)
+		c.fetchedAt = time.Now()
+		return c.matchers
+	}
+	c.matchers, c.fetchedAt = matchers, time.Now()
+	return matchers
+}
+
+// handleAutoReplies — автоответы на сообщение из чата: сначала FAQ-триггеры,
+// если ни один не ответил — подсказка из базы знаний.
+func (b *TelegramBot) handleAutoReplies(message *tgbotapi.Message) {
+	if !b.answerFAQ(message) {
+		b.maybeSuggestKnowledgeBase(message)
+	}
+}
+
+// answerFAQ отвечает в тред сообщения, если сработал триггер и у него не
+// идёт кулдаун в этом чате. true — ответ отправлен.
+func (b *TelegramBot) answerFAQ(message *tgbotapi.Message) bool {
+	if message.From == nil || message.From.IsBot || message.IsCommand() || message.Chat.IsPrivate() {
+		return false
+	}
+	if !b.chatActivityService.IsTrackedChat(message.Chat.ID) {
+		return false
+	}
+	m := service.MatchFAQ(b.faqMatchers(), message.Chat.ID, message.Text)
+	if m == nil {
+		return false
+	}
+	ok, err := b.faqService.TryStartCooldown(context.Background(), &m.Trigger, message.Chat.ID)
+	if err != nil {
+		log.Printf("faq: cooldown trigger=%d chat=%d: %v", m.Trigger.Id, message.Chat.ID, err)
+		return false
+	}
+	if !ok {
+		return false
+	}
+
+	name := message.From.FirstName
+	if message.From.UserName != "" {
+		name = "@" + message.From.UserName
+	}
+	// Шаблон чистим до подстановки: RenderFAQResponse уже экранирует имя и
+	// название чата, повторное экранирование испортило бы их.
+	reply := tgbotapi.NewMessage(message.Chat.ID,
+		service.RenderFAQResponse(sanitizeTelegramHTML(m.Trigger.Response), name, message.Chat.Title))
+	reply.ParseMode = "HTML"
+	reply.DisableWebPagePreview = true
+	reply.ReplyToMessageID = message.MessageID
+	if _, err := b.bot.Send(reply); err != nil {
+		log.Printf("faq: send trigger=%d chat=%d: %v", m.Trigger.Id, message.Chat.ID, err)
+		return false
+	}
+	if err := b.faqService.RecordHit(m.Trigger.Id, message.Chat.ID, message.From.ID, message.MessageID); err != nil {
+		log.Printf("faq: record hit trigger=%d: %v", m.Trigger.Id, err)
+	}
+	return true
+}
@@ -0,0 +1,141 @@
+package bot
+
+import (
+	"errors"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"ithozyeva/internal/i18n"
+	"ithozyeva/internal/service"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// Язык пользователя бота: выбранный в профиле (/language, платформа), иначе
+// language_code из Telegram. Держим в памяти userLangTTL, чтобы не ходить в
+// БД на каждое сообщение в группе.
+const userLangTTL = 10 * time.Minute
+
+type userLangEntry struct {
+	lang   i18n.Lang
+	seenAt time.Time
+}
+
+type userLangs struct {
+	mu        sync.Mutex
+	byUser    map[int64]userLangEntry
+	lastSweep time.Time
+}
+
+func newUserLangs() *userLangs {
+	return &userLangs{byUser: make(map[int64]userLangEntry)}
+}
+
+func (c *userLangs) get(userID int64, now time.Time) (i18n.Lang, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.byUser[userID]
+	if !ok || now.Sub(e.seenAt) > userLangTTL {
+		return "", false
+	}
+	return e.lang, true
+}
+
+func (c *userLangs) set(userID int64, lang i18n.Lang, now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.byUser[userID] = userLangEntry{lang: lang, seenAt: now}
+	if now.Sub(c.lastSweep) < userLangTTL {
+		return
+	}
+	c.lastSweep = now
+	for id, e := range c.byUser {
+		if now.Sub(e.seenAt) > userLangTTL {
+			delete(c.byUser, id)
+		}
+	}
+}
+
+// observeUser запоминает язык автора апдейта. Зовётся до обработки, чтобы
+// дальше язык можно было взять по одному telegram id (в том числе по
+// chat id лички — он совпадает с id пользователя).
+func (b *TelegramBot) observeUser(u *tgbotapi.User) {
+	if u == nil || u.IsBot {
+		return
+	}
+	now := time.Now()
+	if _, ok := b.langs.get(u.ID, now); ok {
+		return
+	}
+	lang, err := b.member.GetLanguage(u.ID, u.LanguageCode)
+	if err != nil {
+		log.Printf("i18n: language of %d: %v", u.ID, err)
+	}
+	b.langs.set(u.ID, lang, now)
+}
+
+// langOf — язык пользователя userID (или лички с ним).
+func (b *TelegramBot) langOf(userID int64) i18n.Lang {
+	now := time.Now()
+	if lang, ok := b.langs.get(userID, now); ok {
+		return lang
+	}
+	if userID <= 0 {
+		// Группы и каналы: языка у чата нет.
+		return i18n.Default
+	}
+	lang, err := b.member.GetLanguage(userID, "")
+	if err != nil {
+		log.Printf("i18n: language of %d: %v", userID, err)
+	}
+	b.langs.set(userID, lang, now)
+	return lang
+}
+
+// tr — сообщение на языке пользователя userID.
+func (b *TelegramBot) tr(userID int64, msg string, args ...interface{}) string {
+	return i18n.T(b.langOf(userID), msg, args...)
+}
+
+// languageNames — название языка на нём самом.
+var languageNames = map[i18n.Lang]string{
+	i18n.RU: "русский",
+	i18n.EN: "English",
+}
+
+// handleLanguageCommand — /language [ru|en] в личке: без аргумента
+// показывает текущий язык, с аргументом — сохраняет выбор в профиле.
+func (b *TelegramBot) handleLanguageCommand(message *tgbotapi.Message) {
+	userID := message.From.ID
+	code := strings.TrimSpace(message.CommandArguments())
+	if code == "" {
+		lang := b.langOf(userID)
+		b.sendMessage(message.Chat.ID, i18n.T(lang, "Язык бота: %s. Сменить: /language ru или /language en", languageNames[lang]))
+		return
+	}
+
+	member, err := b.member.GetByTelegramID(userID)
+	if err != nil || member == nil {
+		b.sendMessage(message.Chat.ID, b.tr(userID, "Вы не зарегистрированы на платформе. Используйте /start для авторизации."))
+		return
+	}
+	lang, err := b.member.SetLanguage(member.Id, code)
+	if errors.Is(err, service.ErrUnsupportedLanguage) {
+		b.sendMessage(message.Chat.ID, b.trErr(userID, err)+": /language ru, /language en")
+		return
+	}
+	if err != nil {
+		log.Printf("i18n: set language of %d: %v", userID, err)
+		b.sendMessage(message.Chat.ID, b.tr(userID, "Не удалось сохранить язык. Попробуйте позже."))
+		return
+	}
+	b.langs.set(userID, lang, time.Now())
+	b.sendMessage(message.Chat.ID, i18n.T(lang, "Готово, язык бота: %s.", languageNames[lang]))
+}
+
+// trErr — текст ошибки на языке пользователя userID.
+func (b *TelegramBot) trErr(userID int64, err error) string {
+	return i18n.LocalizeError(b.langOf(userID), err)
+}
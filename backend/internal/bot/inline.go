@@ -0,0 +1,107 @@
+package bot
+
+import (
+	"html"
+	"log"
+	"strings"
+	"time"
+
+	"ithozyeva/internal/i18n"
+	"ithozyeva/internal/models"
+	"ithozyeva/internal/service"
+	"ithozyeva/internal/utils"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// Inline-режим: «@бот запрос» в любом чате. Отвечаем тем же поиском, что
+// /api/platform/search, — с видимостью по подписке того, кто спрашивает,
+// поэтому результаты персональные. Режим включается в BotFather (/setinline).
+const (
+	inlineCacheSeconds   = 30
+	inlineDescriptionMax = 100
+	inlineStartParam     = "inline"
+)
+
+// inlineKinds — иконка и подпись типа в карточке результата.
+var inlineKinds = map[string]struct{ icon, label string }{
+	models.PlatformSearchEvent:      {"🎓", "Событие"},
+	models.PlatformSearchMentor:     {"🧑‍🏫", "Ментор"},
+	models.PlatformSearchMember:     {"👤", "Участник"},
+	models.PlatformSearchAIMaterial: {"🤖", "AI-материал"},
+}
+
+func (b *TelegramBot) handleInlineQuery(query *tgbotapi.InlineQuery) {
+	lang := b.langOf(query.From.ID)
+	answer := tgbotapi.InlineConfig{
+		InlineQueryID: query.ID,
+		Results:       []interface{}{},
+		CacheTime:     inlineCacheSeconds,
+		IsPersonal:    true,
+	}
+
+	member, err := b.member.GetByTelegramID(query.From.ID)
+	if err != nil || member == nil {
+		// Незарегистрированному — кнопка «в личку с ботом» над пустым списком.
+		answer.SwitchPMText = i18n.T(lang, "Войдите на платформу, чтобы искать")
+		answer.SwitchPMParameter = inlineStartParam
+		b.answerInline(answer)
+		return
+	}
+
+	hits, err := b.platformSearch.Search(member, query.Query, time.Now())
+	if err != nil {
+		log.Printf("inline search user=%d: %v", query.From.ID, err)
+	}
+	for _, h := range hits {
+		answer.Results = append(answer.Results, b.inlineResult(lang, h))
+	}
+	b.answerInline(answer)
+}
+
+func (b *TelegramBot) answerInline(answer tgbotapi.InlineConfig) {
+	if _, err := b.bot.Request(answer); err != nil {
+		log.Printf("answerInlineQuery: %v", err)
+	}
+}
+
+// inlineResult — карточка-статья: в чат уходит описание и кнопка, которая
+// открывает сущность в Mini App.
+func (b *TelegramBot) inlineResult(lang i18n.Lang, h models.PlatformSearchHit) tgbotapi.InlineQueryResultArticle {
+	param := service.PlatformStartParam(h.Kind, h.ID)
+	kind := inlineKinds[h.Kind]
+	description := inlineDescription(lang, h)
+
+	var text strings.Builder
+	text.WriteString(kind.icon + " <b>" + html.EscapeString(h.Title) + "</b>\n")
+	text.WriteString("<i>" + i18n.T(lang, kind.label) + "</i>")
+	if description != "" {
+		text.WriteString("\n\n" + html.EscapeString(description))
+	}
+
+	result := tgbotapi.NewInlineQueryResultArticleHTML(param, kind.icon+" "+h.Title, text.String())
+	result.Description = description
+	if strings.HasPrefix(h.ImageURL, "https://") {
+		result.ThumbURL = h.ImageURL
+	}
+	keyboard := tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonURL(i18n.T(lang, "Открыть в приложении"),
+			service.MiniAppLink(b.bot.Self.UserName, param)),
+	))
+	result.ReplyMarkup = &keyboard
+	return result
+}
+
+// inlineDescription — строка под заголовком: для события дата по МСК и
+// место, для остальных — подзаголовок из поиска.
+func inlineDescription(lang i18n.Lang, h models.PlatformSearchHit) string {
+	description := h.Subtitle
+	if h.Date != nil {
+		date := i18n.T(lang, "%s МСК", h.Date.In(utils.MSKLocation()).Format(i18n.T(lang, "02.01.2006 в 15:04")))
+		if description != "" {
+			date += " · " + description
+		}
+		description = date
+	}
+	return truncateRunes(strings.TrimSpace(description), inlineDescriptionMax)
+}
@@ -0,0 +1,68 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"log"
+	"time"
+
+	"ithozyeva/internal/service"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// Подсказки из базы знаний: на вопрос в трекаемом чате, похожий на
+// существующую статью, бот отвечает ссылкой на неё. Чтобы не шуметь —
+// одна подсказка в чате раз в kbChatCooldown и одна и та же статья в чате
+// не чаще kbEntryCooldown. Кулдауны общие для реплик бота (Redis).
+const (
+	kbChatCooldown  = 2 * time.Minute
+	kbEntryCooldown = 30 * time.Minute
+)
+
+// maybeSuggestKnowledgeBase — подсказка статьи на вопрос из чата. Ответы
+// другим участникам пропускаем: там уже идёт разговор.
+func (b *TelegramBot) maybeSuggestKnowledgeBase(message *tgbotapi.Message) {
+	if message.From == nil || message.From.IsBot || message.IsCommand() || message.Chat.IsPrivate() {
+		return
+	}
+	if service.ReplyToMessageID(message, b.messageThreadID(message)) != nil {
+		return
+	}
+	if !b.chatActivityService.IsTrackedChat(message.Chat.ID) || !service.LooksLikeQuestion(message.Text) {
+		return
+	}
+	ctx, now := context.Background(), time.Now()
+	if ready, err := b.chatState.KBSuggestChatReady(ctx, message.Chat.ID, now, kbChatCooldown); err != nil || !ready {
+		return
+	}
+	entry, err := b.knowledgeBaseService.SuggestForQuestion(message.Text)
+	if err != nil {
+		log.Printf("kb suggest chat=%d: %v", message.Chat.ID, err)
+		return
+	}
+	if entry == nil {
+		return
+	}
+	if ok, err := b.chatState.TakeKBSuggestion(ctx, message.Chat.ID, entry.Id, now, kbChatCooldown, kbEntryCooldown); err != nil || !ok {
+		if err != nil {
+			log.Printf("kb suggest cooldown chat=%d: %v", message.Chat.ID, err)
+		}
+		return
+	}
+
+	title := html.EscapeString(entry.Title)
+	if base := platformBaseURL(); base != "" {
+		title = fmt.Sprintf("<a href=\"%s/knowledge-base/%d\">%s</a>", base, entry.Id, title)
+	}
+	reply := tgbotapi.NewMessage(message.Chat.ID, b.tr(message.From.ID, "💡 Похоже, на это уже отвечали — статья в базе знаний: <b>%s</b>", title))
+	reply.ParseMode = "HTML"
+	reply.DisableWebPagePreview = true
+	reply.ReplyToMessageID = message.MessageID
+	if _, err := b.bot.Send(reply); err != nil {
+		log.Printf("kb suggest send chat=%d: %v", message.Chat.ID, err)
+		return
+	}
+	b.knowledgeBaseService.MarkSuggested(entry.Id)
+}
@@ -0,0 +1,52 @@
+package bot
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"ithozyeva/internal/testutil"
+)
+
+func TestKBSuggestCooldowns(t *testing.T) {
+	_, rdb := testutil.NewMiniRedis(t)
+	state := stateBot(rdb).chatState
+	ctx := context.Background()
+	now := time.Now()
+	take := func(chatID, entryID int64, at time.Time) bool {
+		t.Helper()
+		ok, err := state.TakeKBSuggestion(ctx, chatID, entryID, at, kbChatCooldown, kbEntryCooldown)
+		if err != nil {
+			t.Fatalf("TakeKBSuggestion: %v", err)
+		}
+		return ok
+	}
+	ready := func(chatID int64, at time.Time) bool {
+		t.Helper()
+		ok, err := state.KBSuggestChatReady(ctx, chatID, at, kbChatCooldown)
+		if err != nil {
+			t.Fatalf("KBSuggestChatReady: %v", err)
+		}
+		return ok
+	}
+
+	if !ready(-1, now) || !take(-1, 10, now) {
+		t.Fatal("first suggestion must pass")
+	}
+	if ready(-1, now.Add(time.Minute)) || take(-1, 11, now.Add(time.Minute)) {
+		t.Fatal("chat cooldown must block")
+	}
+	if !take(-2, 10, now.Add(time.Minute)) {
+		t.Fatal("other chat is independent")
+	}
+	// Чат «остыл», но та же статья — ещё рано.
+	if take(-1, 10, now.Add(kbChatCooldown+time.Second)) {
+		t.Fatal("entry cooldown must block")
+	}
+	if !take(-1, 11, now.Add(kbChatCooldown+time.Second)) {
+		t.Fatal("another entry after chat cooldown must pass")
+	}
+	if !take(-1, 10, now.Add(kbEntryCooldown+kbChatCooldown*2)) {
+		t.Fatal("entry cooldown must expire")
+	}
+}
@@ -0,0 +1,21 @@
+package bot
+
+import "ithozyeva/internal/service"
+
+// Лидер среди реплик бота. Апдейты обрабатывают все реплики, а фоновые
+// задачи (проверка подписок, voteban-watcher, алерты, постеры) и события
+// pub/sub — только держатель lease service.BotLeaderKey в Redis, иначе
+// каждая реплика кикала бы и рассылала одно и то же (см. ReplicaLease).
+func newLeaderLease(replicas *service.BotReplicaService) *service.ReplicaLease {
+	return service.NewReplicaLease(replicas, service.BotLeaderKey, "leader")
+}
+
+func (b *TelegramBot) isLeader() bool {
+	return b.lease.Held()
+}
+
+// startLeaderElection делает первую попытку синхронно — чтобы фоновые
+// задачи одиночной реплики не пропустили свой стартовый прогон.
+func (b *TelegramBot) startLeaderElection() {
+	b.lease.Start()
+}
@@ -27,11 +27,10 @@ const (
 	// упирается в самих участников (инициатор + цель уже двое).
 	votebanMinActiveAuthors = 4
 
-	votebanWindowSeconds = 15 * 60 // 15 минут — окно сбора голосов
-	// votebanKickSeconds — длительность санкции (BanChatMember с UntilDate).
-	// В БД хранится в колонке voteban.mute_seconds (имя оставлено по
-	// совместимости с уже отгруженной миграцией T1-модерации).
-	votebanKickSeconds = 60 * 60 // 1 час
+	// Окно голосования, длительность kick-санкции, мут по умолчанию и периоды
+	// /cleanup — в настройках чата (service.GetChatSettings, /modsettings).
+	// Длительность kick'а хранится в колонке voteban.mute_seconds (имя
+	// оставлено по совместимости с уже отгруженной миграцией T1-модерации).
 
 	// Anti-abuse cooldowns / стаж голосующего.
 	votebanCooldownChatSeconds      = 5 * 60        // не чаще одного /voteban в чате раз в 5 мин (любым target'ом)
@@ -41,9 +40,7 @@ const (
 	// одно сообщение в этом чате — отсекает «свежезашедших проходящих мимо».
 	voterMinMessages = 1
 
-	cleanupDefaultPeriod = 24 * time.Hour
-	cleanupMaxPeriod     = 7 * 24 * time.Hour
-	cleanupBatchSleep    = 35 * time.Millisecond // Telegram ~30 удалений/сек
+	cleanupBatchSleep = 35 * time.Millisecond // Telegram ~30 удалений/сек
 
 	moderationWatcherTick = time.Minute
 )
@@ -78,6 +75,7 @@ func (b *TelegramBot) isChatAdmin(chatID, userID int64) bool {
 }
 
 // canModerate true для super-admin платформы, любого ADMIN в БД и админов конкретного чата.
+// Это «полные» модераторы: им доступно всё, включая /modsettings.
 func (b *TelegramBot) canModerate(chatID, userID int64) bool {
 	if b.isSubscriptionAdmin(userID) {
 		return true
@@ -88,6 +86,40 @@ func (b *TelegramBot) canModerate(chatID, userID int64) bool {
 	return b.isChatAdmin(chatID, userID)
 }
 
+// hasModPower — может ли userID применять power (mute/ban/cleanup) в чате:
+// полные модераторы могут всё, волонтёры из bot_chat_moderators — только
+// выданное. Волонтёров проверяем первыми — это запрос в БД, а не в Telegram.
+func (b *TelegramBot) hasModPower(chatID, userID int64, power string) bool {
+	ok, err := b.moderationService.HasModeratorPower(chatID, userID, power)
+	if err != nil {
+		log.Printf("hasModPower: chat=%d user=%d: %v", chatID, userID, err)
+	}
+	if ok {
+		return true
+	}
+	return b.canModerate(chatID, userID)
+}
+
+// isModerationStaff — полный модератор или волонтёр с любыми полномочиями.
+// Таких нельзя банить/мутить/выносить на голосование обычными командами.
+func (b *TelegramBot) isModerationStaff(chatID, userID int64) bool {
+	if m, err := b.moderationService.GetChatModerator(chatID, userID); err == nil && m != nil {
+		return true
+	}
+	return b.canModerate(chatID, userID)
+}
+
+// chatSettings — настройки модерации чата; при ошибке БД — дефолты, чтобы
+// команды не ломались из-за недоступной таблицы.
+func (b *TelegramBot) chatSettings(chatID int64) *models.ChatModerationSettings {
+	st, err := b.moderationService.GetChatSettings(chatID)
+	if err != nil {
+		log.Printf("moderation: settings chat=%d: %v", chatID, err)
+		return service.DefaultChatModerationSettings(chatID)
+	}
+	return st
+}
+
 // --- Argument parsing ---
 
 // commandArgs возвращает аргументы команды без самой команды (с поддержкой @bot_name).
@@ -137,19 +169,19 @@ func (b *TelegramBot) handleBanCommand(message *tgbotapi.Message) {
 	if message.Chat.Type != "group" && message.Chat.Type != "supergroup" {
 		return
 	}
-	if !b.canModerate(message.Chat.ID, message.From.ID) {
+	if !b.hasModPower(message.Chat.ID, message.From.ID, models.ModeratorPowerBan) {
 		return
 	}
 	if message.ReplyToMessage == nil {
-		b.replyAndAutoDelete(message, "Используйте /ban в ответ на сообщение нарушителя. Опционально: /ban 1h, /ban 1d.")
+		b.replyAndAutoDelete(message, b.tr(message.From.ID, "Используйте /ban в ответ на сообщение нарушителя. Опционально: /ban 1h, /ban 1d."))
 		return
 	}
 	target := message.ReplyToMessage.From
 	if target == nil || target.IsBot {
 		return
 	}
-	if b.canModerate(message.Chat.ID, target.ID) {
-		b.replyAndAutoDelete(message, "Нельзя забанить администратора.")
+	if b.isModerationStaff(message.Chat.ID, target.ID) {
+		b.replyAndAutoDelete(message, b.tr(message.From.ID, "Нельзя забанить модератора."))
 		return
 	}
 
@@ -158,7 +190,7 @@ func (b *TelegramBot) handleBanCommand(message *tgbotapi.Message) {
 	if len(args) > 0 {
 		d, err := service.ParseHumanDuration(args[0])
 		if err != nil {
-			b.replyAndAutoDelete(message, fmt.Sprintf("Не понял длительность: %v. Примеры: 30m, 1h, 1d.", err))
+			b.replyAndAutoDelete(message, b.tr(message.From.ID, "Не понял длительность: %s. Примеры: 30m, 1h, 1d.", b.trErr(message.From.ID, err)))
 			return
 		}
 		duration = d
@@ -180,7 +212,7 @@ func (b *TelegramBot) handleBanCommand(message *tgbotapi.Message) {
 		UntilDate: until,
 	}); err != nil {
 		log.Printf("/ban: BanChatMember failed chat=%d user=%d: %v", message.Chat.ID, target.ID, err)
-		b.replyAndAutoDelete(message, "Telegram отказался банить (нужны права администратора с правом блокировки).")
+		b.replyAndAutoDelete(message, b.tr(message.From.ID, "Telegram отказался банить (нужны права администратора с правом блокировки)."))
 		return
 	}
 
@@ -211,7 +243,7 @@ func (b *TelegramBot) handleUnbanCommand(message *tgbotapi.Message) {
 	if message.Chat.Type != "group" && message.Chat.Type != "supergroup" {
 		return
 	}
-	if !b.canModerate(message.Chat.ID, message.From.ID) {
+	if !b.hasModPower(message.Chat.ID, message.From.ID, models.ModeratorPowerBan) {
 		return
 	}
 
@@ -223,12 +255,12 @@ func (b *TelegramBot) handleUnbanCommand(message *tgbotapi.Message) {
 	} else {
 		args := commandArgs(message)
 		if len(args) == 0 {
-			b.replyAndAutoDelete(message, "Использование: /unban в ответ на сообщение, или /unban @username, или /unban <user_id>.")
+			b.replyAndAutoDelete(message, b.tr(message.From.ID, "Использование: /unban в ответ на сообщение, или /unban @username, или /unban <user_id>."))
 			return
 		}
 		id, d, ok := b.parseTargetFromArg(message.Chat.ID, args[0])
 		if !ok {
-			b.replyAndAutoDelete(message, "Не нашёл пользователя. Передайте user_id или @username из этого чата.")
+			b.replyAndAutoDelete(message, b.tr(message.From.ID, "Не нашёл пользователя. Передайте user_id или @username из этого чата."))
 			return
 		}
 		targetID = id
@@ -243,7 +275,7 @@ func (b *TelegramBot) handleUnbanCommand(message *tgbotapi.Message) {
 		OnlyIfBanned: true,
 	}); err != nil {
 		log.Printf("/unban: UnbanChatMember failed chat=%d user=%d: %v", message.Chat.ID, targetID, err)
-		b.replyAndAutoDelete(message, "Telegram отказался разбанить.")
+		b.replyAndAutoDelete(message, b.tr(message.From.ID, "Telegram отказался разбанить."))
 		return
 	}
 
@@ -285,28 +317,29 @@ func (b *TelegramBot) handleMuteCommand(message *tgbotapi.Message) {
 	if message.Chat.Type != "group" && message.Chat.Type != "supergroup" {
 		return
 	}
-	if !b.canModerate(message.Chat.ID, message.From.ID) {
+	if !b.hasModPower(message.Chat.ID, message.From.ID, models.ModeratorPowerMute) {
 		return
 	}
 	if message.ReplyToMessage == nil {
-		b.replyAndAutoDelete(message, "Используйте /mute в ответ на сообщение. Опционально: /mute 30m, /mute 1h.")
+		b.replyAndAutoDelete(message, b.tr(message.From.ID, "Используйте /mute в ответ на сообщение. Опционально: /mute 30m, /mute 1h."))
 		return
 	}
 	target := message.ReplyToMessage.From
 	if target == nil || target.IsBot {
 		return
 	}
-	if b.canModerate(message.Chat.ID, target.ID) {
-		b.replyAndAutoDelete(message, "Нельзя замутить администратора.")
+	if b.isModerationStaff(message.Chat.ID, target.ID) {
+		b.replyAndAutoDelete(message, b.tr(message.From.ID, "Нельзя замутить модератора."))
 		return
 	}
 
 	args := commandArgs(message)
-	var duration time.Duration
+	// Без аргумента — мут по умолчанию из настроек чата (0 — бессрочно).
+	duration := time.Duration(b.chatSettings(message.Chat.ID).MuteDefaultSeconds) * time.Second
 	if len(args) > 0 {
 		d, err := service.ParseHumanDuration(args[0])
 		if err != nil {
-			b.replyAndAutoDelete(message, fmt.Sprintf("Не понял длительность: %v.", err))
+			b.replyAndAutoDelete(message, b.tr(message.From.ID, "Не понял длительность: %s.", b.trErr(message.From.ID, err)))
 			return
 		}
 		duration = d
@@ -322,7 +355,7 @@ func (b *TelegramBot) handleMuteCommand(message *tgbotapi.Message) {
 
 	if err := b.muteUserInChat(message.Chat.ID, target.ID, until); err != nil {
 		log.Printf("/mute: failed chat=%d user=%d: %v", message.Chat.ID, target.ID, err)
-		b.replyAndAutoDelete(message, "Не удалось замутить.")
+		b.replyAndAutoDelete(message, b.tr(message.From.ID, "Не удалось замутить."))
 		return
 	}
 
@@ -364,9 +397,12 @@ func (b *TelegramBot) handleCleanupCommand(message *tgbotapi.Message) {
 	if message.Chat.Type != "group" && message.Chat.Type != "supergroup" {
 		return
 	}
-	if !b.canModerate(message.Chat.ID, message.From.ID) {
+	if !b.hasModPower(message.Chat.ID, message.From.ID, models.ModeratorPowerCleanup) {
 		return
 	}
+	settings := b.chatSettings(message.Chat.ID)
+	defaultPeriod := time.Duration(settings.CleanupDefaultSeconds) * time.Second
+	maxPeriod := time.Duration(settings.CleanupMaxSeconds) * time.Second
 
 	var targetID int64
 	var display string
@@ -376,19 +412,21 @@ func (b *TelegramBot) handleCleanupCommand(message *tgbotapi.Message) {
 	} else {
 		args := commandArgs(message)
 		if len(args) == 0 {
-			b.replyAndAutoDelete(message, "Использование: /cleanup в ответ на сообщение [период], или /cleanup @username [период]. Период по умолчанию — 24h.")
+			b.replyAndAutoDelete(message, b.tr(message.From.ID,
+				"Использование: /cleanup в ответ на сообщение [период], или /cleanup @username [период]. Период по умолчанию — %s.",
+				service.FormatDurationHuman(defaultPeriod)))
 			return
 		}
 		id, d, ok := b.parseTargetFromArg(message.Chat.ID, args[0])
 		if !ok {
-			b.replyAndAutoDelete(message, "Не нашёл пользователя в этом чате.")
+			b.replyAndAutoDelete(message, b.tr(message.From.ID, "Не нашёл пользователя в этом чате."))
 			return
 		}
 		targetID = id
 		display = html.EscapeString(d)
 	}
 
-	period := cleanupDefaultPeriod
+	period := defaultPeriod
 	args := commandArgs(message)
 	// Если в reply, period — args[0]. Если без reply, period — args[1].
 	periodArg := ""
@@ -404,15 +442,15 @@ func (b *TelegramBot) handleCleanupCommand(message *tgbotapi.Message) {
 	if periodArg != "" {
 		d, err := service.ParseHumanDuration(periodArg)
 		if err != nil {
-			b.replyAndAutoDelete(message, fmt.Sprintf("Не понял период: %v.", err))
+			b.replyAndAutoDelete(message, b.tr(message.From.ID, "Не понял период: %s.", b.trErr(message.From.ID, err)))
 			return
 		}
 		if d <= 0 {
-			b.replyAndAutoDelete(message, "Период должен быть больше нуля.")
+			b.replyAndAutoDelete(message, b.tr(message.From.ID, "Период должен быть больше нуля."))
 			return
 		}
-		if d > cleanupMaxPeriod {
-			b.replyAndAutoDelete(message, "Период не больше 7 дней.")
+		if d > maxPeriod {
+			b.replyAndAutoDelete(message, b.tr(message.From.ID, "Период не больше %s.", service.FormatDurationHuman(maxPeriod)))
 			return
 		}
 		period = d
@@ -422,7 +460,7 @@ func (b *TelegramBot) handleCleanupCommand(message *tgbotapi.Message) {
 	ids, err := b.moderationService.MessagesForCleanup(message.Chat.ID, targetID, since)
 	if err != nil {
 		log.Printf("/cleanup: query failed: %v", err)
-		b.replyAndAutoDelete(message, "Ошибка при поиске сообщений.")
+		b.replyAndAutoDelete(message, b.tr(message.From.ID, "Ошибка при поиске сообщений."))
 		return
 	}
 
@@ -499,32 +537,50 @@ func (b *TelegramBot) handleVotebanCommand(message *tgbotapi.Message) {
 	// Цель — только @username, чтобы UX был один: «/voteban @user».
 	args := commandArgs(message)
 	if len(args) == 0 {
-		b.replyAndAutoDelete(message, "Использование: /voteban @username")
+		b.replyAndAutoDelete(message, b.tr(message.From.ID, "Использование: /voteban @username"))
 		return
 	}
 	rawArg := strings.TrimSpace(args[0])
 	username := strings.TrimPrefix(rawArg, "@")
 	if username == "" || !strings.HasPrefix(rawArg, "@") {
-		b.replyAndAutoDelete(message, "Использование: /voteban @username")
+		b.replyAndAutoDelete(message, b.tr(message.From.ID, "Использование: /voteban @username"))
 		return
 	}
 	targetID, err := b.chatActivityService.LookupUserIDByUsername(message.Chat.ID, username)
 	if err != nil || targetID == 0 {
-		b.replyAndAutoDelete(message, "Не нашёл такого пользователя в этом чате.")
+		b.replyAndAutoDelete(message, b.tr(message.From.ID, "Не нашёл такого пользователя в этом чате."))
 		return
 	}
 	target := &tgbotapi.User{ID: targetID, UserName: username}
 
 	if target.ID == b.bot.Self.ID {
-		b.replyAndAutoDelete(message, "На бота голосование не нужно.")
+		b.replyAndAutoDelete(message, b.tr(message.From.ID, "На бота голосование не нужно."))
 		return
 	}
 	if target.ID == message.From.ID {
-		b.replyAndAutoDelete(message, "Самому на себя голосование не нужно.")
+		b.replyAndAutoDelete(message, b.tr(message.From.ID, "Самому на себя голосование не нужно."))
+		return
+	}
+	if b.isModerationStaff(message.Chat.ID, target.ID) {
+		b.replyAndAutoDelete(message, b.tr(message.From.ID, "Нельзя начать голосование на модератора."))
+		return
+	}
+	// Репутация: свежие аккаунты голосование не запускают, старожилов на
+	// голосование не выносят (fail-closed при ошибке БД).
+	if rep, err := b.moderationService.GetReputation(message.Chat.ID, message.From.ID); err != nil || !rep.Eligible {
+		if err != nil {
+			log.Printf("voteban: initiator reputation failed: %v", err)
+		}
+		b.replyAndAutoDelete(message, b.tr(message.From.ID,
+			"Запускать голосование могут участники со стажем от %s.", service.FormatDurationHuman(service.VoterMinTenure)))
 		return
 	}
-	if b.canModerate(message.Chat.ID, target.ID) {
-		b.replyAndAutoDelete(message, "Нельзя начать голосование на администратора.")
+	if rep, err := b.moderationService.GetReputation(message.Chat.ID, target.ID); err != nil || rep.Protected {
+		if err != nil {
+			log.Printf("voteban: target reputation failed: %v", err)
+		}
+		b.replyAndAutoDelete(message,
+			b.tr(message.From.ID, "У участника высокая репутация в сообществе — голосование недоступно, обратитесь к модераторам."))
 		return
 	}
 	if ok, _ := b.isChatMember(message.Chat.ID, message.From.ID); !ok {
@@ -537,14 +593,14 @@ func (b *TelegramBot) handleVotebanCommand(message *tgbotapi.Message) {
 	// В чате одновременно идёт максимум одно голосование (на любого target).
 	if open, _ := b.moderationService.FindAnyOpenVotebanInChat(message.Chat.ID); open != nil {
 		b.replyAndAutoDelete(message,
-			"В чате уже идёт голосование, дождитесь его окончания.")
+			b.tr(message.From.ID, "В чате уже идёт голосование, дождитесь его окончания."))
 		return
 	}
 
 	// Cooldown по чату — защита от спама голосований подряд.
 	if last, _ := b.moderationService.LatestVotebanCreatedInChat(message.Chat.ID); last != nil {
 		if remain := time.Duration(votebanCooldownChatSeconds)*time.Second - time.Since(*last); remain > 0 {
-			b.replyAndAutoDelete(message, fmt.Sprintf(
+			b.replyAndAutoDelete(message, b.tr(message.From.ID,
 				"В чате уже было голосование недавно. Попробуйте через %s.", service.FormatDurationHuman(remain.Round(time.Second))))
 			return
 		}
@@ -552,7 +608,7 @@ func (b *TelegramBot) handleVotebanCommand(message *tgbotapi.Message) {
 	// Cooldown по инициатору — защита от одного активного троля.
 	if last, _ := b.moderationService.LatestVotebanCreatedByInitiator(message.Chat.ID, message.From.ID); last != nil {
 		if remain := time.Duration(votebanCooldownInitiatorSeconds)*time.Second - time.Since(*last); remain > 0 {
-			b.replyAndAutoDelete(message, fmt.Sprintf(
+			b.replyAndAutoDelete(message, b.tr(message.From.ID,
 				"Ваш предыдущий /voteban был недавно. Подождите %s.", service.FormatDurationHuman(remain.Round(time.Second))))
 			return
 		}
@@ -568,7 +624,7 @@ func (b *TelegramBot) handleVotebanCommand(message *tgbotapi.Message) {
 	}
 	if activeAuthors < votebanMinActiveAuthors {
 		b.replyAndAutoDelete(message,
-			"В чате слишком мало активных участников за последние 7 дней — voteban здесь не имеет смысла.")
+			b.tr(message.From.ID, "В чате слишком мало активных участников за последние 7 дней — voteban здесь не имеет смысла."))
 		return
 	}
 	requiredVotes := computeVotebanThreshold(activeAuthors)
@@ -580,7 +636,10 @@ func (b *TelegramBot) handleVotebanCommand(message *tgbotapi.Message) {
 	chatTitle := message.Chat.Title
 
 	// Сначала отправляем poll-сообщение — нам нужен его MessageID для записи.
-	pollText := b.formatVotebanPoll(target, message.From, models.VotebanTally{}, requiredVotes, time.Duration(votebanWindowSeconds)*time.Second)
+	settings := b.chatSettings(message.Chat.ID)
+	window := time.Duration(settings.VotebanWindowSeconds) * time.Second
+	kick := time.Duration(settings.VotebanKickSeconds) * time.Second
+	pollText := b.formatVotebanPoll(target, message.From, models.VotebanTally{}, requiredVotes, window, kick)
 	pollMsg := tgbotapi.NewMessage(message.Chat.ID, pollText)
 	pollMsg.ParseMode = "HTML"
 	pollMsg.DisableWebPagePreview = true
@@ -601,14 +660,14 @@ func (b *TelegramBot) handleVotebanCommand(message *tgbotapi.Message) {
 		TriggerMessageID: triggerPtr,
 		PollMessageID:    sent.MessageID,
 		RequiredVotes:    requiredVotes,
-		MuteSeconds:      votebanKickSeconds, // длительность kick-санкции (БД-колонка из T1)
-		WindowSeconds:    votebanWindowSeconds,
+		MuteSeconds:      settings.VotebanKickSeconds, // длительность kick-санкции (БД-колонка из T1)
+		WindowSeconds:    settings.VotebanWindowSeconds,
 	})
 	if err != nil {
 		// Уже идёт голосование — удаляем только что отправленный poll, оставляем существующий.
 		if err == service.ErrVotebanAlreadyOpen {
 			b.tryDelete(message.Chat.ID, sent.MessageID)
-			b.replyAndAutoDelete(message, "На этого участника уже идёт голосование.")
+			b.replyAndAutoDelete(message, b.tr(message.From.ID, "На этого участника уже идёт голосование."))
 			return
 		}
 		log.Printf("voteban: start failed: %v", err)
@@ -676,25 +735,33 @@ func formatRemainingHuman(d time.Duration) string {
 	}
 }
 
-func (b *TelegramBot) formatVotebanPoll(target, initiator *tgbotapi.User, tally models.VotebanTally, required int, window time.Duration) string {
+func (b *TelegramBot) formatVotebanPoll(target, initiator *tgbotapi.User, tally models.VotebanTally, required int, window, kick time.Duration) string {
 	return fmt.Sprintf(
 		"⚖️ <b>Голосование за кик</b>\n\n"+
 			"Кого: %s\n"+
 			"Кто запустил: %s\n"+
 			"Окно: %s · санкция: кик на %s\n"+
-			"Нужно %d «за» — кик; или %d «против» — отмена\n\n"+
-			"Голосуют участники чата (с активностью за последние 7 дней). Цель не голосует.",
+			"Нужно набрать вес %d «за» — кик; или %d «против» — отмена\n\n"+
+			"Голосуют участники чата (с активностью за последние 7 дней и стажем от 3 дней). "+
+			"Вес голоса — от 0.5 до 2 по репутации: стаж, подписка, баллы, сообщения в чате. Цель не голосует.",
 		targetDisplay(target),
 		targetDisplay(initiator),
 		formatRemainingHuman(window),
-		service.FormatDurationHuman(time.Duration(votebanKickSeconds)*time.Second),
+		service.FormatDurationHuman(kick),
 		required,
 		required,
 	) + b.formatVotebanTally(tally, required)
 }
 
 func (b *TelegramBot) formatVotebanTally(tally models.VotebanTally, required int) string {
-	return fmt.Sprintf("\n\n✅ За: %d/%d   ❌ Против: %d", tally.For, required, tally.Against)
+	return fmt.Sprintf("\n\n✅ За: %s/%d (%d чел.)   ❌ Против: %s/%d (%d чел.)",
+		formatVoteWeight(tally.ForWeight), required, tally.For,
+		formatVoteWeight(tally.AgainstWeight), required, tally.Against)
+}
+
+// formatVoteWeight — взвешенная сумма голосов без лишних нулей: «3», «2.5».
+func formatVoteWeight(w float64) string {
+	return strconv.FormatFloat(math.Round(w*10)/10, 'f', -1, 64)
 }
 
 func (b *TelegramBot) votebanKeyboard(votebanID int64, tally models.VotebanTally) tgbotapi.InlineKeyboardMarkup {
@@ -746,7 +813,7 @@ func (b *TelegramBot) refreshVotebanMessage(vb *models.Voteban, tally models.Vot
 	if window < 0 {
 		window = 0
 	}
-	text := b.formatVotebanPoll(target, initiator, tally, vb.RequiredVotes, window)
+	text := b.formatVotebanPoll(target, initiator, tally, vb.RequiredVotes, window, time.Duration(vb.MuteSeconds)*time.Second)
 
 	edit := tgbotapi.NewEditMessageTextAndMarkup(vb.ChatID, vb.PollMessageID, text, b.votebanKeyboard(vb.Id, tally))
 	edit.ParseMode = "HTML"
@@ -778,15 +845,15 @@ func (b *TelegramBot) handleVotebanCallback(callback *tgbotapi.CallbackQuery) {
 
 	vb, err := b.moderationService.GetVoteban(votebanID)
 	if err != nil || vb == nil {
-		b.answerCallbackQuery(callback.ID, "Голосование не найдено.")
+		b.answerCallbackQuery(callback.ID, b.tr(callback.From.ID, "Голосование не найдено."))
 		return
 	}
 	if vb.Status != models.VotebanStatusOpen {
-		b.answerCallbackQuery(callback.ID, "Голосование уже закрыто.")
+		b.answerCallbackQuery(callback.ID, b.tr(callback.From.ID, "Голосование уже закрыто."))
 		return
 	}
 	if ok, _ := b.isChatMember(vb.ChatID, callback.From.ID); !ok {
-		b.answerCallbackQuery(callback.ID, "Голосовать могут только участники чата.")
+		b.answerCallbackQuery(callback.ID, b.tr(callback.From.ID, "Голосовать могут только участники чата."))
 		return
 	}
 	// Стаж: голосует только тот, кто реально пишет в этом чате. Цель и
@@ -796,7 +863,7 @@ func (b *TelegramBot) handleVotebanCallback(callback *tgbotapi.CallbackQuery) {
 		count, _ := b.chatActivityService.CountUserMessagesInChatSince(
 			vb.ChatID, callback.From.ID, time.Now().Add(-voterMinActivityWindow))
 		if count < int64(voterMinMessages) {
-			b.answerCallbackQuery(callback.ID, "Голосовать могут активные участники чата за последние 7 дней.")
+			b.answerCallbackQuery(callback.ID, b.tr(callback.From.ID, "Голосовать могут активные участники чата за последние 7 дней."))
 			return
 		}
 	}
@@ -805,20 +872,22 @@ func (b *TelegramBot) handleVotebanCallback(callback *tgbotapi.CallbackQuery) {
 	if err != nil {
 		switch err {
 		case service.ErrVoteSelfTarget:
-			b.answerCallbackQuery(callback.ID, "Цель голосования не может голосовать.")
+			b.answerCallbackQuery(callback.ID, b.tr(callback.From.ID, "Цель голосования не может голосовать."))
 		case service.ErrVotebanClosed:
-			b.answerCallbackQuery(callback.ID, "Голосование уже закрыто.")
+			b.answerCallbackQuery(callback.ID, b.tr(callback.From.ID, "Голосование уже закрыто."))
+		case service.ErrVoterIneligible:
+			b.answerCallbackQuery(callback.ID, b.tr(callback.From.ID, "Голосовать могут участники со стажем от 3 дней."))
 		default:
 			log.Printf("voteban: cast failed: %v", err)
-			b.answerCallbackQuery(callback.ID, "Ошибка.")
+			b.answerCallbackQuery(callback.ID, b.tr(callback.From.ID, "Ошибка."))
 		}
 		return
 	}
 
 	if !res.Changed {
-		b.answerCallbackQuery(callback.ID, "Ваш голос уже учтён.")
+		b.answerCallbackQuery(callback.ID, b.tr(callback.From.ID, "Ваш голос уже учтён."))
 	} else {
-		b.answerCallbackQuery(callback.ID, "Голос принят.")
+		b.answerCallbackQuery(callback.ID, b.tr(callback.From.ID, "Голос принят (вес %s).", formatVoteWeight(res.Weight)))
 	}
 	b.refreshVotebanMessage(vb, res.Tally)
 
@@ -878,8 +947,8 @@ func (b *TelegramBot) finalizeVotebanPassed(vb *models.Voteban) {
 
 	tally, _ := b.moderationService.CountVotes(vb.Id)
 	target := &tgbotapi.User{ID: vb.TargetUserID, UserName: vb.TargetUsername, FirstName: vb.TargetFirstName}
-	text := fmt.Sprintf("⚖️ Голосование завершено: %s кикнут из чата на %s (✅ %d / ❌ %d). Авто-возврат после истечения срока.",
-		targetDisplay(target), service.FormatDurationHuman(dur), tally.For, tally.Against)
+	text := fmt.Sprintf("⚖️ Голосование завершено: %s кикнут из чата на %s (✅ %s / ❌ %s). Авто-возврат после истечения срока.",
+		targetDisplay(target), service.FormatDurationHuman(dur), formatVoteWeight(tally.ForWeight), formatVoteWeight(tally.AgainstWeight))
 	edit := tgbotapi.NewEditMessageText(vb.ChatID, vb.PollMessageID, text)
 	edit.ParseMode = "HTML"
 	if _, err := b.bot.Send(edit); err != nil {
@@ -901,8 +970,8 @@ func (b *TelegramBot) finalizeVotebanFailed(vb *models.Voteban) {
 
 	tally, _ := b.moderationService.CountVotes(vb.Id)
 	target := &tgbotapi.User{ID: vb.TargetUserID, UserName: vb.TargetUsername, FirstName: vb.TargetFirstName}
-	text := fmt.Sprintf("⚖️ Голосование закрыто: голосов недостаточно (✅ %d / ❌ %d). %s остаётся в чате.",
-		tally.For, tally.Against, targetDisplay(target))
+	text := fmt.Sprintf("⚖️ Голосование закрыто: голосов недостаточно (✅ %s / ❌ %s). %s остаётся в чате.",
+		formatVoteWeight(tally.ForWeight), formatVoteWeight(tally.AgainstWeight), targetDisplay(target))
 	edit := tgbotapi.NewEditMessageText(vb.ChatID, vb.PollMessageID, text)
 	edit.ParseMode = "HTML"
 	if _, err := b.bot.Send(edit); err != nil {
@@ -926,8 +995,8 @@ func (b *TelegramBot) finalizeVotebanCancelledByVotes(vb *models.Voteban) {
 
 	tally, _ := b.moderationService.CountVotes(vb.Id)
 	target := &tgbotapi.User{ID: vb.TargetUserID, UserName: vb.TargetUsername, FirstName: vb.TargetFirstName}
-	text := fmt.Sprintf("⚖️ Голосование отменено по голосам «против» (✅ %d / ❌ %d). %s остаётся в чате.",
-		tally.For, tally.Against, targetDisplay(target))
+	text := fmt.Sprintf("⚖️ Голосование отменено по голосам «против» (✅ %s / ❌ %s). %s остаётся в чате.",
+		formatVoteWeight(tally.ForWeight), formatVoteWeight(tally.AgainstWeight), targetDisplay(target))
 	edit := tgbotapi.NewEditMessageText(vb.ChatID, vb.PollMessageID, text)
 	edit.ParseMode = "HTML"
 	if _, err := b.bot.Send(edit); err != nil {
@@ -964,14 +1033,14 @@ func (b *TelegramBot) handleGlobalBanCommand(message *tgbotapi.Message) {
 	} else {
 		if len(args) == 0 {
 			b.replyAndAutoDelete(message,
-				"Использование: /globalban в reply | /globalban @user [duration] [reason] | /globalban &lt;id&gt; [duration] [reason]")
+				b.tr(message.From.ID, "Использование: /globalban в reply | /globalban @user [duration] [reason] | /globalban &lt;id&gt; [duration] [reason]"))
 			return
 		}
 		// Без привязки к чату ищем глобально (NULL chatID).
 		id, d, ok := b.resolveTargetGlobally(args[0])
 		if !ok {
 			b.replyAndAutoDelete(message,
-				"Не нашёл пользователя. Передай user_id или @username, который писал хотя бы в одном из наших чатов.")
+				b.tr(message.From.ID, "Не нашёл пользователя. Передай user_id или @username, который писал хотя бы в одном из наших чатов."))
 			return
 		}
 		targetID = id
@@ -980,11 +1049,11 @@ func (b *TelegramBot) handleGlobalBanCommand(message *tgbotapi.Message) {
 	}
 
 	if targetID == b.bot.Self.ID {
-		b.replyAndAutoDelete(message, "Нельзя забанить бота.")
+		b.replyAndAutoDelete(message, b.tr(message.From.ID, "Нельзя забанить бота."))
 		return
 	}
 	if b.isSubscriptionAdmin(targetID) || b.isAdmin(targetID) {
-		b.replyAndAutoDelete(message, "Нельзя глобально забанить администратора.")
+		b.replyAndAutoDelete(message, b.tr(message.From.ID, "Нельзя глобально забанить администратора."))
 		return
 	}
 
@@ -1006,13 +1075,13 @@ func (b *TelegramBot) handleGlobalBanCommand(message *tgbotapi.Message) {
 	chats, err := b.moderationService.KnownChatIDs()
 	if err != nil {
 		log.Printf("globalban: KnownChatIDs failed: %v", err)
-		b.replyAndAutoDelete(message, "Не смог получить список чатов.")
+		b.replyAndAutoDelete(message, b.tr(message.From.ID, "Не смог получить список чатов."))
 		return
 	}
 
 	if _, err := b.moderationService.UpsertGlobalBan(targetID, message.From.ID, reasonPtr, duration); err != nil {
 		log.Printf("globalban: upsert failed for user %d: %v", targetID, err)
-		b.replyAndAutoDelete(message, "Не смог сохранить запись о бане.")
+		b.replyAndAutoDelete(message, b.tr(message.From.ID, "Не смог сохранить запись о бане."))
 		return
 	}
 
@@ -1100,12 +1169,12 @@ func (b *TelegramBot) handleGlobalUnbanCommand(message *tgbotapi.Message) {
 	} else {
 		args := commandArgs(message)
 		if len(args) == 0 {
-			b.replyAndAutoDelete(message, "Использование: /globalunban в reply | /globalunban @user | /globalunban <id>")
+			b.replyAndAutoDelete(message, b.tr(message.From.ID, "Использование: /globalunban в reply | /globalunban @user | /globalunban <id>"))
 			return
 		}
 		id, d, ok := b.resolveTargetGlobally(args[0])
 		if !ok {
-			b.replyAndAutoDelete(message, "Не нашёл пользователя.")
+			b.replyAndAutoDelete(message, b.tr(message.From.ID, "Не нашёл пользователя."))
 			return
 		}
 		targetID = id
@@ -1115,7 +1184,7 @@ func (b *TelegramBot) handleGlobalUnbanCommand(message *tgbotapi.Message) {
 	chats, err := b.moderationService.KnownChatIDs()
 	if err != nil {
 		log.Printf("globalunban: KnownChatIDs failed: %v", err)
-		b.replyAndAutoDelete(message, "Не смог получить список чатов.")
+		b.replyAndAutoDelete(message, b.tr(message.From.ID, "Не смог получить список чатов."))
 		return
 	}
 	if err := b.moderationService.DeleteGlobalBan(targetID); err != nil {
@@ -1161,6 +1230,8 @@ func (b *TelegramBot) runGlobalUnban(targetID, actorID int64, display string, ch
 		display, len(chats), unbanned, failed))
 }
 
+const globalBansListLimit = 50
+
 // handleGlobalBansListCommand — короткая сводка по активным записям.
 func (b *TelegramBot) handleGlobalBansListCommand(message *tgbotapi.Message) {
 	if !b.isSubscriptionAdmin(message.From.ID) {
@@ -1177,7 +1248,13 @@ func (b *TelegramBot) handleGlobalBansListCommand(message *tgbotapi.Message) {
 	}
 	var sb strings.Builder
 	sb.WriteString(fmt.Sprintf("<b>Глобальные баны (%d):</b>\n\n", len(bans)))
-	for _, gb := range bans {
+	for i, gb := range bans {
+		// Импортированные списки бывают на тысячи записей — в сообщение
+		// Telegram они не влезут, полный список есть в админке и экспорте.
+		if i == globalBansListLimit {
+			sb.WriteString(fmt.Sprintf("… и ещё %d\n", len(bans)-i))
+			break
+		}
 		expires := "permanent"
 		if gb.ExpiresAt != nil {
 			expires = "до " + gb.ExpiresAt.Format("2006-01-02 15:04")
@@ -1186,7 +1263,11 @@ func (b *TelegramBot) handleGlobalBansListCommand(message *tgbotapi.Message) {
 		if gb.Reason != nil && *gb.Reason != "" {
 			reason = " · " + html.EscapeString(*gb.Reason)
 		}
-		sb.WriteString(fmt.Sprintf("• <code>%d</code> — %s%s\n", gb.UserID, expires, reason))
+		source := ""
+		if gb.Source != "" && gb.Source != models.GlobalBanSourceManual {
+			source = " [" + gb.Source + "]"
+		}
+		sb.WriteString(fmt.Sprintf("• <code>%d</code> — %s%s%s\n", gb.UserID, expires, source, reason))
 	}
 	b.SendDirectMessage(message.Chat.ID, sb.String())
 }
@@ -1219,6 +1300,9 @@ func (b *TelegramBot) startVotebanWatcher() {
 	ticker := time.NewTicker(moderationWatcherTick)
 	defer ticker.Stop()
 	for range ticker.C {
+		if !b.isLeader() {
+			continue
+		}
 		now := time.Now()
 
 		// 1) Финализация голосований по истечении окна.
@@ -1229,7 +1313,7 @@ func (b *TelegramBot) startVotebanWatcher() {
 			for i := range expired {
 				vb := expired[i]
 				tally, _ := b.moderationService.CountVotes(vb.Id)
-				if tally.For >= vb.RequiredVotes {
+				if tally.ForReached(vb.RequiredVotes) {
 					b.finalizeVotebanPassed(&vb)
 				} else {
 					b.finalizeVotebanFailed(&vb)
@@ -1237,7 +1321,17 @@ func (b *TelegramBot) startVotebanWatcher() {
 			}
 		}
 
-		// 2) Алерты «срок санкции истёк» для ban/mute/voteban.
+		// 2) Режим рейда, в котором давно не было вступлений.
+		raids, err := b.moderationService.ListExpiredActiveRaids(now)
+		if err != nil {
+			log.Printf("raid-watcher: list failed: %v", err)
+		} else {
+			for i := range raids {
+				b.expireRaid(&raids[i])
+			}
+		}
+
+		// 3) Алерты «срок санкции истёк» для ban/mute/voteban.
 		actions, err := b.moderationService.ListExpiredUnnotifiedActions(now)
 		if err != nil {
 			log.Printf("expiry-watcher: list failed: %v", err)
@@ -47,3 +47,12 @@ func TestComputeVotebanThreshold(t *testing.T) {
 		}
 	}
 }
+
+func TestFormatVoteWeight(t *testing.T) {
+	cases := map[float64]string{0: "0", 1: "1", 2.5: "2.5", 0.1 + 0.2: "0.3", 3.04: "3"}
+	for in, want := range cases {
+		if got := formatVoteWeight(in); got != want {
+			t.Errorf("formatVoteWeight(%v) = %q, want %q", in, got, want)
+		}
+	}
+}
@@ -0,0 +1,208 @@
+package bot
+
+import (
+	"fmt"
+	"html"
+	"log"
+	"strings"
+	"time"
+
+	"ithozyeva/internal/i18n"
+	"ithozyeva/internal/models"
+	"ithozyeva/internal/service"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// modSettingKeys — ключи /modsettings <key> <duration> и поле настроек, которое они меняют.
+var modSettingKeys = map[string]func(s *models.ChatModerationSettings) *int{
+	"voteban_window": func(s *models.ChatModerationSettings) *int { return &s.VotebanWindowSeconds },
+	"voteban_kick":   func(s *models.ChatModerationSettings) *int { return &s.VotebanKickSeconds },
+	"mute":           func(s *models.ChatModerationSettings) *int { return &s.MuteDefaultSeconds },
+	"cleanup":        func(s *models.ChatModerationSettings) *int { return &s.CleanupDefaultSeconds },
+	"cleanup_max":    func(s *models.ChatModerationSettings) *int { return &s.CleanupMaxSeconds },
+}
+
+const modSettingsUsage = "Использование:\n" +
+	"/modsettings — текущие настройки и модераторы\n" +
+	"/modsettings voteban_window|voteban_kick|mute|cleanup|cleanup_max <длительность> (mute 0 — бессрочно)\n" +
+	"/modsettings reset — вернуть настройки по умолчанию\n" +
+	"/modsettings mod @user mute,ban,cleanup|all — выдать полномочия (или reply)\n" +
+	"/modsettings unmod @user — снять модератора (или reply)"
+
+// handleModSettingsCommand — настройки модерации чата. Менять может только
+// полный модератор (canModerate): волонтёры не раздают права сами себе.
+func (b *TelegramBot) handleModSettingsCommand(message *tgbotapi.Message) {
+	if message.Chat.Type != "group" && message.Chat.Type != "supergroup" {
+		return
+	}
+	if !b.canModerate(message.Chat.ID, message.From.ID) {
+		return
+	}
+	args := commandArgs(message)
+	if len(args) == 0 {
+		b.replyModSettings(message)
+		return
+	}
+
+	key := strings.ToLower(args[0])
+	switch key {
+	case "reset":
+		if err := b.moderationService.ResetChatSettings(message.Chat.ID, message.From.ID); err != nil {
+			log.Printf("/modsettings reset: %v", err)
+			b.replyAndAutoDelete(message, b.tr(message.From.ID, "Не удалось сбросить настройки."))
+			return
+		}
+		b.replyAndAutoDelete(message, b.tr(message.From.ID, "Настройки модерации сброшены на значения по умолчанию."))
+	case "mod":
+		b.handleModSettingsSetModerator(message, args[1:])
+	case "unmod":
+		b.handleModSettingsRemoveModerator(message, args[1:])
+	default:
+		field, ok := modSettingKeys[key]
+		if !ok || len(args) < 2 {
+			b.replyAndAutoDelete(message, b.tr(message.From.ID, modSettingsUsage))
+			return
+		}
+		var d time.Duration
+		if args[1] != "0" {
+			parsed, err := service.ParseHumanDuration(args[1])
+			if err != nil {
+				b.replyAndAutoDelete(message, b.tr(message.From.ID, "Не понял длительность: %s. Примеры: 30m, 1h, 1d.", b.trErr(message.From.ID, err)))
+				return
+			}
+			d = parsed
+		}
+		st := b.chatSettings(message.Chat.ID)
+		*field(st) = int(d.Seconds())
+		if err := b.moderationService.SaveChatSettings(st, message.From.ID); err != nil {
+			b.replyAndAutoDelete(message, b.tr(message.From.ID, "Не сохранено: %s", b.trErr(message.From.ID, err)))
+			return
+		}
+		b.replyAndAutoDelete(message, b.tr(message.From.ID, "Готово: %s = %s.", key, formatSettingDuration(*field(st))))
+	}
+}
+
+func (b *TelegramBot) replyModSettings(message *tgbotapi.Message) {
+	st := b.chatSettings(message.Chat.ID)
+	lang := b.langOf(message.From.ID)
+	text := i18n.T(lang,
+		"⚙️ <b>Модерация в этом чате</b>\n\n"+
+			"voteban_window — окно голосования: %s\n"+
+			"voteban_kick — кик по голосованию: %s\n"+
+			"mute — /mute без аргумента: %s\n"+
+			"cleanup — /cleanup без периода: %s\n"+
+			"cleanup_max — максимальный период /cleanup: %s",
+		formatSettingDuration(st.VotebanWindowSeconds),
+		formatSettingDuration(st.VotebanKickSeconds),
+		formatSettingDuration(st.MuteDefaultSeconds),
+		formatSettingDuration(st.CleanupDefaultSeconds),
+		formatSettingDuration(st.CleanupMaxSeconds),
+	)
+
+	mods, err := b.moderationService.ListChatModerators(message.Chat.ID)
+	if err != nil {
+		log.Printf("/modsettings: list moderators: %v", err)
+	}
+	if len(mods) > 0 {
+		text += "\n\n<b>" + i18n.T(lang, "Модераторы-волонтёры:") + "</b>"
+		for _, m := range mods {
+			name := fmt.Sprintf("id=%d", m.UserID)
+			if m.Username != "" {
+				name = "@" + m.Username
+			}
+			text += fmt.Sprintf("\n• %s — %s", html.EscapeString(name), formatModeratorPowers(lang, &m))
+		}
+	}
+	b.sendChatHTML(message.Chat.ID, text)
+	b.tryDelete(message.Chat.ID, message.MessageID)
+}
+
+// modSettingsTarget — цель /modsettings mod|unmod: reply или @user/id первым аргументом.
+// Возвращает оставшиеся аргументы.
+func (b *TelegramBot) modSettingsTarget(message *tgbotapi.Message, args []string) (int64, string, []string, bool) {
+	if message.ReplyToMessage != nil && message.ReplyToMessage.From != nil && !message.ReplyToMessage.From.IsBot {
+		return message.ReplyToMessage.From.ID, message.ReplyToMessage.From.UserName, args, true
+	}
+	if len(args) == 0 {
+		return 0, "", nil, false
+	}
+	id, _, ok := b.parseTargetFromArg(message.Chat.ID, args[0])
+	if !ok {
+		return 0, "", nil, false
+	}
+	username := ""
+	if strings.HasPrefix(args[0], "@") {
+		username = strings.TrimPrefix(args[0], "@")
+	}
+	return id, username, args[1:], true
+}
+
+func (b *TelegramBot) handleModSettingsSetModerator(message *tgbotapi.Message, args []string) {
+	userID, username, rest, ok := b.modSettingsTarget(message, args)
+	if !ok || len(rest) == 0 {
+		b.replyAndAutoDelete(message, b.tr(message.From.ID, modSettingsUsage))
+		return
+	}
+	mute, ban, cleanup, err := service.ParseModeratorPowers(strings.Join(rest, ","))
+	if err != nil {
+		b.replyAndAutoDelete(message, b.trErr(message.From.ID, err))
+		return
+	}
+	m := &models.ChatModerator{
+		ChatID:     message.Chat.ID,
+		UserID:     userID,
+		Username:   username,
+		CanMute:    mute,
+		CanBan:     ban,
+		CanCleanup: cleanup,
+	}
+	if err := b.moderationService.SetChatModerator(m, message.From.ID); err != nil {
+		log.Printf("/modsettings mod: %v", err)
+		b.replyAndAutoDelete(message, b.tr(message.From.ID, "Не удалось назначить модератора."))
+		return
+	}
+	target := &tgbotapi.User{ID: userID, UserName: username}
+	b.sendChatHTML(message.Chat.ID, fmt.Sprintf("🛡 %s — модератор чата: %s.", targetDisplay(target), formatModeratorPowers(i18n.Default, m)))
+	b.tryDelete(message.Chat.ID, message.MessageID)
+}
+
+func (b *TelegramBot) handleModSettingsRemoveModerator(message *tgbotapi.Message, args []string) {
+	userID, username, _, ok := b.modSettingsTarget(message, args)
+	if !ok {
+		b.replyAndAutoDelete(message, b.tr(message.From.ID, modSettingsUsage))
+		return
+	}
+	removed, err := b.moderationService.RemoveChatModerator(message.Chat.ID, userID, message.From.ID)
+	if err != nil {
+		log.Printf("/modsettings unmod: %v", err)
+		b.replyAndAutoDelete(message, b.tr(message.From.ID, "Не удалось снять модератора."))
+		return
+	}
+	if !removed {
+		b.replyAndAutoDelete(message, b.tr(message.From.ID, "Этот пользователь не модератор чата."))
+		return
+	}
+	target := &tgbotapi.User{ID: userID, UserName: username}
+	b.sendChatHTML(message.Chat.ID, fmt.Sprintf("%s больше не модератор чата.", targetDisplay(target)))
+	b.tryDelete(message.Chat.ID, message.MessageID)
+}
+
+// formatSettingDuration — секунды настройки человеческим языком; 0 — бессрочно.
+func formatSettingDuration(seconds int) string {
+	return service.FormatDurationHuman(time.Duration(seconds) * time.Second)
+}
+
+func formatModeratorPowers(lang i18n.Lang, m *models.ChatModerator) string {
+	var powers []string
+	if m.CanMute || m.CanBan {
+		powers = append(powers, i18n.T(lang, "мут"))
+	}
+	if m.CanBan {
+		powers = append(powers, i18n.T(lang, "бан"))
+	}
+	if m.CanCleanup {
+		powers = append(powers, i18n.T(lang, "чистка"))
+	}
+	return strings.Join(powers, ", ")
+}
@@ -0,0 +1,100 @@
+package bot
+
+import (
+	"fmt"
+	"html"
+	"log"
+	"strings"
+	"time"
+
+	"ithozyeva/config"
+	"ithozyeva/internal/models"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// rafflePollInterval — как часто бот ищет разыгранные, но не объявленные
+// розыгрыши. Бэкенд разыгрывает их раз в 5 минут.
+const rafflePollInterval = time.Minute
+
+// startRafflePoster публикует победителей ручных розыгрышей в основном
+// чате. Pull-based, как startDigestPoster: розыгрыш проводит бэкенд.
+func (b *TelegramBot) startRafflePoster() {
+	ticker := time.NewTicker(rafflePollInterval)
+	defer ticker.Stop()
+
+	b.postPendingRaffles()
+	for range ticker.C {
+		b.postPendingRaffles()
+	}
+}
+
+func (b *TelegramBot) postPendingRaffles() {
+	if !b.isLeader() || config.CFG.TelegramMainChatID == 0 {
+		return
+	}
+	raffles, err := b.raffleService.Unannounced(20)
+	if err != nil {
+		log.Printf("raffle-poster: load pending error: %v", err)
+		return
+	}
+	for i := range raffles {
+		b.postOneRaffle(&raffles[i])
+	}
+}
+
+// postOneRaffle — «mark first, then send»: лучше потерять объявление, чем
+// задублить его в чате.
+func (b *TelegramBot) postOneRaffle(r *models.Raffle) {
+	winners, err := b.raffleService.Winners(r.Id)
+	if err != nil {
+		log.Printf("raffle-poster: load winners error raffle=%d: %v", r.Id, err)
+		return
+	}
+	ok, err := b.raffleService.MarkAnnounced(r.Id)
+	if err != nil {
+		log.Printf("raffle-poster: mark announced error raffle=%d: %v", r.Id, err)
+		return
+	}
+	if !ok {
+		return
+	}
+
+	chatID := config.CFG.TelegramMainChatID
+	msg := tgbotapi.NewMessage(chatID, formatRaffleWinnersMessage(r, winners, platformBaseURL()))
+	msg.ParseMode = "HTML"
+	msg.DisableWebPagePreview = true
+	if _, err := b.bot.Send(msg); err != nil {
+		log.Printf("raffle-poster: send error raffle=%d chat=%d: %v", r.Id, chatID, err)
+		return
+	}
+	log.Printf("raffle-poster: announced raffle=%d (%d winners)", r.Id, len(winners))
+}
+
+// formatRaffleWinnersMessage — победители по призам в порядке жеребьёвки и
+// раскрытый seed, по которому её можно проверить.
+func formatRaffleWinnersMessage(r *models.Raffle, winners []models.RaffleWinner, baseURL string) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("🎉 <b>Итоги розыгрыша «%s»</b>\n", html.EscapeString(r.Title)))
+
+	prize := ""
+	for _, w := range winners {
+		if w.PrizeTitle != prize {
+			prize = w.PrizeTitle
+			sb.WriteString(fmt.Sprintf("\n🎁 <b>%s</b>\n", html.EscapeString(prize)))
+		}
+		name := strings.TrimSpace(w.MemberFirstName + " " + w.MemberLastName)
+		if w.MemberUsername != "" {
+			name = "@" + w.MemberUsername
+		}
+		sb.WriteString(fmt.Sprintf("%d. %s\n", w.Place, html.EscapeString(name)))
+	}
+
+	if r.ServerSeed != "" {
+		sb.WriteString(fmt.Sprintf("\nSeed жеребьёвки: <code>%s</code>", r.ServerSeed))
+	}
+	if baseURL != "" {
+		sb.WriteString(fmt.Sprintf("\n<a href=\"%s/raffles\">Проверить жеребьёвку</a>", baseURL))
+	}
+	return sb.String()
+}
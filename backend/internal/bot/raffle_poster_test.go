@@ -0,0 +1,32 @@
+package bot
+
+import (
+	"strings"
+	"testing"
+
+	"ithozyeva/internal/models"
+)
+
+func TestFormatRaffleWinnersMessage(t *testing.T) {
+	r := &models.Raffle{Title: "Мерч <летний>", ServerSeed: "abc123"}
+	winners := []models.RaffleWinner{
+		{Place: 1, PrizeTitle: "Худи", MemberUsername: "alice"},
+		{Place: 2, PrizeTitle: "Стикеры", MemberFirstName: "Боб", MemberLastName: "<3"},
+		{Place: 3, PrizeTitle: "Стикеры", MemberUsername: "carol"},
+	}
+	got := formatRaffleWinnersMessage(r, winners, "https://example.org")
+	for _, want := range []string{
+		"«Мерч &lt;летний&gt;»",
+		"🎁 <b>Худи</b>\n1. @alice",
+		"🎁 <b>Стикеры</b>\n2. Боб &lt;3\n3. @carol",
+		"<code>abc123</code>",
+		`<a href="https://example.org/raffles">`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("missing %q in:\n%s", want, got)
+		}
+	}
+	if strings.Count(got, "Стикеры") != 1 {
+		t.Errorf("prize header repeated:\n%s", got)
+	}
+}
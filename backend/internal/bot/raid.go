@@ -0,0 +1,565 @@
+package bot
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"ithozyeva/internal/models"
+	"ithozyeva/internal/service"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// Параметры детектора рейдов.
+const (
+	// Волна: raidJoinThreshold вступлений в один чат за raidJoinWindow.
+	// Обычный приток в наши чаты — единицы в час, 10 за минуту — это
+	// почти всегда спам-волна (или массовый переход по утёкшей ссылке).
+	raidJoinThreshold = 10
+	raidJoinWindow    = time.Minute
+
+	// raidModeDuration — на сколько включается режим. Каждое вступление во
+	// время рейда продлевает его минимум на raidQuietExtension, так что режим
+	// сам гаснет только после паузы во вступлениях.
+	raidModeDuration   = 30 * time.Minute
+	raidQuietExtension = 10 * time.Minute
+
+	raidBanSleep = 50 * time.Millisecond // как в runGlobalBan
+)
+
+// raidJoinSample — вступление, попавшее в скользящее окно детектора.
+type raidJoinSample struct {
+	UserID    int64
+	Username  string
+	FirstName string
+	At        time.Time
+}
+
+// recordRaidJoin добавляет вступление в окно чата и возвращает накопленную
+// волну, если за raidJoinWindow набралось raidJoinThreshold вступлений.
+// Окно общее для реплик бота (Redis); волну получает ровно один вызов —
+// окно при этом очищается, и рейд не включится дважды.
+func (b *TelegramBot) recordRaidJoin(chatID int64, sample raidJoinSample) []raidJoinSample {
+	payload, err := json.Marshal(sample)
+	if err != nil {
+		return nil
+	}
+	raw, err := b.chatState.RecordRaidJoin(context.Background(), chatID, sample.UserID, string(payload),
+		sample.At, raidJoinWindow, raidJoinThreshold)
+	if err != nil {
+		log.Printf("raid: record join chat=%d: %v", chatID, err)
+		return nil
+	}
+	if len(raw) == 0 {
+		return nil
+	}
+	wave := make([]raidJoinSample, 0, len(raw))
+	for _, p := range raw {
+		var s raidJoinSample
+		if err := json.Unmarshal([]byte(p), &s); err == nil {
+			wave = append(wave, s)
+		}
+	}
+	return wave
+}
+
+// --- Detection ---
+
+// handleRaidJoin — вход в raid-детектор из handleChatMemberUpdated. Работает
+// по всем трекаемым чатам (не только подписочным): во время активного рейда
+// ограничивает вступившего, иначе копит волну и при превышении порога
+// включает режим.
+func (b *TelegramBot) handleRaidJoin(update *tgbotapi.ChatMemberUpdated) {
+	user := update.NewChatMember.User
+	if user == nil || user.IsBot {
+		return
+	}
+	chatID := update.Chat.ID
+	if !b.chatActivityService.IsTrackedChat(chatID) {
+		return
+	}
+
+	now := time.Now()
+	sample := raidJoinSample{
+		UserID:    user.ID,
+		Username:  user.UserName,
+		FirstName: user.FirstName,
+		At:        now,
+	}
+
+	raid, err := b.moderationService.FindActiveRaid(chatID)
+	if err != nil {
+		log.Printf("raid: find active chat=%d: %v", chatID, err)
+		return
+	}
+	if raid != nil {
+		b.addRaidJoin(raid, sample)
+		if err := b.moderationService.ExtendRaid(raid.Id, now.Add(raidQuietExtension)); err != nil {
+			log.Printf("raid: extend id=%d: %v", raid.Id, err)
+		}
+		return
+	}
+
+	wave := b.recordRaidJoin(chatID, sample)
+	if wave == nil {
+		return
+	}
+	log.Printf("raid: %d joins in %s in chat=%d — enabling raid mode", len(wave), raidJoinWindow, chatID)
+	if _, err := b.startRaid(chatID, update.Chat.Title, 0, wave); err != nil && !errors.Is(err, service.ErrRaidAlreadyActive) {
+		log.Printf("raid: start chat=%d: %v", chatID, err)
+	}
+}
+
+// startRaid включает режим рейда: запись в БД, ограничение уже вступивших из
+// волны, закрытие инвайтов и алерт модераторам. startedBy=0 — автодетект.
+func (b *TelegramBot) startRaid(chatID int64, chatTitle string, startedBy int64, wave []raidJoinSample) (*models.Raid, error) {
+	// Права читаем до включения, чтобы при выходе из рейда вернуть ровно их.
+	perms := b.currentChatPermissions(chatID)
+	saved := "{}"
+	if perms != nil {
+		if raw, err := json.Marshal(perms); err == nil {
+			saved = string(raw)
+		}
+	}
+
+	raid, err := b.moderationService.StartRaid(service.RaidStartParams{
+		ChatID:           chatID,
+		ChatTitle:        chatTitle,
+		TriggerJoins:     len(wave),
+		StartedBy:        startedBy,
+		SavedPermissions: saved,
+		Duration:         raidModeDuration,
+	})
+	if err != nil {
+		if errors.Is(err, service.ErrRaidAlreadyActive) && raid != nil {
+			for _, s := range wave {
+				b.addRaidJoin(raid, s)
+			}
+		}
+		return raid, err
+	}
+
+	invitesLocked := b.lockChatInvites(chatID, perms)
+	for _, s := range wave {
+		b.addRaidJoin(raid, s)
+	}
+
+	if err := b.moderationService.LogActionWithMeta(&models.ModerationAction{
+		ChatID:       chatID,
+		TargetUserID: 0,
+		ActorUserID:  startedBy,
+		Action:       models.ModerationActionRaidStart,
+	}, map[string]interface{}{
+		"raid_id":        raid.Id,
+		"trigger_joins":  len(wave),
+		"auto":           startedBy == 0,
+		"invites_locked": invitesLocked,
+	}); err != nil {
+		log.Printf("raid: log start failed: %v", err)
+	}
+
+	b.alertRaidStarted(raid, len(wave), invitesLocked)
+	return raid, nil
+}
+
+// addRaidJoin ограничивает вступившего (мут без срока) и записывает его в
+// окно рейда. Ошибку Telegram только логируем — запись всё равно нужна для
+// отката баном.
+func (b *TelegramBot) addRaidJoin(raid *models.Raid, s raidJoinSample) {
+	restricted := true
+	if err := b.muteUserInChat(raid.ChatID, s.UserID, 0); err != nil {
+		restricted = false
+		log.Printf("raid: restrict chat=%d user=%d: %v", raid.ChatID, s.UserID, err)
+	}
+	if err := b.moderationService.AddRaidJoin(&models.RaidJoin{
+		RaidID:     raid.Id,
+		UserID:     s.UserID,
+		Username:   s.Username,
+		FirstName:  s.FirstName,
+		JoinedAt:   s.At,
+		Restricted: restricted,
+	}); err != nil {
+		log.Printf("raid: save join raid=%d user=%d: %v", raid.Id, s.UserID, err)
+	}
+}
+
+// --- Invite links ---
+
+// currentChatPermissions — права участников чата по умолчанию (getChat).
+// nil, если Telegram не ответил.
+func (b *TelegramBot) currentChatPermissions(chatID int64) *tgbotapi.ChatPermissions {
+	chat, err := b.bot.GetChat(tgbotapi.ChatInfoConfig{ChatConfig: tgbotapi.ChatConfig{ChatID: chatID}})
+	if err != nil {
+		log.Printf("raid: getChat %d failed: %v", chatID, err)
+		return nil
+	}
+	return chat.Permissions
+}
+
+// lockChatInvites временно закрывает вход в чат: участникам запрещаем
+// приглашать (can_invite_users=false), а основную ссылку перевыпускаем —
+// exportChatInviteLink отзывает старую, которая могла утечь спамерам.
+// Одноразовые ссылки подписки (member_limit=1) не трогаем: они и так
+// не пускают волну.
+func (b *TelegramBot) lockChatInvites(chatID int64, current *tgbotapi.ChatPermissions) bool {
+	locked := restrictPermissionsAllow()
+	if current != nil {
+		cp := *current
+		locked = &cp
+	}
+	locked.CanInviteUsers = false
+
+	ok := true
+	if _, err := b.bot.Request(tgbotapi.SetChatPermissionsConfig{
+		ChatConfig:  tgbotapi.ChatConfig{ChatID: chatID},
+		Permissions: locked,
+	}); err != nil {
+		ok = false
+		log.Printf("raid: setChatPermissions chat=%d: %v", chatID, err)
+	}
+	if _, err := b.bot.Request(tgbotapi.ChatInviteLinkConfig{
+		ChatConfig: tgbotapi.ChatConfig{ChatID: chatID},
+	}); err != nil {
+		ok = false
+		log.Printf("raid: exportChatInviteLink chat=%d: %v", chatID, err)
+	}
+	return ok
+}
+
+// unlockChatInvites возвращает права участников, сохранённые при включении
+// рейда. Основная ссылка остаётся новой — старую возвращать нельзя и незачем.
+func (b *TelegramBot) unlockChatInvites(raid *models.Raid) {
+	perms := restrictPermissionsAllow()
+	if raid.SavedPermissions != "" && raid.SavedPermissions != "{}" {
+		var saved tgbotapi.ChatPermissions
+		if err := json.Unmarshal([]byte(raid.SavedPermissions), &saved); err == nil {
+			perms = &saved
+		}
+	}
+	if _, err := b.bot.Request(tgbotapi.SetChatPermissionsConfig{
+		ChatConfig:  tgbotapi.ChatConfig{ChatID: raid.ChatID},
+		Permissions: perms,
+	}); err != nil {
+		log.Printf("raid: restore permissions chat=%d: %v", raid.ChatID, err)
+	}
+}
+
+// --- Alerts ---
+
+func raidKeyboard(raidID int64) tgbotapi.InlineKeyboardMarkup {
+	return tgbotapi.NewInlineKeyboardMarkup(
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData("🔨 Забанить всех", fmt.Sprintf("raid:%d:rollback", raidID)),
+			tgbotapi.NewInlineKeyboardButtonData("✅ Ложная тревога", fmt.Sprintf("raid:%d:release", raidID)),
+		),
+	)
+}
+
+// alertRaidStarted пишет в чат (с кнопками для модераторов) и в ЛС
+// super-admin'у — тот видит рейд, даже если сам в этом чате не сидит.
+func (b *TelegramBot) alertRaidStarted(raid *models.Raid, joins int, invitesLocked bool) {
+	reason := fmt.Sprintf("за последнюю минуту вступило %d аккаунтов", joins)
+	if raid.StartedBy != 0 {
+		reason = "включён модератором"
+	}
+	invites := "Инвайт-ссылка перевыпущена, участникам временно запрещено приглашать."
+	if !invitesLocked {
+		invites = "<i>Не удалось закрыть инвайты — проверьте права бота (приглашение и изменение прав).</i>"
+	}
+	text := fmt.Sprintf(
+		"🚨 <b>Режим рейда</b> — %s.\n\n"+
+			"Новые участники ограничены (не могут писать) до решения модератора. %s\n"+
+			"Режим выключится сам после %s без вступлений.\n\n"+
+			"Модераторам: /raid rollback — забанить всех вступивших в окно, /raid off — снять ограничения.",
+		reason, invites, service.FormatDurationHuman(raidQuietExtension))
+
+	msg := tgbotapi.NewMessage(raid.ChatID, text)
+	msg.ParseMode = "HTML"
+	msg.DisableWebPagePreview = true
+	msg.ReplyMarkup = raidKeyboard(raid.Id)
+	if _, err := b.bot.Send(msg); err != nil {
+		log.Printf("raid: send alert chat=%d: %v", raid.ChatID, err)
+	}
+
+	title := raid.ChatTitle
+	if title == "" {
+		title = fmt.Sprintf("chat %d", raid.ChatID)
+	}
+	b.SendDirectMessage(subscriptionAdminID(), fmt.Sprintf(
+		"🚨 Рейд в <b>%s</b> (<code>%d</code>): %s.\nRaid id: %d",
+		html.EscapeString(title), raid.ChatID, reason, raid.Id))
+}
+
+// --- /raid ---
+
+// handleRaidCommand: модераторская команда управления режимом рейда.
+//
+//	/raid            — статус
+//	/raid on         — включить вручную
+//	/raid off        — выключить и снять ограничения (ложная тревога)
+//	/raid rollback   — забанить всех вступивших в окно последнего рейда
+func (b *TelegramBot) handleRaidCommand(message *tgbotapi.Message) {
+	if message.Chat.Type != "group" && message.Chat.Type != "supergroup" {
+		return
+	}
+	if !b.hasModPower(message.Chat.ID, message.From.ID, models.ModeratorPowerBan) {
+		return
+	}
+	args := commandArgs(message)
+	sub := ""
+	if len(args) > 0 {
+		sub = strings.ToLower(args[0])
+	}
+
+	switch sub {
+	case "", "status":
+		b.replyRaidStatus(message)
+	case "on":
+		if _, err := b.startRaid(message.Chat.ID, message.Chat.Title, message.From.ID, nil); err != nil {
+			if errors.Is(err, service.ErrRaidAlreadyActive) {
+				b.replyAndAutoDelete(message, b.tr(message.From.ID, "Режим рейда уже включён."))
+				return
+			}
+			log.Printf("/raid on: %v", err)
+			b.replyAndAutoDelete(message, b.tr(message.From.ID, "Не удалось включить режим рейда."))
+			return
+		}
+		b.tryDelete(message.Chat.ID, message.MessageID)
+	case "off", "rollback":
+		raid, err := b.moderationService.FindLatestUnresolvedRaid(message.Chat.ID)
+		if err != nil {
+			log.Printf("/raid %s: %v", sub, err)
+			b.replyAndAutoDelete(message, b.tr(message.From.ID, "Ошибка при поиске рейда."))
+			return
+		}
+		if raid == nil {
+			b.replyAndAutoDelete(message, b.tr(message.From.ID, "Нет рейда, по которому нужно решение."))
+			return
+		}
+		b.tryDelete(message.Chat.ID, message.MessageID)
+		if sub == "off" {
+			go b.releaseRaid(raid, message.From.ID)
+		} else {
+			go b.rollbackRaid(raid, message.From.ID)
+		}
+	default:
+		b.replyAndAutoDelete(message, b.tr(message.From.ID, "Использование: /raid [on|off|rollback]"))
+	}
+}
+
+func (b *TelegramBot) replyRaidStatus(message *tgbotapi.Message) {
+	raid, err := b.moderationService.FindLatestUnresolvedRaid(message.Chat.ID)
+	if err != nil {
+		log.Printf("/raid status: %v", err)
+		return
+	}
+	if raid == nil {
+		b.replyAndAutoDelete(message, b.tr(message.From.ID, "Режим рейда выключен."))
+		return
+	}
+	joins, _ := b.moderationService.ListRaidJoins(raid.Id)
+	if raid.Status == models.RaidStatusActive {
+		b.replyAndAutoDelete(message, b.tr(message.From.ID,
+			"Режим рейда включён (ещё %s). Вступивших в окно: %d. /raid rollback — забанить всех, /raid off — снять ограничения.",
+			formatRemainingHuman(time.Until(raid.ExpiresAt)), len(joins)))
+		return
+	}
+	b.replyAndAutoDelete(message, b.tr(message.From.ID,
+		"Режим рейда истёк, но %d вступивших ещё ограничены. /raid rollback — забанить всех, /raid off — снять ограничения.",
+		len(joins)))
+}
+
+// handleRaidCallback обрабатывает кнопки под алертом рейда: raid:{id}:rollback|release.
+func (b *TelegramBot) handleRaidCallback(callback *tgbotapi.CallbackQuery) {
+	parts := strings.Split(callback.Data, ":")
+	if len(parts) != 3 {
+		return
+	}
+	raidID, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return
+	}
+	raid, err := b.moderationService.GetRaid(raidID)
+	if err != nil || raid == nil {
+		b.answerCallbackQuery(callback.ID, b.tr(callback.From.ID, "Рейд не найден."))
+		return
+	}
+	if !b.hasModPower(raid.ChatID, callback.From.ID, models.ModeratorPowerBan) {
+		b.answerCallbackQuery(callback.ID, b.tr(callback.From.ID, "Только для модераторов."))
+		return
+	}
+	if raid.Status != models.RaidStatusActive && raid.Status != models.RaidStatusEnded {
+		b.answerCallbackQuery(callback.ID, b.tr(callback.From.ID, "По этому рейду уже принято решение."))
+		return
+	}
+
+	switch parts[2] {
+	case "rollback":
+		b.answerCallbackQuery(callback.ID, b.tr(callback.From.ID, "Баню всех вступивших…"))
+		go b.rollbackRaid(raid, callback.From.ID)
+	case "release":
+		b.answerCallbackQuery(callback.ID, b.tr(callback.From.ID, "Снимаю ограничения…"))
+		go b.releaseRaid(raid, callback.From.ID)
+	}
+}
+
+// --- Resolution ---
+
+// finishRaid атомарно переводит рейд в финальный статус и, если режим ещё
+// был включён, возвращает инвайты. false — решение уже принял кто-то другой.
+func (b *TelegramBot) finishRaid(raid *models.Raid, to string, actorID int64) bool {
+	wasActive := raid.Status == models.RaidStatusActive
+	ok, err := b.moderationService.TransitionRaid(raid.Id,
+		[]string{models.RaidStatusActive, models.RaidStatusEnded}, to, &actorID)
+	if err != nil {
+		log.Printf("raid: transition id=%d → %s: %v", raid.Id, to, err)
+		return false
+	}
+	if !ok {
+		return false
+	}
+	if wasActive {
+		b.unlockChatInvites(raid)
+	}
+	return true
+}
+
+// rollbackRaid банит всех, кто вступил в окно рейда (с удалением их
+// сообщений), и выключает режим. Каждый бан пишется в журнал отдельной
+// записью — админка сможет снять его точечно.
+func (b *TelegramBot) rollbackRaid(raid *models.Raid, actorID int64) {
+	if !b.finishRaid(raid, models.RaidStatusRolledBack, actorID) {
+		return
+	}
+	joins, err := b.moderationService.ListRaidJoins(raid.Id)
+	if err != nil {
+		log.Printf("raid rollback: list joins id=%d: %v", raid.Id, err)
+		return
+	}
+
+	banned, failed := 0, 0
+	for _, j := range joins {
+		if j.Banned {
+			continue
+		}
+		if _, err := b.bot.Request(tgbotapi.BanChatMemberConfig{
+			ChatMemberConfig: tgbotapi.ChatMemberConfig{ChatID: raid.ChatID, UserID: j.UserID},
+			RevokeMessages:   true,
+		}); err != nil {
+			failed++
+			log.Printf("raid rollback: ban chat=%d user=%d: %v", raid.ChatID, j.UserID, err)
+			continue
+		}
+		banned++
+		if err := b.moderationService.MarkRaidJoinBanned(raid.Id, j.UserID); err != nil {
+			log.Printf("raid rollback: mark banned raid=%d user=%d: %v", raid.Id, j.UserID, err)
+		}
+		_ = b.moderationService.LogActionWithMeta(&models.ModerationAction{
+			ChatID:       raid.ChatID,
+			TargetUserID: j.UserID,
+			ActorUserID:  actorID,
+			Action:       models.ModerationActionBan,
+		}, map[string]interface{}{"raid_id": raid.Id})
+		time.Sleep(raidBanSleep)
+	}
+
+	_ = b.moderationService.LogActionWithMeta(&models.ModerationAction{
+		ChatID:      raid.ChatID,
+		ActorUserID: actorID,
+		Action:      models.ModerationActionRaidEnd,
+	}, map[string]interface{}{
+		"raid_id":    raid.Id,
+		"resolution": models.RaidStatusRolledBack,
+		"joins":      len(joins),
+		"banned":     banned,
+		"failed":     failed,
+	})
+
+	summary := fmt.Sprintf("🔨 Рейд откатан: забанено %d из %d вступивших в окно.", banned, len(joins))
+	if failed > 0 {
+		summary += fmt.Sprintf(" Не удалось: %d.", failed)
+	}
+	b.sendChatHTML(raid.ChatID, summary)
+}
+
+// releaseRaid — ложная тревога: выключаем режим и снимаем ограничения со
+// всех вступивших в окно.
+func (b *TelegramBot) releaseRaid(raid *models.Raid, actorID int64) {
+	if !b.finishRaid(raid, models.RaidStatusReleased, actorID) {
+		return
+	}
+	joins, err := b.moderationService.ListRaidJoins(raid.Id)
+	if err != nil {
+		log.Printf("raid release: list joins id=%d: %v", raid.Id, err)
+		return
+	}
+
+	released := 0
+	for _, j := range joins {
+		if j.Banned || !j.Restricted {
+			continue
+		}
+		if _, err := b.bot.Request(tgbotapi.RestrictChatMemberConfig{
+			ChatMemberConfig: tgbotapi.ChatMemberConfig{ChatID: raid.ChatID, UserID: j.UserID},
+			Permissions:      restrictPermissionsAllow(),
+		}); err != nil {
+			log.Printf("raid release: unrestrict chat=%d user=%d: %v", raid.ChatID, j.UserID, err)
+			continue
+		}
+		released++
+		time.Sleep(raidBanSleep)
+	}
+
+	_ = b.moderationService.LogActionWithMeta(&models.ModerationAction{
+		ChatID:      raid.ChatID,
+		ActorUserID: actorID,
+		Action:      models.ModerationActionRaidEnd,
+	}, map[string]interface{}{
+		"raid_id":    raid.Id,
+		"resolution": models.RaidStatusReleased,
+		"joins":      len(joins),
+		"released":   released,
+	})
+	b.sendChatHTML(raid.ChatID, fmt.Sprintf("✅ Режим рейда выключен, ограничения сняты (%d).", released))
+}
+
+// expireRaid вызывается watcher'ом: окно без вступлений истекло. Режим
+// выключаем и инвайты возвращаем, но вступивших оставляем ограниченными —
+// решение (бан или снятие) за модератором.
+func (b *TelegramBot) expireRaid(raid *models.Raid) {
+	ok, err := b.moderationService.TransitionRaid(raid.Id,
+		[]string{models.RaidStatusActive}, models.RaidStatusEnded, nil)
+	if err != nil {
+		log.Printf("raid-watcher: transition id=%d: %v", raid.Id, err)
+		return
+	}
+	if !ok {
+		return
+	}
+	b.unlockChatInvites(raid)
+
+	joins, _ := b.moderationService.ListRaidJoins(raid.Id)
+	_ = b.moderationService.LogActionWithMeta(&models.ModerationAction{
+		ChatID: raid.ChatID,
+		Action: models.ModerationActionRaidEnd,
+	}, map[string]interface{}{
+		"raid_id":    raid.Id,
+		"resolution": models.RaidStatusEnded,
+		"joins":      len(joins),
+	})
+
+	msg := tgbotapi.NewMessage(raid.ChatID, fmt.Sprintf(
+		"⏰ Волна вступлений закончилась — режим рейда выключен, инвайты снова открыты.\n"+
+			"%d вступивших в окно остаются ограничены до решения модератора.", len(joins)))
+	msg.ReplyMarkup = raidKeyboard(raid.Id)
+	if _, err := b.bot.Send(msg); err != nil {
+		log.Printf("raid-watcher: send expiry notice chat=%d: %v", raid.ChatID, err)
+	}
+}
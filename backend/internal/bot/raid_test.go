@@ -0,0 +1,88 @@
+package bot
+
+import (
+	"testing"
+	"time"
+
+	"ithozyeva/internal/testutil"
+)
+
+func TestRecordRaidJoinTriggersOnce(t *testing.T) {
+	_, rdb := testutil.NewMiniRedis(t)
+	b := stateBot(rdb)
+	base := time.Now()
+	for i := 0; i < raidJoinThreshold-1; i++ {
+		if wave := b.recordRaidJoin(1, raidJoinSample{UserID: int64(i + 1), At: base}); wave != nil {
+			t.Fatalf("join %d: unexpected wave of %d", i+1, len(wave))
+		}
+	}
+	wave := b.recordRaidJoin(1, raidJoinSample{UserID: raidJoinThreshold, Username: "last", At: base.Add(time.Second)})
+	if len(wave) != raidJoinThreshold {
+		t.Fatalf("wave = %d, want %d", len(wave), raidJoinThreshold)
+	}
+	found := false
+	for _, s := range wave {
+		found = found || (s.UserID == raidJoinThreshold && s.Username == "last")
+	}
+	if !found {
+		t.Fatalf("wave lost join data: %+v", wave)
+	}
+	// Окно очищено: следующее вступление начинает новую волну.
+	if wave := b.recordRaidJoin(1, raidJoinSample{UserID: 100, At: base.Add(2 * time.Second)}); wave != nil {
+		t.Fatalf("window not reset after trigger: got wave of %d", len(wave))
+	}
+}
+
+func TestRecordRaidJoinWindow(t *testing.T) {
+	_, rdb := testutil.NewMiniRedis(t)
+	b := stateBot(rdb)
+	base := time.Now()
+	b.recordRaidJoin(1, raidJoinSample{UserID: 1, At: base})
+	for i := 2; i < raidJoinThreshold; i++ {
+		b.recordRaidJoin(1, raidJoinSample{UserID: int64(i), At: base.Add(raidJoinWindow / 2)})
+	}
+	// Первое вступление выпало из окна — порог не набран.
+	late := base.Add(raidJoinWindow + time.Second)
+	if wave := b.recordRaidJoin(1, raidJoinSample{UserID: raidJoinThreshold, At: late}); wave != nil {
+		t.Fatalf("stale join counted: wave of %d", len(wave))
+	}
+}
+
+func TestRecordRaidJoinDedupAndChats(t *testing.T) {
+	_, rdb := testutil.NewMiniRedis(t)
+	b := stateBot(rdb)
+	base := time.Now()
+	// Повторный вход того же юзера (leave/join) не накручивает счётчик.
+	for i := 0; i < raidJoinThreshold+2; i++ {
+		if wave := b.recordRaidJoin(1, raidJoinSample{UserID: 42, At: base.Add(time.Duration(i) * time.Second)}); wave != nil {
+			t.Fatalf("rejoin counted as wave")
+		}
+	}
+	// Чаты считаются независимо.
+	for i := 1; i < raidJoinThreshold; i++ {
+		b.recordRaidJoin(1, raidJoinSample{UserID: int64(i), At: base})
+	}
+	if wave := b.recordRaidJoin(2, raidJoinSample{UserID: 1, At: base}); wave != nil {
+		t.Fatalf("joins leaked across chats")
+	}
+}
+
+// Вступления одного чата, пришедшие на разные реплики, копятся в одной
+// волне, и рейд включает ровно одна из них.
+func TestRecordRaidJoinAcrossReplicas(t *testing.T) {
+	_, rdb := testutil.NewMiniRedis(t)
+	replicas := []*TelegramBot{stateBot(rdb), stateBot(rdb)}
+	base := time.Now()
+	waves := 0
+	for i := 0; i < raidJoinThreshold; i++ {
+		if wave := replicas[i%2].recordRaidJoin(1, raidJoinSample{UserID: int64(i + 1), At: base}); wave != nil {
+			waves++
+			if len(wave) != raidJoinThreshold {
+				t.Fatalf("wave = %d, want %d", len(wave), raidJoinThreshold)
+			}
+		}
+	}
+	if waves != 1 {
+		t.Fatalf("waves = %d, want 1", waves)
+	}
+}
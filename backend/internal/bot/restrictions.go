@@ -0,0 +1,377 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"log"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"ithozyeva/internal/i18n"
+	"ithozyeva/internal/models"
+	"ithozyeva/internal/service"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+const (
+	// restrictionPolicyTTL — сколько бот держит политику чата в памяти.
+	// Проверка идёт на каждое сообщение, поэтому в БД не ходим; правки из
+	// админки доходят до бота за эту минуту, /chatpolicy сбрасывает кеш сразу.
+	restrictionPolicyTTL = time.Minute
+
+	// restrictionNotifyCooldown — не чаще одного ЛС на юзера, чат и причину:
+	// флудер с rate limit иначе получил бы по сообщению на каждое удаление.
+	restrictionNotifyCooldown = 10 * time.Minute
+)
+
+// Причины удаления сообщения по политике чата.
+const (
+	restrictionLinks     = "links"
+	restrictionMedia     = "media"
+	restrictionForwards  = "forwards"
+	restrictionRateLimit = "rate"
+)
+
+type cachedRestrictionPolicy struct {
+	policy    *models.ChatRestrictionPolicy
+	fetchedAt time.Time
+}
+
+// chatRestrictions — кеш политик в памяти бота. Окна rate limit и
+// троттлинг уведомлений общие для реплик и живут в Redis
+// (service.BotChatStateService).
+type chatRestrictions struct {
+	mu       sync.Mutex
+	policies map[int64]cachedRestrictionPolicy
+}
+
+func newChatRestrictions() *chatRestrictions {
+	return &chatRestrictions{policies: make(map[int64]cachedRestrictionPolicy)}
+}
+
+func (r *chatRestrictions) cachedPolicy(chatID int64, now time.Time) (*models.ChatRestrictionPolicy, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	c, ok := r.policies[chatID]
+	if !ok || now.Sub(c.fetchedAt) > restrictionPolicyTTL {
+		return nil, false
+	}
+	return c.policy, true
+}
+
+func (r *chatRestrictions) storePolicy(chatID int64, p *models.ChatRestrictionPolicy, now time.Time) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.policies[chatID] = cachedRestrictionPolicy{policy: p, fetchedAt: now}
+}
+
+func (r *chatRestrictions) invalidate(chatID int64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.policies, chatID)
+}
+
+// messageHasLink — ссылка в тексте или подписи (Telegram сам размечает
+// url / text_link entity).
+func messageHasLink(m *tgbotapi.Message) bool {
+	for _, list := range [][]tgbotapi.MessageEntity{m.Entities, m.CaptionEntities} {
+		for _, e := range list {
+			if e.Type == "url" || e.Type == "text_link" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// messageHasMedia — фото, видео, файлы, голосовые. Стикеры не считаем:
+// на них новичков не ограничиваем.
+func messageHasMedia(m *tgbotapi.Message) bool {
+	return len(m.Photo) > 0 || m.Video != nil || m.Animation != nil || m.Document != nil ||
+		m.Audio != nil || m.Voice != nil || m.VideoNote != nil
+}
+
+func messageIsForward(m *tgbotapi.Message) bool {
+	return m.ForwardDate != 0 || m.ForwardFrom != nil || m.ForwardFromChat != nil || m.ForwardSenderName != ""
+}
+
+// newMemberViolation — какое из запретов для новичков нарушает сообщение; "" — никакое.
+func newMemberViolation(p *models.ChatRestrictionPolicy, m *tgbotapi.Message) string {
+	switch {
+	case p.BlockForwards && messageIsForward(m):
+		return restrictionForwards
+	case p.BlockLinks && messageHasLink(m):
+		return restrictionLinks
+	case p.BlockMedia && messageHasMedia(m):
+		return restrictionMedia
+	}
+	return ""
+}
+
+// restrictionPolicy — политика чата из кеша или БД. При ошибке БД
+// ограничения не применяем: лучше пропустить спам, чем удалять всё подряд.
+func (b *TelegramBot) restrictionPolicy(chatID int64) *models.ChatRestrictionPolicy {
+	now := time.Now()
+	if p, ok := b.restrictions.cachedPolicy(chatID, now); ok {
+		return p
+	}
+	p, err := b.moderationService.GetRestrictionPolicy(chatID)
+	if err != nil {
+		log.Printf("restrictions: policy chat=%d: %v", chatID, err)
+		return nil
+	}
+	b.restrictions.storePolicy(chatID, p, now)
+	return p
+}
+
+// enforceChatRestrictions проверяет сообщение по политике трекаемого чата.
+// Нарушение — сообщение удаляется, автору уходит ЛС. true — сообщение
+// удалено и дальше не обрабатывается.
+func (b *TelegramBot) enforceChatRestrictions(message *tgbotapi.Message) bool {
+	if message.Chat.Type != "group" && message.Chat.Type != "supergroup" {
+		return false
+	}
+	if message.From == nil || message.From.IsBot || message.SenderChat != nil {
+		return false
+	}
+	if message.NewChatMembers != nil || message.LeftChatMember != nil {
+		return false
+	}
+	chatID, userID := message.Chat.ID, message.From.ID
+	if !b.chatActivityService.IsTrackedChat(chatID) {
+		return false
+	}
+	p := b.restrictionPolicy(chatID)
+	if p == nil || !p.Enabled() {
+		return false
+	}
+
+	now := time.Now()
+	reason := ""
+	if p.NewMemberDays > 0 {
+		if v := newMemberViolation(p, message); v != "" {
+			isNew, err := b.moderationService.IsNewMember(chatID, userID, p.NewMemberDays, now)
+			if err != nil {
+				log.Printf("restrictions: join lookup chat=%d user=%d: %v", chatID, userID, err)
+			}
+			if isNew {
+				reason = v
+			}
+		}
+	}
+	if reason == "" && p.RateLimitMessages > 0 {
+		window := time.Duration(p.RateLimitWindowSeconds) * time.Second
+		// Ошибка Redis — rate limit не применяем, как и при ошибке БД.
+		count, err := b.chatState.RecordChatMessage(context.Background(), chatID, userID, message.MessageID, now, window)
+		if err != nil {
+			log.Printf("restrictions: rate window chat=%d user=%d: %v", chatID, userID, err)
+		} else if count > int64(p.RateLimitMessages) {
+			reason = restrictionRateLimit
+		}
+	}
+	if reason == "" || b.isRestrictionExempt(p, chatID, userID) {
+		return false
+	}
+
+	b.tryDelete(chatID, message.MessageID)
+	notify, err := b.chatState.TakeRestrictionNotice(context.Background(), chatID, userID, reason, restrictionNotifyCooldown)
+	if err != nil {
+		log.Printf("restrictions: notice cooldown chat=%d user=%d: %v", chatID, userID, err)
+	}
+	if notify {
+		b.SendDirectMessage(userID, formatRestrictionNotice(b.langOf(userID), p, reason, message.Chat.Title))
+	}
+	return true
+}
+
+// isRestrictionExempt — модераторы чата и подписчики с тиром не ниже
+// exempt_tier_level. Вызывается только при нарушении: isChatAdmin — это
+// запрос в Telegram.
+func (b *TelegramBot) isRestrictionExempt(p *models.ChatRestrictionPolicy, chatID, userID int64) bool {
+	if p.ExemptTierLevel > 0 {
+		level, err := b.moderationService.GetSubscriberTierLevel(userID)
+		if err != nil {
+			log.Printf("restrictions: tier lookup user=%d: %v", userID, err)
+		} else if level >= p.ExemptTierLevel {
+			return true
+		}
+	}
+	return b.isModerationStaff(chatID, userID)
+}
+
+func formatRestrictionNotice(lang i18n.Lang, p *models.ChatRestrictionPolicy, reason, chatTitle string) string {
+	chat := i18n.T(lang, "чате")
+	if chatTitle != "" {
+		chat = i18n.T(lang, "чате «%s»", html.EscapeString(chatTitle))
+	}
+	var rule string
+	switch reason {
+	case restrictionLinks:
+		rule = i18n.T(lang, "новым участникам нельзя публиковать ссылки")
+	case restrictionMedia:
+		rule = i18n.T(lang, "новым участникам нельзя публиковать фото, видео и файлы")
+	case restrictionForwards:
+		rule = i18n.T(lang, "новым участникам нельзя пересылать сообщения")
+	case restrictionRateLimit:
+		return i18n.T(lang, "Ваше сообщение в %s удалено: не больше %s за %s. Пожалуйста, пишите реже.",
+			chat, i18n.Count(lang, p.RateLimitMessages, "сообщение"), formatSettingDuration(p.RateLimitWindowSeconds))
+	}
+	return i18n.T(lang, "Ваше сообщение в %s удалено: первые %s после вступления %s. Ограничение снимется само.",
+		chat, i18n.Count(lang, p.NewMemberDays, "день"), rule)
+}
+
+// --- /chatpolicy ---
+
+const chatPolicyUsage = "Использование:\n" +
+	"/chatpolicy — текущая политика\n" +
+	"/chatpolicy newbie <дней> [links,media,forwards|all] — ограничения новичков (0 — выключить)\n" +
+	"/chatpolicy rate <сообщений> [окно] — rate limit, например /chatpolicy rate 5 1m (0 — выключить)\n" +
+	"/chatpolicy exempt <уровень тира> — подписчики с этим тиром и выше освобождены (0 — никто)\n" +
+	"/chatpolicy reset — снять все ограничения"
+
+// handleChatPolicyCommand — политика ограничений в трекаемом чате. Менять
+// может только полный модератор, как и /modsettings.
+func (b *TelegramBot) handleChatPolicyCommand(message *tgbotapi.Message) {
+	if message.Chat.Type != "group" && message.Chat.Type != "supergroup" {
+		return
+	}
+	if !b.canModerate(message.Chat.ID, message.From.ID) {
+		return
+	}
+	chatID := message.Chat.ID
+	if !b.chatActivityService.IsTrackedChat(chatID) {
+		b.replyAndAutoDelete(message, b.tr(message.From.ID, "Чат не отслеживается ботом — политики работают только в трекаемых чатах."))
+		return
+	}
+	args := commandArgs(message)
+	if len(args) == 0 {
+		b.restrictions.invalidate(chatID)
+		if p := b.restrictionPolicy(chatID); p != nil {
+			b.sendChatHTML(chatID, formatChatPolicy(b.langOf(message.From.ID), p))
+		}
+		b.tryDelete(chatID, message.MessageID)
+		return
+	}
+
+	if strings.ToLower(args[0]) == "reset" {
+		if err := b.moderationService.ResetRestrictionPolicy(chatID, message.From.ID); err != nil {
+			log.Printf("/chatpolicy reset: %v", err)
+			b.replyAndAutoDelete(message, b.tr(message.From.ID, "Не удалось сбросить политику."))
+			return
+		}
+		b.restrictions.invalidate(chatID)
+		b.replyAndAutoDelete(message, b.tr(message.From.ID, "Ограничения в чате сняты."))
+		return
+	}
+
+	p, err := b.moderationService.GetRestrictionPolicy(chatID)
+	if err != nil {
+		log.Printf("/chatpolicy: %v", err)
+		b.replyAndAutoDelete(message, b.tr(message.From.ID, "Не удалось загрузить политику."))
+		return
+	}
+	if err := applyChatPolicyArgs(p, args); err != nil {
+		b.replyAndAutoDelete(message, b.trErr(message.From.ID, err)+"\n\n"+b.tr(message.From.ID, chatPolicyUsage))
+		return
+	}
+	if err := b.moderationService.SaveRestrictionPolicy(p, message.From.ID); err != nil {
+		b.replyAndAutoDelete(message, b.tr(message.From.ID, "Не сохранено: %s", b.trErr(message.From.ID, err)))
+		return
+	}
+	b.restrictions.invalidate(chatID)
+	b.sendChatHTML(chatID, formatChatPolicy(b.langOf(message.From.ID), p))
+	b.tryDelete(chatID, message.MessageID)
+}
+
+// applyChatPolicyArgs разбирает аргументы /chatpolicy newbie|rate|exempt в p.
+func applyChatPolicyArgs(p *models.ChatRestrictionPolicy, args []string) error {
+	if len(args) < 2 {
+		return fmt.Errorf("не хватает аргументов")
+	}
+	n, err := strconv.Atoi(args[1])
+	if err != nil || n < 0 {
+		return i18n.Errorf("ожидалось неотрицательное число, получено %q", args[1])
+	}
+	switch strings.ToLower(args[0]) {
+	case "newbie":
+		p.NewMemberDays = n
+		if len(args) > 2 {
+			links, media, forwards := false, false, false
+			for _, kind := range splitPolicyKinds(strings.Join(args[2:], ",")) {
+				switch kind {
+				case restrictionLinks:
+					links = true
+				case restrictionMedia:
+					media = true
+				case restrictionForwards:
+					forwards = true
+				case "all":
+					links, media, forwards = true, true, true
+				default:
+					return i18n.Errorf("неизвестный тип %q (links, media, forwards, all)", kind)
+				}
+			}
+			p.BlockLinks, p.BlockMedia, p.BlockForwards = links, media, forwards
+		}
+	case "rate":
+		p.RateLimitMessages = n
+		if len(args) > 2 {
+			d, err := service.ParseHumanDuration(args[2])
+			if err != nil {
+				return i18n.Errorf("не понял окно: %s (примеры: 30s, 1m, 5m)", i18n.MessageOf(err))
+			}
+			p.RateLimitWindowSeconds = int(d.Seconds())
+		}
+	case "exempt":
+		p.ExemptTierLevel = n
+	default:
+		return i18n.Errorf("неизвестная настройка %q", args[0])
+	}
+	return nil
+}
+
+func splitPolicyKinds(raw string) []string {
+	return strings.FieldsFunc(strings.ToLower(raw), func(r rune) bool {
+		return r == ',' || r == ' ' || r == '+'
+	})
+}
+
+func formatChatPolicy(lang i18n.Lang, p *models.ChatRestrictionPolicy) string {
+	var sb strings.Builder
+	sb.WriteString("🚧 <b>" + i18n.T(lang, "Ограничения в этом чате") + "</b>\n\n")
+	if p.NewMemberDays > 0 {
+		var kinds []string
+		if p.BlockLinks {
+			kinds = append(kinds, i18n.T(lang, "ссылки"))
+		}
+		if p.BlockMedia {
+			kinds = append(kinds, i18n.T(lang, "медиа"))
+		}
+		if p.BlockForwards {
+			kinds = append(kinds, i18n.T(lang, "форварды"))
+		}
+		blocked := i18n.T(lang, "ничего")
+		if len(kinds) > 0 {
+			blocked = strings.Join(kinds, ", ")
+		}
+		sb.WriteString(i18n.T(lang, "Новички (%s после вступления): запрещены %s\n", i18n.Count(lang, p.NewMemberDays, "день"), blocked))
+	} else {
+		sb.WriteString(i18n.T(lang, "Новички: без ограничений\n"))
+	}
+	if p.RateLimitMessages > 0 {
+		sb.WriteString(i18n.T(lang, "Rate limit: не больше %s за %s\n",
+			i18n.Count(lang, p.RateLimitMessages, "сообщение"), formatSettingDuration(p.RateLimitWindowSeconds)))
+	} else {
+		sb.WriteString(i18n.T(lang, "Rate limit: выключен\n"))
+	}
+	if p.ExemptTierLevel > 0 {
+		sb.WriteString(i18n.T(lang, "Освобождены: модераторы и подписчики с тиром %d+", p.ExemptTierLevel))
+	} else {
+		sb.WriteString(i18n.T(lang, "Освобождены: модераторы"))
+	}
+	return sb.String()
+}
@@ -0,0 +1,121 @@
+package bot
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"ithozyeva/internal/models"
+	"ithozyeva/internal/service"
+	"ithozyeva/internal/testutil"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+func TestNewMemberViolation(t *testing.T) {
+	p := service.DefaultChatRestrictionPolicy(1)
+	cases := []struct {
+		name string
+		msg  *tgbotapi.Message
+		want string
+	}{
+		{"plain text", &tgbotapi.Message{Text: "привет"}, ""},
+		{"url", &tgbotapi.Message{Text: "x.com", Entities: []tgbotapi.MessageEntity{{Type: "url"}}}, restrictionLinks},
+		{"caption link", &tgbotapi.Message{CaptionEntities: []tgbotapi.MessageEntity{{Type: "text_link"}}}, restrictionLinks},
+		{"photo", &tgbotapi.Message{Photo: []tgbotapi.PhotoSize{{FileID: "a"}}}, restrictionMedia},
+		{"sticker", &tgbotapi.Message{Sticker: &tgbotapi.Sticker{FileID: "s"}}, ""},
+		{"forward", &tgbotapi.Message{Text: "hi", ForwardDate: 1}, restrictionForwards},
+	}
+	for _, c := range cases {
+		if got := newMemberViolation(p, c.msg); got != c.want {
+			t.Errorf("%s: got %q, want %q", c.name, got, c.want)
+		}
+	}
+
+	p.BlockLinks = false
+	link := &tgbotapi.Message{Entities: []tgbotapi.MessageEntity{{Type: "url"}}}
+	if got := newMemberViolation(p, link); got != "" {
+		t.Errorf("links allowed: got %q", got)
+	}
+}
+
+func TestRestrictionsRateLimit(t *testing.T) {
+	_, rdb := testutil.NewMiniRedis(t)
+	state := stateBot(rdb).chatState
+	base := time.Now()
+	msgID := 0
+	// exceeds — как enforceChatRestrictions: больше limit сообщений в окне.
+	exceeds := func(chatID, userID int64, at time.Time, limit int64) bool {
+		t.Helper()
+		msgID++
+		n, err := state.RecordChatMessage(context.Background(), chatID, userID, msgID, at, time.Minute)
+		if err != nil {
+			t.Fatalf("RecordChatMessage: %v", err)
+		}
+		return n > limit
+	}
+	for i := 0; i < 3; i++ {
+		if exceeds(1, 42, base.Add(time.Duration(i)*time.Second), 3) {
+			t.Fatalf("message %d: limit hit too early", i+1)
+		}
+	}
+	if !exceeds(1, 42, base.Add(3*time.Second), 3) {
+		t.Fatal("4th message within window must exceed limit of 3")
+	}
+	// Другой юзер и другой чат считаются отдельно.
+	if exceeds(1, 43, base, 3) || exceeds(2, 42, base, 3) {
+		t.Fatal("rate windows leaked across users/chats")
+	}
+	// Окно сдвинулось — старые сообщения не считаются.
+	if exceeds(1, 42, base.Add(2*time.Minute), 3) {
+		t.Fatal("stale messages counted")
+	}
+}
+
+func TestRestrictionsNotifyCooldown(t *testing.T) {
+	mr, rdb := testutil.NewMiniRedis(t)
+	state := stateBot(rdb).chatState
+	notify := func(reason string) bool {
+		t.Helper()
+		ok, err := state.TakeRestrictionNotice(context.Background(), 1, 42, reason, restrictionNotifyCooldown)
+		if err != nil {
+			t.Fatalf("TakeRestrictionNotice: %v", err)
+		}
+		return ok
+	}
+	if !notify(restrictionLinks) {
+		t.Fatal("first notice must be sent")
+	}
+	mr.FastForward(time.Minute)
+	if notify(restrictionLinks) {
+		t.Fatal("notice repeated within cooldown")
+	}
+	if !notify(restrictionRateLimit) {
+		t.Fatal("different reason must be notified separately")
+	}
+	mr.FastForward(restrictionNotifyCooldown)
+	if !notify(restrictionLinks) {
+		t.Fatal("notice must be sent again after cooldown")
+	}
+}
+
+func TestApplyChatPolicyArgs(t *testing.T) {
+	p := &models.ChatRestrictionPolicy{RateLimitWindowSeconds: 60}
+	if err := applyChatPolicyArgs(p, []string{"newbie", "3", "links,forwards"}); err != nil {
+		t.Fatalf("newbie: %v", err)
+	}
+	if p.NewMemberDays != 3 || !p.BlockLinks || p.BlockMedia || !p.BlockForwards {
+		t.Fatalf("newbie applied wrong: %+v", p)
+	}
+	if err := applyChatPolicyArgs(p, []string{"rate", "5", "30s"}); err != nil {
+		t.Fatalf("rate: %v", err)
+	}
+	if p.RateLimitMessages != 5 || p.RateLimitWindowSeconds != 30 {
+		t.Fatalf("rate applied wrong: %+v", p)
+	}
+	for _, bad := range [][]string{{"rate"}, {"rate", "-1"}, {"newbie", "3", "stickers"}, {"unknown", "1"}} {
+		if err := applyChatPolicyArgs(p, bad); err == nil {
+			t.Errorf("%v: want error", bad)
+		}
+	}
+}
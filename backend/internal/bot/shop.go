@@ -0,0 +1,23 @@
+package bot
+
+import (
+	"html"
+
+	"ithozyeva/internal/service"
+)
+
+// notifyShopOrder — новый заказ в магазине наград: сообщаем админу, чтобы
+// тот выдал награду и перевёл заказ в админке дальше.
+func (b *TelegramBot) notifyShopOrder(ev service.ShopOrderEvent) {
+	adminID := subscriptionAdminID()
+	buyer := html.EscapeString(ev.FullName)
+	if ev.Username != "" {
+		buyer += " (@" + html.EscapeString(ev.Username) + ")"
+	}
+	text := b.tr(adminID, "🛍 <b>Новый заказ #%d</b>\n%s — %d баллов\nПокупатель: %s",
+		ev.OrderID, html.EscapeString(ev.ItemTitle), ev.Price, buyer)
+	if ev.Comment != "" {
+		text += "\n" + b.tr(adminID, "Комментарий: %s", html.EscapeString(ev.Comment))
+	}
+	b.SendDirectMessage(adminID, text)
+}
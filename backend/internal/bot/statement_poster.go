@@ -0,0 +1,171 @@
+package bot
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"ithozyeva/internal/i18n"
+	"ithozyeva/internal/models"
+	"ithozyeva/internal/service"
+	"ithozyeva/internal/utils"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+const (
+	// statementPollInterval — как часто бот ищет неотправленные выписки.
+	// Бэкенд собирает их раз в месяц.
+	statementPollInterval = time.Minute
+
+	// statementSendPace — пауза между личками, как pushBatchPace в
+	// массовых пушах: выписки уходят всем активным участникам разом.
+	statementSendPace = 100 * time.Millisecond
+)
+
+// statementMonths — месяц выписки; переводится через i18n.T.
+var statementMonths = [...]string{
+	"январь", "февраль", "март", "апрель", "май", "июнь",
+	"июль", "август", "сентябрь", "октябрь", "ноябрь", "декабрь",
+}
+
+// pointReasonLabels — подписи причин начисления для выписки, как
+// reasonLabels во фронтенде платформы; переводятся через i18n.T.
+var pointReasonLabels = map[models.PointReason]string{
+	models.PointReasonEventAttend:        "Посещение событий",
+	models.PointReasonEventHost:          "Проведение событий",
+	models.PointReasonReviewCommunity:    "Отзывы (сообщество)",
+	models.PointReasonReviewService:      "Отзывы (услуги)",
+	models.PointReasonResumeUpload:       "Загрузка резюме",
+	models.PointReasonReferalCreate:      "Создание рефералов",
+	models.PointReasonReferalConversion:  "Конверсия рефералов",
+	models.PointReasonProfileComplete:    "Заполнение профиля",
+	models.PointReasonWeeklyActivity:     "Еженедельная активность",
+	models.PointReasonMonthlyActive:      "Ежемесячная активность",
+	models.PointReasonStreak4Weeks:       "Серия 4 недели",
+	models.PointReasonAdminManual:        "Начисление вручную",
+	models.PointReasonTaskCreate:         "Создание заданий",
+	models.PointReasonTaskExecute:        "Выполнение заданий",
+	models.PointReasonMarketplaceCreate:  "Публикация объявлений",
+	models.PointReasonMarketplaceBuy:     "Покупки",
+	models.PointReasonChatQuest:          "Квесты в чатах",
+	models.PointReasonChatterOfWeek:      "Чаттер недели",
+	models.PointReasonKudosReceived:      "Благодарности",
+	models.PointReasonRaffleSpend:        "Розыгрыши",
+	models.PointReasonCasinoBet:          "Ставки мини-игр",
+	models.PointReasonCasinoWin:          "Выигрыши мини-игр",
+	models.PointReasonCasinoJackpot:      "Джекпот мини-игр",
+	models.PointReasonDailyCheckIn:       "Ежедневный вход",
+	models.PointReasonDailyStreak3:       "Стрик 3 дня",
+	models.PointReasonDailyStreak7:       "Стрик 7 дней",
+	models.PointReasonDailyStreak14:      "Стрик 14 дней",
+	models.PointReasonDailyStreak30:      "Стрик 30 дней",
+	models.PointReasonDailyTaskComplete:  "Дейлики",
+	models.PointReasonDailyAllTasksBonus: "Бонус за все дейлики",
+	models.PointReasonChallengeComplete:  "Челленджи",
+	models.PointReasonDailyRaffleWin:     "Ежедневный розыгрыш",
+	models.PointReasonShopPurchase:       "Покупки в магазине",
+	models.PointReasonShopRefund:         "Возвраты магазина",
+	models.PointReasonStreakFreeze:       "Заморозки стрика",
+	models.PointReasonStreakRepair:       "Восстановление стрика",
+	models.PointReasonLeaderboardPrize:   "Призы рейтинга",
+	models.PointReasonClawback:           "Списания",
+	models.PointReasonReversal:           "Отмены транзакций",
+}
+
+// startStatementPoster рассылает ежемесячные выписки, собранные бэкендом.
+// Pull-based, как startDigestPoster.
+func (b *TelegramBot) startStatementPoster() {
+	ticker := time.NewTicker(statementPollInterval)
+	defer ticker.Stop()
+
+	b.sendPendingStatements()
+	for range ticker.C {
+		b.sendPendingStatements()
+	}
+}
+
+func (b *TelegramBot) sendPendingStatements() {
+	if !b.isLeader() {
+		return
+	}
+	records, err := b.statementService.ListUnsent(100)
+	if err != nil {
+		log.Printf("statement-poster: load pending error: %v", err)
+		return
+	}
+	for i := range records {
+		b.sendOneStatement(&records[i])
+		time.Sleep(statementSendPace)
+	}
+}
+
+// sendOneStatement — «mark first, then send»: лучше потерять выписку, чем
+// прислать её дважды.
+func (b *TelegramBot) sendOneStatement(rec *models.PointsStatementRecord) {
+	ok, err := b.statementService.MarkSent(rec.Id)
+	if err != nil {
+		log.Printf("statement-poster: mark sent error statement=%d: %v", rec.Id, err)
+		return
+	}
+	if !ok || rec.TelegramID == 0 {
+		return
+	}
+	st := service.DecodeStatement(rec)
+	if st == nil {
+		log.Printf("statement-poster: broken payload statement=%d", rec.Id)
+		return
+	}
+
+	msg := tgbotapi.NewMessage(rec.TelegramID, formatStatementMessage(b.langOf(rec.TelegramID), st, platformBaseURL()))
+	msg.ParseMode = "HTML"
+	msg.DisableWebPagePreview = true
+	if _, err := b.bot.Send(msg); err != nil {
+		log.Printf("statement-poster: send error statement=%d member=%d: %v", rec.Id, rec.MemberId, err)
+	}
+}
+
+// formatStatementMessage — итоги месяца и разбивка по причинам на языке
+// участника.
+func formatStatementMessage(lang i18n.Lang, st *models.PointsStatement, baseURL string) string {
+	from := st.From.In(utils.MSKLocation())
+
+	var sb strings.Builder
+	sb.WriteString(i18n.T(lang, "📊 <b>Твои баллы за %s %d</b>", i18n.T(lang, statementMonths[from.Month()-1]), from.Year()) + "\n\n")
+	sb.WriteString(i18n.T(lang, "Заработано: <b>+%d</b>", st.Earned) + "\n")
+	sb.WriteString(i18n.T(lang, "Потрачено: <b>−%d</b>", st.Spent) + "\n")
+	sb.WriteString(i18n.T(lang, "Итого за месяц: <b>%s</b>", signed(st.Net)) + "\n")
+	sb.WriteString(i18n.T(lang, "Баланс на конец месяца: <b>%d</b>", st.Balance) + "\n")
+
+	if len(st.ByReason) > 0 {
+		sb.WriteString("\n<b>" + i18n.T(lang, "По источникам:") + "</b>\n")
+		for _, r := range st.ByReason {
+			label := string(r.Reason)
+			if l, ok := pointReasonLabels[r.Reason]; ok {
+				label = i18n.T(lang, l)
+			}
+			sb.WriteString(fmt.Sprintf("• %s: %s (%d)\n", label, signed(r.Amount), r.Count))
+		}
+	}
+
+	if st.CreditsEarned > 0 || st.CreditsSpent > 0 {
+		sb.WriteString("\n" + i18n.T(lang, "Реферальные кредиты: +%d / −%d", st.CreditsEarned, st.CreditsSpent) + "\n")
+	}
+
+	if baseURL != "" {
+		sb.WriteString(fmt.Sprintf("\n<a href=\"%s/progress\">%s</a>", baseURL, i18n.T(lang, "История и выгрузка")))
+	}
+	return sb.String()
+}
+
+// signed — число со знаком: +5, −3, 0.
+func signed(n int) string {
+	switch {
+	case n > 0:
+		return fmt.Sprintf("+%d", n)
+	case n < 0:
+		return fmt.Sprintf("−%d", -n)
+	}
+	return "0"
+}
@@ -0,0 +1,67 @@
+package bot
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"ithozyeva/internal/i18n"
+	"ithozyeva/internal/models"
+	"ithozyeva/internal/utils"
+)
+
+func TestFormatStatementMessage(t *testing.T) {
+	st := &models.PointsStatement{
+		From:    time.Date(2026, 9, 1, 0, 0, 0, 0, utils.MSKLocation()),
+		Earned:  120,
+		Spent:   30,
+		Net:     90,
+		Balance: 410,
+		ByReason: []models.PointsReasonTotal{
+			{Reason: models.PointReasonEventHost, Amount: 100, Count: 4},
+			{Reason: models.PointReasonCasinoBet, Amount: -30, Count: 3},
+			{Reason: "legacy_bonus", Amount: 20, Count: 1},
+		},
+	}
+	got := formatStatementMessage(i18n.RU, st, "https://example.org")
+	for _, want := range []string{
+		"за сентябрь 2026",
+		"Заработано: <b>+120</b>",
+		"Потрачено: <b>−30</b>",
+		"Итого за месяц: <b>+90</b>",
+		"Баланс на конец месяца: <b>410</b>",
+		"• Проведение событий: +100 (4)",
+		"• Ставки мини-игр: −30 (3)",
+		"• legacy_bonus: +20 (1)",
+		`<a href="https://example.org/progress">`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("missing %q in:\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "Реферальные кредиты") {
+		t.Errorf("credits line without credits:\n%s", got)
+	}
+}
+
+func TestFormatStatementMessageEnglish(t *testing.T) {
+	st := &models.PointsStatement{
+		From:          time.Date(2026, 9, 1, 0, 0, 0, 0, utils.MSKLocation()),
+		Earned:        120,
+		Net:           120,
+		CreditsEarned: 2,
+		ByReason:      []models.PointsReasonTotal{{Reason: models.PointReasonEventHost, Amount: 120, Count: 4}},
+	}
+	got := formatStatementMessage(i18n.EN, st, "https://example.org")
+	for _, want := range []string{
+		"Your points for September 2026",
+		"Earned: <b>+120</b>",
+		"• Hosting events: +120 (4)",
+		"Referral credits: +2 / −0",
+		">History and export</a>",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("missing %q in:\n%s", want, got)
+		}
+	}
+}
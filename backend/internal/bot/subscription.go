@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"ithozyeva/config"
+	"ithozyeva/internal/i18n"
 	"ithozyeva/internal/models"
 	"ithozyeva/internal/service"
 
@@ -47,18 +48,19 @@ func (b *TelegramBot) resolveUserTier(userID int64) (tierName string, isSubscrib
 func (b *TelegramBot) sendTariffsMessage(chatID int64) {
 	tiers, err := b.subscriptionService.GetPublicTiers()
 	if err != nil || len(tiers) == 0 {
-		b.sendMessage(chatID, "Не удалось загрузить тарифы. Напишите админу: /support")
+		b.sendMessage(chatID, b.tr(chatID, "Не удалось загрузить тарифы. Напишите админу: /support"))
 		return
 	}
 
+	lang := b.langOf(chatID)
 	var text strings.Builder
-	text.WriteString("<b>Тарифы IT-ХОЗЯЕВА</b>\n\n")
+	text.WriteString("<b>" + i18n.T(lang, "Тарифы IT-ХОЗЯЕВА") + "</b>\n\n")
 	for _, t := range tiers {
 		text.WriteString("💎 <b>")
 		text.WriteString(html.EscapeString(t.Name))
 		text.WriteString("</b>")
 		if t.Price > 0 {
-			text.WriteString(fmt.Sprintf(" — %d ₽/мес", t.Price))
+			text.WriteString(" — " + i18n.T(lang, "%d ₽/мес", t.Price))
 		}
 		if t.Description != "" {
 			text.WriteString(". ")
@@ -66,7 +68,7 @@ func (b *TelegramBot) sendTariffsMessage(chatID int64) {
 		}
 		text.WriteString("\n")
 	}
-	text.WriteString("\nПосле оплаты нажмите /sub — бот выдаст инвайты в чаты по вашему тиру.")
+	text.WriteString("\n" + i18n.T(lang, "После оплаты нажмите /sub — бот выдаст инвайты в чаты по вашему тиру."))
 
 	rows := make([][]tgbotapi.InlineKeyboardButton, 0, len(tiers)+1)
 	for _, t := range tiers {
@@ -79,7 +81,7 @@ func (b *TelegramBot) sendTariffsMessage(chatID int64) {
 		))
 	}
 	rows = append(rows, tgbotapi.NewInlineKeyboardRow(
-		tgbotapi.NewInlineKeyboardButtonData("✅ Я оплатил → проверить", "wiz:sub"),
+		tgbotapi.NewInlineKeyboardButtonData(i18n.T(lang, "✅ Я оплатил → проверить"), "wiz:sub"),
 	))
 
 	msg := tgbotapi.NewMessage(chatID, text.String())
@@ -105,7 +107,7 @@ func (b *TelegramBot) notifyUserOfSyncResult(userID int64, result *service.SyncR
 		b.sendSubscriptionLinks(userID, result)
 	}
 	if len(result.Revoked) > 0 {
-		b.SendDirectMessage(userID, msgAccessRevoked)
+		b.SendDirectMessage(userID, b.tr(userID, msgAccessRevoked))
 	}
 }
 
@@ -256,12 +258,13 @@ func (b *TelegramBot) handleSubCommand(message *tgbotapi.Message) {
 	// раздать им invite-ссылки, а через минуту их снова кикнут наши же
 	// модерационные хуки. Лучше один внятный отказ.
 	if active, gb, _ := b.moderationService.IsGloballyBanned(user.ID); active && gb != nil {
-		text := "Доступ ограничен."
+		lang := b.langOf(user.ID)
+		text := i18n.T(lang, "Доступ ограничен.")
 		if gb.Reason != nil && *gb.Reason != "" {
-			text += "\nПричина: " + *gb.Reason
+			text += "\n" + i18n.T(lang, "Причина: %s", *gb.Reason)
 		}
 		if gb.ExpiresAt != nil {
-			text += "\nДо: " + gb.ExpiresAt.Format("2006-01-02 15:04")
+			text += "\n" + i18n.T(lang, "До: %s", gb.ExpiresAt.Format("2006-01-02 15:04"))
 		}
 		b.sendMessage(message.Chat.ID, text)
 		return
@@ -273,14 +276,14 @@ func (b *TelegramBot) handleSubCommand(message *tgbotapi.Message) {
 	)
 	if err != nil {
 		log.Printf("Error onboarding user %d: %v", user.ID, err)
-		b.sendMessage(message.Chat.ID, "Произошла ошибка. Попробуйте позже.")
+		b.sendMessage(message.Chat.ID, b.tr(message.From.ID, "Произошла ошибка. Попробуйте позже."))
 		return
 	}
 
 	if result.EffectiveTierID == nil {
-		b.sendMessage(message.Chat.ID,
+		b.sendMessage(message.Chat.ID, b.tr(user.ID,
 			"У вас нет активной подписки.\n\n"+
-				"Подпишитесь через Boosty или Tribute, затем вернитесь и нажмите /sub снова.")
+				"Подпишитесь через Boosty или Tribute, затем вернитесь и нажмите /sub снова."))
 		return
 	}
 
@@ -296,12 +299,13 @@ func (b *TelegramBot) handleSubCommand(message *tgbotapi.Message) {
 func (b *TelegramBot) handleSubStatusCommand(message *tgbotapi.Message) {
 	user, err := b.subscriptionService.GetUser(message.From.ID)
 	if err != nil {
-		b.sendMessage(message.Chat.ID, "Вы не зарегистрированы. Используйте /sub для начала.")
+		b.sendMessage(message.Chat.ID, b.tr(message.From.ID, "Вы не зарегистрированы. Используйте /sub для начала."))
 		return
 	}
 
+	lang := b.langOf(message.From.ID)
 	tierID := user.EffectiveTierID()
-	tierName := "Нет"
+	tierName := i18n.T(lang, "Нет")
 	if tierID != nil {
 		tier, err := b.subscriptionService.GetTier(*tierID)
 		if err == nil {
@@ -334,7 +338,7 @@ func (b *TelegramBot) handleSubStatusCommand(message *tgbotapi.Message) {
 	// tierName escape: handleMyGroupsCommand уже эскейпит, держим тот же
 	// инвариант — иначе админ назовёт тир со спецсимволом и /substatus
 	// перестаёт доставляться юзерам.
-	text := fmt.Sprintf("<b>Статус подписки</b>\n\n"+
+	text := i18n.T(lang, "<b>Статус подписки</b>\n\n"+
 		"Тир: %s\n"+
 		"Доступных чатов: %d\n", html.EscapeString(tierName), len(unique))
 
@@ -349,9 +353,9 @@ func (b *TelegramBot) handleSubStatusCommand(message *tgbotapi.Message) {
 			}
 			items = append(items, chatListItem{chat: chat, link: link})
 		}
-		text += formatChatsGrouped(items)
-		text += "\n<i>Во все сразу вступать не обязательно — Telegram после нескольких " +
-			"подряд вступлений просит подождать. Заходи, куда хочется.</i>"
+		text += formatChatsGrouped(lang, items)
+		text += "\n<i>" + i18n.T(lang, "Во все сразу вступать не обязательно — Telegram после нескольких "+
+			"подряд вступлений просит подождать. Заходи, куда хочется.") + "</i>"
 	}
 
 	b.SendDirectMessage(message.Chat.ID, text)
@@ -363,34 +367,34 @@ func (b *TelegramBot) handleMyGroupsCommand(message *tgbotapi.Message) {
 	userID := message.From.ID
 	user, err := b.subscriptionService.GetUser(userID)
 	if err != nil {
-		b.sendMessage(message.Chat.ID, "Вы не зарегистрированы. Используйте /sub, чтобы начать.")
+		b.sendMessage(message.Chat.ID, b.tr(message.From.ID, "Вы не зарегистрированы. Используйте /sub, чтобы начать."))
 		return
 	}
 
 	effectiveTierID := user.EffectiveTierID()
 	if effectiveTierID == nil {
-		b.sendMessage(message.Chat.ID,
-			"У вас нет активной подписки. Используйте /sub, чтобы получить доступ к чатам.")
+		b.sendMessage(message.Chat.ID, b.tr(userID,
+			"У вас нет активной подписки. Используйте /sub, чтобы получить доступ к чатам."))
 		return
 	}
 
 	tier, err := b.subscriptionService.GetTier(*effectiveTierID)
 	if err != nil {
 		log.Printf("mygroups: failed to get tier %d: %v", *effectiveTierID, err)
-		b.sendMessage(message.Chat.ID, "Не удалось получить ваш тир. Попробуйте позже.")
+		b.sendMessage(message.Chat.ID, b.tr(message.From.ID, "Не удалось получить ваш тир. Попробуйте позже."))
 		return
 	}
 
 	chats, err := b.subscriptionService.GetChatsForTierLevel(tier.Level)
 	if err != nil {
 		log.Printf("mygroups: failed to list chats for level %d: %v", tier.Level, err)
-		b.sendMessage(message.Chat.ID, "Не удалось получить список чатов.")
+		b.sendMessage(message.Chat.ID, b.tr(message.From.ID, "Не удалось получить список чатов."))
 		return
 	}
 
 	if len(chats) == 0 {
-		b.SendDirectMessage(message.Chat.ID,
-			"По вашему тиру <b>"+html.EscapeString(tier.Name)+"</b> пока нет подключённых чатов.")
+		b.SendDirectMessage(message.Chat.ID, b.tr(userID,
+			"По вашему тиру <b>%s</b> пока нет подключённых чатов.", html.EscapeString(tier.Name)))
 		return
 	}
 
@@ -415,13 +419,14 @@ func (b *TelegramBot) handleMyGroupsCommand(message *tgbotapi.Message) {
 		})
 	}
 
-	text := fmt.Sprintf(
+	lang := b.langOf(userID)
+	text := i18n.T(lang,
 		"<b>Доступные чаты по подписке (%s):</b>\n",
 		html.EscapeString(tier.Name))
-	text += formatChatsGrouped(items)
-	text += "\n<i>✅ — чат, в котором вы уже состоите. Во все сразу вступать " +
-		"не обязательно — Telegram ограничивает подряд идущие вступления, " +
-		"так что выбирай, что тебе интересно.</i>"
+	text += formatChatsGrouped(lang, items)
+	text += "\n<i>" + i18n.T(lang, "✅ — чат, в котором вы уже состоите. Во все сразу вступать "+
+		"не обязательно — Telegram ограничивает подряд идущие вступления, "+
+		"так что выбирай, что тебе интересно.") + "</i>"
 
 	b.SendDirectMessage(message.Chat.ID, text)
 }
@@ -434,16 +439,18 @@ func (b *TelegramBot) subscriptionDeepLink() string {
 // postAnchorWelcome posts a welcome message in the anchor chat with a button
 // that deep-links to the bot so the user can receive DM invite links.
 func (b *TelegramBot) postAnchorWelcome(chatID int64, user *tgbotapi.User) {
+	// Приветствие адресное — на языке новичка.
+	lang := b.langOf(user.ID)
 	mention := "@" + user.UserName
 	if user.UserName == "" {
 		name := strings.TrimSpace(user.FirstName + " " + user.LastName)
 		if name == "" {
-			name = "друг"
+			name = i18n.T(lang, "друг")
 		}
 		mention = fmt.Sprintf("<a href=\"tg://user?id=%d\">%s</a>", user.ID, name)
 	}
 
-	text := fmt.Sprintf(
+	text := i18n.T(lang,
 		"%s, добро пожаловать! Нажмите кнопку ниже, чтобы получить доступ к остальным чатам.",
 		mention)
 
@@ -452,7 +459,7 @@ func (b *TelegramBot) postAnchorWelcome(chatID int64, user *tgbotapi.User) {
 	msg.DisableNotification = true
 	msg.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(
 		tgbotapi.NewInlineKeyboardRow(
-			tgbotapi.NewInlineKeyboardButtonURL("Получить доступ", b.subscriptionDeepLink()),
+			tgbotapi.NewInlineKeyboardButtonURL(i18n.T(lang, "Получить доступ"), b.subscriptionDeepLink()),
 		),
 	)
 	if _, err := b.bot.Send(msg); err != nil {
@@ -468,13 +475,13 @@ type chatListItem struct {
 	isMember bool
 }
 
-// formatChatsGrouped группирует items по Category (NULL → «Прочее»),
+// formatChatsGrouped группирует items по Category (NULL → «Прочее» на lang),
 // сортирует категории по MAX(priority) DESC, внутри категории — по title.
 // Результат — HTML-строка с заголовками-категориями и пунктами-списком.
 // Передавайте прегенерированные link-и (для юзерских команд — одноразовые);
 // если link пуст, выводится просто название.
-func formatChatsGrouped(items []chatListItem) string {
-	const fallbackCategory = "Прочее"
+func formatChatsGrouped(lang i18n.Lang, items []chatListItem) string {
+	fallbackCategory := i18n.T(lang, "Прочее")
 	const fallbackEmoji = "💬"
 
 	type group struct {
@@ -553,11 +560,12 @@ func (b *TelegramBot) sendSubscriptionLinks(chatID int64, result *service.SyncRe
 		items = append(items, chatListItem{chat: *chat, link: g.Link})
 	}
 
-	text := fmt.Sprintf("Подписка подтверждена! Доступно чатов: <b>%d</b>\n", len(result.Granted))
-	text += formatChatsGrouped(items)
-	text += "\n<i>Необязательно вступать во все сразу — Telegram после нескольких подряд " +
-		"вступлений просит подождать. Выбирай чаты, которые тебе интересны; " +
-		"остальные всегда под рукой в /mygroups.</i>"
+	lang := b.langOf(chatID)
+	text := i18n.T(lang, "Подписка подтверждена! Доступно чатов: <b>%d</b>\n", len(result.Granted))
+	text += formatChatsGrouped(lang, items)
+	text += "\n<i>" + i18n.T(lang, "Необязательно вступать во все сразу — Telegram после нескольких подряд "+
+		"вступлений просит подождать. Выбирай чаты, которые тебе интересны; "+
+		"остальные всегда под рукой в /mygroups.") + "</i>"
 
 	msg := tgbotapi.NewMessage(chatID, text)
 	msg.ParseMode = "HTML"
@@ -575,6 +583,20 @@ func (b *TelegramBot) sendSubscriptionLinks(chatID int64, result *service.SyncRe
 // инвайтов и киков): без этого таблица access отражала бы только то, что
 // бот сам выдал ссылкой, и периодик не видел бы реально сидящих в чатах.
 func (b *TelegramBot) handleChatMemberUpdated(update *tgbotapi.ChatMemberUpdated) {
+	// Бан-лист, учёт вступлений и raid-детектор смотрят на все трекаемые чаты,
+	// не только подписочные.
+	if !isActiveMemberStatus(update.OldChatMember.Status) && isActiveMemberStatus(update.NewChatMember.Status) {
+		if b.enforceBanlistOnJoin(update) {
+			return
+		}
+		if u := update.NewChatMember.User; u != nil && !u.IsBot && b.chatActivityService.IsTrackedChat(update.Chat.ID) {
+			if err := b.moderationService.RecordChatJoin(update.Chat.ID, u.ID); err != nil {
+				log.Printf("record chat join chat=%d user=%d: %v", update.Chat.ID, u.ID, err)
+			}
+		}
+		b.handleRaidJoin(update)
+	}
+
 	chat, err := b.subscriptionService.GetChat(update.Chat.ID)
 	if err != nil {
 		return // Not a known subscription chat
@@ -759,6 +781,9 @@ func (b *TelegramBot) startSubscriptionChecker() {
 	for {
 		select {
 		case <-tierTicker.C:
+			if !b.isLeader() {
+				continue
+			}
 			results := b.subscriptionService.PeriodicCheck(
 				b.botCheckFunc(),
 				b.createInviteLinkFunc(),
@@ -767,6 +792,9 @@ func (b *TelegramBot) startSubscriptionChecker() {
 			)
 			b.sendPeriodicCheckReport(subscriptionAdminID(), "Периодическая проверка подписок", results, true)
 		case <-sweepTicker.C:
+			if !b.isLeader() {
+				continue
+			}
 			stats, err := b.subscriptionService.SweepRealMembership(b.uncachedCheckFunc(), 50*time.Millisecond)
 			if err != nil {
 				log.Printf("Daily membership sweep failed: %v", err)
@@ -787,7 +815,7 @@ func (b *TelegramBot) handleSubTiersCommand(message *tgbotapi.Message) {
 	}
 	tiers, err := b.subscriptionService.GetAllTiers()
 	if err != nil {
-		b.sendMessage(message.Chat.ID, "Ошибка получения тиров.")
+		b.sendMessage(message.Chat.ID, b.tr(message.From.ID, "Ошибка получения тиров."))
 		return
 	}
 
@@ -806,7 +834,7 @@ func (b *TelegramBot) handleSubChatsCommand(message *tgbotapi.Message) {
 	}
 	chats, err := b.subscriptionService.GetAllChats()
 	if err != nil || len(chats) == 0 {
-		b.sendMessage(message.Chat.ID, "Нет зарегистрированных чатов.")
+		b.sendMessage(message.Chat.ID, b.tr(message.From.ID, "Нет зарегистрированных чатов."))
 		return
 	}
 
@@ -842,7 +870,7 @@ func (b *TelegramBot) handleSubAddChatCommand(message *tgbotapi.Message) {
 
 	chatID, err := strconv.ParseInt(args[1], 10, 64)
 	if err != nil {
-		b.sendMessage(message.Chat.ID, "Неверный chat_id.")
+		b.sendMessage(message.Chat.ID, b.tr(message.From.ID, "Неверный chat_id."))
 		return
 	}
 
@@ -929,12 +957,12 @@ func (b *TelegramBot) notifyNewChatAccess(chatID int64, chatTitle string, tierLe
 	}
 
 	titleEscaped := html.EscapeString(chatTitle)
-	text := fmt.Sprintf(
-		"🆕 Вам открыт новый чат по вашей подписке:\n\n<b>%s</b>\n\n<a href=\"%s\">Перейти в чат</a>",
-		titleEscaped, link)
 	delivered, skipped, failed := 0, 0, 0
 
 	for _, user := range users {
+		text := b.tr(user.ID,
+			"🆕 Вам открыт новый чат по вашей подписке:\n\n<b>%s</b>\n\n<a href=\"%s\">Перейти в чат</a>",
+			titleEscaped, link)
 		msg := tgbotapi.NewMessage(user.ID, text)
 		msg.ParseMode = "HTML"
 		msg.DisableWebPagePreview = true
@@ -972,13 +1000,13 @@ func (b *TelegramBot) handleSubSetAnchorCommand(message *tgbotapi.Message) {
 
 	args := strings.Fields(message.Text)
 	if len(args) < 3 {
-		b.sendMessage(message.Chat.ID, "Использование: /subsetanchor <chat_id> <tier_slug|clear>")
+		b.sendMessage(message.Chat.ID, b.tr(message.From.ID, "Использование: /subsetanchor <chat_id> <tier_slug|clear>"))
 		return
 	}
 
 	chatID, err := strconv.ParseInt(args[1], 10, 64)
 	if err != nil {
-		b.sendMessage(message.Chat.ID, "Неверный chat_id.")
+		b.sendMessage(message.Chat.ID, b.tr(message.From.ID, "Неверный chat_id."))
 		return
 	}
 
@@ -1014,13 +1042,13 @@ func (b *TelegramBot) handleSubRemoveChatCommand(message *tgbotapi.Message) {
 
 	args := strings.Fields(message.Text)
 	if len(args) < 2 {
-		b.sendMessage(message.Chat.ID, "Использование: /subremovechat <chat_id>")
+		b.sendMessage(message.Chat.ID, b.tr(message.From.ID, "Использование: /subremovechat <chat_id>"))
 		return
 	}
 
 	chatID, err := strconv.ParseInt(args[1], 10, 64)
 	if err != nil {
-		b.sendMessage(message.Chat.ID, "Неверный chat_id.")
+		b.sendMessage(message.Chat.ID, b.tr(message.From.ID, "Неверный chat_id."))
 		return
 	}
 
@@ -1078,19 +1106,19 @@ func (b *TelegramBot) handleSubUserInfoCommand(message *tgbotapi.Message) {
 
 	args := strings.Fields(message.Text)
 	if len(args) < 2 {
-		b.sendMessage(message.Chat.ID, "Использование: /subuserinfo <user_id>")
+		b.sendMessage(message.Chat.ID, b.tr(message.From.ID, "Использование: /subuserinfo <user_id>"))
 		return
 	}
 
 	userID, err := strconv.ParseInt(args[1], 10, 64)
 	if err != nil {
-		b.sendMessage(message.Chat.ID, "Неверный user_id.")
+		b.sendMessage(message.Chat.ID, b.tr(message.From.ID, "Неверный user_id."))
 		return
 	}
 
 	user, err := b.subscriptionService.GetUser(userID)
 	if err != nil {
-		b.sendMessage(message.Chat.ID, "Пользователь не найден.")
+		b.sendMessage(message.Chat.ID, b.tr(message.From.ID, "Пользователь не найден."))
 		return
 	}
 
@@ -1140,18 +1168,18 @@ func (b *TelegramBot) handleSubOverrideCommand(message *tgbotapi.Message) {
 
 	args := strings.Fields(message.Text)
 	if len(args) < 3 {
-		b.sendMessage(message.Chat.ID, "Использование: /suboverride <user_id> <tier_slug|clear>")
+		b.sendMessage(message.Chat.ID, b.tr(message.From.ID, "Использование: /suboverride <user_id> <tier_slug|clear>"))
 		return
 	}
 
 	userID, err := strconv.ParseInt(args[1], 10, 64)
 	if err != nil {
-		b.sendMessage(message.Chat.ID, "Неверный user_id.")
+		b.sendMessage(message.Chat.ID, b.tr(message.From.ID, "Неверный user_id."))
 		return
 	}
 
 	if _, err := b.subscriptionService.GetUser(userID); err != nil {
-		b.sendMessage(message.Chat.ID, "Пользователь не найден.")
+		b.sendMessage(message.Chat.ID, b.tr(message.From.ID, "Пользователь не найден."))
 		return
 	}
 
@@ -1190,7 +1218,7 @@ func (b *TelegramBot) handleSubCheckAllCommand(message *tgbotapi.Message) {
 		return
 	}
 
-	b.sendMessage(message.Chat.ID, "Запуск полной проверки подписок...")
+	b.sendMessage(message.Chat.ID, b.tr(message.From.ID, "Запуск полной проверки подписок..."))
 
 	chatID := message.Chat.ID
 	service.SafeGo("bot subcheckall", func() {
@@ -1356,7 +1384,7 @@ func (b *TelegramBot) handleSubMemberSweepCommand(message *tgbotapi.Message) {
 		return
 	}
 
-	b.sendMessage(message.Chat.ID, "Запуск sweep реального членства... это займёт несколько минут.")
+	b.sendMessage(message.Chat.ID, b.tr(message.From.ID, "Запуск sweep реального членства... это займёт несколько минут."))
 
 	chatID := message.Chat.ID
 	service.SafeGo("bot member-sweep", func() {
@@ -1392,7 +1420,7 @@ func (b *TelegramBot) handleSubKickDryCommand(message *tgbotapi.Message) {
 		return
 	}
 
-	b.sendMessage(message.Chat.ID, "Запуск dry-run проверки... ничего реально не кикается.")
+	b.sendMessage(message.Chat.ID, b.tr(message.From.ID, "Запуск dry-run проверки... ничего реально не кикается."))
 
 	chatID := message.Chat.ID
 	service.SafeGo("bot kick-dry-run", func() {
@@ -1527,19 +1555,19 @@ func (b *TelegramBot) handleSubPinCommand(message *tgbotapi.Message) {
 
 	args := strings.Fields(message.Text)
 	if len(args) < 2 {
-		b.sendMessage(message.Chat.ID, "Использование: /subpin <anchor_chat_id>")
+		b.sendMessage(message.Chat.ID, b.tr(message.From.ID, "Использование: /subpin <anchor_chat_id>"))
 		return
 	}
 
 	chatID, err := strconv.ParseInt(args[1], 10, 64)
 	if err != nil {
-		b.sendMessage(message.Chat.ID, "Неверный chat_id.")
+		b.sendMessage(message.Chat.ID, b.tr(message.From.ID, "Неверный chat_id."))
 		return
 	}
 
 	chat, err := b.subscriptionService.GetChat(chatID)
 	if err != nil || chat.AnchorForTierID == nil {
-		b.sendMessage(message.Chat.ID, "Чат не зарегистрирован как anchor.")
+		b.sendMessage(message.Chat.ID, b.tr(message.From.ID, "Чат не зарегистрирован как anchor."))
 		return
 	}
 
@@ -1,294 +1,222 @@
 package bot
 
 import (
-	"bytes"
-	"encoding/json"
-	"fmt"
+	"context"
 	"html"
-	"io"
 	"log"
-	"net/http"
 	"strconv"
 	"strings"
-	"sync"
 	"time"
 
 	"ithozyeva/config"
+	"ithozyeva/internal/i18n"
 	"ithozyeva/internal/models"
+	"ithozyeva/internal/repository"
+	"ithozyeva/internal/service"
+	"ithozyeva/internal/utils"
 
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 )
 
-const (
-	summarizeDefaultLimit = 200
-	summarizeDailyLimit   = 5
-)
-
-var openAIClient = &http.Client{Timeout: 120 * time.Second}
-
-// fallbackModels — цепочка моделей для retry: от лучшей к запасным.
-var fallbackModels = []string{
-	"Qwen/Qwen3-235B-A22B-Instruct-2507",
-	"zai-org/GLM-4.7",
-	"ai-sage/GigaChat3-10B-A1.8B",
-	"zai-org/GLM-4.7-Flash",
-	"t-tech/T-pro-it-2.0",
-	"t-tech/T-pro-it-2.1",
-}
-
-// userSummarizeCount — лимит суммаризаций на пользователя в день
-var (
-	userSummarizeCount = make(map[int64]map[string]int) // userID -> date -> count
-	summarizeMu        sync.Mutex
-)
-
-func checkAndIncrementLimit(userID int64) bool {
-	summarizeMu.Lock()
-	defer summarizeMu.Unlock()
-
-	today := time.Now().Format("2006-01-02")
-	if userSummarizeCount[userID] == nil {
-		userSummarizeCount[userID] = make(map[string]int)
-	}
-
-	if userSummarizeCount[userID][today] >= summarizeDailyLimit {
-		return false
-	}
-	userSummarizeCount[userID][today]++
-
-	// Очищаем старые даты
-	for date := range userSummarizeCount[userID] {
-		if date != today {
-			delete(userSummarizeCount[userID], date)
-		}
-	}
-	return true
-}
-
-func getRemainingLimit(userID int64) int {
-	summarizeMu.Lock()
-	defer summarizeMu.Unlock()
-
-	today := time.Now().Format("2006-01-02")
-	if userSummarizeCount[userID] == nil {
-		return summarizeDailyLimit
-	}
-	used := userSummarizeCount[userID][today]
-	remaining := summarizeDailyLimit - used
-	if remaining < 0 {
-		return 0
-	}
-	return remaining
-}
+const summarizeDefaultLimit = 200
 
-type openAIRequest struct {
-	Model       string          `json:"model"`
-	Messages    []openAIMessage `json:"messages"`
-	MaxTokens   int             `json:"max_tokens,omitempty"`
-	Temperature float64         `json:"temperature,omitempty"`
-}
-
-type openAIMessage struct {
-	Role    string `json:"role"`
-	Content string `json:"content"`
-}
-
-type openAIResponse struct {
-	Choices []struct {
-		Message openAIMessage `json:"message"`
-	} `json:"choices"`
-}
-
-// handleSummarizeCommand — /summarize [N|day|week|3d]
+// handleSummarizeCommand — /summarize [N|day|week|3d|ГГГГ-ММ-ДД..ГГГГ-ММ-ДД],
+// ответом на сообщение — ветка обсуждения. Квота и кэш саммари —
+// в Redis (service.SummarizeService): повторный запрос того же окна
+// отдаётся из кэша и квоту не тратит.
 func (b *TelegramBot) handleSummarizeCommand(message *tgbotapi.Message) {
 	deleteMsg := tgbotapi.NewDeleteMessage(message.Chat.ID, message.MessageID)
 	b.bot.Request(deleteMsg)
 
+	lang := b.langOf(message.From.ID)
 	if config.CFG.OpenAIKey == "" {
-		b.SendDirectMessage(message.From.ID, "Суммаризация не настроена: отсутствует OPENAI_API_KEY.")
+		b.SendDirectMessage(message.From.ID, i18n.T(lang, "Суммаризация не настроена: отсутствует OPENAI_API_KEY."))
 		return
 	}
 
-	if !checkAndIncrementLimit(message.From.ID) {
-		b.SendDirectMessage(message.From.ID, fmt.Sprintf("Лимит суммаризаций исчерпан (%d/%d в день). Попробуйте завтра.", summarizeDailyLimit, summarizeDailyLimit))
+	window, err := parseSummarizeWindow(message.CommandArguments(), time.Now())
+	if err != nil {
+		b.SendDirectMessage(message.From.ID, i18n.T(lang, "Диапазон /summarize: %s.", i18n.LocalizeError(lang, err)))
 		return
 	}
-
-	arg := strings.TrimSpace(message.CommandArguments())
-	messages, label, err := b.fetchMessages(message.Chat.ID, arg)
+	messages, labelMsg, err := b.fetchMessages(message, window)
 	if err != nil {
 		log.Printf("Error fetching messages for summarize: %v", err)
-		b.SendDirectMessage(message.From.ID, "Ошибка при получении сообщений.")
+		b.SendDirectMessage(message.From.ID, i18n.T(lang, "Ошибка при получении сообщений."))
 		return
 	}
 
 	if len(messages) == 0 {
-		b.SendDirectMessage(message.From.ID, "Нет сообщений для суммаризации за указанный период.")
+		b.SendDirectMessage(message.From.ID, i18n.T(lang, "Нет сообщений для суммаризации за указанный период."))
 		return
 	}
+	label := labelMsg.In(lang)
 
-	remaining := getRemainingLimit(message.From.ID)
-	b.SendDirectMessage(message.From.ID, fmt.Sprintf("⏳ Суммаризирую %d сообщений (%s) из чата <b>%s</b>...\nОсталось запросов: %d/%d",
-		len(messages), label, html.EscapeString(message.Chat.Title), remaining, summarizeDailyLimit))
+	ctx := context.Background()
+	cacheKey := service.SummaryCacheKey(message.Chat.ID, messages)
+	cached, err := b.summarizeService.GetCachedSummary(ctx, cacheKey)
+	if err != nil {
+		log.Printf("summarize: cache read failed (chat=%d): %v", message.Chat.ID, err)
+	}
+	if cached != nil {
+		b.SendDirectMessage(message.From.ID, formatSummaryResult(lang, message.Chat.Title, len(messages), label, cached.Model, cached.Summary)+
+			"\n\n<i>"+i18n.T(lang, "Из кэша от %s МСК, лимит не списан.", cached.CreatedAt.In(utils.MSKLocation()).Format("15:04"))+"</i>")
+		return
+	}
 
-	var sb strings.Builder
-	for i := len(messages) - 1; i >= 0; i-- {
-		m := messages[i]
-		name := m.TelegramUsername
-		if name == "" {
-			name = m.TelegramFirstName
-		}
-		sb.WriteString(fmt.Sprintf("[%s] %s: %s\n", m.SentAt.Format("02.01 15:04"), name, m.MessageText))
+	now := time.Now()
+	quota, err := b.summarizeService.ConsumeQuota(ctx, message.From.ID, now)
+	if err != nil {
+		log.Printf("summarize: quota check failed (user=%d): %v", message.From.ID, err)
+		b.SendDirectMessage(message.From.ID, i18n.T(lang, "Не удалось проверить лимит суммаризаций, попробуйте позже."))
+		return
 	}
+	if !quota.Allowed {
+		b.SendDirectMessage(message.From.ID, i18n.T(lang, "Лимит суммаризаций исчерпан (%d/%d в день). Попробуйте завтра или повысьте тир подписки.", quota.Used, quota.Limit))
+		return
+	}
+
+	b.SendDirectMessage(message.From.ID, i18n.T(lang, "⏳ Суммаризирую %s (%s) из чата <b>%s</b>...\nОсталось запросов: %d/%d",
+		i18n.Count(lang, len(messages), "сообщение"), label, html.EscapeString(message.Chat.Title), quota.Remaining, quota.Limit))
 
-	summary, usedModel, err := callOpenAIWithRetry(sb.String())
+	summary, usedModel, err := service.SummarizeChatLog(service.FormatChatLog(messages))
 	if err != nil {
 		log.Printf("Error calling OpenAI for summarize (all models failed): %v", err)
-		b.SendDirectMessage(message.From.ID, "Ошибка: все AI-модели недоступны.")
+		if err := b.summarizeService.RefundQuota(ctx, message.From.ID, now); err != nil {
+			log.Printf("summarize: quota refund failed (user=%d): %v", message.From.ID, err)
+		}
+		b.SendDirectMessage(message.From.ID, i18n.T(lang, "Ошибка: все AI-модели недоступны. Запрос не засчитан в лимит."))
 		return
 	}
 
-	// summary НЕ эскейпим: системный промпт OpenAI (см. callOpenAI выше)
-	// явно требует HTML-форматирование (<b>, <i>, <code>) для Telegram —
-	// эскейп превратил бы теги в литералы &lt;b&gt; и сломал отрисовку.
-	// Если модель вернёт битый HTML — Telegram отвергнет parse_mode, но это
-	// уже проблема модели, не bot-кода. chat.Title эскейпим — там HTML
-	// не предусмотрен.
-	result := fmt.Sprintf("📋 <b>Суммаризация чата %s</b>\n(%d сообщений, %s, модель: %s)\n\n%s",
-		html.EscapeString(message.Chat.Title), len(messages), label, usedModel, summary)
-	b.SendDirectMessage(message.From.ID, result)
+	if err := b.summarizeService.CacheSummary(ctx, cacheKey, service.CachedSummary{
+		Summary:   summary,
+		Model:     usedModel,
+		CreatedAt: now,
+	}); err != nil {
+		log.Printf("summarize: cache write failed (chat=%d): %v", message.Chat.ID, err)
+	}
+
+	b.SendDirectMessage(message.From.ID, formatSummaryResult(lang, message.Chat.Title, len(messages), label, usedModel, summary))
+}
+
+// formatSummaryResult — итоговое сообщение с саммари.
+//
+// summary НЕ эскейпим: системный промпт (service.ChatSummarySystemPrompt)
+// явно требует HTML-форматирование (<b>, <i>, <code>) для Telegram —
+// эскейп превратил бы теги в литералы &lt;b&gt; и сломал отрисовку.
+// Если модель вернёт битый HTML — Telegram отвергнет parse_mode, но это
+// уже проблема модели, не bot-кода. chat.Title эскейпим — там HTML
+// не предусмотрен.
+func formatSummaryResult(lang i18n.Lang, chatTitle string, count int, label, model, summary string) string {
+	return i18n.T(lang, "📋 <b>Суммаризация чата %s</b>\n(%s, %s, модель: %s)\n\n%s",
+		html.EscapeString(chatTitle), i18n.Count(lang, count, "сообщение"), label, model, summary)
+}
+
+// Пределы окон /summarize.
+const (
+	summarizeMaxLastN        = 1000
+	summarizeThreadLimit     = 500
+	summarizeRangeMaxDays    = 31
+	summarizeRangeMaxMessage = 1500
+)
+
+// summarizeWindow — какие сообщения суммаризировать: по времени
+// [since, until) и/или последние limit. label переводится при ответе.
+type summarizeWindow struct {
+	since, until time.Time
+	limit        int
+	label        i18n.Message
 }
 
-// fetchMessages возвращает сообщения в зависимости от аргумента:
+// parseSummarizeWindow разбирает аргумент /summarize:
 // "" — последние 200
 // "day" — за сутки
 // "week" — за неделю
 // "3d" — за 3 дня
 // число (50, 100, 500) — последние N (макс 1000)
-func (b *TelegramBot) fetchMessages(chatID int64, arg string) ([]models.ChatMessage, string, error) {
-	switch strings.ToLower(arg) {
+// "2026-10-01..2026-10-07" или "2026-10-01" — дни по МСК включительно
+func parseSummarizeWindow(arg string, now time.Time) (summarizeWindow, error) {
+	arg = strings.ToLower(strings.TrimSpace(arg))
+	switch arg {
 	case "day", "today", "сегодня", "день":
-		since := time.Now().Add(-24 * time.Hour)
-		msgs, err := b.chatActivityService.GetMessagesSince(chatID, since)
-		return msgs, "за сутки", err
-
+		return summarizeWindow{since: now.Add(-24 * time.Hour), label: i18n.Msg("за сутки")}, nil
 	case "week", "неделя":
-		since := time.Now().Add(-7 * 24 * time.Hour)
-		msgs, err := b.chatActivityService.GetMessagesSince(chatID, since)
-		return msgs, "за неделю", err
-
+		return summarizeWindow{since: now.Add(-7 * 24 * time.Hour), label: i18n.Msg("за неделю")}, nil
 	case "3d", "3дня":
-		since := time.Now().Add(-3 * 24 * time.Hour)
-		msgs, err := b.chatActivityService.GetMessagesSince(chatID, since)
-		return msgs, "за 3 дня", err
-
-	default:
-		if n, err := strconv.Atoi(arg); err == nil && n > 0 {
-			if n > 1000 {
-				n = 1000
-			}
-			msgs, err := b.chatActivityService.GetRecentMessages(chatID, n)
-			return msgs, fmt.Sprintf("последние %d", n), err
-		}
-
-		msgs, err := b.chatActivityService.GetRecentMessages(chatID, summarizeDefaultLimit)
-		return msgs, fmt.Sprintf("последние %d", summarizeDefaultLimit), err
-	}
-}
-
-// callOpenAIWithRetry пробует модели по цепочке: сначала из конфига, потом fallback.
-func callOpenAIWithRetry(chatLog string) (summary string, model string, err error) {
-	models := buildModelChain()
-
-	var lastErr error
-	for _, m := range models {
-		summary, err := callOpenAI(chatLog, m)
-		if err == nil {
-			return summary, m, nil
-		}
-		log.Printf("Model %s failed: %v, trying next...", m, err)
-		lastErr = err
+		return summarizeWindow{since: now.Add(-3 * 24 * time.Hour), label: i18n.Msg("за 3 дня")}, nil
 	}
-
-	return "", "", fmt.Errorf("all %d models failed, last error: %w", len(models), lastErr)
-}
-
-func buildModelChain() []string {
-	primary := config.CFG.OpenAIModel
-	if primary == "" {
-		return fallbackModels
+	if strings.Contains(arg, "..") || isISODate(arg) {
+		return parseSummarizeRange(arg, now)
 	}
-
-	chain := []string{primary}
-	for _, m := range fallbackModels {
-		if m != primary {
-			chain = append(chain, m)
+	if n, err := strconv.Atoi(arg); err == nil && n > 0 {
+		if n > summarizeMaxLastN {
+			n = summarizeMaxLastN
 		}
+		return summarizeWindow{limit: n, label: i18n.Msg("последние %d", n)}, nil
 	}
-	return chain
+	return summarizeWindow{limit: summarizeDefaultLimit, label: i18n.Msg("последние %d", summarizeDefaultLimit)}, nil
 }
 
-func callOpenAI(chatLog string, model string) (string, error) {
-	baseURL := config.CFG.OpenAIBaseURL
-	if baseURL == "" {
-		baseURL = "https://foundation-models.api.cloud.ru/v1"
-	}
-
-	reqBody := openAIRequest{
-		Model:       model,
-		MaxTokens:   2500,
-		Temperature: 0.5,
-		Messages: []openAIMessage{
-			{
-				Role: "system",
-				Content: `Ты — помощник, который суммаризирует переписки из Telegram-чатов IT-сообщества.
-Твоя задача — кратко и структурировано изложить основные темы обсуждений, ключевые мнения и выводы.
-Формат: маркированный список основных тем с краткими пояснениями.
-Пиши на русском языке. Будь лаконичен.
-ВАЖНО: Используй HTML-разметку для форматирования (Telegram HTML). Доступные теги: <b>жирный</b>, <i>курсив</i>, <code>код</code>. НЕ используй Markdown (**, __, #, -). Для списков используй символ • в начале строки.`,
-			},
-			{
-				Role:    "user",
-				Content: "Суммаризируй эту переписку:\n\n" + chatLog,
-			},
-		},
-	}
-
-	body, err := json.Marshal(reqBody)
-	if err != nil {
-		return "", fmt.Errorf("marshal request: %w", err)
-	}
+func isISODate(s string) bool {
+	_, err := time.Parse("2006-01-02", s)
+	return err == nil
+}
 
-	req, err := http.NewRequest("POST", baseURL+"/chat/completions", bytes.NewReader(body))
-	if err != nil {
-		return "", fmt.Errorf("create request: %w", err)
+func parseSummarizeRange(arg string, now time.Time) (summarizeWindow, error) {
+	fromStr, toStr, isRange := strings.Cut(arg, "..")
+	if !isRange {
+		toStr = fromStr
 	}
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Authorization", "Bearer "+config.CFG.OpenAIKey)
-
-	resp, err := openAIClient.Do(req)
+	loc := utils.MSKLocation()
+	from, err := time.ParseInLocation("2006-01-02", strings.TrimSpace(fromStr), loc)
 	if err != nil {
-		return "", fmt.Errorf("do request: %w", err)
+		return summarizeWindow{}, i18n.Errorf("не понял дату %q — формат ГГГГ-ММ-ДД", strings.TrimSpace(fromStr))
 	}
-	defer resp.Body.Close()
-
-	respBody, err := io.ReadAll(resp.Body)
+	to, err := time.ParseInLocation("2006-01-02", strings.TrimSpace(toStr), loc)
 	if err != nil {
-		return "", fmt.Errorf("read response: %w", err)
-	}
-
-	if resp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("API error %d: %s", resp.StatusCode, string(respBody))
-	}
-
-	var result openAIResponse
-	if err := json.Unmarshal(respBody, &result); err != nil {
-		return "", fmt.Errorf("unmarshal response: %w", err)
-	}
-
-	if len(result.Choices) == 0 {
-		return "", fmt.Errorf("no choices in response")
-	}
+		return summarizeWindow{}, i18n.Errorf("не понял дату %q — формат ГГГГ-ММ-ДД", strings.TrimSpace(toStr))
+	}
+	switch {
+	case to.Before(from):
+		return summarizeWindow{}, i18n.Errorf("начало диапазона позже конца")
+	case from.After(now):
+		return summarizeWindow{}, i18n.Errorf("диапазон в будущем")
+	case to.Sub(from) >= summarizeRangeMaxDays*24*time.Hour:
+		return summarizeWindow{}, i18n.Errorf("диапазон не длиннее %d дней", summarizeRangeMaxDays)
+	}
+	label := i18n.Msg("за %s", from.Format("02.01.2006"))
+	if !to.Equal(from) {
+		label = i18n.Msg("за %s–%s", from.Format("02.01"), to.Format("02.01.2006"))
+	}
+	return summarizeWindow{
+		since: from,
+		until: to.AddDate(0, 0, 1),
+		limit: summarizeRangeMaxMessage,
+		label: label,
+	}, nil
+}
 
-	return result.Choices[0].Message.Content, nil
+// fetchMessages возвращает сообщения для /summarize. Команда ответом на
+// сообщение — ветка обсуждения вокруг него (аргумент не учитывается); в
+// теме форума окно ограничивается темой.
+func (b *TelegramBot) fetchMessages(message *tgbotapi.Message, w summarizeWindow) ([]models.ChatMessage, i18n.Message, error) {
+	threadID := b.messageThreadID(message)
+	if replyID := service.ReplyToMessageID(message, threadID); replyID != nil {
+		msgs, err := b.chatActivityService.GetThreadMessages(message.Chat.ID, *replyID, summarizeThreadLimit)
+		return msgs, i18n.Msg("ветка обсуждения"), err
+	}
+
+	label := w.label
+	if threadID != 0 {
+		label = i18n.Msg("%s, эта тема", w.label)
+	}
+	msgs, err := b.chatActivityService.FindMessages(repository.ChatMessageQuery{
+		ChatID:   message.Chat.ID,
+		ThreadID: threadID,
+		Since:    w.since,
+		Until:    w.until,
+		Limit:    w.limit,
+	})
+	return msgs, label, err
 }
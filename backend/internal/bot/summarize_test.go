@@ -0,0 +1,95 @@
+package bot
+
+import (
+	"testing"
+	"time"
+
+	"ithozyeva/internal/testutil"
+	"ithozyeva/internal/utils"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+func TestParseSummarizeWindow(t *testing.T) {
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, utils.MSKLocation())
+
+	w, err := parseSummarizeWindow("", now)
+	if err != nil || w.limit != summarizeDefaultLimit || !w.since.IsZero() {
+		t.Fatalf("default: %+v, %v", w, err)
+	}
+	w, _ = parseSummarizeWindow("5000", now)
+	if w.limit != summarizeMaxLastN {
+		t.Fatalf("N must be capped: %+v", w)
+	}
+	w, _ = parseSummarizeWindow("day", now)
+	if !w.since.Equal(now.Add(-24*time.Hour)) || w.limit != 0 {
+		t.Fatalf("day: %+v", w)
+	}
+
+	w, err = parseSummarizeWindow("2026-10-01..2026-10-07", now)
+	if err != nil {
+		t.Fatalf("range: %v", err)
+	}
+	wantSince := time.Date(2026, 10, 1, 0, 0, 0, 0, utils.MSKLocation())
+	wantUntil := time.Date(2026, 10, 8, 0, 0, 0, 0, utils.MSKLocation())
+	if !w.since.Equal(wantSince) || !w.until.Equal(wantUntil) || w.label.String() != "за 01.10–07.10.2026" {
+		t.Fatalf("range: %+v", w)
+	}
+
+	w, err = parseSummarizeWindow("2026-10-05", now)
+	if err != nil || !w.until.Equal(w.since.AddDate(0, 0, 1)) || w.label.String() != "за 05.10.2026" {
+		t.Fatalf("single day: %+v, %v", w, err)
+	}
+}
+
+func TestParseSummarizeWindowErrors(t *testing.T) {
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, utils.MSKLocation())
+	for _, arg := range []string{
+		"2026-10-07..2026-10-01", // конец раньше начала
+		"2026-11-01..2026-11-02", // будущее
+		"2026-01-01..2026-03-01", // длиннее 31 дня
+		"2026-10-01..вчера",
+	} {
+		if _, err := parseSummarizeWindow(arg, now); err == nil {
+			t.Errorf("%q: expected error", arg)
+		}
+	}
+}
+
+func TestParseTopics(t *testing.T) {
+	raw := []byte(`[
+		{"update_id":1,"message":{"message_id":10,"message_thread_id":3,"is_topic_message":true,"chat":{"id":-100}}},
+		{"update_id":2,"message":{"message_id":11,"message_thread_id":4,"chat":{"id":-100}}},
+		{"update_id":3,"callback_query":{"id":"x"}}
+	]`)
+	// Без is_topic_message это ответ в обычной группе, не тема.
+	got := parseTopics(raw)
+	if len(got) != 1 || got[0] != (topicMessage{chatID: -100, messageID: 10, threadID: 3}) {
+		t.Fatalf("parseTopics = %+v", got)
+	}
+
+	if topic, ok := parseTopic([]byte(`{"update_id":4,"message":{"message_id":12,"message_thread_id":5,"is_topic_message":true,"chat":{"id":-100}}}`)); !ok || topic.threadID != 5 {
+		t.Fatalf("parseTopic = %+v, %v", topic, ok)
+	}
+}
+
+func TestTopicThread(t *testing.T) {
+	mr, rdb := testutil.NewMiniRedis(t)
+	b := stateBot(rdb)
+	chat := &tgbotapi.Chat{ID: -100}
+
+	b.rememberTopic(topicMessage{chatID: -100, messageID: 10, threadID: 3})
+	// Тему запомнила одна реплика — видит и другая.
+	if got := stateBot(rdb).messageThreadID(&tgbotapi.Message{MessageID: 10, Chat: chat}); got != 3 {
+		t.Fatalf("topic message: got %d", got)
+	}
+	if got := b.messageThreadID(&tgbotapi.Message{MessageID: 11, Chat: chat}); got != 0 {
+		t.Fatalf("unknown message: got %d", got)
+	}
+
+	// Запись живёт topicTTL.
+	mr.FastForward(topicTTL + time.Minute)
+	if got := b.messageThreadID(&tgbotapi.Message{MessageID: 10, Chat: chat}); got != 0 {
+		t.Fatalf("expired entry survived: %d", got)
+	}
+}
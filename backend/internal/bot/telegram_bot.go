@@ -18,6 +18,7 @@ import (
 
 	"ithozyeva/config"
 	"ithozyeva/database"
+	"ithozyeva/internal/i18n"
 	"ithozyeva/internal/models"
 	"ithozyeva/internal/repository"
 	"ithozyeva/internal/service"
@@ -64,10 +65,10 @@ func getEventLocation(timezone string) *time.Location {
 }
 
 // formatEventDateStr форматирует дату события с учётом его таймзоны
-func formatEventDateStr(eventDate time.Time, timezone string) string {
+func formatEventDateStr(lang i18n.Lang, eventDate time.Time, timezone string) string {
 	loc := getEventLocation(timezone)
 	dateInTz := eventDate.In(loc)
-	return dateInTz.Format("02.01.2006 в 15:04")
+	return dateInTz.Format(i18n.T(lang, "02.01.2006 в 15:04"))
 }
 
 // formatTimezoneLabel возвращает человекочитаемую метку таймзоны
@@ -78,16 +79,6 @@ func formatTimezoneLabel(timezone string) string {
 	return timezone
 }
 
-func formatMonths(months int) string {
-	if months%10 == 1 && months%100 != 11 {
-		return fmt.Sprintf("%d месяц", months)
-	}
-	if months%10 >= 2 && months%10 <= 4 && (months%100 < 10 || months%100 >= 20) {
-		return fmt.Sprintf("%d месяца", months)
-	}
-	return fmt.Sprintf("%d месяцев", months)
-}
-
 // truncateRunes обрезает строку до n рун с многоточием, если что-то срезано.
 // Считаем именно руны, а не байты: иначе на кириллице порежется в середине
 // символа и Telegram отдаст «can't decode message text in UTF-8».
@@ -133,6 +124,22 @@ type TelegramBot struct {
 	supportService              *service.SupportService
 	moderationService           *service.ModerationService
 	pendingReferral             *service.PendingReferralService
+	digestService               *service.ChatDigestService
+	raffleService               *service.RaffleService
+	statementService            *service.PointsStatementService
+	summarizeService            *service.SummarizeService
+	restrictions                *chatRestrictions
+	knowledgeBaseService        *service.KnowledgeBaseService
+	faqService                  *service.FAQService
+	faq                         *faqCache
+	replicaService              *service.BotReplicaService
+	chatState                   *service.BotChatStateService
+	lease                       *service.ReplicaLease
+	langs                       *userLangs
+	platformSearch              *service.PlatformSearchService
+	videoDownloads              *service.VideoDownloadService
+	videoQueue                  *videoQueue
+	shopService                 *service.ShopService
 }
 
 func NewTelegramBot(redisClient *redis.Client) (*TelegramBot, error) {
@@ -164,7 +171,7 @@ func NewTelegramBot(redisClient *redis.Client) (*TelegramBot, error) {
 	moderationService := service.NewModerationServiceWithRedis(redisClient)
 	pendingReferral := service.NewPendingReferralService(redisClient)
 
-	return &TelegramBot{
+	b := &TelegramBot{
 		bot:                         bot,
 		tg_service:                  tg_service,
 		member:                      member_service,
@@ -177,7 +184,24 @@ func NewTelegramBot(redisClient *redis.Client) (*TelegramBot, error) {
 		supportService:              supportService,
 		moderationService:           moderationService,
 		pendingReferral:             pendingReferral,
-	}, nil
+		digestService:               service.NewChatDigestService(),
+		raffleService:               service.NewRaffleService(),
+		statementService:            service.NewPointsStatementService(),
+		summarizeService:            service.NewSummarizeService(redisClient),
+		restrictions:                newChatRestrictions(),
+		knowledgeBaseService:        service.NewKnowledgeBaseService(),
+		faqService:                  service.NewFAQService(redisClient),
+		faq:                         &faqCache{},
+		replicaService:              service.NewBotReplicaService(redisClient),
+		chatState:                   service.NewBotChatStateService(redisClient),
+		langs:                       newUserLangs(),
+		platformSearch:              service.NewPlatformSearchService(),
+		videoDownloads:              service.NewVideoDownloadService(),
+		shopService:                 service.NewShopService(redisClient),
+	}
+	b.lease = newLeaderLease(b.replicaService)
+	b.videoQueue = newVideoQueue(config.CFG.VideoDownloadWorkers, config.CFG.VideoDownloadQueueSize, b.processVideoJob)
+	return b, nil
 }
 
 func (b *TelegramBot) Start() {
@@ -190,6 +214,10 @@ func (b *TelegramBot) Start() {
 	// повторные вызовы при рестарте.
 	b.setupMenuButton()
 
+	// Фоновые задачи ниже крутятся на каждой реплике, но работу делает
+	// только держатель lease лидера в Redis (см. leader.go).
+	b.startLeaderElection()
+
 	// Start birthday checker
 	go b.startBirthdayChecker()
 
@@ -199,7 +227,7 @@ func (b *TelegramBot) Start() {
 	// Start subscription checker
 	go b.startSubscriptionChecker()
 
-	// Финализация протёкших voteban-голосований.
+	// Финализация протёкших voteban-голосований и затихших рейдов.
 	go b.startVotebanWatcher()
 
 	// Публикация авто-сгенерированных чат-квестов: API создаёт квесты для
@@ -207,14 +235,29 @@ func (b *TelegramBot) Start() {
 	// с NL, не с РФ-сервера).
 	go b.startAutoQuestPoster()
 
+	// Публикация еженедельных AI-дайджестов, которые генерирует бэкенд.
+	go b.startDigestPoster()
+
+	// Объявление победителей розыгрышей в основном чате.
+	go b.startRafflePoster()
+	go b.startStatementPoster()
+
 	// Подписка на канал moderation:revoke — backend (RU) кладёт команды
 	// «снять санкцию» из админки, бот выполняет в Telegram.
-	b.moderationService.SubscribeRevoke(context.Background(), b.handleRevokeEvent)
+	// Pub/sub доставляет событие всем репликам — выполняет лидер.
+	b.moderationService.SubscribeRevoke(context.Background(), func(ev service.ModerationRevokeEvent) {
+		if b.isLeader() {
+			b.handleRevokeEvent(ev)
+		}
+	})
 
 	// Слушаем Redis pub/sub от бэкенда: когда админ через UI привязывает чат
 	// к новому тиру, приходит событие — бот рассылает invite-ссылки всем
 	// пользователям с подходящим тиром (та же логика, что и в /subaddchat).
 	b.subscriptionService.SubscribeNewChatAccess(context.Background(), func(ev service.NewChatAccessEvent) {
+		if !b.isLeader() {
+			return
+		}
 		chat, err := b.subscriptionService.GetChat(ev.ChatID)
 		if err != nil {
 			log.Printf("new-chat-access: chat %d not found: %v", ev.ChatID, err)
@@ -223,183 +266,222 @@ func (b *TelegramBot) Start() {
 		b.notifyNewChatAccess(ev.ChatID, chat.Title, ev.MinTierLevel, subscriptionAdminID())
 	})
 
-	u := tgbotapi.NewUpdate(0)
-	u.Timeout = 60
-	u.AllowedUpdates = []string{"message", "callback_query", "chat_member", "my_chat_member"}
+	// Новые заказы магазина наград (API публикует в Redis) — админу в личку.
+	b.shopService.SubscribeOrders(context.Background(), func(ev service.ShopOrderEvent) {
+		if b.isLeader() {
+			b.notifyShopOrder(ev)
+		}
+	})
 
-	updates := b.bot.GetUpdatesChan(u)
+	allowed := []string{"message", "callback_query", "inline_query", "chat_member", "my_chat_member"}
+	if config.CFG.TelegramWebhookURL != "" {
+		b.serveWebhook(allowed)
+		return
+	}
+	b.pollUpdates(allowed)
+}
 
-	for update := range updates {
-		// Обработка изменений участников чатов (подписки)
-		if update.ChatMember != nil {
-			go b.handleChatMemberUpdated(update.ChatMember)
-			continue
-		}
+// handleUpdate — обработка одного апдейта. Зовётся из воркеров
+// updateDispatcher: апдейты одного чата идут по порядку, разных — параллельно.
+func (b *TelegramBot) handleUpdate(update tgbotapi.Update) {
+	// Язык автора — для ответов ему (см. i18n.go).
+	b.observeUser(update.SentFrom())
 
-		// Обработка изменений бота в чатах
-		if update.MyChatMember != nil {
-			go b.handleMyChatMemberUpdated(update.MyChatMember)
-			continue
-		}
+	// Обработка изменений участников чатов (подписки). Синхронно, на
+	// воркере чата: вступления одного чата идут по порядку, как сообщения.
+	if update.ChatMember != nil {
+		b.handleChatMemberUpdated(update.ChatMember)
+		return
+	}
 
-		// Обработка callback кнопок
-		if update.CallbackQuery != nil {
-			b.handleCallbackQuery(update.CallbackQuery)
-			continue
-		}
+	// Обработка изменений бота в чатах
+	if update.MyChatMember != nil {
+		b.handleMyChatMemberUpdated(update.MyChatMember)
+		return
+	}
 
-		if update.Message == nil {
-			continue
-		}
+	// Обработка callback кнопок
+	if update.CallbackQuery != nil {
+		b.handleCallbackQuery(update.CallbackQuery)
+		return
+	}
 
-		// Трекинг активности чатов — для каждого сообщения (асинхронно, чтобы не блокировать обработку)
-		go b.chatActivityService.TrackMessage(update.Message)
+	// Inline-запросы «@бот ...» из любых чатов (см. inline.go)
+	if update.InlineQuery != nil {
+		b.handleInlineQuery(update.InlineQuery)
+		return
+	}
 
-		// Обработка ссылок на короткие видео (Reels, TikTok, Shorts)
-		if update.Message.Text != "" {
-			if urls := extractVideoURLs(update.Message.Text); len(urls) > 0 {
-				go b.handleVideoURLs(update.Message, urls)
-			}
-		}
+	if update.Message == nil {
+		return
+	}
 
-		// Обработка новых участников чата
-		if update.Message.NewChatMembers != nil {
-			for _, newMember := range update.Message.NewChatMembers {
-				b.handleNewChatMember(update.Message.Chat.ID, &newMember)
-			}
-			b.deleteServiceMessage(update.Message.Chat.ID, update.Message.MessageID)
-			continue
-		}
+	// Трекинг активности чатов — для каждого сообщения (асинхронно, чтобы не блокировать обработку)
+	go b.chatActivityService.TrackMessage(update.Message, b.messageThreadID(update.Message))
 
-		// Сообщение «X вышел(а) из группы» — тоже убираем в наших чатах.
-		if update.Message.LeftChatMember != nil {
-			b.deleteServiceMessage(update.Message.Chat.ID, update.Message.MessageID)
-			continue
-		}
+	// Политика чата: ограничения новичков и rate limit. Проверяем до
+	// скачивания видео и команд — удалённое сообщение дальше не идёт.
+	if b.enforceChatRestrictions(update.Message) {
+		return
+	}
 
-		// Команда /chatid — отправляет ID чата владельцу и удаляет сообщение
-		if update.Message.IsCommand() && update.Message.Command() == "chatid" {
-			b.handleChatIDCommand(update.Message)
-			continue
+	// Обработка ссылок на короткие видео (Reels, TikTok, Shorts, VK, X, Reddit)
+	if update.Message.Text != "" {
+		if links := b.videoDownloads.ExtractLinks(update.Message.Text); len(links) > 0 {
+			go b.handleVideoURLs(update.Message, links)
 		}
+		// Автоответ FAQ или статья из базы знаний на вопрос
+		go b.handleAutoReplies(update.Message)
+	}
 
-		// Команда /summarize — суммаризация чата через AI
-		if update.Message.IsCommand() && update.Message.Command() == "summarize" {
-			go b.handleSummarizeCommand(update.Message)
-			continue
+	// Обработка новых участников чата
+	if update.Message.NewChatMembers != nil {
+		for _, newMember := range update.Message.NewChatMembers {
+			b.handleNewChatMember(update.Message.Chat.ID, &newMember)
 		}
+		b.deleteServiceMessage(update.Message.Chat.ID, update.Message.MessageID)
+		return
+	}
 
-		// Команда /highlight — сохранение сообщения как хайлайт
-		if update.Message.IsCommand() && update.Message.Command() == "highlight" {
-			b.handleHighlightCommand(update.Message)
-			continue
-		}
+	// Сообщение «X вышел(а) из группы» — тоже убираем в наших чатах.
+	if update.Message.LeftChatMember != nil {
+		b.deleteServiceMessage(update.Message.Chat.ID, update.Message.MessageID)
+		return
+	}
 
-		// Команда /whois — информация об участнике в групповых чатах
-		if update.Message.IsCommand() && update.Message.Command() == "whois" {
-			b.handleWhoisCommand(update.Message)
-			continue
-		}
+	// Команда /chatid — отправляет ID чата владельцу и удаляет сообщение
+	if update.Message.IsCommand() && update.Message.Command() == "chatid" {
+		b.handleChatIDCommand(update.Message)
+		return
+	}
 
-		// Модерационные команды (работают в групповых чатах).
-		if update.Message.IsCommand() {
-			switch update.Message.Command() {
-			case "ban":
-				b.handleBanCommand(update.Message)
-				continue
-			case "unban":
-				b.handleUnbanCommand(update.Message)
-				continue
-			case "mute":
-				b.handleMuteCommand(update.Message)
-				continue
-			case "cleanup":
-				b.handleCleanupCommand(update.Message)
-				continue
-			case "voteban":
-				b.handleVotebanCommand(update.Message)
-				continue
-			case "globalban":
-				b.handleGlobalBanCommand(update.Message)
-				continue
-			case "globalunban":
-				b.handleGlobalUnbanCommand(update.Message)
-				continue
-			case "globalbans":
-				b.handleGlobalBansListCommand(update.Message)
-				continue
-			}
-		}
+	// Команда /summarize — суммаризация чата через AI
+	if update.Message.IsCommand() && update.Message.Command() == "summarize" {
+		go b.handleSummarizeCommand(update.Message)
+		return
+	}
 
-		// Голое «/слово» в группе (без аргументов и без другого текста):
-		// удаляем, чтобы клик по подсвеченной команде не превращался в цепочку спама.
-		// Наши команды обработаны выше и сюда не падают.
-		if update.Message.Chat.Type != "private" {
-			text := strings.TrimSpace(update.Message.Text)
-			if strings.HasPrefix(text, "/") && !strings.ContainsAny(text, " \t\n") {
-				b.deleteBareCommand(update.Message.Chat.ID, update.Message.MessageID)
-				continue
-			}
-		}
+	// Команда /highlight — сохранение сообщения как хайлайт
+	if update.Message.IsCommand() && update.Message.Command() == "highlight" {
+		b.handleHighlightCommand(update.Message)
+		return
+	}
 
-		// Бот отвечает только в личных сообщениях
-		if update.Message.Chat.Type != "private" {
-			continue
+	// Команда /whois — информация об участнике в групповых чатах
+	if update.Message.IsCommand() && update.Message.Command() == "whois" {
+		b.handleWhoisCommand(update.Message)
+		return
+	}
+
+	// Модерационные команды (работают в групповых чатах).
+	if update.Message.IsCommand() {
+		switch update.Message.Command() {
+		case "ban":
+			b.handleBanCommand(update.Message)
+			return
+		case "unban":
+			b.handleUnbanCommand(update.Message)
+			return
+		case "mute":
+			b.handleMuteCommand(update.Message)
+			return
+		case "cleanup":
+			b.handleCleanupCommand(update.Message)
+			return
+		case "voteban":
+			b.handleVotebanCommand(update.Message)
+			return
+		case "globalban":
+			b.handleGlobalBanCommand(update.Message)
+			return
+		case "globalunban":
+			b.handleGlobalUnbanCommand(update.Message)
+			return
+		case "globalbans":
+			b.handleGlobalBansListCommand(update.Message)
+			return
+		case "raid":
+			b.handleRaidCommand(update.Message)
+			return
+		case "modsettings":
+			b.handleModSettingsCommand(update.Message)
+			return
+		case "chatpolicy":
+			b.handleChatPolicyCommand(update.Message)
+			return
 		}
+	}
 
-		// Открытый саппорт-тикет перехватывает следующее сообщение,
-		// кроме команд (на случай если юзер решит нажать /cancel
-		// или /start ещё раз). Команды обрабатываем как обычно.
-		if !update.Message.IsCommand() && b.handleSupportIncoming(update.Message) {
-			continue
+	// Голое «/слово» в группе (без аргументов и без другого текста):
+	// удаляем, чтобы клик по подсвеченной команде не превращался в цепочку спама.
+	// Наши команды обработаны выше и сюда не падают.
+	if update.Message.Chat.Type != "private" {
+		text := strings.TrimSpace(update.Message.Text)
+		if strings.HasPrefix(text, "/") && !strings.ContainsAny(text, " \t\n") {
+			b.deleteBareCommand(update.Message.Chat.ID, update.Message.MessageID)
+			return
 		}
+	}
 
-		if update.Message.IsCommand() {
-			switch update.Message.Command() {
-			case "start":
-				b.handleStartCommand(update.Message)
-			case "mypoints":
-				b.handleMyPointsCommand(update.Message)
-			case "events":
-				b.handleEventsCommand(update.Message)
-			case "sub":
-				b.handleSubCommand(update.Message)
-			case "substatus":
-				b.handleSubStatusCommand(update.Message)
-			case "mygroups":
-				b.handleMyGroupsCommand(update.Message)
-			// Admin subscription commands
-			case "subchats":
-				b.handleSubChatsCommand(update.Message)
-			case "subtiers":
-				b.handleSubTiersCommand(update.Message)
-			case "subaddchat":
-				b.handleSubAddChatCommand(update.Message)
-			case "subsetanchor":
-				b.handleSubSetAnchorCommand(update.Message)
-			case "subremovechat":
-				b.handleSubRemoveChatCommand(update.Message)
-			case "subusers":
-				b.handleSubUsersCommand(update.Message)
-			case "subuserinfo":
-				b.handleSubUserInfoCommand(update.Message)
-			case "suboverride":
-				b.handleSubOverrideCommand(update.Message)
-			case "subcheckall":
-				b.handleSubCheckAllCommand(update.Message)
-			case "submembersweep":
-				b.handleSubMemberSweepCommand(update.Message)
-			case "subkickdry":
-				b.handleSubKickDryCommand(update.Message)
-			case "substats":
-				b.handleSubStatsCommand(update.Message)
-			case "subpin":
-				b.handleSubPinCommand(update.Message)
-			case "cancel":
-				b.handleCancelCommand(update.Message)
-			case "help":
-				b.handleHelpCommand(update.Message)
-			}
+	// Бот отвечает только в личных сообщениях
+	if update.Message.Chat.Type != "private" {
+		return
+	}
+
+	// Открытый саппорт-тикет перехватывает следующее сообщение,
+	// кроме команд (на случай если юзер решит нажать /cancel
+	// или /start ещё раз). Команды обрабатываем как обычно.
+	if !update.Message.IsCommand() && b.handleSupportIncoming(update.Message) {
+		return
+	}
+
+	if update.Message.IsCommand() {
+		switch update.Message.Command() {
+		case "start":
+			b.handleStartCommand(update.Message)
+		case "mypoints":
+			b.handleMyPointsCommand(update.Message)
+		case "events":
+			b.handleEventsCommand(update.Message)
+		case "sub":
+			b.handleSubCommand(update.Message)
+		case "substatus":
+			b.handleSubStatusCommand(update.Message)
+		case "mygroups":
+			b.handleMyGroupsCommand(update.Message)
+		// Admin subscription commands
+		case "subchats":
+			b.handleSubChatsCommand(update.Message)
+		case "subtiers":
+			b.handleSubTiersCommand(update.Message)
+		case "subaddchat":
+			b.handleSubAddChatCommand(update.Message)
+		case "subsetanchor":
+			b.handleSubSetAnchorCommand(update.Message)
+		case "subremovechat":
+			b.handleSubRemoveChatCommand(update.Message)
+		case "subusers":
+			b.handleSubUsersCommand(update.Message)
+		case "subuserinfo":
+			b.handleSubUserInfoCommand(update.Message)
+		case "suboverride":
+			b.handleSubOverrideCommand(update.Message)
+		case "subcheckall":
+			b.handleSubCheckAllCommand(update.Message)
+		case "submembersweep":
+			b.handleSubMemberSweepCommand(update.Message)
+		case "subkickdry":
+			b.handleSubKickDryCommand(update.Message)
+		case "substats":
+			b.handleSubStatsCommand(update.Message)
+		case "subpin":
+			b.handleSubPinCommand(update.Message)
+		case "cancel":
+			b.handleCancelCommand(update.Message)
+		case "language":
+			b.handleLanguageCommand(update.Message)
+		case "help":
+			b.handleHelpCommand(update.Message)
 		}
 	}
 }
@@ -421,7 +503,7 @@ func (b *TelegramBot) setupMenuButton() {
 		WebApp struct {
 			URL string `json:"url"`
 		} `json:"web_app"`
-	}{Type: "web_app", Text: "Открыть платформу"}
+	}{Type: "web_app", Text: i18n.T(i18n.Default, "Открыть платформу")}
 	menuButton.WebApp.URL = miniAppURL
 
 	payload, err := json.Marshal(menuButton)
@@ -445,47 +527,58 @@ func (b *TelegramBot) registerCommands() {
 	// /mypoints, /events) запускаются через inline-кнопки из /start —
 	// так меню не растягивается на пол-экрана. Сами команды продолжают
 	// работать как fallback для тех, кто набирает их руками.
-	commands := []tgbotapi.BotCommand{
-		{Command: "start", Description: "Открыть меню бота"},
-		{Command: "summarize", Description: "Саммари чата (day/week/3d/число)"},
-		{Command: "whois", Description: "Кто этот участник"},
-		{Command: "help", Description: "Помощь"},
-	}
-	cfg := tgbotapi.NewSetMyCommands(commands...)
-	if _, err := b.bot.Request(cfg); err != nil {
-		log.Printf("Error registering bot commands: %v", err)
+	//
+	// Меню регистрируем на каждом языке: Telegram сам показывает версию
+	// по language_code клиента, русская — для всех остальных.
+	for _, lang := range i18n.Supported {
+		commands := []tgbotapi.BotCommand{
+			{Command: "start", Description: i18n.T(lang, "Открыть меню бота")},
+			{Command: "summarize", Description: i18n.T(lang, "Саммари чата (day/week/3d/число/даты, ответом — ветка)")},
+			{Command: "whois", Description: i18n.T(lang, "Кто этот участник")},
+			{Command: "language", Description: i18n.T(lang, "Язык бота")},
+			{Command: "help", Description: i18n.T(lang, "Помощь")},
+		}
+		cfg := tgbotapi.NewSetMyCommands(commands...)
+		if lang != i18n.Default {
+			cfg.LanguageCode = string(lang)
+		}
+		if _, err := b.bot.Request(cfg); err != nil {
+			log.Printf("Error registering bot commands (%s): %v", lang, err)
+		}
 	}
 }
 
 func (b *TelegramBot) handleMyPointsCommand(message *tgbotapi.Message) {
 	member, err := b.member.GetByTelegramID(message.From.ID)
 	if err != nil {
-		b.sendMessage(message.Chat.ID, "Вы не зарегистрированы на платформе. Используйте /start для авторизации.")
+		b.sendMessage(message.Chat.ID, b.tr(message.From.ID, "Вы не зарегистрированы на платформе. Используйте /start для авторизации."))
 		return
 	}
 
 	pointsSvc := service.NewPointsService()
 	balance, err := pointsSvc.GetBalance(member.Id)
 	if err != nil {
-		b.sendMessage(message.Chat.ID, "Ошибка при получении баллов.")
+		b.sendMessage(message.Chat.ID, b.tr(message.From.ID, "Ошибка при получении баллов."))
 		return
 	}
 
-	text := fmt.Sprintf("Ваш баланс: %d баллов", balance)
+	lang := b.langOf(message.From.ID)
+	text := i18n.T(lang, "Ваш баланс: %s", i18n.Count(lang, balance, "балл"))
 	b.sendMessage(message.Chat.ID, text)
 }
 
 func (b *TelegramBot) handleEventsCommand(message *tgbotapi.Message) {
 	events, err := b.eventService.GetUpcomingEvents(3)
 	if err != nil || len(events) == 0 {
-		b.sendMessage(message.Chat.ID, "Ближайших событий не найдено.")
+		b.sendMessage(message.Chat.ID, b.tr(message.From.ID, "Ближайших событий не найдено."))
 		return
 	}
 
+	lang := b.langOf(message.From.ID)
 	var builder strings.Builder
-	builder.WriteString("<b>Ближайшие события:</b>\n\n")
+	builder.WriteString(i18n.T(lang, "<b>Ближайшие события:</b>") + "\n\n")
 	for _, event := range events {
-		dateStr := formatEventDateStr(event.Date, event.Timezone)
+		dateStr := formatEventDateStr(lang, event.Date, event.Timezone)
 		tzLabel := formatTimezoneLabel(event.Timezone)
 		builder.WriteString(fmt.Sprintf("📆 <b>%s</b>\n%s (%s)\n\n", event.Title, dateStr, tzLabel))
 	}
@@ -496,39 +589,44 @@ func (b *TelegramBot) handleEventsCommand(message *tgbotapi.Message) {
 }
 
 func (b *TelegramBot) handleHelpCommand(message *tgbotapi.Message) {
-	text := "Подписка, чаты, баллы, события, связь с админом — всё через /start с кнопками.\n\n" +
-		"Вспомогательное в группах:\n" +
-		"/summarize [day|week|3d|N] — AI-саммари чата (5/день на юзера)\n" +
-		"/whois — кто участник (reply или /whois @username)\n" +
-		"/voteban @username — голосование за кик из чата на час (одно голосование на чат одновременно; порог 15% активных за 7 дней, clamp 3-10; симметрия за/против; cooldown 5 мин в чате и 30 мин на инициатора)\n\n" +
-		"Модерация (админам чата и платформы):\n" +
-		"/ban [duration] — бан в этом чате (reply). Пример: /ban 1h, /ban 1d. Без аргумента — навсегда\n" +
-		"/unban — разбан (reply, /unban @user или /unban <id>)\n" +
-		"/mute [duration] — мут (reply). Пример: /mute 30m\n" +
-		"/cleanup [period] — удалить сообщения юзера в этом чате за период (reply, по умолчанию 24h)"
+	lang := b.langOf(message.From.ID)
+	text := i18n.T(lang, "Подписка, чаты, баллы, события, связь с админом — всё через /start с кнопками.\n\n"+
+		"Вспомогательное в группах:\n"+
+		"/summarize [day|week|3d|N|2026-10-01..2026-10-07] — AI-саммари чата или темы; ответом на сообщение — его ветки (дневной лимит по тиру подписки, повтор — из кэша)\n"+
+		"/whois — кто участник (reply или /whois @username)\n"+
+		"/voteban @username — голосование за кик из чата на час (одно голосование на чат одновременно; порог 15% активных за 7 дней, clamp 3-10; симметрия за/против; голоса взвешены по репутации 0.5–2; стаж от 3 дней; участников с высокой репутацией не выносят; cooldown 5 мин в чате и 30 мин на инициатора)\n\n"+
+		"Модерация (админам чата и платформы; волонтёрам из /modsettings — по выданным полномочиям):\n"+
+		"/ban [duration] — бан в этом чате (reply). Пример: /ban 1h, /ban 1d. Без аргумента — навсегда\n"+
+		"/unban — разбан (reply, /unban @user или /unban <id>)\n"+
+		"/mute [duration] — мут (reply). Пример: /mute 30m\n"+
+		"/cleanup [period] — удалить сообщения юзера в этом чате за период (reply; период по умолчанию — из /modsettings, изначально 24h)\n"+
+		"/raid [on|off|rollback] — режим рейда: включается сам при 10+ вступлениях за минуту (новички ограничены, инвайты закрыты); rollback — забанить всех вступивших в окно, off — снять ограничения\n"+
+		"/modsettings — настройки модерации чата (окно и санкция voteban, мут и период /cleanup по умолчанию) и модераторы-волонтёры с полномочиями mute/ban/cleanup\n"+
+		"/chatpolicy — ограничения чата: новичкам первые N дней без ссылок/медиа/форвардов, rate limit сообщений, освобождение подписчиков с тиром\n"+
+		"/language [ru|en] — язык бота")
 
 	if b.isAdmin(message.From.ID) {
-		text += "\n\nАдмин-команды подписок:\n" +
-			"/subtiers - Список тиров\n" +
-			"/subchats - Зарегистрированные чаты\n" +
-			"/subaddchat <chat_id> <tier_slug> [anchor] - Добавить чат\n" +
-			"/subsetanchor <chat_id> <tier_slug|clear> - Установить anchor\n" +
-			"/subremovechat <chat_id> - Удалить чат\n" +
-			"/subusers [page] - Список пользователей\n" +
-			"/subuserinfo <user_id> - Инфо о пользователе\n" +
-			"/suboverride <user_id> <tier_slug|clear> - Ручной тир\n" +
-			"/subcheckall - Проверить всех\n" +
-			"/submembersweep - Backfill реального членства (~10 мин)\n" +
-			"/subkickdry - Dry-run кика: показать, кого удалили бы, без действий\n" +
-			"/substats - Статистика\n" +
-			"/subpin <anchor_chat_id> - Запостить и закрепить приветствие в anchor-чате"
+		text += "\n\n" + i18n.T(lang, "Админ-команды подписок:\n"+
+			"/subtiers - Список тиров\n"+
+			"/subchats - Зарегистрированные чаты\n"+
+			"/subaddchat <chat_id> <tier_slug> [anchor] - Добавить чат\n"+
+			"/subsetanchor <chat_id> <tier_slug|clear> - Установить anchor\n"+
+			"/subremovechat <chat_id> - Удалить чат\n"+
+			"/subusers [page] - Список пользователей\n"+
+			"/subuserinfo <user_id> - Инфо о пользователе\n"+
+			"/suboverride <user_id> <tier_slug|clear> - Ручной тир\n"+
+			"/subcheckall - Проверить всех\n"+
+			"/submembersweep - Backfill реального членства (~10 мин)\n"+
+			"/subkickdry - Dry-run кика: показать, кого удалили бы, без действий\n"+
+			"/substats - Статистика\n"+
+			"/subpin <anchor_chat_id> - Запостить и закрепить приветствие в anchor-чате")
 	}
 
 	if b.isSubscriptionAdmin(message.From.ID) {
-		text += "\n\nGlobal-бан (только super-admin):\n" +
-			"/globalban (reply | @user | <id>) [duration] [reason] — забанить во всех чатах сразу\n" +
-			"/globalunban (reply | @user | <id>) — снять глобальный бан\n" +
-			"/globalbans — список активных глобальных банов"
+		text += "\n\n" + i18n.T(lang, "Global-бан (только super-admin):\n"+
+			"/globalban (reply | @user | <id>) [duration] [reason] — забанить во всех чатах сразу\n"+
+			"/globalunban (reply | @user | <id>) — снять глобальный бан\n"+
+			"/globalbans — список активных глобальных банов")
 	}
 
 	b.sendMessage(message.Chat.ID, text)
@@ -554,7 +652,7 @@ func (b *TelegramBot) handleWhoisCommand(message *tgbotapi.Message) {
 				}
 				return
 			}
-			msg := tgbotapi.NewMessage(message.Chat.ID, "Ответьте на сообщение командой /whois или укажите username: /whois @username")
+			msg := tgbotapi.NewMessage(message.Chat.ID, b.tr(message.From.ID, "Ответьте на сообщение командой /whois или укажите username: /whois @username"))
 			msg.ReplyToMessageID = message.MessageID
 			b.bot.Send(msg)
 			return
@@ -586,7 +684,7 @@ func (b *TelegramBot) handleWhoisCommand(message *tgbotapi.Message) {
 			}
 			return
 		}
-		msg := tgbotapi.NewMessage(message.Chat.ID, "Участник не найден на платформе.")
+		msg := tgbotapi.NewMessage(message.Chat.ID, b.tr(message.From.ID, "Участник не найден на платформе."))
 		msg.ReplyToMessageID = message.MessageID
 		b.bot.Send(msg)
 		return
@@ -595,6 +693,8 @@ func (b *TelegramBot) handleWhoisCommand(message *tgbotapi.Message) {
 	// Формируем карточку участника. Все пользовательские поля экранируем,
 	// иначе Telegram при ParseMode=HTML ломается на тегах из bio/компании
 	// («Unsupported start tag "script"…») и сообщение не отправляется.
+	// Карточку видит весь чат — язык берём того, кто спросил.
+	lang := b.langOf(message.From.ID)
 	var builder strings.Builder
 
 	name := strings.TrimSpace(fmt.Sprintf("%s %s", member.FirstName, member.LastName))
@@ -632,13 +732,13 @@ func (b *TelegramBot) handleWhoisCommand(message *tgbotapi.Message) {
 	// Давность участия
 	months := int(time.Since(member.CreatedAt).Hours() / 24 / 30)
 	if months > 0 {
-		builder.WriteString(fmt.Sprintf("\n📅 С нами: %s", formatMonths(months)))
+		builder.WriteString("\n📅 " + i18n.T(lang, "С нами: %s", i18n.Count(lang, months, "месяц")))
 	} else {
 		days := int(time.Since(member.CreatedAt).Hours() / 24)
 		if days > 0 {
-			builder.WriteString(fmt.Sprintf("\n📅 С нами: %d дн.", days))
+			builder.WriteString("\n📅 " + i18n.T(lang, "С нами: %s", i18n.Count(lang, days, "день")))
 		} else {
-			builder.WriteString("\n📅 С нами: сегодня")
+			builder.WriteString("\n📅 " + i18n.T(lang, "С нами: сегодня"))
 		}
 	}
 
@@ -646,7 +746,7 @@ func (b *TelegramBot) handleWhoisCommand(message *tgbotapi.Message) {
 	kudosRepo := repository.NewKudosRepository()
 	kudosCount, kudosErr := kudosRepo.GetReceivedCount(member.Id)
 	if kudosErr == nil && kudosCount > 0 {
-		builder.WriteString(fmt.Sprintf("\n💜 Благодарностей: %d", kudosCount))
+		builder.WriteString("\n💜 " + i18n.T(lang, "Благодарностей: %d", kudosCount))
 	}
 
 	// Менторская информация
@@ -656,7 +756,7 @@ func (b *TelegramBot) handleWhoisCommand(message *tgbotapi.Message) {
 			builder.WriteString(fmt.Sprintf("\n💼 %s", html.EscapeString(mentor.Occupation)))
 		}
 		if mentor.Experience != "" {
-			builder.WriteString(fmt.Sprintf("\n📊 Опыт: %s", html.EscapeString(mentor.Experience)))
+			builder.WriteString("\n📊 " + i18n.T(lang, "Опыт: %s", html.EscapeString(mentor.Experience)))
 		}
 		if len(mentor.ProfTags) > 0 {
 			var tags []string
@@ -673,7 +773,7 @@ func (b *TelegramBot) handleWhoisCommand(message *tgbotapi.Message) {
 		if !strings.HasPrefix(platformURL, "http://") && !strings.HasPrefix(platformURL, "https://") {
 			platformURL = "https://" + platformURL
 		}
-		builder.WriteString(fmt.Sprintf("\n\n🔗 <a href=\"%s/members/%d\">Профиль на платформе</a>", platformURL, member.Id))
+		builder.WriteString(fmt.Sprintf("\n\n🔗 <a href=\"%s/members/%d\">%s</a>", platformURL, member.Id, i18n.T(lang, "Профиль на платформе")))
 	}
 
 	msg := tgbotapi.NewMessage(message.Chat.ID, builder.String())
@@ -733,7 +833,9 @@ func (b *TelegramBot) startBirthdayChecker() {
 		}
 		time.Sleep(time.Until(next))
 
-		b.checkBirthdays()
+		if b.isLeader() {
+			b.checkBirthdays()
+		}
 	}
 }
 
@@ -864,52 +966,53 @@ func (b *TelegramBot) handleReferralStart(telegramUserID int64, code string) {
 // для подписчиков — полный набор пунктов (чаты, события, баллы), для UNSUBSCRIBER —
 // прогрев (тарифы, проверка оплаты, платформа для preview).
 func (b *TelegramBot) sendWelcomeWizard(chatID int64, isSubscriber bool) {
+	lang := b.langOf(chatID)
 	var text string
 	var keyboard [][]tgbotapi.InlineKeyboardButton
 
 	if isSubscriber {
-		text = "<b>Привет! Я бот сообщества IT-X.</b>\n\n" +
-			"Через меня можно:\n" +
-			"• получить инвайт-ссылки в чаты по твоей подписке,\n" +
-			"• посмотреть свой тир, баллы и ближайшие события,\n" +
-			"• авторизоваться на платформе " +
-			"<a href=\"https://ithozyaeva.ru\">ithozyaeva.ru</a>,\n" +
-			"• написать админу.\n\n" +
-			"Выбери, с чего начать:"
+		text = i18n.T(lang, "<b>Привет! Я бот сообщества IT-X.</b>\n\n"+
+			"Через меня можно:\n"+
+			"• получить инвайт-ссылки в чаты по твоей подписке,\n"+
+			"• посмотреть свой тир, баллы и ближайшие события,\n"+
+			"• авторизоваться на платформе "+
+			"<a href=\"https://ithozyaeva.ru\">ithozyaeva.ru</a>,\n"+
+			"• написать админу.\n\n"+
+			"Выбери, с чего начать:")
 		keyboard = [][]tgbotapi.InlineKeyboardButton{
 			tgbotapi.NewInlineKeyboardRow(
-				tgbotapi.NewInlineKeyboardButtonData("🔑 Проверить подписку", "wiz:sub"),
-				tgbotapi.NewInlineKeyboardButtonData("📚 Мои чаты", "wiz:status"),
+				tgbotapi.NewInlineKeyboardButtonData(i18n.T(lang, "🔑 Проверить подписку"), "wiz:sub"),
+				tgbotapi.NewInlineKeyboardButtonData(i18n.T(lang, "📚 Мои чаты"), "wiz:status"),
 			),
 			tgbotapi.NewInlineKeyboardRow(
-				tgbotapi.NewInlineKeyboardButtonData("🆕 Куда ещё зайти", "wiz:mygroups"),
-				tgbotapi.NewInlineKeyboardButtonData("🎓 События", "wiz:events"),
+				tgbotapi.NewInlineKeyboardButtonData(i18n.T(lang, "🆕 Куда ещё зайти"), "wiz:mygroups"),
+				tgbotapi.NewInlineKeyboardButtonData(i18n.T(lang, "🎓 События"), "wiz:events"),
 			),
 			tgbotapi.NewInlineKeyboardRow(
-				tgbotapi.NewInlineKeyboardButtonData("⭐ Мои баллы", "wiz:points"),
-				tgbotapi.NewInlineKeyboardButtonData("🌐 Платформа", "wiz:auth"),
+				tgbotapi.NewInlineKeyboardButtonData(i18n.T(lang, "⭐ Мои баллы"), "wiz:points"),
+				tgbotapi.NewInlineKeyboardButtonData(i18n.T(lang, "🌐 Платформа"), "wiz:auth"),
 			),
 			tgbotapi.NewInlineKeyboardRow(
-				tgbotapi.NewInlineKeyboardButtonData("📨 Написать админу", "wiz:support"),
-				tgbotapi.NewInlineKeyboardButtonData("❓ Как это работает", "wiz:help"),
+				tgbotapi.NewInlineKeyboardButtonData(i18n.T(lang, "📨 Написать админу"), "wiz:support"),
+				tgbotapi.NewInlineKeyboardButtonData(i18n.T(lang, "❓ Как это работает"), "wiz:help"),
 			),
 		}
 	} else {
-		text = "<b>Привет! Я бот сообщества IT-X.</b>\n\n" +
-			"Здесь оформляется подписка на закрытые чаты, менторство и платформу " +
-			"<a href=\"https://ithozyaeva.ru\">ithozyaeva.ru</a>.\n\n" +
-			"Выбери, с чего начать:"
+		text = i18n.T(lang, "<b>Привет! Я бот сообщества IT-X.</b>\n\n"+
+			"Здесь оформляется подписка на закрытые чаты, менторство и платформу "+
+			"<a href=\"https://ithozyaeva.ru\">ithozyaeva.ru</a>.\n\n"+
+			"Выбери, с чего начать:")
 		keyboard = [][]tgbotapi.InlineKeyboardButton{
 			tgbotapi.NewInlineKeyboardRow(
-				tgbotapi.NewInlineKeyboardButtonData("💎 Тарифы", "wiz:tariffs"),
-				tgbotapi.NewInlineKeyboardButtonData("🔑 Проверить оплату", "wiz:sub"),
+				tgbotapi.NewInlineKeyboardButtonData(i18n.T(lang, "💎 Тарифы"), "wiz:tariffs"),
+				tgbotapi.NewInlineKeyboardButtonData(i18n.T(lang, "🔑 Проверить оплату"), "wiz:sub"),
 			),
 			tgbotapi.NewInlineKeyboardRow(
-				tgbotapi.NewInlineKeyboardButtonData("🌐 Платформа", "wiz:auth"),
-				tgbotapi.NewInlineKeyboardButtonData("❓ Как это работает", "wiz:help"),
+				tgbotapi.NewInlineKeyboardButtonData(i18n.T(lang, "🌐 Платформа"), "wiz:auth"),
+				tgbotapi.NewInlineKeyboardButtonData(i18n.T(lang, "❓ Как это работает"), "wiz:help"),
 			),
 			tgbotapi.NewInlineKeyboardRow(
-				tgbotapi.NewInlineKeyboardButtonData("📨 Написать админу", "wiz:support"),
+				tgbotapi.NewInlineKeyboardButtonData(i18n.T(lang, "📨 Написать админу"), "wiz:support"),
 			),
 		}
 	}
@@ -926,7 +1029,7 @@ func (b *TelegramBot) sendWelcomeWizard(chatID int64, isSubscriber bool) {
 // handleHighlightCommand сохраняет сообщение как хайлайт
 func (b *TelegramBot) handleHighlightCommand(message *tgbotapi.Message) {
 	if message.ReplyToMessage == nil {
-		msg := tgbotapi.NewMessage(message.Chat.ID, "Ответьте на сообщение командой /highlight, чтобы сохранить его как хайлайт.")
+		msg := tgbotapi.NewMessage(message.Chat.ID, b.tr(message.From.ID, "Ответьте на сообщение командой /highlight, чтобы сохранить его как хайлайт."))
 		msg.ReplyToMessageID = message.MessageID
 		b.bot.Send(msg)
 		return
@@ -934,7 +1037,7 @@ func (b *TelegramBot) handleHighlightCommand(message *tgbotapi.Message) {
 
 	reply := message.ReplyToMessage
 	if reply.Text == "" {
-		msg := tgbotapi.NewMessage(message.Chat.ID, "Можно сохранить только текстовые сообщения.")
+		msg := tgbotapi.NewMessage(message.Chat.ID, b.tr(message.From.ID, "Можно сохранить только текстовые сообщения."))
 		msg.ReplyToMessageID = message.MessageID
 		b.bot.Send(msg)
 		return
@@ -961,13 +1064,13 @@ func (b *TelegramBot) handleHighlightCommand(message *tgbotapi.Message) {
 	_, err = b.chatHighlightService.Create(highlight)
 	if err != nil {
 		log.Printf("Error saving highlight: %v", err)
-		msg := tgbotapi.NewMessage(message.Chat.ID, "Ошибка при сохранении хайлайта.")
+		msg := tgbotapi.NewMessage(message.Chat.ID, b.tr(message.From.ID, "Ошибка при сохранении хайлайта."))
 		msg.ReplyToMessageID = message.MessageID
 		b.bot.Send(msg)
 		return
 	}
 
-	msg := tgbotapi.NewMessage(message.Chat.ID, "⭐ Сообщение сохранено как хайлайт!")
+	msg := tgbotapi.NewMessage(message.Chat.ID, b.tr(message.From.ID, "⭐ Сообщение сохранено как хайлайт!"))
 	msg.ReplyToMessageID = message.MessageID
 	b.bot.Send(msg)
 }
@@ -1039,7 +1142,8 @@ func (b *TelegramBot) deleteBareCommand(chatID int64, messageID int) {
 func (b *TelegramBot) SendEventAlert(telegramID int64, event *models.Event, isInitial bool) error {
 	now := time.Now()
 	timeUntilEvent := event.Date.Sub(now)
-	messageText := b.formatEventAlert(event, isInitial, timeUntilEvent)
+	lang := b.langOf(telegramID)
+	messageText := formatEventAlert(lang, event, isInitial, timeUntilEvent)
 
 	msg := tgbotapi.NewMessage(telegramID, messageText)
 	msg.ParseMode = "HTML"
@@ -1047,8 +1151,8 @@ func (b *TelegramBot) SendEventAlert(telegramID int64, event *models.Event, isIn
 	if isInitial {
 		keyboard := tgbotapi.NewInlineKeyboardMarkup(
 			tgbotapi.NewInlineKeyboardRow(
-				tgbotapi.NewInlineKeyboardButtonData("✅ Приду", fmt.Sprintf("event_attend:%d", event.Id)),
-				tgbotapi.NewInlineKeyboardButtonData("❌ Не приду", fmt.Sprintf("event_decline:%d", event.Id)),
+				tgbotapi.NewInlineKeyboardButtonData(i18n.T(lang, "✅ Приду"), fmt.Sprintf("event_attend:%d", event.Id)),
+				tgbotapi.NewInlineKeyboardButtonData(i18n.T(lang, "❌ Не приду"), fmt.Sprintf("event_decline:%d", event.Id)),
 			),
 		)
 		msg.ReplyMarkup = keyboard
@@ -1058,25 +1162,25 @@ func (b *TelegramBot) SendEventAlert(telegramID int64, event *models.Event, isIn
 	return err
 }
 
-func (b *TelegramBot) formatEventAlert(event *models.Event, isInitial bool, timeUntilEvent time.Duration) string {
+func formatEventAlert(lang i18n.Lang, event *models.Event, isInitial bool, timeUntilEvent time.Duration) string {
 	var builder strings.Builder
 
 	if event.ExclusiveChatID != nil && *event.ExclusiveChatID != 0 {
 		label := event.ExclusiveChatTitle
 		if label == "" {
-			label = "Эксклюзив"
+			label = i18n.T(lang, "Эксклюзив")
 		}
 		// Title чата приходит от TG, может содержать <, &.
 		builder.WriteString(fmt.Sprintf("👑 <b>%s</b>\n", html.EscapeString(label)))
 	}
 
 	if isInitial {
-		builder.WriteString("⭐ <b>Новое событие!</b>\n\n")
+		builder.WriteString("⭐ <b>" + i18n.T(lang, "Новое событие!") + "</b>\n\n")
 	} else if timeUntilEvent <= 1*time.Minute && timeUntilEvent > -2*time.Minute {
-		builder.WriteString("🚀 <b>Событие началось!</b>\n\n")
+		builder.WriteString("🚀 <b>" + i18n.T(lang, "Событие началось!") + "</b>\n\n")
 	} else {
-		timeRemaining := b.formatTimeRemaining(timeUntilEvent)
-		builder.WriteString(fmt.Sprintf("📌 <b>Напоминание о событии</b>%s\n\n", timeRemaining))
+		timeRemaining := formatTimeRemaining(lang, timeUntilEvent)
+		builder.WriteString(fmt.Sprintf("📌 <b>%s</b>%s\n\n", i18n.T(lang, "Напоминание о событии"), timeRemaining))
 	}
 
 	// Admin-controlled поля Title/Description/Place прогоняем через
@@ -1091,12 +1195,12 @@ func (b *TelegramBot) formatEventAlert(event *models.Event, isInitial bool, time
 		builder.WriteString(fmt.Sprintf("\n%s\n", sanitizeTelegramHTML(event.Description)))
 	}
 
-	dateStr := formatEventDateStr(event.Date, event.Timezone)
+	dateStr := formatEventDateStr(lang, event.Date, event.Timezone)
 	tzLabel := formatTimezoneLabel(event.Timezone)
-	builder.WriteString(fmt.Sprintf("\n📆 <b>Дата:</b> %s (%s)\n", dateStr, tzLabel))
+	builder.WriteString(fmt.Sprintf("\n📆 <b>%s</b> %s (%s)\n", i18n.T(lang, "Дата:"), dateStr, tzLabel))
 
 	if len(event.Hosts) > 0 {
-		builder.WriteString("\n👥 <b>Спикеры:</b>\n")
+		builder.WriteString("\n👥 <b>" + i18n.T(lang, "Спикеры:") + "</b>\n")
 		for _, host := range event.Hosts {
 			// FirstName/LastName приходят из Telegram — экранируем как
 			// plain text, форматирования от юзера тут не ждём.
@@ -1115,55 +1219,24 @@ func (b *TelegramBot) formatEventAlert(event *models.Event, isInitial bool, time
 	}
 
 	if event.PlaceType == models.EventOnline {
-		builder.WriteString(fmt.Sprintf("\n🔗 <b>Ссылка:</b> %s\n", sanitizeTelegramHTML(event.Place)))
+		builder.WriteString(fmt.Sprintf("\n🔗 <b>%s</b> %s\n", i18n.T(lang, "Ссылка:"), sanitizeTelegramHTML(event.Place)))
 	} else {
 		place := event.Place
 		if event.CustomPlaceType != "" {
 			place = event.CustomPlaceType + ", " + event.Place
 		}
-		builder.WriteString(fmt.Sprintf("\n📍 <b>Место:</b> %s\n", sanitizeTelegramHTML(place)))
+		builder.WriteString(fmt.Sprintf("\n📍 <b>%s</b> %s\n", i18n.T(lang, "Место:"), sanitizeTelegramHTML(place)))
 	}
 
 	// Добавляем информацию о повторениях
-	if event.IsRepeating && event.RepeatPeriod != nil {
-		builder.WriteString("\n🔄 <b>Повторяющееся событие:</b> ")
-		interval := 1
-		if event.RepeatInterval != nil {
-			interval = *event.RepeatInterval
-		}
-
-		periodLabels := map[string]string{
-			"DAILY":   "день",
-			"WEEKLY":  "неделя",
-			"MONTHLY": "месяц",
-			"YEARLY":  "год",
-		}
-
-		periodLabel := periodLabels[*event.RepeatPeriod]
-		if periodLabel == "" {
-			periodLabel = strings.ToLower(*event.RepeatPeriod)
-		}
-
-		if interval == 1 {
-			builder.WriteString(fmt.Sprintf("каждый %s", periodLabel))
-		} else {
-			builder.WriteString(fmt.Sprintf("каждые %d %s", interval, b.pluralizePeriod(interval, periodLabel)))
-		}
-
-		if event.RepeatEndDate != nil {
-			loc := getEventLocation(event.Timezone)
-			endDateStr := event.RepeatEndDate.In(loc).Format("02.01.2006")
-			builder.WriteString(fmt.Sprintf(" до %s", endDateStr))
-		}
-		builder.WriteString("\n")
-	}
+	builder.WriteString(formatEventRepeat(lang, event))
 
 	return builder.String()
 }
 
-func (b *TelegramBot) formatTimeRemaining(timeUntilEvent time.Duration) string {
+func formatTimeRemaining(lang i18n.Lang, timeUntilEvent time.Duration) string {
 	if timeUntilEvent <= 0 {
-		return " (событие началось)"
+		return " " + i18n.T(lang, "(событие началось)")
 	}
 
 	days := int(timeUntilEvent.Hours()) / 24
@@ -1172,51 +1245,62 @@ func (b *TelegramBot) formatTimeRemaining(timeUntilEvent time.Duration) string {
 
 	var parts []string
 	if days > 0 {
-		parts = append(parts, fmt.Sprintf("%d %s", days, b.pluralize(days, "день", "дня", "дней")))
+		parts = append(parts, i18n.Count(lang, days, "день"))
 	}
 	if hours > 0 {
-		parts = append(parts, fmt.Sprintf("%d %s", hours, b.pluralize(hours, "час", "часа", "часов")))
+		parts = append(parts, i18n.Count(lang, hours, "час"))
 	}
 	if minutes > 0 && days == 0 {
-		parts = append(parts, fmt.Sprintf("%d %s", minutes, b.pluralize(minutes, "минута", "минуты", "минут")))
+		parts = append(parts, i18n.Count(lang, minutes, "минута"))
 	}
 
 	if len(parts) > 0 {
-		return fmt.Sprintf(" (до события осталось %s)", strings.Join(parts, " "))
+		return " " + i18n.T(lang, "(до события осталось %s)", strings.Join(parts, " "))
 	}
 
 	return ""
 }
 
-func (b *TelegramBot) pluralize(n int, one, few, many string) string {
-	if n%10 == 1 && n%100 != 11 {
-		return one
-	}
-	if n%10 >= 2 && n%10 <= 4 && (n%100 < 10 || n%100 >= 20) {
-		return few
-	}
-	return many
+// eventRepeatUnits — единица повторения события (ключ форм в i18n.Plural)
+// и подпись для интервала 1: «каждую неделю», а не «каждый неделя».
+var eventRepeatUnits = map[string]struct{ unit, every string }{
+	"DAILY":   {"день", "каждый день"},
+	"WEEKLY":  {"неделя", "каждую неделю"},
+	"MONTHLY": {"месяц", "каждый месяц"},
+	"YEARLY":  {"год", "каждый год"},
 }
 
-func (b *TelegramBot) pluralizePeriod(n int, period string) string {
-	forms := map[string][]string{
-		"день":   {"дня", "дней"},
-		"неделя": {"недели", "недель"},
-		"месяц":  {"месяца", "месяцев"},
-		"год":    {"года", "лет"},
+// formatEventRepeat — строка «Повторяющееся событие: ...» или "", если
+// событие разовое.
+func formatEventRepeat(lang i18n.Lang, event *models.Event) string {
+	if !event.IsRepeating || event.RepeatPeriod == nil {
+		return ""
 	}
-
-	if forms[period] == nil {
-		return period
+	interval := 1
+	if event.RepeatInterval != nil {
+		interval = *event.RepeatInterval
 	}
 
-	if n%10 == 1 && n%100 != 11 {
-		return period
+	var every string
+	period, ok := eventRepeatUnits[*event.RepeatPeriod]
+	switch {
+	case !ok:
+		every = i18n.T(lang, "каждые %d %s", interval, strings.ToLower(*event.RepeatPeriod))
+	case interval == 1:
+		every = i18n.T(lang, period.every)
+	default:
+		every = i18n.T(lang, "каждые %s", i18n.Count(lang, interval, period.unit))
 	}
-	if n%10 >= 2 && n%10 <= 4 && (n%100 < 10 || n%100 >= 20) {
-		return forms[period][0]
+
+	var builder strings.Builder
+	builder.WriteString("\n🔄 <b>" + i18n.T(lang, "Повторяющееся событие:") + "</b> " + every)
+	if event.RepeatEndDate != nil {
+		loc := getEventLocation(event.Timezone)
+		endDateStr := event.RepeatEndDate.In(loc).Format("02.01.2006")
+		builder.WriteString(" " + i18n.T(lang, "до %s", endDateStr))
 	}
-	return forms[period][1]
+	builder.WriteString("\n")
+	return builder.String()
 }
 
 // handleCallbackQuery обрабатывает нажатия на callback кнопки
@@ -1236,6 +1320,12 @@ func (b *TelegramBot) handleCallbackQuery(callback *tgbotapi.CallbackQuery) {
 		return
 	}
 
+	// Режим рейда — raid:{raid_id}:rollback|release.
+	if strings.HasPrefix(data, "raid:") {
+		b.handleRaidCallback(callback)
+		return
+	}
+
 	// Парсим callback data
 	if strings.HasPrefix(data, "event_attend:") {
 		eventIdStr := strings.TrimPrefix(data, "event_attend:")
@@ -1246,7 +1336,7 @@ func (b *TelegramBot) handleCallbackQuery(callback *tgbotapi.CallbackQuery) {
 		member, err := b.member.GetByTelegramID(userID)
 		if err != nil {
 			log.Printf("Error getting member by telegram ID %d: %v", userID, err)
-			b.answerCallbackQuery(callback.ID, "Ошибка: пользователь не найден")
+			b.answerCallbackQuery(callback.ID, b.tr(callback.From.ID, "Ошибка: пользователь не найден"))
 			return
 		}
 
@@ -1254,7 +1344,7 @@ func (b *TelegramBot) handleCallbackQuery(callback *tgbotapi.CallbackQuery) {
 		_, err = b.eventAlertSubscription.UpdateSubscriptionStatus(eventId, member.Id, models.EventAlertStatusSubscribed)
 		if err != nil {
 			log.Printf("Error updating subscription status: %v", err)
-			b.answerCallbackQuery(callback.ID, "Ошибка при обновлении подписки")
+			b.answerCallbackQuery(callback.ID, b.tr(callback.From.ID, "Ошибка при обновлении подписки"))
 			return
 		}
 
@@ -1264,7 +1354,7 @@ func (b *TelegramBot) handleCallbackQuery(callback *tgbotapi.CallbackQuery) {
 			log.Printf("Error adding member %d to event %d: %v", member.Id, eventId, err)
 		}
 
-		b.answerCallbackQuery(callback.ID, "Отлично! Вы записаны на мероприятие")
+		b.answerCallbackQuery(callback.ID, b.tr(callback.From.ID, "Отлично! Вы записаны на мероприятие"))
 
 		// Обновляем сообщение, убирая кнопки
 		editMsg := tgbotapi.NewEditMessageText(callback.Message.Chat.ID, callback.Message.MessageID, callback.Message.Text)
@@ -1280,7 +1370,7 @@ func (b *TelegramBot) handleCallbackQuery(callback *tgbotapi.CallbackQuery) {
 		member, err := b.member.GetByTelegramID(userID)
 		if err != nil {
 			log.Printf("Error getting member by telegram ID %d: %v", userID, err)
-			b.answerCallbackQuery(callback.ID, "Ошибка: пользователь не найден")
+			b.answerCallbackQuery(callback.ID, b.tr(callback.From.ID, "Ошибка: пользователь не найден"))
 			return
 		}
 
@@ -1288,7 +1378,7 @@ func (b *TelegramBot) handleCallbackQuery(callback *tgbotapi.CallbackQuery) {
 		_, err = b.eventAlertSubscription.UpdateSubscriptionStatus(eventId, member.Id, models.EventAlertStatusUnsubscribed)
 		if err != nil {
 			log.Printf("Error updating subscription status: %v", err)
-			b.answerCallbackQuery(callback.ID, "Ошибка при обновлении подписки")
+			b.answerCallbackQuery(callback.ID, b.tr(callback.From.ID, "Ошибка при обновлении подписки"))
 			return
 		}
 
@@ -1298,7 +1388,7 @@ func (b *TelegramBot) handleCallbackQuery(callback *tgbotapi.CallbackQuery) {
 			log.Printf("Error removing member %d from event %d: %v", member.Id, eventId, err)
 		}
 
-		b.answerCallbackQuery(callback.ID, "Вы отписаны от мероприятия")
+		b.answerCallbackQuery(callback.ID, b.tr(callback.From.ID, "Вы отписаны от мероприятия"))
 
 		// Обновляем сообщение, убирая кнопки
 		editMsg := tgbotapi.NewEditMessageText(callback.Message.Chat.ID, callback.Message.MessageID, callback.Message.Text)
@@ -1366,18 +1456,19 @@ func (b *TelegramBot) sendAuthButton(user *tgbotapi.User, chatID int64) {
 	token, err := b.tg_service.GenerateAuthToken(user.ID)
 	if err != nil {
 		log.Printf("sendAuthButton: token gen for user %d failed: %v", user.ID, err)
-		b.sendMessage(chatID, "Не удалось сгенерировать ссылку авторизации. Попробуйте позже.")
+		b.sendMessage(chatID, b.tr(user.ID, "Не удалось сгенерировать ссылку авторизации. Попробуйте позже."))
 		return
 	}
 	sendAuthToBackend(b.bot, token, user)
 
 	authUrl := fmt.Sprintf("%s?token=%s", redirectUrl, token)
-	msg := tgbotapi.NewMessage(chatID,
+	lang := b.langOf(user.ID)
+	msg := tgbotapi.NewMessage(chatID, i18n.T(lang,
 		"Нажмите кнопку ниже, чтобы авторизоваться на платформе ithozyaeva.ru. "+
-			"Ссылка одноразовая.")
+			"Ссылка одноразовая."))
 	msg.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(
 		tgbotapi.NewInlineKeyboardRow(
-			tgbotapi.NewInlineKeyboardButtonURL("Авторизоваться", authUrl),
+			tgbotapi.NewInlineKeyboardButtonURL(i18n.T(lang, "Авторизоваться"), authUrl),
 		),
 	)
 	if _, err := b.bot.Send(msg); err != nil {
@@ -1389,14 +1480,14 @@ func (b *TelegramBot) sendAuthButton(user *tgbotapi.User, chatID int64) {
 // делает /sub и где настраивать уведомления. Открывается по кнопке
 // «Как это работает».
 func (b *TelegramBot) sendWelcomeFAQ(chatID int64) {
-	text := "<b>Как это работает</b>\n\n" +
-		"• Подписка оформляется через Boosty или Tribute.\n" +
-		"• Когда ты в <b>якорном чате</b> своего тира — бот считает подписку активной.\n" +
-		"• /sub или кнопка <b>«Проверить подписку»</b> выдаёт инвайты во все доступные чаты.\n" +
-		"• /mygroups или <b>«Куда ещё зайти»</b> показывает полный список чатов по подписке, " +
-		"включая те, где ты уже состоишь (они помечены ✅).\n" +
-		"• Если тебе открыли новый чат, бот пришлёт сюда сообщение со ссылкой — отдельно действия не нужны.\n\n" +
-		"Если что-то не работает — нажми в /start кнопку «Написать админу»."
+	text := b.tr(chatID, "<b>Как это работает</b>\n\n"+
+		"• Подписка оформляется через Boosty или Tribute.\n"+
+		"• Когда ты в <b>якорном чате</b> своего тира — бот считает подписку активной.\n"+
+		"• /sub или кнопка <b>«Проверить подписку»</b> выдаёт инвайты во все доступные чаты.\n"+
+		"• /mygroups или <b>«Куда ещё зайти»</b> показывает полный список чатов по подписке, "+
+		"включая те, где ты уже состоишь (они помечены ✅).\n"+
+		"• Если тебе открыли новый чат, бот пришлёт сюда сообщение со ссылкой — отдельно действия не нужны.\n\n"+
+		"Если что-то не работает — нажми в /start кнопку «Написать админу».")
 	msg := tgbotapi.NewMessage(chatID, text)
 	msg.ParseMode = "HTML"
 	msg.DisableWebPagePreview = true
@@ -1411,18 +1502,18 @@ func (b *TelegramBot) sendWelcomeFAQ(chatID int64) {
 func (b *TelegramBot) beginSupportTicket(userID int64, chatID int64) {
 	err := b.supportService.BeginTicket(context.Background(), userID)
 	if err == service.ErrSupportRateLimited {
-		b.sendMessage(chatID,
-			"Слишком часто. Попробуй через минуту — и потом пиши одним сообщением.")
+		b.sendMessage(chatID, b.tr(userID,
+			"Слишком часто. Попробуй через минуту — и потом пиши одним сообщением."))
 		return
 	}
 	if err != nil {
 		log.Printf("beginSupportTicket: %v", err)
-		b.sendMessage(chatID, "Не удалось открыть тикет. Попробуй позже.")
+		b.sendMessage(chatID, b.tr(userID, "Не удалось открыть тикет. Попробуй позже."))
 		return
 	}
-	b.sendMessage(chatID,
+	b.sendMessage(chatID, b.tr(userID,
 		"Напиши следующим сообщением, что передать админу — уйдёт сразу.\n"+
-			"Отменить: /cancel. У тебя 10 минут.")
+			"Отменить: /cancel. У тебя 10 минут."))
 }
 
 // handleSupportIncoming — если от userID ждут ticket-сообщение, пересылаем
@@ -1438,7 +1529,7 @@ func (b *TelegramBot) handleSupportIncoming(message *tgbotapi.Message) bool {
 	fwd := tgbotapi.NewForward(subscriptionAdminID(), message.Chat.ID, message.MessageID)
 	if _, err := b.bot.Send(fwd); err != nil {
 		log.Printf("handleSupportIncoming: forward failed: %v", err)
-		b.sendMessage(message.Chat.ID, "Не удалось отправить сообщение админу. Попробуй позже.")
+		b.sendMessage(message.Chat.ID, b.tr(message.From.ID, "Не удалось отправить сообщение админу. Попробуй позже."))
 		_ = b.supportService.EndTicket(ctx, message.From.ID)
 		return true
 	}
@@ -1451,7 +1542,7 @@ func (b *TelegramBot) handleSupportIncoming(message *tgbotapi.Message) bool {
 		"📨 Саппорт от %s (id=<code>%d</code>)",
 		username, message.From.ID))
 	_ = b.supportService.EndTicket(ctx, message.From.ID)
-	b.sendMessage(message.Chat.ID, "Отправлено. Админ увидит.")
+	b.sendMessage(message.Chat.ID, b.tr(message.From.ID, "Отправлено. Админ увидит."))
 	return true
 }
 
@@ -1459,11 +1550,11 @@ func (b *TelegramBot) handleSupportIncoming(message *tgbotapi.Message) bool {
 func (b *TelegramBot) handleCancelCommand(message *tgbotapi.Message) {
 	ctx := context.Background()
 	if !b.supportService.IsAwaiting(ctx, message.From.ID) {
-		b.sendMessage(message.Chat.ID, "Нечего отменять.")
+		b.sendMessage(message.Chat.ID, b.tr(message.From.ID, "Нечего отменять."))
 		return
 	}
 	_ = b.supportService.EndTicket(ctx, message.From.ID)
-	b.sendMessage(message.Chat.ID, "Отменено.")
+	b.sendMessage(message.Chat.ID, b.tr(message.From.ID, "Отменено."))
 }
 
 // getNotificationSettingsMap получает настройки уведомлений для списка участников
@@ -1608,7 +1699,7 @@ func (b *TelegramBot) SendEventUpdateAlert(event *models.Event) error {
 			continue
 		}
 
-		messageText := b.formatEventUpdateAlert(event)
+		messageText := formatEventUpdateAlert(b.langOf(member.TelegramID), event)
 		msg := tgbotapi.NewMessage(member.TelegramID, messageText)
 		msg.ParseMode = "HTML"
 
@@ -1646,7 +1737,9 @@ func (b *TelegramBot) SendEventCancelAlert(event *models.Event) error {
 
 		// Title — admin-controlled; sanitize, чтобы амперсанд или `<` не
 		// ронял parse_mode и алерт «событие отменено» доставлялся.
-		messageText := fmt.Sprintf("❌ <b>Событие отменено!</b>\n\n<b>%s</b>\n\nСобытие было отменено организаторами.", sanitizeTelegramHTML(event.Title))
+		lang := b.langOf(member.TelegramID)
+		messageText := fmt.Sprintf("❌ <b>%s</b>\n\n<b>%s</b>\n\n%s",
+			i18n.T(lang, "Событие отменено!"), sanitizeTelegramHTML(event.Title), i18n.T(lang, "Событие было отменено организаторами."))
 		msg := tgbotapi.NewMessage(member.TelegramID, messageText)
 		msg.ParseMode = "HTML"
 
@@ -1664,10 +1757,10 @@ func (b *TelegramBot) SendEventCancelAlert(event *models.Event) error {
 }
 
 // formatEventUpdateAlert форматирует сообщение об изменении события
-func (b *TelegramBot) formatEventUpdateAlert(event *models.Event) string {
+func formatEventUpdateAlert(lang i18n.Lang, event *models.Event) string {
 	var builder strings.Builder
 
-	builder.WriteString("📝 <b>Событие изменено!</b>\n\n")
+	builder.WriteString("📝 <b>" + i18n.T(lang, "Событие изменено!") + "</b>\n\n")
 	// См. комментарий в formatEventAlert: admin-поля через sanitize,
 	// TG-юзер-поля (хосты) через html.EscapeString.
 	builder.WriteString(fmt.Sprintf("<b>%s</b>\n", sanitizeTelegramHTML(event.Title)))
@@ -1676,12 +1769,12 @@ func (b *TelegramBot) formatEventUpdateAlert(event *models.Event) string {
 		builder.WriteString(fmt.Sprintf("\n%s\n", sanitizeTelegramHTML(event.Description)))
 	}
 
-	dateStr := formatEventDateStr(event.Date, event.Timezone)
+	dateStr := formatEventDateStr(lang, event.Date, event.Timezone)
 	tzLabel := formatTimezoneLabel(event.Timezone)
-	builder.WriteString(fmt.Sprintf("\n📆 <b>Дата:</b> %s (%s)\n", dateStr, tzLabel))
+	builder.WriteString(fmt.Sprintf("\n📆 <b>%s</b> %s (%s)\n", i18n.T(lang, "Дата:"), dateStr, tzLabel))
 
 	if len(event.Hosts) > 0 {
-		builder.WriteString("\n👥 <b>Спикеры:</b>\n")
+		builder.WriteString("\n👥 <b>" + i18n.T(lang, "Спикеры:") + "</b>\n")
 		for _, host := range event.Hosts {
 			name := strings.TrimSpace(fmt.Sprintf("%s %s", host.FirstName, host.LastName))
 			if name == "" {
@@ -1698,50 +1791,19 @@ func (b *TelegramBot) formatEventUpdateAlert(event *models.Event) string {
 	}
 
 	if event.PlaceType == models.EventOnline {
-		builder.WriteString(fmt.Sprintf("\n🔗 <b>Ссылка:</b> %s\n", sanitizeTelegramHTML(event.Place)))
+		builder.WriteString(fmt.Sprintf("\n🔗 <b>%s</b> %s\n", i18n.T(lang, "Ссылка:"), sanitizeTelegramHTML(event.Place)))
 	} else {
 		place := event.Place
 		if event.CustomPlaceType != "" {
 			place = event.CustomPlaceType + ", " + event.Place
 		}
-		builder.WriteString(fmt.Sprintf("\n📍 <b>Место:</b> %s\n", sanitizeTelegramHTML(place)))
+		builder.WriteString(fmt.Sprintf("\n📍 <b>%s</b> %s\n", i18n.T(lang, "Место:"), sanitizeTelegramHTML(place)))
 	}
 
 	// Добавляем информацию о повторениях
-	if event.IsRepeating && event.RepeatPeriod != nil {
-		builder.WriteString("\n🔄 <b>Повторяющееся событие:</b> ")
-		interval := 1
-		if event.RepeatInterval != nil {
-			interval = *event.RepeatInterval
-		}
-
-		periodLabels := map[string]string{
-			"DAILY":   "день",
-			"WEEKLY":  "неделя",
-			"MONTHLY": "месяц",
-			"YEARLY":  "год",
-		}
-
-		periodLabel := periodLabels[*event.RepeatPeriod]
-		if periodLabel == "" {
-			periodLabel = strings.ToLower(*event.RepeatPeriod)
-		}
+	builder.WriteString(formatEventRepeat(lang, event))
 
-		if interval == 1 {
-			builder.WriteString(fmt.Sprintf("каждый %s", periodLabel))
-		} else {
-			builder.WriteString(fmt.Sprintf("каждые %d %s", interval, b.pluralizePeriod(interval, periodLabel)))
-		}
-
-		if event.RepeatEndDate != nil {
-			loc := getEventLocation(event.Timezone)
-			endDateStr := event.RepeatEndDate.In(loc).Format("02.01.2006")
-			builder.WriteString(fmt.Sprintf(" до %s", endDateStr))
-		}
-		builder.WriteString("\n")
-	}
-
-	builder.WriteString("\n💡 <i>Пожалуйста, проверьте актуальную информацию о событии</i>")
+	builder.WriteString("\n💡 <i>" + i18n.T(lang, "Пожалуйста, проверьте актуальную информацию о событии") + "</i>")
 
 	return builder.String()
 }
@@ -1751,6 +1813,9 @@ func (b *TelegramBot) startEventAlertsScheduler() {
 	defer ticker.Stop()
 
 	for range ticker.C {
+		if !b.isLeader() {
+			continue
+		}
 		b.processMissingInitialAlerts()
 		b.checkAndSendEventAlerts()
 	}
@@ -2071,6 +2136,8 @@ type AuthRequest struct {
 	LastName  string      `json:"last_name"`
 	Role      models.Role `json:"role"`
 	AvatarURL string      `json:"avatar_url,omitempty"`
+	// LanguageCode — language_code Telegram: язык новой записи участника.
+	LanguageCode string `json:"language_code,omitempty"`
 }
 
 func downloadTelegramAvatar(botAPI *tgbotapi.BotAPI, userID int64) ([]byte, error) {
@@ -2155,6 +2222,8 @@ func sendAuthToBackend(botAPI *tgbotapi.BotAPI, token string, user *tgbotapi.Use
 		LastName:  user.LastName,
 		Role:      role,
 		AvatarURL: avatarURL,
+
+		LanguageCode: user.LanguageCode,
 	}
 
 	jsonData, err := json.Marshal(data)
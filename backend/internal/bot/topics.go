@@ -0,0 +1,118 @@
+package bot
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// Темы форума. tgbotapi v5.5.1 не знает message_thread_id/is_topic_message,
+// поэтому забираем апдейты сами и достаём эти поля из сырого JSON рядом с
+// обычным разбором. Тема запоминается в Redis по (chat_id, message_id) на
+// topicTTL — этого хватает, чтобы обработчики апдейта успели её прочитать
+// на любой реплике.
+const topicTTL = 10 * time.Minute
+
+// topicMessage — сообщение в теме форума.
+type topicMessage struct {
+	chatID    int64
+	messageID int
+	threadID  int
+}
+
+// rawTopicUpdate — только нужные поля апдейта.
+type rawTopicUpdate struct {
+	Message *struct {
+		MessageID       int  `json:"message_id"`
+		MessageThreadID int  `json:"message_thread_id"`
+		IsTopicMessage  bool `json:"is_topic_message"`
+		Chat            struct {
+			ID int64 `json:"id"`
+		} `json:"chat"`
+	} `json:"message"`
+}
+
+func (u rawTopicUpdate) topic() (topicMessage, bool) {
+	if u.Message == nil || !u.Message.IsTopicMessage || u.Message.MessageThreadID == 0 {
+		return topicMessage{}, false
+	}
+	return topicMessage{chatID: u.Message.Chat.ID, messageID: u.Message.MessageID, threadID: u.Message.MessageThreadID}, true
+}
+
+// parseTopics — сообщения в темах из сырого ответа getUpdates.
+func parseTopics(raw []byte) []topicMessage {
+	var updates []rawTopicUpdate
+	if err := json.Unmarshal(raw, &updates); err != nil {
+		return nil
+	}
+	var topics []topicMessage
+	for _, u := range updates {
+		if t, ok := u.topic(); ok {
+			topics = append(topics, t)
+		}
+	}
+	return topics
+}
+
+// parseTopic — то же для одного апдейта (тело webhook'а).
+func parseTopic(raw []byte) (topicMessage, bool) {
+	var u rawTopicUpdate
+	if err := json.Unmarshal(raw, &u); err != nil {
+		return topicMessage{}, false
+	}
+	return u.topic()
+}
+
+func (b *TelegramBot) rememberTopic(t topicMessage) {
+	if err := b.chatState.RememberTopic(context.Background(), t.chatID, t.messageID, t.threadID, topicTTL); err != nil {
+		log.Printf("topics: remember chat=%d message=%d: %v", t.chatID, t.messageID, err)
+	}
+}
+
+// messageThreadID — тема форума, в которой написано сообщение (0 — вне темы).
+func (b *TelegramBot) messageThreadID(message *tgbotapi.Message) int {
+	if message == nil || message.Chat == nil {
+		return 0
+	}
+	threadID, err := b.chatState.TopicThread(context.Background(), message.Chat.ID, message.MessageID)
+	if err != nil {
+		log.Printf("topics: lookup chat=%d message=%d: %v", message.Chat.ID, message.MessageID, err)
+	}
+	return threadID
+}
+
+// getUpdatesChan — аналог tgbotapi.BotAPI.GetUpdatesChan, который по пути
+// запоминает темы форума.
+func (b *TelegramBot) getUpdatesChan(config tgbotapi.UpdateConfig) <-chan tgbotapi.Update {
+	ch := make(chan tgbotapi.Update, b.bot.Buffer)
+	go func() {
+		for {
+			resp, err := b.bot.Request(config)
+			if err != nil {
+				log.Println(err)
+				log.Println("Failed to get updates, retrying in 3 seconds...")
+				time.Sleep(3 * time.Second)
+				continue
+			}
+			var updates []tgbotapi.Update
+			if err := json.Unmarshal(resp.Result, &updates); err != nil {
+				log.Printf("getUpdates: unmarshal: %v", err)
+				time.Sleep(3 * time.Second)
+				continue
+			}
+			for _, t := range parseTopics(resp.Result) {
+				b.rememberTopic(t)
+			}
+			for _, update := range updates {
+				if update.UpdateID >= config.Offset {
+					config.Offset = update.UpdateID + 1
+					ch <- update
+				}
+			}
+		}
+	}()
+	return ch
+}
@@ -0,0 +1,206 @@
+package bot
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"log"
+	"net/url"
+
+	"ithozyeva/config"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/gofiber/fiber/v2"
+)
+
+// Транспорт апдейтов. Long polling допускает один процесс на токен
+// (второй getUpdates получает 409), webhook — сколько угодно реплик за
+// балансировщиком: каждая принимает апдейт, забирает его claim'ом в Redis
+// и обрабатывает в своём пуле воркеров. Апдейты одного чата при этом
+// попадают на разные реплики, поэтому всё состояние по чатам, которое
+// переживает апдейт (окна rate limit и скачиваний, волна рейда, кулдауны
+// подсказок, темы), лежит в Redis — см. service.BotChatStateService.
+
+// updateQueueSize — очередь на воркер. Переполнилась — webhook отвечает
+// 503, и Telegram доставит апдейт позже (возможно, другой реплике).
+const updateQueueSize = 100
+
+// webhookSecretHeader — Telegram кладёт сюда secret_token из setWebhook.
+const webhookSecretHeader = "X-Telegram-Bot-Api-Secret-Token"
+
+// updateDispatcher — пул воркеров. Апдейты одного чата, принятые этой
+// репликой, попадают в один воркер и обрабатываются по порядку, разные
+// чаты — параллельно. Между репликами порядок не гарантирован: общие
+// счётчики меняются атомарно в Redis, а не полагаются на очерёдность.
+type updateDispatcher struct {
+	shards []chan tgbotapi.Update
+}
+
+func newUpdateDispatcher(workers int, handle func(tgbotapi.Update)) *updateDispatcher {
+	if workers <= 0 {
+		workers = 1
+	}
+	d := &updateDispatcher{shards: make([]chan tgbotapi.Update, workers)}
+	for i := range d.shards {
+		ch := make(chan tgbotapi.Update, updateQueueSize)
+		d.shards[i] = ch
+		go func() {
+			for update := range ch {
+				runUpdate(update, handle)
+			}
+		}()
+	}
+	return d
+}
+
+// runUpdate изолирует панику одного апдейта: раньше она роняла весь
+// цикл, теперь — только этот апдейт.
+func runUpdate(update tgbotapi.Update, handle func(tgbotapi.Update)) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("update %d: panic: %v", update.UpdateID, r)
+		}
+	}()
+	handle(update)
+}
+
+func (d *updateDispatcher) shard(update tgbotapi.Update) chan tgbotapi.Update {
+	return d.shards[uint64(updateChatKey(update))%uint64(len(d.shards))]
+}
+
+// dispatch ставит апдейт в очередь, дожидаясь места (polling).
+func (d *updateDispatcher) dispatch(update tgbotapi.Update) {
+	d.shard(update) <- update
+}
+
+// tryDispatch — без ожидания (webhook); false — очередь полна.
+func (d *updateDispatcher) tryDispatch(update tgbotapi.Update) bool {
+	select {
+	case d.shard(update) <- update:
+		return true
+	default:
+		return false
+	}
+}
+
+// updateChatKey — чат, к которому относится апдейт; для апдейтов без
+// чата — сам update_id (порядок не важен).
+func updateChatKey(update tgbotapi.Update) int64 {
+	switch {
+	case update.Message != nil && update.Message.Chat != nil:
+		return update.Message.Chat.ID
+	case update.CallbackQuery != nil && update.CallbackQuery.Message != nil && update.CallbackQuery.Message.Chat != nil:
+		return update.CallbackQuery.Message.Chat.ID
+	case update.CallbackQuery != nil && update.CallbackQuery.From != nil:
+		return update.CallbackQuery.From.ID
+	case update.InlineQuery != nil && update.InlineQuery.From != nil:
+		return update.InlineQuery.From.ID
+	case update.ChatMember != nil:
+		return update.ChatMember.Chat.ID
+	case update.MyChatMember != nil:
+		return update.MyChatMember.Chat.ID
+	}
+	return int64(update.UpdateID)
+}
+
+// pollUpdates — long polling. Webhook, оставшийся от прошлого запуска,
+// снимаем: при нём getUpdates не работает.
+func (b *TelegramBot) pollUpdates(allowed []string) {
+	if _, err := b.bot.Request(tgbotapi.DeleteWebhookConfig{}); err != nil {
+		log.Printf("deleteWebhook: %v", err)
+	}
+	u := tgbotapi.NewUpdate(0)
+	u.Timeout = 60
+	u.AllowedUpdates = allowed
+
+	d := newUpdateDispatcher(config.CFG.TelegramUpdateWorkers, b.handleUpdate)
+	for update := range b.getUpdatesChan(u) {
+		d.dispatch(update)
+	}
+}
+
+// serveWebhook регистрирует webhook в Telegram и принимает апдейты на
+// TELEGRAM_WEBHOOK_PORT по пути из TELEGRAM_WEBHOOK_URL.
+func (b *TelegramBot) serveWebhook(allowed []string) {
+	hookURL, err := url.Parse(config.CFG.TelegramWebhookURL)
+	if err != nil {
+		log.Fatalf("Invalid TELEGRAM_WEBHOOK_URL: %v", err)
+	}
+	path := hookURL.Path
+	if path == "" {
+		path = "/"
+	}
+
+	// tgbotapi v5.5.1 не знает secret_token — зовём setWebhook напрямую.
+	params := tgbotapi.Params{
+		"url":          config.CFG.TelegramWebhookURL,
+		"secret_token": config.CFG.TelegramWebhookSecret,
+	}
+	if err := params.AddInterface("allowed_updates", allowed); err != nil {
+		log.Fatalf("setWebhook: %v", err)
+	}
+	if _, err := b.bot.MakeRequest("setWebhook", params); err != nil {
+		log.Fatalf("setWebhook: %v", err)
+	}
+
+	d := newUpdateDispatcher(config.CFG.TelegramUpdateWorkers, b.handleUpdate)
+	h := &webhookHandler{
+		secret:   config.CFG.TelegramWebhookSecret,
+		claim:    b.replicaService.ClaimUpdate,
+		release:  b.replicaService.ReleaseUpdate,
+		dispatch: d.tryDispatch,
+		remember: func(raw []byte) {
+			if t, ok := parseTopic(raw); ok {
+				b.rememberTopic(t)
+			}
+		},
+	}
+
+	app := fiber.New(fiber.Config{AppName: "ITX bot webhook", DisableStartupMessage: true})
+	app.Post(path, h.Handle)
+	log.Printf("Telegram webhook listening on :%s%s", config.CFG.TelegramWebhookPort, path)
+	if err := app.Listen(":" + config.CFG.TelegramWebhookPort); err != nil {
+		log.Fatalf("Failed to start webhook server: %v", err)
+	}
+}
+
+// webhookHandler — приём одного апдейта. Зависимости — функциями, чтобы
+// проверять протокол без Redis и Telegram.
+type webhookHandler struct {
+	secret   string
+	claim    func(ctx context.Context, updateID int) (bool, error)
+	release  func(ctx context.Context, updateID int) error
+	dispatch func(tgbotapi.Update) bool
+	remember func(raw []byte)
+}
+
+func (h *webhookHandler) Handle(c *fiber.Ctx) error {
+	if subtle.ConstantTimeCompare([]byte(c.Get(webhookSecretHeader)), []byte(h.secret)) != 1 {
+		return c.SendStatus(fiber.StatusUnauthorized)
+	}
+	var update tgbotapi.Update
+	if err := json.Unmarshal(c.Body(), &update); err != nil {
+		return c.SendStatus(fiber.StatusBadRequest)
+	}
+
+	ctx := c.UserContext()
+	claimed, err := h.claim(ctx, update.UpdateID)
+	if err != nil {
+		// Без Redis не знаем, не обработала ли апдейт другая реплика —
+		// пусть Telegram повторит.
+		log.Printf("webhook: claim update %d: %v", update.UpdateID, err)
+		return c.SendStatus(fiber.StatusServiceUnavailable)
+	}
+	if !claimed {
+		return c.SendStatus(fiber.StatusOK)
+	}
+
+	h.remember(c.Body())
+	if !h.dispatch(update) {
+		if err := h.release(ctx, update.UpdateID); err != nil {
+			log.Printf("webhook: release update %d: %v", update.UpdateID, err)
+		}
+		return c.SendStatus(fiber.StatusServiceUnavailable)
+	}
+	return c.SendStatus(fiber.StatusOK)
+}
@@ -0,0 +1,163 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"ithozyeva/internal/service"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/gofiber/fiber/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+// stateBot — бот, чьё состояние по чатам лежит в rdb (в тестах —
+// miniredis). Два бота на одном rdb — две реплики webhook'а.
+func stateBot(rdb *redis.Client) *TelegramBot {
+	return &TelegramBot{chatState: service.NewBotChatStateService(rdb)}
+}
+
+func TestUpdateChatKey(t *testing.T) {
+	chat := &tgbotapi.Chat{ID: -100}
+	cases := map[string]struct {
+		update tgbotapi.Update
+		want   int64
+	}{
+		"message":     {tgbotapi.Update{UpdateID: 1, Message: &tgbotapi.Message{Chat: chat}}, -100},
+		"callback":    {tgbotapi.Update{UpdateID: 2, CallbackQuery: &tgbotapi.CallbackQuery{Message: &tgbotapi.Message{Chat: chat}}}, -100},
+		"inline cb":   {tgbotapi.Update{UpdateID: 3, CallbackQuery: &tgbotapi.CallbackQuery{From: &tgbotapi.User{ID: 7}}}, 7},
+		"chat member": {tgbotapi.Update{UpdateID: 4, ChatMember: &tgbotapi.ChatMemberUpdated{Chat: *chat}}, -100},
+		"inline":      {tgbotapi.Update{UpdateID: 6, InlineQuery: &tgbotapi.InlineQuery{From: &tgbotapi.User{ID: 9}}}, 9},
+		"unsupported": {tgbotapi.Update{UpdateID: 5}, 5},
+	}
+	for name, c := range cases {
+		if got := updateChatKey(c.update); got != c.want {
+			t.Errorf("%s: got %d, want %d", name, got, c.want)
+		}
+	}
+}
+
+func TestUpdateDispatcherKeepsChatOrder(t *testing.T) {
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	seen := map[int64][]int{}
+	d := newUpdateDispatcher(4, func(u tgbotapi.Update) {
+		defer wg.Done()
+		if u.UpdateID == 3 {
+			panic("boom") // паника не должна убить воркер
+		}
+		mu.Lock()
+		seen[u.Message.Chat.ID] = append(seen[u.Message.Chat.ID], u.UpdateID)
+		mu.Unlock()
+	})
+	for i := 1; i <= 40; i++ {
+		wg.Add(1)
+		d.dispatch(tgbotapi.Update{UpdateID: i, Message: &tgbotapi.Message{Chat: &tgbotapi.Chat{ID: int64(-(i % 3))}}})
+	}
+	wg.Wait()
+	for chatID, ids := range seen {
+		for i := 1; i < len(ids); i++ {
+			if ids[i] < ids[i-1] {
+				t.Fatalf("chat %d out of order: %v", chatID, ids)
+			}
+		}
+	}
+	if got := len(seen[0]) + len(seen[-1]) + len(seen[-2]); got != 39 {
+		t.Fatalf("processed %d updates, want 39", got)
+	}
+}
+
+type fakeWebhookDeps struct {
+	claimed    map[int]bool
+	claimErr   error
+	queueFull  bool
+	dispatched []int
+	remembered int
+}
+
+func (f *fakeWebhookDeps) handler() *webhookHandler {
+	return &webhookHandler{
+		secret: "s3cret",
+		claim: func(_ context.Context, id int) (bool, error) {
+			if f.claimErr != nil {
+				return false, f.claimErr
+			}
+			if f.claimed[id] {
+				return false, nil
+			}
+			f.claimed[id] = true
+			return true, nil
+		},
+		release: func(_ context.Context, id int) error {
+			delete(f.claimed, id)
+			return nil
+		},
+		dispatch: func(u tgbotapi.Update) bool {
+			if f.queueFull {
+				return false
+			}
+			f.dispatched = append(f.dispatched, u.UpdateID)
+			return true
+		},
+		remember: func([]byte) { f.remembered++ },
+	}
+}
+
+func postWebhook(t *testing.T, app *fiber.App, secret, body string) int {
+	t.Helper()
+	req := httptest.NewRequest("POST", "/hook", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	if secret != "" {
+		req.Header.Set(webhookSecretHeader, secret)
+	}
+	resp, err := app.Test(req, int(time.Second.Milliseconds()))
+	if err != nil {
+		t.Fatalf("request: %v", err)
+	}
+	return resp.StatusCode
+}
+
+func TestWebhookHandler(t *testing.T) {
+	deps := &fakeWebhookDeps{claimed: map[int]bool{}}
+	app := fiber.New()
+	app.Post("/hook", deps.handler().Handle)
+	update := `{"update_id": 10, "message": {"message_id": 1, "chat": {"id": -5}, "text": "hi"}}`
+
+	if code := postWebhook(t, app, "", update); code != fiber.StatusUnauthorized {
+		t.Fatalf("no secret: %d", code)
+	}
+	if code := postWebhook(t, app, "wrong", update); code != fiber.StatusUnauthorized {
+		t.Fatalf("wrong secret: %d", code)
+	}
+	if code := postWebhook(t, app, "s3cret", "{"); code != fiber.StatusBadRequest {
+		t.Fatalf("bad body: %d", code)
+	}
+	if code := postWebhook(t, app, "s3cret", update); code != fiber.StatusOK {
+		t.Fatalf("first delivery: %d", code)
+	}
+	// Ретрай Telegram или доставка на другую реплику — без повторной обработки.
+	if code := postWebhook(t, app, "s3cret", update); code != fiber.StatusOK {
+		t.Fatalf("duplicate: %d", code)
+	}
+	if len(deps.dispatched) != 1 || deps.remembered != 1 {
+		t.Fatalf("dispatched %v, remembered %d", deps.dispatched, deps.remembered)
+	}
+
+	deps.queueFull = true
+	if code := postWebhook(t, app, "s3cret", `{"update_id": 11}`); code != fiber.StatusServiceUnavailable {
+		t.Fatalf("full queue: %d", code)
+	}
+	if deps.claimed[11] {
+		t.Fatal("claim must be released when the update is not queued")
+	}
+
+	deps.claimErr = errors.New("redis down")
+	if code := postWebhook(t, app, "s3cret", `{"update_id": 12}`); code != fiber.StatusServiceUnavailable {
+		t.Fatalf("claim error: %d", code)
+	}
+}
@@ -4,37 +4,92 @@ import (
 	"context"
 	"fmt"
 	"ithozyeva/config"
+	"ithozyeva/internal/i18n"
+	"ithozyeva/internal/models"
+	"ithozyeva/internal/service"
 	"log"
 	"os"
 	"os/exec"
 	"path/filepath"
-	"regexp"
 	"time"
 
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 )
 
-var videoURLRegex = regexp.MustCompile(
-	`https?://(?:` +
-		`(?:www\.)?instagram\.com/reels?/[\w-]+` +
-		`|(?:www\.)?tiktok\.com/@[\w.]+/video/\d+` +
-		`|(?:vm|vt)\.tiktok\.com/[\w-]+` +
-		`|(?:www\.)?youtube\.com/shorts/[\w-]+` +
-		`|youtu\.be/[\w-]+` +
-		`)[\w\-._~:/?#\[\]@!$&'()*+,;=%]*`,
+// Скачивание коротких видео по ссылкам из сообщений. yt-dlp запускается
+// в ограниченном пуле воркеров (VIDEO_DOWNLOAD_WORKERS) с очередью
+// (VIDEO_DOWNLOAD_QUEUE_SIZE); загруженный ролик запоминаем по file_id и
+// повторную ссылку на него пересылаем без скачивания. Источники ссылок —
+// service.VideoSources.
+const (
+	videoDownloadTimeout = 60 * time.Second
+	videoMaxFileSize     = 49 * 1024 * 1024 // 49 MB, лимит Telegram — 50
+	// Не больше videoChatLimit скачиваний в чате за videoChatWindow:
+	// пересылки из кэша не считаются. Окно общее для реплик бота (Redis).
+	videoChatLimit  = 5
+	videoChatWindow = 10 * time.Minute
 )
 
-const maxVideoURLsPerMessage = 3
+type videoJob struct {
+	lang         i18n.Lang
+	chatID       int64
+	userID       int64
+	replyToMsgID int
+	link         service.VideoLink
+}
+
+// videoQueue — пул воркеров yt-dlp с общей очередью.
+type videoQueue struct {
+	jobs chan videoJob
+}
+
+func newVideoQueue(workers, size int, handle func(videoJob)) *videoQueue {
+	if workers <= 0 {
+		workers = 1
+	}
+	q := &videoQueue{jobs: make(chan videoJob, size)}
+	for i := 0; i < workers; i++ {
+		go func() {
+			for job := range q.jobs {
+				runVideoJob(job, handle)
+			}
+		}()
+	}
+	return q
+}
+
+func runVideoJob(job videoJob, handle func(videoJob)) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("[video_download] panic on %s: %v", job.link.URL, r)
+		}
+	}()
+	handle(job)
+}
+
+// tryEnqueue — без ожидания; false — очередь полна.
+func (q *videoQueue) tryEnqueue(job videoJob) bool {
+	select {
+	case q.jobs <- job:
+		return true
+	default:
+		return false
+	}
+}
 
-func extractVideoURLs(text string) []string {
-	matches := videoURLRegex.FindAllString(text, maxVideoURLsPerMessage+1)
-	if len(matches) > maxVideoURLsPerMessage {
-		matches = matches[:maxVideoURLsPerMessage]
+// allowVideoDownload резервирует скачивание в окне чата. Ошибка Redis
+// лимит не применяет: очередь скачиваний и так ограничена.
+func (b *TelegramBot) allowVideoDownload(job videoJob) bool {
+	jobID := fmt.Sprintf("%d:%s", job.replyToMsgID, job.link.URL)
+	ok, err := b.chatState.AllowVideoDownload(context.Background(), job.chatID, jobID, time.Now(), videoChatWindow, videoChatLimit)
+	if err != nil {
+		log.Printf("[video_download] chat limit chat=%d: %v", job.chatID, err)
+		return true
 	}
-	return matches
+	return ok
 }
 
-func (b *TelegramBot) handleVideoURLs(message *tgbotapi.Message, urls []string) {
+func (b *TelegramBot) handleVideoURLs(message *tgbotapi.Message, links []service.VideoLink) {
 	if message.From != nil && message.From.ID == b.bot.Self.ID {
 		return
 	}
@@ -46,23 +101,90 @@ func (b *TelegramBot) handleVideoURLs(message *tgbotapi.Message, urls []string)
 		return
 	}
 
-	for _, url := range urls {
-		if err := b.downloadAndSendVideo(message.Chat.ID, message.MessageID, url); err != nil {
-			log.Printf("[video_download] error processing %s: %v", url, err)
+	job := videoJob{lang: i18n.Default, chatID: message.Chat.ID, replyToMsgID: message.MessageID}
+	if message.From != nil {
+		job.lang = b.langOf(message.From.ID)
+		job.userID = message.From.ID
+	}
+	limitReplied := false
+	for _, link := range links {
+		job.link = link
+		entry := &models.VideoDownload{URL: link.URL, Source: link.Source, ChatID: job.chatID, UserID: job.userID}
+
+		if b.sendCachedVideo(job) {
+			entry.Status = models.VideoDownloadCached
+			b.videoDownloads.Record(entry)
+			continue
+		}
+		if !b.allowVideoDownload(job) {
+			entry.Status = models.VideoDownloadRateLimited
+			b.videoDownloads.Record(entry)
+			// В группе молчим, чтобы не шуметь; в личке объясняем.
+			if isPrivate && !limitReplied {
+				b.sendReplyText(job.chatID, job.replyToMsgID, i18n.T(job.lang, "Слишком много видео за короткое время, попробуйте через несколько минут"))
+				limitReplied = true
+			}
+			continue
 		}
+		if !b.videoQueue.tryEnqueue(job) {
+			entry.Status = models.VideoDownloadQueueFull
+			b.videoDownloads.Record(entry)
+			b.sendReplyText(job.chatID, job.replyToMsgID, i18n.T(job.lang, "Сейчас скачивается слишком много видео, попробуйте позже"))
+		}
+	}
+}
+
+// sendCachedVideo пересылает ролик по file_id из кэша. Если Telegram
+// file_id не принял — забываем его, ролик скачается заново.
+func (b *TelegramBot) sendCachedVideo(job videoJob) bool {
+	fileID, ok := b.videoDownloads.CachedFileID(job.link)
+	if !ok {
+		return false
 	}
+	video := tgbotapi.NewVideo(job.chatID, tgbotapi.FileID(fileID))
+	video.ReplyToMessageID = job.replyToMsgID
+	if _, err := b.bot.Send(video); err != nil {
+		log.Printf("[video_download] cached file_id for %s rejected: %v", job.link.Key, err)
+		b.videoDownloads.Forget(job.link)
+		return false
+	}
+	b.videoDownloads.CacheHit(job.link)
+	return true
 }
 
-func (b *TelegramBot) downloadAndSendVideo(chatID int64, replyToMsgID int, url string) error {
+// processVideoJob — воркер: скачивание, отправка и запись в журнал.
+func (b *TelegramBot) processVideoJob(job videoJob) {
+	start := time.Now()
+	status, size, err := b.downloadAndSendVideo(job)
+	entry := &models.VideoDownload{
+		URL:        job.link.URL,
+		Source:     job.link.Source,
+		ChatID:     job.chatID,
+		UserID:     job.userID,
+		Status:     status,
+		DurationMs: int(time.Since(start).Milliseconds()),
+		FileSize:   size,
+	}
+	if err != nil {
+		entry.Error = err.Error()
+		log.Printf("[video_download] error processing %s: %v", job.link.URL, err)
+	}
+	b.videoDownloads.Record(entry)
+}
+
+// downloadAndSendVideo возвращает исход (models.VideoDownload*) и размер файла.
+func (b *TelegramBot) downloadAndSendVideo(job videoJob) (string, int64, error) {
+	lang, chatID, replyToMsgID, url := job.lang, job.chatID, job.replyToMsgID, job.link.URL
+
 	tmpDir, err := os.MkdirTemp("", "ytdlp-*")
 	if err != nil {
-		return fmt.Errorf("failed to create temp dir: %w", err)
+		return models.VideoDownloadFailed, 0, fmt.Errorf("failed to create temp dir: %w", err)
 	}
 	defer os.RemoveAll(tmpDir)
 
 	outputTemplate := filepath.Join(tmpDir, "video.%(ext)s")
 
-	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	ctx, cancel := context.WithTimeout(context.Background(), videoDownloadTimeout)
 	defer cancel()
 
 	cmd := exec.CommandContext(ctx, "yt-dlp",
@@ -76,40 +198,45 @@ func (b *TelegramBot) downloadAndSendVideo(chatID int64, replyToMsgID int, url s
 	output, err := cmd.CombinedOutput()
 	if err != nil {
 		if ctx.Err() == context.DeadlineExceeded {
-			b.sendReplyText(chatID, replyToMsgID, "Скачивание видео заняло слишком много времени")
-			return fmt.Errorf("timeout downloading %s", url)
+			b.sendReplyText(chatID, replyToMsgID, i18n.T(lang, "Скачивание видео заняло слишком много времени"))
+			return models.VideoDownloadTimeout, 0, fmt.Errorf("timeout downloading %s", url)
 		}
-		b.sendReplyText(chatID, replyToMsgID, "Не удалось скачать видео по этой ссылке")
-		return fmt.Errorf("yt-dlp failed: %w, output: %s", err, string(output))
+		b.sendReplyText(chatID, replyToMsgID, i18n.T(lang, "Не удалось скачать видео по этой ссылке"))
+		return models.VideoDownloadFailed, 0, fmt.Errorf("yt-dlp failed: %w, output: %s", err, string(output))
 	}
 
 	files, err := filepath.Glob(filepath.Join(tmpDir, "video.*"))
 	if err != nil || len(files) == 0 {
-		b.sendReplyText(chatID, replyToMsgID, "Не удалось скачать видео по этой ссылке")
-		return fmt.Errorf("no downloaded file found in %s", tmpDir)
+		b.sendReplyText(chatID, replyToMsgID, i18n.T(lang, "Не удалось скачать видео по этой ссылке"))
+		return models.VideoDownloadFailed, 0, fmt.Errorf("no downloaded file found in %s", tmpDir)
 	}
 
 	videoPath := files[0]
 
 	info, err := os.Stat(videoPath)
 	if err != nil {
-		return fmt.Errorf("failed to stat video file: %w", err)
+		return models.VideoDownloadFailed, 0, fmt.Errorf("failed to stat video file: %w", err)
 	}
 
-	const maxSize = 49 * 1024 * 1024 // 49 MB
-	if info.Size() > maxSize {
-		b.sendReplyText(chatID, replyToMsgID, "Видео слишком большое для Telegram (лимит 50 МБ)")
-		return fmt.Errorf("video too large: %d bytes", info.Size())
+	if info.Size() > videoMaxFileSize {
+		b.sendReplyText(chatID, replyToMsgID, i18n.T(lang, "Видео слишком большое для Telegram (лимит 50 МБ)"))
+		return models.VideoDownloadTooLarge, info.Size(), fmt.Errorf("video too large: %d bytes", info.Size())
 	}
 
 	video := tgbotapi.NewVideo(chatID, tgbotapi.FilePath(videoPath))
 	video.ReplyToMessageID = replyToMsgID
 
-	if _, err := b.bot.Send(video); err != nil {
-		return fmt.Errorf("failed to send video: %w", err)
+	sent, err := b.bot.Send(video)
+	if err != nil {
+		return models.VideoDownloadFailed, info.Size(), fmt.Errorf("failed to send video: %w", err)
+	}
+	// Telegram может превратить короткий ролик без звука в анимацию —
+	// такой не кэшируем, NewVideo с чужим типом file_id не примет.
+	if sent.Video != nil {
+		b.videoDownloads.Remember(job.link, sent.Video.FileID, info.Size())
 	}
 
-	return nil
+	return models.VideoDownloadSent, info.Size(), nil
 }
 
 func (b *TelegramBot) sendReplyText(chatID int64, replyToMsgID int, text string) {
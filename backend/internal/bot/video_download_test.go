@@ -0,0 +1,85 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"ithozyeva/internal/service"
+	"ithozyeva/internal/testutil"
+)
+
+func TestVideoChatLimit(t *testing.T) {
+	_, rdb := testutil.NewMiniRedis(t)
+	state := stateBot(rdb).chatState
+	now := time.Now()
+	jobs := 0
+	allow := func(chatID int64, at time.Time) bool {
+		t.Helper()
+		jobs++
+		ok, err := state.AllowVideoDownload(context.Background(), chatID, fmt.Sprint(jobs), at, videoChatWindow, videoChatLimit)
+		if err != nil {
+			t.Fatalf("AllowVideoDownload: %v", err)
+		}
+		return ok
+	}
+
+	for i := 0; i < videoChatLimit; i++ {
+		if !allow(-1, now.Add(time.Duration(i)*time.Second)) {
+			t.Fatalf("download %d must pass", i)
+		}
+	}
+	if allow(-1, now.Add(time.Minute)) {
+		t.Fatal("limit must block")
+	}
+	if !allow(-2, now.Add(time.Minute)) {
+		t.Fatal("other chat is independent")
+	}
+	// Первое скачивание вышло из окна — освободилось одно место.
+	later := now.Add(videoChatWindow + time.Second/2)
+	if !allow(-1, later) {
+		t.Fatal("window must slide")
+	}
+	if allow(-1, later) {
+		t.Fatal("only one slot frees up")
+	}
+}
+
+// Лимит общий для реплик: скачивания, принятые разными процессами, идут
+// в одно окно чата.
+func TestAllowVideoDownloadAcrossReplicas(t *testing.T) {
+	_, rdb := testutil.NewMiniRedis(t)
+	replicas := []*TelegramBot{stateBot(rdb), stateBot(rdb)}
+	allowed := 0
+	for i := 0; i < videoChatLimit+2; i++ {
+		job := videoJob{chatID: -1, replyToMsgID: i + 1, link: service.VideoLink{URL: "https://example.com/v"}}
+		if replicas[i%2].allowVideoDownload(job) {
+			allowed++
+		}
+	}
+	if allowed != videoChatLimit {
+		t.Fatalf("allowed = %d, want %d", allowed, videoChatLimit)
+	}
+}
+
+func TestVideoQueueTryEnqueue(t *testing.T) {
+	release := make(chan struct{})
+	started := make(chan struct{}, 1)
+	q := newVideoQueue(1, 1, func(videoJob) {
+		started <- struct{}{}
+		<-release
+	})
+	defer close(release)
+
+	if !q.tryEnqueue(videoJob{}) {
+		t.Fatal("first job must be accepted")
+	}
+	<-started // воркер занят первым
+	if !q.tryEnqueue(videoJob{}) {
+		t.Fatal("second job must wait in the queue")
+	}
+	if q.tryEnqueue(videoJob{}) {
+		t.Fatal("full queue must reject")
+	}
+}
@@ -25,7 +25,7 @@ func (h *AchievementHandler) GetMyAchievements(c *fiber.Ctx) error {
 
 	resp, err := h.svc.GetUserAchievements(member.Id)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Не удалось получить достижения"})
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Не удалось получить достижения")})
 	}
 
 	return c.JSON(resp)
@@ -34,12 +34,12 @@ func (h *AchievementHandler) GetMyAchievements(c *fiber.Ctx) error {
 func (h *AchievementHandler) GetMemberAchievements(c *fiber.Ctx) error {
 	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Неверный ID"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный ID")})
 	}
 
 	resp, err := h.svc.GetUserAchievements(id)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Не удалось получить достижения"})
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Не удалось получить достижения")})
 	}
 
 	return c.JSON(resp)
@@ -26,7 +26,7 @@ func (h *AdminDailyTaskHandler) List(c *fiber.Ctx) error {
 	tasks, err := h.repo.GetAllAdmin()
 	if err != nil {
 		log.Printf("admin daily tasks list: %v", err)
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Ошибка загрузки"})
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Ошибка загрузки")})
 	}
 	return c.JSON(fiber.Map{"items": tasks})
 }
@@ -34,15 +34,15 @@ func (h *AdminDailyTaskHandler) List(c *fiber.Ctx) error {
 func (h *AdminDailyTaskHandler) Create(c *fiber.Ctx) error {
 	t := new(models.DailyTask)
 	if err := c.BodyParser(t); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Неверный запрос"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный запрос")})
 	}
 	if err := validateDailyTask(t); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": trErr(c, err)})
 	}
 	t.Id = 0
 	if err := h.repo.Create(t); err != nil {
 		log.Printf("admin daily task create: %v", err)
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Ошибка создания"})
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Ошибка создания")})
 	}
 	return c.Status(fiber.StatusCreated).JSON(t)
 }
@@ -50,18 +50,18 @@ func (h *AdminDailyTaskHandler) Create(c *fiber.Ctx) error {
 func (h *AdminDailyTaskHandler) Update(c *fiber.Ctx) error {
 	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Неверный ID"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный ID")})
 	}
 	existing, err := h.repo.GetById(id)
 	if errors.Is(err, gorm.ErrRecordNotFound) {
-		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Не найдено"})
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": tr(c, "Не найдено")})
 	}
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Ошибка"})
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Ошибка")})
 	}
 	patch := new(models.DailyTask)
 	if err := c.BodyParser(patch); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Неверный запрос"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный запрос")})
 	}
 	// Обновляем только поля, разрешённые админу. Code оставляем неизменным
 	// для предотвращения коллизий с уже выпавшими сетами (UNIQUE по code).
@@ -74,11 +74,11 @@ func (h *AdminDailyTaskHandler) Update(c *fiber.Ctx) error {
 	existing.TriggerKey = patch.TriggerKey
 	existing.Active = patch.Active
 	if err := validateDailyTask(existing); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": trErr(c, err)})
 	}
 	if err := h.repo.Update(existing); err != nil {
 		log.Printf("admin daily task update: %v", err)
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Ошибка обновления"})
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Ошибка обновления")})
 	}
 	return c.JSON(existing)
 }
@@ -86,11 +86,11 @@ func (h *AdminDailyTaskHandler) Update(c *fiber.Ctx) error {
 func (h *AdminDailyTaskHandler) Delete(c *fiber.Ctx) error {
 	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Неверный ID"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный ID")})
 	}
 	if err := h.repo.Delete(id); err != nil {
 		log.Printf("admin daily task delete: %v", err)
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Ошибка удаления"})
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Ошибка удаления")})
 	}
 	return c.SendStatus(fiber.StatusNoContent)
 }
@@ -103,7 +103,7 @@ func (h *AdminDailyTaskHandler) RecentSets(c *fiber.Ctx) error {
 	sets, err := h.repo.GetRecentSets(limit)
 	if err != nil {
 		log.Printf("admin daily task sets: %v", err)
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Ошибка загрузки"})
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Ошибка загрузки")})
 	}
 	return c.JSON(fiber.Map{"items": sets})
 }
@@ -146,7 +146,7 @@ func (h *AdminChallengeHandler) ListTemplates(c *fiber.Ctx) error {
 	tpls, err := h.repo.GetAllTemplatesAdmin()
 	if err != nil {
 		log.Printf("admin challenge templates list: %v", err)
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Ошибка загрузки"})
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Ошибка загрузки")})
 	}
 	return c.JSON(fiber.Map{"items": tpls})
 }
@@ -154,15 +154,15 @@ func (h *AdminChallengeHandler) ListTemplates(c *fiber.Ctx) error {
 func (h *AdminChallengeHandler) CreateTemplate(c *fiber.Ctx) error {
 	t := new(models.ChallengeTemplate)
 	if err := c.BodyParser(t); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Неверный запрос"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный запрос")})
 	}
 	if err := validateChallengeTemplate(t); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": trErr(c, err)})
 	}
 	t.Id = 0
 	if err := h.repo.CreateTemplate(t); err != nil {
 		log.Printf("admin challenge template create: %v", err)
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Ошибка создания"})
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Ошибка создания")})
 	}
 	return c.Status(fiber.StatusCreated).JSON(t)
 }
@@ -170,18 +170,18 @@ func (h *AdminChallengeHandler) CreateTemplate(c *fiber.Ctx) error {
 func (h *AdminChallengeHandler) UpdateTemplate(c *fiber.Ctx) error {
 	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Неверный ID"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный ID")})
 	}
 	existing, err := h.repo.GetTemplateById(id)
 	if errors.Is(err, gorm.ErrRecordNotFound) {
-		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Не найдено"})
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": tr(c, "Не найдено")})
 	}
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Ошибка"})
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Ошибка")})
 	}
 	patch := new(models.ChallengeTemplate)
 	if err := c.BodyParser(patch); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Неверный запрос"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный запрос")})
 	}
 	existing.Title = patch.Title
 	existing.Description = patch.Description
@@ -193,11 +193,11 @@ func (h *AdminChallengeHandler) UpdateTemplate(c *fiber.Ctx) error {
 	existing.AchievementCode = patch.AchievementCode
 	existing.Active = patch.Active
 	if err := validateChallengeTemplate(existing); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": trErr(c, err)})
 	}
 	if err := h.repo.UpdateTemplate(existing); err != nil {
 		log.Printf("admin challenge template update: %v", err)
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Ошибка обновления"})
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Ошибка обновления")})
 	}
 	return c.JSON(existing)
 }
@@ -205,11 +205,11 @@ func (h *AdminChallengeHandler) UpdateTemplate(c *fiber.Ctx) error {
 func (h *AdminChallengeHandler) DeleteTemplate(c *fiber.Ctx) error {
 	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Неверный ID"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный ID")})
 	}
 	if err := h.repo.DeleteTemplate(id); err != nil {
 		log.Printf("admin challenge template delete: %v", err)
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Ошибка удаления"})
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Ошибка удаления")})
 	}
 	return c.SendStatus(fiber.StatusNoContent)
 }
@@ -222,7 +222,7 @@ func (h *AdminChallengeHandler) ListInstances(c *fiber.Ctx) error {
 	insts, err := h.repo.GetRecentInstances(limit)
 	if err != nil {
 		log.Printf("admin challenge instances: %v", err)
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Ошибка загрузки"})
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Ошибка загрузки")})
 	}
 	return c.JSON(fiber.Map{"items": insts})
 }
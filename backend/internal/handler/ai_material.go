@@ -28,11 +28,11 @@ func respondAIMaterialErr(c *fiber.Ctx, err error) error {
 	switch {
 	case errors.Is(err, service.ErrAIMaterialNotFound),
 		errors.Is(err, gorm.ErrRecordNotFound):
-		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": err.Error()})
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": trErr(c, err)})
 	case errors.Is(err, service.ErrAIMaterialForbidden):
-		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": err.Error()})
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": trErr(c, err)})
 	default:
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": trErr(c, err)})
 	}
 }
 
@@ -65,13 +65,13 @@ func (h *AIMaterialHandler) Search(c *fiber.Ctx) error {
 	}
 
 	if filter.Kind != "" && !models.IsValidAIMaterialKind(models.AIMaterialKind(filter.Kind)) {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Некорректная категория"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Некорректная категория")})
 	}
 
 	items, total, err := h.svc.Search(filter)
 	if err != nil {
 		log.Printf("AIMaterial search error: %v", err)
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Не удалось получить материалы"})
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Не удалось получить материалы")})
 	}
 
 	return c.JSON(fiber.Map{"items": items, "total": total})
@@ -84,7 +84,7 @@ func (h *AIMaterialHandler) GetByID(c *fiber.Ctx) error {
 	}
 	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Неверный ID"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный ID")})
 	}
 
 	item, err := h.svc.GetByID(id, member.Id, hasAdminRole(member))
@@ -104,7 +104,7 @@ func (h *AIMaterialHandler) Create(c *fiber.Ctx) error {
 
 	var req models.CreateAIMaterialRequest
 	if err := c.BodyParser(&req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Неверный формат запроса"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный формат запроса")})
 	}
 
 	item, err := h.svc.Create(&req, member.Id)
@@ -121,12 +121,12 @@ func (h *AIMaterialHandler) Update(c *fiber.Ctx) error {
 	}
 	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Неверный ID"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный ID")})
 	}
 
 	var req models.UpdateAIMaterialRequest
 	if err := c.BodyParser(&req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Неверный формат запроса"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный формат запроса")})
 	}
 
 	item, err := h.svc.Update(id, &req, member.Id, hasAdminRole(member))
@@ -143,7 +143,7 @@ func (h *AIMaterialHandler) Delete(c *fiber.Ctx) error {
 	}
 	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Неверный ID"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный ID")})
 	}
 
 	if err := h.svc.Delete(id, member.Id, hasAdminRole(member)); err != nil {
@@ -160,7 +160,7 @@ func (h *AIMaterialHandler) SetHidden(c *fiber.Ctx) error {
 	}
 	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Неверный ID"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный ID")})
 	}
 
 	type body struct {
@@ -168,7 +168,7 @@ func (h *AIMaterialHandler) SetHidden(c *fiber.Ctx) error {
 	}
 	var b body
 	if err := c.BodyParser(&b); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Неверный формат запроса"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный формат запроса")})
 	}
 
 	if err := h.svc.SetHidden(id, b.Hidden, hasAdminRole(member)); err != nil {
@@ -184,7 +184,7 @@ func (h *AIMaterialHandler) ToggleLike(c *fiber.Ctx) error {
 	}
 	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Неверный ID"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный ID")})
 	}
 	liked, count, err := h.svc.ToggleLike(id, member.Id, hasAdminRole(member))
 	if err != nil {
@@ -200,7 +200,7 @@ func (h *AIMaterialHandler) ToggleBookmark(c *fiber.Ctx) error {
 	}
 	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Неверный ID"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный ID")})
 	}
 	bookmarked, count, err := h.svc.ToggleBookmark(id, member.Id, hasAdminRole(member))
 	if err != nil {
@@ -218,7 +218,7 @@ func (h *AIMaterialHandler) TopTags(c *fiber.Ctx) error {
 	tags, err := h.svc.TopTags(q, limit)
 	if err != nil {
 		log.Printf("AIMaterial top tags error: %v", err)
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Не удалось получить теги"})
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Не удалось получить теги")})
 	}
 	return c.JSON(fiber.Map{"tags": tags})
 }
@@ -30,7 +30,7 @@ type AuditLogSearchRequest struct {
 func (h *AuditLogHandler) Search(c *fiber.Ctx) error {
 	req := new(AuditLogSearchRequest)
 	if err := c.QueryParser(req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Неверный запрос"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный запрос")})
 	}
 
 	filter := make(repository.SearchFilter)
@@ -48,7 +48,7 @@ func (h *AuditLogHandler) Search(c *fiber.Ctx) error {
 	result, err := h.svc.Search(req.Limit, req.Offset, &filter)
 	if err != nil {
 		log.Printf("audit log search error: %v", err)
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Ошибка поиска записей аудита"})
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Ошибка поиска записей аудита")})
 	}
 
 	return c.JSON(result)
@@ -6,6 +6,7 @@ import (
 
 	"ithozyeva/config"
 	"ithozyeva/internal/bot"
+	"ithozyeva/internal/i18n"
 	"ithozyeva/internal/models"
 	"ithozyeva/internal/service"
 	"ithozyeva/internal/utils"
@@ -129,6 +130,7 @@ func (h *TelegramAuthHandler) AuthenticateWebApp(c *fiber.Ctx) error {
 			Username:   tgUser.Username,
 			FirstName:  tgUser.FirstName,
 			LastName:   tgUser.LastName,
+			Language:   string(i18n.FromTelegram(tgUser.LanguageCode)),
 			Roles:      []models.Role{models.MemberRoleUnsubscriber},
 		}
 		created, createErr := h.authService.CreateNewMember(newUser, token)
@@ -149,6 +151,11 @@ func (h *TelegramAuthHandler) AuthenticateWebApp(c *fiber.Ctx) error {
 			user.Username = tgUser.Username
 		}
 		h.memberService.Update(user)
+		if user.Language == "" {
+			if lang, err := h.memberService.GetLanguage(tgUser.ID, tgUser.LanguageCode); err == nil {
+				user.Language = string(lang)
+			}
+		}
 
 		if _, err := h.authService.CreateOrUpdateToken(tgUser.ID, token); err != nil {
 			log.Printf("webapp auth: failed to upsert token for tg_id=%d: %v", tgUser.ID, err)
@@ -323,6 +330,8 @@ type HandleBotMessageReq struct {
 	LastName  string      `json:"last_name"`
 	Role      models.Role `json:"role"`
 	AvatarURL string      `json:"avatar_url"`
+	// LanguageCode — language_code Telegram для новой записи.
+	LanguageCode string `json:"language_code"`
 }
 
 func (h *TelegramAuthHandler) HandleBotMessage(c *fiber.Ctx) error {
@@ -370,6 +379,7 @@ func (h *TelegramAuthHandler) HandleBotMessage(c *fiber.Ctx) error {
 			FirstName:  req.FirstName,
 			LastName:   req.LastName,
 			AvatarURL:  req.AvatarURL,
+			Language:   string(i18n.FromTelegram(req.LanguageCode)),
 			Roles:      []models.Role{role},
 		}
 
@@ -6,6 +6,7 @@ import (
 	"strconv"
 	"strings"
 
+	"ithozyeva/internal/i18n"
 	"ithozyeva/internal/models"
 	"ithozyeva/internal/service"
 
@@ -20,6 +21,25 @@ func getActorId(c *fiber.Ctx) int64 {
 	return 0
 }
 
+// requestLang — язык ответа: выбранный участником, иначе из Accept-Language.
+func requestLang(c *fiber.Ctx) i18n.Lang {
+	fallback := i18n.FromAcceptLanguage(c.Get(fiber.HeaderAcceptLanguage))
+	if member, ok := c.Locals("member").(*models.Member); ok && member != nil {
+		return i18n.Resolve(member.Language, fallback)
+	}
+	return fallback
+}
+
+// tr — сообщение для ответа на языке запроса.
+func tr(c *fiber.Ctx, msg string, args ...interface{}) string {
+	return i18n.T(requestLang(c), msg, args...)
+}
+
+// trErr — текст ошибки сервиса на языке запроса.
+func trErr(c *fiber.Ctx, err error) string {
+	return i18n.LocalizeError(requestLang(c), err)
+}
+
 // getActorName извлекает имя актора из контекста запроса
 func getActorName(c *fiber.Ctx) string {
 	if member, ok := c.Locals("member").(*models.Member); ok && member != nil {
@@ -57,14 +77,14 @@ func NewBaseHandler[T any](service service.BaseService[T]) *BaseHandler[T] {
 func (h *BaseHandler[T]) Search(c *fiber.Ctx) error {
 	req := new(models.SearchRequest)
 	if err := c.QueryParser(req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Неверный запрос"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный запрос")})
 	}
 
 	// Передаем указатели в сервис
 	result, err := h.service.Search(req.Limit, req.Offset, nil, nil)
 	if err != nil {
 		log.Printf("search error: %v", err)
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Ошибка поиска"})
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Ошибка поиска")})
 	}
 
 	return c.JSON(result)
@@ -74,13 +94,13 @@ func (h *BaseHandler[T]) Search(c *fiber.Ctx) error {
 func (h *BaseHandler[T]) Create(c *fiber.Ctx) error {
 	entity := new(T)
 	if err := c.BodyParser(entity); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Неверный запрос"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный запрос")})
 	}
 
 	result, err := h.service.Create(entity)
 	if err != nil {
 		log.Printf("create error: %v", err)
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Ошибка создания"})
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Ошибка создания")})
 	}
 
 	return c.Status(fiber.StatusCreated).JSON(result)
@@ -90,13 +110,13 @@ func (h *BaseHandler[T]) Create(c *fiber.Ctx) error {
 func (h *BaseHandler[T]) Update(c *fiber.Ctx) error {
 	entity := new(T)
 	if err := c.BodyParser(entity); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Неверный запрос"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный запрос")})
 	}
 
 	result, err := h.service.Update(entity)
 	if err != nil {
 		log.Printf("update error: %v", err)
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Ошибка обновления"})
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Ошибка обновления")})
 	}
 
 	return c.JSON(result)
@@ -106,13 +126,13 @@ func (h *BaseHandler[T]) Update(c *fiber.Ctx) error {
 func (h *BaseHandler[T]) Delete(c *fiber.Ctx) error {
 	id, err := strconv.Atoi(c.Params("id"))
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Неверный ID"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный ID")})
 	}
 
 	entity, err := h.service.GetById(int64(id))
 
 	if err != nil {
-		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Сущность не найдена"})
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": tr(c, "Сущность не найдена")})
 	}
 
 	// Пробуем использовать интерфейс Identifiable
@@ -125,13 +145,13 @@ func (h *BaseHandler[T]) Delete(c *fiber.Ctx) error {
 		if idField.IsValid() && idField.CanSet() {
 			idField.SetInt(int64(id))
 		} else {
-			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Невозможно установить ID для сущности"})
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Невозможно установить ID для сущности")})
 		}
 	}
 
 	if err := h.service.Delete(entity); err != nil {
 		log.Printf("delete error: %v", err)
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Ошибка удаления"})
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Ошибка удаления")})
 	}
 
 	return c.SendStatus(fiber.StatusNoContent)
@@ -141,12 +161,12 @@ func (h *BaseHandler[T]) Delete(c *fiber.Ctx) error {
 func (h *BaseHandler[T]) GetById(c *fiber.Ctx) error {
 	id, err := strconv.Atoi(c.Params("id"))
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Неверный ID"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный ID")})
 	}
 
 	entity, err := h.service.GetById(int64(id))
 	if err != nil {
-		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Сущность не найдена"})
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": tr(c, "Сущность не найдена")})
 	}
 
 	return c.JSON(entity)
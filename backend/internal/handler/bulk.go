@@ -31,12 +31,12 @@ type BulkIdsRequest struct {
 func (h *BulkHandler) BulkDeleteEvents(c *fiber.Ctx) error {
 	req := new(BulkIdsRequest)
 	if err := c.BodyParser(req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Неверный запрос"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный запрос")})
 	}
 
 	if err := database.DB.Where("id IN ?", req.Ids).Delete(&models.Event{}).Error; err != nil {
 		log.Printf("bulk delete events error: %v", err)
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Ошибка массового удаления событий"})
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Ошибка массового удаления событий")})
 	}
 
 	actorId, actorName, actorType := getActorId(c), getActorName(c), getActorType(c)
@@ -52,12 +52,12 @@ func (h *BulkHandler) BulkDeleteEvents(c *fiber.Ctx) error {
 func (h *BulkHandler) BulkDeleteMentors(c *fiber.Ctx) error {
 	req := new(BulkIdsRequest)
 	if err := c.BodyParser(req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Неверный запрос"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный запрос")})
 	}
 
 	if err := database.DB.Where("id IN ?", req.Ids).Delete(&models.MentorDbShortModel{}).Error; err != nil {
 		log.Printf("bulk delete mentors error: %v", err)
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Ошибка массового удаления менторов"})
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Ошибка массового удаления менторов")})
 	}
 
 	actorId, actorName, actorType := getActorId(c), getActorName(c), getActorType(c)
@@ -73,12 +73,12 @@ func (h *BulkHandler) BulkDeleteMentors(c *fiber.Ctx) error {
 func (h *BulkHandler) BulkDeleteMembers(c *fiber.Ctx) error {
 	req := new(BulkIdsRequest)
 	if err := c.BodyParser(req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Неверный запрос"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный запрос")})
 	}
 
 	if err := database.DB.Where("id IN ?", req.Ids).Delete(&models.Member{}).Error; err != nil {
 		log.Printf("bulk delete members error: %v", err)
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Ошибка массового удаления участников"})
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Ошибка массового удаления участников")})
 	}
 
 	actorId, actorName, actorType := getActorId(c), getActorName(c), getActorType(c)
@@ -94,12 +94,12 @@ func (h *BulkHandler) BulkDeleteMembers(c *fiber.Ctx) error {
 func (h *BulkHandler) BulkDeleteReviews(c *fiber.Ctx) error {
 	req := new(BulkIdsRequest)
 	if err := c.BodyParser(req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Неверный запрос"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный запрос")})
 	}
 
 	if err := database.DB.Where("id IN ?", req.Ids).Delete(&models.ReviewOnCommunity{}).Error; err != nil {
 		log.Printf("bulk delete reviews error: %v", err)
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Ошибка массового удаления отзывов"})
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Ошибка массового удаления отзывов")})
 	}
 
 	actorId, actorName, actorType := getActorId(c), getActorName(c), getActorType(c)
@@ -115,7 +115,7 @@ func (h *BulkHandler) BulkDeleteReviews(c *fiber.Ctx) error {
 func (h *BulkHandler) BulkApproveReviews(c *fiber.Ctx) error {
 	req := new(BulkIdsRequest)
 	if err := c.BodyParser(req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Неверный запрос"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный запрос")})
 	}
 
 	var reviews []models.ReviewOnCommunity
@@ -129,7 +129,7 @@ func (h *BulkHandler) BulkApproveReviews(c *fiber.Ctx) error {
 	})
 	if err != nil {
 		log.Printf("BulkApproveReviews error: %v", err)
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Ошибка при одобрении отзывов"})
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Ошибка при одобрении отзывов")})
 	}
 
 	actorId, actorName, actorType := getActorId(c), getActorName(c), getActorType(c)
@@ -149,7 +149,7 @@ func (h *BulkHandler) BulkApproveReviews(c *fiber.Ctx) error {
 func (h *BulkHandler) BulkApproveServiceReviews(c *fiber.Ctx) error {
 	req := new(BulkIdsRequest)
 	if err := c.BodyParser(req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Неверный запрос"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный запрос")})
 	}
 
 	var reviews []models.ReviewOnService
@@ -163,7 +163,7 @@ func (h *BulkHandler) BulkApproveServiceReviews(c *fiber.Ctx) error {
 	})
 	if err != nil {
 		log.Printf("BulkApproveServiceReviews error: %v", err)
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Ошибка при одобрении отзывов"})
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Ошибка при одобрении отзывов")})
 	}
 
 	actorId, actorName, actorType := getActorId(c), getActorName(c), getActorType(c)
@@ -185,12 +185,12 @@ func (h *BulkHandler) BulkApproveServiceReviews(c *fiber.Ctx) error {
 func (h *BulkHandler) BulkDeleteMentorsReviews(c *fiber.Ctx) error {
 	req := new(BulkIdsRequest)
 	if err := c.BodyParser(req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Неверный запрос"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный запрос")})
 	}
 
 	if err := database.DB.Where("id IN ?", req.Ids).Delete(&models.ReviewOnService{}).Error; err != nil {
 		log.Printf("bulk delete mentor reviews error: %v", err)
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Ошибка массового удаления отзывов"})
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Ошибка массового удаления отзывов")})
 	}
 
 	actorId, actorName, actorType := getActorId(c), getActorName(c), getActorType(c)
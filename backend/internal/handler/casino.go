@@ -1,12 +1,14 @@
 package handler
 
 import (
+	"errors"
 	"log"
 	"strconv"
 	"sync"
 	"time"
 
 	"ithozyeva/internal/models"
+	"ithozyeva/internal/repository"
 	"ithozyeva/internal/service"
 
 	"github.com/gofiber/fiber/v2"
@@ -14,15 +16,34 @@ import (
 
 type CasinoHandler struct {
 	svc      *service.CasinoService
+	crash    *service.CrashService
+	jackpot  *service.CasinoJackpotService
+	limits   *service.CasinoLimitsService
+	auditSvc *service.AuditService
 	lastBet  sync.Map
 }
 
 func NewCasinoHandler() *CasinoHandler {
 	return &CasinoHandler{
-		svc: service.NewCasinoService(),
+		svc:      service.NewCasinoService(),
+		crash:    service.GetCrashService(),
+		jackpot:  service.NewCasinoJackpotService(),
+		limits:   service.NewCasinoLimitsService(),
+		auditSvc: service.NewAuditService(),
 	}
 }
 
+// betError — ответ на неудачную ставку: отказ по лимитам показываем как есть,
+// остальное — общим текстом.
+func betError(c *fiber.Ctx, game string, memberId int64, err error) error {
+	var limitErr *service.CasinoLimitError
+	if errors.As(err, &limitErr) {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": trErr(c, err)})
+	}
+	log.Printf("%s error (member=%d): %v", game, memberId, err)
+	return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Не удалось выполнить ставку")})
+}
+
 func (h *CasinoHandler) checkRateLimit(memberId int64) error {
 	now := time.Now()
 	if last, ok := h.lastBet.Load(memberId); ok {
@@ -40,18 +61,17 @@ func (h *CasinoHandler) PlayCoinFlip(c *fiber.Ctx) error {
 		return err
 	}
 	if err := h.checkRateLimit(member.Id); err != nil {
-		return c.Status(fiber.StatusTooManyRequests).JSON(fiber.Map{"error": "Подождите секунду между ставками"})
+		return c.Status(fiber.StatusTooManyRequests).JSON(fiber.Map{"error": tr(c, "Подождите секунду между ставками")})
 	}
 
 	req := new(models.CoinFlipRequest)
 	if err := c.BodyParser(req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Неверный запрос"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный запрос")})
 	}
 
 	result, err := h.svc.PlayCoinFlip(member.Id, req)
 	if err != nil {
-		log.Printf("PlayCoinFlip error (member=%d): %v", member.Id, err)
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Не удалось выполнить ставку"})
+		return betError(c, "PlayCoinFlip", member.Id, err)
 	}
 
 	BroadcastEvent("minigames")
@@ -64,18 +84,17 @@ func (h *CasinoHandler) PlayDiceRoll(c *fiber.Ctx) error {
 		return err
 	}
 	if err := h.checkRateLimit(member.Id); err != nil {
-		return c.Status(fiber.StatusTooManyRequests).JSON(fiber.Map{"error": "Подождите секунду между ставками"})
+		return c.Status(fiber.StatusTooManyRequests).JSON(fiber.Map{"error": tr(c, "Подождите секунду между ставками")})
 	}
 
 	req := new(models.DiceRollRequest)
 	if err := c.BodyParser(req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Неверный запрос"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный запрос")})
 	}
 
 	result, err := h.svc.PlayDiceRoll(member.Id, req)
 	if err != nil {
-		log.Printf("PlayDiceRoll error (member=%d): %v", member.Id, err)
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Не удалось выполнить ставку"})
+		return betError(c, "PlayDiceRoll", member.Id, err)
 	}
 
 	BroadcastEvent("minigames")
@@ -88,18 +107,17 @@ func (h *CasinoHandler) PlayWheel(c *fiber.Ctx) error {
 		return err
 	}
 	if err := h.checkRateLimit(member.Id); err != nil {
-		return c.Status(fiber.StatusTooManyRequests).JSON(fiber.Map{"error": "Подождите секунду между ставками"})
+		return c.Status(fiber.StatusTooManyRequests).JSON(fiber.Map{"error": tr(c, "Подождите секунду между ставками")})
 	}
 
 	req := new(models.WheelRequest)
 	if err := c.BodyParser(req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Неверный запрос"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный запрос")})
 	}
 
 	result, err := h.svc.PlayWheel(member.Id, req)
 	if err != nil {
-		log.Printf("PlayWheel error (member=%d): %v", member.Id, err)
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Не удалось выполнить ставку"})
+		return betError(c, "PlayWheel", member.Id, err)
 	}
 
 	BroadcastEvent("minigames")
@@ -111,7 +129,7 @@ func (h *CasinoHandler) GetGlobalFeed(c *fiber.Ctx) error {
 	items, err := h.svc.GetGlobalFeed(limit)
 	if err != nil {
 		log.Printf("GetGlobalFeed error: %v", err)
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Ошибка загрузки ленты"})
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Ошибка загрузки ленты")})
 	}
 	return c.JSON(fiber.Map{"items": items})
 }
@@ -127,7 +145,7 @@ func (h *CasinoHandler) GetHistory(c *fiber.Ctx) error {
 	items, total, err := h.svc.GetHistory(member.Id, limit, offset)
 	if err != nil {
 		log.Printf("GetHistory error (member=%d): %v", member.Id, err)
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Ошибка загрузки истории"})
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Ошибка загрузки истории")})
 	}
 
 	return c.JSON(fiber.Map{"items": items, "total": total})
@@ -141,16 +159,292 @@ func (h *CasinoHandler) GetStats(c *fiber.Ctx) error {
 	stats, err := h.svc.GetStats(member.Id)
 	if err != nil {
 		log.Printf("GetStats error (member=%d): %v", member.Id, err)
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Ошибка загрузки статистики"})
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Ошибка загрузки статистики")})
 	}
 	return c.JSON(stats)
 }
 
+// GetSeed GET /api/platform/minigames/seed — хеш текущего серверного
+// seed'а, клиентский seed, nonce и прошлая раскрытая пара.
+func (h *CasinoHandler) GetSeed(c *fiber.Ctx) error {
+	member, err := getMember(c)
+	if err != nil {
+		return err
+	}
+	state, err := h.svc.SeedState(member.Id)
+	if err != nil {
+		log.Printf("casino seed state error (member=%d): %v", member.Id, err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Ошибка загрузки seed'ов")})
+	}
+	return c.JSON(state)
+}
+
+// RotateSeed POST /api/platform/minigames/seed/rotate — раскрыть текущий
+// серверный seed и начать новую пару.
+func (h *CasinoHandler) RotateSeed(c *fiber.Ctx) error {
+	member, err := getMember(c)
+	if err != nil {
+		return err
+	}
+	var req models.CasinoRotateSeedRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный запрос")})
+		}
+	}
+	state, err := h.svc.RotateSeed(member.Id, req.ClientSeed)
+	if errors.Is(err, service.ErrInvalidClientSeed) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": trErr(c, err)})
+	}
+	if err != nil {
+		log.Printf("casino rotate seed error (member=%d): %v", member.Id, err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Не удалось сменить seed")})
+	}
+	return c.JSON(state)
+}
+
+// VerifyBet GET /api/platform/minigames/verify/:id — пересчёт исхода любой
+// ставки по её раскрытым seed'ам.
+func (h *CasinoHandler) VerifyBet(c *fiber.Ctx) error {
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный ID")})
+	}
+	v, err := h.svc.VerifyBet(id)
+	switch {
+	case errors.Is(err, service.ErrCasinoBetNotFound):
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": trErr(c, err)})
+	case errors.Is(err, service.ErrCasinoBetNotFair), errors.Is(err, service.ErrCasinoSeedNotRevealed):
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": trErr(c, err)})
+	case err != nil:
+		log.Printf("casino verify error (bet=%d): %v", id, err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Не удалось проверить ставку")})
+	}
+	return c.JSON(v)
+}
+
+// GetCrash GET /api/platform/minigames/crash — текущий раунд, его ставки и
+// последние точки краша.
+func (h *CasinoHandler) GetCrash(c *fiber.Ctx) error {
+	state, err := h.crash.State()
+	if err != nil {
+		log.Printf("crash state error: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Ошибка загрузки раунда")})
+	}
+	return c.JSON(state)
+}
+
+// PlaceCrashBet POST /api/platform/minigames/crash/bet — ставка в раунд,
+// пока идёт приём ставок.
+func (h *CasinoHandler) PlaceCrashBet(c *fiber.Ctx) error {
+	member, err := getMember(c)
+	if err != nil {
+		return err
+	}
+	if err := h.checkRateLimit(member.Id); err != nil {
+		return c.Status(fiber.StatusTooManyRequests).JSON(fiber.Map{"error": tr(c, "Подождите секунду между ставками")})
+	}
+	req := new(models.CrashBetRequest)
+	if err := c.BodyParser(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный запрос")})
+	}
+	result, err := h.crash.PlaceBet(member.Id, req)
+	switch {
+	case errors.Is(err, service.ErrCrashBettingClosed), errors.Is(err, service.ErrCrashAlreadyBet),
+		errors.Is(err, service.ErrCrashInvalidAuto), errors.Is(err, repository.ErrInsufficientBalance):
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": trErr(c, err)})
+	case err != nil:
+		return betError(c, "PlaceCrashBet", member.Id, err)
+	}
+	PublishToMember(member.Id, "points")
+	return c.JSON(result)
+}
+
+// CrashCashOut POST /api/platform/minigames/crash/cashout — вывод на
+// текущем множителе.
+func (h *CasinoHandler) CrashCashOut(c *fiber.Ctx) error {
+	member, err := getMember(c)
+	if err != nil {
+		return err
+	}
+	result, err := h.crash.CashOut(member.Id)
+	switch {
+	case errors.Is(err, service.ErrCrashNoRunningRound), errors.Is(err, service.ErrCrashNoBet),
+		errors.Is(err, service.ErrCrashCashedOut), errors.Is(err, service.ErrCrashTooLate):
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": trErr(c, err)})
+	case err != nil:
+		log.Printf("crash cash-out error (member=%d): %v", member.Id, err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Не удалось вывести ставку")})
+	}
+	return c.JSON(result)
+}
+
+// GetCrashRounds GET /minigames/crash/rounds — история раундов с раскрытыми
+// seed'ами.
+func (h *CasinoHandler) GetCrashRounds(c *fiber.Ctx) error {
+	limit, _ := strconv.Atoi(c.Query("limit", "20"))
+	offset, _ := strconv.Atoi(c.Query("offset", "0"))
+	items, total, err := h.crash.Rounds(limit, offset)
+	if err != nil {
+		log.Printf("crash rounds error: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Ошибка загрузки истории")})
+	}
+	return c.JSON(fiber.Map{"items": items, "total": total})
+}
+
+// GetCrashRound GET /minigames/crash/rounds/:id — раунд со всеми ставками.
+func (h *CasinoHandler) GetCrashRound(c *fiber.Ctx) error {
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный ID")})
+	}
+	round, err := h.crash.Round(id)
+	if errors.Is(err, service.ErrCrashRoundNotFound) {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": trErr(c, err)})
+	}
+	if err != nil {
+		log.Printf("crash round error (round=%d): %v", id, err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Ошибка загрузки раунда")})
+	}
+	return c.JSON(round)
+}
+
+// GetJackpot GET /api/platform/minigames/jackpot — пул и последние выигрыши.
+func (h *CasinoHandler) GetJackpot(c *fiber.Ctx) error {
+	state, err := h.jackpot.State()
+	if err != nil {
+		log.Printf("jackpot state error: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Ошибка загрузки джекпота")})
+	}
+	return c.JSON(state)
+}
+
+// GetLimits GET /api/platform/minigames/limits — личные и действующие лимиты,
+// расход за сегодня и ожидающие ослабления.
+func (h *CasinoHandler) GetLimits(c *fiber.Ctx) error {
+	member, err := getMember(c)
+	if err != nil {
+		return err
+	}
+	state, err := h.limits.State(member.Id)
+	if err != nil {
+		log.Printf("casino limits state error (member=%d): %v", member.Id, err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Ошибка загрузки лимитов")})
+	}
+	return c.JSON(state)
+}
+
+// UpdateLimits PUT /api/platform/minigames/limits — ужесточение действует
+// сразу, ослабление — после задержки.
+func (h *CasinoHandler) UpdateLimits(c *fiber.Ctx) error {
+	member, err := getMember(c)
+	if err != nil {
+		return err
+	}
+	req := new(models.CasinoLimitsRequest)
+	if err := c.BodyParser(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный запрос")})
+	}
+	state, err := h.limits.Update(member.Id, req)
+	if err != nil {
+		return h.limitsError(c, member.Id, err)
+	}
+	return c.JSON(state)
+}
+
+// SelfExclude POST /api/platform/minigames/limits/exclude — самоисключение
+// на N дней; отменить его нельзя.
+func (h *CasinoHandler) SelfExclude(c *fiber.Ctx) error {
+	member, err := getMember(c)
+	if err != nil {
+		return err
+	}
+	var req models.CasinoSelfExcludeRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный запрос")})
+	}
+	state, err := h.limits.SelfExclude(member.Id, req.Days)
+	if err != nil {
+		return h.limitsError(c, member.Id, err)
+	}
+	return c.JSON(state)
+}
+
+// GetLimitsHistory GET /api/platform/minigames/limits/history
+func (h *CasinoHandler) GetLimitsHistory(c *fiber.Ctx) error {
+	member, err := getMember(c)
+	if err != nil {
+		return err
+	}
+	limit, _ := strconv.Atoi(c.Query("limit", "20"))
+	offset, _ := strconv.Atoi(c.Query("offset", "0"))
+	items, total, err := h.limits.History(member.Id, limit, offset)
+	if err != nil {
+		log.Printf("casino limits history error (member=%d): %v", member.Id, err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Ошибка загрузки истории")})
+	}
+	return c.JSON(fiber.Map{"items": items, "total": total})
+}
+
+func (h *CasinoHandler) limitsError(c *fiber.Ctx, memberId int64, err error) error {
+	var limitErr *service.CasinoLimitError
+	if errors.As(err, &limitErr) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": trErr(c, err)})
+	}
+	log.Printf("casino limits update error (member=%d): %v", memberId, err)
+	return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Не удалось сохранить лимиты")})
+}
+
+// GetGlobalLimits GET /api/admin/minigames/limits
+func (h *CasinoHandler) GetGlobalLimits(c *fiber.Ctx) error {
+	return c.JSON(h.limits.Global())
+}
+
+// UpdateGlobalLimits PUT /api/admin/minigames/limits — глобальные лимиты для
+// всех участников; 0 выключает лимит.
+func (h *CasinoHandler) UpdateGlobalLimits(c *fiber.Ctx) error {
+	var req models.CasinoGlobalLimits
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный запрос")})
+	}
+	limits, err := h.limits.SetGlobal(req)
+	if err != nil {
+		var limitErr *service.CasinoLimitError
+		if errors.As(err, &limitErr) {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": trErr(c, err)})
+		}
+		log.Printf("casino global limits error: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Не удалось сохранить лимиты")})
+	}
+	go h.auditSvc.Log(getActorId(c), getActorName(c), getActorType(c), models.AuditActionUpdate, "casino_limits", 0, "Глобальные лимиты мини-игр")
+	return c.JSON(limits)
+}
+
+// GetMemberLimits GET /api/admin/minigames/limits/members/:id — лимиты
+// участника и история их изменений.
+func (h *CasinoHandler) GetMemberLimits(c *fiber.Ctx) error {
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный ID")})
+	}
+	state, err := h.limits.State(id)
+	if err != nil {
+		log.Printf("casino member limits error (member=%d): %v", id, err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Ошибка загрузки лимитов")})
+	}
+	history, _, err := h.limits.History(id, 50, 0)
+	if err != nil {
+		log.Printf("casino member limits history error (member=%d): %v", id, err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Ошибка загрузки истории")})
+	}
+	return c.JSON(fiber.Map{"state": state, "history": history})
+}
+
 func (h *CasinoHandler) GetAdminStats(c *fiber.Ctx) error {
 	stats, err := h.svc.GetAdminStats()
 	if err != nil {
 		log.Printf("GetAdminStats error: %v", err)
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Ошибка загрузки статистики"})
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Ошибка загрузки статистики")})
 	}
 	return c.JSON(stats)
 }
@@ -170,7 +464,7 @@ func (h *CasinoHandler) GetAdminBets(c *fiber.Ctx) error {
 	items, total, err := h.svc.SearchBets(username, game, limit, offset)
 	if err != nil {
 		log.Printf("SearchBets error: %v", err)
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Ошибка поиска ставок"})
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Ошибка поиска ставок")})
 	}
 
 	return c.JSON(fiber.Map{"items": items, "total": total})
@@ -27,7 +27,7 @@ func (h *ChallengesHandler) GetMyChallenges(c *fiber.Ctx) error {
 	resp, err := h.svc.GetMyChallenges(member.Id)
 	if err != nil {
 		log.Printf("get my challenges (member=%d): %v", member.Id, err)
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Ошибка загрузки челленджей"})
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Ошибка загрузки челленджей")})
 	}
 	return c.JSON(resp)
 }
@@ -23,7 +23,7 @@ func NewChatActivityHandler() *ChatActivityHandler {
 func (h *ChatActivityHandler) GetStats(c *fiber.Ctx) error {
 	stats, err := h.service.GetStats()
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Ошибка загрузки статистики"})
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Ошибка загрузки статистики")})
 	}
 	return c.JSON(stats)
 }
@@ -49,7 +49,7 @@ func (h *ChatActivityHandler) GetChart(c *fiber.Ctx) error {
 		if parsed, err := strconv.ParseInt(uid, 10, 64); err == nil {
 			activity, err := h.service.GetDailyActivityByUser(parsed, days)
 			if err != nil {
-				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Ошибка загрузки графика"})
+				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Ошибка загрузки графика")})
 			}
 			return c.JSON(activity)
 		}
@@ -57,7 +57,7 @@ func (h *ChatActivityHandler) GetChart(c *fiber.Ctx) error {
 
 	activity, err := h.service.GetActivityChart(chatID, days)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Ошибка загрузки графика"})
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Ошибка загрузки графика")})
 	}
 	return c.JSON(activity)
 }
@@ -80,7 +80,7 @@ func (h *ChatActivityHandler) GetTopUsers(c *fiber.Ctx) error {
 
 	users, err := h.service.GetTopUsers(days, limit)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Ошибка загрузки топ пользователей"})
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Ошибка загрузки топ пользователей")})
 	}
 	return c.JSON(users)
 }
@@ -89,7 +89,7 @@ func (h *ChatActivityHandler) GetTopUsers(c *fiber.Ctx) error {
 func (h *ChatActivityHandler) GetChats(c *fiber.Ctx) error {
 	chats, err := h.service.GetTrackedChats()
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Ошибка загрузки чатов"})
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Ошибка загрузки чатов")})
 	}
 	return c.JSON(chats)
 }
@@ -98,12 +98,12 @@ func (h *ChatActivityHandler) GetChats(c *fiber.Ctx) error {
 func (h *ChatActivityHandler) GetUserStats(c *fiber.Ctx) error {
 	uid := c.Query("user_id")
 	if uid == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Необходимо указать user_id"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Необходимо указать user_id")})
 	}
 
 	userID, err := strconv.ParseInt(uid, 10, 64)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Неверный user_id"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный user_id")})
 	}
 
 	days := 30
@@ -115,7 +115,7 @@ func (h *ChatActivityHandler) GetUserStats(c *fiber.Ctx) error {
 
 	stats, err := h.service.GetUserStats(userID, days)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Ошибка загрузки статистики пользователя"})
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Ошибка загрузки статистики пользователя")})
 	}
 	return c.JSON(stats)
 }
@@ -138,7 +138,7 @@ func (h *ChatActivityHandler) ExportCSV(c *fiber.Ctx) error {
 
 	rows, err := h.service.GetMessagesForExport(chatID, days)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Ошибка экспорта"})
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Ошибка экспорта")})
 	}
 
 	// Формируем CSV
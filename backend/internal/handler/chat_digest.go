@@ -0,0 +1,61 @@
+package handler
+
+import (
+	"log"
+	"strconv"
+
+	"ithozyeva/internal/service"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type ChatDigestHandler struct {
+	svc *service.ChatDigestService
+}
+
+func NewChatDigestHandler() *ChatDigestHandler {
+	return &ChatDigestHandler{
+		svc: service.NewChatDigestService(),
+	}
+}
+
+// List GET /api/platform/digests?chat_id=&limit=&offset=
+// Архив еженедельных дайджестов, новые первыми.
+func (h *ChatDigestHandler) List(c *fiber.Ctx) error {
+	limit, err := strconv.Atoi(c.Query("limit", "10"))
+	if err != nil || limit <= 0 {
+		limit = 10
+	}
+	if limit > 50 {
+		limit = 50
+	}
+	offset, err := strconv.Atoi(c.Query("offset", "0"))
+	if err != nil || offset < 0 {
+		offset = 0
+	}
+	chatID, _ := strconv.ParseInt(c.Query("chat_id", "0"), 10, 64)
+
+	digests, total, err := h.svc.ListDigests(chatID, limit, offset)
+	if err != nil {
+		log.Printf("List digests error: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Ошибка загрузки дайджестов")})
+	}
+	return c.JSON(fiber.Map{"items": digests, "total": total})
+}
+
+// GetById GET /api/platform/digests/:id
+func (h *ChatDigestHandler) GetById(c *fiber.Ctx) error {
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Некорректный id")})
+	}
+	digest, err := h.svc.GetDigest(id)
+	if err != nil {
+		log.Printf("Get digest error: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Ошибка загрузки дайджеста")})
+	}
+	if digest == nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": tr(c, "Дайджест не найден")})
+	}
+	return c.JSON(digest)
+}
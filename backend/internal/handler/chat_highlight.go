@@ -32,7 +32,7 @@ func (h *ChatHighlightHandler) GetRecent(c *fiber.Ctx) error {
 	highlights, err := h.svc.GetRecent(limit)
 	if err != nil {
 		log.Printf("GetRecent highlights error: %v", err)
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Ошибка загрузки дайджеста"})
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Ошибка загрузки дайджеста")})
 	}
 
 	return c.JSON(highlights)
@@ -55,7 +55,7 @@ func (h *ChatHighlightHandler) Search(c *fiber.Ctx) error {
 	highlights, total, err := h.svc.Search(limit, offset)
 	if err != nil {
 		log.Printf("Search highlights error: %v", err)
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Ошибка поиска дайджеста"})
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Ошибка поиска дайджеста")})
 	}
 
 	return c.JSON(fiber.Map{
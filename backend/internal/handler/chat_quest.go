@@ -44,7 +44,7 @@ func (h *ChatQuestHandler) GetAll(c *fiber.Ctx) error {
 
 	quests, total, err := h.service.GetAllQuests(limit, offset)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Ошибка загрузки заданий"})
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Ошибка загрузки заданий")})
 	}
 
 	return c.JSON(fiber.Map{
@@ -57,15 +57,15 @@ func (h *ChatQuestHandler) GetAll(c *fiber.Ctx) error {
 func (h *ChatQuestHandler) Create(c *fiber.Ctx) error {
 	var quest models.ChatQuest
 	if err := c.BodyParser(&quest); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Неверный формат данных"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный формат данных")})
 	}
 
 	if quest.Title == "" || quest.TargetCount <= 0 {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Необходимо указать название и целевое количество"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Необходимо указать название и целевое количество")})
 	}
 
 	if err := h.service.CreateQuest(&quest); err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Ошибка создания задания"})
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Ошибка создания задания")})
 	}
 
 	return c.Status(fiber.StatusCreated).JSON(quest)
@@ -75,21 +75,21 @@ func (h *ChatQuestHandler) Create(c *fiber.Ctx) error {
 func (h *ChatQuestHandler) Update(c *fiber.Ctx) error {
 	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Неверный ID"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный ID")})
 	}
 
 	existing, err := h.service.GetQuestByID(id)
 	if err != nil {
-		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Задание не найдено"})
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": tr(c, "Задание не найдено")})
 	}
 
 	if err := c.BodyParser(existing); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Неверный формат данных"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный формат данных")})
 	}
 	existing.Id = id
 
 	if err := h.service.UpdateQuest(existing); err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Ошибка обновления задания"})
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Ошибка обновления задания")})
 	}
 
 	return c.JSON(existing)
@@ -99,11 +99,11 @@ func (h *ChatQuestHandler) Update(c *fiber.Ctx) error {
 func (h *ChatQuestHandler) Delete(c *fiber.Ctx) error {
 	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Неверный ID"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный ID")})
 	}
 
 	if err := h.service.DeleteQuest(id); err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Ошибка удаления задания"})
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Ошибка удаления задания")})
 	}
 
 	return c.JSON(fiber.Map{"success": true})
@@ -113,14 +113,14 @@ func (h *ChatQuestHandler) Delete(c *fiber.Ctx) error {
 func (h *ChatQuestHandler) GetAllForMember(c *fiber.Ctx) error {
 	member := getMemberFromContext(c)
 	if member == nil {
-		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Не авторизован"})
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": tr(c, "Не авторизован")})
 	}
 
 	filter := c.Query("filter", "all")
 
 	quests, err := h.service.GetAllQuestsForMember(member.Id, filter)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Ошибка загрузки заданий"})
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Ошибка загрузки заданий")})
 	}
 
 	return c.JSON(quests)
@@ -130,12 +130,12 @@ func (h *ChatQuestHandler) GetAllForMember(c *fiber.Ctx) error {
 func (h *ChatQuestHandler) GetActive(c *fiber.Ctx) error {
 	member := getMemberFromContext(c)
 	if member == nil {
-		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Не авторизован"})
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": tr(c, "Не авторизован")})
 	}
 
 	quests, err := h.service.GetActiveQuestsForMember(member.Id)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Ошибка загрузки заданий"})
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Ошибка загрузки заданий")})
 	}
 
 	return c.JSON(quests)
@@ -0,0 +1,80 @@
+package handler
+
+import (
+	"errors"
+	"log"
+	"strconv"
+
+	"ithozyeva/internal/models"
+	"ithozyeva/internal/service"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type ChatSearchHandler struct {
+	svc *service.ChatSearchService
+}
+
+func NewChatSearchHandler() *ChatSearchHandler {
+	return &ChatSearchHandler{
+		svc: service.NewChatSearchService(),
+	}
+}
+
+// Search GET /api/platform/chat-search?q=&chat_id=&author=&from=&to=&sort=&limit=&offset=
+// Поиск по истории чатов, к которым у участника есть доступ по подписке.
+func (h *ChatSearchHandler) Search(c *fiber.Ctx) error {
+	member, ok := c.Locals("member").(*models.Member)
+	if !ok || member == nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Unauthorized"})
+	}
+	limit, err := strconv.Atoi(c.Query("limit", "20"))
+	if err != nil || limit <= 0 {
+		limit = 20
+	}
+	if limit > 50 {
+		limit = 50
+	}
+	offset, err := strconv.Atoi(c.Query("offset", "0"))
+	if err != nil || offset < 0 {
+		offset = 0
+	}
+	chatID, _ := strconv.ParseInt(c.Query("chat_id", "0"), 10, 64)
+
+	q, err := service.BuildChatSearchQuery(service.ChatSearchParams{
+		Query:  c.Query("q"),
+		Author: c.Query("author"),
+		From:   c.Query("from"),
+		To:     c.Query("to"),
+		Sort:   c.Query("sort"),
+		Limit:  limit,
+		Offset: offset,
+	})
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": trErr(c, err)})
+	}
+
+	items, total, err := h.svc.Search(member.TelegramID, chatID, q)
+	if errors.Is(err, service.ErrChatSearchForbidden) {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": trErr(c, err)})
+	}
+	if err != nil {
+		log.Printf("Chat search error: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Ошибка поиска")})
+	}
+	return c.JSON(fiber.Map{"items": items, "total": total})
+}
+
+// GetChats GET /api/platform/chat-search/chats — чаты для фильтра.
+func (h *ChatSearchHandler) GetChats(c *fiber.Ctx) error {
+	member, ok := c.Locals("member").(*models.Member)
+	if !ok || member == nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Unauthorized"})
+	}
+	chats, err := h.svc.SearchableChats(member.TelegramID)
+	if err != nil {
+		log.Printf("Searchable chats error: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Ошибка загрузки чатов")})
+	}
+	return c.JSON(chats)
+}
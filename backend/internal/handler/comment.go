@@ -24,11 +24,11 @@ func respondCommentErr(c *fiber.Ctx, err error) error {
 	case errors.Is(err, service.ErrCommentNotFound),
 		errors.Is(err, service.ErrEntityNotFound),
 		errors.Is(err, gorm.ErrRecordNotFound):
-		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": err.Error()})
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": trErr(c, err)})
 	case errors.Is(err, service.ErrCommentForbidden):
-		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": err.Error()})
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": trErr(c, err)})
 	default:
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": trErr(c, err)})
 	}
 }
 
@@ -43,7 +43,7 @@ func (h *CommentHandler) ListForEntity(entityType models.CommentEntityType) fibe
 		}
 		entityID, err := strconv.ParseInt(c.Params("id"), 10, 64)
 		if err != nil {
-			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Неверный ID"})
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный ID")})
 		}
 		limit, _ := strconv.Atoi(c.Query("limit", "20"))
 		offset, _ := strconv.Atoi(c.Query("offset", "0"))
@@ -63,11 +63,11 @@ func (h *CommentHandler) CreateForEntity(entityType models.CommentEntityType) fi
 		}
 		entityID, err := strconv.ParseInt(c.Params("id"), 10, 64)
 		if err != nil {
-			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Неверный ID"})
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный ID")})
 		}
 		var req models.CreateCommentRequest
 		if err := c.BodyParser(&req); err != nil {
-			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Неверный формат запроса"})
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный формат запроса")})
 		}
 		created, err := h.svc.Create(entityType, entityID, member, req.Body)
 		if err != nil {
@@ -86,11 +86,11 @@ func (h *CommentHandler) Update(c *fiber.Ctx) error {
 	}
 	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Неверный ID"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный ID")})
 	}
 	var req models.CreateCommentRequest
 	if err := c.BodyParser(&req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Неверный формат запроса"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный формат запроса")})
 	}
 	updated, err := h.svc.Update(id, member.Id, req.Body, hasAdminRole(member))
 	if err != nil {
@@ -106,7 +106,7 @@ func (h *CommentHandler) Delete(c *fiber.Ctx) error {
 	}
 	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Неверный ID"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный ID")})
 	}
 	if err := h.svc.Delete(id, member.Id, hasAdminRole(member)); err != nil {
 		return respondCommentErr(c, err)
@@ -121,7 +121,7 @@ func (h *CommentHandler) ToggleLike(c *fiber.Ctx) error {
 	}
 	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Неверный ID"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный ID")})
 	}
 	liked, count, err := h.svc.ToggleLike(id, member)
 	if err != nil {
@@ -142,14 +142,14 @@ func (h *CommentHandler) SetHidden(c *fiber.Ctx) error {
 	}
 	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Неверный ID"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный ID")})
 	}
 	type body struct {
 		Hidden bool `json:"hidden"`
 	}
 	var b body
 	if err := c.BodyParser(&b); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Неверный формат запроса"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный формат запроса")})
 	}
 	if err := h.svc.SetHidden(id, b.Hidden, hasAdminRole(member)); err != nil {
 		return respondCommentErr(c, err)
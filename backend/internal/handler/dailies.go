@@ -1,7 +1,9 @@
 package handler
 
 import (
+	"errors"
 	"log"
+	"time"
 
 	"ithozyeva/internal/models"
 	"ithozyeva/internal/service"
@@ -32,7 +34,7 @@ func (h *DailiesHandler) Today(c *fiber.Ctx) error {
 	resp, err := h.taskSvc.GetMyToday(member.Id)
 	if err != nil {
 		log.Printf("dailies today (member=%d): %v", member.Id, err)
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Ошибка загрузки заданий"})
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Ошибка загрузки заданий")})
 	}
 	return c.JSON(resp)
 }
@@ -47,7 +49,7 @@ func (h *DailiesHandler) CheckIn(c *fiber.Ctx) error {
 	result, err := h.checkInSvc.CheckIn(member.Id)
 	if err != nil {
 		log.Printf("check-in failed (member=%d): %v", member.Id, err)
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Не удалось зафиксировать check-in"})
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Не удалось зафиксировать check-in")})
 	}
 
 	streak, err := h.streakSvc.BuildResponse(member.Id)
@@ -79,7 +81,48 @@ func (h *DailiesHandler) MyStreak(c *fiber.Ctx) error {
 	resp, err := h.streakSvc.BuildResponse(member.Id)
 	if err != nil {
 		log.Printf("streak fetch (member=%d): %v", member.Id, err)
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Ошибка загрузки стрика"})
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Ошибка загрузки стрика")})
 	}
 	return c.JSON(resp)
 }
+
+// BuyFreezes — POST /api/platform/streak/freezes
+func (h *DailiesHandler) BuyFreezes(c *fiber.Ctx) error {
+	member, err := getMember(c)
+	if err != nil {
+		return err
+	}
+	var req models.StreakFreezePurchaseRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный запрос")})
+		}
+	}
+	resp, err := h.streakSvc.BuyFreezes(member.Id, req.Quantity, time.Now())
+	if err != nil {
+		return h.writeStreakError(c, "streak buy freezes", member.Id, err)
+	}
+	return c.JSON(resp)
+}
+
+// RepairStreak — POST /api/platform/streak/repair
+func (h *DailiesHandler) RepairStreak(c *fiber.Ctx) error {
+	member, err := getMember(c)
+	if err != nil {
+		return err
+	}
+	resp, err := h.streakSvc.Repair(member.Id, time.Now())
+	if err != nil {
+		return h.writeStreakError(c, "streak repair", member.Id, err)
+	}
+	return c.JSON(resp)
+}
+
+func (h *DailiesHandler) writeStreakError(c *fiber.Ctx, op string, memberId int64, err error) error {
+	var streakErr *service.StreakError
+	if errors.As(err, &streakErr) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": trErr(c, err)})
+	}
+	log.Printf("%s (member=%d): %v", op, memberId, err)
+	return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Ошибка стрика")})
+}
@@ -49,7 +49,7 @@ type EventsSearchRequest struct {
 func (h *EventsHandler) Search(c *fiber.Ctx) error {
 	req := new(EventsSearchRequest)
 	if err := c.QueryParser(req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Неверный запрос"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный запрос")})
 	}
 
 	filter := make(repository.SearchFilter)
@@ -79,7 +79,7 @@ func (h *EventsHandler) Search(c *fiber.Ctx) error {
 		result, err := h.svc.SearchUpcoming(req.Limit, req.Offset, &filter)
 		if err != nil {
 			log.Printf("events upcoming search error: %v", err)
-			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Ошибка поиска событий"})
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Ошибка поиска событий")})
 		}
 		// Daily-task «Заглянуть в афишу событий» (seed: view_events). До этого
 		// триггер висел только на /api/events/next (лендинг-ручка), а платформа
@@ -97,7 +97,7 @@ func (h *EventsHandler) Search(c *fiber.Ctx) error {
 	})
 	if err != nil {
 		log.Printf("events search error: %v", err)
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Ошибка поиска событий"})
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Ошибка поиска событий")})
 	}
 
 	return c.JSON(result)
@@ -113,7 +113,7 @@ func (h *EventsHandler) GetOld(c *fiber.Ctx) error {
 	})
 	if err != nil {
 		log.Printf("get old events error: %v", err)
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Ошибка загрузки событий"})
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Ошибка загрузки событий")})
 	}
 
 	return c.JSON(result)
@@ -128,7 +128,7 @@ func (h *EventsHandler) GetNext(c *fiber.Ctx) error {
 	})
 	if err != nil {
 		log.Printf("get next events error: %v", err)
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Ошибка загрузки событий"})
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Ошибка загрузки событий")})
 	}
 
 	if member, mErr := getMember(c); mErr == nil && member != nil {
@@ -141,7 +141,7 @@ func (h *EventsHandler) GetNext(c *fiber.Ctx) error {
 func (h *EventsHandler) AddMember(c *fiber.Ctx) error {
 	req := new(WorkWithEventRequest)
 	if err := c.BodyParser(req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Неверный запрос"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный запрос")})
 	}
 
 	member, err := getMember(c)
@@ -152,10 +152,10 @@ func (h *EventsHandler) AddMember(c *fiber.Ctx) error {
 	result, err := h.svc.AddMember(req.EventId, int(member.Id))
 	if err != nil {
 		if errors.Is(err, service.ErrParticipantLimitReached) {
-			return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": "Достигнут лимит участников"})
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": tr(c, "Достигнут лимит участников")})
 		}
 		log.Printf("add member to event error (event=%d, member=%d): %v", req.EventId, member.Id, err)
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Ошибка регистрации на событие"})
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Ошибка регистрации на событие")})
 	}
 
 	service.TrackDailyTrigger(member.Id, "register_event", 1)
@@ -170,7 +170,7 @@ type WorkWithEventRequest struct {
 func (h *EventsHandler) RemoveMember(c *fiber.Ctx) error {
 	req := new(WorkWithEventRequest)
 	if err := c.BodyParser(req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Неверный запрос"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный запрос")})
 	}
 
 	member, err := getMember(c)
@@ -181,7 +181,7 @@ func (h *EventsHandler) RemoveMember(c *fiber.Ctx) error {
 	result, err := h.svc.RemoveMember(req.EventId, int(member.Id))
 	if err != nil {
 		log.Printf("remove member from event error (event=%d, member=%d): %v", req.EventId, member.Id, err)
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Ошибка отмены регистрации"})
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Ошибка отмены регистрации")})
 	}
 
 	return c.JSON(result)
@@ -190,12 +190,12 @@ func (h *EventsHandler) RemoveMember(c *fiber.Ctx) error {
 func (h *EventsHandler) GetICSFile(c *fiber.Ctx) error {
 	req := new(WorkWithEventRequest)
 	if err := c.QueryParser(req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Неверный запрос"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный запрос")})
 	}
 
 	event, err := h.svc.GetById(int64(req.EventId))
 	if err != nil {
-		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Событие не найдено"})
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": tr(c, "Событие не найдено")})
 	}
 
 	ics := utils.GenerateICS(event)
@@ -209,7 +209,7 @@ func (h *EventsHandler) GetICSFile(c *fiber.Ctx) error {
 func (h *EventsHandler) Create(c *fiber.Ctx) error {
 	event := new(models.Event)
 	if err := c.BodyParser(event); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Неверный запрос"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный запрос")})
 	}
 
 	// Подставляем название эксклюзивного чата
@@ -219,14 +219,14 @@ func (h *EventsHandler) Create(c *fiber.Ctx) error {
 	resolvedTags, err := h.svc.ResolveEventTags(event.EventTags)
 	if err != nil {
 		log.Printf("resolve event tags error: %v", err)
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Ошибка обработки тегов"})
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Ошибка обработки тегов")})
 	}
 	event.EventTags = resolvedTags
 
 	result, err := h.service.Create(event)
 	if err != nil {
 		log.Printf("create event error: %v", err)
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Ошибка создания события"})
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Ошибка создания события")})
 	}
 
 	// Отправляем инициализирующие алерты в фоне.
@@ -260,7 +260,7 @@ func (h *EventsHandler) Create(c *fiber.Ctx) error {
 func (h *EventsHandler) Update(c *fiber.Ctx) error {
 	event := new(models.Event)
 	if err := c.BodyParser(event); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Неверный запрос"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный запрос")})
 	}
 
 	// Подставляем название эксклюзивного чата
@@ -270,14 +270,14 @@ func (h *EventsHandler) Update(c *fiber.Ctx) error {
 	resolvedTags, err := h.svc.ResolveEventTags(event.EventTags)
 	if err != nil {
 		log.Printf("resolve event tags error: %v", err)
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Ошибка обработки тегов"})
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Ошибка обработки тегов")})
 	}
 	event.EventTags = resolvedTags
 
 	result, err := h.service.Update(event)
 	if err != nil {
 		log.Printf("update event error: %v", err)
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Ошибка обновления события"})
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Ошибка обновления события")})
 	}
 
 	// Отправляем уведомления об изменении события в фоне
@@ -303,12 +303,12 @@ func (h *EventsHandler) Update(c *fiber.Ctx) error {
 func (h *EventsHandler) Delete(c *fiber.Ctx) error {
 	id, err := strconv.Atoi(c.Params("id"))
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Неверный ID"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный ID")})
 	}
 
 	entity, err := h.service.GetById(int64(id))
 	if err != nil {
-		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Событие не найдено"})
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": tr(c, "Событие не найдено")})
 	}
 
 	// Snapshot member IDs before cascade delete removes event_members
@@ -316,7 +316,7 @@ func (h *EventsHandler) Delete(c *fiber.Ctx) error {
 
 	if err := h.service.Delete(entity); err != nil {
 		log.Printf("delete event error (id=%d): %v", id, err)
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Ошибка удаления события"})
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Ошибка удаления события")})
 	}
 
 	// Send notifications after delete using pre-snapshotted member IDs
@@ -0,0 +1,119 @@
+package handler
+
+import (
+	"errors"
+	"log"
+	"strconv"
+
+	"ithozyeva/internal/models"
+	"ithozyeva/internal/service"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+// FAQHandler — триггеры автоответчика бота (админка).
+type FAQHandler struct {
+	svc *service.FAQService
+}
+
+func NewFAQHandler(redisClient *redis.Client) *FAQHandler {
+	return &FAQHandler{svc: service.NewFAQService(redisClient)}
+}
+
+// List GET /api/admin/faq?chat_id= — триггеры; chat_id — чат и общие.
+func (h *FAQHandler) List(c *fiber.Ctx) error {
+	chatID, _ := strconv.ParseInt(c.Query("chat_id", "0"), 10, 64)
+	triggers, err := h.svc.List(chatID)
+	if err != nil {
+		log.Printf("List FAQ triggers error: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Ошибка загрузки триггеров")})
+	}
+	return c.JSON(fiber.Map{"items": triggers, "total": len(triggers)})
+}
+
+// Create POST /api/admin/faq
+func (h *FAQHandler) Create(c *fiber.Ctx) error {
+	var req models.FAQTriggerRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный запрос")})
+	}
+	t := req.ToTrigger()
+	if err := h.svc.Create(t, getActorId(c)); err != nil {
+		return h.writeError(c, "Create FAQ trigger", err)
+	}
+	return c.Status(fiber.StatusCreated).JSON(t)
+}
+
+// Update PUT /api/admin/faq/:id
+func (h *FAQHandler) Update(c *fiber.Ctx) error {
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Некорректный id")})
+	}
+	var req models.FAQTriggerRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный запрос")})
+	}
+	t, err := h.svc.Update(id, req.ToTrigger(), getActorId(c))
+	if err != nil {
+		return h.writeError(c, "Update FAQ trigger", err)
+	}
+	return c.JSON(t)
+}
+
+// Delete DELETE /api/admin/faq/:id
+func (h *FAQHandler) Delete(c *fiber.Ctx) error {
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Некорректный id")})
+	}
+	if err := h.svc.Delete(id); err != nil {
+		return h.writeError(c, "Delete FAQ trigger", err)
+	}
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// Stats GET /api/admin/faq/stats?days=30 — срабатывания по триггерам.
+func (h *FAQHandler) Stats(c *fiber.Ctx) error {
+	days, err := strconv.Atoi(c.Query("days", "30"))
+	if err != nil || days <= 0 || days > 365 {
+		days = 30
+	}
+	stats, err := h.svc.Stats(days)
+	if err != nil {
+		log.Printf("FAQ stats error: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Ошибка загрузки статистики")})
+	}
+	return c.JSON(fiber.Map{"items": stats, "days": days})
+}
+
+// Test POST /api/admin/faq/test — какой триггер ответил бы на сообщение.
+func (h *FAQHandler) Test(c *fiber.Ctx) error {
+	var req struct {
+		ChatID int64  `json:"chatId"`
+		Text   string `json:"text"`
+	}
+	if err := c.BodyParser(&req); err != nil || req.Text == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный запрос")})
+	}
+	t, err := h.svc.TestMatch(req.ChatID, req.Text)
+	if err != nil {
+		log.Printf("FAQ test match error: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Ошибка проверки")})
+	}
+	return c.JSON(fiber.Map{"matched": t != nil, "trigger": t})
+}
+
+// writeError: «не найдено» — 404, ошибки валидации — 400, остальное — 500.
+func (h *FAQHandler) writeError(c *fiber.Ctx, op string, err error) error {
+	if errors.Is(err, service.ErrFAQTriggerNotFound) {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": trErr(c, err)})
+	}
+	var validationErr *service.FAQValidationError
+	if errors.As(err, &validationErr) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": trErr(c, err)})
+	}
+	log.Printf("%s error: %v", op, err)
+	return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Ошибка сохранения триггера")})
+}
@@ -28,13 +28,13 @@ func (h *FeedbackHandler) Create(c *fiber.Ctx) error {
 
 	req := new(models.CreateFeedbackRequest)
 	if err := c.BodyParser(req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Неверный запрос"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный запрос")})
 	}
 
 	feedback, err := h.svc.Create(member, *req)
 	if err != nil {
 		log.Printf("create feedback error (member=%d): %v", member.Id, err)
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": trErr(c, err)})
 	}
 
 	return c.Status(fiber.StatusCreated).JSON(feedback)
@@ -47,7 +47,7 @@ func (h *FeedbackHandler) AdminList(c *fiber.Ctx) error {
 	items, total, err := h.svc.List(limit, offset)
 	if err != nil {
 		log.Printf("admin list feedback error: %v", err)
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Ошибка загрузки отзывов"})
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Ошибка загрузки отзывов")})
 	}
 
 	return c.JSON(fiber.Map{
@@ -0,0 +1,117 @@
+package handler
+
+import (
+	"errors"
+	"log"
+	"strconv"
+
+	"ithozyeva/internal/models"
+	"ithozyeva/internal/service"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type KnowledgeBaseHandler struct {
+	svc *service.KnowledgeBaseService
+}
+
+func NewKnowledgeBaseHandler() *KnowledgeBaseHandler {
+	return &KnowledgeBaseHandler{
+		svc: service.NewKnowledgeBaseService(),
+	}
+}
+
+// Search GET /knowledge-base?q=&tag_id=&limit=&offset= (админка и платформа).
+func (h *KnowledgeBaseHandler) Search(c *fiber.Ctx) error {
+	limit, err := strconv.Atoi(c.Query("limit", "20"))
+	if err != nil || limit <= 0 {
+		limit = 20
+	}
+	if limit > 50 {
+		limit = 50
+	}
+	offset, err := strconv.Atoi(c.Query("offset", "0"))
+	if err != nil || offset < 0 {
+		offset = 0
+	}
+	tagID, _ := strconv.ParseInt(c.Query("tag_id", "0"), 10, 64)
+
+	entries, total, err := h.svc.Search(c.Query("q"), tagID, limit, offset)
+	if err != nil {
+		log.Printf("Knowledge base search error: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Ошибка поиска по базе знаний")})
+	}
+	return c.JSON(fiber.Map{"items": entries, "total": total})
+}
+
+// GetById GET /knowledge-base/:id
+func (h *KnowledgeBaseHandler) GetById(c *fiber.Ctx) error {
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Некорректный id")})
+	}
+	entry, err := h.svc.Get(id)
+	if errors.Is(err, service.ErrKBEntryNotFound) {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": trErr(c, err)})
+	}
+	if err != nil {
+		log.Printf("Get knowledge base entry error: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Ошибка загрузки статьи")})
+	}
+	return c.JSON(entry)
+}
+
+// Create POST /api/admin/knowledge-base
+func (h *KnowledgeBaseHandler) Create(c *fiber.Ctx) error {
+	var req models.KBEntryRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный запрос")})
+	}
+	entry, err := h.svc.Create(req, getActorId(c))
+	if err != nil {
+		return h.writeError(c, "Create knowledge base entry", err)
+	}
+	return c.Status(fiber.StatusCreated).JSON(entry)
+}
+
+// Update PUT /api/admin/knowledge-base/:id
+func (h *KnowledgeBaseHandler) Update(c *fiber.Ctx) error {
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Некорректный id")})
+	}
+	var req models.KBEntryRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный запрос")})
+	}
+	entry, err := h.svc.Update(id, req, getActorId(c))
+	if err != nil {
+		return h.writeError(c, "Update knowledge base entry", err)
+	}
+	return c.JSON(entry)
+}
+
+// Delete DELETE /api/admin/knowledge-base/:id
+func (h *KnowledgeBaseHandler) Delete(c *fiber.Ctx) error {
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Некорректный id")})
+	}
+	if err := h.svc.Delete(id); err != nil {
+		return h.writeError(c, "Delete knowledge base entry", err)
+	}
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// writeError: «не найдено» — 404, ошибки валидации — 400, остальное — 500.
+func (h *KnowledgeBaseHandler) writeError(c *fiber.Ctx, op string, err error) error {
+	if errors.Is(err, service.ErrKBEntryNotFound) {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": trErr(c, err)})
+	}
+	var validationErr *service.KBValidationError
+	if errors.As(err, &validationErr) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": trErr(c, err)})
+	}
+	log.Printf("%s error: %v", op, err)
+	return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Ошибка сохранения статьи")})
+}
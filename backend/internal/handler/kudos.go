@@ -28,13 +28,13 @@ func (h *KudosHandler) Send(c *fiber.Ctx) error {
 
 	req := new(models.CreateKudosRequest)
 	if err := c.BodyParser(req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Неверный запрос"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный запрос")})
 	}
 
 	kudos, err := h.svc.Send(member.Id, req.ToId, req.Message)
 	if err != nil {
 		log.Printf("send kudos error (from=%d, to=%d): %v", member.Id, req.ToId, err)
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Не удалось отправить благодарность"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Не удалось отправить благодарность")})
 	}
 
 	BroadcastEvent("kudos")
@@ -52,7 +52,7 @@ func (h *KudosHandler) GetRecent(c *fiber.Ctx) error {
 	items, total, err := h.svc.GetRecent(limit, offset)
 	if err != nil {
 		log.Printf("get recent kudos error: %v", err)
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Ошибка загрузки благодарностей"})
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Ошибка загрузки благодарностей")})
 	}
 
 	if member, mErr := getMember(c); mErr == nil && member != nil {
@@ -0,0 +1,116 @@
+package handler
+
+import (
+	"bufio"
+	"errors"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"ithozyeva/internal/models"
+	"ithozyeva/internal/service"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// LedgerExportHandler — выгрузка журналов баллов/кредитов и личная
+// выписка за месяц.
+type LedgerExportHandler struct {
+	svc          *service.LedgerExportService
+	statementSvc *service.PointsStatementService
+}
+
+func NewLedgerExportHandler() *LedgerExportHandler {
+	return &LedgerExportHandler{
+		svc:          service.NewLedgerExportService(),
+		statementSvc: service.NewPointsStatementService(),
+	}
+}
+
+// ExportMyPoints GET /api/platform/points/me/export?format=csv|json&from=&to=&reason=
+func (h *LedgerExportHandler) ExportMyPoints(c *fiber.Ctx) error {
+	return h.exportMine(c, models.LedgerPoints)
+}
+
+// ExportMyCredits GET /api/platform/credits/me/export — как ExportMyPoints.
+func (h *LedgerExportHandler) ExportMyCredits(c *fiber.Ctx) error {
+	return h.exportMine(c, models.LedgerCredits)
+}
+
+// AdminExportPoints GET /api/admin/points/export?member_id=&format=&from=&to=&reason=
+// — журнал всех участников или одного.
+func (h *LedgerExportHandler) AdminExportPoints(c *fiber.Ctx) error {
+	return h.adminExport(c, models.LedgerPoints)
+}
+
+// AdminExportCredits GET /api/admin/credits/export — как AdminExportPoints.
+func (h *LedgerExportHandler) AdminExportCredits(c *fiber.Ctx) error {
+	return h.adminExport(c, models.LedgerCredits)
+}
+
+func (h *LedgerExportHandler) exportMine(c *fiber.Ctx, ledger string) error {
+	member, err := getMember(c)
+	if err != nil {
+		return err
+	}
+	return h.export(c, ledger, member.Id)
+}
+
+func (h *LedgerExportHandler) adminExport(c *fiber.Ctx, ledger string) error {
+	var memberId int64
+	if raw := c.Query("member_id"); raw != "" {
+		id, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil || id <= 0 {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный ID")})
+		}
+		memberId = id
+	}
+	return h.export(c, ledger, memberId)
+}
+
+// export отдаёт журнал потоком: строки пишутся в ответ по мере чтения из
+// БД. Ошибка посреди выгрузки уже не меняет статус — только логируется.
+func (h *LedgerExportHandler) export(c *fiber.Ctx, ledger string, memberId int64) error {
+	format := strings.ToLower(c.Query("format", service.LedgerFormatCSV))
+	if format != service.LedgerFormatCSV && format != service.LedgerFormatJSON {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Формат должен быть csv или json")})
+	}
+	filter, err := service.ParseLedgerExportFilter(ledger, c.Query("from"), c.Query("to"), c.Query("reason"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": trErr(c, err)})
+	}
+	filter.MemberId = memberId
+
+	if format == service.LedgerFormatCSV {
+		c.Set("Content-Type", "text/csv; charset=utf-8")
+	} else {
+		c.Set("Content-Type", "application/json; charset=utf-8")
+	}
+	c.Set("Content-Disposition", "attachment; filename="+service.LedgerExportFilename(filter, format))
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		if err := h.svc.Export(w, format, filter); err != nil {
+			log.Printf("ledger export error (ledger=%s member=%d): %v", ledger, memberId, err)
+		}
+	})
+	return nil
+}
+
+// GetMyStatement GET /api/platform/points/me/statement?month=YYYY-MM —
+// итоги месяца по причинам; без month — текущий месяц.
+func (h *LedgerExportHandler) GetMyStatement(c *fiber.Ctx) error {
+	member, err := getMember(c)
+	if err != nil {
+		return err
+	}
+	start, err := service.ParseStatementMonth(c.Query("month"), time.Now())
+	if errors.Is(err, service.ErrStatementMonth) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": trErr(c, err)})
+	}
+	st, err := h.statementSvc.Build(member.Id, start)
+	if err != nil {
+		log.Printf("points statement error (member=%d): %v", member.Id, err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Не удалось получить выписку")})
+	}
+	return c.JSON(st)
+}
@@ -0,0 +1,39 @@
+package handler
+
+import (
+	"log"
+	"strconv"
+	"time"
+
+	"ithozyeva/internal/service"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type LLMUsageHandler struct {
+	svc *service.LLMUsageService
+}
+
+func NewLLMUsageHandler() *LLMUsageHandler {
+	return &LLMUsageHandler{
+		svc: service.NewLLMUsageService(),
+	}
+}
+
+// GetReport GET /api/admin/chat-activity/llm-usage?days=7
+// Токены, задержка и отказы по моделям цепочки fallback и по фичам.
+func (h *LLMUsageHandler) GetReport(c *fiber.Ctx) error {
+	days, err := strconv.Atoi(c.Query("days", "7"))
+	if err != nil || days <= 0 {
+		days = 7
+	}
+	if days > 90 {
+		days = 90
+	}
+	report, err := h.svc.Report(time.Now().AddDate(0, 0, -days))
+	if err != nil {
+		log.Printf("LLM usage report error: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Ошибка загрузки статистики LLM")})
+	}
+	return c.JSON(report)
+}
@@ -50,7 +50,7 @@ func (h *MarketplaceHandler) Search(c *fiber.Ctx) error {
 
 	items, total, err := h.svc.Search(statusPtr, limit, offset)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Не удалось получить объявления"})
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Не удалось получить объявления")})
 	}
 
 	return c.JSON(fiber.Map{"items": items, "total": total})
@@ -59,12 +59,12 @@ func (h *MarketplaceHandler) Search(c *fiber.Ctx) error {
 func (h *MarketplaceHandler) GetById(c *fiber.Ctx) error {
 	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Неверный ID"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный ID")})
 	}
 
 	item, err := h.svc.GetById(id)
 	if err != nil {
-		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Объявление не найдено"})
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": tr(c, "Объявление не найдено")})
 	}
 
 	return c.JSON(item)
@@ -94,7 +94,7 @@ func (h *MarketplaceHandler) Create(c *fiber.Ctx) error {
 	}
 
 	if req.Title == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Название обязательно"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Название обязательно")})
 	}
 
 	var imageContent []byte
@@ -104,25 +104,25 @@ func (h *MarketplaceHandler) Create(c *fiber.Ctx) error {
 	fileHeader, err := c.FormFile("image")
 	if err == nil && fileHeader != nil {
 		if fileHeader.Size > maxMarketplaceImageSize {
-			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Файл превышает 10MB"})
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Файл превышает 10MB")})
 		}
 
 		file, err := fileHeader.Open()
 		if err != nil {
-			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Не удалось открыть файл"})
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Не удалось открыть файл")})
 		}
 		defer file.Close()
 
 		data, err := io.ReadAll(file)
 		if err != nil {
-			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Не удалось прочитать файл"})
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Не удалось прочитать файл")})
 		}
 
 		// Тип определяем по содержимому, а не по заголовку клиента — иначе
 		// можно подсунуть произвольный бинарник под application/jpeg.
 		detected := http.DetectContentType(data)
 		if !allowedMarketplaceImageTypes[detected] {
-			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Допустимые форматы: jpg, png, webp"})
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Допустимые форматы: jpg, png, webp")})
 		}
 
 		ct := detected
@@ -142,7 +142,7 @@ func (h *MarketplaceHandler) Create(c *fiber.Ctx) error {
 	item, err := h.svc.Create(req, member.Id, imageFileName, imageContent, imageContentType)
 	if err != nil {
 		log.Printf("Marketplace create error (member=%d): %v", member.Id, err)
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Не удалось создать объявление"})
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Не удалось создать объявление")})
 	}
 
 	go h.pointSvc.GiveForAction(member.Id, models.PointReasonMarketplaceCreate, "marketplace", item.Id,
@@ -158,7 +158,7 @@ func (h *MarketplaceHandler) Update(c *fiber.Ctx) error {
 	}
 	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Неверный ID"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный ID")})
 	}
 
 	isAdmin := false
@@ -171,13 +171,13 @@ func (h *MarketplaceHandler) Update(c *fiber.Ctx) error {
 
 	var req models.CreateMarketplaceItemRequest
 	if err := c.BodyParser(&req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Неверный формат запроса"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный формат запроса")})
 	}
 
 	item, err := h.svc.Update(id, &req, member.Id, isAdmin)
 	if err != nil {
 		log.Printf("Marketplace update error (item=%d, member=%d): %v", id, member.Id, err)
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Не удалось обновить объявление"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Не удалось обновить объявление")})
 	}
 
 	return c.JSON(item)
@@ -190,13 +190,13 @@ func (h *MarketplaceHandler) RequestPurchase(c *fiber.Ctx) error {
 	}
 	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Неверный ID"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный ID")})
 	}
 
 	item, err := h.svc.RequestPurchase(id, member.Id)
 	if err != nil {
 		log.Printf("Marketplace requestPurchase error (item=%d, member=%d): %v", id, member.Id, err)
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Не удалось оформить заявку на покупку"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Не удалось оформить заявку на покупку")})
 	}
 
 	service.SafeGo("marketplace purchase notification", func() {
@@ -216,7 +216,7 @@ func (h *MarketplaceHandler) CancelPurchase(c *fiber.Ctx) error {
 	}
 	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Неверный ID"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный ID")})
 	}
 
 	isAdmin := false
@@ -230,7 +230,7 @@ func (h *MarketplaceHandler) CancelPurchase(c *fiber.Ctx) error {
 	item, err := h.svc.CancelPurchase(id, member.Id, isAdmin)
 	if err != nil {
 		log.Printf("Marketplace cancelPurchase error (item=%d, member=%d): %v", id, member.Id, err)
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Не удалось отменить покупку"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Не удалось отменить покупку")})
 	}
 
 	return c.JSON(item)
@@ -243,7 +243,7 @@ func (h *MarketplaceHandler) MarkSold(c *fiber.Ctx) error {
 	}
 	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Неверный ID"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный ID")})
 	}
 
 	isAdmin := false
@@ -257,7 +257,7 @@ func (h *MarketplaceHandler) MarkSold(c *fiber.Ctx) error {
 	item, err := h.svc.MarkSold(id, member.Id, isAdmin)
 	if err != nil {
 		log.Printf("Marketplace markSold error (item=%d, member=%d): %v", id, member.Id, err)
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Не удалось отметить как проданное"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Не удалось отметить как проданное")})
 	}
 
 	if item.BuyerId != nil {
@@ -275,7 +275,7 @@ func (h *MarketplaceHandler) Delete(c *fiber.Ctx) error {
 	}
 	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Неверный ID"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный ID")})
 	}
 
 	isAdmin := false
@@ -288,7 +288,7 @@ func (h *MarketplaceHandler) Delete(c *fiber.Ctx) error {
 
 	if err := h.svc.Delete(id, member.Id, isAdmin); err != nil {
 		log.Printf("Marketplace delete error (item=%d, member=%d): %v", id, member.Id, err)
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Не удалось удалить объявление"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Не удалось удалить объявление")})
 	}
 
 	return c.SendStatus(fiber.StatusNoContent)
@@ -55,7 +55,7 @@ type SearchMembersRequest struct {
 func (h *MembersHandler) Search(c *fiber.Ctx) error {
 	req := new(SearchMembersRequest)
 	if err := c.QueryParser(req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Неверный запрос"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный запрос")})
 	}
 
 	filter := make(repository.SearchFilter)
@@ -78,7 +78,7 @@ func (h *MembersHandler) Search(c *fiber.Ctx) error {
 	result, err := h.svc.Search(req.Limit, req.Offset, finalFilter, nil)
 	if err != nil {
 		log.Printf("Members search error: %v", err)
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Ошибка поиска участников"})
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Ошибка поиска участников")})
 	}
 
 	return c.JSON(result)
@@ -88,12 +88,12 @@ func (h *MembersHandler) Search(c *fiber.Ctx) error {
 func (h *MembersHandler) GetById(c *fiber.Ctx) error {
 	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Неверный ID"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный ID")})
 	}
 
 	result, err := h.svc.GetById(id)
 	if err != nil {
-		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Участник не найден"})
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": tr(c, "Участник не найден")})
 	}
 
 	return c.JSON(result)
@@ -104,7 +104,7 @@ func (h *MembersHandler) GetById(c *fiber.Ctx) error {
 func (h *MembersHandler) SearchPublic(c *fiber.Ctx) error {
 	req := new(SearchMembersRequest)
 	if err := c.QueryParser(req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Неверный запрос"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный запрос")})
 	}
 
 	filter := make(repository.SearchFilter)
@@ -127,7 +127,7 @@ func (h *MembersHandler) SearchPublic(c *fiber.Ctx) error {
 	result, err := h.svc.Search(req.Limit, req.Offset, finalFilter, nil)
 	if err != nil {
 		log.Printf("Members public search error: %v", err)
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Ошибка поиска участников"})
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Ошибка поиска участников")})
 	}
 
 	if result != nil {
@@ -144,14 +144,14 @@ func (h *MembersHandler) Create(c *fiber.Ctx) error {
 	request := new(models.Member)
 	err := c.BodyParser(request)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Неверный запрос"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный запрос")})
 	}
 
 	result, err := h.svc.Create(request)
 
 	if err != nil {
 		log.Printf("Member create error: %v", err)
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Ошибка создания участника"})
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Ошибка создания участника")})
 	}
 
 	go h.auditSvc.Log(getActorId(c), getActorName(c), getActorType(c), models.AuditActionCreate, "member", result.Id, result.FirstName+" "+result.LastName)
@@ -175,19 +175,19 @@ func (h *MembersHandler) Update(c *fiber.Ctx) error {
 	request := new(UpdateRequest)
 	err := c.BodyParser(request)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Неверный запрос"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный запрос")})
 	}
 
 	member, err := h.svc.GetById(request.Id)
 	if err != nil {
-		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Участник не найден"})
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": tr(c, "Участник не найден")})
 	}
 
 	if err := utils.ValidateProfileLengths(request.FirstName, request.LastName, request.Bio, request.Grade, request.Company); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": trErr(c, err)})
 	}
 	if err := utils.ValidateUsername(request.Username); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": trErr(c, err)})
 	}
 
 	member.FirstName = request.FirstName
@@ -209,7 +209,7 @@ func (h *MembersHandler) Update(c *fiber.Ctx) error {
 	if parsedDate != nil {
 		bdayTime := time.Time(*parsedDate)
 		if err := utils.ValidateBirthdayRange(&bdayTime); err != nil {
-			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": trErr(c, err)})
 		}
 	}
 	member.Birthday = parsedDate
@@ -218,10 +218,10 @@ func (h *MembersHandler) Update(c *fiber.Ctx) error {
 
 	if err != nil {
 		if errors.Is(err, repository.ErrUsernameTaken) {
-			return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": "Никнейм уже занят"})
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": tr(c, "Никнейм уже занят")})
 		}
 		log.Printf("Member update error: %v", err)
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Ошибка обновления участника"})
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Ошибка обновления участника")})
 	}
 
 	go h.auditSvc.Log(getActorId(c), getActorName(c), getActorType(c), models.AuditActionUpdate, "member", result.Id, result.FirstName+" "+result.LastName)
@@ -232,19 +232,19 @@ func (h *MembersHandler) Update(c *fiber.Ctx) error {
 func (h *MembersHandler) Delete(c *fiber.Ctx) error {
 	id, err := strconv.Atoi(c.Params("id"))
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Неверный ID"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный ID")})
 	}
 
 	entity, err := h.svc.GetById(int64(id))
 	if err != nil {
-		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Участник не найден"})
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": tr(c, "Участник не найден")})
 	}
 
 	entityName := entity.FirstName + " " + entity.LastName
 
 	if err := h.svc.Delete(entity); err != nil {
 		log.Printf("Member delete error: %v", err)
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Ошибка удаления участника"})
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Ошибка удаления участника")})
 	}
 
 	go h.auditSvc.Log(getActorId(c), getActorName(c), getActorType(c), models.AuditActionDelete, "member", int64(id), entityName)
@@ -280,7 +280,7 @@ func (h *MembersHandler) GetMyReferralCabinet(c *fiber.Ctx) error {
 	cabinet, err := h.svc.GetReferralCabinet(member, config.CFG.TelegramBotName)
 	if err != nil {
 		log.Printf("GetReferralCabinet failed (member=%d): %v", member.Id, err)
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Не удалось получить реф-кабинет"})
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Не удалось получить реф-кабинет")})
 	}
 	return c.JSON(cabinet)
 }
@@ -294,7 +294,7 @@ func (h *MembersHandler) GetMyReferrer(c *fiber.Ctx) error {
 	}
 	info, err := h.svc.GetReferrer(member)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Не удалось получить реферрера"})
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Не удалось получить реферрера")})
 	}
 	return c.JSON(fiber.Map{"referrer": info})
 }
@@ -307,19 +307,42 @@ func (h *MembersHandler) MarkReferrerSeen(c *fiber.Ctx) error {
 		return err
 	}
 	if err := h.svc.MarkReferralWelcomeSeen(member.Id); err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Не удалось сохранить"})
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Не удалось сохранить")})
 	}
 	return c.SendStatus(fiber.StatusNoContent)
 }
 
+// SetMyLanguage PUT /api/platform/members/me/language {"language": "en"} —
+// язык бота и ответов API.
+func (h *MembersHandler) SetMyLanguage(c *fiber.Ctx) error {
+	var req struct {
+		Language string `json:"language"`
+	}
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный запрос")})
+	}
+	member, err := getMember(c)
+	if err != nil {
+		return err
+	}
+	lang, err := h.svc.SetLanguage(member.Id, req.Language)
+	if errors.Is(err, service.ErrUnsupportedLanguage) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": trErr(c, err)})
+	}
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Не удалось сохранить")})
+	}
+	return c.JSON(fiber.Map{"language": lang})
+}
+
 func (h *MembersHandler) UpdateProfile(c *fiber.Ctx) error {
 	request := new(UpdateRequest)
 	if err := c.BodyParser(request); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Неверный запрос"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный запрос")})
 	}
 
 	if err := utils.ValidateProfileLengths(request.FirstName, request.LastName, request.Bio, request.Grade, request.Company); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": trErr(c, err)})
 	}
 
 	member, err := getMember(c)
@@ -343,7 +366,7 @@ func (h *MembersHandler) UpdateProfile(c *fiber.Ctx) error {
 	if parsedDate != nil {
 		bdayTime := time.Time(*parsedDate)
 		if err := utils.ValidateBirthdayRange(&bdayTime); err != nil {
-			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": trErr(c, err)})
 		}
 	}
 	member.Birthday = parsedDate
@@ -352,7 +375,7 @@ func (h *MembersHandler) UpdateProfile(c *fiber.Ctx) error {
 
 	if err != nil {
 		log.Printf("update profile error (member=%d): %v", member.Id, err)
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Ошибка обновления профиля"})
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Ошибка обновления профиля")})
 	}
 
 	go h.pointsSvc.CheckProfileComplete(result)
@@ -380,28 +403,28 @@ func (h *MembersHandler) UploadAvatar(c *fiber.Ctx) error {
 
 	fileHeader, err := c.FormFile("file")
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Файл обязателен"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Файл обязателен")})
 	}
 	if fileHeader.Size > maxAvatarSize {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Файл превышает 5MB"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Файл превышает 5MB")})
 	}
 
 	ext := strings.ToLower(filepath.Ext(fileHeader.Filename))
 	if ext != ".jpg" && ext != ".jpeg" && ext != ".png" && ext != ".webp" {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Допустимые форматы: jpg, png, webp"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Допустимые форматы: jpg, png, webp")})
 	}
 
 	file, err := fileHeader.Open()
 	if err != nil {
 		log.Printf("failed to open avatar file: %v", err)
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Ошибка открытия файла"})
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Ошибка открытия файла")})
 	}
 	defer file.Close()
 
 	data, err := io.ReadAll(file)
 	if err != nil {
 		log.Printf("failed to read avatar file: %v", err)
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Ошибка чтения файла"})
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Ошибка чтения файла")})
 	}
 
 	// Определяем Content-Type по содержимому файла, а не по заголовку клиента
@@ -412,13 +435,13 @@ func (h *MembersHandler) UploadAvatar(c *fiber.Ctx) error {
 		"image/webp": true,
 	}
 	if !allowedContentTypes[contentType] {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Недопустимый тип файла"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Недопустимый тип файла")})
 	}
 
 	s3Client, err := utils.NewS3Client()
 	if err != nil {
 		log.Printf("Failed to create S3 client: %v", err)
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Ошибка загрузки файла"})
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Ошибка загрузки файла")})
 	}
 
 	key := fmt.Sprintf("avatars/%s%s", uuid.NewString(), ext)
@@ -426,7 +449,7 @@ func (h *MembersHandler) UploadAvatar(c *fiber.Ctx) error {
 	
 	if err := s3Client.UploadPublic(context.Background(), key, data, contentType); err != nil {
 		log.Printf("Failed to upload avatar: %v", err)
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Ошибка загрузки файла"})
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Ошибка загрузки файла")})
 	}
 
 	avatarURL := s3Client.GetPublicURL(key)
@@ -436,7 +459,7 @@ func (h *MembersHandler) UploadAvatar(c *fiber.Ctx) error {
 	result, err := h.svc.Update(member)
 	if err != nil {
 		log.Printf("update member after avatar upload error (member=%d): %v", member.Id, err)
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Ошибка сохранения аватара"})
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Ошибка сохранения аватара")})
 	}
 
 	go h.pointsSvc.CheckProfileComplete(result)
@@ -454,12 +477,12 @@ func (h *MembersHandler) UploadAvatar(c *fiber.Ctx) error {
 func (h *MembersHandler) GetPublicProfile(c *fiber.Ctx) error {
 	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Неверный ID"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный ID")})
 	}
 
 	member, err := h.svc.GetById(id)
 	if err != nil {
-		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Участник не найден"})
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": tr(c, "Участник не найден")})
 	}
 
 	member.SubscriptionTier = h.svc.GetEffectiveTier(member.TelegramID)
@@ -498,7 +521,7 @@ func (h *MembersHandler) GetPermissions(c *fiber.Ctx) error {
 	permissions, err := h.svc.GetPermissions(member.Id)
 	if err != nil {
 		log.Printf("get permissions error (member=%d): %v", member.Id, err)
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Ошибка получения разрешений"})
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Ошибка получения разрешений")})
 	}
 
 	if config.CFG != nil && config.CFG.SuperAdminTelegramID != 0 && member.TelegramID == config.CFG.SuperAdminTelegramID {
@@ -35,12 +35,12 @@ func NewMentorHandler() *MentorHandler {
 func (h *MentorHandler) GetById(c *fiber.Ctx) error {
 	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Неверный ID"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный ID")})
 	}
 
 	entity, err := h.svc.GetByIdFull(id)
 	if err != nil {
-		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Ментор не найден"})
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": tr(c, "Ментор не найден")})
 	}
 
 	if member, mErr := getMember(c); mErr == nil && member != nil {
@@ -57,12 +57,12 @@ func (h *MentorHandler) GetById(c *fiber.Ctx) error {
 func (h *MentorHandler) GetByIdPublic(c *fiber.Ctx) error {
 	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Неверный ID"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный ID")})
 	}
 
 	entity, err := h.svc.GetByIdFull(id)
 	if err != nil {
-		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Ментор не найден"})
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": tr(c, "Ментор не найден")})
 	}
 
 	if member, mErr := getMember(c); mErr == nil && member != nil {
@@ -79,13 +79,13 @@ func (h *MentorHandler) GetByIdPublic(c *fiber.Ctx) error {
 func (h *MentorHandler) AddReviewToService(c *fiber.Ctx) error {
 	review := new(models.ReviewOnService)
 	if err := c.BodyParser(review); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Неверный запрос"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный запрос")})
 	}
 
 	result, err := h.svc.AddReviewToService(review)
 	if err != nil {
 		log.Printf("add review to service error: %v", err)
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Ошибка добавления отзыва"})
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Ошибка добавления отзыва")})
 	}
 
 	return c.JSON(result)
@@ -100,7 +100,7 @@ func (h *MentorHandler) AddReviewFromPlatform(c *fiber.Ctx) error {
 
 	req := new(Request)
 	if err := c.BodyParser(req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Неверный запрос"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный запрос")})
 	}
 
 	member, err := getMember(c)
@@ -121,7 +121,7 @@ func (h *MentorHandler) AddReviewFromPlatform(c *fiber.Ctx) error {
 	result, err := h.svc.AddReviewToService(review)
 	if err != nil {
 		log.Printf("add review from platform error (member=%d): %v", member.Id, err)
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Ошибка добавления отзыва"})
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Ошибка добавления отзыва")})
 	}
 
 	return c.JSON(result)
@@ -131,13 +131,13 @@ func (h *MentorHandler) AddReviewFromPlatform(c *fiber.Ctx) error {
 func (h *MentorHandler) Create(c *fiber.Ctx) error {
 	request := new(models.MentorDbModel)
 	if err := c.BodyParser(request); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Неверный запрос"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный запрос")})
 	}
 
 	result, err := h.svc.CreateWithRelations(request)
 	if err != nil {
 		log.Printf("create mentor error: %v", err)
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Ошибка создания ментора"})
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Ошибка создания ментора")})
 	}
 
 	go h.auditSvc.Log(getActorId(c), getActorName(c), getActorType(c), models.AuditActionCreate, "mentor", result.Id, fmt.Sprintf("%s %s", result.FirstName, result.LastName))
@@ -149,18 +149,18 @@ func (h *MentorHandler) Create(c *fiber.Ctx) error {
 func (h *MentorHandler) Update(c *fiber.Ctx) error {
 	request := new(models.MentorDbModel)
 	if err := c.BodyParser(request); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Неверный запрос"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный запрос")})
 	}
 
 	// Проверяем, что ID указан
 	if request.Id == 0 {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "ID ментора не указан"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "ID ментора не указан")})
 	}
 
 	result, err := h.svc.UpdateWithRelations(request)
 	if err != nil {
 		log.Printf("update mentor error (id=%d): %v", request.Id, err)
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Ошибка обновления ментора"})
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Ошибка обновления ментора")})
 	}
 
 	go h.auditSvc.Log(getActorId(c), getActorName(c), getActorType(c), models.AuditActionUpdate, "mentor", result.Id, fmt.Sprintf("%s %s", result.FirstName, result.LastName))
@@ -172,24 +172,24 @@ func (h *MentorHandler) Update(c *fiber.Ctx) error {
 func (h *MentorHandler) Delete(c *fiber.Ctx) error {
 	id, err := strconv.Atoi(c.Params("id"))
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Неверный ID"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный ID")})
 	}
 
 	fullEntity, err := h.svc.GetByIdFull(int64(id))
 	if err != nil {
-		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Ментор не найден"})
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": tr(c, "Ментор не найден")})
 	}
 
 	entity, err := h.service.GetById(int64(id))
 	if err != nil {
-		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Ментор не найден"})
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": tr(c, "Ментор не найден")})
 	}
 
 	entityName := fmt.Sprintf("%s %s", fullEntity.FirstName, fullEntity.LastName)
 
 	if err := h.service.Delete(entity); err != nil {
 		log.Printf("delete mentor error (id=%d): %v", id, err)
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Ошибка удаления ментора"})
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Ошибка удаления ментора")})
 	}
 
 	go h.auditSvc.Log(getActorId(c), getActorName(c), getActorType(c), models.AuditActionDelete, "mentor", int64(id), entityName)
@@ -200,13 +200,13 @@ func (h *MentorHandler) Delete(c *fiber.Ctx) error {
 func (h *MentorHandler) GetServices(c *fiber.Ctx) error {
 	id, err := strconv.Atoi(c.Params("id"))
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Неверный запрос"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный запрос")})
 	}
 
 	result, err := h.svc.GetServices(int64(id))
 
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Неверный запрос"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный запрос")})
 	}
 
 	return c.JSON(result)
@@ -215,13 +215,13 @@ func (h *MentorHandler) GetServices(c *fiber.Ctx) error {
 func (h *MentorHandler) GetAllWithRelations(c *fiber.Ctx) error {
 	req := new(models.SearchRequest)
 	if err := c.QueryParser(req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Неверный запрос"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный запрос")})
 	}
 
 	result, err := h.svc.GetAllWithRelations(req.Limit, req.Offset)
 	if err != nil {
 		log.Printf("get all mentors error: %v", err)
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Ошибка загрузки менторов"})
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Ошибка загрузки менторов")})
 	}
 
 	return c.JSON(result)
@@ -232,13 +232,13 @@ func (h *MentorHandler) GetAllWithRelations(c *fiber.Ctx) error {
 func (h *MentorHandler) GetAllWithRelationsPublic(c *fiber.Ctx) error {
 	req := new(models.SearchRequest)
 	if err := c.QueryParser(req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Неверный запрос"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный запрос")})
 	}
 
 	result, err := h.svc.GetAllWithRelations(req.Limit, req.Offset)
 	if err != nil {
 		log.Printf("get all mentors public error: %v", err)
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Ошибка загрузки менторов"})
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Ошибка загрузки менторов")})
 	}
 
 	if result != nil {
@@ -258,7 +258,7 @@ type UpdateInfoRequest struct {
 func (h *MentorHandler) UpdateInfo(c *fiber.Ctx) error {
 	req := new(UpdateInfoRequest)
 	if err := c.BodyParser(req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Неверный запрос"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный запрос")})
 	}
 
 	member, err := getMember(c)
@@ -269,7 +269,7 @@ func (h *MentorHandler) UpdateInfo(c *fiber.Ctx) error {
 
 	if err != nil {
 		log.Printf("get mentor by member ID error: %v", err)
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Ошибка получения данных ментора"})
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Ошибка получения данных ментора")})
 	}
 
 	existedMentor.Occupation = req.Occupation
@@ -278,7 +278,7 @@ func (h *MentorHandler) UpdateInfo(c *fiber.Ctx) error {
 	result, err := h.svc.UpdateWithRelations(existedMentor)
 	if err != nil {
 		log.Printf("update mentor info error: %v", err)
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Ошибка обновления информации ментора"})
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Ошибка обновления информации ментора")})
 	}
 
 	return c.JSON(result)
@@ -291,7 +291,7 @@ type UpdateProfTagsRequest struct {
 func (h *MentorHandler) UpdateProfTags(c *fiber.Ctx) error {
 	req := new(UpdateProfTagsRequest)
 	if err := c.BodyParser(req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Неверный запрос"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный запрос")})
 	}
 
 	member, err := getMember(c)
@@ -302,7 +302,7 @@ func (h *MentorHandler) UpdateProfTags(c *fiber.Ctx) error {
 
 	if err != nil {
 		log.Printf("get mentor by member ID error: %v", err)
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Ошибка получения данных ментора"})
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Ошибка получения данных ментора")})
 	}
 
 	existedMentor.ProfTags = req.ProfTags
@@ -310,7 +310,7 @@ func (h *MentorHandler) UpdateProfTags(c *fiber.Ctx) error {
 	result, err := h.svc.UpdateWithRelations(existedMentor)
 	if err != nil {
 		log.Printf("update mentor prof tags error: %v", err)
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Ошибка обновления тегов ментора"})
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Ошибка обновления тегов ментора")})
 	}
 
 	return c.JSON(result)
@@ -323,7 +323,7 @@ type UpdateContactsRequest struct {
 func (h *MentorHandler) UpdateContacts(c *fiber.Ctx) error {
 	req := new(UpdateContactsRequest)
 	if err := c.BodyParser(req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Неверный запрос"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный запрос")})
 	}
 
 	member, err := getMember(c)
@@ -334,7 +334,7 @@ func (h *MentorHandler) UpdateContacts(c *fiber.Ctx) error {
 
 	if err != nil {
 		log.Printf("get mentor by member ID error: %v", err)
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Ошибка получения данных ментора"})
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Ошибка получения данных ментора")})
 	}
 
 	existedMentor.Contacts = req.Contacts
@@ -342,7 +342,7 @@ func (h *MentorHandler) UpdateContacts(c *fiber.Ctx) error {
 	result, err := h.svc.UpdateWithRelations(existedMentor)
 	if err != nil {
 		log.Printf("update mentor contacts error: %v", err)
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Ошибка обновления контактов ментора"})
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Ошибка обновления контактов ментора")})
 	}
 
 	return c.JSON(result)
@@ -355,17 +355,17 @@ type UpdateOrderRequest struct {
 func (h *MentorHandler) UpdateOrder(c *fiber.Ctx) error {
 	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Неверный ID"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный ID")})
 	}
 
 	req := new(UpdateOrderRequest)
 	if err := c.BodyParser(req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Неверный запрос"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный запрос")})
 	}
 
 	if err := database.DB.Model(&models.MentorDbShortModel{}).Where("id = ?", id).Update("order", req.Order).Error; err != nil {
 		log.Printf("update mentor order error (id=%d): %v", id, err)
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Ошибка обновления порядка ментора"})
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Ошибка обновления порядка ментора")})
 	}
 
 	go h.auditSvc.Log(getActorId(c), getActorName(c), getActorType(c), models.AuditActionUpdate, "mentor", id, fmt.Sprintf("order=%d", req.Order))
@@ -380,7 +380,7 @@ type UpdateServicesRequest struct {
 func (h *MentorHandler) UpdateServices(c *fiber.Ctx) error {
 	req := new(UpdateServicesRequest)
 	if err := c.BodyParser(req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Неверный запрос"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный запрос")})
 	}
 
 	member, err := getMember(c)
@@ -391,7 +391,7 @@ func (h *MentorHandler) UpdateServices(c *fiber.Ctx) error {
 
 	if err != nil {
 		log.Printf("get mentor by member ID error: %v", err)
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Ошибка получения данных ментора"})
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Ошибка получения данных ментора")})
 	}
 
 	existedMentor.Services = req.Services
@@ -399,7 +399,7 @@ func (h *MentorHandler) UpdateServices(c *fiber.Ctx) error {
 	result, err := h.svc.UpdateWithRelations(existedMentor)
 	if err != nil {
 		log.Printf("update mentor services error: %v", err)
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Ошибка обновления услуг ментора"})
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Ошибка обновления услуг ментора")})
 	}
 
 	return c.JSON(result)
@@ -1,8 +1,13 @@
 package handler
 
 import (
+	"bytes"
+	"io"
 	"strconv"
+	"strings"
+	"time"
 
+	"ithozyeva/config"
 	"ithozyeva/internal/models"
 	"ithozyeva/internal/service"
 
@@ -24,7 +29,7 @@ func NewModerationHandler(redisClient *redis.Client) *ModerationHandler {
 func (h *ModerationHandler) GetActiveSanctions(c *fiber.Ctx) error {
 	rows, err := h.svc.ListActiveSanctionsView()
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": trErr(c, err)})
 	}
 	return c.JSON(fiber.Map{"items": rows, "total": len(rows)})
 }
@@ -33,7 +38,7 @@ func (h *ModerationHandler) GetActiveSanctions(c *fiber.Ctx) error {
 func (h *ModerationHandler) GetRecentActions(c *fiber.Ctx) error {
 	rows, err := h.svc.ListRecentActionsView()
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": trErr(c, err)})
 	}
 	return c.JSON(fiber.Map{"items": rows, "total": len(rows)})
 }
@@ -42,16 +47,101 @@ func (h *ModerationHandler) GetRecentActions(c *fiber.Ctx) error {
 func (h *ModerationHandler) GetGlobalBans(c *fiber.Ctx) error {
 	bans, err := h.svc.ListActiveGlobalBans()
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": trErr(c, err)})
 	}
 	return c.JSON(fiber.Map{"items": bans, "total": len(bans)})
 }
 
+// ExportGlobalBans GET /api/admin/moderation/global-bans/export?format=json|csv&source=
+// Активные глобальные баны в формате общего бан-листа (см. service/banlist.go)
+// — файл для партнёрских сообществ. source пустой — все источники.
+func (h *ModerationHandler) ExportGlobalBans(c *fiber.Ctx) error {
+	format := strings.ToLower(c.Query("format", service.BanlistFormatJSON))
+	if format != service.BanlistFormatJSON && format != service.BanlistFormatCSV {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "format must be json or csv"})
+	}
+	entries, err := h.svc.ExportGlobalBans(c.Query("source"))
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": trErr(c, err)})
+	}
+
+	var buf bytes.Buffer
+	if format == service.BanlistFormatCSV {
+		err = service.WriteBanlistCSV(&buf, entries)
+		c.Set("Content-Type", "text/csv; charset=utf-8")
+	} else {
+		err = service.WriteBanlistJSON(&buf, "ithozyeva", entries, time.Now())
+		c.Set("Content-Type", "application/json; charset=utf-8")
+	}
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": trErr(c, err)})
+	}
+	c.Set("Content-Disposition", "attachment; filename=banlist."+format)
+	return c.Send(buf.Bytes())
+}
+
+// ImportGlobalBans POST /api/admin/moderation/global-bans/import?format=json|csv
+// Тело — файл бан-листа целиком или multipart-поле file. Формат без
+// параметра определяется по содержимому. Записи получают source=import и не
+// перетирают ручные баны; забанит их бот при вступлении в чат.
+func (h *ModerationHandler) ImportGlobalBans(c *fiber.Ctx) error {
+	data := c.Body()
+	if fileHeader, err := c.FormFile("file"); err == nil {
+		f, err := fileHeader.Open()
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "cannot open file"})
+		}
+		defer f.Close()
+		if data, err = io.ReadAll(io.LimitReader(f, service.BanlistMaxBytes+1)); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "cannot read file"})
+		}
+	}
+	if len(data) > service.BanlistMaxBytes {
+		return c.Status(fiber.StatusRequestEntityTooLarge).JSON(fiber.Map{"error": "file too large"})
+	}
+
+	entries, err := service.ParseBanlist(data, c.Query("format"), time.Now())
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": trErr(c, err)})
+	}
+	if len(entries) == 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": trErr(c, service.ErrBanlistEmpty)})
+	}
+	res, err := h.svc.ImportBanlist(entries, models.GlobalBanSourceImport, actorTelegramID(c))
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": trErr(c, err)})
+	}
+	return c.JSON(res)
+}
+
+// SyncGlobalBans POST /api/admin/moderation/global-bans/sync
+// Внеочередная синхронизация с BANLIST_SYNC_URL (обычно — по таймеру).
+func (h *ModerationHandler) SyncGlobalBans(c *fiber.Ctx) error {
+	if config.CFG.BanlistSyncURL == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "BANLIST_SYNC_URL is not configured"})
+	}
+	res, err := h.svc.SyncBanlist(c.Context(), config.CFG.BanlistSyncURL)
+	if err != nil {
+		return c.Status(fiber.StatusBadGateway).JSON(fiber.Map{"error": trErr(c, err)})
+	}
+	return c.JSON(res)
+}
+
 // GetOpenVotebans GET /api/admin/moderation/votebans
 func (h *ModerationHandler) GetOpenVotebans(c *fiber.Ctx) error {
 	rows, err := h.svc.ListOpenVotebansEnriched()
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": trErr(c, err)})
+	}
+	return c.JSON(fiber.Map{"items": rows, "total": len(rows)})
+}
+
+// GetRaids GET /api/admin/moderation/raids
+// Последние рейды (активные и завершённые) с числом вступивших и забаненных.
+func (h *ModerationHandler) GetRaids(c *fiber.Ctx) error {
+	rows, err := h.svc.ListRecentRaidsView()
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": trErr(c, err)})
 	}
 	return c.JSON(fiber.Map{"items": rows, "total": len(rows)})
 }
@@ -66,7 +156,7 @@ func (h *ModerationHandler) RevokeSanction(c *fiber.Ctx) error {
 	}
 	action, err := h.svc.GetActionByID(id)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": trErr(c, err)})
 	}
 	if action == nil {
 		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "not found"})
@@ -109,7 +199,7 @@ func (h *ModerationHandler) CancelVoteban(c *fiber.Ctx) error {
 	}
 	vb, changed, err := h.svc.CancelOpenVoteban(id)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": trErr(c, err)})
 	}
 	if vb == nil {
 		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "not found"})
@@ -127,6 +217,114 @@ func (h *ModerationHandler) CancelVoteban(c *fiber.Ctx) error {
 	return c.JSON(fiber.Map{"ok": true, "changed": changed})
 }
 
+// GetChatSettingsList GET /api/admin/moderation/settings
+// Чаты с переопределёнными настройками + все модераторы-волонтёры.
+func (h *ModerationHandler) GetChatSettingsList(c *fiber.Ctx) error {
+	settings, err := h.svc.ListChatSettings()
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": trErr(c, err)})
+	}
+	mods, err := h.svc.ListChatModerators(0)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": trErr(c, err)})
+	}
+	return c.JSON(fiber.Map{"items": settings, "total": len(settings), "moderators": mods})
+}
+
+// GetChatSettings GET /api/admin/moderation/chats/:chat_id/settings
+// Настройки с подстановкой дефолтов + модераторы чата.
+func (h *ModerationHandler) GetChatSettings(c *fiber.Ctx) error {
+	chatID, err := strconv.ParseInt(c.Params("chat_id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "bad chat_id"})
+	}
+	st, err := h.svc.GetChatSettings(chatID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": trErr(c, err)})
+	}
+	mods, err := h.svc.ListChatModerators(chatID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": trErr(c, err)})
+	}
+	return c.JSON(fiber.Map{"settings": st, "moderators": mods})
+}
+
+// UpdateChatSettings PUT /api/admin/moderation/chats/:chat_id/settings
+// Частичное обновление: не переданные поля остаются текущими.
+func (h *ModerationHandler) UpdateChatSettings(c *fiber.Ctx) error {
+	chatID, err := strconv.ParseInt(c.Params("chat_id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "bad chat_id"})
+	}
+	st, err := h.svc.GetChatSettings(chatID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": trErr(c, err)})
+	}
+	if err := c.BodyParser(st); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "bad body"})
+	}
+	st.ChatID = chatID
+	if err := h.svc.SaveChatSettings(st, actorTelegramID(c)); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": trErr(c, err)})
+	}
+	return c.JSON(st)
+}
+
+// ResetChatSettings DELETE /api/admin/moderation/chats/:chat_id/settings
+func (h *ModerationHandler) ResetChatSettings(c *fiber.Ctx) error {
+	chatID, err := strconv.ParseInt(c.Params("chat_id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "bad chat_id"})
+	}
+	if err := h.svc.ResetChatSettings(chatID, actorTelegramID(c)); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": trErr(c, err)})
+	}
+	return c.JSON(fiber.Map{"ok": true})
+}
+
+// SetChatModerator PUT /api/admin/moderation/chats/:chat_id/moderators/:user_id
+// Body: {username, canMute, canBan, canCleanup}.
+func (h *ModerationHandler) SetChatModerator(c *fiber.Ctx) error {
+	chatID, err := strconv.ParseInt(c.Params("chat_id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "bad chat_id"})
+	}
+	userID, err := strconv.ParseInt(c.Params("user_id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "bad user_id"})
+	}
+	m := new(models.ChatModerator)
+	if err := c.BodyParser(m); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "bad body"})
+	}
+	m.ChatID = chatID
+	m.UserID = userID
+	if err := h.svc.SetChatModerator(m, actorTelegramID(c)); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": trErr(c, err)})
+	}
+	return c.JSON(m)
+}
+
+// RemoveChatModerator DELETE /api/admin/moderation/chats/:chat_id/moderators/:user_id
+func (h *ModerationHandler) RemoveChatModerator(c *fiber.Ctx) error {
+	chatID, err := strconv.ParseInt(c.Params("chat_id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "bad chat_id"})
+	}
+	userID, err := strconv.ParseInt(c.Params("user_id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "bad user_id"})
+	}
+	removed, err := h.svc.RemoveChatModerator(chatID, userID, actorTelegramID(c))
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": trErr(c, err)})
+	}
+	if !removed {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "not found"})
+	}
+	return c.JSON(fiber.Map{"ok": true})
+}
+
 // actorMemberID извлекает Member.Id из context, проставленного RequireAuth.
 // 0 — если что-то пошло не так (не должен происходить в защищённой группе).
 func actorMemberID(c *fiber.Ctx) int64 {
@@ -137,3 +335,71 @@ func actorMemberID(c *fiber.Ctx) int64 {
 	}
 	return 0
 }
+
+// actorTelegramID — telegram id админа для журнала модерации (actor_user_id
+// там telegram-шный). 0 — у участника не привязан Telegram.
+func actorTelegramID(c *fiber.Ctx) int64 {
+	if v := c.Locals("member"); v != nil {
+		if m, ok := v.(*models.Member); ok && m != nil {
+			return m.TelegramID
+		}
+	}
+	return 0
+}
+
+// GetRestrictionPolicies GET /api/admin/moderation/policies
+// Чаты с заданными политиками ограничений (новички, rate limit).
+func (h *ModerationHandler) GetRestrictionPolicies(c *fiber.Ctx) error {
+	rows, err := h.svc.ListRestrictionPolicies()
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": trErr(c, err)})
+	}
+	return c.JSON(fiber.Map{"items": rows, "total": len(rows)})
+}
+
+// GetRestrictionPolicy GET /api/admin/moderation/chats/:chat_id/policy
+func (h *ModerationHandler) GetRestrictionPolicy(c *fiber.Ctx) error {
+	chatID, err := strconv.ParseInt(c.Params("chat_id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "bad chat_id"})
+	}
+	p, err := h.svc.GetRestrictionPolicy(chatID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": trErr(c, err)})
+	}
+	return c.JSON(p)
+}
+
+// UpdateRestrictionPolicy PUT /api/admin/moderation/chats/:chat_id/policy
+// Частичное обновление, как у настроек. Бот подхватывает политику в
+// течение минуты (кеш в памяти бота).
+func (h *ModerationHandler) UpdateRestrictionPolicy(c *fiber.Ctx) error {
+	chatID, err := strconv.ParseInt(c.Params("chat_id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "bad chat_id"})
+	}
+	p, err := h.svc.GetRestrictionPolicy(chatID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": trErr(c, err)})
+	}
+	if err := c.BodyParser(p); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "bad body"})
+	}
+	p.ChatID = chatID
+	if err := h.svc.SaveRestrictionPolicy(p, actorTelegramID(c)); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": trErr(c, err)})
+	}
+	return c.JSON(p)
+}
+
+// ResetRestrictionPolicy DELETE /api/admin/moderation/chats/:chat_id/policy
+func (h *ModerationHandler) ResetRestrictionPolicy(c *fiber.Ctx) error {
+	chatID, err := strconv.ParseInt(c.Params("chat_id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "bad chat_id"})
+	}
+	if err := h.svc.ResetRestrictionPolicy(chatID, actorTelegramID(c)); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": trErr(c, err)})
+	}
+	return c.JSON(fiber.Map{"ok": true})
+}
@@ -28,7 +28,7 @@ func (h *NotificationHandler) GetMy(c *fiber.Ctx) error {
 		Limit(50).
 		Find(&notifications).Error; err != nil {
 		log.Printf("get notifications error (member=%d): %v", member.Id, err)
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Ошибка загрузки уведомлений"})
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Ошибка загрузки уведомлений")})
 	}
 
 	return c.JSON(notifications)
@@ -45,7 +45,7 @@ func (h *NotificationHandler) GetUnreadCount(c *fiber.Ctx) error {
 		Where("member_id = ? AND read = false", member.Id).
 		Count(&count).Error; err != nil {
 		log.Printf("get unread count error (member=%d): %v", member.Id, err)
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Ошибка получения количества уведомлений"})
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Ошибка получения количества уведомлений")})
 	}
 
 	return c.JSON(fiber.Map{"count": count})
@@ -58,7 +58,7 @@ func (h *NotificationHandler) MarkAsRead(c *fiber.Ctx) error {
 	}
 	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Неверный ID"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный ID")})
 	}
 
 	result := database.DB.Model(&models.Notification{}).
@@ -67,11 +67,11 @@ func (h *NotificationHandler) MarkAsRead(c *fiber.Ctx) error {
 
 	if result.Error != nil {
 		log.Printf("mark notification as read error (id=%d, member=%d): %v", id, member.Id, result.Error)
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Ошибка обновления уведомления"})
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Ошибка обновления уведомления")})
 	}
 
 	if result.RowsAffected == 0 {
-		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Уведомление не найдено"})
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": tr(c, "Уведомление не найдено")})
 	}
 
 	return c.SendStatus(fiber.StatusOK)
@@ -87,7 +87,7 @@ func (h *NotificationHandler) MarkAllAsRead(c *fiber.Ctx) error {
 		Where("member_id = ? AND read = false", member.Id).
 		Update("read", true).Error; err != nil {
 		log.Printf("mark all notifications as read error (member=%d): %v", member.Id, err)
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Ошибка обновления уведомлений"})
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Ошибка обновления уведомлений")})
 	}
 
 	return c.SendStatus(fiber.StatusOK)
@@ -0,0 +1,35 @@
+package handler
+
+import (
+	"log"
+	"time"
+
+	"ithozyeva/internal/models"
+	"ithozyeva/internal/service"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type PlatformSearchHandler struct {
+	svc *service.PlatformSearchService
+}
+
+func NewPlatformSearchHandler() *PlatformSearchHandler {
+	return &PlatformSearchHandler{svc: service.NewPlatformSearchService()}
+}
+
+// Search GET /api/platform/search?q= — события, менторы, участники и
+// AI-материалы, которые участник может открыть (тот же поиск, что в
+// inline-режиме бота).
+func (h *PlatformSearchHandler) Search(c *fiber.Ctx) error {
+	member, ok := c.Locals("member").(*models.Member)
+	if !ok || member == nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Unauthorized"})
+	}
+	items, err := h.svc.Search(member, c.Query("q"), time.Now())
+	if err != nil {
+		log.Printf("Platform search error: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Ошибка поиска")})
+	}
+	return c.JSON(fiber.Map{"items": items})
+}
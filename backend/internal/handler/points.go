@@ -1,20 +1,27 @@
 package handler
 
 import (
+	"errors"
+	"fmt"
 	"ithozyeva/internal/models"
 	"ithozyeva/internal/service"
+	"log"
 	"strconv"
 
 	"github.com/gofiber/fiber/v2"
 )
 
 type PointsHandler struct {
-	svc *service.PointsService
+	svc            *service.PointsService
+	leaderboardSvc *service.LeaderboardService
+	auditSvc       *service.AuditService
 }
 
 func NewPointsHandler() *PointsHandler {
 	return &PointsHandler{
-		svc: service.NewPointsService(),
+		svc:            service.NewPointsService(),
+		leaderboardSvc: service.NewLeaderboardService(),
+		auditSvc:       service.NewAuditService(),
 	}
 }
 
@@ -26,7 +33,7 @@ func (h *PointsHandler) GetMyPoints(c *fiber.Ctx) error {
 
 	summary, err := h.svc.GetMyPoints(member.Id)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Не удалось получить баллы"})
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Не удалось получить баллы")})
 	}
 
 	return c.JSON(summary)
@@ -39,18 +46,52 @@ func (h *PointsHandler) GetLeaderboard(c *fiber.Ctx) error {
 		limit = 20
 	}
 
-	entries, err := h.svc.GetLeaderboard(limit)
-	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Не удалось получить рейтинг"})
+	member, mErr := getMember(c)
+	if mErr == nil && member != nil {
+		service.TrackDailyTrigger(member.Id, "view_leaderboard", 1)
 	}
 
-	if member, mErr := getMember(c); mErr == nil && member != nil {
-		service.TrackDailyTrigger(member.Id, "view_leaderboard", 1)
+	// ?period=weekly|monthly|quarterly — рейтинг текущего периода,
+	// без параметра — по всему балансу, как раньше.
+	if period := c.Query("period"); period != "" && period != "all" {
+		var memberId int64
+		if member != nil {
+			memberId = member.Id
+		}
+		resp, err := h.leaderboardSvc.Current(period, memberId, limit)
+		if errors.Is(err, service.ErrUnknownLeaderboardPeriod) {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": trErr(c, err)})
+		}
+		if err != nil {
+			log.Printf("period leaderboard error (period=%s): %v", period, err)
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Не удалось получить рейтинг")})
+		}
+		return c.JSON(resp)
+	}
+
+	entries, err := h.svc.GetLeaderboard(limit)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Не удалось получить рейтинг")})
 	}
 
 	return c.JSON(fiber.Map{"items": entries})
 }
 
+// GetLeaderboardHistory GET /api/platform/points/leaderboard/history?period=weekly&limit=10 —
+// завершённые периоды с топом и призами.
+func (h *PointsHandler) GetLeaderboardHistory(c *fiber.Ctx) error {
+	limit, _ := strconv.Atoi(c.Query("limit", "10"))
+	snaps, err := h.leaderboardSvc.History(c.Query("period", models.LeaderboardWeekly), limit)
+	if errors.Is(err, service.ErrUnknownLeaderboardPeriod) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": trErr(c, err)})
+	}
+	if err != nil {
+		log.Printf("leaderboard history error: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Не удалось получить рейтинг")})
+	}
+	return c.JSON(fiber.Map{"items": snaps})
+}
+
 func (h *PointsHandler) AdminSearch(c *fiber.Ctx) error {
 	limitStr := c.Query("limit", "20")
 	offsetStr := c.Query("offset", "0")
@@ -69,7 +110,7 @@ func (h *PointsHandler) AdminSearch(c *fiber.Ctx) error {
 
 	items, total, err := h.svc.SearchTransactions(usernamePtr, limit, offset)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Не удалось получить транзакции"})
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Не удалось получить транзакции")})
 	}
 
 	return c.JSON(fiber.Map{"items": items, "total": total})
@@ -78,29 +119,58 @@ func (h *PointsHandler) AdminSearch(c *fiber.Ctx) error {
 func (h *PointsHandler) AdminAward(c *fiber.Ctx) error {
 	var req models.AdminAwardRequest
 	if err := c.BodyParser(&req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Неверный формат запроса"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный формат запроса")})
 	}
 
 	if req.MemberId <= 0 || req.Amount <= 0 {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "memberId и amount обязательны и должны быть > 0"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "memberId и amount обязательны и должны быть > 0")})
 	}
 
 	if err := h.svc.AdminAwardPoints(req.MemberId, req.Amount, req.Description); err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Не удалось начислить баллы"})
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Не удалось начислить баллы")})
 	}
 
 	return c.JSON(fiber.Map{"success": true})
 }
 
-func (h *PointsHandler) AdminDelete(c *fiber.Ctx) error {
+// AdminReverse POST /api/admin/points/:id/reverse — отмена транзакции
+// сторно-записью; причина обязательна и попадает в описание.
+func (h *PointsHandler) AdminReverse(c *fiber.Ctx) error {
 	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Неверный ID"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный ID")})
+	}
+	var req models.AdminReverseRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный формат запроса")})
 	}
 
-	if err := h.svc.DeleteTransaction(id); err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Не удалось удалить транзакцию"})
+	rev, err := h.svc.Reverse(id, req.Reason)
+	switch {
+	case errors.Is(err, service.ErrPointTransactionNotFound):
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": trErr(c, err)})
+	case errors.Is(err, service.ErrPointTransactionReversed),
+		errors.Is(err, service.ErrPointReversalOfReversal),
+		errors.Is(err, service.ErrPointReversalReason):
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": trErr(c, err)})
+	case err != nil:
+		log.Printf("points reverse error (id=%d): %v", id, err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Не удалось отменить транзакцию")})
 	}
 
-	return c.JSON(fiber.Map{"success": true})
+	go h.auditSvc.Log(getActorId(c), getActorName(c), getActorType(c), models.AuditActionReverse, "point_transaction", id, rev.Description)
+	go CreateNotification(rev.MemberId, "points_reversal", "Транзакция отменена",
+		fmt.Sprintf("%s (%+d баллов)", rev.Description, rev.Amount))
+	return c.JSON(rev)
+}
+
+// AdminReconcile POST /api/admin/points/reconcile — внеплановая сверка
+// снапшотов балансов с журналом.
+func (h *PointsHandler) AdminReconcile(c *fiber.Ctx) error {
+	report, err := h.svc.ReconcileBalances()
+	if err != nil {
+		log.Printf("points reconcile error: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Не удалось сверить балансы")})
+	}
+	return c.JSON(report)
 }
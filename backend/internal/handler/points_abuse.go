@@ -0,0 +1,116 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+
+	"ithozyeva/internal/models"
+	"ithozyeva/internal/service"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// PointsAbuseHandler — очередь подозрительных начислений в админке:
+// просмотр, списание (clawback) и отклонение флагов.
+type PointsAbuseHandler struct {
+	svc      *service.PointsAbuseService
+	auditSvc *service.AuditService
+}
+
+func NewPointsAbuseHandler() *PointsAbuseHandler {
+	return &PointsAbuseHandler{
+		svc:      service.NewPointsAbuseService(),
+		auditSvc: service.NewAuditService(),
+	}
+}
+
+// Flags GET /api/admin/points/flags?status=open&limit=20&offset=0
+func (h *PointsAbuseHandler) Flags(c *fiber.Ctx) error {
+	limit, _ := strconv.Atoi(c.Query("limit", "20"))
+	offset, _ := strconv.Atoi(c.Query("offset", "0"))
+	items, total, err := h.svc.Search(c.Query("status"), limit, offset)
+	if err != nil {
+		log.Printf("points flags error: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Не удалось получить флаги")})
+	}
+	return c.JSON(fiber.Map{"items": items, "total": total})
+}
+
+// Flag GET /api/admin/points/flags/:id — флаг и транзакции участников за окно.
+func (h *PointsAbuseHandler) Flag(c *fiber.Ctx) error {
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный ID")})
+	}
+	detail, err := h.svc.Detail(id)
+	if err != nil {
+		return h.writeError(c, fmt.Sprintf("points flag (id=%d)", id), err)
+	}
+	return c.JSON(detail)
+}
+
+// Clawback POST /api/admin/points/flags/:id/clawback — списание выбранных
+// начислений. Каждое списание пишется в аудит, участнику — уведомление.
+func (h *PointsAbuseHandler) Clawback(c *fiber.Ctx) error {
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный ID")})
+	}
+	var req models.PointsClawbackRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный запрос")})
+	}
+	actorId, actorName := getActorId(c), getActorName(c)
+	clawbacks, err := h.svc.Clawback(id, req.TransactionIds, req.Reason, actorId, actorName)
+	if err != nil {
+		return h.writeError(c, fmt.Sprintf("points clawback (flag=%d)", id), err)
+	}
+
+	perMember := make(map[int64]int)
+	for _, cb := range clawbacks {
+		go h.auditSvc.Log(actorId, actorName, getActorType(c), models.AuditActionClawback, "point_transaction", cb.TransactionId,
+			fmt.Sprintf("-%d (%s), флаг #%d: %s", cb.Amount, cb.Reason, id, req.Reason))
+		perMember[cb.MemberId] += cb.Amount
+	}
+	for memberId, amount := range perMember {
+		go CreateNotification(memberId, "points_clawback", "Баллы списаны",
+			fmt.Sprintf("Списано %d баллов: %s", amount, req.Reason))
+	}
+	return c.JSON(fiber.Map{"items": clawbacks})
+}
+
+// Dismiss POST /api/admin/points/flags/:id/dismiss — ложное срабатывание.
+func (h *PointsAbuseHandler) Dismiss(c *fiber.Ctx) error {
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный ID")})
+	}
+	var req models.PointsFlagDismissRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный запрос")})
+		}
+	}
+	flag, err := h.svc.Dismiss(id, req.Note, getActorId(c), getActorName(c))
+	if err != nil {
+		return h.writeError(c, fmt.Sprintf("points flag dismiss (id=%d)", id), err)
+	}
+	go h.auditSvc.Log(getActorId(c), getActorName(c), getActorType(c), models.AuditActionUpdate, "points_flag", flag.Id,
+		fmt.Sprintf("%s → %s", flag.Kind, flag.Status))
+	return c.JSON(flag)
+}
+
+// writeError: «не найдено» — 404, отказы — 400, остальное — 500.
+func (h *PointsAbuseHandler) writeError(c *fiber.Ctx, op string, err error) error {
+	if errors.Is(err, service.ErrPointsFlagNotFound) {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": trErr(c, err)})
+	}
+	var abuseErr *service.PointsAbuseError
+	if errors.As(err, &abuseErr) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": trErr(c, err)})
+	}
+	log.Printf("%s error: %v", op, err)
+	return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Ошибка обработки флага")})
+}
@@ -12,12 +12,14 @@ import (
 type ProfileStatsHandler struct {
 	repo           *repository.ProfileStatsRepository
 	achievementSvc *service.AchievementService
+	leaderboardSvc *service.LeaderboardService
 }
 
 func NewProfileStatsHandler() *ProfileStatsHandler {
 	return &ProfileStatsHandler{
 		repo:           repository.NewProfileStatsRepository(),
 		achievementSvc: service.NewAchievementService(),
+		leaderboardSvc: service.NewLeaderboardService(),
 	}
 }
 
@@ -31,6 +33,16 @@ func (h *ProfileStatsHandler) enrichAchievements(stats *repository.ProfileStats,
 	stats.AchievementsTotal = total
 }
 
+// enrichLeaderboardWins — призовые места в рейтингах прошлых периодов.
+func (h *ProfileStatsHandler) enrichLeaderboardWins(stats *repository.ProfileStats, memberId int64) {
+	wins, err := h.leaderboardSvc.MemberWins(memberId)
+	if err != nil {
+		log.Printf("enrichLeaderboardWins: failed for member %d: %v", memberId, err)
+		return
+	}
+	stats.LeaderboardWins = wins
+}
+
 func (h *ProfileStatsHandler) GetMyStats(c *fiber.Ctx) error {
 	member, err := getMember(c)
 	if err != nil {
@@ -39,22 +51,24 @@ func (h *ProfileStatsHandler) GetMyStats(c *fiber.Ctx) error {
 	stats, err := h.repo.GetStats(member.Id)
 	if err != nil {
 		log.Printf("get my profile stats error (member=%d): %v", member.Id, err)
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Ошибка загрузки статистики профиля"})
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Ошибка загрузки статистики профиля")})
 	}
 	h.enrichAchievements(stats, member.Id)
+	h.enrichLeaderboardWins(stats, member.Id)
 	return c.JSON(stats)
 }
 
 func (h *ProfileStatsHandler) GetMemberStats(c *fiber.Ctx) error {
 	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Неверный ID"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный ID")})
 	}
 	stats, err := h.repo.GetStats(id)
 	if err != nil {
 		log.Printf("get member profile stats error (id=%d): %v", id, err)
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Ошибка загрузки статистики профиля"})
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Ошибка загрузки статистики профиля")})
 	}
 	h.enrichAchievements(stats, id)
+	h.enrichLeaderboardWins(stats, id)
 	return c.JSON(stats)
 }
@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"errors"
 	"log"
 	"strconv"
 
@@ -13,12 +14,14 @@ import (
 type RaffleHandler struct {
 	svc      *service.RaffleService
 	dailySvc *service.DailyRaffleService
+	auditSvc *service.AuditService
 }
 
 func NewRaffleHandler() *RaffleHandler {
 	return &RaffleHandler{
 		svc:      service.NewRaffleService(),
 		dailySvc: service.NewDailyRaffleService(),
+		auditSvc: service.NewAuditService(),
 	}
 }
 
@@ -31,7 +34,7 @@ func (h *RaffleHandler) DailyToday(c *fiber.Ctx) error {
 	pub, err := h.dailySvc.GetTodayPublic(member.Id)
 	if err != nil {
 		log.Printf("daily raffle today (member=%d): %v", member.Id, err)
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Ошибка загрузки ежедневного розыгрыша"})
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Ошибка загрузки ежедневного розыгрыша")})
 	}
 	if pub == nil {
 		return c.JSON(fiber.Map{"raffle": nil})
@@ -47,7 +50,7 @@ func (h *RaffleHandler) GetAll(c *fiber.Ctx) error {
 	items, err := h.svc.GetAll(member.Id)
 	if err != nil {
 		log.Printf("get raffles error (member=%d): %v", member.Id, err)
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Ошибка загрузки розыгрышей"})
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Ошибка загрузки розыгрышей")})
 	}
 	return c.JSON(items)
 }
@@ -56,7 +59,7 @@ func (h *RaffleHandler) GetAllAdmin(c *fiber.Ctx) error {
 	items, err := h.svc.GetAllAdmin()
 	if err != nil {
 		log.Printf("get admin raffles error: %v", err)
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Ошибка загрузки розыгрышей"})
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Ошибка загрузки розыгрышей")})
 	}
 	return c.JSON(items)
 }
@@ -68,17 +71,17 @@ func (h *RaffleHandler) BuyTickets(c *fiber.Ctx) error {
 	}
 	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Неверный ID"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный ID")})
 	}
 
 	req := new(models.BuyTicketRequest)
 	if err := c.BodyParser(req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Неверный запрос"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный запрос")})
 	}
 
 	if err := h.svc.BuyTickets(id, member.Id, req.Count); err != nil {
 		log.Printf("buy raffle tickets error (raffle=%d, member=%d): %v", id, member.Id, err)
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": trErr(c, err)})
 	}
 
 	BroadcastEvent("raffles")
@@ -88,23 +91,87 @@ func (h *RaffleHandler) BuyTickets(c *fiber.Ctx) error {
 func (h *RaffleHandler) Create(c *fiber.Ctx) error {
 	raffle := new(models.Raffle)
 	if err := c.BodyParser(raffle); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Неверный запрос"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный запрос")})
 	}
 	if err := h.svc.Create(raffle); err != nil {
+		var refused *service.RaffleError
+		if errors.As(err, &refused) {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": trErr(c, err)})
+		}
 		log.Printf("create raffle error: %v", err)
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Ошибка создания розыгрыша"})
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Ошибка создания розыгрыша")})
 	}
 	return c.Status(fiber.StatusCreated).JSON(raffle)
 }
 
+// GetDrawProof GET /api/platform/raffles/:id/draw — раскрытый seed,
+// участники и пересчёт жеребьёвки.
+func (h *RaffleHandler) GetDrawProof(c *fiber.Ctx) error {
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный ID")})
+	}
+	proof, err := h.svc.DrawProof(id)
+	switch {
+	case errors.Is(err, service.ErrRaffleNotFound):
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": trErr(c, err)})
+	case errors.Is(err, service.ErrRaffleNotDrawn):
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": trErr(c, err)})
+	case err != nil:
+		log.Printf("raffle draw proof error (id=%d): %v", id, err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Ошибка проверки розыгрыша")})
+	}
+	return c.JSON(proof)
+}
+
+// GetWinners GET /api/admin/raffles/:id/winners — победители и выдача призов.
+func (h *RaffleHandler) GetWinners(c *fiber.Ctx) error {
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный ID")})
+	}
+	items, err := h.svc.Winners(id)
+	if err != nil {
+		log.Printf("get raffle winners error (id=%d): %v", id, err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Ошибка загрузки победителей")})
+	}
+	return c.JSON(fiber.Map{"items": items})
+}
+
+// UpdateFulfillment PUT /api/admin/raffles/winners/:id — отметка о выдаче
+// приза.
+func (h *RaffleHandler) UpdateFulfillment(c *fiber.Ctx) error {
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный ID")})
+	}
+	req := new(models.RaffleFulfillmentRequest)
+	if err := c.BodyParser(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный запрос")})
+	}
+	winner, err := h.svc.UpdateFulfillment(id, req)
+	var refused *service.RaffleError
+	switch {
+	case errors.Is(err, service.ErrRaffleWinnerNotFound):
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": trErr(c, err)})
+	case errors.As(err, &refused):
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": trErr(c, err)})
+	case err != nil:
+		log.Printf("update raffle fulfillment error (winner=%d): %v", id, err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Ошибка обновления выдачи приза")})
+	}
+	go h.auditSvc.Log(getActorId(c), getActorName(c), getActorType(c), models.AuditActionUpdate, "raffle_winner", winner.Id, winner.PrizeTitle)
+	return c.JSON(winner)
+}
+
 func (h *RaffleHandler) Delete(c *fiber.Ctx) error {
 	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Неверный ID"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный ID")})
 	}
 	if err := h.svc.Delete(id); err != nil {
 		log.Printf("delete raffle error (id=%d): %v", id, err)
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Ошибка удаления розыгрыша"})
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Ошибка удаления розыгрыша")})
 	}
 	return c.SendStatus(fiber.StatusNoContent)
 }
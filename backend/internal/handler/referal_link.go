@@ -40,7 +40,7 @@ type ReferalLinkSearchRequest struct {
 func (h *ReferalLinkHandler) Search(c *fiber.Ctx) error {
 	req := new(ReferalLinkSearchRequest)
 	if err := c.QueryParser(req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Неверный запрос"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный запрос")})
 	}
 
 	filter := make(repository.SearchFilter)
@@ -62,7 +62,7 @@ func (h *ReferalLinkHandler) Search(c *fiber.Ctx) error {
 
 	result, err := h.svc.SearchWithMember(req.Limit, req.Offset, &filter, nil, memberId)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Ошибка при загрузке реферальных ссылок"})
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Ошибка при загрузке реферальных ссылок")})
 	}
 
 	return c.JSON(result)
@@ -71,7 +71,7 @@ func (h *ReferalLinkHandler) Search(c *fiber.Ctx) error {
 func (h *ReferalLinkHandler) AddLink(c *fiber.Ctx) error {
 	req := new(models.AddLinkRequest)
 	if err := c.BodyParser(req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Неверный запрос"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный запрос")})
 	}
 
 	member, err := getMember(c)
@@ -82,7 +82,7 @@ func (h *ReferalLinkHandler) AddLink(c *fiber.Ctx) error {
 	result, err := h.svc.AddLink(req, member)
 	if err != nil {
 		log.Printf("add referral link error (member=%d): %v", member.Id, err)
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Ошибка создания реферальной ссылки"})
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Ошибка создания реферальной ссылки")})
 	}
 
 	go h.auditSvc.Log(getActorId(c), getActorName(c), getActorType(c), models.AuditActionCreate, "referal_link", result.Id, result.Company)
@@ -96,7 +96,7 @@ func (h *ReferalLinkHandler) AddLink(c *fiber.Ctx) error {
 func (h *ReferalLinkHandler) UpdateLink(c *fiber.Ctx) error {
 	req := new(models.UpdateLinkRequest)
 	if err := c.BodyParser(req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Неверный запрос"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный запрос")})
 	}
 
 	member, err := getMember(c)
@@ -107,17 +107,17 @@ func (h *ReferalLinkHandler) UpdateLink(c *fiber.Ctx) error {
 	existedLink, err := h.service.GetById(req.Id)
 	if err != nil {
 		log.Printf("get referral link error (id=%d): %v", req.Id, err)
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Реферальная ссылка не найдена"})
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Реферальная ссылка не найдена")})
 	}
 
 	if member.Id != existedLink.Author.Id {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Нельзя изменять чужие реферальные ссылки"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Нельзя изменять чужие реферальные ссылки")})
 	}
 
 	result, err := h.svc.UpdateLink(req, member)
 	if err != nil {
 		log.Printf("update referral link error (id=%d): %v", req.Id, err)
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Ошибка обновления реферальной ссылки"})
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Ошибка обновления реферальной ссылки")})
 	}
 
 	go h.auditSvc.Log(getActorId(c), getActorName(c), getActorType(c), models.AuditActionUpdate, "referal_link", result.Id, result.Company)
@@ -132,7 +132,7 @@ type TrackConversionRequest struct {
 func (h *ReferalLinkHandler) TrackConversion(c *fiber.Ctx) error {
 	req := new(TrackConversionRequest)
 	if err := c.BodyParser(req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Неверный запрос"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный запрос")})
 	}
 
 	member, err := getMember(c)
@@ -145,10 +145,10 @@ func (h *ReferalLinkHandler) TrackConversion(c *fiber.Ctx) error {
 	// начислять себе очки за «конверсию» собственных линок.
 	link, err := h.service.GetById(req.ReferralLinkId)
 	if err != nil {
-		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Реферальная ссылка не найдена"})
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": tr(c, "Реферальная ссылка не найдена")})
 	}
 	if link.AuthorId == member.Id {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Нельзя конвертировать собственную ссылку"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Нельзя конвертировать собственную ссылку")})
 	}
 
 	err = h.svc.TrackConversion(req.ReferralLinkId, member.Id)
@@ -159,7 +159,7 @@ func (h *ReferalLinkHandler) TrackConversion(c *fiber.Ctx) error {
 			return c.SendStatus(fiber.StatusOK)
 		}
 		log.Printf("track conversion error (link=%d, member=%d): %v", req.ReferralLinkId, member.Id, err)
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Ошибка отслеживания конверсии"})
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Ошибка отслеживания конверсии")})
 	}
 
 	// Реферальные ссылки на вакансии — отдельная история от платформенных
@@ -182,7 +182,7 @@ func (h *ReferalLinkHandler) TrackConversion(c *fiber.Ctx) error {
 func (h *ReferalLinkHandler) AdminSearch(c *fiber.Ctx) error {
 	req := new(ReferalLinkSearchRequest)
 	if err := c.QueryParser(req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Неверный запрос"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный запрос")})
 	}
 
 	filter := make(repository.SearchFilter)
@@ -200,7 +200,7 @@ func (h *ReferalLinkHandler) AdminSearch(c *fiber.Ctx) error {
 	result, err := h.service.Search(req.Limit, req.Offset, &filter, nil)
 	if err != nil {
 		log.Printf("admin search referral links error: %v", err)
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Ошибка поиска реферальных ссылок"})
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Ошибка поиска реферальных ссылок")})
 	}
 
 	return c.JSON(result)
@@ -209,12 +209,12 @@ func (h *ReferalLinkHandler) AdminSearch(c *fiber.Ctx) error {
 func (h *ReferalLinkHandler) AdminGetById(c *fiber.Ctx) error {
 	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Неверный ID"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный ID")})
 	}
 
 	result, err := h.service.GetById(id)
 	if err != nil {
-		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Ссылка не найдена"})
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": tr(c, "Ссылка не найдена")})
 	}
 
 	return c.JSON(result)
@@ -223,13 +223,13 @@ func (h *ReferalLinkHandler) AdminGetById(c *fiber.Ctx) error {
 func (h *ReferalLinkHandler) AdminDelete(c *fiber.Ctx) error {
 	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Неверный ID"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный ID")})
 	}
 
 	err = h.svc.Delete(&models.ReferalLink{Id: id})
 	if err != nil {
 		log.Printf("admin delete referral link error (id=%d): %v", id, err)
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Ошибка удаления реферальной ссылки"})
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Ошибка удаления реферальной ссылки")})
 	}
 
 	go h.auditSvc.Log(getActorId(c), getActorName(c), getActorType(c), models.AuditActionDelete, "referal_link", id, "admin delete")
@@ -240,7 +240,7 @@ func (h *ReferalLinkHandler) AdminDelete(c *fiber.Ctx) error {
 func (h *ReferalLinkHandler) DeleteLink(c *fiber.Ctx) error {
 	req := new(models.DeleteLinkRequest)
 	if err := c.BodyParser(req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Неверный запрос"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный запрос")})
 	}
 
 	member, err := getMember(c)
@@ -251,17 +251,17 @@ func (h *ReferalLinkHandler) DeleteLink(c *fiber.Ctx) error {
 	existedLink, err := h.service.GetById(req.Id)
 	if err != nil {
 		log.Printf("get referral link error (id=%d): %v", req.Id, err)
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Реферальная ссылка не найдена"})
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Реферальная ссылка не найдена")})
 	}
 
 	if member.Id != existedLink.Author.Id {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Нельзя изменять чужие реферальные ссылки"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Нельзя изменять чужие реферальные ссылки")})
 	}
 
 	err = h.svc.Delete(&models.ReferalLink{Id: req.Id})
 	if err != nil {
 		log.Printf("delete referral link error (id=%d): %v", req.Id, err)
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Ошибка удаления реферальной ссылки"})
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Ошибка удаления реферальной ссылки")})
 	}
 
 	go h.auditSvc.Log(getActorId(c), getActorName(c), getActorType(c), models.AuditActionDelete, "referal_link", req.Id, "")
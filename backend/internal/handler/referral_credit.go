@@ -33,7 +33,7 @@ func (h *ReferralCreditHandler) GetMine(c *fiber.Ctx) error {
 	}
 	summary, err := h.svc.GetSummary(member.Id)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Не удалось получить кредиты"})
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Не удалось получить кредиты")})
 	}
 	return c.JSON(summary)
 }
@@ -55,7 +55,7 @@ func (h *ReferralCreditHandler) AdminSearch(c *fiber.Ctx) error {
 	}
 	items, total, err := h.svc.SearchTransactions(usernamePtr, limit, offset)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Не удалось получить транзакции"})
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Не удалось получить транзакции")})
 	}
 	return c.JSON(fiber.Map{"items": items, "total": total})
 }
@@ -63,16 +63,16 @@ func (h *ReferralCreditHandler) AdminSearch(c *fiber.Ctx) error {
 func (h *ReferralCreditHandler) AdminAward(c *fiber.Ctx) error {
 	var req models.AdminAwardCreditsRequest
 	if err := c.BodyParser(&req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Неверный формат запроса"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный формат запроса")})
 	}
 	if req.MemberId <= 0 || req.Amount == 0 {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "memberId обязателен; amount должен быть != 0"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "memberId обязателен; amount должен быть != 0")})
 	}
 	if err := h.svc.AdminAward(req.MemberId, req.Amount, req.Description); err != nil {
 		if errors.Is(err, repository.ErrInsufficientCredits) {
-			return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{"error": "Списание невозможно: на балансе меньше указанной суммы"})
+			return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{"error": tr(c, "Списание невозможно: на балансе меньше указанной суммы")})
 		}
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Не удалось начислить кредиты"})
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Не удалось начислить кредиты")})
 	}
 
 	// Audit + notification: credits — реальная стоимость (через них покупается
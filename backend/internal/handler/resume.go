@@ -50,10 +50,10 @@ func (h *ResumeHandler) Upload(c *fiber.Ctx) error {
 
 	fileHeader, err := c.FormFile("file")
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Файл обязателен"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Файл обязателен")})
 	}
 	if fileHeader.Size > maxResumeSize {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Файл превышает 10MB"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Файл превышает 10MB")})
 	}
 
 	file, err := fileHeader.Open()
@@ -78,7 +78,7 @@ func (h *ResumeHandler) Upload(c *fiber.Ctx) error {
 	detected := http.DetectContentType(data)
 	if !allowedResumeContentTypes[detected] {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Допустимые форматы: pdf, doc, docx",
+			"error": tr(c, "Допустимые форматы: pdf, doc, docx"),
 		})
 	}
 	contentType := detected
@@ -134,12 +134,12 @@ func (h *ResumeHandler) UpdateMy(c *fiber.Ctx) error {
 
 	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Некорректный идентификатор"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Некорректный идентификатор")})
 	}
 
 	payload := new(models.UpdateResumeRequest)
 	if err := c.BodyParser(payload); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Некорректное тело запроса"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Некорректное тело запроса")})
 	}
 
 	if payload.WorkFormat != nil {
@@ -168,7 +168,7 @@ func (h *ResumeHandler) DeleteMy(c *fiber.Ctx) error {
 
 	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Некорректный идентификатор"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Некорректный идентификатор")})
 	}
 
 	resume, err := h.svc.DeleteResume(id, member.TelegramID)
@@ -190,7 +190,7 @@ func (h *ResumeHandler) DownloadMy(c *fiber.Ctx) error {
 
 	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Некорректный идентификатор"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Некорректный идентификатор")})
 	}
 
 	resume, err := h.svc.GetByIdForMember(id, member.TelegramID)
@@ -232,7 +232,7 @@ func (h *ResumeHandler) AdminDownload(c *fiber.Ctx) error {
 func (h *ResumeHandler) AdminGet(c *fiber.Ctx) error {
 	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Некорректный идентификатор"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Некорректный идентификатор")})
 	}
 
 	resume, err := h.svc.GetByIdWithMember(id)
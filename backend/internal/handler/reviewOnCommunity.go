@@ -30,13 +30,13 @@ func NewReviewOnCommunityHandler() *ReviewOnCommunityHandler {
 func (h *ReviewOnCommunityHandler) GetAllWithAuthor(c *fiber.Ctx) error {
 	req := new(models.SearchRequest)
 	if err := c.QueryParser(req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Неверный запрос"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный запрос")})
 	}
 
 	result, err := h.svc.GetAllWithAuthor(req.Limit, req.Offset)
 	if err != nil {
 		log.Printf("get reviews with author error: %v", err)
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Ошибка загрузки отзывов"})
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Ошибка загрузки отзывов")})
 	}
 
 	return c.JSON(result)
@@ -45,7 +45,7 @@ func (h *ReviewOnCommunityHandler) GetAllWithAuthor(c *fiber.Ctx) error {
 func (h *ReviewOnCommunityHandler) AddReview(c *fiber.Ctx) error {
 	review := new(models.AddReviewOnCommunityRequest)
 	if err := c.BodyParser(review); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Неверный запрос"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный запрос")})
 	}
 
 	author, err := getMember(c)
@@ -57,7 +57,7 @@ func (h *ReviewOnCommunityHandler) AddReview(c *fiber.Ctx) error {
 
 	if err != nil {
 		log.Printf("add review error (member=%d): %v", author.Id, err)
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Ошибка добавления отзыва"})
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Ошибка добавления отзыва")})
 	}
 
 	service.TrackDailyTrigger(author.Id, "leave_review", 1)
@@ -67,13 +67,13 @@ func (h *ReviewOnCommunityHandler) AddReview(c *fiber.Ctx) error {
 func (h *ReviewOnCommunityHandler) CreateReview(c *fiber.Ctx) error {
 	review := new(models.CreateReviewOnCommunityRequest)
 	if err := c.BodyParser(review); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Неверный запрос"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный запрос")})
 	}
 
 	err := h.svc.CreateReviewOnCommunity(review)
 	if err != nil {
 		log.Printf("create review error: %v", err)
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Ошибка создания отзыва"})
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Ошибка создания отзыва")})
 	}
 
 	go h.auditSvc.Log(getActorId(c), getActorName(c), getActorType(c), models.AuditActionCreate, "review_on_community", 0, review.AuthorTg)
@@ -90,7 +90,7 @@ func (h *ReviewOnCommunityHandler) GetMyReviews(c *fiber.Ctx) error {
 	reviews, err := h.svc.GetByAuthorId(member.Id)
 	if err != nil {
 		log.Printf("get my reviews error (member=%d): %v", member.Id, err)
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Ошибка загрузки отзывов"})
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Ошибка загрузки отзывов")})
 	}
 	return c.JSON(reviews)
 }
@@ -102,20 +102,20 @@ func (h *ReviewOnCommunityHandler) UpdateMyReview(c *fiber.Ctx) error {
 	}
 	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Неверный ID"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный ID")})
 	}
 
 	review, err := h.svc.GetById(id)
 	if err != nil {
-		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Отзыв не найден"})
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": tr(c, "Отзыв не найден")})
 	}
 	if int64(review.AuthorId) != member.Id {
-		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": "Нет доступа"})
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": tr(c, "Нет доступа")})
 	}
 
 	req := new(models.AddReviewOnCommunityRequest)
 	if err := c.BodyParser(req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Неверный запрос"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный запрос")})
 	}
 
 	review.Text = req.Text
@@ -123,7 +123,7 @@ func (h *ReviewOnCommunityHandler) UpdateMyReview(c *fiber.Ctx) error {
 	result, err := h.svc.Update(review)
 	if err != nil {
 		log.Printf("update review error (id=%d): %v", id, err)
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Ошибка обновления отзыва"})
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Ошибка обновления отзыва")})
 	}
 	return c.JSON(result)
 }
@@ -135,20 +135,20 @@ func (h *ReviewOnCommunityHandler) DeleteMyReview(c *fiber.Ctx) error {
 	}
 	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Неверный ID"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный ID")})
 	}
 
 	review, err := h.svc.GetById(id)
 	if err != nil {
-		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Отзыв не найден"})
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": tr(c, "Отзыв не найден")})
 	}
 	if int64(review.AuthorId) != member.Id {
-		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": "Нет доступа"})
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": tr(c, "Нет доступа")})
 	}
 
 	if err := h.svc.Delete(review); err != nil {
 		log.Printf("delete my review error (id=%d): %v", id, err)
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Ошибка удаления отзыва"})
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Ошибка удаления отзыва")})
 	}
 	return c.SendStatus(fiber.StatusNoContent)
 }
@@ -157,7 +157,7 @@ func (h *ReviewOnCommunityHandler) GetApproved(c *fiber.Ctx) error {
 	result, err := h.svc.GetApproved()
 	if err != nil {
 		log.Printf("get approved reviews error: %v", err)
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Ошибка загрузки отзывов"})
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Ошибка загрузки отзывов")})
 	}
 
 	return c.JSON(result)
@@ -166,13 +166,13 @@ func (h *ReviewOnCommunityHandler) GetApproved(c *fiber.Ctx) error {
 func (h *ReviewOnCommunityHandler) Approve(c *fiber.Ctx) error {
 	id, err := strconv.Atoi(c.Params("id"))
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Неверный запрос"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный запрос")})
 	}
 
 	result, err := h.svc.Approve(int64(id))
 	if err != nil {
 		log.Printf("approve review error (id=%d): %v", id, err)
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Ошибка одобрения отзыва"})
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Ошибка одобрения отзыва")})
 	}
 
 	go h.auditSvc.Log(getActorId(c), getActorName(c), getActorType(c), models.AuditActionApprove, "review_on_community", int64(id), result.Text)
@@ -186,17 +186,17 @@ func (h *ReviewOnCommunityHandler) Approve(c *fiber.Ctx) error {
 func (h *ReviewOnCommunityHandler) Delete(c *fiber.Ctx) error {
 	id, err := strconv.Atoi(c.Params("id"))
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Неверный ID"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный ID")})
 	}
 
 	entity, err := h.service.GetById(int64(id))
 	if err != nil {
-		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Отзыв не найден"})
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": tr(c, "Отзыв не найден")})
 	}
 
 	if err := h.service.Delete(entity); err != nil {
 		log.Printf("delete review error (id=%d): %v", id, err)
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Ошибка удаления отзыва"})
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Ошибка удаления отзыва")})
 	}
 
 	go h.auditSvc.Log(getActorId(c), getActorName(c), getActorType(c), models.AuditActionDelete, "review_on_community", int64(id), entity.Text)
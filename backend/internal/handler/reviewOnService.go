@@ -31,13 +31,13 @@ func NewReviewOnServiceHandler() *ReviewOnServiceHandler {
 func (h *ReviewOnServiceHandler) Search(c *fiber.Ctx) error {
 	req := new(models.SearchRequest)
 	if err := c.QueryParser(req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Неверный запрос"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный запрос")})
 	}
 
 	result, err := h.svc.Search(req.Limit, req.Offset, nil, nil)
 	if err != nil {
 		log.Printf("search service reviews error: %v", err)
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Ошибка поиска отзывов"})
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Ошибка поиска отзывов")})
 	}
 
 	return c.JSON(result)
@@ -47,13 +47,13 @@ func (h *ReviewOnServiceHandler) Search(c *fiber.Ctx) error {
 func (h *ReviewOnServiceHandler) GetReviewsWithMentorInfo(c *fiber.Ctx) error {
 	req := new(models.SearchRequest)
 	if err := c.QueryParser(req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Неверный запрос"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный запрос")})
 	}
 
 	result, err := h.svc.GetReviewsWithMentorInfo(req.Limit, req.Offset)
 	if err != nil {
 		log.Printf("get reviews with mentor info error: %v", err)
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Ошибка загрузки отзывов"})
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Ошибка загрузки отзывов")})
 	}
 
 	return c.JSON(result)
@@ -63,7 +63,7 @@ func (h *ReviewOnServiceHandler) GetReviewsWithMentorInfo(c *fiber.Ctx) error {
 func (h *ReviewOnServiceHandler) CreateReview(c *fiber.Ctx) error {
 	request := new(models.ReviewOnServiceRequest)
 	if err := c.BodyParser(request); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Неверный запрос"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный запрос")})
 	}
 
 	result, err := h.svc.Create(&models.ReviewOnService{
@@ -74,7 +74,7 @@ func (h *ReviewOnServiceHandler) CreateReview(c *fiber.Ctx) error {
 	})
 	if err != nil {
 		log.Printf("create service review error: %v", err)
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Ошибка создания отзыва"})
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Ошибка создания отзыва")})
 	}
 
 	go h.auditSvc.Log(getActorId(c), getActorName(c), getActorType(c), models.AuditActionCreate, "review_on_service", int64(result.Id), result.Author)
@@ -89,17 +89,17 @@ func (h *ReviewOnServiceHandler) CreateReview(c *fiber.Ctx) error {
 func (h *ReviewOnServiceHandler) Delete(c *fiber.Ctx) error {
 	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Неверный ID"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный ID")})
 	}
 
 	entity, err := h.svc.GetById(id)
 	if err != nil {
-		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Отзыв не найден"})
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": tr(c, "Отзыв не найден")})
 	}
 
 	if err := h.svc.Delete(entity); err != nil {
 		log.Printf("delete service review error (id=%d): %v", id, err)
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Ошибка удаления отзыва"})
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Ошибка удаления отзыва")})
 	}
 
 	go h.auditSvc.Log(getActorId(c), getActorName(c), getActorType(c), models.AuditActionDelete, "review_on_service", id, entity.Author)
@@ -111,13 +111,13 @@ func (h *ReviewOnServiceHandler) Delete(c *fiber.Ctx) error {
 func (h *ReviewOnServiceHandler) Approve(c *fiber.Ctx) error {
 	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Неверный ID"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный ID")})
 	}
 
 	result, err := h.svc.Approve(id)
 	if err != nil {
 		log.Printf("approve service review error (id=%d): %v", id, err)
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Ошибка одобрения отзыва"})
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Ошибка одобрения отзыва")})
 	}
 
 	go h.auditSvc.Log(getActorId(c), getActorName(c), getActorType(c), models.AuditActionApprove, "review_on_service", id, result.Author)
@@ -134,12 +134,12 @@ func (h *ReviewOnServiceHandler) Approve(c *fiber.Ctx) error {
 func (h *ReviewOnServiceHandler) GetById(c *fiber.Ctx) error {
 	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Неверный ID"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный ID")})
 	}
 
 	result, err := h.svc.GetById(id)
 	if err != nil {
-		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Отзыв не найден"})
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": tr(c, "Отзыв не найден")})
 	}
 
 	return c.JSON(result)
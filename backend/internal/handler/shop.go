@@ -0,0 +1,212 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+
+	"ithozyeva/internal/models"
+	"ithozyeva/internal/service"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+// ShopHandler — магазин наград за баллы: витрина и заказы участника,
+// каталог и выдача заказов в админке.
+type ShopHandler struct {
+	svc      *service.ShopService
+	auditSvc *service.AuditService
+}
+
+func NewShopHandler(redisClient *redis.Client) *ShopHandler {
+	return &ShopHandler{
+		svc:      service.NewShopService(redisClient),
+		auditSvc: service.NewAuditService(),
+	}
+}
+
+// shopOrderStatusTitles — заголовки уведомлений покупателю.
+var shopOrderStatusTitles = map[string]string{
+	models.ShopOrderShipped:   "Заказ отправлен",
+	models.ShopOrderDelivered: "Заказ выдан",
+	models.ShopOrderCancelled: "Заказ отменён",
+}
+
+// Items GET /api/platform/shop/items
+func (h *ShopHandler) Items(c *fiber.Ctx) error {
+	member, err := getMember(c)
+	if err != nil {
+		return err
+	}
+	items, err := h.svc.Items(member.Id)
+	if err != nil {
+		log.Printf("shop items error (member=%d): %v", member.Id, err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Ошибка загрузки магазина")})
+	}
+	return c.JSON(fiber.Map{"items": items})
+}
+
+// Buy POST /api/platform/shop/items/:id/buy
+func (h *ShopHandler) Buy(c *fiber.Ctx) error {
+	member, err := getMember(c)
+	if err != nil {
+		return err
+	}
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный ID")})
+	}
+	var req models.ShopPurchaseRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный запрос")})
+		}
+	}
+	order, err := h.svc.Purchase(member, id, req.Comment)
+	if err != nil {
+		return h.writeError(c, fmt.Sprintf("shop buy (item=%d, member=%d)", id, member.Id), err)
+	}
+	return c.Status(fiber.StatusCreated).JSON(order)
+}
+
+// MyOrders GET /api/platform/shop/orders
+func (h *ShopHandler) MyOrders(c *fiber.Ctx) error {
+	member, err := getMember(c)
+	if err != nil {
+		return err
+	}
+	orders, err := h.svc.MyOrders(member.Id)
+	if err != nil {
+		log.Printf("shop my orders error (member=%d): %v", member.Id, err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Ошибка загрузки заказов")})
+	}
+	return c.JSON(fiber.Map{"items": orders})
+}
+
+// CancelMyOrder POST /api/platform/shop/orders/:id/cancel
+func (h *ShopHandler) CancelMyOrder(c *fiber.Ctx) error {
+	member, err := getMember(c)
+	if err != nil {
+		return err
+	}
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный ID")})
+	}
+	order, err := h.svc.CancelMyOrder(member.Id, id)
+	if err != nil {
+		return h.writeError(c, fmt.Sprintf("shop cancel (order=%d, member=%d)", id, member.Id), err)
+	}
+	return c.JSON(order)
+}
+
+// AdminItems GET /api/admin/shop/items — весь каталог, включая снятое с продажи.
+func (h *ShopHandler) AdminItems(c *fiber.Ctx) error {
+	items, err := h.svc.AdminItems()
+	if err != nil {
+		log.Printf("shop admin items error: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Ошибка загрузки магазина")})
+	}
+	return c.JSON(fiber.Map{"items": items, "total": len(items)})
+}
+
+// CreateItem POST /api/admin/shop/items
+func (h *ShopHandler) CreateItem(c *fiber.Ctx) error {
+	var req models.ShopItemRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный запрос")})
+	}
+	item := req.ToItem()
+	if err := h.svc.CreateItem(item); err != nil {
+		return h.writeError(c, "shop create item", err)
+	}
+	go h.auditSvc.Log(getActorId(c), getActorName(c), getActorType(c), models.AuditActionCreate, "shop_item", item.Id, item.Title)
+	return c.Status(fiber.StatusCreated).JSON(item)
+}
+
+// UpdateItem PUT /api/admin/shop/items/:id
+func (h *ShopHandler) UpdateItem(c *fiber.Ctx) error {
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный ID")})
+	}
+	var req models.ShopItemRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный запрос")})
+	}
+	item, err := h.svc.UpdateItem(id, req.ToItem())
+	if err != nil {
+		return h.writeError(c, "shop update item", err)
+	}
+	go h.auditSvc.Log(getActorId(c), getActorName(c), getActorType(c), models.AuditActionUpdate, "shop_item", item.Id, item.Title)
+	return c.JSON(item)
+}
+
+// DeleteItem DELETE /api/admin/shop/items/:id
+func (h *ShopHandler) DeleteItem(c *fiber.Ctx) error {
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный ID")})
+	}
+	if err := h.svc.DeleteItem(id); err != nil {
+		return h.writeError(c, "shop delete item", err)
+	}
+	go h.auditSvc.Log(getActorId(c), getActorName(c), getActorType(c), models.AuditActionDelete, "shop_item", id, "")
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// AdminOrders GET /api/admin/shop/orders?status=pending&limit=20&offset=0
+func (h *ShopHandler) AdminOrders(c *fiber.Ctx) error {
+	limit, _ := strconv.Atoi(c.Query("limit", "20"))
+	offset, _ := strconv.Atoi(c.Query("offset", "0"))
+	items, total, err := h.svc.SearchOrders(c.Query("status"), limit, offset)
+	if err != nil {
+		log.Printf("shop admin orders error: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Ошибка загрузки заказов")})
+	}
+	return c.JSON(fiber.Map{"items": items, "total": total})
+}
+
+// SetOrderStatus PATCH /api/admin/shop/orders/:id — отправка, выдача или
+// отмена с возвратом баллов; покупатель получает уведомление.
+func (h *ShopHandler) SetOrderStatus(c *fiber.Ctx) error {
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный ID")})
+	}
+	var req models.ShopOrderStatusRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный запрос")})
+	}
+	order, err := h.svc.SetOrderStatus(id, req.Status, req.AdminNote)
+	if err != nil {
+		return h.writeError(c, fmt.Sprintf("shop order status (order=%d)", id), err)
+	}
+	go h.auditSvc.Log(getActorId(c), getActorName(c), getActorType(c), models.AuditActionUpdate, "shop_order", order.Id,
+		fmt.Sprintf("%s → %s", order.ItemTitle, order.Status))
+
+	body := order.ItemTitle
+	if order.Status == models.ShopOrderCancelled {
+		body = fmt.Sprintf("%s — %d баллов вернулись на счёт", order.ItemTitle, order.Price)
+	}
+	if order.AdminNote != "" {
+		body += "\n" + order.AdminNote
+	}
+	go CreateNotification(order.MemberId, "shop_order", shopOrderStatusTitles[order.Status], body)
+	return c.JSON(order)
+}
+
+// writeError: «не найдено» — 404, отказы магазина — 400, остальное — 500.
+func (h *ShopHandler) writeError(c *fiber.Ctx, op string, err error) error {
+	if errors.Is(err, service.ErrShopItemNotFound) || errors.Is(err, service.ErrShopOrderNotFound) {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": trErr(c, err)})
+	}
+	var shopErr *service.ShopError
+	if errors.Is(err, service.ErrShopItemHasOrders) || errors.As(err, &shopErr) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": trErr(c, err)})
+	}
+	log.Printf("%s error: %v", op, err)
+	return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Ошибка магазина")})
+}
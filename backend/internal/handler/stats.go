@@ -73,7 +73,7 @@ func (h *StatsHandler) GetChartStats(c *fiber.Ctx) error {
 		ORDER BY date_trunc('month', e.date)
 	`).Scan(&chartStats.EventAttendance).Error; err != nil {
 		log.Printf("Chart stats (event attendance) error: %v", err)
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Ошибка загрузки статистики"})
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Ошибка загрузки статистики")})
 	}
 
 	// Member growth by month (last 12 months) using created_at
@@ -94,7 +94,7 @@ func (h *StatsHandler) GetChartStats(c *fiber.Ctx) error {
 		ORDER BY d.month
 	`).Scan(&chartStats.MemberGrowth).Error; err != nil {
 		log.Printf("Chart stats (member growth) error: %v", err)
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Ошибка загрузки статистики"})
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Ошибка загрузки статистики")})
 	}
 
 	return c.JSON(chartStats)
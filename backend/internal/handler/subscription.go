@@ -79,7 +79,7 @@ func (h *SubscriptionHandler) GetStats(c *fiber.Ctx) error {
 func (h *SubscriptionHandler) PublicTiers(c *fiber.Ctx) error {
 	tiers, err := h.svc.GetPublicTiers()
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Не удалось получить тарифы"})
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Не удалось получить тарифы")})
 	}
 	return c.JSON(fiber.Map{"items": tiers})
 }
@@ -87,7 +87,7 @@ func (h *SubscriptionHandler) PublicTiers(c *fiber.Ctx) error {
 func (h *SubscriptionHandler) GetTiers(c *fiber.Ctx) error {
 	tiers, err := h.svc.GetAllTiers()
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Не удалось получить тиры"})
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Не удалось получить тиры")})
 	}
 
 	tierCounts, _ := h.svc.CountAllUsersByTier()
@@ -109,7 +109,7 @@ func (h *SubscriptionHandler) GetTiers(c *fiber.Ctx) error {
 func (h *SubscriptionHandler) GetChats(c *fiber.Ctx) error {
 	chats, err := h.svc.GetAllChats()
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Не удалось получить чаты"})
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Не удалось получить чаты")})
 	}
 
 	tm := h.tierMap()
@@ -167,7 +167,7 @@ func (h *SubscriptionHandler) GetUsers(c *fiber.Ctx) error {
 	total, _ := h.svc.CountAllUsers()
 	users, err := h.svc.GetPaginatedUsers(offset, limit)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Не удалось получить пользователей"})
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Не удалось получить пользователей")})
 	}
 
 	// Batch load tiers and access counts
@@ -215,12 +215,12 @@ func (h *SubscriptionHandler) GetUsers(c *fiber.Ctx) error {
 func (h *SubscriptionHandler) GetUser(c *fiber.Ctx) error {
 	userID, err := strconv.ParseInt(c.Params("id"), 10, 64)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Неверный ID"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный ID")})
 	}
 
 	user, err := h.svc.GetUser(userID)
 	if err != nil {
-		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Пользователь не найден"})
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": tr(c, "Пользователь не найден")})
 	}
 
 	// Load all tiers once for name resolution
@@ -286,7 +286,7 @@ func (h *SubscriptionHandler) GetUser(c *fiber.Ctx) error {
 func (h *SubscriptionHandler) SetOverride(c *fiber.Ctx) error {
 	userID, err := strconv.ParseInt(c.Params("id"), 10, 64)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Неверный ID"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный ID")})
 	}
 
 	var req struct {
@@ -297,16 +297,16 @@ func (h *SubscriptionHandler) SetOverride(c *fiber.Ctx) error {
 		Months int `json:"months"`
 	}
 	if err := c.BodyParser(&req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Неверный формат запроса"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный формат запроса")})
 	}
 
 	if req.Months < 0 || req.Months > 60 {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Срок должен быть от 0 до 60 месяцев"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Срок должен быть от 0 до 60 месяцев")})
 	}
 
 	tier, err := h.svc.GetTierBySlug(req.TierSlug)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Тир не найден"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Тир не найден")})
 	}
 
 	var expiresAt *time.Time
@@ -316,7 +316,7 @@ func (h *SubscriptionHandler) SetOverride(c *fiber.Ctx) error {
 	}
 
 	if err := h.svc.SetManualTierWithExpiry(userID, &tier.ID, expiresAt); err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Не удалось установить тир"})
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Не удалось установить тир")})
 	}
 
 	auditPayload := map[string]interface{}{
@@ -334,11 +334,11 @@ func (h *SubscriptionHandler) SetOverride(c *fiber.Ctx) error {
 func (h *SubscriptionHandler) ClearOverride(c *fiber.Ctx) error {
 	userID, err := strconv.ParseInt(c.Params("id"), 10, 64)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Неверный ID"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный ID")})
 	}
 
 	if err := h.svc.SetManualTier(userID, nil); err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Не удалось снять тир"})
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Не удалось снять тир")})
 	}
 
 	h.svc.AddAudit(userID, "manual_override", map[string]interface{}{
@@ -359,34 +359,34 @@ func (h *SubscriptionHandler) CreateChat(c *fiber.Ctx) error {
 		Emoji           *string `json:"emoji"`
 	}
 	if err := c.BodyParser(&req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Неверный формат запроса"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный формат запроса")})
 	}
 	if req.ID == 0 || req.Title == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "ID и название обязательны"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "ID и название обязательны")})
 	}
 	if req.ChatType == "" {
 		req.ChatType = "supergroup"
 	}
 
 	if err := h.svc.UpsertChat(req.ID, req.Title, req.ChatType); err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Не удалось создать чат"})
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Не удалось создать чат")})
 	}
 
 	if req.Category != nil || req.Emoji != nil {
 		if err := h.svc.UpdateChatMeta(req.ID, req.Category, req.Emoji); err != nil {
-			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Не удалось сохранить категорию"})
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Не удалось сохранить категорию")})
 		}
 	}
 
 	if req.AnchorForTierID != nil {
 		if err := h.svc.SetAnchor(req.ID, req.AnchorForTierID); err != nil {
-			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Не удалось установить anchor"})
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Не удалось установить anchor")})
 		}
 	}
 
 	if len(req.TierIDs) > 0 {
 		if err := h.svc.SetChatTiers(req.ID, req.TierIDs); err != nil {
-			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Не удалось привязать тиры"})
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Не удалось привязать тиры")})
 		}
 		// Новый чат → все его тиры «новые». Шлём одно событие с min level.
 		if level, ok := h.minTierLevel(req.TierIDs); ok {
@@ -420,12 +420,12 @@ func (h *SubscriptionHandler) minTierLevel(tierIDs []uint) (int, bool) {
 func (h *SubscriptionHandler) UpdateChat(c *fiber.Ctx) error {
 	chatID, err := strconv.ParseInt(c.Params("id"), 10, 64)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Неверный ID"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный ID")})
 	}
 
 	existing, err := h.svc.GetChat(chatID)
 	if err != nil {
-		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Чат не найден"})
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": tr(c, "Чат не найден")})
 	}
 
 	var req struct {
@@ -439,12 +439,12 @@ func (h *SubscriptionHandler) UpdateChat(c *fiber.Ctx) error {
 		Priority        *int    `json:"priority"`
 	}
 	if err := c.BodyParser(&req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Неверный формат запроса"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный формат запроса")})
 	}
 
 	if req.Title != nil && *req.Title != "" {
 		if err := h.svc.UpsertChat(chatID, *req.Title, existing.ChatType); err != nil {
-			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Не удалось обновить чат"})
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Не удалось обновить чат")})
 		}
 	}
 
@@ -456,17 +456,17 @@ func (h *SubscriptionHandler) UpdateChat(c *fiber.Ctx) error {
 			emoji = nil
 		}
 		if err := h.svc.UpdateChatMeta(chatID, cat, emoji); err != nil {
-			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Не удалось сохранить категорию"})
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Не удалось сохранить категорию")})
 		}
 	}
 
 	if req.ClearAnchor {
 		if err := h.svc.SetAnchor(chatID, nil); err != nil {
-			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Не удалось снять anchor"})
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Не удалось снять anchor")})
 		}
 	} else if req.AnchorForTierID != nil {
 		if err := h.svc.SetAnchor(chatID, req.AnchorForTierID); err != nil {
-			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Не удалось установить anchor"})
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Не удалось установить anchor")})
 		}
 	}
 
@@ -476,14 +476,14 @@ func (h *SubscriptionHandler) UpdateChat(c *fiber.Ctx) error {
 		// опубликовать рассылку только по реально добавленным тирам.
 		oldTierIDs, _ := h.svc.GetTierIDsForChat(chatID)
 		if err := h.svc.SetChatTiers(chatID, *req.TierIDs); err != nil {
-			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Не удалось обновить тиры"})
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Не удалось обновить тиры")})
 		}
 		addedTiers = diffTiers(*req.TierIDs, oldTierIDs)
 	}
 
 	if req.Priority != nil {
 		if err := h.svc.SetChatPriority(chatID, *req.Priority); err != nil {
-			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Не удалось сохранить приоритет"})
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Не удалось сохранить приоритет")})
 		}
 	}
 
@@ -517,11 +517,11 @@ func diffTiers(next, prev []uint) []uint {
 func (h *SubscriptionHandler) DeleteChat(c *fiber.Ctx) error {
 	chatID, err := strconv.ParseInt(c.Params("id"), 10, 64)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Неверный ID"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный ID")})
 	}
 
 	if err := h.svc.DeleteChat(chatID); err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Не удалось удалить чат"})
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Не удалось удалить чат")})
 	}
 
 	return c.JSON(fiber.Map{"success": true})
@@ -530,12 +530,12 @@ func (h *SubscriptionHandler) DeleteChat(c *fiber.Ctx) error {
 func (h *SubscriptionHandler) GetChatDetail(c *fiber.Ctx) error {
 	chatID, err := strconv.ParseInt(c.Params("id"), 10, 64)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Неверный ID"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный ID")})
 	}
 
 	chat, err := h.svc.GetChat(chatID)
 	if err != nil {
-		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Чат не найден"})
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": tr(c, "Чат не найден")})
 	}
 
 	tierIDs, _ := h.svc.GetTierIDsForChat(chatID)
@@ -559,13 +559,13 @@ func (h *SubscriptionHandler) GetChatDetail(c *fiber.Ctx) error {
 func (h *SubscriptionHandler) ResolveChat(c *fiber.Ctx) error {
 	chatID, err := strconv.ParseInt(c.Params("id"), 10, 64)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Неверный ID"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный ID")})
 	}
 
 	url := fmt.Sprintf("https://api.telegram.org/bot%s/getChat?chat_id=%d", config.CFG.TelegramToken, chatID)
 	resp, err := http.Get(url) //nolint:gosec
 	if err != nil {
-		return c.Status(fiber.StatusBadGateway).JSON(fiber.Map{"error": "Не удалось связаться с Telegram"})
+		return c.Status(fiber.StatusBadGateway).JSON(fiber.Map{"error": tr(c, "Не удалось связаться с Telegram")})
 	}
 	defer resp.Body.Close()
 
@@ -580,7 +580,7 @@ func (h *SubscriptionHandler) ResolveChat(c *fiber.Ctx) error {
 	if err := json.NewDecoder(resp.Body).Decode(&tgResp); err != nil || !tgResp.OK {
 		desc := tgResp.Description
 		if desc == "" {
-			desc = "Чат не найден или бот не является участником"
+			desc = tr(c, "Чат не найден или бот не является участником")
 		}
 		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": desc})
 	}
@@ -600,7 +600,7 @@ func (h *SubscriptionHandler) ResolveChat(c *fiber.Ctx) error {
 func (h *SubscriptionHandler) GetInternalUserSubscription(c *fiber.Ctx) error {
 	tgID, err := strconv.ParseInt(c.Params("tg_id"), 10, 64)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Неверный tg_id"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный tg_id")})
 	}
 
 	resp := fiber.Map{
@@ -646,10 +646,10 @@ func (h *SubscriptionHandler) PurchaseWithCredits(c *fiber.Ctx) error {
 		TierSlug string `json:"tier_slug"`
 	}
 	if err := c.BodyParser(&req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Неверный формат запроса"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный формат запроса")})
 	}
 	if req.TierSlug == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "tier_slug обязателен"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "tier_slug обязателен")})
 	}
 
 	member, err := getMember(c)
@@ -667,19 +667,19 @@ func (h *SubscriptionHandler) PurchaseWithCredits(c *fiber.Ctx) error {
 	result, err := h.svc.PurchaseTierWithCredits(member.Id, member.TelegramID, username, fullName, req.TierSlug)
 	if err != nil {
 		if errors.Is(err, repository.ErrInsufficientCredits) {
-			return c.Status(fiber.StatusPaymentRequired).JSON(fiber.Map{"error": "Недостаточно кредитов"})
+			return c.Status(fiber.StatusPaymentRequired).JSON(fiber.Map{"error": tr(c, "Недостаточно кредитов")})
 		}
 		if errors.Is(err, service.ErrTierNotPurchasable) {
-			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Этот тариф нельзя купить за кредиты"})
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Этот тариф нельзя купить за кредиты")})
 		}
 		if errors.Is(err, service.ErrBessrochnyGrantExists) {
-			return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": "У вас уже бессрочная подписка от администратора"})
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": tr(c, "У вас уже бессрочная подписка от администратора")})
 		}
 		if errors.Is(err, service.ErrTierDowngrade) {
-			return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": "Нельзя купить тариф ниже текущего"})
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": tr(c, "Нельзя купить тариф ниже текущего")})
 		}
 		log.Printf("PurchaseWithCredits error (member=%d, slug=%s): %v", member.Id, req.TierSlug, err)
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Не удалось купить подписку"})
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Не удалось купить подписку")})
 	}
 	return c.JSON(result)
 }
@@ -687,16 +687,16 @@ func (h *SubscriptionHandler) PurchaseWithCredits(c *fiber.Ctx) error {
 func (h *SubscriptionHandler) RevokeAccess(c *fiber.Ctx) error {
 	userID, err := strconv.ParseInt(c.Params("id"), 10, 64)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Неверный user ID"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный user ID")})
 	}
 
 	chatID, err := strconv.ParseInt(c.Params("chatId"), 10, 64)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Неверный chat ID"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный chat ID")})
 	}
 
 	if err := h.svc.RevokeAccess(userID, chatID); err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Не удалось отозвать доступ"})
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Не удалось отозвать доступ")})
 	}
 
 	h.svc.AddAudit(userID, "revoke", map[string]interface{}{
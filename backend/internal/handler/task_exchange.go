@@ -38,7 +38,7 @@ func (h *TaskExchangeHandler) Search(c *fiber.Ctx) error {
 
 	items, total, err := h.svc.Search(statusPtr, limit, offset)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Не удалось получить задания"})
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Не удалось получить задания")})
 	}
 
 	return c.JSON(fiber.Map{"items": items, "total": total})
@@ -47,12 +47,12 @@ func (h *TaskExchangeHandler) Search(c *fiber.Ctx) error {
 func (h *TaskExchangeHandler) GetById(c *fiber.Ctx) error {
 	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Неверный ID"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный ID")})
 	}
 
 	task, err := h.svc.GetById(id)
 	if err != nil {
-		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Задание не найдено"})
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": tr(c, "Задание не найдено")})
 	}
 
 	return c.JSON(task)
@@ -66,11 +66,11 @@ func (h *TaskExchangeHandler) Create(c *fiber.Ctx) error {
 
 	var req models.CreateTaskExchangeRequest
 	if err := c.BodyParser(&req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Неверный формат запроса"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный формат запроса")})
 	}
 
 	if req.Title == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Название обязательно"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Название обязательно")})
 	}
 
 	maxAssignees := req.MaxAssignees
@@ -87,7 +87,7 @@ func (h *TaskExchangeHandler) Create(c *fiber.Ctx) error {
 
 	created, err := h.svc.Create(task)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Не удалось создать задание"})
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Не удалось создать задание")})
 	}
 
 	BroadcastEvent("tasks")
@@ -101,7 +101,7 @@ func (h *TaskExchangeHandler) Update(c *fiber.Ctx) error {
 	}
 	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Неверный ID"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный ID")})
 	}
 
 	isAdmin := false
@@ -114,13 +114,13 @@ func (h *TaskExchangeHandler) Update(c *fiber.Ctx) error {
 
 	var req models.UpdateTaskExchangeRequest
 	if err := c.BodyParser(&req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Неверный формат запроса"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный формат запроса")})
 	}
 
 	task, err := h.svc.Update(id, member.Id, isAdmin, req)
 	if err != nil {
 		log.Printf("Task update error (task=%d, member=%d): %v", id, member.Id, err)
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Не удалось обновить задание"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Не удалось обновить задание")})
 	}
 
 	BroadcastEvent("tasks")
@@ -134,13 +134,13 @@ func (h *TaskExchangeHandler) Assign(c *fiber.Ctx) error {
 	}
 	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Неверный ID"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный ID")})
 	}
 
 	task, err := h.svc.Assign(id, member.Id)
 	if err != nil {
 		log.Printf("Task assign error (task=%d, member=%d): %v", id, member.Id, err)
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Не удалось назначить задание"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Не удалось назначить задание")})
 	}
 
 	BroadcastEvent("tasks")
@@ -154,13 +154,13 @@ func (h *TaskExchangeHandler) Unassign(c *fiber.Ctx) error {
 	}
 	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Неверный ID"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный ID")})
 	}
 
 	task, err := h.svc.Unassign(id, member.Id)
 	if err != nil {
 		log.Printf("Task unassign error (task=%d, member=%d): %v", id, member.Id, err)
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Не удалось отменить назначение"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Не удалось отменить назначение")})
 	}
 
 	return c.JSON(task)
@@ -173,12 +173,12 @@ func (h *TaskExchangeHandler) RemoveAssignee(c *fiber.Ctx) error {
 	}
 	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Неверный ID"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный ID")})
 	}
 
 	memberId, err := strconv.ParseInt(c.Params("memberId"), 10, 64)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Неверный ID участника"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный ID участника")})
 	}
 
 	isAdmin := false
@@ -192,7 +192,7 @@ func (h *TaskExchangeHandler) RemoveAssignee(c *fiber.Ctx) error {
 	task, err := h.svc.RemoveAssignee(id, memberId, member.Id, isAdmin)
 	if err != nil {
 		log.Printf("Task removeAssignee error (task=%d, assignee=%d, requester=%d): %v", id, memberId, member.Id, err)
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Не удалось удалить исполнителя"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Не удалось удалить исполнителя")})
 	}
 
 	return c.JSON(task)
@@ -205,7 +205,7 @@ func (h *TaskExchangeHandler) MarkDone(c *fiber.Ctx) error {
 	}
 	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Неверный ID"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный ID")})
 	}
 
 	isAdmin := false
@@ -219,7 +219,7 @@ func (h *TaskExchangeHandler) MarkDone(c *fiber.Ctx) error {
 	task, err := h.svc.MarkDone(id, member.Id, isAdmin)
 	if err != nil {
 		log.Printf("Task markDone error (task=%d, member=%d): %v", id, member.Id, err)
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Не удалось отметить задание как выполненное"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Не удалось отметить задание как выполненное")})
 	}
 
 	// Notify creator that task is done
@@ -237,13 +237,13 @@ func (h *TaskExchangeHandler) MarkDone(c *fiber.Ctx) error {
 func (h *TaskExchangeHandler) Approve(c *fiber.Ctx) error {
 	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Неверный ID"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный ID")})
 	}
 
 	task, err := h.svc.Approve(id)
 	if err != nil {
 		log.Printf("Task approve error (task=%d): %v", id, err)
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Не удалось одобрить задание"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Не удалось одобрить задание")})
 	}
 
 	// Award points
@@ -275,20 +275,20 @@ func (h *TaskExchangeHandler) Approve(c *fiber.Ctx) error {
 func (h *TaskExchangeHandler) Reject(c *fiber.Ctx) error {
 	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Неверный ID"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный ID")})
 	}
 
 	// Get task before rejection to know the assignees
 	taskBefore, err := h.svc.GetById(id)
 	if err != nil {
-		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Задание не найдено"})
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": tr(c, "Задание не найдено")})
 	}
 	assignees := taskBefore.Assignees
 
 	task, err := h.svc.Reject(id)
 	if err != nil {
 		log.Printf("Task reject error (task=%d): %v", id, err)
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Не удалось отклонить задание"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Не удалось отклонить задание")})
 	}
 
 	// Notify assignees about rejection
@@ -312,7 +312,7 @@ func (h *TaskExchangeHandler) Delete(c *fiber.Ctx) error {
 	}
 	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Неверный ID"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Неверный ID")})
 	}
 
 	// Check if admin
@@ -326,7 +326,7 @@ func (h *TaskExchangeHandler) Delete(c *fiber.Ctx) error {
 
 	if err := h.svc.Delete(id, member.Id, isAdmin); err != nil {
 		log.Printf("Task delete error (task=%d, member=%d): %v", id, member.Id, err)
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Не удалось удалить задание"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "Не удалось удалить задание")})
 	}
 
 	BroadcastEvent("tasks")
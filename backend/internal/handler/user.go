@@ -34,13 +34,13 @@ type TokenResponse struct {
 func (h *UserHandler) Login(c *fiber.Ctx) error {
 	req := new(LoginRequest)
 	if err := c.BodyParser(req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "неверный запрос"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "неверный запрос")})
 	}
 
 	token, err := h.svc.Login(req.Login, req.Password)
 	if err != nil {
 		log.Printf("login error (login=%s): %v", req.Login, err)
-		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Неверный логин или пароль"})
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": tr(c, "Неверный логин или пароль")})
 	}
 
 	return c.JSON(TokenResponse{Token: token})
@@ -49,13 +49,13 @@ func (h *UserHandler) Login(c *fiber.Ctx) error {
 func (h *UserHandler) RefreshToken(c *fiber.Ctx) error {
 	req := new(RefreshTokenRequest)
 	if err := c.BodyParser(req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "неверный запрос"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": tr(c, "неверный запрос")})
 	}
 
 	newToken, err := h.svc.RefreshToken(req.Token)
 	if err != nil {
 		log.Printf("refresh token error: %v", err)
-		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Недействительный токен"})
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": tr(c, "Недействительный токен")})
 	}
 
 	return c.JSON(TokenResponse{Token: newToken})
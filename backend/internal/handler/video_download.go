@@ -0,0 +1,37 @@
+package handler
+
+import (
+	"log"
+	"strconv"
+	"time"
+
+	"ithozyeva/internal/service"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type VideoDownloadHandler struct {
+	svc *service.VideoDownloadService
+}
+
+func NewVideoDownloadHandler() *VideoDownloadHandler {
+	return &VideoDownloadHandler{svc: service.NewVideoDownloadService()}
+}
+
+// Stats GET /api/admin/video-downloads/stats?days=7
+// Скачивания, пересылки из кэша, отказы и ошибки по источникам и дням.
+func (h *VideoDownloadHandler) Stats(c *fiber.Ctx) error {
+	days, err := strconv.Atoi(c.Query("days", "7"))
+	if err != nil || days <= 0 {
+		days = 7
+	}
+	if days > 90 {
+		days = 90
+	}
+	report, err := h.svc.Report(time.Now().AddDate(0, 0, -days))
+	if err != nil {
+		log.Printf("Video download stats error: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": tr(c, "Ошибка загрузки статистики скачиваний")})
+	}
+	return c.JSON(report)
+}
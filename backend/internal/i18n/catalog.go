@@ -0,0 +1,17 @@
+package i18n
+
+// catalogs — переводы с русского. Каталог разбит по файлам: сообщения API
+// (catalog_en_api.go) и бота (catalog_en_bot.go).
+var catalogs = map[Lang]map[string]string{
+	EN: merge(enAPI, enBot),
+}
+
+func merge(parts ...map[string]string) map[string]string {
+	out := make(map[string]string)
+	for _, p := range parts {
+		for k, v := range p {
+			out[k] = v
+		}
+	}
+	return out
+}
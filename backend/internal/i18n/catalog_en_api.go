@@ -0,0 +1,470 @@
+package i18n
+
+// enAPI — английские тексты ошибок API: хендлеры и сервисы.
+var enAPI = map[string]string{
+	"Ошибка поиска по базе знаний":                                      "Knowledge base search failed",
+	"Некорректный id":                                                   "Invalid id",
+	"Ошибка загрузки статьи":                                            "Failed to load the article",
+	"Неверный запрос":                                                   "Invalid request",
+	"Ошибка сохранения статьи":                                          "Failed to save the article",
+	"Подождите секунду между ставками":                                  "Wait a second between bets",
+	"Не удалось выполнить ставку":                                       "Failed to place the bet",
+	"Ошибка загрузки ленты":                                             "Failed to load the feed",
+	"Ошибка загрузки истории":                                           "Failed to load the history",
+	"Ошибка загрузки статистики":                                        "Failed to load statistics",
+	"Ошибка поиска ставок":                                              "Failed to search bets",
+	"Ошибка загрузки дайджестов":                                        "Failed to load digests",
+	"Ошибка загрузки дайджеста":                                         "Failed to load the digest",
+	"Дайджест не найден":                                                "Digest not found",
+	"Не удалось получить достижения":                                    "Failed to get achievements",
+	"Неверный ID":                                                       "Invalid ID",
+	"Ошибка загрузки уведомлений":                                       "Failed to load notifications",
+	"Ошибка получения количества уведомлений":                           "Failed to get the notification count",
+	"Ошибка обновления уведомления":                                     "Failed to update the notification",
+	"Уведомление не найдено":                                            "Notification not found",
+	"Ошибка обновления уведомлений":                                     "Failed to update notifications",
+	"Ошибка загрузки отзывов":                                           "Failed to load reviews",
+	"неверный запрос":                                                   "invalid request",
+	"Неверный логин или пароль":                                         "Invalid login or password",
+	"Недействительный токен":                                            "Invalid token",
+	"Ошибка массового удаления событий":                                 "Failed to bulk delete events",
+	"Ошибка массового удаления менторов":                                "Failed to bulk delete mentors",
+	"Ошибка массового удаления участников":                              "Failed to bulk delete members",
+	"Ошибка массового удаления отзывов":                                 "Failed to bulk delete reviews",
+	"Ошибка при одобрении отзывов":                                      "Failed to approve reviews",
+	"Ошибка загрузки челленджей":                                        "Failed to load challenges",
+	"Ошибка поиска":                                                     "Search failed",
+	"Ошибка создания":                                                   "Failed to create",
+	"Ошибка обновления":                                                 "Failed to update",
+	"Сущность не найдена":                                               "Entity not found",
+	"Невозможно установить ID для сущности":                             "Cannot set the entity ID",
+	"Ошибка удаления":                                                   "Failed to delete",
+	"Ошибка добавления отзыва":                                          "Failed to add the review",
+	"Ошибка создания отзыва":                                            "Failed to create the review",
+	"Отзыв не найден":                                                   "Review not found",
+	"Нет доступа":                                                       "Access denied",
+	"Ошибка обновления отзыва":                                          "Failed to update the review",
+	"Ошибка удаления отзыва":                                            "Failed to delete the review",
+	"Ошибка одобрения отзыва":                                           "Failed to approve the review",
+	"Ошибка поиска записей аудита":                                      "Failed to search audit records",
+	"Не удалось получить кредиты":                                       "Failed to get credits",
+	"Не удалось получить транзакции":                                    "Failed to get transactions",
+	"Неверный формат запроса":                                           "Invalid request format",
+	"memberId обязателен; amount должен быть != 0":                      "memberId is required; amount must be != 0",
+	"Списание невозможно: на балансе меньше указанной суммы":            "Cannot debit: the balance is less than the amount",
+	"Не удалось начислить кредиты":                                      "Failed to grant credits",
+	"Ошибка загрузки":                                                   "Failed to load",
+	"Не найдено":                                                        "Not found",
+	"Ошибка":                                                            "Error",
+	"code обязателен":                                                   "code is required",
+	"title обязателен":                                                  "title is required",
+	"неизвестный tier":                                                  "unknown tier",
+	"trigger_key обязателен":                                            "trigger_key is required",
+	"kind должен быть weekly или monthly":                               "kind must be weekly or monthly",
+	"metric_key обязателен":                                             "metric_key is required",
+	"Ошибка загрузки заданий":                                           "Failed to load tasks",
+	"Не удалось зафиксировать check-in":                                 "Failed to record the check-in",
+	"Ошибка загрузки стрика":                                            "Failed to load the streak",
+	"Не авторизован":                                                    "Not authorized",
+	"Ошибка загрузки настроек уведомлений":                              "Failed to load notification settings",
+	"Ошибка обновления настроек уведомлений":                            "Failed to update notification settings",
+	"Ошибка загрузки триггеров":                                         "Failed to load triggers",
+	"Ошибка проверки":                                                   "Check failed",
+	"Ошибка сохранения триггера":                                        "Failed to save the trigger",
+	"Файл обязателен":                                                   "File is required",
+	"Файл превышает 10MB":                                               "File exceeds 10MB",
+	"Допустимые форматы: pdf, doc, docx":                                "Allowed formats: pdf, doc, docx",
+	"Некорректный идентификатор":                                        "Invalid identifier",
+	"Некорректное тело запроса":                                         "Invalid request body",
+	"Некорректная категория":                                            "Invalid category",
+	"Не удалось получить материалы":                                     "Failed to get materials",
+	"Не удалось получить теги":                                          "Failed to get tags",
+	"Неверный формат данных":                                            "Invalid data format",
+	"Необходимо указать название и целевое количество":                  "Title and target count are required",
+	"Ошибка создания задания":                                           "Failed to create the task",
+	"Задание не найдено":                                                "Task not found",
+	"Ошибка обновления задания":                                         "Failed to update the task",
+	"Ошибка удаления задания":                                           "Failed to delete the task",
+	"Ментор не найден":                                                  "Mentor not found",
+	"Ошибка создания ментора":                                           "Failed to create the mentor",
+	"ID ментора не указан":                                              "Mentor ID is missing",
+	"Ошибка обновления ментора":                                         "Failed to update the mentor",
+	"Ошибка удаления ментора":                                           "Failed to delete the mentor",
+	"Ошибка загрузки менторов":                                          "Failed to load mentors",
+	"Ошибка получения данных ментора":                                   "Failed to get mentor data",
+	"Ошибка обновления информации ментора":                              "Failed to update mentor info",
+	"Ошибка обновления тегов ментора":                                   "Failed to update mentor tags",
+	"Ошибка обновления контактов ментора":                               "Failed to update mentor contacts",
+	"Ошибка обновления порядка ментора":                                 "Failed to update mentor order",
+	"Ошибка обновления услуг ментора":                                   "Failed to update mentor services",
+	"Ошибка загрузки статистики LLM":                                    "Failed to load LLM statistics",
+	"Ошибка загрузки статистики скачиваний":                             "Failed to load download statistics",
+	"Ошибка загрузки ежедневного розыгрыша":                             "Failed to load the daily raffle",
+	"Ошибка загрузки розыгрышей":                                        "Failed to load raffles",
+	"Ошибка создания розыгрыша":                                         "Failed to create the raffle",
+	"Ошибка удаления розыгрыша":                                         "Failed to delete the raffle",
+	"Не удалось отправить благодарность":                                "Failed to send kudos",
+	"Ошибка загрузки благодарностей":                                    "Failed to load kudos",
+	"Ошибка загрузки статистики профиля":                                "Failed to load profile statistics",
+	"Ошибка при загрузке реферальных ссылок":                            "Failed to load referral links",
+	"Ошибка создания реферальной ссылки":                                "Failed to create the referral link",
+	"Реферальная ссылка не найдена":                                     "Referral link not found",
+	"Нельзя изменять чужие реферальные ссылки":                          "You cannot modify other members' referral links",
+	"Ошибка обновления реферальной ссылки":                              "Failed to update the referral link",
+	"Нельзя конвертировать собственную ссылку":                          "You cannot convert your own link",
+	"Ошибка отслеживания конверсии":                                     "Failed to track the conversion",
+	"Ошибка поиска реферальных ссылок":                                  "Failed to search referral links",
+	"Ссылка не найдена":                                                 "Link not found",
+	"Ошибка удаления реферальной ссылки":                                "Failed to delete the referral link",
+	"Ошибка поиска дайджеста":                                           "Failed to search the digest",
+	"Не удалось получить баллы":                                         "Failed to get points",
+	"Не удалось получить рейтинг":                                       "Failed to get the leaderboard",
+	"memberId и amount обязательны и должны быть > 0":                   "memberId and amount are required and must be > 0",
+	"Не удалось начислить баллы":                                        "Failed to grant points",
+	"Не удалось отменить транзакцию":                                    "Failed to reverse the transaction",
+	"Ошибка поиска участников":                                          "Failed to search members",
+	"Участник не найден":                                                "Member not found",
+	"Ошибка создания участника":                                         "Failed to create the member",
+	"Участник не найден":                                               "Member not found",
+	"Никнейм уже занят":                                                 "Nickname is already taken",
+	"Ошибка обновления участника":                                       "Failed to update the member",
+	"Ошибка удаления участника":                                         "Failed to delete the member",
+	"Не удалось получить реф-кабинет":                                   "Failed to get the referral dashboard",
+	"Не удалось получить реферрера":                                     "Failed to get the referrer",
+	"Не удалось сохранить":                                              "Failed to save",
+	"Ошибка обновления профиля":                                         "Failed to update the profile",
+	"Файл превышает 5MB":                                                "File exceeds 5MB",
+	"Допустимые форматы: jpg, png, webp":                                "Allowed formats: jpg, png, webp",
+	"Ошибка открытия файла":                                             "Failed to open the file",
+	"Ошибка чтения файла":                                               "Failed to read the file",
+	"Недопустимый тип файла":                                            "Unsupported file type",
+	"Ошибка загрузки файла":                                             "Failed to upload the file",
+	"Ошибка сохранения аватара":                                         "Failed to save the avatar",
+	"Ошибка получения разрешений":                                       "Failed to get permissions",
+	"Не удалось получить тарифы":                                        "Failed to get plans",
+	"Не удалось получить тиры":                                          "Failed to get tiers",
+	"Не удалось получить чаты":                                          "Failed to get chats",
+	"Не удалось получить пользователей":                                 "Failed to get users",
+	"Пользователь не найден":                                            "User not found",
+	"Срок должен быть от 0 до 60 месяцев":                               "Duration must be between 0 and 60 months",
+	"Тир не найден":                                                     "Tier not found",
+	"Не удалось установить тир":                                         "Failed to set the tier",
+	"Не удалось снять тир":                                              "Failed to clear the tier",
+	"ID и название обязательны":                                         "ID and title are required",
+	"Не удалось создать чат":                                            "Failed to create the chat",
+	"Не удалось сохранить категорию":                                    "Failed to save the category",
+	"Не удалось установить anchor":                                      "Failed to set the anchor",
+	"Не удалось привязать тиры":                                         "Failed to link tiers",
+	"Чат не найден":                                                     "Chat not found",
+	"Не удалось обновить чат":                                           "Failed to update the chat",
+	"Не удалось снять anchor":                                           "Failed to clear the anchor",
+	"Не удалось обновить тиры":                                          "Failed to update tiers",
+	"Не удалось сохранить приоритет":                                    "Failed to save the priority",
+	"Не удалось удалить чат":                                            "Failed to delete the chat",
+	"Не удалось связаться с Telegram":                                   "Failed to reach Telegram",
+	"Чат не найден или бот не является участником":                      "Chat not found or the bot is not a member",
+	"Неверный tg_id":                                                    "Invalid tg_id",
+	"tier_slug обязателен":                                              "tier_slug is required",
+	"Недостаточно кредитов":                                             "Not enough credits",
+	"Этот тариф нельзя купить за кредиты":                               "This plan cannot be bought with credits",
+	"У вас уже бессрочная подписка от администратора":                   "You already have a permanent subscription from an administrator",
+	"Нельзя купить тариф ниже текущего":                                 "You cannot buy a plan below your current one",
+	"Не удалось купить подписку":                                        "Failed to buy the subscription",
+	"Неверный user ID":                                                  "Invalid user ID",
+	"Неверный chat ID":                                                  "Invalid chat ID",
+	"Не удалось отозвать доступ":                                        "Failed to revoke access",
+	"Ошибка поиска отзывов":                                             "Failed to search reviews",
+	"Ошибка загрузки чатов":                                             "Failed to load chats",
+	"Ошибка загрузки графика":                                           "Failed to load the chart",
+	"Ошибка загрузки топ пользователей":                                 "Failed to load top users",
+	"Необходимо указать user_id":                                        "user_id is required",
+	"Неверный user_id":                                                  "Invalid user_id",
+	"Ошибка загрузки статистики пользователя":                           "Failed to load user statistics",
+	"Ошибка экспорта":                                                   "Export failed",
+	"Не удалось получить задания":                                       "Failed to get tasks",
+	"Название обязательно":                                              "Title is required",
+	"Не удалось создать задание":                                        "Failed to create the task",
+	"Не удалось обновить задание":                                       "Failed to update the task",
+	"Не удалось назначить задание":                                      "Failed to assign the task",
+	"Не удалось отменить назначение":                                    "Failed to cancel the assignment",
+	"Неверный ID участника":                                             "Invalid member ID",
+	"Не удалось удалить исполнителя":                                    "Failed to remove the assignee",
+	"Не удалось отметить задание как выполненное":                       "Failed to mark the task as done",
+	"Не удалось одобрить задание":                                       "Failed to approve the task",
+	"Не удалось отклонить задание":                                      "Failed to reject the task",
+	"Не удалось удалить задание":                                        "Failed to delete the task",
+	"Ошибка поиска событий":                                             "Failed to search events",
+	"Ошибка загрузки событий":                                           "Failed to load events",
+	"Достигнут лимит участников":                                        "Participant limit reached",
+	"Ошибка регистрации на событие":                                     "Failed to register for the event",
+	"Ошибка отмены регистрации":                                         "Failed to cancel the registration",
+	"Событие не найдено":                                                "Event not found",
+	"Ошибка обработки тегов":                                            "Failed to process tags",
+	"Ошибка создания события":                                           "Failed to create the event",
+	"Ошибка обновления события":                                         "Failed to update the event",
+	"Ошибка удаления события":                                           "Failed to delete the event",
+	"Не удалось получить объявления":                                    "Failed to get listings",
+	"Объявление не найдено":                                             "Listing not found",
+	"Не удалось открыть файл":                                           "Failed to open the file",
+	"Не удалось прочитать файл":                                         "Failed to read the file",
+	"Не удалось создать объявление":                                     "Failed to create the listing",
+	"Не удалось обновить объявление":                                    "Failed to update the listing",
+	"Не удалось оформить заявку на покупку":                             "Failed to place the purchase request",
+	"Не удалось отменить покупку":                                       "Failed to cancel the purchase",
+	"Не удалось отметить как проданное":                                 "Failed to mark as sold",
+	"Не удалось удалить объявление":                                     "Failed to delete the listing",
+	"статья не найдена":                                                 "article not found",
+	"заголовок — от %d до %d символов":                                  "title must be %d to %d characters",
+	"ответ не может быть пустым":                                        "answer cannot be empty",
+	"ответ длиннее %d символов":                                         "answer is longer than %d characters",
+	"выберите хотя бы один хайлайт":                                     "select at least one highlight",
+	"не больше %d хайлайтов в статье":                                   "no more than %d highlights per article",
+	"часть хайлайтов не найдена":                                        "some highlights were not found",
+	"часть тегов не найдена":                                            "some tags were not found",
+	"минимальная ставка: 10 баллов":                                     "minimum bet: 10 points",
+	"максимальная ставка: 1000 баллов":                                  "maximum bet: 1000 points",
+	"выберите heads или tails":                                          "choose heads or tails",
+	"ошибка генерации":                                                  "generation error",
+	"ошибка при размещении ставки":                                      "failed to place the bet",
+	"цель должна быть от 2 до 98":                                       "target must be between 2 and 98",
+	"выберите over или under":                                           "choose over or under",
+	"можно отправить не более одного отзыва в сутки":                    "you can leave at most one review per day",
+	"комментарий слишком длинный (макс. %d символов)":                   "comment is too long (max %d characters)",
+	"пользователь не найден":                                            "user not found",
+	"неверный пароль":                                                   "wrong password",
+	"не удалось сгенерировать токен":                                    "failed to generate a token",
+	"неверный метод подписи":                                            "unexpected signing method",
+	"недействительный токен":                                            "invalid token",
+	"недействительные данные токена":                                    "invalid token data",
+	"недействительная дата истечения токена":                            "invalid token expiration date",
+	"токен слишком старый для обновления":                               "token is too old to refresh",
+	"недействительный логин в токене":                                   "invalid login in token",
+	"не удалось сгенерировать новый токен":                              "failed to generate a new token",
+	"у пользователя нет telegram_id":                                    "user has no telegram_id",
+	"срок новичка — от 0 (выключено) до %d дней":                        "newcomer period must be 0 (off) to %d days",
+	"rate limit — от 0 (выключено) до %d сообщений":                     "rate limit must be 0 (off) to %d messages",
+	"окно rate limit — от 5s до 1h":                                     "rate limit window must be 5s to 1h",
+	"уровень тира не может быть отрицательным":                          "tier level cannot be negative",
+	"daily-раффл недоступен":                                            "daily raffle is unavailable",
+	"неверный формат длительности: %q":                                  "invalid duration format: %q",
+	"неверное число: %q":                                                "invalid number: %q",
+	"неизвестная единица %q":                                            "unknown unit %q",
+	"неверное значение голоса":                                          "invalid vote value",
+	"voteban: на этого пользователя уже идёт голосование":               "voteban: a vote on this user is already running",
+	"voteban: голосование закрыто":                                      "voteban: voting is closed",
+	"voteban: цель голосования не может голосовать":                     "voteban: the target of the vote cannot vote",
+	"raid: в чате уже включён режим рейда":                              "raid: raid mode is already on in this chat",
+	"триггер не найден":                                                 "trigger not found",
+	"материал не найден":                                                "material not found",
+	"недостаточно прав":                                                 "insufficient permissions",
+	"длина названия должна быть от %d до %d символов":                   "title must be %d to %d characters long",
+	"длина описания должна быть от %d до %d символов":                   "description must be %d to %d characters long",
+	"некорректный тип контента":                                         "invalid content type",
+	"некорректная категория материала":                                  "invalid material category",
+	"содержимое промта обязательно":                                     "prompt content is required",
+	"содержимое промта не должно превышать %d символов":                 "prompt content must not exceed %d characters",
+	"ссылка обязательна":                                                "link is required",
+	"длина ссылки не должна превышать %d символов":                      "link must not exceed %d characters",
+	"ссылка должна начинаться с http:// или https://":                   "link must start with http:// or https://",
+	"конфиг агента обязателен":                                          "agent config is required",
+	"конфиг агента не должен превышать %d символов":                     "agent config must not exceed %d characters",
+	"длина тега не должна превышать %d символов":                        "tag must not exceed %d characters",
+	"в пуле меньше %d активных задач":                                   "the pool has fewer than %d active tasks",
+	"за один запрос нельзя купить больше %d билетов":                    "you cannot buy more than %d tickets per request",
+	"розыгрыш не найден":                                                "raffle not found",
+	"розыгрыш завершён":                                                 "raffle is finished",
+	"этот розыгрыш не поддерживает покупку билетов":                     "this raffle does not sell tickets",
+	"превышен лимит билетов":                                            "ticket limit exceeded",
+	"недостаточно баллов (нужно %d, доступно %d)":                       "not enough points (need %d, available %d)",
+	"нельзя отправить благодарность самому себе":                        "you cannot thank yourself",
+	"сообщение не может быть пустым":                                    "message cannot be empty",
+	"можно отправить не более 3 благодарностей в день":                  "you can send at most 3 kudos per day",
+	"комментарий не найден":                                             "comment not found",
+	"сущность не найдена":                                               "entity not found",
+	"неизвестный тип сущности: %s":                                      "unknown entity type: %s",
+	"неизвестный тип сущности":                                          "unknown entity type",
+	"длина комментария должна быть от %d до %d символов":                "comment must be %d to %d characters long",
+	"язык не поддерживается":                                            "language is not supported",
+	"voteban: голосующий не проходит по стажу":                          "voteban: the voter has not been in the chat long enough",
+	"окно голосования — от 1m до 2h":                                    "voting window must be 1m to 2h",
+	"санкция voteban — от 1m до 30d":                                    "voteban sanction must be 1m to 30d",
+	"мут по умолчанию — от 0 (бессрочно) до 30d":                        "default mute must be 0 (permanent) to 30d",
+	"максимальный период чистки — от 1m до 7d":                          "maximum cleanup period must be 1m to 7d",
+	"период чистки по умолчанию — от 1m до максимального":               "default cleanup period must be 1m to the maximum",
+	"нужны chat_id и user_id":                                           "chat_id and user_id are required",
+	"выберите хотя бы одно полномочие: mute, ban, cleanup":              "select at least one power: mute, ban, cleanup",
+	"неизвестное полномочие %q (mute, ban, cleanup, all)":               "unknown power %q (mute, ban, cleanup, all)",
+	"укажите полномочия: mute, ban, cleanup или all":                    "specify powers: mute, ban, cleanup or all",
+	"нет доступа к этому чату":                                          "no access to this chat",
+	"запрос короче %d символов":                                         "query is shorter than %d characters",
+	"запрос длиннее %d символов":                                        "query is longer than %d characters",
+	"дата from — в формате ГГГГ-ММ-ДД":                                  "from date must be YYYY-MM-DD",
+	"дата to — в формате ГГГГ-ММ-ДД":                                    "to date must be YYYY-MM-DD",
+	"дата from позже to":                                                "from date is after to",
+	"задание не найдено":                                                "task not found",
+	"только автор или админ может редактировать задание":                "only the author or an admin can edit the task",
+	"нельзя редактировать задание в этом статусе":                       "the task cannot be edited in this status",
+	"не удалось проверить количество исполнителей":                      "failed to check the number of assignees",
+	"нельзя уменьшить лимит ниже текущего числа исполнителей":           "cannot lower the limit below the current number of assignees",
+	"не удалось обновить задание":                                       "failed to update the task",
+	"нельзя взять своё задание":                                         "you cannot take your own task",
+	"задание недоступно для взятия":                                     "the task is not available",
+	"не удалось проверить назначение":                                   "failed to check the assignment",
+	"вы уже являетесь исполнителем":                                     "you are already an assignee",
+	"все слоты исполнителей заняты":                                     "all assignee slots are taken",
+	"не удалось взять задание":                                          "failed to take the task",
+	"не удалось обновить статус задания":                                "failed to update the task status",
+	"вы не являетесь исполнителем":                                      "you are not an assignee",
+	"не удалось отказаться от задания":                                  "failed to drop the task",
+	"только автор или админ может удалять исполнителей":                 "only the author or an admin can remove assignees",
+	"пользователь не является исполнителем":                             "the user is not an assignee",
+	"не удалось удалить исполнителя":                                    "failed to remove the assignee",
+	"только автор или админ может отметить выполнение":                  "only the author or an admin can mark the task as done",
+	"не удалось отметить задание выполненным":                           "failed to mark the task as done",
+	"задание не в работе":                                               "the task is not in progress",
+	"не удалось одобрить задание":                                       "failed to approve the task",
+	"задание не на проверке":                                            "the task is not under review",
+	"не удалось отклонить задание":                                      "failed to reject the task",
+	"только автор может удалить задание":                                "only the author can delete the task",
+	"можно удалить только открытые задания":                             "only open tasks can be deleted",
+	"достигнут лимит участников":                                        "participant limit reached",
+	"название обязательно":                                              "title is required",
+	"объявление не найдено":                                             "listing not found",
+	"только автор может редактировать объявление":                       "only the author can edit the listing",
+	"нельзя купить своё объявление":                                     "you cannot buy your own listing",
+	"не удалось забронировать":                                          "failed to reserve",
+	"объявление недоступно для покупки":                                 "the listing is not available for purchase",
+	"нет прав для отмены заявки":                                        "no permission to cancel the request",
+	"не удалось отменить бронь":                                         "failed to cancel the reservation",
+	"объявление не забронировано":                                       "the listing is not reserved",
+	"только продавец может подтвердить продажу":                         "only the seller can confirm the sale",
+	"не удалось подтвердить продажу":                                    "failed to confirm the sale",
+	"только автор может удалить объявление":                             "only the author can delete the listing",
+	"можно удалить только активные объявления":                          "only active listings can be deleted",
+	"banlist: список пуст":                                              "banlist: the list is empty",
+	"banlist: неизвестный формат %q (json, csv)":                        "banlist: unknown format %q (json, csv)",
+	"banlist: запись %d: некорректный user_id %d":                       "banlist: record %d: invalid user_id %d",
+	"banlist: больше %d записей":                                        "banlist: more than %d records",
+	"banlist: неподдерживаемая версия формата %d":                       "banlist: unsupported format version %d",
+	"banlist: csv: нет колонки user_id":                                 "banlist: csv: no user_id column",
+	"banlist: csv строка %d: некорректный user_id":                      "banlist: csv line %d: invalid user_id",
+	"banlist: fetch: ответ больше %d байт":                              "banlist: fetch: response larger than %d bytes",
+	"некорректная регулярка: %v":                                        "invalid regex: %v",
+	"во фразе %q нет значимых слов":                                     "phrase %q has no significant words",
+	"укажите хотя бы одну фразу":                                        "specify at least one phrase",
+	"тип срабатывания — keywords, regex или similarity":                 "trigger type must be keywords, regex or similarity",
+	"название — от 1 до %d символов":                                    "name must be 1 to %d characters",
+	"шаблон срабатывания — от 1 до %d символов":                         "trigger pattern must be 1 to %d characters",
+	"ответ — от 1 до %d символов":                                       "response must be 1 to %d characters",
+	"кулдаун — от 0 до %d секунд":                                       "cooldown must be 0 to %d seconds",
+	"Никнейм должен быть от 1 до 32 символов":                           "Nickname must be 1 to 32 characters",
+	"Никнейм может содержать только латиницу, цифры и _":                "Nickname may contain only Latin letters, digits and _",
+	"Имя не должно превышать 64 символа":                                "First name must not exceed 64 characters",
+	"Фамилия не должна превышать 64 символа":                            "Last name must not exceed 64 characters",
+	"Био не должно превышать 500 символов":                              "Bio must not exceed 500 characters",
+	"Грейд не должен превышать 100 символов":                            "Grade must not exceed 100 characters",
+	"Компания не должна превышать 100 символов":                         "Company must not exceed 100 characters",
+	"Дата рождения слишком давняя":                                      "Date of birth is too far in the past",
+	"Дата рождения не может быть в будущем":                             "Date of birth cannot be in the future",
+	"недостаточно баллов":                                               "not enough points",
+	"ID ментора не может быть 0":                                        "Mentor ID cannot be 0",
+	"requests должен быть срезом":                                       "requests must be a slice",
+	"неизвестный тип таблицы":                                           "unknown table type",
+	"Ошибка загрузки магазина":                                          "Failed to load the shop",
+	"Ошибка загрузки заказов":                                           "Failed to load orders",
+	"Ошибка магазина":                                                   "Shop error",
+	"товар не найден":                                                   "item not found",
+	"заказ не найден":                                                   "order not found",
+	"по товару уже есть заказы — снимите его с продажи":                 "the item already has orders — take it off sale instead",
+	"неизвестный вид награды: %s":                                       "unknown reward kind: %s",
+	"укажите название":                                                  "enter a title",
+	"название длиннее %d символов":                                      "title is longer than %d characters",
+	"цена должна быть больше нуля":                                      "price must be greater than zero",
+	"остаток не может быть отрицательным":                               "stock cannot be negative",
+	"лимит на участника не может быть отрицательным":                    "per-member limit cannot be negative",
+	"в позиции не больше %d заморозок":                                  "an item can hold at most %d freezes",
+	"комментарий длиннее %d символов":                                   "comment is longer than %d characters",
+	"товар закончился":                                                  "the item is sold out",
+	"лимит покупок этого товара — %d в одни руки":                       "this item is limited to %d per member",
+	"заказ уже отправлен — отменить его может только админ":             "the order has already been shipped — only an admin can cancel it",
+	"нельзя перевести заказ из «%s» в «%s»":                             "cannot move the order from “%s” to “%s”",
+	"неизвестный период рейтинга":                                       "unknown leaderboard period",
+	"Не удалось получить флаги":                                         "Failed to load flags",
+	"Ошибка обработки флага":                                            "Failed to process the flag",
+	"флаг не найден":                                                    "flag not found",
+	"укажите причину списания":                                          "enter a reason for the clawback",
+	"выберите транзакции для списания":                                  "select transactions to claw back",
+	"флаг уже закрыт":                                                   "the flag is already resolved",
+	"транзакция #%d не найдена":                                         "transaction #%d not found",
+	"транзакция #%d уже отменена":                                       "transaction #%d has already been reversed",
+	"транзакция #%d не относится к участникам флага":                    "transaction #%d does not belong to the flagged members",
+	"списать можно только начисление (#%d)":                             "only credits can be clawed back (#%d)",
+	"Не удалось сверить балансы":                                        "Failed to reconcile balances",
+	"транзакция не найдена":                                             "transaction not found",
+	"транзакция уже отменена":                                           "the transaction has already been reversed",
+	"сторно нельзя отменить":                                            "a reversal cannot be reversed",
+	"укажите причину отмены":                                            "specify the reversal reason",
+	"Ошибка загрузки seed'ов":                                           "Failed to load seeds",
+	"Не удалось сменить seed":                                           "Failed to rotate the seed",
+	"Не удалось проверить ставку":                                       "Failed to verify the bet",
+	"ставка не найдена":                                                 "bet not found",
+	"ставка сделана до provably fair — проверить её нельзя":             "the bet was placed before provably fair and cannot be verified",
+	"серверный seed ещё активен — смените пару, чтобы проверить ставку": "the server seed is still active — rotate it to verify the bet",
+	"клиентский seed: 1–64 символа из латиницы, цифр, «-» и «_»":        "client seed: 1–64 Latin letters, digits, \"-\" or \"_\"",
+	"неизвестная игра %s":                                               "unknown game %s",
+	"неверная ставка на кости: %s":                                      "invalid dice bet: %s",
+
+	// Лимиты мини-игр
+	"дневной лимит проигрыша — от %d до %d баллов":                    "daily loss limit must be between %d and %d points",
+	"дневной лимит ставок — от %d до %d":                              "daily bet limit must be between %d and %d",
+	"пауза включается после серии от %d до %d проигрышей":             "cooldown must start after a streak of %d to %d losses",
+	"длительность паузы — от %d до %d минут":                          "cooldown length must be between %d and %d minutes",
+	"лимиты не могут быть отрицательными":                             "limits cannot be negative",
+	"пауза включается после серии минимум из 2 проигрышей":            "cooldown must start after a streak of at least 2 losses",
+	"задержка ослабления лимитов — не больше %d часов":                "limit increase delay cannot exceed %d hours",
+	"укажите длительность паузы":                                      "specify the cooldown length",
+	"самоисключение — от 1 до %d дней":                                "self-exclusion must be between 1 and %d days",
+	"самоисключение уже действует до %s МСК — сократить его нельзя":   "self-exclusion is already active until %s MSK and cannot be shortened",
+	"мини-игры закрыты самоисключением до %s МСК":                     "mini-games are closed by self-exclusion until %s MSK",
+	"дневной лимит ставок (%d) исчерпан, продолжить можно завтра":     "daily bet limit (%d) reached, you can continue tomorrow",
+	"ставка превышает дневной лимит проигрыша %d баллов: осталось %d": "bet exceeds the daily loss limit of %d points: %d left",
+	"пауза после %d проигрышей подряд — до %s МСК":                    "cooldown after %d losses in a row until %s MSK",
+	"Ошибка загрузки лимитов":                                         "Failed to load limits",
+	"Не удалось сохранить лимиты":                                     "Failed to save limits",
+
+	// Crash и джекпот
+	"приём ставок на раунд закрыт — дождитесь следующего": "betting for this round is closed — wait for the next one",
+	"вы уже сделали ставку в этом раунде":                 "you have already placed a bet in this round",
+	"раунд ещё не начался или уже завершён":               "the round has not started yet or is already over",
+	"у вас нет ставки в этом раунде":                      "you have no bet in this round",
+	"ставка уже выведена":                                 "the bet has already been cashed out",
+	"не успели — раунд уже разбился":                      "too late — the round has already crashed",
+	"автовывод — от 1.01 до 1000":                         "auto cash-out must be between 1.01 and 1000",
+	"раунд не найден":                                     "round not found",
+	"Ошибка загрузки раунда":                              "Failed to load the round",
+	"Не удалось вывести ставку":                           "Failed to cash out",
+	"Ошибка загрузки джекпота":                            "Failed to load the jackpot",
+	"Ошибка проверки розыгрыша":                           "Failed to verify the raffle draw",
+	"Ошибка загрузки победителей":                         "Failed to load winners",
+	"Ошибка обновления выдачи приза":                      "Failed to update prize fulfillment",
+	"розыгрыш ещё не проведён":                            "the raffle has not been drawn yet",
+	"победитель не найден":                                "winner not found",
+	"у приза должно быть название":                        "a prize must have a title",
+	"укажите приз":                                        "specify a prize",
+	"не больше %d призовых мест":                          "no more than %d prize tiers",
+	"не больше %d победителей в розыгрыше":                "no more than %d winners per raffle",
+	"неизвестный статус выдачи: %s":                       "unknown fulfillment status: %s",
+	"Формат должен быть csv или json":                     "Format must be csv or json",
+	"Не удалось получить выписку":                         "Failed to get statement",
+	"неизвестный журнал: %s":                              "unknown ledger: %s",
+	"неверная дата начала: %s":                            "invalid start date: %s",
+	"неверная дата конца: %s":                             "invalid end date: %s",
+	"дата начала позже даты конца":                        "start date is after end date",
+	"неверная причина: %s":                                "invalid reason: %s",
+	"не больше %d причин в фильтре":                       "no more than %d reasons per filter",
+	"неверный месяц выписки":                              "invalid statement month",
+	"можно купить от 1 до %d заморозок":                   "you can buy 1 to %d freezes",
+	"можно держать не больше %d купленных заморозок":      "you can hold at most %d purchased freezes",
+	"восстанавливать нечего: стрик не обрывался или прошло больше 48 часов": "nothing to repair: the streak is intact or more than 48 hours have passed",
+	"Ошибка стрика": "Streak error",
+}
@@ -0,0 +1,324 @@
+package i18n
+
+// enBot — английские тексты бота.
+var enBot = map[string]string{
+	"💡 Похоже, на это уже отвечали — статья в базе знаний: <b>%s</b>": "💡 Looks like this was answered before — knowledge base article: <b>%s</b>",
+	"Режим рейда уже включён.":                                        "Raid mode is already on.",
+	"Не удалось включить режим рейда.":                                "Failed to turn on raid mode.",
+	"Ошибка при поиске рейда.":                                        "Failed to look up the raid.",
+	"Нет рейда, по которому нужно решение.":                           "There is no raid waiting for a decision.",
+	"Использование: /raid [on|off|rollback]":                          "Usage: /raid [on|off|rollback]",
+	"Режим рейда выключен.":                                           "Raid mode is off.",
+	"Режим рейда включён (ещё %s). Вступивших в окно: %d. /raid rollback — забанить всех, /raid off — снять ограничения.": "Raid mode is on (%s left). Joined during the window: %d. /raid rollback — ban them all, /raid off — lift restrictions.",
+	"Режим рейда истёк, но %d вступивших ещё ограничены. /raid rollback — забанить всех, /raid off — снять ограничения.":  "Raid mode has expired, but %d newcomers are still restricted. /raid rollback — ban them all, /raid off — lift restrictions.",
+	"Рейд не найден.":                                        "Raid not found.",
+	"Только для модераторов.":                                "Moderators only.",
+	"По этому рейду уже принято решение.":                    "A decision on this raid has already been made.",
+	"Баню всех вступивших…":                                  "Banning everyone who joined…",
+	"Снимаю ограничения…":                                    "Lifting restrictions…",
+	"02.01.2006 в 15:04":                                     "02.01.2006 at 15:04",
+	"Открыть платформу":                                      "Open the platform",
+	"Открыть меню бота":                                      "Open the bot menu",
+	"Саммари чата (day/week/3d/число/даты, ответом — ветка)": "Chat summary (day/week/3d/number/dates, as a reply — the thread)",
+	"Кто этот участник":                                      "Who is this member",
+	"Язык бота":                                              "Bot language",
+	"Помощь":                                                 "Help",
+	"Вы не зарегистрированы на платформе. Используйте /start для авторизации.": "You are not registered on the platform. Use /start to sign in.",
+	"Ошибка при получении баллов.":                                             "Failed to get points.",
+	"Ваш баланс: %s":                "Your balance: %s",
+	"Ближайших событий не найдено.": "No upcoming events.",
+	"<b>Ближайшие события:</b>":     "<b>Upcoming events:</b>",
+	"Подписка, чаты, баллы, события, связь с админом — всё через /start с кнопками.\n\nВспомогательное в группах:\n/summarize [day|week|3d|N|2026-10-01..2026-10-07] — AI-саммари чата или темы; ответом на сообщение — его ветки (дневной лимит по тиру подписки, повтор — из кэша)\n/whois — кто участник (reply или /whois @username)\n/voteban @username — голосование за кик из чата на час (одно голосование на чат одновременно; порог 15% активных за 7 дней, clamp 3-10; симметрия за/против; голоса взвешены по репутации 0.5–2; стаж от 3 дней; участников с высокой репутацией не выносят; cooldown 5 мин в чате и 30 мин на инициатора)\n\nМодерация (админам чата и платформы; волонтёрам из /modsettings — по выданным полномочиям):\n/ban [duration] — бан в этом чате (reply). Пример: /ban 1h, /ban 1d. Без аргумента — навсегда\n/unban — разбан (reply, /unban @user или /unban <id>)\n/mute [duration] — мут (reply). Пример: /mute 30m\n/cleanup [period] — удалить сообщения юзера в этом чате за период (reply; период по умолчанию — из /modsettings, изначально 24h)\n/raid [on|off|rollback] — режим рейда: включается сам при 10+ вступлениях за минуту (новички ограничены, инвайты закрыты); rollback — забанить всех вступивших в окно, off — снять ограничения\n/modsettings — настройки модерации чата (окно и санкция voteban, мут и период /cleanup по умолчанию) и модераторы-волонтёры с полномочиями mute/ban/cleanup\n/chatpolicy — ограничения чата: новичкам первые N дней без ссылок/медиа/форвардов, rate limit сообщений, освобождение подписчиков с тиром\n/language [ru|en] — язык бота": "Subscription, chats, points, events, contacting an admin — all via /start with buttons.\n\nHelpers in groups:\n/summarize [day|week|3d|N|2026-10-01..2026-10-07] — AI summary of the chat or topic; as a reply to a message — of its thread (daily limit by subscription tier, repeats come from cache)\n/whois — who the member is (reply or /whois @username)\n/voteban @username — vote to kick from the chat for an hour (one vote per chat at a time; threshold 15% of members active in 7 days, clamp 3-10; for/against symmetry; votes weighted by reputation 0.5–2; at least 3 days in the chat; members with high reputation cannot be voted out; cooldown 5 min per chat and 30 min per initiator)\n\nModeration (for chat and platform admins; for volunteers from /modsettings — according to granted powers):\n/ban [duration] — ban in this chat (reply). Example: /ban 1h, /ban 1d. No argument — forever\n/unban — unban (reply, /unban @user or /unban <id>)\n/mute [duration] — mute (reply). Example: /mute 30m\n/cleanup [period] — delete the user's messages in this chat for a period (reply; default period comes from /modsettings, initially 24h)\n/raid [on|off|rollback] — raid mode: turns on by itself at 10+ joins per minute (newcomers restricted, invites closed); rollback — ban everyone who joined during the window, off — lift restrictions\n/modsettings — chat moderation settings (voteban window and sanction, default mute and /cleanup period) and volunteer moderators with mute/ban/cleanup powers\n/chatpolicy — chat restrictions: no links/media/forwards for newcomers during the first N days, message rate limit, exemption for subscribers by tier\n/language [ru|en] — bot language",
+	"Админ-команды подписок:\n/subtiers - Список тиров\n/subchats - Зарегистрированные чаты\n/subaddchat <chat_id> <tier_slug> [anchor] - Добавить чат\n/subsetanchor <chat_id> <tier_slug|clear> - Установить anchor\n/subremovechat <chat_id> - Удалить чат\n/subusers [page] - Список пользователей\n/subuserinfo <user_id> - Инфо о пользователе\n/suboverride <user_id> <tier_slug|clear> - Ручной тир\n/subcheckall - Проверить всех\n/submembersweep - Backfill реального членства (~10 мин)\n/subkickdry - Dry-run кика: показать, кого удалили бы, без действий\n/substats - Статистика\n/subpin <anchor_chat_id> - Запостить и закрепить приветствие в anchor-чате": "Subscription admin commands:\n/subtiers - List tiers\n/subchats - Registered chats\n/subaddchat <chat_id> <tier_slug> [anchor] - Add a chat\n/subsetanchor <chat_id> <tier_slug|clear> - Set the anchor\n/subremovechat <chat_id> - Remove a chat\n/subusers [page] - List users\n/subuserinfo <user_id> - User info\n/suboverride <user_id> <tier_slug|clear> - Manual tier\n/subcheckall - Check everyone\n/submembersweep - Backfill actual membership (~10 min)\n/subkickdry - Kick dry run: show who would be removed, without acting\n/substats - Statistics\n/subpin <anchor_chat_id> - Post and pin the welcome message in an anchor chat",
+	"Global-бан (только super-admin):\n/globalban (reply | @user | <id>) [duration] [reason] — забанить во всех чатах сразу\n/globalunban (reply | @user | <id>) — снять глобальный бан\n/globalbans — список активных глобальных банов": "Global ban (super-admin only):\n/globalban (reply | @user | <id>) [duration] [reason] — ban in all chats at once\n/globalunban (reply | @user | <id>) — lift the global ban\n/globalbans — list active global bans",
+	"Ответьте на сообщение командой /whois или укажите username: /whois @username": "Reply to a message with /whois or pass a username: /whois @username",
+	"Участник не найден на платформе.":                                             "Member not found on the platform.",
+	"С нами: %s":           "With us for: %s",
+	"С нами: сегодня":      "With us since today",
+	"Благодарностей: %d":   "Kudos: %d",
+	"Опыт: %s":             "Experience: %s",
+	"Профиль на платформе": "Platform profile",
+	"<b>Привет! Я бот сообщества IT-X.</b>\n\nЧерез меня можно:\n• получить инвайт-ссылки в чаты по твоей подписке,\n• посмотреть свой тир, баллы и ближайшие события,\n• авторизоваться на платформе <a href=\"https://ithozyaeva.ru\">ithozyaeva.ru</a>,\n• написать админу.\n\nВыбери, с чего начать:": "<b>Hi! I'm the IT-X community bot.</b>\n\nWith me you can:\n• get invite links to the chats included in your subscription,\n• check your tier, points and upcoming events,\n• sign in to the <a href=\"https://ithozyaeva.ru\">ithozyaeva.ru</a> platform,\n• message an admin.\n\nChoose where to start:",
+	"🔑 Проверить подписку": "🔑 Check subscription",
+	"📚 Мои чаты":           "📚 My chats",
+	"🆕 Куда ещё зайти":     "🆕 Where else to join",
+	"🎓 События":            "🎓 Events",
+	"⭐ Мои баллы":          "⭐ My points",
+	"🌐 Платформа":          "🌐 Platform",
+	"📨 Написать админу":    "📨 Message an admin",
+	"❓ Как это работает":   "❓ How it works",
+	"<b>Привет! Я бот сообщества IT-X.</b>\n\nЗдесь оформляется подписка на закрытые чаты, менторство и платформу <a href=\"https://ithozyaeva.ru\">ithozyaeva.ru</a>.\n\nВыбери, с чего начать:": "<b>Hi! I'm the IT-X community bot.</b>\n\nThis is where you subscribe to the private chats, mentoring and the <a href=\"https://ithozyaeva.ru\">ithozyaeva.ru</a> platform.\n\nChoose where to start:",
+	"💎 Тарифы":           "💎 Plans",
+	"🔑 Проверить оплату": "🔑 Check payment",
+	"Ответьте на сообщение командой /highlight, чтобы сохранить его как хайлайт.": "Reply to a message with /highlight to save it as a highlight.",
+	"Можно сохранить только текстовые сообщения.":                                 "Only text messages can be saved.",
+	"Ошибка при сохранении хайлайта.":                                             "Failed to save the highlight.",
+	"⭐ Сообщение сохранено как хайлайт!":                                          "⭐ Message saved as a highlight!",
+	"✅ Приду":                  "✅ I'll be there",
+	"❌ Не приду":               "❌ Can't make it",
+	"Эксклюзив":                "Exclusive",
+	"Новое событие!":           "New event!",
+	"Событие началось!":        "The event has started!",
+	"Напоминание о событии":    "Event reminder",
+	"Дата:":                    "Date:",
+	"Спикеры:":                 "Speakers:",
+	"Ссылка:":                  "Link:",
+	"Место:":                   "Place:",
+	"(событие началось)":       "(the event has started)",
+	"(до события осталось %s)": "(%s until the event)",
+	"каждые %d %s":             "every %d %s",
+	"каждые %s":                "every %s",
+	"Повторяющееся событие:":   "Recurring event:",
+	"до %s": "until %s",
+	"Ошибка: пользователь не найден":                                                            "Error: user not found",
+	"Ошибка при обновлении подписки":                                                            "Failed to update the subscription",
+	"Отлично! Вы записаны на мероприятие":                                                       "Great! You are signed up for the event",
+	"Вы отписаны от мероприятия":                                                                "You are no longer signed up for the event",
+	"Не удалось сгенерировать ссылку авторизации. Попробуйте позже.":                            "Failed to generate a sign-in link. Try again later.",
+	"Нажмите кнопку ниже, чтобы авторизоваться на платформе ithozyaeva.ru. Ссылка одноразовая.": "Press the button below to sign in to ithozyaeva.ru. The link works once.",
+	"Авторизоваться": "Sign in",
+	"<b>Как это работает</b>\n\n• Подписка оформляется через Boosty или Tribute.\n• Когда ты в <b>якорном чате</b> своего тира — бот считает подписку активной.\n• /sub или кнопка <b>«Проверить подписку»</b> выдаёт инвайты во все доступные чаты.\n• /mygroups или <b>«Куда ещё зайти»</b> показывает полный список чатов по подписке, включая те, где ты уже состоишь (они помечены ✅).\n• Если тебе открыли новый чат, бот пришлёт сюда сообщение со ссылкой — отдельно действия не нужны.\n\nЕсли что-то не работает — нажми в /start кнопку «Написать админу».": "<b>How it works</b>\n\n• You subscribe via Boosty or Tribute.\n• While you are in the <b>anchor chat</b> of your tier, the bot treats your subscription as active.\n• /sub or the <b>“Check subscription”</b> button gives you invites to all available chats.\n• /mygroups or <b>“Where else to join”</b> shows the full list of chats in your subscription, including the ones you are already in (marked ✅).\n• When a new chat opens up for you, the bot sends the link here — nothing else to do.\n\nIf something doesn't work, press “Message an admin” in /start.",
+	"Слишком часто. Попробуй через минуту — и потом пиши одним сообщением.":                                "Too often. Try again in a minute — and then write it all in one message.",
+	"Не удалось открыть тикет. Попробуй позже.":                                                            "Failed to open a ticket. Try again later.",
+	"Напиши следующим сообщением, что передать админу — уйдёт сразу.\nОтменить: /cancel. У тебя 10 минут.": "Write what to pass to the admin in your next message — it will be sent right away.\nCancel: /cancel. You have 10 minutes.",
+	"Не удалось отправить сообщение админу. Попробуй позже.":                                               "Failed to send the message to the admin. Try again later.",
+	"Отправлено. Админ увидит.":                                                                            "Sent. The admin will see it.",
+	"Нечего отменять.":  "Nothing to cancel.",
+	"Отменено.":         "Cancelled.",
+	"Событие отменено!": "Event cancelled!",
+	"Событие было отменено организаторами.":                                                    "The event has been cancelled by the organizers.",
+	"Событие изменено!":                                                                        "Event changed!",
+	"Пожалуйста, проверьте актуальную информацию о событии":                                    "Please check the latest event details",
+	"Суммаризация не настроена: отсутствует OPENAI_API_KEY.":                                   "Summarization is not configured: OPENAI_API_KEY is missing.",
+	"Диапазон /summarize: %s.":                                                                 "/summarize range: %s.",
+	"Ошибка при получении сообщений.":                                                          "Failed to fetch messages.",
+	"Нет сообщений для суммаризации за указанный период.":                                      "No messages to summarize for this period.",
+	"Из кэша от %s МСК, лимит не списан.":                                                      "From cache of %s MSK, the limit was not charged.",
+	"Не удалось проверить лимит суммаризаций, попробуйте позже.":                               "Failed to check the summarization limit, try again later.",
+	"Лимит суммаризаций исчерпан (%d/%d в день). Попробуйте завтра или повысьте тир подписки.": "Summarization limit reached (%d/%d per day). Try tomorrow or upgrade your subscription tier.",
+	"⏳ Суммаризирую %s (%s) из чата <b>%s</b>...\nОсталось запросов: %d/%d":                    "⏳ Summarizing %s (%s) from chat <b>%s</b>...\nRequests left: %d/%d",
+	"Ошибка: все AI-модели недоступны. Запрос не засчитан в лимит.":                            "Error: all AI models are unavailable. The request was not counted against the limit.",
+	"📋 <b>Суммаризация чата %s</b>\n(%s, %s, модель: %s)\n\n%s":                                "📋 <b>Summary of chat %s</b>\n(%s, %s, model: %s)\n\n%s",
+	"за сутки":     "for the last day",
+	"за неделю":    "for the week",
+	"за 3 дня":     "for 3 days",
+	"последние %d": "last %d",
+	"не понял дату %q — формат ГГГГ-ММ-ДД": "can't parse date %q — use YYYY-MM-DD",
+	"начало диапазона позже конца":         "range start is after its end",
+	"диапазон в будущем":                   "range is in the future",
+	"диапазон не длиннее %d дней":          "range must not exceed %d days",
+	"за %s":            "for %s",
+	"за %s–%s":         "for %s–%s",
+	"ветка обсуждения": "discussion thread",
+	"%s, эта тема":     "%s, this topic",
+	"Используйте /ban в ответ на сообщение нарушителя. Опционально: /ban 1h, /ban 1d.":                                  "Use /ban as a reply to the offender's message. Optional: /ban 1h, /ban 1d.",
+	"Нельзя забанить модератора.":                                                                                       "You cannot ban a moderator.",
+	"Не понял длительность: %s. Примеры: 30m, 1h, 1d.":                                                                  "Can't parse the duration: %s. Examples: 30m, 1h, 1d.",
+	"Telegram отказался банить (нужны права администратора с правом блокировки).":                                       "Telegram refused to ban (the bot needs admin rights to ban users).",
+	"Использование: /unban в ответ на сообщение, или /unban @username, или /unban <user_id>.":                           "Usage: /unban as a reply, or /unban @username, or /unban <user_id>.",
+	"Не нашёл пользователя. Передайте user_id или @username из этого чата.":                                             "User not found. Pass the user_id or @username from this chat.",
+	"Telegram отказался разбанить.":                                                                                     "Telegram refused to unban.",
+	"Используйте /mute в ответ на сообщение. Опционально: /mute 30m, /mute 1h.":                                         "Use /mute as a reply to a message. Optional: /mute 30m, /mute 1h.",
+	"Нельзя замутить модератора.":                                                                                       "You cannot mute a moderator.",
+	"Не понял длительность: %s.":                                                                                        "Can't parse the duration: %s.",
+	"Не удалось замутить.":                                                                                              "Failed to mute.",
+	"Использование: /cleanup в ответ на сообщение [период], или /cleanup @username [период]. Период по умолчанию — %s.": "Usage: /cleanup as a reply [period], or /cleanup @username [period]. Default period is %s.",
+	"Не нашёл пользователя в этом чате.":                                                                                "User not found in this chat.",
+	"Не понял период: %s.":                                                                                              "Can't parse the period: %s.",
+	"Период должен быть больше нуля.":                                                                                   "The period must be greater than zero.",
+	"Период не больше %s.":                                                                                              "The period must not exceed %s.",
+	"Ошибка при поиске сообщений.":                                                                                      "Failed to search messages.",
+	"Использование: /voteban @username":                                                                                 "Usage: /voteban @username",
+	"Не нашёл такого пользователя в этом чате.":                                                                         "No such user in this chat.",
+	"На бота голосование не нужно.":                                                                                     "No need to vote on the bot.",
+	"Самому на себя голосование не нужно.":                                                                              "No need to vote on yourself.",
+	"Нельзя начать голосование на модератора.":                                                                          "You cannot start a vote on a moderator.",
+	"Запускать голосование могут участники со стажем от %s.":                                                            "Only members who have been here at least %s can start a vote.",
+	"У участника высокая репутация в сообществе — голосование недоступно, обратитесь к модераторам.": "This member has a high community reputation — voting is unavailable, contact the moderators.",
+	"В чате уже идёт голосование, дождитесь его окончания.":                                          "A vote is already running in this chat, wait for it to finish.",
+	"В чате уже было голосование недавно. Попробуйте через %s.":                                      "There was a vote in this chat recently. Try again in %s.",
+	"Ваш предыдущий /voteban был недавно. Подождите %s.":                                             "Your previous /voteban was recent. Wait %s.",
+	"В чате слишком мало активных участников за последние 7 дней — voteban здесь не имеет смысла.":   "Too few members were active in this chat in the last 7 days — voteban makes no sense here.",
+	"На этого участника уже идёт голосование.":                                                       "A vote on this member is already running.",
+	"Голосование не найдено.":                                                                        "Vote not found.",
+	"Голосование уже закрыто.":                                                                       "The vote is already closed.",
+	"Голосовать могут только участники чата.":                                                        "Only chat members can vote.",
+	"Голосовать могут активные участники чата за последние 7 дней.":                                  "Only members active in the chat in the last 7 days can vote.",
+	"Цель голосования не может голосовать.":                                                          "The target of the vote cannot vote.",
+	"Голосовать могут участники со стажем от 3 дней.":                                                "Only members who have been here at least 3 days can vote.",
+	"Ошибка.":                "Error.",
+	"Ваш голос уже учтён.":   "Your vote has already been counted.",
+	"Голос принят (вес %s).": "Vote accepted (weight %s).",
+	"Использование: /globalban в reply | /globalban @user [duration] [reason] | /globalban &lt;id&gt; [duration] [reason]": "Usage: /globalban as a reply | /globalban @user [duration] [reason] | /globalban &lt;id&gt; [duration] [reason]",
+	"Не нашёл пользователя. Передай user_id или @username, который писал хотя бы в одном из наших чатов.":                  "User not found. Pass a user_id or an @username that has written in at least one of our chats.",
+	"Нельзя забанить бота.":                                                        "You cannot ban the bot.",
+	"Нельзя глобально забанить администратора.":                                    "You cannot globally ban an administrator.",
+	"Не смог получить список чатов.":                                               "Failed to get the chat list.",
+	"Не смог сохранить запись о бане.":                                             "Failed to save the ban record.",
+	"Использование: /globalunban в reply | /globalunban @user | /globalunban <id>": "Usage: /globalunban as a reply | /globalunban @user | /globalunban <id>",
+	"Не нашёл пользователя.":                                                       "User not found.",
+	"Не удалось сбросить настройки.":                                               "Failed to reset the settings.",
+	"Настройки модерации сброшены на значения по умолчанию.":                       "Moderation settings have been reset to defaults.",
+	"Не сохранено: %s":                                                             "Not saved: %s",
+	"Готово: %s = %s.":                                                             "Done: %s = %s.",
+	"⚙️ <b>Модерация в этом чате</b>\n\nvoteban_window — окно голосования: %s\nvoteban_kick — кик по голосованию: %s\nmute — /mute без аргумента: %s\ncleanup — /cleanup без периода: %s\ncleanup_max — максимальный период /cleanup: %s": "⚙️ <b>Moderation in this chat</b>\n\nvoteban_window — voting window: %s\nvoteban_kick — kick by vote: %s\nmute — /mute without an argument: %s\ncleanup — /cleanup without a period: %s\ncleanup_max — maximum /cleanup period: %s",
+	"Модераторы-волонтёры:":                "Volunteer moderators:",
+	"Не удалось назначить модератора.":     "Failed to assign the moderator.",
+	"Не удалось снять модератора.":         "Failed to remove the moderator.",
+	"Этот пользователь не модератор чата.": "This user is not a chat moderator.",
+	"мут":    "mute",
+	"бан":    "ban",
+	"чистка": "cleanup",
+	"Скачивание видео заняло слишком много времени":                           "Downloading the video took too long",
+	"Не удалось скачать видео по этой ссылке":                                 "Failed to download the video from this link",
+	"Видео слишком большое для Telegram (лимит 50 МБ)":                        "The video is too large for Telegram (50 MB limit)",
+	"Слишком много видео за короткое время, попробуйте через несколько минут": "Too many videos in a short time, try again in a few minutes",
+	"Сейчас скачивается слишком много видео, попробуйте позже":                "Too many videos are being downloaded right now, try again later",
+	"Не удалось загрузить тарифы. Напишите админу: /support":                  "Failed to load plans. Message an admin: /support",
+	"Тарифы IT-ХОЗЯЕВА": "IT-HOZYAEVA plans",
+	"%d ₽/мес":          "%d ₽/mo",
+	"После оплаты нажмите /sub — бот выдаст инвайты в чаты по вашему тиру.": "After paying, press /sub — the bot will send invites to the chats of your tier.",
+	"✅ Я оплатил → проверить": "✅ I've paid → check",
+	"Доступ ограничен.":       "Access restricted.",
+	"Причина: %s":             "Reason: %s",
+	"До: %s":                  "Until: %s",
+	"Произошла ошибка. Попробуйте позже.": "Something went wrong. Try again later.",
+	"У вас нет активной подписки.\n\nПодпишитесь через Boosty или Tribute, затем вернитесь и нажмите /sub снова.": "You don't have an active subscription.\n\nSubscribe via Boosty or Tribute, then come back and press /sub again.",
+	"Вы не зарегистрированы. Используйте /sub для начала.":                                                        "You are not registered. Use /sub to get started.",
+	"Нет": "None",
+	"<b>Статус подписки</b>\n\nТир: %s\nДоступных чатов: %d\n":                                                                   "<b>Subscription status</b>\n\nTier: %s\nAvailable chats: %d\n",
+	"Во все сразу вступать не обязательно — Telegram после нескольких подряд вступлений просит подождать. Заходи, куда хочется.": "You don't have to join them all at once — Telegram asks you to wait after several joins in a row. Join whichever you like.",
+	"Вы не зарегистрированы. Используйте /sub, чтобы начать.":                                                                    "You are not registered. Use /sub to get started.",
+	"У вас нет активной подписки. Используйте /sub, чтобы получить доступ к чатам.":                                              "You don't have an active subscription. Use /sub to get access to the chats.",
+	"Не удалось получить ваш тир. Попробуйте позже.":                                                                             "Failed to get your tier. Try again later.",
+	"Не удалось получить список чатов.":                                                                                          "Failed to get the chat list.",
+	"По вашему тиру <b>%s</b> пока нет подключённых чатов.":                                                                      "There are no chats connected to your tier <b>%s</b> yet.",
+	"<b>Доступные чаты по подписке (%s):</b>\n":                                                                                  "<b>Chats available with your subscription (%s):</b>\n",
+	"✅ — чат, в котором вы уже состоите. Во все сразу вступать не обязательно — Telegram ограничивает подряд идущие вступления, так что выбирай, что тебе интересно.": "✅ — a chat you are already in. You don't have to join them all at once — Telegram limits joins in a row, so pick what interests you.",
+	"друг": "friend",
+	"%s, добро пожаловать! Нажмите кнопку ниже, чтобы получить доступ к остальным чатам.": "%s, welcome! Press the button below to get access to the other chats.",
+	"Получить доступ": "Get access",
+	"Прочее":          "Other",
+	"Подписка подтверждена! Доступно чатов: <b>%d</b>\n": "Subscription confirmed! Chats available: <b>%d</b>\n",
+	"Необязательно вступать во все сразу — Telegram после нескольких подряд вступлений просит подождать. Выбирай чаты, которые тебе интересны; остальные всегда под рукой в /mygroups.": "You don't have to join them all at once — Telegram asks you to wait after several joins in a row. Pick the chats you're interested in; the rest are always at hand in /mygroups.",
+	"Ошибка получения тиров.":       "Failed to get tiers.",
+	"Нет зарегистрированных чатов.": "No registered chats.",
+	"Неверный chat_id.":             "Invalid chat_id.",
+	"🆕 Вам открыт новый чат по вашей подписке:\n\n<b>%s</b>\n\n<a href=\"%s\">Перейти в чат</a>": "🆕 A new chat is now open to you with your subscription:\n\n<b>%s</b>\n\n<a href=\"%s\">Go to the chat</a>",
+	"Использование: /subsetanchor <chat_id> <tier_slug|clear>":                                   "Usage: /subsetanchor <chat_id> <tier_slug|clear>",
+	"Использование: /subremovechat <chat_id>":                                                    "Usage: /subremovechat <chat_id>",
+	"Использование: /subuserinfo <user_id>":                                                      "Usage: /subuserinfo <user_id>",
+	"Неверный user_id.":                                              "Invalid user_id.",
+	"Пользователь не найден.":                                        "User not found.",
+	"Использование: /suboverride <user_id> <tier_slug|clear>":        "Usage: /suboverride <user_id> <tier_slug|clear>",
+	"Запуск полной проверки подписок...":                             "Starting a full subscription check...",
+	"Запуск sweep реального членства... это займёт несколько минут.": "Starting the membership sweep... this will take a few minutes.",
+	"Запуск dry-run проверки... ничего реально не кикается.":         "Starting a dry-run check... nobody is actually kicked.",
+	"Использование: /subpin <anchor_chat_id>":                        "Usage: /subpin <anchor_chat_id>",
+	"Чат не зарегистрирован как anchor.":                             "The chat is not registered as an anchor.",
+	"чате":      "the chat",
+	"чате «%s»": "the chat “%s”",
+	"новым участникам нельзя публиковать ссылки":                                             "new members cannot post links",
+	"новым участникам нельзя публиковать фото, видео и файлы":                                "new members cannot post photos, videos and files",
+	"новым участникам нельзя пересылать сообщения":                                           "new members cannot forward messages",
+	"Ваше сообщение в %s удалено: не больше %s за %s. Пожалуйста, пишите реже.":              "Your message in %s was deleted: no more than %s per %s. Please post less often.",
+	"Ваше сообщение в %s удалено: первые %s после вступления %s. Ограничение снимется само.": "Your message in %s was deleted: for the first %s after joining, %s. The restriction will lift by itself.",
+	"Чат не отслеживается ботом — политики работают только в трекаемых чатах.":               "The bot does not track this chat — policies only work in tracked chats.",
+	"Не удалось сбросить политику.":                                                          "Failed to reset the policy.",
+	"Ограничения в чате сняты.":                                                              "Chat restrictions have been lifted.",
+	"Не удалось загрузить политику.":                                                         "Failed to load the policy.",
+	"не хватает аргументов":                                                                  "not enough arguments",
+	"ожидалось неотрицательное число, получено %q":                                           "expected a non-negative number, got %q",
+	"неизвестный тип %q (links, media, forwards, all)":                                       "unknown type %q (links, media, forwards, all)",
+	"не понял окно: %s (примеры: 30s, 1m, 5m)":                                               "can't parse the window: %s (examples: 30s, 1m, 5m)",
+	"неизвестная настройка %q":                                                               "unknown setting %q",
+	"Ограничения в этом чате":                                                                "Restrictions in this chat",
+	"ссылки":   "links",
+	"медиа":    "media",
+	"форварды": "forwards",
+	"ничего":   "nothing",
+	"Новички (%s после вступления): запрещены %s\n":         "Newcomers (%s after joining): %s not allowed\n",
+	"Новички: без ограничений\n":                            "Newcomers: no restrictions\n",
+	"Rate limit: не больше %s за %s\n":                      "Rate limit: no more than %s per %s\n",
+	"Rate limit: выключен\n":                                "Rate limit: off\n",
+	"Освобождены: модераторы и подписчики с тиром %d+":      "Exempt: moderators and subscribers with tier %d+",
+	"Освобождены: модераторы":                               "Exempt: moderators",
+	"Язык бота: %s. Сменить: /language ru или /language en": "Bot language: %s. Change it: /language ru or /language en",
+	"Не удалось сохранить язык. Попробуйте позже.":          "Failed to save the language. Try again later.",
+	"Готово, язык бота: %s.":                                "Done, bot language: %s.",
+	"Использование:\n/modsettings — текущие настройки и модераторы\n/modsettings voteban_window|voteban_kick|mute|cleanup|cleanup_max <длительность> (mute 0 — бессрочно)\n/modsettings reset — вернуть настройки по умолчанию\n/modsettings mod @user mute,ban,cleanup|all — выдать полномочия (или reply)\n/modsettings unmod @user — снять модератора (или reply)":                      "Usage:\n/modsettings — current settings and moderators\n/modsettings voteban_window|voteban_kick|mute|cleanup|cleanup_max <duration> (mute 0 — permanent)\n/modsettings reset — restore the default settings\n/modsettings mod @user mute,ban,cleanup|all — grant powers (or reply)\n/modsettings unmod @user — remove a moderator (or reply)",
+	"Использование:\n/chatpolicy — текущая политика\n/chatpolicy newbie <дней> [links,media,forwards|all] — ограничения новичков (0 — выключить)\n/chatpolicy rate <сообщений> [окно] — rate limit, например /chatpolicy rate 5 1m (0 — выключить)\n/chatpolicy exempt <уровень тира> — подписчики с этим тиром и выше освобождены (0 — никто)\n/chatpolicy reset — снять все ограничения": "Usage:\n/chatpolicy — current policy\n/chatpolicy newbie <days> [links,media,forwards|all] — newcomer restrictions (0 — off)\n/chatpolicy rate <messages> [window] — rate limit, e.g. /chatpolicy rate 5 1m (0 — off)\n/chatpolicy exempt <tier level> — subscribers with this tier and above are exempt (0 — nobody)\n/chatpolicy reset — lift all restrictions",
+	"Уровень вашей подписки изменился. Доступ к некоторым чатам был отозван.": "Your subscription level has changed. Access to some chats has been revoked.",
+	"каждый день":   "every day",
+	"каждую неделю": "every week",
+	"каждый месяц":  "every month",
+	"каждый год":    "every year",
+	"Войдите на платформу, чтобы искать": "Sign in to the platform to search",
+	"Событие":              "Event",
+	"Ментор":               "Mentor",
+	"Участник":             "Member",
+	"AI-материал":          "AI material",
+	"Открыть в приложении": "Open in the app",
+	"%s МСК":               "%s MSK",
+	"🛍 <b>Новый заказ #%d</b>\n%s — %d баллов\nПокупатель: %s": "🛍 <b>New order #%d</b>\n%s — %d points\nBuyer: %s",
+	"Комментарий: %s":                   "Comment: %s",
+	"📊 <b>Твои баллы за %s %d</b>":      "📊 <b>Your points for %s %d</b>",
+	"Заработано: <b>+%d</b>":            "Earned: <b>+%d</b>",
+	"Потрачено: <b>−%d</b>":             "Spent: <b>−%d</b>",
+	"Итого за месяц: <b>%s</b>":         "Net for the month: <b>%s</b>",
+	"Баланс на конец месяца: <b>%d</b>": "Balance at month end: <b>%d</b>",
+	"По источникам:":                    "By source:",
+	"Реферальные кредиты: +%d / −%d":    "Referral credits: +%d / −%d",
+	"История и выгрузка":                "History and export",
+	"январь":                            "January",
+	"февраль":                           "February",
+	"март":                              "March",
+	"апрель":                            "April",
+	"май":                               "May",
+	"июнь":                              "June",
+	"июль":                              "July",
+	"август":                            "August",
+	"сентябрь":                          "September",
+	"октябрь":                           "October",
+	"ноябрь":                            "November",
+	"декабрь":                           "December",
+	"Посещение событий":                 "Event attendance",
+	"Проведение событий":                "Hosting events",
+	"Отзывы (сообщество)":               "Reviews (community)",
+	"Отзывы (услуги)":                   "Reviews (services)",
+	"Загрузка резюме":                   "Resume upload",
+	"Создание рефералов":                "Referral creation",
+	"Конверсия рефералов":               "Referral conversion",
+	"Заполнение профиля":                "Profile completion",
+	"Еженедельная активность":           "Weekly activity",
+	"Ежемесячная активность":            "Monthly activity",
+	"Серия 4 недели":                    "4-week streak",
+	"Начисление вручную":                "Manual award",
+	"Создание заданий":                  "Task creation",
+	"Выполнение заданий":                "Task completion",
+	"Публикация объявлений":             "Marketplace listings",
+	"Покупки":                           "Purchases",
+	"Квесты в чатах":                    "Chat quests",
+	"Чаттер недели":                     "Chatter of the week",
+	"Благодарности":                     "Kudos",
+	"Розыгрыши":                         "Raffles",
+	"Ставки мини-игр":                   "Mini-game bets",
+	"Выигрыши мини-игр":                 "Mini-game wins",
+	"Джекпот мини-игр":                  "Mini-game jackpot",
+	"Ежедневный вход":                   "Daily check-in",
+	"Стрик 3 дня":                       "3-day streak",
+	"Стрик 7 дней":                      "7-day streak",
+	"Стрик 14 дней":                     "14-day streak",
+	"Стрик 30 дней":                     "30-day streak",
+	"Дейлики":                           "Dailies",
+	"Бонус за все дейлики":              "All dailies bonus",
+	"Челленджи":                         "Challenges",
+	"Ежедневный розыгрыш":               "Daily raffle",
+	"Покупки в магазине":                "Shop purchases",
+	"Возвраты магазина":                 "Shop refunds",
+	"Заморозки стрика":                  "Streak freezes",
+	"Восстановление стрика":             "Streak repair",
+	"Призы рейтинга":                    "Leaderboard prizes",
+	"Списания":                          "Clawbacks",
+	"Отмены транзакций":                 "Transaction reversals",
+}
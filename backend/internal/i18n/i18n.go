@@ -0,0 +1,83 @@
+// Package i18n — каталог сообщений бота и API. Ключ сообщения — исходный
+// русский текст (как msgid в gettext): код остаётся читаемым, а строка без
+// перевода просто показывается по-русски.
+package i18n
+
+import (
+	"fmt"
+	"strings"
+)
+
+type Lang string
+
+const (
+	RU Lang = "ru"
+	EN Lang = "en"
+
+	// Default — язык, если предпочтение неизвестно: сообщество
+	// русскоязычное.
+	Default = RU
+)
+
+// Supported — языки, которые можно выбрать.
+var Supported = []Lang{RU, EN}
+
+// Parse разбирает код языка: "ru", "en", "en-US", "EN_gb". Неподдерживаемый
+// код — ok=false.
+func Parse(code string) (Lang, bool) {
+	code = strings.ToLower(strings.TrimSpace(code))
+	if i := strings.IndexAny(code, "-_"); i >= 0 {
+		code = code[:i]
+	}
+	for _, l := range Supported {
+		if string(l) == code {
+			return l, true
+		}
+	}
+	return "", false
+}
+
+// FromTelegram — язык по language_code пользователя Telegram. Русский
+// интерфейс — русский, любой другой — английский (переводов на остальные
+// языки нет, а английский им ближе); пустой код — Default.
+func FromTelegram(code string) Lang {
+	if strings.TrimSpace(code) == "" {
+		return Default
+	}
+	if l, ok := Parse(code); ok {
+		return l
+	}
+	return EN
+}
+
+// FromAcceptLanguage — первый поддерживаемый язык из заголовка
+// Accept-Language (веса q не учитываем: браузеры пишут языки по убыванию).
+func FromAcceptLanguage(header string) Lang {
+	for _, part := range strings.Split(header, ",") {
+		tag, _, _ := strings.Cut(part, ";")
+		if l, ok := Parse(tag); ok {
+			return l
+		}
+	}
+	return Default
+}
+
+// Resolve — сохранённое предпочтение, если оно валидно, иначе fallback.
+func Resolve(pref string, fallback Lang) Lang {
+	if l, ok := Parse(pref); ok {
+		return l
+	}
+	return fallback
+}
+
+// T переводит msg на lang и подставляет args (fmt.Sprintf). Нет перевода —
+// исходный текст.
+func T(lang Lang, msg string, args ...interface{}) string {
+	if tr, ok := catalogs[lang][msg]; ok {
+		msg = tr
+	}
+	if len(args) == 0 {
+		return msg
+	}
+	return fmt.Sprintf(msg, args...)
+}
@@ -0,0 +1,140 @@
+package i18n
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	for code, want := range map[string]Lang{
+		"ru": RU, "en": EN, "en-US": EN, "EN_gb": EN, " ru ": RU,
+	} {
+		if got, ok := Parse(code); !ok || got != want {
+			t.Errorf("Parse(%q) = %q, %v; want %q", code, got, ok, want)
+		}
+	}
+	for _, code := range []string{"", "de", "english"} {
+		if _, ok := Parse(code); ok {
+			t.Errorf("Parse(%q) should fail", code)
+		}
+	}
+}
+
+func TestFromTelegramAndAcceptLanguage(t *testing.T) {
+	for code, want := range map[string]Lang{"": RU, "ru": RU, "en": EN, "de": EN, "pt-br": EN} {
+		if got := FromTelegram(code); got != want {
+			t.Errorf("FromTelegram(%q) = %q, want %q", code, got, want)
+		}
+	}
+	for header, want := range map[string]Lang{
+		"":                         RU,
+		"de-DE,de;q=0.9":           RU,
+		"de-DE,en-US;q=0.8,ru;q=0": EN,
+		"ru-RU,ru;q=0.9,en;q=0.8":  RU,
+	} {
+		if got := FromAcceptLanguage(header); got != want {
+			t.Errorf("FromAcceptLanguage(%q) = %q, want %q", header, got, want)
+		}
+	}
+	if got := Resolve("en", RU); got != EN {
+		t.Errorf("Resolve(en) = %q", got)
+	}
+	if got := Resolve("", EN); got != EN {
+		t.Errorf("Resolve(\"\") = %q, want fallback", got)
+	}
+}
+
+func TestPlural(t *testing.T) {
+	ru := map[int]string{
+		0: "дней", 1: "день", 2: "дня", 4: "дня", 5: "дней", 11: "дней", 12: "дней",
+		21: "день", 22: "дня", 25: "дней", 111: "дней", 112: "дней", 121: "день",
+	}
+	for n, want := range ru {
+		if got := Plural(RU, n, "день"); got != want {
+			t.Errorf("Plural(ru, %d) = %q, want %q", n, got, want)
+		}
+	}
+	if got := Count(EN, 1, "день"); got != "1 day" {
+		t.Errorf("Count(en, 1) = %q", got)
+	}
+	if got := Count(EN, 21, "день"); got != "21 days" {
+		t.Errorf("Count(en, 21) = %q", got)
+	}
+	if got := Plural(EN, 3, "unknown"); got != "unknown" {
+		t.Errorf("unknown word = %q", got)
+	}
+}
+
+func TestPluralFormsComplete(t *testing.T) {
+	want := map[Lang]int{RU: 3, EN: 2}
+	for lang, words := range plurals {
+		for word, forms := range words {
+			if len(forms) != want[lang] {
+				t.Errorf("%s/%s: %d forms, want %d", lang, word, len(forms), want[lang])
+			}
+			if _, ok := plurals[RU][word]; !ok {
+				t.Errorf("%s/%s: no Russian base form", lang, word)
+			}
+		}
+	}
+}
+
+func TestT(t *testing.T) {
+	if got := T(EN, "Голосование не найдено."); got != "Vote not found." {
+		t.Errorf("T(en) = %q", got)
+	}
+	if got := T(RU, "Голосование не найдено."); got != "Голосование не найдено." {
+		t.Errorf("T(ru) = %q", got)
+	}
+	if got := T(EN, "Нет такого ключа %d", 5); got != "Нет такого ключа 5" {
+		t.Errorf("missing key = %q", got)
+	}
+	// Без аргументов формат не разбирается: «15%» остаётся как есть.
+	if got := T(RU, "порог 15%"); got != "порог 15%" {
+		t.Errorf("no args = %q", got)
+	}
+}
+
+func TestMessageAndErrors(t *testing.T) {
+	label := Msg("%s, эта тема", Msg("за %s", "05.10.2026"))
+	if got := label.In(EN); got != "for 05.10.2026, this topic" {
+		t.Errorf("nested In(en) = %q", got)
+	}
+	if got := label.String(); got != "за 05.10.2026, эта тема" {
+		t.Errorf("String() = %q", got)
+	}
+
+	err := fmt.Errorf("save: %w", Errorf("не больше %d хайлайтов в статье", 10))
+	if got := LocalizeError(EN, err); got != "no more than 10 highlights per article" {
+		t.Errorf("wrapped Errorf = %q", got)
+	}
+	if got := LocalizeError(EN, errors.New("недостаточно прав")); got != "insufficient permissions" {
+		t.Errorf("plain error = %q", got)
+	}
+	if got := LocalizeError(EN, nil); got != "" {
+		t.Errorf("nil = %q", got)
+	}
+	if m := MessageOf(errors.New("x")); m.Format != "x" {
+		t.Errorf("MessageOf(plain) = %+v", m)
+	}
+}
+
+// Переводы должны сохранять глаголы формата в том же порядке — иначе
+// Sprintf подставит аргументы не туда.
+func TestCatalogVerbs(t *testing.T) {
+	verb := regexp.MustCompile(`%[-+#0-9.]*[a-zA-Z%]`)
+	for lang, catalog := range catalogs {
+		for key, tr := range catalog {
+			if tr == "" {
+				t.Errorf("%s: empty translation for %q", lang, key)
+				continue
+			}
+			if k, v := verb.FindAllString(key, -1), verb.FindAllString(tr, -1); !reflect.DeepEqual(k, v) {
+				t.Errorf("%s: verbs %v != %v in %q", lang, k, v, key)
+			}
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package i18n
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Message — текст с отложенным переводом: формат (ключ каталога) и
+// аргументы. Нужен, когда текст собирают в сервисе, а язык известен только
+// в хендлере или боте.
+type Message struct {
+	Format string
+	Args   []interface{}
+}
+
+// Msg — Message из формата и аргументов.
+func Msg(format string, args ...interface{}) Message {
+	return Message{Format: format, Args: args}
+}
+
+// String — текст на языке по умолчанию.
+func (m Message) String() string {
+	return m.In(Default)
+}
+
+// In — текст на языке lang. Аргументы-Message тоже переводятся: так
+// ошибка разбора может стать частью другой ошибки.
+func (m Message) In(lang Lang) string {
+	args := make([]interface{}, len(m.Args))
+	for i, a := range m.Args {
+		if nested, ok := a.(Message); ok {
+			a = nested.In(lang)
+		}
+		args[i] = a
+	}
+	return T(lang, m.Format, args...)
+}
+
+// Error — ошибка с переводимым текстом.
+type Error struct {
+	Message
+}
+
+func (e *Error) Error() string { return e.String() }
+
+// Errorf — как fmt.Errorf, но текст переводится через LocalizeError.
+// Обёртку %w не поддерживает: такие ошибки не для пользователя.
+func Errorf(format string, args ...interface{}) error {
+	return &Error{Msg(format, args...)}
+}
+
+// localizable — ошибка, которая умеет показать себя на нужном языке
+// (*Error и типы с встроенным Message).
+type localizable interface {
+	In(lang Lang) string
+}
+
+// LocalizeError — текст ошибки для пользователя на языке lang.
+func LocalizeError(lang Lang, err error) string {
+	if err == nil {
+		return ""
+	}
+	var l localizable
+	if errors.As(err, &l) {
+		return l.In(lang)
+	}
+	return T(lang, err.Error())
+}
+
+// MessageOf — Message ошибки: переводимая ошибка сохраняет формат и
+// аргументы, обычная — становится ключом каталога целиком.
+func MessageOf(err error) Message {
+	var e *Error
+	if errors.As(err, &e) {
+		return e.Message
+	}
+	return Message{Format: err.Error()}
+}
+
+var _ fmt.Stringer = Message{}
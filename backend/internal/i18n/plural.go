@@ -0,0 +1,74 @@
+package i18n
+
+import "strconv"
+
+// Формы слов для чисел. Ключ — русская начальная форма; у русского три
+// формы (1 день, 2 дня, 5 дней), у английского две (1 day, 2 days).
+var plurals = map[Lang]map[string][]string{
+	RU: {
+		"день":      {"день", "дня", "дней"},
+		"неделя":    {"неделя", "недели", "недель"},
+		"месяц":     {"месяц", "месяца", "месяцев"},
+		"год":       {"год", "года", "лет"},
+		"час":       {"час", "часа", "часов"},
+		"минута":    {"минута", "минуты", "минут"},
+		"балл":      {"балл", "балла", "баллов"},
+		"сообщение": {"сообщение", "сообщения", "сообщений"},
+		"участник":  {"участник", "участника", "участников"},
+		"голос":     {"голос", "голоса", "голосов"},
+		"чат":       {"чат", "чата", "чатов"},
+	},
+	EN: {
+		"день":      {"day", "days"},
+		"неделя":    {"week", "weeks"},
+		"месяц":     {"month", "months"},
+		"год":       {"year", "years"},
+		"час":       {"hour", "hours"},
+		"минута":    {"minute", "minutes"},
+		"балл":      {"point", "points"},
+		"сообщение": {"message", "messages"},
+		"участник":  {"member", "members"},
+		"голос":     {"vote", "votes"},
+		"чат":       {"chat", "chats"},
+	},
+}
+
+// pluralIndex — номер формы по правилам CLDR для целых чисел.
+func pluralIndex(lang Lang, n int) int {
+	if n < 0 {
+		n = -n
+	}
+	if lang == EN {
+		if n == 1 {
+			return 0
+		}
+		return 1
+	}
+	switch {
+	case n%10 == 1 && n%100 != 11:
+		return 0
+	case n%10 >= 2 && n%10 <= 4 && (n%100 < 10 || n%100 >= 20):
+		return 1
+	default:
+		return 2
+	}
+}
+
+// Plural — форма слова word для n: Plural(RU, 5, "день") = "дней".
+// Неизвестное слово возвращается как есть.
+func Plural(lang Lang, n int, word string) string {
+	forms, ok := plurals[lang][word]
+	if !ok {
+		forms, ok = plurals[Default][word]
+		lang = Default
+	}
+	if !ok {
+		return word
+	}
+	return forms[pluralIndex(lang, n)]
+}
+
+// Count — число со словом: Count(EN, 3, "день") = "3 days".
+func Count(lang Lang, n int, word string) string {
+	return strconv.Itoa(n) + " " + Plural(lang, n, word)
+}
@@ -12,10 +12,12 @@ const (
 type AuditAction string
 
 const (
-	AuditActionCreate  AuditAction = "create"
-	AuditActionUpdate  AuditAction = "update"
-	AuditActionDelete  AuditAction = "delete"
-	AuditActionApprove AuditAction = "approve"
+	AuditActionCreate   AuditAction = "create"
+	AuditActionUpdate   AuditAction = "update"
+	AuditActionDelete   AuditAction = "delete"
+	AuditActionApprove  AuditAction = "approve"
+	AuditActionClawback AuditAction = "clawback"
+	AuditActionReverse  AuditAction = "reverse"
 )
 
 type AuditLog struct {
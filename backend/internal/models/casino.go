@@ -14,6 +14,8 @@ type CasinoBet struct {
 	Multiplier float64 `json:"multiplier" gorm:"column:multiplier;not null;default:0"`
 	Payout     int     `json:"payout" gorm:"column:payout;not null;default:0"`
 	Profit     int     `json:"profit" gorm:"column:profit;not null;default:0"`
+	SeedId     *int64  `json:"seedId" gorm:"column:seed_id"`
+	Nonce      *int    `json:"nonce" gorm:"column:nonce"`
 	CreatedAt  time.Time `json:"createdAt" gorm:"column:created_at;autoCreateTime"`
 }
 
@@ -33,16 +35,20 @@ type WheelRequest struct {
 }
 
 type CasinoBetResponse struct {
-	Id         int64   `json:"id"`
-	Game       string  `json:"game"`
-	BetAmount  int     `json:"betAmount"`
-	BetChoice  string  `json:"betChoice"`
-	Result     string  `json:"result"`
-	Multiplier float64 `json:"multiplier"`
-	Payout     int     `json:"payout"`
-	Profit     int     `json:"profit"`
-	Balance    int     `json:"balance"`
-	CreatedAt  time.Time `json:"createdAt"`
+	Id             int64     `json:"id"`
+	Game           string    `json:"game"`
+	BetAmount      int       `json:"betAmount"`
+	BetChoice      string    `json:"betChoice"`
+	Result         string    `json:"result"`
+	Multiplier     float64   `json:"multiplier"`
+	Payout         int       `json:"payout"`
+	Profit         int       `json:"profit"`
+	Balance        int       `json:"balance"`
+	ServerSeedHash string    `json:"serverSeedHash"`
+	ClientSeed     string    `json:"clientSeed"`
+	Nonce          int       `json:"nonce"`
+	JackpotWin     int       `json:"jackpotWin"`
+	CreatedAt      time.Time `json:"createdAt"`
 }
 
 type CasinoHistoryItem struct {
@@ -54,6 +60,8 @@ type CasinoHistoryItem struct {
 	Multiplier float64 `json:"multiplier"`
 	Payout     int     `json:"payout"`
 	Profit     int     `json:"profit"`
+	SeedId     *int64  `json:"seedId"`
+	Nonce      *int    `json:"nonce"`
 	CreatedAt  time.Time `json:"createdAt"`
 }
 
@@ -66,11 +74,23 @@ type CasinoStats struct {
 }
 
 type CasinoAdminStats struct {
-	TotalBets     int64 `json:"totalBets"`
-	TotalWagered  int   `json:"totalWagered"`
-	TotalPayout   int   `json:"totalPayout"`
-	HouseProfit   int   `json:"houseProfit"`
-	UniquePlayers int64 `json:"uniquePlayers"`
+	TotalBets     int64                   `json:"totalBets"`
+	TotalWagered  int                     `json:"totalWagered"`
+	TotalPayout   int                     `json:"totalPayout"`
+	HouseProfit   int                     `json:"houseProfit"`
+	UniquePlayers int64                   `json:"uniquePlayers"`
+	GameStats     []CasinoGameStats       `json:"gameStats" gorm:"-"`
+	Jackpot       CasinoJackpotAdminStats `json:"jackpot" gorm:"-"`
+	Crash         CasinoCrashAdminStats   `json:"crash" gorm:"-"`
+}
+
+// CasinoGameStats — оборот и прибыль по одной игре.
+type CasinoGameStats struct {
+	Game         string `json:"game"`
+	TotalBets    int64  `json:"totalBets"`
+	TotalWagered int    `json:"totalWagered"`
+	TotalPayout  int    `json:"totalPayout"`
+	HouseProfit  int    `json:"houseProfit"`
 }
 
 type CasinoFeedItem struct {
@@ -102,3 +122,62 @@ type CasinoAdminBet struct {
 	Profit          int     `json:"profit"`
 	CreatedAt       time.Time `json:"createdAt"`
 }
+
+// CasinoSeed — пара seed'ов участника для provably fair. ServerSeed
+// отдаётся наружу только после ротации (revealed_at).
+type CasinoSeed struct {
+	Id             int64      `json:"id" gorm:"primaryKey"`
+	MemberId       int64      `json:"memberId" gorm:"column:member_id;not null"`
+	ServerSeed     string     `json:"-" gorm:"column:server_seed;not null"`
+	ServerSeedHash string     `json:"serverSeedHash" gorm:"column:server_seed_hash;not null"`
+	ClientSeed     string     `json:"clientSeed" gorm:"column:client_seed;not null"`
+	Nonce          int        `json:"nonce" gorm:"column:nonce;not null;default:0"`
+	Active         bool       `json:"active" gorm:"column:active;not null;default:true"`
+	CreatedAt      time.Time  `json:"createdAt" gorm:"column:created_at;autoCreateTime"`
+	RevealedAt     *time.Time `json:"revealedAt" gorm:"column:revealed_at"`
+}
+
+func (CasinoSeed) TableName() string {
+	return "casino_seeds"
+}
+
+// CasinoRevealedSeed — отыгранная пара с раскрытым серверным seed'ом.
+type CasinoRevealedSeed struct {
+	CasinoSeed
+	ServerSeed string `json:"serverSeed"`
+}
+
+// CasinoSeedState — текущая пара (только хеш) и предыдущая раскрытая.
+type CasinoSeedState struct {
+	Active   *CasinoSeed         `json:"active"`
+	Previous *CasinoRevealedSeed `json:"previous"`
+}
+
+// CasinoRotateSeedRequest — новый клиентский seed; пустой — случайный.
+type CasinoRotateSeedRequest struct {
+	ClientSeed string `json:"clientSeed"`
+}
+
+// CasinoBetVerification — пересчёт исхода ставки по раскрытым seed'ам.
+type CasinoBetVerification struct {
+	BetId          int64  `json:"betId"`
+	Game           string `json:"game"`
+	BetAmount      int    `json:"betAmount"`
+	BetChoice      string `json:"betChoice"`
+	ServerSeed     string `json:"serverSeed"`
+	ServerSeedHash string `json:"serverSeedHash"`
+	ClientSeed     string `json:"clientSeed"`
+	Nonce          int    `json:"nonce"`
+	// RoundId — раунд crash: seed у него общий на все ставки раунда.
+	RoundId *int64 `json:"roundId,omitempty"`
+	// HashMatches — sha256(serverSeed) совпал с хешем, показанным до игры.
+	HashMatches bool `json:"hashMatches"`
+	// Recorded* — что записано в ставке, Computed* — пересчёт.
+	RecordedResult     string  `json:"recordedResult"`
+	RecordedMultiplier float64 `json:"recordedMultiplier"`
+	RecordedPayout     int     `json:"recordedPayout"`
+	ComputedResult     string  `json:"computedResult"`
+	ComputedMultiplier float64 `json:"computedMultiplier"`
+	ComputedPayout     int     `json:"computedPayout"`
+	Valid              bool    `json:"valid"`
+}
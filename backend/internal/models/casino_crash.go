@@ -0,0 +1,167 @@
+package models
+
+import "time"
+
+const (
+	CrashRoundBetting = "betting"
+	CrashRoundRunning = "running"
+	CrashRoundCrashed = "crashed"
+)
+
+// CrashRound — раунд crash. ServerSeed и CrashPoint наружу отдаются только
+// после краша (см. CrashRoundView).
+type CrashRound struct {
+	Id             int64      `json:"id" gorm:"primaryKey"`
+	ServerSeed     string     `json:"-" gorm:"column:server_seed;not null"`
+	ServerSeedHash string     `json:"serverSeedHash" gorm:"column:server_seed_hash;not null"`
+	CrashPoint     float64    `json:"-" gorm:"column:crash_point;not null"`
+	Status         string     `json:"status" gorm:"column:status;not null"`
+	BettingEndsAt  time.Time  `json:"bettingEndsAt" gorm:"column:betting_ends_at;not null"`
+	StartedAt      *time.Time `json:"startedAt" gorm:"column:started_at"`
+	CrashedAt      *time.Time `json:"crashedAt" gorm:"column:crashed_at"`
+	CreatedAt      time.Time  `json:"createdAt" gorm:"column:created_at;autoCreateTime"`
+}
+
+func (CrashRound) TableName() string {
+	return "casino_crash_rounds"
+}
+
+// CrashBet — участие в раунде; деньги — в связанной строке casino_bets.
+type CrashBet struct {
+	BetId       int64    `json:"betId" gorm:"primaryKey;column:bet_id;autoIncrement:false"`
+	RoundId     int64    `json:"roundId" gorm:"column:round_id;not null"`
+	MemberId    int64    `json:"memberId" gorm:"column:member_id;not null"`
+	AutoCashOut *float64 `json:"autoCashOut" gorm:"column:auto_cash_out"`
+	CashedOutAt *float64 `json:"cashedOutAt" gorm:"column:cashed_out_at"`
+}
+
+func (CrashBet) TableName() string {
+	return "casino_crash_bets"
+}
+
+// CrashRoundView — раунд для клиентов: до краша без seed'а и точки краша.
+type CrashRoundView struct {
+	Id             int64      `json:"id"`
+	Status         string     `json:"status"`
+	ServerSeedHash string     `json:"serverSeedHash"`
+	ServerSeed     string     `json:"serverSeed,omitempty"`
+	CrashPoint     float64    `json:"crashPoint,omitempty"`
+	BettingEndsAt  time.Time  `json:"bettingEndsAt"`
+	StartedAt      *time.Time `json:"startedAt"`
+	CrashedAt      *time.Time `json:"crashedAt"`
+}
+
+// CrashRoundBet — ставка раунда для списка игроков.
+type CrashRoundBet struct {
+	BetId           int64    `json:"betId"`
+	MemberId        int64    `json:"memberId"`
+	MemberFirstName string   `json:"memberFirstName"`
+	MemberUsername  string   `json:"memberUsername"`
+	BetAmount       int      `json:"betAmount"`
+	AutoCashOut     *float64 `json:"autoCashOut"`
+	CashedOutAt     *float64 `json:"cashedOutAt"`
+	Payout          int      `json:"payout"`
+}
+
+// CrashState — текущий раунд, его ставки и точки краша последних раундов.
+type CrashState struct {
+	Round      *CrashRoundView `json:"round"`
+	Bets       []CrashRoundBet `json:"bets"`
+	History    []float64       `json:"history"`
+	ServerTime time.Time       `json:"serverTime"`
+	Jackpot    int             `json:"jackpot"`
+}
+
+// CrashRoundSummary — завершённый раунд в истории.
+type CrashRoundSummary struct {
+	Id             int64     `json:"id"`
+	CrashPoint     float64   `json:"crashPoint"`
+	ServerSeed     string    `json:"serverSeed"`
+	ServerSeedHash string    `json:"serverSeedHash"`
+	Players        int       `json:"players"`
+	TotalWagered   int       `json:"totalWagered"`
+	TotalPayout    int       `json:"totalPayout"`
+	CrashedAt      time.Time `json:"crashedAt"`
+}
+
+// CrashRoundDetail — раунд с раскрытым seed'ом и всеми ставками.
+type CrashRoundDetail struct {
+	Round CrashRoundView  `json:"round"`
+	Bets  []CrashRoundBet `json:"bets"`
+}
+
+// CrashEvent — SSE-событие "crash": фаза раунда, ставка или вывод.
+type CrashEvent struct {
+	Event string          `json:"event"`
+	Round *CrashRoundView `json:"round,omitempty"`
+	Bet   *CrashRoundBet  `json:"bet,omitempty"`
+}
+
+type CrashBetRequest struct {
+	BetAmount   int      `json:"betAmount"`
+	AutoCashOut *float64 `json:"autoCashOut"`
+}
+
+type CrashBetResponse struct {
+	BetId       int64    `json:"betId"`
+	RoundId     int64    `json:"roundId"`
+	BetAmount   int      `json:"betAmount"`
+	AutoCashOut *float64 `json:"autoCashOut"`
+	Balance     int      `json:"balance"`
+	JackpotWin  int      `json:"jackpotWin"`
+}
+
+type CrashCashOutResponse struct {
+	BetId      int64   `json:"betId"`
+	Multiplier float64 `json:"multiplier"`
+	Payout     int     `json:"payout"`
+	Balance    int     `json:"balance"`
+}
+
+// CasinoCrashAdminStats — сводка по раундам crash для админки; CashOutRate —
+// доля ставок, выведенных до краша, в процентах.
+type CasinoCrashAdminStats struct {
+	Rounds         int64   `json:"rounds"`
+	AvgCrashPoint  float64 `json:"avgCrashPoint"`
+	MaxCrashPoint  float64 `json:"maxCrashPoint"`
+	InstantCrashes int64   `json:"instantCrashes"`
+	AvgPlayers     float64 `json:"avgPlayers"`
+	CashOutRate    float64 `json:"cashOutRate"`
+}
+
+// CasinoJackpotSettings — app_settings.casino_jackpot.
+type CasinoJackpotSettings struct {
+	ContributionPercent float64 `json:"contributionPercent"`
+	TurnoverPerWin      int     `json:"turnoverPerWin"`
+	SeedAmount          int     `json:"seedAmount"`
+}
+
+type CasinoJackpotWin struct {
+	Id              int64     `json:"id" gorm:"primaryKey"`
+	MemberId        int64     `json:"memberId" gorm:"column:member_id;not null"`
+	BetId           *int64    `json:"betId" gorm:"column:bet_id"`
+	Game            string    `json:"game" gorm:"column:game;not null"`
+	Amount          int       `json:"amount" gorm:"column:amount;not null"`
+	CreatedAt       time.Time `json:"createdAt" gorm:"column:created_at;autoCreateTime"`
+	MemberFirstName string    `json:"memberFirstName" gorm:"->;column:member_first_name"`
+	MemberUsername  string    `json:"memberUsername" gorm:"->;column:member_username"`
+}
+
+func (CasinoJackpotWin) TableName() string {
+	return "casino_jackpot_wins"
+}
+
+// CasinoJackpotState — текущий пул и последние выигрыши.
+type CasinoJackpotState struct {
+	Amount     int                   `json:"amount"`
+	RecentWins []CasinoJackpotWin    `json:"recentWins"`
+	Settings   CasinoJackpotSettings `json:"settings"`
+}
+
+// CasinoJackpotAdminStats — пул, выплаты и взносы для админки.
+type CasinoJackpotAdminStats struct {
+	Amount     int   `json:"amount"`
+	Wins       int64 `json:"wins"`
+	TotalPaid  int   `json:"totalPaid"`
+	LargestWin int   `json:"largestWin"`
+}
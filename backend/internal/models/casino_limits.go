@@ -0,0 +1,100 @@
+package models
+
+import "time"
+
+// Поля личных лимитов мини-игр (как в casino_limit_changes.field).
+const (
+	CasinoLimitDailyLoss       = "daily_loss_limit"
+	CasinoLimitDailyBets       = "daily_bet_limit"
+	CasinoLimitCooldownLosses  = "cooldown_after_losses"
+	CasinoLimitCooldownMinutes = "cooldown_minutes"
+	CasinoLimitSelfExclusion   = "self_exclusion"
+)
+
+const (
+	CasinoLimitChangeApplied   = "applied"
+	CasinoLimitChangePending   = "pending"
+	CasinoLimitChangeCancelled = "cancelled"
+)
+
+const CasinoSelfExclusionMaxDays = 365
+
+// CasinoLimits — личные лимиты участника; nil — лимита нет.
+type CasinoLimits struct {
+	MemberId            int64      `json:"-" gorm:"primaryKey;column:member_id;autoIncrement:false"`
+	DailyLossLimit      *int       `json:"dailyLossLimit" gorm:"column:daily_loss_limit"`
+	DailyBetLimit       *int       `json:"dailyBetLimit" gorm:"column:daily_bet_limit"`
+	CooldownAfterLosses *int       `json:"cooldownAfterLosses" gorm:"column:cooldown_after_losses"`
+	CooldownMinutes     *int       `json:"cooldownMinutes" gorm:"column:cooldown_minutes"`
+	ExcludedUntil       *time.Time `json:"excludedUntil" gorm:"column:excluded_until"`
+	UpdatedAt           time.Time  `json:"updatedAt" gorm:"column:updated_at;autoUpdateTime"`
+}
+
+func (CasinoLimits) TableName() string {
+	return "casino_limits"
+}
+
+// CasinoGlobalLimits — глобальные лимиты из app_settings.casino_limits;
+// 0 — лимит выключен.
+type CasinoGlobalLimits struct {
+	DailyLossLimit      int `json:"dailyLossLimit"`
+	DailyBetLimit       int `json:"dailyBetLimit"`
+	CooldownAfterLosses int `json:"cooldownAfterLosses"`
+	CooldownMinutes     int `json:"cooldownMinutes"`
+	IncreaseDelayHours  int `json:"increaseDelayHours"`
+}
+
+// CasinoEffectiveLimits — что реально действует: строжайшее из личного и
+// глобального; 0 — лимита нет.
+type CasinoEffectiveLimits struct {
+	DailyLossLimit      int `json:"dailyLossLimit"`
+	DailyBetLimit       int `json:"dailyBetLimit"`
+	CooldownAfterLosses int `json:"cooldownAfterLosses"`
+	CooldownMinutes     int `json:"cooldownMinutes"`
+}
+
+// CasinoLimitChange — запись истории изменения лимита. У самоисключения
+// NewValue — число дней.
+type CasinoLimitChange struct {
+	Id          int64     `json:"id" gorm:"primaryKey"`
+	MemberId    int64     `json:"memberId" gorm:"column:member_id;not null"`
+	Field       string    `json:"field" gorm:"column:field;not null"`
+	OldValue    *int      `json:"oldValue" gorm:"column:old_value"`
+	NewValue    *int      `json:"newValue" gorm:"column:new_value"`
+	Status      string    `json:"status" gorm:"column:status;not null"`
+	EffectiveAt time.Time `json:"effectiveAt" gorm:"column:effective_at;not null"`
+	CreatedAt   time.Time `json:"createdAt" gorm:"column:created_at;autoCreateTime"`
+}
+
+func (CasinoLimitChange) TableName() string {
+	return "casino_limit_changes"
+}
+
+// CasinoDayUsage — ставки и чистый проигрыш участника за МСК-день.
+type CasinoDayUsage struct {
+	Bets int `json:"bets"`
+	Loss int `json:"loss"`
+}
+
+// CasinoLimitsState — лимиты участника для страницы мини-игр.
+type CasinoLimitsState struct {
+	Self          CasinoLimits          `json:"self"`
+	Global        CasinoGlobalLimits    `json:"global"`
+	Effective     CasinoEffectiveLimits `json:"effective"`
+	Pending       []CasinoLimitChange   `json:"pending"`
+	Today         CasinoDayUsage        `json:"today"`
+	CooldownUntil *time.Time            `json:"cooldownUntil"`
+}
+
+// CasinoLimitsRequest — новые личные лимиты целиком; null — снять лимит.
+type CasinoLimitsRequest struct {
+	DailyLossLimit      *int `json:"dailyLossLimit"`
+	DailyBetLimit       *int `json:"dailyBetLimit"`
+	CooldownAfterLosses *int `json:"cooldownAfterLosses"`
+	CooldownMinutes     *int `json:"cooldownMinutes"`
+}
+
+// CasinoSelfExcludeRequest — самоисключение на Days дней.
+type CasinoSelfExcludeRequest struct {
+	Days int `json:"days"`
+}
@@ -0,0 +1,42 @@
+package models
+
+import "time"
+
+// ChatDigest — еженедельный AI-дайджест трекаемого чата.
+// TopMembers и Highlights — JSON-массивы DigestMember / DigestHighlight.
+type ChatDigest struct {
+	Id            int64      `json:"id" gorm:"primaryKey"`
+	ChatID        int64      `json:"chatId" gorm:"column:chat_id"`
+	ChatTitle     string     `json:"chatTitle" gorm:"column:chat_title"`
+	PeriodStart   time.Time  `json:"periodStart" gorm:"column:period_start"`
+	PeriodEnd     time.Time  `json:"periodEnd" gorm:"column:period_end"`
+	MessageCount  int        `json:"messageCount" gorm:"column:message_count"`
+	ActiveMembers int        `json:"activeMembers" gorm:"column:active_members"`
+	Summary       string     `json:"summary" gorm:"column:summary;type:text"`
+	Model         string     `json:"model" gorm:"column:model"`
+	TopMembers    string     `json:"topMembers" gorm:"column:top_members;type:jsonb;default:'[]'"`
+	Highlights    string     `json:"highlights" gorm:"column:highlights;type:jsonb;default:'[]'"`
+	PostedAt      *time.Time `json:"postedAt" gorm:"column:posted_at"`
+	CreatedAt     time.Time  `json:"createdAt" gorm:"column:created_at"`
+}
+
+func (ChatDigest) TableName() string {
+	return "chat_digests"
+}
+
+// DigestMember — самый активный участник недели.
+type DigestMember struct {
+	TelegramUserID    int64  `json:"telegramUserId"`
+	TelegramUsername  string `json:"telegramUsername"`
+	TelegramFirstName string `json:"telegramFirstName"`
+	Count             int64  `json:"count"`
+}
+
+// DigestHighlight — хайлайт недели (копия из chat_highlights).
+type DigestHighlight struct {
+	Id              int64  `json:"id"`
+	MessageID       int    `json:"messageId"`
+	AuthorUsername  string `json:"authorUsername"`
+	AuthorFirstName string `json:"authorFirstName"`
+	MessageText     string `json:"messageText"`
+}
@@ -24,6 +24,8 @@ type ChatMessage struct {
 	MemberID          *int64    `json:"memberId" gorm:"column:member_id"`
 	MessageText       string    `json:"messageText" gorm:"column:message_text"`
 	TelegramMessageID *int      `json:"telegramMessageId" gorm:"column:telegram_message_id"`
+	ReplyToMessageID  *int      `json:"replyToMessageId" gorm:"column:reply_to_message_id"`
+	MessageThreadID   *int      `json:"messageThreadId" gorm:"column:message_thread_id"` // тема форума
 	SentAt            time.Time `json:"sentAt" gorm:"column:sent_at"`
 	CreatedAt         time.Time `json:"createdAt" gorm:"column:created_at"`
 }
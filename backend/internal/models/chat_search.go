@@ -0,0 +1,26 @@
+package models
+
+import "time"
+
+// ChatSearchResult — найденное сообщение чата.
+type ChatSearchResult struct {
+	Id                int64     `json:"id"`
+	ChatID            int64     `json:"chatId"`
+	ChatTitle         string    `json:"chatTitle"`
+	TelegramUserID    int64     `json:"telegramUserId"`
+	TelegramUsername  string    `json:"telegramUsername"`
+	TelegramFirstName string    `json:"telegramFirstName"`
+	MessageText       string    `json:"messageText"`
+	Snippet           string    `json:"snippet"` // HTML: текст экранирован, совпадения в <mark>
+	TelegramMessageID *int      `json:"telegramMessageId"`
+	MessageThreadID   *int      `json:"messageThreadId"`
+	SentAt            time.Time `json:"sentAt"`
+	Rank              float64   `json:"rank"`
+	Link              string    `json:"link" gorm:"-"` // t.me-ссылка на сообщение, если его можно открыть
+}
+
+// SearchableChat — чат, по которому участнику доступен поиск.
+type SearchableChat struct {
+	ChatID int64  `json:"chatId"`
+	Title  string `json:"title"`
+}
@@ -0,0 +1,83 @@
+package models
+
+import "time"
+
+// Способы срабатывания FAQ-триггера.
+const (
+	FAQMatchKeywords   = "keywords"   // pattern: фразы через запятую/перевод строки, все слова фразы в сообщении
+	FAQMatchRegex      = "regex"      // pattern: регулярка RE2, без учёта регистра
+	FAQMatchSimilarity = "similarity" // pattern: образцы вопроса по одному на строку
+)
+
+// FAQTrigger — триггер автоответчика. ChatID = 0 — все трекаемые чаты.
+type FAQTrigger struct {
+	Id              int64     `json:"id" gorm:"primaryKey"`
+	ChatID          int64     `json:"chatId" gorm:"column:chat_id"`
+	Name            string    `json:"name" gorm:"column:name"`
+	MatchType       string    `json:"matchType" gorm:"column:match_type"`
+	Pattern         string    `json:"pattern" gorm:"column:pattern"`
+	Response        string    `json:"response" gorm:"column:response"`
+	CooldownSeconds int       `json:"cooldownSeconds" gorm:"column:cooldown_seconds"`
+	Priority        int       `json:"priority" gorm:"column:priority"`
+	Enabled         bool      `json:"enabled" gorm:"column:enabled"`
+	CreatedBy       int64     `json:"createdBy" gorm:"column:created_by"`
+	UpdatedBy       int64     `json:"updatedBy" gorm:"column:updated_by"`
+	CreatedAt       time.Time `json:"createdAt" gorm:"column:created_at"`
+	UpdatedAt       time.Time `json:"updatedAt" gorm:"column:updated_at"`
+}
+
+func (FAQTrigger) TableName() string {
+	return "bot_faq_triggers"
+}
+
+// FAQHit — срабатывание триггера.
+type FAQHit struct {
+	Id        int64     `json:"id" gorm:"primaryKey"`
+	TriggerID int64     `json:"triggerId" gorm:"column:trigger_id"`
+	ChatID    int64     `json:"chatId" gorm:"column:chat_id"`
+	UserID    int64     `json:"userId" gorm:"column:user_id"`
+	MessageID int       `json:"messageId" gorm:"column:message_id"`
+	CreatedAt time.Time `json:"createdAt" gorm:"column:created_at;autoCreateTime"`
+}
+
+func (FAQHit) TableName() string {
+	return "bot_faq_hits"
+}
+
+// FAQTriggerStats — срабатывания триггера за период.
+type FAQTriggerStats struct {
+	TriggerID int64      `json:"triggerId"`
+	Name      string     `json:"name"`
+	ChatID    int64      `json:"chatId"`
+	Enabled   bool       `json:"enabled"`
+	Hits      int64      `json:"hits"`
+	Chats     int64      `json:"chats"` // в скольких чатах срабатывал
+	LastHitAt *time.Time `json:"lastHitAt"`
+}
+
+// FAQTriggerRequest — тело создания/редактирования. Enabled не передан —
+// триггер включён.
+type FAQTriggerRequest struct {
+	ChatID          int64  `json:"chatId"`
+	Name            string `json:"name"`
+	MatchType       string `json:"matchType"`
+	Pattern         string `json:"pattern"`
+	Response        string `json:"response"`
+	CooldownSeconds int    `json:"cooldownSeconds"`
+	Priority        int    `json:"priority"`
+	Enabled         *bool  `json:"enabled"`
+}
+
+func (r FAQTriggerRequest) ToTrigger() *FAQTrigger {
+	enabled := r.Enabled == nil || *r.Enabled
+	return &FAQTrigger{
+		ChatID:          r.ChatID,
+		Name:            r.Name,
+		MatchType:       r.MatchType,
+		Pattern:         r.Pattern,
+		Response:        r.Response,
+		CooldownSeconds: r.CooldownSeconds,
+		Priority:        r.Priority,
+		Enabled:         enabled,
+	}
+}
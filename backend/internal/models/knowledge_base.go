@@ -0,0 +1,32 @@
+package models
+
+import "time"
+
+// KBEntry — статья базы знаний: вопрос, отредактированный модератором ответ
+// и хайлайты-источники из чатов.
+type KBEntry struct {
+	Id             int64           `json:"id" gorm:"primaryKey"`
+	Title          string          `json:"title" gorm:"column:title"`
+	Question       string          `json:"question" gorm:"column:question"`
+	Answer         string          `json:"answer" gorm:"column:answer"`
+	CreatedBy      int64           `json:"createdBy" gorm:"column:created_by"`
+	UpdatedBy      int64           `json:"updatedBy" gorm:"column:updated_by"`
+	SuggestedCount int             `json:"suggestedCount" gorm:"column:suggested_count;->"`
+	CreatedAt      time.Time       `json:"createdAt" gorm:"column:created_at"`
+	UpdatedAt      time.Time       `json:"updatedAt" gorm:"column:updated_at"`
+	Highlights     []ChatHighlight `json:"highlights" gorm:"many2many:kb_entry_highlights;joinForeignKey:entry_id;joinReferences:highlight_id"`
+	ProfTags       []ProfTag       `json:"profTags" gorm:"many2many:kb_entry_tags;joinForeignKey:entry_id;joinReferences:tag_id"`
+}
+
+func (KBEntry) TableName() string {
+	return "kb_entries"
+}
+
+// KBEntryRequest — тело создания/редактирования статьи.
+type KBEntryRequest struct {
+	Title        string  `json:"title"`
+	Question     string  `json:"question"`
+	Answer       string  `json:"answer"`
+	HighlightIDs []int64 `json:"highlightIds"`
+	TagIDs       []int64 `json:"tagIds"`
+}
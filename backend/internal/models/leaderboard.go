@@ -0,0 +1,90 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+
+	"ithozyeva/internal/s3resolve"
+)
+
+const (
+	LeaderboardWeekly    = "weekly"
+	LeaderboardMonthly   = "monthly"
+	LeaderboardQuarterly = "quarterly"
+)
+
+// LeaderboardPeriods — периоды в порядке отображения.
+var LeaderboardPeriods = []string{LeaderboardWeekly, LeaderboardMonthly, LeaderboardQuarterly}
+
+// LeaderboardSnapshot — замороженный итог завершённого периода.
+type LeaderboardSnapshot struct {
+	Id           int64                      `json:"id" gorm:"primaryKey"`
+	Period       string                     `json:"period" gorm:"column:period;size:16;not null"`
+	PeriodKey    string                     `json:"periodKey" gorm:"column:period_key;size:16;not null"`
+	StartsAt     time.Time                  `json:"startsAt" gorm:"column:starts_at;not null"`
+	EndsAt       time.Time                  `json:"endsAt" gorm:"column:ends_at;not null"`
+	Participants int                        `json:"participants" gorm:"column:participants;not null;default:0"`
+	CreatedAt    time.Time                  `json:"createdAt" gorm:"column:created_at;autoCreateTime"`
+	Entries      []LeaderboardSnapshotEntry `json:"entries" gorm:"-"`
+}
+
+func (LeaderboardSnapshot) TableName() string {
+	return "leaderboard_snapshots"
+}
+
+// LeaderboardSnapshotEntry — место участника в снапшоте.
+type LeaderboardSnapshotEntry struct {
+	SnapshotId int64 `json:"-" gorm:"column:snapshot_id;primaryKey"`
+	MemberId   int64 `json:"memberId" gorm:"column:member_id;primaryKey"`
+	Rank       int   `json:"rank" gorm:"column:rank;not null"`
+	Total      int   `json:"total" gorm:"column:total;not null"`
+	Prize      int   `json:"prize" gorm:"column:prize;not null;default:0"`
+	// Заполняется JOIN'ом с members при чтении.
+	FirstName string `json:"firstName" gorm:"->;column:first_name"`
+	LastName  string `json:"lastName" gorm:"->;column:last_name"`
+	Username  string `json:"tg" gorm:"->;column:username"`
+	AvatarURL string `json:"avatarUrl" gorm:"->;column:avatar_url"`
+}
+
+func (LeaderboardSnapshotEntry) TableName() string {
+	return "leaderboard_snapshot_entries"
+}
+
+func (e *LeaderboardSnapshotEntry) AfterFind(tx *gorm.DB) (err error) {
+	e.AvatarURL = s3resolve.ResolveS3URL(e.AvatarURL)
+	return nil
+}
+
+// LeaderboardRank — строка живого рейтинга периода.
+type LeaderboardRank struct {
+	Rank int `json:"rank"`
+	MemberPointsBalance
+}
+
+// LeaderboardPeriod — границы периода [StartsAt, EndsAt).
+type LeaderboardPeriod struct {
+	Period    string    `json:"period"`
+	PeriodKey string    `json:"periodKey"`
+	StartsAt  time.Time `json:"startsAt"`
+	EndsAt    time.Time `json:"endsAt"`
+}
+
+// LeaderboardResponse — рейтинг текущего периода, место участника и
+// призы за места.
+type LeaderboardResponse struct {
+	LeaderboardPeriod
+	Items  []LeaderboardRank `json:"items"`
+	Me     *LeaderboardRank  `json:"me"`
+	Prizes []int             `json:"prizes"`
+}
+
+// LeaderboardWin — место в призовой зоне прошлого периода (для профиля).
+type LeaderboardWin struct {
+	Period    string    `json:"period"`
+	PeriodKey string    `json:"periodKey"`
+	EndsAt    time.Time `json:"endsAt"`
+	Rank      int       `json:"rank"`
+	Total     int       `json:"total"`
+	Prize     int       `json:"prize"`
+}
@@ -0,0 +1,48 @@
+package models
+
+import "time"
+
+// Фичи, от имени которых ходим в LLM.
+const (
+	LLMFeatureSummarize = "summarize"
+	LLMFeatureDigest    = "digest"
+)
+
+// LLMUsage — одна попытка вызова модели.
+type LLMUsage struct {
+	Id               int64     `json:"id" gorm:"primaryKey"`
+	Feature          string    `json:"feature" gorm:"column:feature"`
+	Model            string    `json:"model" gorm:"column:model"`
+	Success          bool      `json:"success" gorm:"column:success"`
+	PromptTokens     int       `json:"promptTokens" gorm:"column:prompt_tokens"`
+	CompletionTokens int       `json:"completionTokens" gorm:"column:completion_tokens"`
+	TotalTokens      int       `json:"totalTokens" gorm:"column:total_tokens"`
+	LatencyMs        int       `json:"latencyMs" gorm:"column:latency_ms"`
+	Error            string    `json:"error" gorm:"column:error"`
+	CreatedAt        time.Time `json:"createdAt" gorm:"column:created_at;autoCreateTime"`
+}
+
+func (LLMUsage) TableName() string {
+	return "llm_usage"
+}
+
+// LLMModelStats — агрегат по модели за период (админка).
+type LLMModelStats struct {
+	Model            string  `json:"model"`
+	Calls            int64   `json:"calls"`
+	Failures         int64   `json:"failures"`
+	PromptTokens     int64   `json:"promptTokens"`
+	CompletionTokens int64   `json:"completionTokens"`
+	TotalTokens      int64   `json:"totalTokens"`
+	AvgLatencyMs     float64 `json:"avgLatencyMs"`
+	MaxLatencyMs     int64   `json:"maxLatencyMs"`
+	InChain          bool    `json:"inChain"` // модель из текущей цепочки fallback
+}
+
+// LLMFeatureStats — агрегат по фиче за период.
+type LLMFeatureStats struct {
+	Feature     string `json:"feature"`
+	Calls       int64  `json:"calls"`
+	Failures    int64  `json:"failures"`
+	TotalTokens int64  `json:"totalTokens"`
+}
@@ -35,6 +35,9 @@ type Member struct {
 	Roles       []Role       `json:"roles" gorm:"-:all"`
 	Birthday    *DateOnly    `json:"birthday" gorm:"column:birthday"`
 	CreatedAt   time.Time    `json:"createdAt" gorm:"column:created_at;autoCreateTime"`
+	// Language — язык интерфейса бота и ответов API (i18n.Lang). Пусто —
+	// ещё не выбран: берём из language_code Telegram при первом входе.
+	Language string `json:"language" gorm:"column:language;default:''"`
 	// ReferralCode — персональный код юзера для приглашения в сообщество.
 	// 8 символов Crockford-base32. Заполняется stratup-job'ом + при создании
 	// нового члена. Не отдаём наружу через json чтобы случайно не засветить
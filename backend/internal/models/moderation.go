@@ -12,6 +12,14 @@ const (
 	ModerationActionVotebanKick = "voteban_kick"
 	ModerationActionGlobalBan   = "globalban"
 	ModerationActionGlobalUnban = "globalunban"
+	ModerationActionRaidStart   = "raid_start"
+	ModerationActionRaidEnd     = "raid_end"
+	ModerationActionSettings    = "settings" // изменение настроек / модераторов чата
+	// Общие бан-листы: бан при вступлении по записи из списка, импорт файла,
+	// синхронизация с внешним URL.
+	ModerationActionBanlistBan    = "banlist_ban"
+	ModerationActionBanlistImport = "banlist_import"
+	ModerationActionBanlistSync   = "banlist_sync"
 )
 
 // ModerationActionsWithExpiry — действия, для которых имеет смысл слать
@@ -83,6 +91,7 @@ type VotebanVote struct {
 	VotebanID   int64     `json:"votebanId" gorm:"column:voteban_id;primaryKey"`
 	VoterUserID int64     `json:"voterUserId" gorm:"column:voter_user_id;primaryKey"`
 	Vote        int16     `json:"vote" gorm:"column:vote"`
+	Weight      float64   `json:"weight" gorm:"column:weight"` // вес по репутации на момент голоса
 	CreatedAt   time.Time `json:"createdAt" gorm:"column:created_at"`
 	UpdatedAt   time.Time `json:"updatedAt" gorm:"column:updated_at"`
 }
@@ -91,10 +100,25 @@ func (VotebanVote) TableName() string {
 	return "bot_voteban_votes"
 }
 
-// VotebanTally — агрегированные счётчики голосов.
+// VotebanTally — агрегированные счётчики голосов. For/Against — число
+// голосующих, ForWeight/AgainstWeight — сумма их весов по репутации; порог
+// сравнивается со взвешенной суммой.
 type VotebanTally struct {
-	For     int `json:"for"`
-	Against int `json:"against"`
+	For           int     `json:"for"`
+	Against       int     `json:"against"`
+	ForWeight     float64 `json:"forWeight"`
+	AgainstWeight float64 `json:"againstWeight"`
+}
+
+// ForReached — набрал ли взвешенный «за» порог required. Веса округлены до
+// 0.1, сумма float может «не дотянуть» на 1e-15 — сравниваем с допуском.
+func (t VotebanTally) ForReached(required int) bool {
+	return t.ForWeight+1e-9 >= float64(required)
+}
+
+// AgainstReached — то же для «против» (порог симметричный).
+func (t VotebanTally) AgainstReached(required int) bool {
+	return t.AgainstWeight+1e-9 >= float64(required)
 }
 
 // GlobalBan — запись о глобальной блокировке пользователя. ExpiresAt=nil
@@ -104,10 +128,18 @@ type GlobalBan struct {
 	BannedBy  int64      `json:"bannedBy" gorm:"column:banned_by"`
 	Reason    *string    `json:"reason" gorm:"column:reason"`
 	ExpiresAt *time.Time `json:"expiresAt" gorm:"column:expires_at"`
+	Source    string     `json:"source" gorm:"column:source;default:manual"`
 	CreatedAt time.Time  `json:"createdAt" gorm:"column:created_at"`
 	UpdatedAt time.Time  `json:"updatedAt" gorm:"column:updated_at"`
 }
 
+// Источники глобального бана (bot_global_bans.source).
+const (
+	GlobalBanSourceManual    = "manual"    // /globalban — сразу во всех чатах
+	GlobalBanSourceImport    = "import"    // файл от партнёрского сообщества
+	GlobalBanSourceBlocklist = "blocklist" // синхронизация с BANLIST_SYNC_URL
+)
+
 func (GlobalBan) TableName() string {
 	return "bot_global_bans"
 }
@@ -119,3 +151,138 @@ func (g GlobalBan) IsActive(now time.Time) bool {
 	}
 	return g.ExpiresAt.After(now)
 }
+
+const (
+	RaidStatusActive     = "active"      // режим включён: новых ограничиваем, инвайты закрыты
+	RaidStatusEnded      = "ended"       // режим истёк сам, вступившие ещё ограничены — ждём решения модератора
+	RaidStatusRolledBack = "rolled_back" // все вступившие в окно забанены
+	RaidStatusReleased   = "released"    // ложная тревога — ограничения сняты
+)
+
+// Raid — режим рейда в чате: волна вступлений за короткое окно. Пока запись
+// active, каждый новый участник попадает в bot_raid_joins и ограничивается.
+type Raid struct {
+	Id               int64      `json:"id" gorm:"primaryKey"`
+	ChatID           int64      `json:"chatId" gorm:"column:chat_id"`
+	ChatTitle        string     `json:"chatTitle" gorm:"column:chat_title"`
+	Status           string     `json:"status" gorm:"column:status"`
+	TriggerJoins     int        `json:"triggerJoins" gorm:"column:trigger_joins"`
+	StartedBy        int64      `json:"startedBy" gorm:"column:started_by"` // 0 — включён автоматически
+	EndedBy          *int64     `json:"endedBy" gorm:"column:ended_by"`
+	SavedPermissions string     `json:"-" gorm:"column:saved_permissions;type:jsonb;default:'{}'"`
+	ExpiresAt        time.Time  `json:"expiresAt" gorm:"column:expires_at"`
+	EndedAt          *time.Time `json:"endedAt" gorm:"column:ended_at"`
+	CreatedAt        time.Time  `json:"createdAt" gorm:"column:created_at"`
+}
+
+func (Raid) TableName() string {
+	return "bot_raids"
+}
+
+// RaidJoin — участник, вступивший в чат в окно рейда.
+type RaidJoin struct {
+	RaidID     int64     `json:"raidId" gorm:"column:raid_id;primaryKey"`
+	UserID     int64     `json:"userId" gorm:"column:user_id;primaryKey"`
+	Username   string    `json:"username" gorm:"column:username"`
+	FirstName  string    `json:"firstName" gorm:"column:first_name"`
+	JoinedAt   time.Time `json:"joinedAt" gorm:"column:joined_at"`
+	Restricted bool      `json:"restricted" gorm:"column:restricted"`
+	Banned     bool      `json:"banned" gorm:"column:banned"`
+}
+
+func (RaidJoin) TableName() string {
+	return "bot_raid_joins"
+}
+
+// ChatModerationSettings — настройки модерации конкретного чата. Нет строки —
+// действуют дефолты (service.DefaultChatModerationSettings).
+type ChatModerationSettings struct {
+	ChatID                int64     `json:"chatId" gorm:"column:chat_id;primaryKey;autoIncrement:false"`
+	VotebanWindowSeconds  int       `json:"votebanWindowSeconds" gorm:"column:voteban_window_seconds"`
+	VotebanKickSeconds    int       `json:"votebanKickSeconds" gorm:"column:voteban_kick_seconds"`
+	MuteDefaultSeconds    int       `json:"muteDefaultSeconds" gorm:"column:mute_default_seconds"` // 0 — бессрочно
+	CleanupDefaultSeconds int       `json:"cleanupDefaultSeconds" gorm:"column:cleanup_default_seconds"`
+	CleanupMaxSeconds     int       `json:"cleanupMaxSeconds" gorm:"column:cleanup_max_seconds"`
+	UpdatedBy             int64     `json:"updatedBy" gorm:"column:updated_by"`
+	UpdatedAt             time.Time `json:"updatedAt" gorm:"column:updated_at"`
+}
+
+func (ChatModerationSettings) TableName() string {
+	return "bot_chat_moderation_settings"
+}
+
+// Полномочия дополнительного модератора.
+const (
+	ModeratorPowerMute    = "mute"
+	ModeratorPowerBan     = "ban"
+	ModeratorPowerCleanup = "cleanup"
+)
+
+// ChatModerator — волонтёр-модератор чата без прав Telegram-админа.
+// UserID — telegram id.
+type ChatModerator struct {
+	ChatID     int64     `json:"chatId" gorm:"column:chat_id;primaryKey;autoIncrement:false"`
+	UserID     int64     `json:"userId" gorm:"column:user_id;primaryKey;autoIncrement:false"`
+	Username   string    `json:"username" gorm:"column:username"`
+	CanMute    bool      `json:"canMute" gorm:"column:can_mute"`
+	CanBan     bool      `json:"canBan" gorm:"column:can_ban"`
+	CanCleanup bool      `json:"canCleanup" gorm:"column:can_cleanup"`
+	AddedBy    int64     `json:"addedBy" gorm:"column:added_by"`
+	CreatedAt  time.Time `json:"createdAt" gorm:"column:created_at"`
+	UpdatedAt  time.Time `json:"updatedAt" gorm:"column:updated_at"`
+}
+
+func (ChatModerator) TableName() string {
+	return "bot_chat_moderators"
+}
+
+// Has — есть ли у модератора полномочие. Бан строже мута, поэтому can_ban
+// включает и мут.
+func (m *ChatModerator) Has(power string) bool {
+	switch power {
+	case ModeratorPowerMute:
+		return m.CanMute || m.CanBan
+	case ModeratorPowerBan:
+		return m.CanBan
+	case ModeratorPowerCleanup:
+		return m.CanCleanup
+	}
+	return false
+}
+
+// ChatJoin — вступление в чат по данным бота (chat_member-апдейты).
+type ChatJoin struct {
+	ChatID        int64     `json:"chatId" gorm:"column:chat_id;primaryKey;autoIncrement:false"`
+	UserID        int64     `json:"userId" gorm:"column:user_id;primaryKey;autoIncrement:false"`
+	FirstJoinedAt time.Time `json:"firstJoinedAt" gorm:"column:first_joined_at"`
+	LastJoinedAt  time.Time `json:"lastJoinedAt" gorm:"column:last_joined_at"`
+}
+
+func (ChatJoin) TableName() string {
+	return "bot_chat_joins"
+}
+
+// ChatRestrictionPolicy — ограничения для новичков и rate limit в трекаемом
+// чате. Нет строки — ограничений нет.
+type ChatRestrictionPolicy struct {
+	ChatID                 int64     `json:"chatId" gorm:"column:chat_id;primaryKey;autoIncrement:false"`
+	NewMemberDays          int       `json:"newMemberDays" gorm:"column:new_member_days"` // 0 — выключено
+	BlockLinks             bool      `json:"blockLinks" gorm:"column:block_links"`
+	BlockMedia             bool      `json:"blockMedia" gorm:"column:block_media"`
+	BlockForwards          bool      `json:"blockForwards" gorm:"column:block_forwards"`
+	RateLimitMessages      int       `json:"rateLimitMessages" gorm:"column:rate_limit_messages"` // 0 — выключено
+	RateLimitWindowSeconds int       `json:"rateLimitWindowSeconds" gorm:"column:rate_limit_window_seconds"`
+	ExemptTierLevel        int       `json:"exemptTierLevel" gorm:"column:exempt_tier_level"` // 0 — тир не освобождает
+	UpdatedBy              int64     `json:"updatedBy" gorm:"column:updated_by"`
+	UpdatedAt              time.Time `json:"updatedAt" gorm:"column:updated_at"`
+}
+
+func (ChatRestrictionPolicy) TableName() string {
+	return "bot_chat_restriction_policies"
+}
+
+// Enabled — действует ли хоть одно ограничение.
+func (p *ChatRestrictionPolicy) Enabled() bool {
+	newbie := p.NewMemberDays > 0 && (p.BlockLinks || p.BlockMedia || p.BlockForwards)
+	return newbie || p.RateLimitMessages > 0
+}
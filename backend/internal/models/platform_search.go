@@ -0,0 +1,21 @@
+package models
+
+import "time"
+
+// Типы сущностей в поиске по платформе (REST /search и inline-режим бота).
+const (
+	PlatformSearchEvent      = "event"
+	PlatformSearchMentor     = "mentor"
+	PlatformSearchMember     = "member"
+	PlatformSearchAIMaterial = "ai_material"
+)
+
+// PlatformSearchHit — найденная сущность платформы.
+type PlatformSearchHit struct {
+	Kind     string     `json:"kind"`
+	ID       int64      `json:"id"`
+	Title    string     `json:"title"`
+	Subtitle string     `json:"subtitle"`           // должность, грейд, место события, краткое описание
+	Date     *time.Time `json:"date,omitempty"`     // только события
+	ImageURL string     `json:"imageUrl,omitempty"` // аватар участника или ментора
+}
@@ -42,6 +42,14 @@ const (
 	PointReasonDailyAllTasksBonus  PointReason = "daily_all_tasks_bonus"
 	PointReasonChallengeComplete   PointReason = "challenge_complete"
 	PointReasonDailyRaffleWin      PointReason = "daily_raffle_win"
+	PointReasonShopPurchase        PointReason = "shop_purchase"
+	PointReasonShopRefund          PointReason = "shop_refund"
+	PointReasonLeaderboardPrize    PointReason = "leaderboard_prize"
+	PointReasonClawback            PointReason = "clawback"
+	PointReasonReversal            PointReason = "reversal"
+	PointReasonCasinoJackpot       PointReason = "casino_jackpot"
+	PointReasonStreakFreeze        PointReason = "streak_freeze"
+	PointReasonStreakRepair        PointReason = "streak_repair"
 )
 
 var PointValues = map[PointReason]int{
@@ -82,6 +90,7 @@ type PointTransaction struct {
 	SourceType  string      `json:"sourceType" gorm:"column:source_type;size:50;not null"`
 	SourceId    int64       `json:"sourceId" gorm:"column:source_id;not null;default:0"`
 	Description string      `json:"description" gorm:"column:description;default:''"`
+	ReversesId  *int64      `json:"reversesId,omitempty" gorm:"column:reverses_id"`
 	CreatedAt   time.Time   `json:"createdAt" gorm:"column:created_at;autoCreateTime"`
 }
 
@@ -114,11 +123,98 @@ type AdminPointTransaction struct {
 	Reason          PointReason `json:"reason"`
 	SourceType      string      `json:"sourceType"`
 	Description     string      `json:"description"`
+	ReversesId      *int64      `json:"reversesId"`
+	ReversedById    *int64      `json:"reversedById"`
 	CreatedAt       time.Time   `json:"createdAt"`
 }
 
+// AdminReverseRequest — причина сторно обязательна.
+type AdminReverseRequest struct {
+	Reason string `json:"reason"`
+}
+
+// PointsBalanceMismatch — расхождение снапшота баланса с журналом.
+type PointsBalanceMismatch struct {
+	MemberId int64 `json:"memberId"`
+	Snapshot int   `json:"snapshot"`
+	Ledger   int   `json:"ledger"`
+}
+
+// PointsReconcileReport — итог сверки снапшотов с журналом; расхождения
+// исправлены в пользу журнала.
+type PointsReconcileReport struct {
+	Checked    int64                   `json:"checked"`
+	Mismatches []PointsBalanceMismatch `json:"mismatches"`
+}
+
 type AdminAwardRequest struct {
 	MemberId    int64  `json:"memberId"`
 	Amount      int    `json:"amount"`
 	Description string `json:"description"`
 }
+
+// Журналы для выгрузки: баллы и реферальные кредиты.
+const (
+	LedgerPoints  = "points"
+	LedgerCredits = "credits"
+)
+
+// LedgerExportFilter — фильтр выгрузки журнала. MemberId 0 — все
+// участники (только для админки), From/To — полуинтервал [From, To).
+type LedgerExportFilter struct {
+	Ledger   string
+	MemberId int64
+	From     *time.Time
+	To       *time.Time
+	Reasons  []string
+}
+
+// LedgerExportRow — строка выгрузки, общая для обоих журналов.
+type LedgerExportRow struct {
+	Id             int64     `json:"id"`
+	MemberId       int64     `json:"memberId"`
+	MemberUsername string    `json:"memberUsername"`
+	Amount         int       `json:"amount"`
+	Reason         string    `json:"reason"`
+	SourceType     string    `json:"sourceType"`
+	SourceId       int64     `json:"sourceId"`
+	Description    string    `json:"description"`
+	CreatedAt      time.Time `json:"createdAt"`
+}
+
+// PointsReasonTotal — сумма и число операций по одной причине за период.
+type PointsReasonTotal struct {
+	Reason PointReason `json:"reason"`
+	Amount int         `json:"amount"`
+	Count  int         `json:"count"`
+}
+
+// PointsStatement — выписка участника за месяц. Balance — баланс по
+// журналу на конец периода.
+type PointsStatement struct {
+	MemberId      int64               `json:"memberId"`
+	Month         string              `json:"month"`
+	From          time.Time           `json:"from"`
+	To            time.Time           `json:"to"`
+	Earned        int                 `json:"earned"`
+	Spent         int                 `json:"spent"`
+	Net           int                 `json:"net"`
+	Balance       int                 `json:"balance"`
+	ByReason      []PointsReasonTotal `json:"byReason"`
+	CreditsEarned int                 `json:"creditsEarned"`
+	CreditsSpent  int                 `json:"creditsSpent"`
+}
+
+// PointsStatementRecord — собранная ежемесячная выписка в очереди бота.
+// TelegramID подтягивается из members при выборке неотправленных.
+type PointsStatementRecord struct {
+	Id         int64      `json:"id" gorm:"primaryKey"`
+	MemberId   int64      `json:"memberId" gorm:"column:member_id;not null"`
+	Month      time.Time  `json:"month" gorm:"column:month;type:date;not null"`
+	Payload    string     `json:"payload" gorm:"column:payload;type:jsonb;not null"`
+	SentAt     *time.Time `json:"sentAt" gorm:"column:sent_at"`
+	CreatedAt  time.Time  `json:"createdAt" gorm:"column:created_at;autoCreateTime"`
+	TelegramID int64      `json:"-" gorm:"->;column:telegram_id"`
+}
+
+func (PointsStatementRecord) TableName() string { return "points_statements" }
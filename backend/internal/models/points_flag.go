@@ -0,0 +1,80 @@
+package models
+
+import "time"
+
+const (
+	PointsFlagKudosRing    = "kudos_ring"
+	PointsFlagEarningBurst = "earning_burst"
+	PointsFlagCasinoStreak = "casino_streak"
+)
+
+const (
+	PointsFlagOpen       = "open"
+	PointsFlagDismissed  = "dismissed"
+	PointsFlagClawedBack = "clawed_back"
+)
+
+// PointsCap — лимит начислений по reason'у (app_settings points_caps);
+// 0 — без лимита.
+type PointsCap struct {
+	Daily  int `json:"daily"`
+	Weekly int `json:"weekly"`
+}
+
+// PointsFlag — подозрительная активность в очереди на проверку админом.
+type PointsFlag struct {
+	Id              int64      `json:"id" gorm:"primaryKey"`
+	Kind            string     `json:"kind" gorm:"column:kind;size:32;not null"`
+	MemberId        int64      `json:"memberId" gorm:"column:member_id;not null"`
+	RelatedMemberId *int64     `json:"relatedMemberId" gorm:"column:related_member_id"`
+	Score           int        `json:"score" gorm:"column:score;not null;default:0"`
+	Details         string     `json:"details" gorm:"column:details;not null;default:''"`
+	DedupKey        string     `json:"-" gorm:"column:dedup_key;size:128;not null"`
+	WindowStart     time.Time  `json:"windowStart" gorm:"column:window_start;not null"`
+	WindowEnd       time.Time  `json:"windowEnd" gorm:"column:window_end;not null"`
+	Status          string     `json:"status" gorm:"column:status;size:16;not null;default:'open'"`
+	ResolvedBy      *int64     `json:"resolvedBy" gorm:"column:resolved_by"`
+	ResolvedByName  string     `json:"resolvedByName" gorm:"column:resolved_by_name;not null;default:''"`
+	ResolutionNote  string     `json:"resolutionNote" gorm:"column:resolution_note;not null;default:''"`
+	ResolvedAt      *time.Time `json:"resolvedAt" gorm:"column:resolved_at"`
+	CreatedAt       time.Time  `json:"createdAt" gorm:"column:created_at;autoCreateTime"`
+}
+
+func (PointsFlag) TableName() string {
+	return "points_flags"
+}
+
+// AdminPointsFlag — флаг с данными участников для админки.
+type AdminPointsFlag struct {
+	PointsFlag
+	MemberFirstName string `json:"memberFirstName"`
+	MemberLastName  string `json:"memberLastName"`
+	MemberUsername  string `json:"memberUsername"`
+	RelatedUsername string `json:"relatedUsername"`
+}
+
+// PointsFlagDetail — флаг и транзакции его участников за окно сигнала;
+// уже списанные — с ReversedById.
+type PointsFlagDetail struct {
+	Flag         AdminPointsFlag         `json:"flag"`
+	Transactions []AdminPointTransaction `json:"transactions"`
+}
+
+// PointsClawbackRequest — какие транзакции флага списать и почему.
+type PointsClawbackRequest struct {
+	TransactionIds []int64 `json:"transactionIds"`
+	Reason         string  `json:"reason"`
+}
+
+// PointsFlagDismissRequest — флаг ложный.
+type PointsFlagDismissRequest struct {
+	Note string `json:"note"`
+}
+
+// PointsClawback — списанная транзакция и компенсирующая запись.
+type PointsClawback struct {
+	TransactionId int64       `json:"transactionId"`
+	MemberId      int64       `json:"memberId"`
+	Amount        int         `json:"amount"`
+	Reason        PointReason `json:"reason"`
+}
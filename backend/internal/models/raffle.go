@@ -41,7 +41,80 @@ type Raffle struct {
 	EntryRule   RaffleEntryRule `json:"entryRule" gorm:"column:entry_rule;size:24;default:'purchase'"`
 	DayKey      *time.Time      `json:"dayKey" gorm:"column:day_key;type:date"`
 	WinnerId    *int64          `json:"winnerId" gorm:"column:winner_id"`
+	ServerSeed  string          `json:"-" gorm:"column:server_seed"`
+	SeedHash    string          `json:"seedHash" gorm:"column:seed_hash"`
+	DrawnAt     *time.Time      `json:"drawnAt" gorm:"column:drawn_at"`
+	AnnouncedAt *time.Time      `json:"-" gorm:"column:announced_at"`
 	CreatedAt   time.Time       `json:"createdAt" gorm:"column:created_at;autoCreateTime"`
+	// Prizes — призовые места при создании и в админском списке.
+	Prizes []RafflePrize `json:"prizes" gorm:"-"`
+}
+
+// RafflePrize — призовое место: WinnersCount победителей получают Title.
+type RafflePrize struct {
+	Id           int64  `json:"id" gorm:"primaryKey"`
+	RaffleId     int64  `json:"raffleId" gorm:"column:raffle_id;not null"`
+	Position     int    `json:"position" gorm:"column:position;not null"`
+	Title        string `json:"title" gorm:"column:title;not null"`
+	WinnersCount int    `json:"winnersCount" gorm:"column:winners_count;not null"`
+}
+
+func (RafflePrize) TableName() string {
+	return "raffle_prizes"
+}
+
+const (
+	RaffleWinnerPending   = "pending"
+	RaffleWinnerFulfilled = "fulfilled"
+)
+
+// RaffleWinner — победитель розыгрыша и выдача его приза. Place — номер
+// вытянутого слота, призы идут по Position.
+type RaffleWinner struct {
+	Id              int64      `json:"id" gorm:"primaryKey"`
+	RaffleId        int64      `json:"raffleId" gorm:"column:raffle_id;not null"`
+	PrizeId         *int64     `json:"prizeId" gorm:"column:prize_id"`
+	PrizeTitle      string     `json:"prizeTitle" gorm:"column:prize_title;not null"`
+	Place           int        `json:"place" gorm:"column:place;not null"`
+	MemberId        int64      `json:"memberId" gorm:"column:member_id;not null"`
+	Tickets         int        `json:"tickets" gorm:"column:tickets;not null"`
+	Status          string     `json:"status" gorm:"column:status;not null;default:'pending'"`
+	FulfilledAt     *time.Time `json:"fulfilledAt" gorm:"column:fulfilled_at"`
+	FulfillmentNote string     `json:"fulfillmentNote" gorm:"column:fulfillment_note;not null;default:''"`
+	CreatedAt       time.Time  `json:"createdAt" gorm:"column:created_at;autoCreateTime"`
+	MemberFirstName string     `json:"memberFirstName" gorm:"->;column:member_first_name"`
+	MemberLastName  string     `json:"memberLastName" gorm:"->;column:member_last_name"`
+	MemberUsername  string     `json:"memberUsername" gorm:"->;column:member_username"`
+}
+
+func (RaffleWinner) TableName() string {
+	return "raffle_winners"
+}
+
+// RaffleEntrant — участник жеребьёвки и число его билетов.
+type RaffleEntrant struct {
+	MemberId int64 `json:"memberId"`
+	Tickets  int   `json:"tickets"`
+}
+
+// RaffleDrawProof — всё, что нужно для самостоятельной проверки
+// жеребьёвки, и результат её пересчёта на сервере.
+type RaffleDrawProof struct {
+	RaffleId    int64           `json:"raffleId"`
+	ServerSeed  string          `json:"serverSeed"`
+	SeedHash    string          `json:"seedHash"`
+	ClientSeed  string          `json:"clientSeed"`
+	HashMatches bool            `json:"hashMatches"`
+	Entrants    []RaffleEntrant `json:"entrants"`
+	Prizes      []RafflePrize   `json:"prizes"`
+	Winners     []RaffleWinner  `json:"winners"`
+	Computed    []int64         `json:"computed"`
+	Valid       bool            `json:"valid"`
+}
+
+type RaffleFulfillmentRequest struct {
+	Status string `json:"status"`
+	Note   string `json:"note"`
 }
 
 type RaffleTicket struct {
@@ -83,6 +156,11 @@ type RafflePublic struct {
 	WinnerLastName  string          `json:"winnerLastName,omitempty"`
 	WinnerUsername  string          `json:"winnerUsername,omitempty"`
 	WinnerAvatarURL string          `json:"winnerAvatarUrl,omitempty"`
+	SeedHash        string          `json:"seedHash"`
+	// ServerSeed раскрывается после розыгрыша.
+	ServerSeed string         `json:"serverSeed,omitempty"`
+	Prizes     []RafflePrize  `json:"prizes" gorm:"-"`
+	Winners    []RaffleWinner `json:"winners" gorm:"-"`
 	// MySources — какие источники уже принесли юзеру билет в этом раффле.
 	// Пример: ["check_in","daily_task"]. Используется во фронте для подсветки
 	// «как ещё получить билет». Сейчас заполняется только для daily-раффла.
@@ -0,0 +1,134 @@
+package models
+
+import (
+	"time"
+
+	"ithozyeva/internal/s3resolve"
+
+	"gorm.io/gorm"
+)
+
+// Виды наград в магазине. Выдачу ведут админы через заказы; вид нужен
+// витрине и тем, кто выдаёт. Исключение — streak_freeze: заморозки
+// зачисляются на стрик при покупке, и заказ сразу выдан.
+const (
+	ShopItemMerch            = "merch"
+	ShopItemMentorSession    = "mentor_session"
+	ShopItemSubscriptionDays = "subscription_days"
+	ShopItemStreakFreeze     = "streak_freeze"
+	ShopItemCosmetic         = "cosmetic"
+)
+
+// Статусы заказа: pending → shipped → delivered; cancelled — с возвратом
+// баллов, из pending или shipped.
+const (
+	ShopOrderPending   = "pending"
+	ShopOrderShipped   = "shipped"
+	ShopOrderDelivered = "delivered"
+	ShopOrderCancelled = "cancelled"
+)
+
+// ShopItem — позиция каталога. Stock nil — без ограничения,
+// PerMemberLimit 0 — сколько угодно в одни руки.
+type ShopItem struct {
+	Id             int64     `json:"id" gorm:"primaryKey"`
+	Kind           string    `json:"kind" gorm:"column:kind"`
+	Title          string    `json:"title" gorm:"column:title"`
+	Description    string    `json:"description" gorm:"column:description"`
+	ImageURL       string    `json:"imageUrl" gorm:"column:image_url"`
+	Price          int       `json:"price" gorm:"column:price"`
+	Quantity       int       `json:"quantity" gorm:"column:quantity"`
+	Stock          *int      `json:"stock" gorm:"column:stock"`
+	PerMemberLimit int       `json:"perMemberLimit" gorm:"column:per_member_limit"`
+	IsActive       bool      `json:"isActive" gorm:"column:is_active"`
+	SortOrder      int       `json:"sortOrder" gorm:"column:sort_order"`
+	CreatedAt      time.Time `json:"createdAt" gorm:"column:created_at;autoCreateTime"`
+	UpdatedAt      time.Time `json:"updatedAt" gorm:"column:updated_at;autoUpdateTime"`
+}
+
+func (ShopItem) TableName() string {
+	return "shop_items"
+}
+
+func (i *ShopItem) AfterFind(tx *gorm.DB) (err error) {
+	i.ImageURL = s3resolve.ResolveS3URL(i.ImageURL)
+	return nil
+}
+
+// ShopItemPublic — позиция витрины с покупками текущего участника.
+type ShopItemPublic struct {
+	ShopItem
+	MyOrders int `json:"myOrders"` // без отменённых
+}
+
+// ShopOrder — покупка. ItemTitle и Price — на момент покупки.
+type ShopOrder struct {
+	Id          int64      `json:"id" gorm:"primaryKey"`
+	ItemId      int64      `json:"itemId" gorm:"column:item_id"`
+	MemberId    int64      `json:"memberId" gorm:"column:member_id"`
+	ItemTitle   string     `json:"itemTitle" gorm:"column:item_title"`
+	Price       int        `json:"price" gorm:"column:price"`
+	Status      string     `json:"status" gorm:"column:status"`
+	Comment     string     `json:"comment" gorm:"column:comment"`
+	AdminNote   string     `json:"adminNote" gorm:"column:admin_note"`
+	CreatedAt   time.Time  `json:"createdAt" gorm:"column:created_at;autoCreateTime"`
+	UpdatedAt   time.Time  `json:"updatedAt" gorm:"column:updated_at;autoUpdateTime"`
+	ShippedAt   *time.Time `json:"shippedAt" gorm:"column:shipped_at"`
+	DeliveredAt *time.Time `json:"deliveredAt" gorm:"column:delivered_at"`
+	CancelledAt *time.Time `json:"cancelledAt" gorm:"column:cancelled_at"`
+}
+
+func (ShopOrder) TableName() string {
+	return "shop_orders"
+}
+
+// AdminShopOrder — заказ с покупателем и видом награды (админка).
+type AdminShopOrder struct {
+	ShopOrder
+	ItemKind        string `json:"itemKind"`
+	MemberFirstName string `json:"memberFirstName"`
+	MemberLastName  string `json:"memberLastName"`
+	MemberUsername  string `json:"memberUsername"`
+	MemberTgID      int64  `json:"memberTelegramId"`
+}
+
+// ShopItemRequest — тело создания/редактирования позиции. IsActive не
+// передан — позиция в продаже.
+type ShopItemRequest struct {
+	Kind           string `json:"kind"`
+	Title          string `json:"title"`
+	Description    string `json:"description"`
+	ImageURL       string `json:"imageUrl"`
+	Price          int    `json:"price"`
+	Quantity       int    `json:"quantity"`
+	Stock          *int   `json:"stock"`
+	PerMemberLimit int    `json:"perMemberLimit"`
+	IsActive       *bool  `json:"isActive"`
+	SortOrder      int    `json:"sortOrder"`
+}
+
+func (r ShopItemRequest) ToItem() *ShopItem {
+	return &ShopItem{
+		Kind:           r.Kind,
+		Title:          r.Title,
+		Description:    r.Description,
+		ImageURL:       r.ImageURL,
+		Price:          r.Price,
+		Quantity:       r.Quantity,
+		Stock:          r.Stock,
+		PerMemberLimit: r.PerMemberLimit,
+		IsActive:       r.IsActive == nil || *r.IsActive,
+		SortOrder:      r.SortOrder,
+	}
+}
+
+// ShopPurchaseRequest — покупка; Comment — контакты, размер и т.п.
+type ShopPurchaseRequest struct {
+	Comment string `json:"comment"`
+}
+
+// ShopOrderStatusRequest — смена статуса заказа админом.
+type ShopOrderStatusRequest struct {
+	Status    string `json:"status"`
+	AdminNote string `json:"adminNote"`
+}
@@ -16,14 +16,17 @@ func (DailyCheckIn) TableName() string {
 
 // MemberStreak — сводка по стрику юзера
 type MemberStreak struct {
-	MemberId          int64      `json:"memberId" gorm:"column:member_id;primaryKey"`
-	CurrentStreak     int        `json:"currentStreak" gorm:"column:current_streak;not null;default:0"`
-	LongestStreak     int        `json:"longestStreak" gorm:"column:longest_streak;not null;default:0"`
-	LastCheckInDate   *time.Time `json:"lastCheckInDate" gorm:"column:last_check_in_date;type:date"`
-	FreezesAvailable  int        `json:"freezesAvailable" gorm:"column:freezes_available;not null;default:1"`
-	FreezeWeekYear    *int       `json:"freezeWeekYear" gorm:"column:freeze_week_year"`
-	FreezeWeekNum     *int       `json:"freezeWeekNum" gorm:"column:freeze_week_num"`
-	UpdatedAt         time.Time  `json:"updatedAt" gorm:"column:updated_at;autoUpdateTime"`
+	MemberId         int64      `json:"memberId" gorm:"column:member_id;primaryKey"`
+	CurrentStreak    int        `json:"currentStreak" gorm:"column:current_streak;not null;default:0"`
+	LongestStreak    int        `json:"longestStreak" gorm:"column:longest_streak;not null;default:0"`
+	LastCheckInDate  *time.Time `json:"lastCheckInDate" gorm:"column:last_check_in_date;type:date"`
+	FreezesAvailable int        `json:"freezesAvailable" gorm:"column:freezes_available;not null;default:1"`
+	FreezeWeekYear   *int       `json:"freezeWeekYear" gorm:"column:freeze_week_year"`
+	FreezeWeekNum    *int       `json:"freezeWeekNum" gorm:"column:freeze_week_num"`
+	PurchasedFreezes int        `json:"purchasedFreezes" gorm:"column:purchased_freezes;not null;default:0"`
+	LostStreak       int        `json:"lostStreak" gorm:"column:lost_streak;not null;default:0"`
+	LostAt           *time.Time `json:"lostAt" gorm:"column:lost_at"`
+	UpdatedAt        time.Time  `json:"updatedAt" gorm:"column:updated_at;autoUpdateTime"`
 }
 
 func (MemberStreak) TableName() string {
@@ -37,15 +40,32 @@ type StreakMilestone struct {
 	Reached bool `json:"reached"`
 }
 
-// StreakResponse — ответ GET /streak/me
+// StreakRepairOffer — оборвавшийся стрик, который ещё можно выкупить.
+type StreakRepairOffer struct {
+	LostStreak int       `json:"lostStreak"`
+	Price      int       `json:"price"`
+	Deadline   time.Time `json:"deadline"`
+}
+
+// StreakResponse — ответ GET /streak/me. FreezesAvailable — все
+// заморозки (недельная и купленные) за вычетом уже ушедших на пропуски.
 type StreakResponse struct {
-	Current          int               `json:"current"`
-	Longest          int               `json:"longest"`
-	FreezesAvailable int               `json:"freezesAvailable"`
-	LastCheckIn      *time.Time        `json:"lastCheckIn"`
-	NextThreshold    *int              `json:"nextThreshold"`
-	DaysToNext       *int              `json:"daysToNext"`
-	Milestones       []StreakMilestone `json:"milestones"`
+	Current          int                `json:"current"`
+	Longest          int                `json:"longest"`
+	FreezesAvailable int                `json:"freezesAvailable"`
+	PurchasedFreezes int                `json:"purchasedFreezes"`
+	FreezePrice      int                `json:"freezePrice"`
+	FreezeMax        int                `json:"freezeMax"`
+	Repair           *StreakRepairOffer `json:"repair"`
+	LastCheckIn      *time.Time         `json:"lastCheckIn"`
+	NextThreshold    *int               `json:"nextThreshold"`
+	DaysToNext       *int               `json:"daysToNext"`
+	Milestones       []StreakMilestone  `json:"milestones"`
+}
+
+// StreakFreezePurchaseRequest — тело POST /streak/freezes.
+type StreakFreezePurchaseRequest struct {
+	Quantity int `json:"quantity"`
 }
 
 // CheckInResponse — ответ POST /dailies/check-in
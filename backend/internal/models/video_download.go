@@ -0,0 +1,73 @@
+package models
+
+import "time"
+
+// Исход запроса на скачивание видео.
+const (
+	VideoDownloadSent        = "sent"         // скачали и отправили
+	VideoDownloadCached      = "cached"       // переслали по file_id из кэша
+	VideoDownloadFailed      = "failed"       // yt-dlp или Telegram вернули ошибку
+	VideoDownloadTooLarge    = "too_large"    // больше лимита Telegram
+	VideoDownloadTimeout     = "timeout"      // yt-dlp не уложился в таймаут
+	VideoDownloadRateLimited = "rate_limited" // лимит чата исчерпан
+	VideoDownloadQueueFull   = "queue_full"   // все воркеры заняты, очередь полна
+)
+
+// VideoFileCache — ролик, уже загруженный в Telegram.
+type VideoFileCache struct {
+	SourceURL  string    `json:"sourceUrl" gorm:"column:source_url;primaryKey"`
+	Source     string    `json:"source" gorm:"column:source"`
+	FileID     string    `json:"fileId" gorm:"column:file_id"`
+	FileSize   int64     `json:"fileSize" gorm:"column:file_size"`
+	Hits       int       `json:"hits" gorm:"column:hits"`
+	CreatedAt  time.Time `json:"createdAt" gorm:"column:created_at;autoCreateTime"`
+	LastUsedAt time.Time `json:"lastUsedAt" gorm:"column:last_used_at"`
+}
+
+func (VideoFileCache) TableName() string {
+	return "video_file_cache"
+}
+
+// VideoDownload — один запрос на видео из чата.
+type VideoDownload struct {
+	Id         int64     `json:"id" gorm:"primaryKey"`
+	URL        string    `json:"url" gorm:"column:url"`
+	Source     string    `json:"source" gorm:"column:source"`
+	ChatID     int64     `json:"chatId" gorm:"column:chat_id"`
+	UserID     int64     `json:"userId" gorm:"column:user_id"`
+	Status     string    `json:"status" gorm:"column:status"`
+	DurationMs int       `json:"durationMs" gorm:"column:duration_ms"`
+	FileSize   int64     `json:"fileSize" gorm:"column:file_size"`
+	Error      string    `json:"error" gorm:"column:error"`
+	CreatedAt  time.Time `json:"createdAt" gorm:"column:created_at;autoCreateTime"`
+}
+
+func (VideoDownload) TableName() string {
+	return "video_downloads"
+}
+
+// VideoSourceStats — агрегат по источнику за период (админка).
+type VideoSourceStats struct {
+	Source        string  `json:"source"`
+	Requests      int64   `json:"requests"`
+	Downloaded    int64   `json:"downloaded"`
+	CacheHits     int64   `json:"cacheHits"`
+	Failures      int64   `json:"failures"`      // failed, too_large, timeout
+	Rejected      int64   `json:"rejected"`      // rate_limited, queue_full
+	AvgDurationMs float64 `json:"avgDurationMs"` // только реальные скачивания
+}
+
+// VideoDownloadDay — те же цифры по дням.
+type VideoDownloadDay struct {
+	Day        time.Time `json:"day"`
+	Downloaded int64     `json:"downloaded"`
+	CacheHits  int64     `json:"cacheHits"`
+	Failures   int64     `json:"failures"`
+	Rejected   int64     `json:"rejected"`
+}
+
+// VideoCacheSummary — размер кэша file_id.
+type VideoCacheSummary struct {
+	Entries int64 `json:"entries"`
+	Hits    int64 `json:"hits"`
+}
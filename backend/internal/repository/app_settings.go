@@ -20,3 +20,12 @@ func (r *AppSettingsRepository) GetRaw(key string) ([]byte, error) {
 	).Scan(&raw).Error
 	return raw, err
 }
+
+// Set сохраняет JSON-значение настройки (upsert).
+func (r *AppSettingsRepository) Set(key string, raw []byte) error {
+	return database.DB.Exec(
+		`INSERT INTO app_settings (key, value) VALUES (?, ?::jsonb)
+		 ON CONFLICT (key) DO UPDATE SET value = EXCLUDED.value, updated_at = NOW()`,
+		key, string(raw),
+	).Error
+}
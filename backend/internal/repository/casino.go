@@ -2,14 +2,19 @@ package repository
 
 import (
 	"errors"
+	"time"
 
 	"ithozyeva/database"
 	"ithozyeva/internal/models"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
-var ErrInsufficientBalance = errors.New("недостаточно баллов")
+var (
+	ErrInsufficientBalance = errors.New("недостаточно баллов")
+	ErrNoActiveCasinoSeed  = errors.New("нет активной пары seed'ов")
+)
 
 type CasinoRepository struct{}
 
@@ -17,7 +22,14 @@ func NewCasinoRepository() *CasinoRepository {
 	return &CasinoRepository{}
 }
 
-func (r *CasinoRepository) PlaceBet(memberId int64, bet *models.CasinoBet, won bool) (int, error) {
+// PlaceBet списывает ставку и записывает её исход. play считает исход по
+// активной паре seed'ов и выданному nonce'у (заполняет Result, Multiplier,
+// Payout, Profit) — под тем же advisory-lock'ом, что и проверка баланса,
+// поэтому nonce'ы одной пары не повторяются. Ошибка play (в том числе отказ
+// по лимитам) откатывает ставку целиком. settled вызывается в той же
+// транзакции после записи ставки (bet.Id уже заполнен) — так джекпот
+// пополняется и разыгрывается атомарно со ставкой.
+func (r *CasinoRepository) PlaceBet(memberId int64, bet *models.CasinoBet, play func(tx *gorm.DB, seed *models.CasinoSeed, nonce int) (bool, error), settled func(tx *gorm.DB) error) (int, error) {
 	var balance int
 
 	err := database.DB.Transaction(func(tx *gorm.DB) error {
@@ -32,10 +44,8 @@ func (r *CasinoRepository) PlaceBet(memberId int64, bet *models.CasinoBet, won b
 		}
 
 		// Check balance
-		if err := tx.Raw(
-			`SELECT COALESCE(SUM(amount), 0) FROM point_transactions WHERE member_id = ?`,
-			memberId,
-		).Scan(&balance).Error; err != nil {
+		var err error
+		if balance, err = NewPointsRepository().GetBalanceTx(tx, memberId); err != nil {
 			return err
 		}
 
@@ -43,6 +53,21 @@ func (r *CasinoRepository) PlaceBet(memberId int64, bet *models.CasinoBet, won b
 			return ErrInsufficientBalance
 		}
 
+		seed, err := r.activeSeedForUpdateTx(tx, memberId)
+		if err != nil {
+			return err
+		}
+		nonce := seed.Nonce
+		if err := tx.Model(seed).Update("nonce", gorm.Expr("nonce + 1")).Error; err != nil {
+			return err
+		}
+		bet.SeedId = &seed.Id
+		bet.Nonce = &nonce
+		won, err := play(tx, seed, nonce)
+		if err != nil {
+			return err
+		}
+
 		// Debit bet amount
 		debitTx := &models.PointTransaction{
 			MemberId:    memberId,
@@ -75,12 +100,12 @@ func (r *CasinoRepository) PlaceBet(memberId int64, bet *models.CasinoBet, won b
 		if err := tx.Create(bet).Error; err != nil {
 			return err
 		}
+		if err := settled(tx); err != nil {
+			return err
+		}
 
 		// Recalculate balance
-		if err := tx.Raw(
-			`SELECT COALESCE(SUM(amount), 0) FROM point_transactions WHERE member_id = ?`,
-			memberId,
-		).Scan(&balance).Error; err != nil {
+		if balance, err = NewPointsRepository().GetBalanceTx(tx, memberId); err != nil {
 			return err
 		}
 
@@ -99,7 +124,7 @@ func (r *CasinoRepository) GetHistory(memberId int64, limit, offset int) ([]mode
 	}
 
 	if err := database.DB.Raw(`
-		SELECT id, game, bet_amount, bet_choice, result, multiplier, payout, profit, created_at
+		SELECT id, game, bet_amount, bet_choice, result, multiplier, payout, profit, seed_id, nonce, created_at
 		FROM casino_bets
 		WHERE member_id = ?
 		ORDER BY created_at DESC
@@ -151,6 +176,22 @@ func (r *CasinoRepository) GetAdminStats() (*models.CasinoAdminStats, error) {
 			COUNT(DISTINCT member_id) as unique_players
 		FROM casino_bets
 	`).Scan(stats).Error
+	if err != nil {
+		return nil, err
+	}
+
+	stats.GameStats = make([]models.CasinoGameStats, 0)
+	err = database.DB.Raw(`
+		SELECT
+			game,
+			COUNT(*) as total_bets,
+			COALESCE(SUM(bet_amount), 0) as total_wagered,
+			COALESCE(SUM(payout), 0) as total_payout,
+			COALESCE(SUM(bet_amount), 0) - COALESCE(SUM(payout), 0) as house_profit
+		FROM casino_bets
+		GROUP BY game
+		ORDER BY total_wagered DESC
+	`).Scan(&stats.GameStats).Error
 	return stats, err
 }
 
@@ -185,3 +226,99 @@ func (r *CasinoRepository) SearchBets(username *string, game *string, limit, off
 
 	return items, total, nil
 }
+
+func (r *CasinoRepository) activeSeedForUpdateTx(tx *gorm.DB, memberId int64) (*models.CasinoSeed, error) {
+	var seeds []models.CasinoSeed
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("member_id = ? AND active", memberId).
+		Limit(1).Find(&seeds).Error
+	if err != nil {
+		return nil, err
+	}
+	if len(seeds) == 0 {
+		return nil, ErrNoActiveCasinoSeed
+	}
+	return &seeds[0], nil
+}
+
+// ActiveSeed — текущая пара участника или nil.
+func (r *CasinoRepository) ActiveSeed(memberId int64) (*models.CasinoSeed, error) {
+	var seeds []models.CasinoSeed
+	err := database.DB.Where("member_id = ? AND active", memberId).Limit(1).Find(&seeds).Error
+	if err != nil || len(seeds) == 0 {
+		return nil, err
+	}
+	return &seeds[0], nil
+}
+
+// LastRevealedSeed — последняя раскрытая пара участника или nil.
+func (r *CasinoRepository) LastRevealedSeed(memberId int64) (*models.CasinoSeed, error) {
+	var seeds []models.CasinoSeed
+	err := database.DB.Where("member_id = ? AND NOT active", memberId).
+		Order("revealed_at DESC").Limit(1).Find(&seeds).Error
+	if err != nil || len(seeds) == 0 {
+		return nil, err
+	}
+	return &seeds[0], nil
+}
+
+// CreateSeed заводит первую пару участника; false — активная уже есть
+// (параллельный запрос успел раньше).
+func (r *CasinoRepository) CreateSeed(seed *models.CasinoSeed) (bool, error) {
+	res := database.DB.Clauses(clause.OnConflict{
+		Columns:     []clause.Column{{Name: "member_id"}},
+		TargetWhere: clause.Where{Exprs: []clause.Expression{clause.Expr{SQL: "active"}}},
+		DoNothing:   true,
+	}).Create(seed)
+	return res.RowsAffected > 0, res.Error
+}
+
+// RotateSeed раскрывает активную пару и делает next активной. Под
+// advisory-lock'ом участника, как и ставки, — ротация не разрезает ставку.
+func (r *CasinoRepository) RotateSeed(memberId int64, next *models.CasinoSeed) (*models.CasinoSeed, error) {
+	var prev *models.CasinoSeed
+	err := database.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec(`SELECT pg_advisory_xact_lock(?)`, memberId).Error; err != nil {
+			return err
+		}
+		var err error
+		prev, err = r.activeSeedForUpdateTx(tx, memberId)
+		if err != nil && !errors.Is(err, ErrNoActiveCasinoSeed) {
+			return err
+		}
+		if prev != nil {
+			now := time.Now()
+			prev.Active = false
+			prev.RevealedAt = &now
+			if err := tx.Model(prev).Updates(map[string]interface{}{"active": false, "revealed_at": now}).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Create(next).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return prev, nil
+}
+
+// GetBetWithSeed — ставка и её пара seed'ов (nil, если ставка старше
+// provably fair).
+func (r *CasinoRepository) GetBetWithSeed(betId int64) (*models.CasinoBet, *models.CasinoSeed, error) {
+	var bets []models.CasinoBet
+	if err := database.DB.Where("id = ?", betId).Limit(1).Find(&bets).Error; err != nil {
+		return nil, nil, err
+	}
+	if len(bets) == 0 {
+		return nil, nil, nil
+	}
+	bet := &bets[0]
+	if bet.SeedId == nil {
+		return bet, nil, nil
+	}
+	var seed models.CasinoSeed
+	if err := database.DB.First(&seed, *bet.SeedId).Error; err != nil {
+		return nil, nil, err
+	}
+	return bet, &seed, nil
+}
@@ -0,0 +1,259 @@
+package repository
+
+import (
+	"fmt"
+	"math"
+
+	"ithozyeva/database"
+	"ithozyeva/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type CrashRepository struct{}
+
+func NewCrashRepository() *CrashRepository {
+	return &CrashRepository{}
+}
+
+// OpenRound — незавершённый раунд (приём ставок или полёт); nil — нет.
+func (r *CrashRepository) OpenRound() (*models.CrashRound, error) {
+	return r.openRoundTx(database.DB, "")
+}
+
+func (r *CrashRepository) openRoundTx(db *gorm.DB, status string) (*models.CrashRound, error) {
+	cond, arg := "status <> ?", models.CrashRoundCrashed
+	if status != "" {
+		cond, arg = "status = ?", status
+	}
+	var rounds []models.CrashRound
+	if err := db.Where(cond, arg).Order("id DESC").Limit(1).Find(&rounds).Error; err != nil {
+		return nil, err
+	}
+	if len(rounds) == 0 {
+		return nil, nil
+	}
+	return &rounds[0], nil
+}
+
+// BettingRoundTx — раунд, принимающий ставки, под FOR SHARE: старт раунда
+// (UPDATE статуса) дождётся конца уже начатых ставок.
+func (r *CrashRepository) BettingRoundTx(tx *gorm.DB) (*models.CrashRound, error) {
+	return r.openRoundTx(tx.Clauses(clause.Locking{Strength: "SHARE"}), models.CrashRoundBetting)
+}
+
+// RunningRoundTx — идущий раунд.
+func (r *CrashRepository) RunningRoundTx(tx *gorm.DB) (*models.CrashRound, error) {
+	return r.openRoundTx(tx, models.CrashRoundRunning)
+}
+
+func (r *CrashRepository) GetRound(id int64) (*models.CrashRound, error) {
+	var rounds []models.CrashRound
+	if err := database.DB.Where("id = ?", id).Limit(1).Find(&rounds).Error; err != nil {
+		return nil, err
+	}
+	if len(rounds) == 0 {
+		return nil, nil
+	}
+	return &rounds[0], nil
+}
+
+// GetBet — запись ставки в раунде crash по id ставки в casino_bets.
+func (r *CrashRepository) GetBet(betId int64) (*models.CrashBet, error) {
+	var bets []models.CrashBet
+	if err := database.DB.Where("bet_id = ?", betId).Limit(1).Find(&bets).Error; err != nil {
+		return nil, err
+	}
+	if len(bets) == 0 {
+		return nil, nil
+	}
+	return &bets[0], nil
+}
+
+func (r *CrashRepository) CreateRound(round *models.CrashRound) error {
+	return database.DB.Create(round).Error
+}
+
+// StartRound переводит раунд из приёма ставок в полёт.
+func (r *CrashRepository) StartRound(round *models.CrashRound) error {
+	return database.DB.Model(round).Where("status = ?", models.CrashRoundBetting).
+		Updates(map[string]interface{}{"status": models.CrashRoundRunning, "started_at": round.StartedAt}).Error
+}
+
+func (r *CrashRepository) HasBetTx(tx *gorm.DB, roundId, memberId int64) (bool, error) {
+	var n int64
+	err := tx.Model(&models.CrashBet{}).Where("round_id = ? AND member_id = ?", roundId, memberId).Count(&n).Error
+	return n > 0, err
+}
+
+// PlaceBetTx списывает ставку и записывает её в casino_bets и в раунд.
+// Пока ставка не выведена, в casino_bets она числится проигранной.
+func (r *CrashRepository) PlaceBetTx(tx *gorm.DB, bet *models.CasinoBet, crashBet *models.CrashBet) error {
+	if err := tx.Create(&models.PointTransaction{
+		MemberId:    bet.MemberId,
+		Amount:      -bet.BetAmount,
+		Reason:      models.PointReasonCasinoBet,
+		SourceType:  "casino",
+		SourceId:    0,
+		Description: "Ставка: " + bet.Game,
+	}).Error; err != nil {
+		return err
+	}
+	bet.Profit = -bet.BetAmount
+	if err := tx.Create(bet).Error; err != nil {
+		return err
+	}
+	crashBet.BetId = bet.Id
+	return tx.Create(crashBet).Error
+}
+
+// MemberBetForUpdateTx — ставка участника в раунде под блокировкой строки,
+// чтобы ручной и автоматический вывод не выплатили её дважды.
+func (r *CrashRepository) MemberBetForUpdateTx(tx *gorm.DB, roundId, memberId int64) (*models.CrashBet, error) {
+	var bets []models.CrashBet
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("round_id = ? AND member_id = ?", roundId, memberId).Limit(1).Find(&bets).Error
+	if err != nil || len(bets) == 0 {
+		return nil, err
+	}
+	return &bets[0], nil
+}
+
+// DueAutoCashOutsTx — невыведенные ставки раунда с автовыводом ниже upTo.
+func (r *CrashRepository) DueAutoCashOutsTx(tx *gorm.DB, roundId int64, upTo float64) ([]models.CrashBet, error) {
+	bets := make([]models.CrashBet, 0)
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("round_id = ? AND cashed_out_at IS NULL AND auto_cash_out IS NOT NULL AND auto_cash_out <= ?", roundId, upTo).
+		Order("auto_cash_out, bet_id").Find(&bets).Error
+	return bets, err
+}
+
+// CashOutTx фиксирует вывод на multiplier: выплата в casino_bets и
+// начисление баллов. Возвращает выплату.
+func (r *CrashRepository) CashOutTx(tx *gorm.DB, crashBet *models.CrashBet, multiplier float64) (int, error) {
+	var bet models.CasinoBet
+	if err := tx.Where("id = ?", crashBet.BetId).First(&bet).Error; err != nil {
+		return 0, err
+	}
+	// Множитель — в сотых: считаем в целых, чтобы 100 × 1.15 не дало 114.
+	payout := bet.BetAmount * int(math.Round(multiplier*100)) / 100
+	if err := tx.Model(crashBet).Update("cashed_out_at", multiplier).Error; err != nil {
+		return 0, err
+	}
+	crashBet.CashedOutAt = &multiplier
+	if err := tx.Model(&bet).Updates(map[string]interface{}{
+		"result":     fmt.Sprintf("cashout %.2f", multiplier),
+		"multiplier": multiplier,
+		"payout":     payout,
+		"profit":     payout - bet.BetAmount,
+	}).Error; err != nil {
+		return 0, err
+	}
+	if payout > 0 {
+		if err := tx.Create(&models.PointTransaction{
+			MemberId:    bet.MemberId,
+			Amount:      payout,
+			Reason:      models.PointReasonCasinoWin,
+			SourceType:  "casino",
+			SourceId:    0,
+			Description: "Выигрыш: " + bet.Game,
+		}).Error; err != nil {
+			return 0, err
+		}
+	}
+	return payout, nil
+}
+
+// FinishRoundTx завершает раунд: невыведенные ставки проиграны.
+func (r *CrashRepository) FinishRoundTx(tx *gorm.DB, round *models.CrashRound) error {
+	if err := tx.Model(round).Updates(map[string]interface{}{
+		"status":     models.CrashRoundCrashed,
+		"crashed_at": round.CrashedAt,
+	}).Error; err != nil {
+		return err
+	}
+	return tx.Exec(
+		`UPDATE casino_bets SET result = ?
+		 WHERE id IN (SELECT bet_id FROM casino_crash_bets WHERE round_id = ? AND cashed_out_at IS NULL)`,
+		fmt.Sprintf("crash %.2f", round.CrashPoint), round.Id,
+	).Error
+}
+
+func (r *CrashRepository) RoundBets(roundId int64) ([]models.CrashRoundBet, error) {
+	items := make([]models.CrashRoundBet, 0)
+	err := database.DB.Raw(`
+		SELECT cr.bet_id, cr.member_id, m.first_name AS member_first_name, m.username AS member_username,
+			cb.bet_amount, cr.auto_cash_out, cr.cashed_out_at, cb.payout
+		FROM casino_crash_bets cr
+		JOIN casino_bets cb ON cb.id = cr.bet_id
+		JOIN members m ON m.id = cr.member_id
+		WHERE cr.round_id = ?
+		ORDER BY cb.bet_amount DESC, cr.bet_id
+	`, roundId).Scan(&items).Error
+	return items, err
+}
+
+// RoundBet — одна ставка раунда в том же виде, что и RoundBets.
+func (r *CrashRepository) RoundBet(betId int64) (*models.CrashRoundBet, error) {
+	var items []models.CrashRoundBet
+	err := database.DB.Raw(`
+		SELECT cr.bet_id, cr.member_id, m.first_name AS member_first_name, m.username AS member_username,
+			cb.bet_amount, cr.auto_cash_out, cr.cashed_out_at, cb.payout
+		FROM casino_crash_bets cr
+		JOIN casino_bets cb ON cb.id = cr.bet_id
+		JOIN members m ON m.id = cr.member_id
+		WHERE cr.bet_id = ?
+	`, betId).Scan(&items).Error
+	if err != nil || len(items) == 0 {
+		return nil, err
+	}
+	return &items[0], nil
+}
+
+// RecentCrashPoints — точки краша последних раундов, новые первыми.
+func (r *CrashRepository) RecentCrashPoints(limit int) ([]float64, error) {
+	points := make([]float64, 0)
+	err := database.DB.Model(&models.CrashRound{}).Where("status = ?", models.CrashRoundCrashed).
+		Order("id DESC").Limit(limit).Pluck("crash_point", &points).Error
+	return points, err
+}
+
+// Rounds — история завершённых раундов с оборотом.
+func (r *CrashRepository) Rounds(limit, offset int) ([]models.CrashRoundSummary, int64, error) {
+	items := make([]models.CrashRoundSummary, 0)
+	var total int64
+	if err := database.DB.Model(&models.CrashRound{}).Where("status = ?", models.CrashRoundCrashed).
+		Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	err := database.DB.Raw(`
+		SELECT r.id, r.crash_point, r.server_seed, r.server_seed_hash, r.crashed_at,
+			COUNT(cr.bet_id) AS players,
+			COALESCE(SUM(cb.bet_amount), 0) AS total_wagered,
+			COALESCE(SUM(cb.payout), 0) AS total_payout
+		FROM casino_crash_rounds r
+		LEFT JOIN casino_crash_bets cr ON cr.round_id = r.id
+		LEFT JOIN casino_bets cb ON cb.id = cr.bet_id
+		WHERE r.status = ?
+		GROUP BY r.id
+		ORDER BY r.id DESC
+		LIMIT ? OFFSET ?
+	`, models.CrashRoundCrashed, limit, offset).Scan(&items).Error
+	return items, total, err
+}
+
+func (r *CrashRepository) AdminStats() (models.CasinoCrashAdminStats, error) {
+	var stats models.CasinoCrashAdminStats
+	err := database.DB.Raw(`
+		SELECT COUNT(*) AS rounds,
+			COALESCE(AVG(crash_point), 0) AS avg_crash_point,
+			COALESCE(MAX(crash_point), 0) AS max_crash_point,
+			COUNT(*) FILTER (WHERE crash_point <= 1) AS instant_crashes,
+			COALESCE((SELECT COUNT(*) FROM casino_crash_bets)::float / NULLIF(COUNT(*), 0), 0) AS avg_players,
+			COALESCE((SELECT 100.0 * COUNT(cashed_out_at) / NULLIF(COUNT(*), 0) FROM casino_crash_bets), 0) AS cash_out_rate
+		FROM casino_crash_rounds
+		WHERE status = ?
+	`, models.CrashRoundCrashed).Scan(&stats).Error
+	return stats, err
+}
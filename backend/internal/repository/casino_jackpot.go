@@ -0,0 +1,73 @@
+package repository
+
+import (
+	"ithozyeva/database"
+	"ithozyeva/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type CasinoJackpotRepository struct{}
+
+func NewCasinoJackpotRepository() *CasinoJackpotRepository {
+	return &CasinoJackpotRepository{}
+}
+
+// Pool — текущий пул в сотых долях балла.
+func (r *CasinoJackpotRepository) Pool() (int64, error) {
+	var pool int64
+	err := database.DB.Raw(`SELECT pool_hundredths FROM casino_jackpot WHERE id = 1`).Scan(&pool).Error
+	return pool, err
+}
+
+// ContributeTx добавляет взнос ставки в пул.
+func (r *CasinoJackpotRepository) ContributeTx(tx *gorm.DB, hundredths int64) error {
+	if hundredths <= 0 {
+		return nil
+	}
+	return tx.Exec(
+		`UPDATE casino_jackpot SET pool_hundredths = pool_hundredths + ?, updated_at = NOW() WHERE id = 1`,
+		hundredths,
+	).Error
+}
+
+// TakeTx забирает пул целиком и начинает его заново с reset; возвращает
+// забранное. Строка пула блокируется до конца транзакции, поэтому два
+// выигрыша подряд не делят один и тот же пул.
+func (r *CasinoJackpotRepository) TakeTx(tx *gorm.DB, reset int64) (int64, error) {
+	var pool int64
+	if err := tx.Raw(`SELECT pool_hundredths FROM casino_jackpot WHERE id = 1 FOR UPDATE`).Scan(&pool).Error; err != nil {
+		return 0, err
+	}
+	err := tx.Exec(
+		`UPDATE casino_jackpot SET pool_hundredths = ?, updated_at = NOW() WHERE id = 1`, reset,
+	).Error
+	return pool, err
+}
+
+func (r *CasinoJackpotRepository) CreateWinTx(tx *gorm.DB, win *models.CasinoJackpotWin) error {
+	return tx.Create(win).Error
+}
+
+func (r *CasinoJackpotRepository) RecentWins(limit int) ([]models.CasinoJackpotWin, error) {
+	items := make([]models.CasinoJackpotWin, 0)
+	err := database.DB.Table("casino_jackpot_wins w").
+		Select("w.*, m.first_name AS member_first_name, m.username AS member_username").
+		Joins("JOIN members m ON m.id = w.member_id").
+		Order("w.created_at DESC").Limit(limit).Scan(&items).Error
+	return items, err
+}
+
+func (r *CasinoJackpotRepository) AdminStats() (models.CasinoJackpotAdminStats, error) {
+	var stats models.CasinoJackpotAdminStats
+	err := database.DB.Raw(`
+		SELECT COUNT(*) AS wins, COALESCE(SUM(amount), 0) AS total_paid, COALESCE(MAX(amount), 0) AS largest_win
+		FROM casino_jackpot_wins
+	`).Scan(&stats).Error
+	if err != nil {
+		return stats, err
+	}
+	pool, err := r.Pool()
+	stats.Amount = int(pool / 100)
+	return stats, err
+}
@@ -0,0 +1,132 @@
+package repository
+
+import (
+	"time"
+
+	"ithozyeva/database"
+	"ithozyeva/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type CasinoLimitsRepository struct{}
+
+func NewCasinoLimitsRepository() *CasinoLimitsRepository {
+	return &CasinoLimitsRepository{}
+}
+
+// GetTx — личные лимиты участника; без записи — пустые.
+func (r *CasinoLimitsRepository) GetTx(db *gorm.DB, memberId int64) (*models.CasinoLimits, error) {
+	var items []models.CasinoLimits
+	if err := db.Where("member_id = ?", memberId).Limit(1).Find(&items).Error; err != nil {
+		return nil, err
+	}
+	if len(items) == 0 {
+		return &models.CasinoLimits{MemberId: memberId}, nil
+	}
+	return &items[0], nil
+}
+
+// GetForUpdateTx — лимиты под блокировкой строки; запись заводится при
+// первом обращении.
+func (r *CasinoLimitsRepository) GetForUpdateTx(tx *gorm.DB, memberId int64) (*models.CasinoLimits, error) {
+	if err := tx.Exec(
+		`INSERT INTO casino_limits (member_id) VALUES (?) ON CONFLICT (member_id) DO NOTHING`, memberId,
+	).Error; err != nil {
+		return nil, err
+	}
+	var limits models.CasinoLimits
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("member_id = ?", memberId).First(&limits).Error
+	return &limits, err
+}
+
+func (r *CasinoLimitsRepository) SaveTx(tx *gorm.DB, limits *models.CasinoLimits) error {
+	return tx.Save(limits).Error
+}
+
+// CancelPendingTx отменяет ожидающее изменение поля.
+func (r *CasinoLimitsRepository) CancelPendingTx(tx *gorm.DB, memberId int64, field string) error {
+	return tx.Model(&models.CasinoLimitChange{}).
+		Where("member_id = ? AND field = ? AND status = ?", memberId, field, models.CasinoLimitChangePending).
+		Update("status", models.CasinoLimitChangeCancelled).Error
+}
+
+func (r *CasinoLimitsRepository) CreateChangeTx(tx *gorm.DB, change *models.CasinoLimitChange) error {
+	return tx.Create(change).Error
+}
+
+// DueChangesTx — ожидающие изменения, срок которых наступил, по порядку.
+func (r *CasinoLimitsRepository) DueChangesTx(tx *gorm.DB, memberId int64, now time.Time) ([]models.CasinoLimitChange, error) {
+	items := make([]models.CasinoLimitChange, 0)
+	err := tx.Where("member_id = ? AND status = ? AND effective_at <= ?", memberId, models.CasinoLimitChangePending, now).
+		Order("id").Find(&items).Error
+	return items, err
+}
+
+func (r *CasinoLimitsRepository) MarkAppliedTx(tx *gorm.DB, ids []int64) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	return tx.Model(&models.CasinoLimitChange{}).Where("id IN ?", ids).
+		Update("status", models.CasinoLimitChangeApplied).Error
+}
+
+// Pending — ожидающие вступления в силу изменения.
+func (r *CasinoLimitsRepository) Pending(memberId int64) ([]models.CasinoLimitChange, error) {
+	return r.PendingTx(database.DB, memberId)
+}
+
+func (r *CasinoLimitsRepository) PendingTx(tx *gorm.DB, memberId int64) ([]models.CasinoLimitChange, error) {
+	items := make([]models.CasinoLimitChange, 0)
+	err := tx.Where("member_id = ? AND status = ?", memberId, models.CasinoLimitChangePending).
+		Order("effective_at").Find(&items).Error
+	return items, err
+}
+
+// History — все изменения лимитов участника, новые первыми.
+func (r *CasinoLimitsRepository) History(memberId int64, limit, offset int) ([]models.CasinoLimitChange, int64, error) {
+	items := make([]models.CasinoLimitChange, 0)
+	var total int64
+	q := database.DB.Model(&models.CasinoLimitChange{}).Where("member_id = ?", memberId)
+	if err := q.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	err := q.Order("created_at DESC, id DESC").Limit(limit).Offset(offset).Find(&items).Error
+	return items, total, err
+}
+
+// DayUsage — число ставок и чистый проигрыш (ставки минус выплаты) с since.
+func (r *CasinoLimitsRepository) DayUsage(db *gorm.DB, memberId int64, since time.Time) (models.CasinoDayUsage, error) {
+	var usage models.CasinoDayUsage
+	err := db.Raw(
+		`SELECT COUNT(*) AS bets, COALESCE(SUM(bet_amount - payout), 0) AS loss
+		 FROM casino_bets WHERE member_id = ? AND created_at >= ?`,
+		memberId, since,
+	).Scan(&usage).Error
+	return usage, err
+}
+
+// LossStreak — сколько последних ставок подряд (не больше n) проиграны и
+// когда была последняя из них.
+func (r *CasinoLimitsRepository) LossStreak(db *gorm.DB, memberId int64, n int) (int, time.Time, error) {
+	var rows []struct {
+		Profit    int
+		CreatedAt time.Time
+	}
+	err := db.Raw(
+		`SELECT profit, created_at FROM casino_bets WHERE member_id = ? ORDER BY id DESC LIMIT ?`,
+		memberId, n,
+	).Scan(&rows).Error
+	if err != nil || len(rows) == 0 {
+		return 0, time.Time{}, err
+	}
+	streak := 0
+	for _, row := range rows {
+		if row.Profit >= 0 {
+			break
+		}
+		streak++
+	}
+	return streak, rows[0].CreatedAt, nil
+}
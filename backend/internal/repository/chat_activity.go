@@ -273,6 +273,79 @@ func (r *ChatActivityRepository) GetMessagesSince(chatID int64, since time.Time)
 	return messages, err
 }
 
+// ChatMessageQuery — фильтр сообщений чата для суммаризации.
+type ChatMessageQuery struct {
+	ChatID   int64
+	ThreadID int       // тема форума; 0 — весь чат
+	Since    time.Time // нулевое — без нижней границы
+	Until    time.Time // нулевое — без верхней границы (не включая)
+	Limit    int       // 0 — без лимита; берутся самые свежие
+}
+
+// FindMessages возвращает сообщения с текстом по фильтру, sent_at DESC.
+func (r *ChatActivityRepository) FindMessages(q ChatMessageQuery) ([]models.ChatMessage, error) {
+	db := database.DB.Where("chat_id = ? AND message_text != ''", q.ChatID)
+	if q.ThreadID != 0 {
+		db = db.Where("message_thread_id = ?", q.ThreadID)
+	}
+	if !q.Since.IsZero() {
+		db = db.Where("sent_at >= ?", q.Since)
+	}
+	if !q.Until.IsZero() {
+		db = db.Where("sent_at < ?", q.Until)
+	}
+	if q.Limit > 0 {
+		db = db.Limit(q.Limit)
+	}
+	var messages []models.ChatMessage
+	err := db.Order("sent_at DESC").Find(&messages).Error
+	return messages, err
+}
+
+// threadMaxDepth — предел подъёма по цепочке ответов к корню ветки.
+const threadMaxDepth = 100
+
+// GetThreadMessages собирает ветку обсуждения: поднимается по reply_to от
+// messageID до корня (или до первого сообщения, которого нет в БД), затем
+// берёт все ответы на корень рекурсивно. Возвращает sent_at DESC.
+func (r *ChatActivityRepository) GetThreadMessages(chatID int64, messageID, limit int) ([]models.ChatMessage, error) {
+	var rootID int
+	err := database.DB.Raw(`
+		WITH RECURSIVE up AS (
+			SELECT telegram_message_id, reply_to_message_id, 0 AS depth
+			FROM chat_messages
+			WHERE chat_id = ? AND telegram_message_id = ?
+			UNION ALL
+			SELECT m.telegram_message_id, m.reply_to_message_id, up.depth + 1
+			FROM chat_messages m
+			JOIN up ON m.chat_id = ? AND m.telegram_message_id = up.reply_to_message_id
+			WHERE up.depth < ?
+		)
+		SELECT COALESCE((SELECT telegram_message_id FROM up ORDER BY depth DESC LIMIT 1), ?)
+	`, chatID, messageID, chatID, threadMaxDepth, messageID).Scan(&rootID).Error
+	if err != nil {
+		return nil, err
+	}
+
+	// Якорь — сам корень и прямые ответы на него: корень мог не попасть в
+	// БД (написан до начала трекинга), а ответы на него — попасть.
+	var messages []models.ChatMessage
+	err = database.DB.Raw(`
+		WITH RECURSIVE thread AS (
+			SELECT * FROM chat_messages
+			WHERE chat_id = ? AND (telegram_message_id = ? OR reply_to_message_id = ?)
+			UNION
+			SELECT m.* FROM chat_messages m
+			JOIN thread t ON m.chat_id = ? AND m.reply_to_message_id = t.telegram_message_id
+		)
+		SELECT * FROM thread
+		WHERE message_text != ''
+		ORDER BY sent_at DESC
+		LIMIT ?
+	`, chatID, rootID, rootID, chatID, limit).Scan(&messages).Error
+	return messages, err
+}
+
 // DeleteOldMessages удаляет сообщения старше указанной даты
 func (r *ChatActivityRepository) DeleteOldMessages(beforeDate time.Time) (int64, error) {
 	result := database.DB.Where("sent_at < ?", beforeDate).Delete(&models.ChatMessage{})
@@ -0,0 +1,137 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"ithozyeva/database"
+	"ithozyeva/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ChatDigestRepository struct{}
+
+func NewChatDigestRepository() *ChatDigestRepository {
+	return &ChatDigestRepository{}
+}
+
+// Exists — есть ли уже дайджест чата за период (идемпотентность генерации).
+func (r *ChatDigestRepository) Exists(chatID int64, periodStart time.Time) (bool, error) {
+	var count int64
+	err := database.DB.Model(&models.ChatDigest{}).
+		Where("chat_id = ? AND period_start = ?", chatID, periodStart).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// Create вставляет дайджест; повтор за тот же период — no-op (false).
+func (r *ChatDigestRepository) Create(d *models.ChatDigest) (bool, error) {
+	res := database.DB.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "chat_id"}, {Name: "period_start"}},
+		DoNothing: true,
+	}).Create(d)
+	return res.RowsAffected > 0, res.Error
+}
+
+// GetByID — дайджест или (nil, nil).
+func (r *ChatDigestRepository) GetByID(id int64) (*models.ChatDigest, error) {
+	var d models.ChatDigest
+	err := database.DB.First(&d, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &d, nil
+}
+
+// List — архив дайджестов, новые первыми; chatID=0 — по всем чатам.
+func (r *ChatDigestRepository) List(chatID int64, limit, offset int) ([]models.ChatDigest, int64, error) {
+	q := database.DB.Model(&models.ChatDigest{})
+	if chatID != 0 {
+		q = q.Where("chat_id = ?", chatID)
+	}
+	var total int64
+	if err := q.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	var list []models.ChatDigest
+	err := q.Order("period_start DESC, chat_id").Limit(limit).Offset(offset).Find(&list).Error
+	return list, total, err
+}
+
+// ListUnposted — сгенерированные, но ещё не опубликованные ботом.
+func (r *ChatDigestRepository) ListUnposted(limit int) ([]models.ChatDigest, error) {
+	var list []models.ChatDigest
+	err := database.DB.Where("posted_at IS NULL").
+		Order("created_at").
+		Limit(limit).
+		Find(&list).Error
+	return list, err
+}
+
+// MarkPosted помечает дайджест опубликованным. false — его уже взял другой
+// инстанс бота.
+func (r *ChatDigestRepository) MarkPosted(id int64, at time.Time) (bool, error) {
+	res := database.DB.Model(&models.ChatDigest{}).
+		Where("id = ? AND posted_at IS NULL", id).
+		Update("posted_at", at)
+	return res.RowsAffected > 0, res.Error
+}
+
+// GetMessagesBetween — сообщения чата с текстом за [from, to), новые первыми
+// (как GetMessagesSince), не больше limit.
+func (r *ChatDigestRepository) GetMessagesBetween(chatID int64, from, to time.Time, limit int) ([]models.ChatMessage, error) {
+	var messages []models.ChatMessage
+	err := database.DB.
+		Where("chat_id = ? AND message_text != '' AND sent_at >= ? AND sent_at < ?", chatID, from, to).
+		Order("sent_at DESC").
+		Limit(limit).
+		Find(&messages).Error
+	return messages, err
+}
+
+// CountMessagesBetween — число сообщений и уникальных авторов чата за период.
+func (r *ChatDigestRepository) CountMessagesBetween(chatID int64, from, to time.Time) (messages int64, authors int64, err error) {
+	var row struct {
+		Messages int64
+		Authors  int64
+	}
+	err = database.DB.Raw(`
+		SELECT COUNT(*) AS messages, COUNT(DISTINCT telegram_user_id) AS authors
+		FROM chat_messages
+		WHERE chat_id = ? AND sent_at >= ? AND sent_at < ?
+	`, chatID, from, to).Scan(&row).Error
+	return row.Messages, row.Authors, err
+}
+
+// TopMembersBetween — самые активные авторы чата за период.
+func (r *ChatDigestRepository) TopMembersBetween(chatID int64, from, to time.Time, limit int) ([]models.DigestMember, error) {
+	var list []models.DigestMember
+	err := database.DB.Raw(`
+		SELECT telegram_user_id,
+		       (ARRAY_AGG(telegram_username ORDER BY sent_at DESC))[1] AS telegram_username,
+		       (ARRAY_AGG(telegram_first_name ORDER BY sent_at DESC))[1] AS telegram_first_name,
+		       COUNT(*) AS count
+		FROM chat_messages
+		WHERE chat_id = ? AND sent_at >= ? AND sent_at < ?
+		GROUP BY telegram_user_id
+		ORDER BY count DESC
+		LIMIT ?
+	`, chatID, from, to, limit).Scan(&list).Error
+	return list, err
+}
+
+// HighlightsBetween — хайлайты чата за период, последние первыми.
+func (r *ChatDigestRepository) HighlightsBetween(chatID int64, from, to time.Time, limit int) ([]models.ChatHighlight, error) {
+	var list []models.ChatHighlight
+	err := database.DB.
+		Where("chat_id = ? AND created_at >= ? AND created_at < ?", chatID, from, to).
+		Order("created_at DESC").
+		Limit(limit).
+		Find(&list).Error
+	return list, err
+}
@@ -0,0 +1,102 @@
+package repository
+
+import (
+	"time"
+
+	"ithozyeva/database"
+	"ithozyeva/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type ChatSearchRepository struct{}
+
+func NewChatSearchRepository() *ChatSearchRepository {
+	return &ChatSearchRepository{}
+}
+
+// ChatSearchQuery — параметры поиска. ChatIDs — чаты, доступные участнику
+// (уже пересечённые с фильтром по чату).
+type ChatSearchQuery struct {
+	Text           string
+	ChatIDs        []int64
+	AuthorID       int64
+	AuthorUsername string
+	Since          time.Time // нулевое — без нижней границы
+	Until          time.Time // нулевое — без верхней границы (не включая)
+	SortByDate     bool
+	Limit          int
+	Offset         int
+}
+
+// tsQueryExpr — запрос в обеих конфигурациях; websearch-синтаксис («фраза»,
+// -исключение, or) не падает на произвольном вводе, в отличие от to_tsquery.
+const tsQueryExpr = "(websearch_to_tsquery('russian', ?) || websearch_to_tsquery('english', ?))"
+
+// ListSearchableChats — трекаемые чаты, к которым у пользователя есть
+// действующий доступ (subscription_user_chat_access без revoked_at).
+func (r *ChatSearchRepository) ListSearchableChats(telegramUserID int64) ([]models.SearchableChat, error) {
+	var chats []models.SearchableChat
+	err := database.DB.Raw(`
+		SELECT tc.chat_id, tc.title
+		FROM subscription_user_chat_access a
+		JOIN tracked_chats tc ON tc.chat_id = a.chat_id AND tc.is_active
+		WHERE a.user_id = ? AND a.revoked_at IS NULL
+		ORDER BY tc.title
+	`, telegramUserID).Scan(&chats).Error
+	return chats, err
+}
+
+func (r *ChatSearchRepository) filtered(q ChatSearchQuery) *gorm.DB {
+	db := database.DB.Table("chat_messages m").
+		Joins("JOIN tracked_chats tc ON tc.chat_id = m.chat_id").
+		Where("m.search_vector @@ "+tsQueryExpr, q.Text, q.Text).
+		Where("m.chat_id IN ?", q.ChatIDs)
+	if q.AuthorID != 0 {
+		db = db.Where("m.telegram_user_id = ?", q.AuthorID)
+	}
+	if q.AuthorUsername != "" {
+		db = db.Where("LOWER(m.telegram_username) = LOWER(?)", q.AuthorUsername)
+	}
+	if !q.Since.IsZero() {
+		db = db.Where("m.sent_at >= ?", q.Since)
+	}
+	if !q.Until.IsZero() {
+		db = db.Where("m.sent_at < ?", q.Until)
+	}
+	return db
+}
+
+// Search — совпадения по релевантности (или по дате) и общее число.
+func (r *ChatSearchRepository) Search(q ChatSearchQuery) ([]models.ChatSearchResult, int64, error) {
+	if len(q.ChatIDs) == 0 {
+		return []models.ChatSearchResult{}, 0, nil
+	}
+	var total int64
+	if err := r.filtered(q).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	order := "rank DESC, m.sent_at DESC"
+	if q.SortByDate {
+		order = "m.sent_at DESC"
+	}
+	// Текст экранируем до ts_headline: сниппет отдаётся как HTML, а
+	// разметку в нём должны давать только <mark>.
+	var results []models.ChatSearchResult
+	err := r.filtered(q).
+		Select(`m.id, m.chat_id, tc.title AS chat_title, m.telegram_user_id,
+			m.telegram_username, m.telegram_first_name, m.message_text,
+			m.telegram_message_id, m.message_thread_id, m.sent_at,
+			ts_rank(m.search_vector, `+tsQueryExpr+`) AS rank,
+			ts_headline('russian',
+				replace(replace(replace(m.message_text, '&', '&amp;'), '<', '&lt;'), '>', '&gt;'),
+				`+tsQueryExpr+`,
+				'StartSel=<mark>, StopSel=</mark>, MaxWords=35, MinWords=15, MaxFragments=2') AS snippet`,
+			q.Text, q.Text, q.Text, q.Text).
+		Order(order).
+		Limit(q.Limit).
+		Offset(q.Offset).
+		Scan(&results).Error
+	return results, total, err
+}
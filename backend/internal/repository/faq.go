@@ -0,0 +1,87 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"ithozyeva/database"
+	"ithozyeva/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type FAQRepository struct{}
+
+func NewFAQRepository() *FAQRepository {
+	return &FAQRepository{}
+}
+
+// List — триггеры; chatID != 0 — триггеры чата вместе с общими, 0 — все.
+func (r *FAQRepository) List(chatID int64) ([]models.FAQTrigger, error) {
+	db := database.DB.Order("priority DESC, id")
+	if chatID != 0 {
+		db = db.Where("chat_id IN ?", []int64{0, chatID})
+	}
+	var triggers []models.FAQTrigger
+	err := db.Find(&triggers).Error
+	return triggers, err
+}
+
+// ListEnabled — включённые триггеры (для бота): общие и по чатам.
+func (r *FAQRepository) ListEnabled() ([]models.FAQTrigger, error) {
+	var triggers []models.FAQTrigger
+	err := database.DB.Where("enabled").Order("priority DESC, id").Find(&triggers).Error
+	return triggers, err
+}
+
+// GetByID — триггер или (nil, nil).
+func (r *FAQRepository) GetByID(id int64) (*models.FAQTrigger, error) {
+	var t models.FAQTrigger
+	if err := database.DB.First(&t, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &t, nil
+}
+
+func (r *FAQRepository) Create(t *models.FAQTrigger) error {
+	return database.DB.Create(t).Error
+}
+
+// Update сохраняет редактируемые поля; false — триггера нет.
+func (r *FAQRepository) Update(t *models.FAQTrigger) (bool, error) {
+	t.UpdatedAt = time.Now()
+	res := database.DB.Model(t).
+		Select("chat_id", "name", "match_type", "pattern", "response", "cooldown_seconds", "priority", "enabled", "updated_by", "updated_at").
+		Updates(t)
+	return res.RowsAffected > 0, res.Error
+}
+
+// Delete удаляет триггер (срабатывания — каскадом); false — не было.
+func (r *FAQRepository) Delete(id int64) (bool, error) {
+	res := database.DB.Delete(&models.FAQTrigger{}, id)
+	return res.RowsAffected > 0, res.Error
+}
+
+func (r *FAQRepository) RecordHit(h *models.FAQHit) error {
+	return database.DB.Create(h).Error
+}
+
+// Stats — срабатывания по триггерам с since, популярные первыми. Триггеры
+// без срабатываний тоже в списке — видно, что ответ никому не нужен.
+func (r *FAQRepository) Stats(since time.Time) ([]models.FAQTriggerStats, error) {
+	var rows []models.FAQTriggerStats
+	err := database.DB.Raw(`
+		SELECT t.id AS trigger_id, t.name, t.chat_id, t.enabled,
+			COUNT(h.id) AS hits,
+			COUNT(DISTINCT h.chat_id) AS chats,
+			MAX(h.created_at) AS last_hit_at
+		FROM bot_faq_triggers t
+		LEFT JOIN bot_faq_hits h ON h.trigger_id = t.id AND h.created_at >= ?
+		GROUP BY t.id
+		ORDER BY hits DESC, t.id
+	`, since).Scan(&rows).Error
+	return rows, err
+}
@@ -0,0 +1,161 @@
+package repository
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"ithozyeva/database"
+	"ithozyeva/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type KnowledgeBaseRepository struct{}
+
+func NewKnowledgeBaseRepository() *KnowledgeBaseRepository {
+	return &KnowledgeBaseRepository{}
+}
+
+// GetByID — статья с хайлайтами и тегами или (nil, nil).
+func (r *KnowledgeBaseRepository) GetByID(id int64) (*models.KBEntry, error) {
+	var e models.KBEntry
+	err := database.DB.
+		Preload("Highlights", func(db *gorm.DB) *gorm.DB { return db.Order("chat_highlights.created_at") }).
+		Preload("ProfTags").
+		First(&e, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &e, nil
+}
+
+// Search — статьи по тексту (websearch-синтаксис, по релевантности) и тегу;
+// пустой text — свежие правки первыми. Хайлайты в списке не подгружаются.
+func (r *KnowledgeBaseRepository) Search(text string, tagID int64, limit, offset int) ([]models.KBEntry, int64, error) {
+	db := database.DB.Model(&models.KBEntry{})
+	if text != "" {
+		db = db.Where("search_vector @@ "+tsQueryExpr, text, text)
+	}
+	if tagID != 0 {
+		db = db.Where("id IN (SELECT entry_id FROM kb_entry_tags WHERE tag_id = ?)", tagID)
+	}
+	var total int64
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	if text != "" {
+		db = db.Clauses(clause.OrderBy{Expression: clause.Expr{
+			SQL:  "ts_rank(search_vector, " + tsQueryExpr + ") DESC, updated_at DESC",
+			Vars: []interface{}{text, text},
+		}})
+	} else {
+		db = db.Order("updated_at DESC")
+	}
+	var entries []models.KBEntry
+	err := db.Preload("ProfTags").Limit(limit).Offset(offset).Find(&entries).Error
+	return entries, total, err
+}
+
+// CountExisting — сколько из ids реально есть в таблице (проверка ссылок
+// на хайлайты и теги до записи).
+func (r *KnowledgeBaseRepository) CountExisting(table string, ids []int64) (int64, error) {
+	if len(ids) == 0 {
+		return 0, nil
+	}
+	var count int64
+	err := database.DB.Table(table).Where("id IN ?", ids).Count(&count).Error
+	return count, err
+}
+
+// FirstHighlightText — текст самого раннего из хайлайтов.
+func (r *KnowledgeBaseRepository) FirstHighlightText(ids []int64) (string, error) {
+	var text string
+	err := database.DB.Model(&models.ChatHighlight{}).
+		Where("id IN ?", ids).
+		Order("created_at").
+		Limit(1).
+		Pluck("message_text", &text).Error
+	return text, err
+}
+
+// Save создаёт (Id == 0) или обновляет статью и целиком заменяет её
+// хайлайты и теги. false — обновляемой статьи нет.
+func (r *KnowledgeBaseRepository) Save(e *models.KBEntry, highlightIDs, tagIDs []int64) (bool, error) {
+	found := true
+	err := database.DB.Transaction(func(tx *gorm.DB) error {
+		if e.Id == 0 {
+			if err := tx.Omit(clause.Associations).Create(e).Error; err != nil {
+				return err
+			}
+		} else {
+			e.UpdatedAt = time.Now()
+			res := tx.Model(e).Select("title", "question", "answer", "updated_by", "updated_at").Updates(e)
+			if res.Error != nil {
+				return res.Error
+			}
+			if res.RowsAffected == 0 {
+				found = false
+				return nil
+			}
+		}
+		if err := replaceLinks(tx, "kb_entry_highlights", "highlight_id", e.Id, highlightIDs); err != nil {
+			return err
+		}
+		return replaceLinks(tx, "kb_entry_tags", "tag_id", e.Id, tagIDs)
+	})
+	return found, err
+}
+
+func replaceLinks(tx *gorm.DB, table, column string, entryID int64, ids []int64) error {
+	if err := tx.Exec("DELETE FROM "+table+" WHERE entry_id = ?", entryID).Error; err != nil {
+		return err
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+	values := make([]string, 0, len(ids))
+	args := make([]interface{}, 0, len(ids)*2)
+	for _, id := range ids {
+		values = append(values, "(?, ?)")
+		args = append(args, entryID, id)
+	}
+	return tx.Exec("INSERT INTO "+table+" (entry_id, "+column+") VALUES "+strings.Join(values, ", ")+
+		" ON CONFLICT DO NOTHING", args...).Error
+}
+
+// Delete удаляет статью; false — не было.
+func (r *KnowledgeBaseRepository) Delete(id int64) (bool, error) {
+	res := database.DB.Delete(&models.KBEntry{}, id)
+	return res.RowsAffected > 0, res.Error
+}
+
+// FindCandidates — статьи, где встречается хоть одно из слов (OR-запрос:
+// вопрос в чате сформулирован иначе, чем статья, AND отсёк бы почти всё).
+// words — только буквы и цифры, их собирает сервис.
+func (r *KnowledgeBaseRepository) FindCandidates(words []string, limit int) ([]models.KBEntry, error) {
+	if len(words) == 0 {
+		return nil, nil
+	}
+	q := strings.Join(words, " | ")
+	var entries []models.KBEntry
+	err := database.DB.
+		Where("search_vector @@ (to_tsquery('russian', ?) || to_tsquery('english', ?))", q, q).
+		Clauses(clause.OrderBy{Expression: clause.Expr{
+			SQL:  "ts_rank(search_vector, to_tsquery('russian', ?) || to_tsquery('english', ?)) DESC",
+			Vars: []interface{}{q, q},
+		}}).
+		Limit(limit).
+		Find(&entries).Error
+	return entries, err
+}
+
+// IncrementSuggested — бот подсказал статью в чате.
+func (r *KnowledgeBaseRepository) IncrementSuggested(id int64) error {
+	return database.DB.Model(&models.KBEntry{}).Where("id = ?", id).
+		UpdateColumn("suggested_count", gorm.Expr("suggested_count + 1")).Error
+}
@@ -0,0 +1,154 @@
+package repository
+
+import (
+	"time"
+
+	"ithozyeva/database"
+	"ithozyeva/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type LeaderboardRepository struct{}
+
+func NewLeaderboardRepository() *LeaderboardRepository {
+	return &LeaderboardRepository{}
+}
+
+// periodTotalsCTE — сумма баллов участников за [start, end) без excluded
+// reason'ов. Места: больше баллов выше; при равенстве выше тот, кто набрал
+// сумму раньше. excluded не должен быть пустым — NOT IN () в SQL нет.
+const periodTotalsCTE = `
+	WITH totals AS (
+		SELECT member_id, SUM(amount) AS total, MAX(created_at) AS last_at
+		FROM point_transactions
+		WHERE created_at >= ? AND created_at < ? AND reason NOT IN ?
+		GROUP BY member_id
+		HAVING SUM(amount) > 0
+	), ranked AS (
+		SELECT member_id, total,
+		       ROW_NUMBER() OVER (ORDER BY total DESC, last_at ASC, member_id ASC) AS rank
+		FROM totals
+	)`
+
+// Ranking — первые limit мест периода.
+func (r *LeaderboardRepository) Ranking(start, end time.Time, excluded []string, limit int) ([]models.LeaderboardRank, error) {
+	return r.ranking(database.DB, start, end, excluded, `r.rank <= ?`, limit)
+}
+
+// MemberRank — место участника в периоде или nil, если он без баллов.
+func (r *LeaderboardRepository) MemberRank(start, end time.Time, excluded []string, memberId int64) (*models.LeaderboardRank, error) {
+	rows, err := r.ranking(database.DB, start, end, excluded, `r.member_id = ?`, memberId)
+	if err != nil || len(rows) == 0 {
+		return nil, err
+	}
+	return &rows[0], nil
+}
+
+func (r *LeaderboardRepository) ranking(db *gorm.DB, start, end time.Time, excluded []string, filter string, arg interface{}) ([]models.LeaderboardRank, error) {
+	rows := make([]models.LeaderboardRank, 0)
+	err := db.Raw(periodTotalsCTE+`
+		SELECT r.rank, r.member_id, m.first_name, m.last_name, m.username, m.avatar_url, r.total
+		FROM ranked r
+		JOIN members m ON m.id = r.member_id
+		WHERE `+filter+`
+		ORDER BY r.rank`,
+		start, end, excluded, arg,
+	).Scan(&rows).Error
+	for i := range rows {
+		rows[i].AfterFind(nil)
+	}
+	return rows, err
+}
+
+// Participants — сколько участников набрали баллы в периоде.
+func (r *LeaderboardRepository) Participants(db *gorm.DB, start, end time.Time, excluded []string) (int, error) {
+	var n int
+	err := db.Raw(periodTotalsCTE+` SELECT COUNT(*) FROM ranked`, start, end, excluded).Scan(&n).Error
+	return n, err
+}
+
+// RankingTx — Ranking внутри транзакции снапшота.
+func (r *LeaderboardRepository) RankingTx(tx *gorm.DB, start, end time.Time, excluded []string, limit int) ([]models.LeaderboardRank, error) {
+	return r.ranking(tx, start, end, excluded, `r.rank <= ?`, limit)
+}
+
+// GetSnapshot — снапшот периода или nil.
+func (r *LeaderboardRepository) GetSnapshot(period, periodKey string) (*models.LeaderboardSnapshot, error) {
+	var snaps []models.LeaderboardSnapshot
+	err := database.DB.Where("period = ? AND period_key = ?", period, periodKey).Limit(1).Find(&snaps).Error
+	if err != nil || len(snaps) == 0 {
+		return nil, err
+	}
+	return &snaps[0], nil
+}
+
+// LatestSnapshot — последний снапшот периода или nil.
+func (r *LeaderboardRepository) LatestSnapshot(period string) (*models.LeaderboardSnapshot, error) {
+	var snaps []models.LeaderboardSnapshot
+	err := database.DB.Where("period = ?", period).Order("starts_at DESC").Limit(1).Find(&snaps).Error
+	if err != nil || len(snaps) == 0 {
+		return nil, err
+	}
+	return &snaps[0], nil
+}
+
+// CreateSnapshotTx вставляет снапшот; false — его уже создал другой инстанс.
+func (r *LeaderboardRepository) CreateSnapshotTx(tx *gorm.DB, snap *models.LeaderboardSnapshot) (bool, error) {
+	res := tx.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "period"}, {Name: "period_key"}},
+		DoNothing: true,
+	}).Create(snap)
+	return res.RowsAffected > 0, res.Error
+}
+
+func (r *LeaderboardRepository) CreateEntriesTx(tx *gorm.DB, entries []models.LeaderboardSnapshotEntry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+	return tx.Create(&entries).Error
+}
+
+// ListSnapshots — последние снапшоты периода, новые первыми.
+func (r *LeaderboardRepository) ListSnapshots(period string, limit int) ([]models.LeaderboardSnapshot, error) {
+	snaps := make([]models.LeaderboardSnapshot, 0)
+	err := database.DB.Where("period = ?", period).Order("starts_at DESC").Limit(limit).Find(&snaps).Error
+	return snaps, err
+}
+
+// Entries — места снапшотов до maxRank включительно, с данными участников.
+func (r *LeaderboardRepository) Entries(snapshotIds []int64, maxRank int) ([]models.LeaderboardSnapshotEntry, error) {
+	entries := make([]models.LeaderboardSnapshotEntry, 0)
+	if len(snapshotIds) == 0 {
+		return entries, nil
+	}
+	err := database.DB.Table("leaderboard_snapshot_entries e").
+		Select("e.snapshot_id, e.member_id, e.rank, e.total, e.prize, m.first_name, m.last_name, m.username, m.avatar_url").
+		Joins("JOIN members m ON m.id = e.member_id").
+		Where("e.snapshot_id IN ? AND e.rank <= ?", snapshotIds, maxRank).
+		Order("e.snapshot_id, e.rank").
+		Find(&entries).Error
+	return entries, err
+}
+
+// PrizeEntries — призовые места снапшота (для начисления).
+func (r *LeaderboardRepository) PrizeEntries(snapshotId int64) ([]models.LeaderboardSnapshotEntry, error) {
+	entries := make([]models.LeaderboardSnapshotEntry, 0)
+	err := database.DB.Where("snapshot_id = ? AND prize > 0", snapshotId).Order("rank").Find(&entries).Error
+	return entries, err
+}
+
+// MemberWins — призовые места и подиумы участника в прошлых периодах.
+func (r *LeaderboardRepository) MemberWins(memberId int64, podium int) ([]models.LeaderboardWin, error) {
+	wins := make([]models.LeaderboardWin, 0)
+	err := database.DB.Raw(
+		`SELECT s.period, s.period_key, s.ends_at, e.rank, e.total, e.prize
+		 FROM leaderboard_snapshot_entries e
+		 JOIN leaderboard_snapshots s ON s.id = e.snapshot_id
+		 WHERE e.member_id = ? AND (e.prize > 0 OR e.rank <= ?)
+		 ORDER BY s.ends_at DESC, e.rank`,
+		memberId, podium,
+	).Scan(&wins).Error
+	return wins, err
+}
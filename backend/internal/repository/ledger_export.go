@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"fmt"
+
+	"ithozyeva/database"
+	"ithozyeva/internal/models"
+)
+
+type LedgerExportRepository struct{}
+
+func NewLedgerExportRepository() *LedgerExportRepository {
+	return &LedgerExportRepository{}
+}
+
+// ledgerTables — журнал выгрузки → таблица.
+var ledgerTables = map[string]string{
+	models.LedgerPoints:  "point_transactions",
+	models.LedgerCredits: "referral_credit_transactions",
+}
+
+// Stream отдаёт строки журнала по одной в fn в хронологическом порядке,
+// не собирая выборку в память. Ошибка fn прерывает выгрузку.
+func (r *LedgerExportRepository) Stream(f models.LedgerExportFilter, fn func(*models.LedgerExportRow) error) error {
+	table, ok := ledgerTables[f.Ledger]
+	if !ok {
+		return fmt.Errorf("unknown ledger %q", f.Ledger)
+	}
+	q := database.DB.Table(table + " t").
+		Select(`t.id, t.member_id, COALESCE(m.username, '') AS member_username, t.amount,
+		        t.reason, t.source_type, t.source_id, t.description, t.created_at`).
+		Joins("JOIN members m ON m.id = t.member_id")
+	if f.MemberId != 0 {
+		q = q.Where("t.member_id = ?", f.MemberId)
+	}
+	if f.From != nil {
+		q = q.Where("t.created_at >= ?", *f.From)
+	}
+	if f.To != nil {
+		q = q.Where("t.created_at < ?", *f.To)
+	}
+	if len(f.Reasons) > 0 {
+		q = q.Where("t.reason IN ?", f.Reasons)
+	}
+
+	rows, err := q.Order("t.created_at, t.id").Rows()
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var row models.LedgerExportRow
+		if err := database.DB.ScanRows(rows, &row); err != nil {
+			return err
+		}
+		if err := fn(&row); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
@@ -0,0 +1,64 @@
+package repository
+
+import (
+	"time"
+
+	"ithozyeva/database"
+	"ithozyeva/internal/models"
+)
+
+type LLMUsageRepository struct{}
+
+func NewLLMUsageRepository() *LLMUsageRepository {
+	return &LLMUsageRepository{}
+}
+
+func (r *LLMUsageRepository) Create(u *models.LLMUsage) error {
+	return database.DB.Create(u).Error
+}
+
+// StatsByModel — вызовы, отказы, токены и задержка по моделям с since.
+func (r *LLMUsageRepository) StatsByModel(since time.Time) ([]models.LLMModelStats, error) {
+	var rows []models.LLMModelStats
+	err := database.DB.Raw(`
+		SELECT model,
+			COUNT(*) AS calls,
+			COUNT(*) FILTER (WHERE NOT success) AS failures,
+			COALESCE(SUM(prompt_tokens), 0) AS prompt_tokens,
+			COALESCE(SUM(completion_tokens), 0) AS completion_tokens,
+			COALESCE(SUM(total_tokens), 0) AS total_tokens,
+			COALESCE(AVG(latency_ms), 0) AS avg_latency_ms,
+			COALESCE(MAX(latency_ms), 0) AS max_latency_ms
+		FROM llm_usage
+		WHERE created_at >= ?
+		GROUP BY model
+		ORDER BY calls DESC
+	`, since).Scan(&rows).Error
+	return rows, err
+}
+
+// StatsByFeature — те же цифры в разрезе фич.
+func (r *LLMUsageRepository) StatsByFeature(since time.Time) ([]models.LLMFeatureStats, error) {
+	var rows []models.LLMFeatureStats
+	err := database.DB.Raw(`
+		SELECT feature,
+			COUNT(*) AS calls,
+			COUNT(*) FILTER (WHERE NOT success) AS failures,
+			COALESCE(SUM(total_tokens), 0) AS total_tokens
+		FROM llm_usage
+		WHERE created_at >= ?
+		GROUP BY feature
+		ORDER BY calls DESC
+	`, since).Scan(&rows).Error
+	return rows, err
+}
+
+// RecentFailures — последние отказы (текст ошибки для разбора).
+func (r *LLMUsageRepository) RecentFailures(since time.Time, limit int) ([]models.LLMUsage, error) {
+	var rows []models.LLMUsage
+	err := database.DB.Where("created_at >= ? AND NOT success", since).
+		Order("created_at DESC").
+		Limit(limit).
+		Find(&rows).Error
+	return rows, err
+}
@@ -231,6 +231,34 @@ func (r *MemberRepository) SetReferralWelcomeSeenAt(memberID int64) error {
 		Update("referral_welcome_seen_at", gorm.Expr("NOW()")).Error
 }
 
+// GetLanguageByTelegramID — сохранённый язык участника; found=false — такого
+// участника нет.
+func (r *MemberRepository) GetLanguageByTelegramID(telegramID int64) (lang string, found bool, err error) {
+	var langs []string
+	err = database.DB.Model(&models.Member{}).
+		Where("telegram_id = ?", telegramID).
+		Limit(1).
+		Pluck("language", &langs).Error
+	if err != nil || len(langs) == 0 {
+		return "", false, err
+	}
+	return langs[0], true, nil
+}
+
+// SeedLanguage ставит язык, только если участник его ещё не выбирал.
+func (r *MemberRepository) SeedLanguage(telegramID int64, lang string) error {
+	return database.DB.Model(&models.Member{}).
+		Where("telegram_id = ? AND language = ''", telegramID).
+		Update("language", lang).Error
+}
+
+// SetLanguage — явный выбор языка участником.
+func (r *MemberRepository) SetLanguage(memberID int64, lang string) error {
+	return database.DB.Model(&models.Member{}).
+		Where("id = ?", memberID).
+		Update("language", lang).Error
+}
+
 // MembersWithoutReferralCode — для startup-backfill'а. Возвращает id'ы юзеров,
 // которым нужно сгенерировать код. Limit чтобы не забирать всё разом —
 // backfill идёт батчами.
@@ -96,12 +96,13 @@ func (r *ModerationRepository) GetVoteban(id int64) (*models.Voteban, error) {
 
 // UpsertVote ставит/обновляет голос. Возвращает true, если запись создалась
 // впервые (для логов / UX), и финальное значение голоса.
-func (r *ModerationRepository) UpsertVote(votebanID, voterID int64, vote int16) error {
+func (r *ModerationRepository) UpsertVote(votebanID, voterID int64, vote int16, weight float64) error {
 	now := time.Now()
 	v := models.VotebanVote{
 		VotebanID:   votebanID,
 		VoterUserID: voterID,
 		Vote:        vote,
+		Weight:      weight,
 		CreatedAt:   now,
 		UpdatedAt:   now,
 	}
@@ -109,6 +110,7 @@ func (r *ModerationRepository) UpsertVote(votebanID, voterID int64, vote int16)
 		Columns: []clause.Column{{Name: "voteban_id"}, {Name: "voter_user_id"}},
 		DoUpdates: clause.Assignments(map[string]interface{}{
 			"vote":       vote,
+			"weight":     weight,
 			"updated_at": now,
 		}),
 	}).Create(&v).Error
@@ -128,16 +130,17 @@ func (r *ModerationRepository) GetVote(votebanID, voterID int64) (*int16, error)
 	return &v.Vote, nil
 }
 
-// CountVotes считает «за» и «против» по voteban_id.
+// CountVotes считает «за» и «против» по voteban_id: число голосов и сумму весов.
 func (r *ModerationRepository) CountVotes(votebanID int64) (models.VotebanTally, error) {
 	var tally models.VotebanTally
 	type row struct {
-		Vote  int16
-		Count int
+		Vote   int16
+		Count  int
+		Weight float64
 	}
 	var rows []row
 	err := database.DB.Model(&models.VotebanVote{}).
-		Select("vote, COUNT(*) as count").
+		Select("vote, COUNT(*) as count, COALESCE(SUM(weight), 0) as weight").
 		Where("voteban_id = ?", votebanID).
 		Group("vote").
 		Scan(&rows).Error
@@ -148,8 +151,10 @@ func (r *ModerationRepository) CountVotes(votebanID int64) (models.VotebanTally,
 		switch r.Vote {
 		case models.VotebanVoteFor:
 			tally.For = r.Count
+			tally.ForWeight = r.Weight
 		case models.VotebanVoteAgainst:
 			tally.Against = r.Count
+			tally.AgainstWeight = r.Weight
 		}
 	}
 	return tally, nil
@@ -246,11 +251,38 @@ func (r *ModerationRepository) UpsertGlobalBan(b *models.GlobalBan) error {
 	return database.DB.Clauses(clause.OnConflict{
 		Columns: []clause.Column{{Name: "user_id"}},
 		DoUpdates: clause.AssignmentColumns([]string{
-			"banned_by", "reason", "expires_at", "updated_at",
+			"banned_by", "reason", "expires_at", "source", "updated_at",
 		}),
 	}).Create(b).Error
 }
 
+// InsertSharedBans вставляет записи из общего бан-листа пачками. Существующие
+// записи обновляются, только если у них тот же источник: импорт не
+// перетирает наш ручной /globalban и наоборот. Возвращает число
+// вставленных/обновлённых строк.
+func (r *ModerationRepository) InsertSharedBans(bans []models.GlobalBan) (int64, error) {
+	if len(bans) == 0 {
+		return 0, nil
+	}
+	res := database.DB.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"reason", "expires_at", "updated_at"}),
+		Where: clause.Where{Exprs: []clause.Expression{
+			clause.Expr{SQL: "bot_global_bans.source = EXCLUDED.source"},
+		}},
+	}).CreateInBatches(bans, 500)
+	return res.RowsAffected, res.Error
+}
+
+// DeleteStaleSharedBans удаляет записи источника source, не обновлявшиеся с
+// before: синхронизация трогает updated_at у всех записей из актуального
+// списка, так что остальные из внешнего списка уже убрали.
+func (r *ModerationRepository) DeleteStaleSharedBans(source string, before time.Time) (int64, error) {
+	res := database.DB.Where("source = ? AND updated_at < ?", source, before).
+		Delete(&models.GlobalBan{})
+	return res.RowsAffected, res.Error
+}
+
 // GetGlobalBan возвращает запись или (nil, nil) если её нет.
 func (r *ModerationRepository) GetGlobalBan(userID int64) (*models.GlobalBan, error) {
 	var b models.GlobalBan
@@ -271,10 +303,17 @@ func (r *ModerationRepository) DeleteGlobalBan(userID int64) error {
 
 // ListActiveGlobalBans возвращает все активные баны (не истёкшие).
 func (r *ModerationRepository) ListActiveGlobalBans(now time.Time) ([]models.GlobalBan, error) {
+	return r.ListActiveGlobalBansBySource(now, "")
+}
+
+// ListActiveGlobalBansBySource — активные баны одного источника; "" — всех.
+func (r *ModerationRepository) ListActiveGlobalBansBySource(now time.Time, source string) ([]models.GlobalBan, error) {
 	var list []models.GlobalBan
-	err := database.DB.Where("expires_at IS NULL OR expires_at > ?", now).
-		Order("created_at DESC").
-		Find(&list).Error
+	q := database.DB.Where("expires_at IS NULL OR expires_at > ?", now)
+	if source != "" {
+		q = q.Where("source = ?", source)
+	}
+	err := q.Order("created_at DESC").Find(&list).Error
 	return list, err
 }
 
@@ -292,8 +331,10 @@ type ModerationActionView struct {
 // VotebanView — voteban + раскладка голосов.
 type VotebanView struct {
 	models.Voteban
-	VotesFor     int `json:"votesFor" gorm:"column:votes_for"`
-	VotesAgainst int `json:"votesAgainst" gorm:"column:votes_against"`
+	VotesFor           int     `json:"votesFor" gorm:"column:votes_for"`
+	VotesAgainst       int     `json:"votesAgainst" gorm:"column:votes_against"`
+	VotesForWeight     float64 `json:"votesForWeight" gorm:"column:votes_for_weight"`
+	VotesAgainstWeight float64 `json:"votesAgainstWeight" gorm:"column:votes_against_weight"`
 }
 
 // listEnrichedActions возвращает список с username/first_name из последнего
@@ -364,7 +405,9 @@ func (r *ModerationRepository) ListOpenVotebansEnriched() ([]VotebanView, error)
 		SELECT
 			v.*,
 			COALESCE((SELECT COUNT(*) FROM bot_voteban_votes WHERE voteban_id = v.id AND vote = 1), 0)  AS votes_for,
-			COALESCE((SELECT COUNT(*) FROM bot_voteban_votes WHERE voteban_id = v.id AND vote = -1), 0) AS votes_against
+			COALESCE((SELECT COUNT(*) FROM bot_voteban_votes WHERE voteban_id = v.id AND vote = -1), 0) AS votes_against,
+			COALESCE((SELECT SUM(weight) FROM bot_voteban_votes WHERE voteban_id = v.id AND vote = 1), 0)  AS votes_for_weight,
+			COALESCE((SELECT SUM(weight) FROM bot_voteban_votes WHERE voteban_id = v.id AND vote = -1), 0) AS votes_against_weight
 		FROM bot_votebans v
 		WHERE v.status = ?
 		ORDER BY v.created_at DESC
@@ -385,3 +428,341 @@ func (r *ModerationRepository) KnownChatIDs() ([]int64, error) {
 	`).Pluck("chat_id", &ids).Error
 	return ids, err
 }
+
+// --- Raid mode ---
+
+// CreateRaid сохраняет новую запись рейда. Второй активный рейд в том же
+// чате не даст завести uniq_bot_raids_active — вызывающий ловит ошибку.
+func (r *ModerationRepository) CreateRaid(raid *models.Raid) error {
+	if raid.SavedPermissions == "" {
+		raid.SavedPermissions = "{}"
+	}
+	return database.DB.Create(raid).Error
+}
+
+// GetRaid читает запись по id.
+func (r *ModerationRepository) GetRaid(id int64) (*models.Raid, error) {
+	var raid models.Raid
+	if err := database.DB.First(&raid, id).Error; err != nil {
+		return nil, err
+	}
+	return &raid, nil
+}
+
+// FindActiveRaid — активный рейд в чате или (nil, nil).
+func (r *ModerationRepository) FindActiveRaid(chatID int64) (*models.Raid, error) {
+	var raid models.Raid
+	err := database.DB.Where("chat_id = ? AND status = ?", chatID, models.RaidStatusActive).
+		First(&raid).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &raid, nil
+}
+
+// FindLatestUnresolvedRaid — последний рейд в чате, по которому модератор
+// ещё не принял решение (active или ended). Нужен для /raid rollback после
+// того, как режим истёк сам.
+func (r *ModerationRepository) FindLatestUnresolvedRaid(chatID int64) (*models.Raid, error) {
+	var raid models.Raid
+	err := database.DB.Where("chat_id = ? AND status IN ?", chatID,
+		[]string{models.RaidStatusActive, models.RaidStatusEnded}).
+		Order("created_at DESC").
+		First(&raid).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &raid, nil
+}
+
+// ExtendRaid сдвигает expires_at активного рейда (только вперёд).
+func (r *ModerationRepository) ExtendRaid(id int64, expiresAt time.Time) error {
+	return database.DB.Model(&models.Raid{}).
+		Where("id = ? AND status = ? AND expires_at < ?", id, models.RaidStatusActive, expiresAt).
+		Update("expires_at", expiresAt).Error
+}
+
+// TransitionRaid переводит рейд из одного из статусов from в to. Возвращает
+// true, если строка обновилась — так параллельные callback'и и watcher не
+// выполнят откат дважды.
+func (r *ModerationRepository) TransitionRaid(id int64, from []string, to string, endedBy *int64) (bool, error) {
+	updates := map[string]interface{}{"status": to}
+	if endedBy != nil {
+		updates["ended_by"] = *endedBy
+	}
+	if to != models.RaidStatusActive {
+		updates["ended_at"] = time.Now()
+	}
+	res := database.DB.Model(&models.Raid{}).
+		Where("id = ? AND status IN ?", id, from).
+		Updates(updates)
+	return res.RowsAffected > 0, res.Error
+}
+
+// ListExpiredActiveRaids — активные рейды, у которых истекло окно.
+func (r *ModerationRepository) ListExpiredActiveRaids(now time.Time) ([]models.Raid, error) {
+	var list []models.Raid
+	err := database.DB.Where("status = ? AND expires_at <= ?", models.RaidStatusActive, now).
+		Find(&list).Error
+	return list, err
+}
+
+// AddRaidJoin записывает вступившего в окно рейда. Повторное вступление того
+// же юзера (вышел-зашёл) не дублирует строку.
+func (r *ModerationRepository) AddRaidJoin(j *models.RaidJoin) error {
+	return database.DB.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "raid_id"}, {Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"restricted"}),
+	}).Create(j).Error
+}
+
+// ListRaidJoins — все вступившие в окно рейда, по времени вступления.
+func (r *ModerationRepository) ListRaidJoins(raidID int64) ([]models.RaidJoin, error) {
+	var list []models.RaidJoin
+	err := database.DB.Where("raid_id = ?", raidID).
+		Order("joined_at ASC").
+		Find(&list).Error
+	return list, err
+}
+
+// MarkRaidJoinBanned помечает вступившего как забаненного при откате.
+func (r *ModerationRepository) MarkRaidJoinBanned(raidID, userID int64) error {
+	return database.DB.Model(&models.RaidJoin{}).
+		Where("raid_id = ? AND user_id = ?", raidID, userID).
+		Update("banned", true).Error
+}
+
+// RaidView — рейд + число вступивших в окно.
+type RaidView struct {
+	models.Raid
+	JoinsCount  int `json:"joinsCount" gorm:"column:joins_count"`
+	BannedCount int `json:"bannedCount" gorm:"column:banned_count"`
+}
+
+// ListRecentRaids — последние 50 рейдов для админки.
+func (r *ModerationRepository) ListRecentRaids() ([]RaidView, error) {
+	var rows []RaidView
+	err := database.DB.Raw(`
+		SELECT
+			r.*,
+			COALESCE((SELECT COUNT(*) FROM bot_raid_joins WHERE raid_id = r.id), 0)            AS joins_count,
+			COALESCE((SELECT COUNT(*) FROM bot_raid_joins WHERE raid_id = r.id AND banned), 0) AS banned_count
+		FROM bot_raids r
+		ORDER BY r.created_at DESC
+		LIMIT 50
+	`).Scan(&rows).Error
+	return rows, err
+}
+
+// --- Reputation ---
+
+// ReputationSignals — сырые сигналы репутации telegram-юзера в конкретном чате.
+// MemberSince — регистрация на платформе (nil, если не участник),
+// FirstSeenInChat — первое сохранённое сообщение в чате (в пределах
+// retention chat_messages).
+type ReputationSignals struct {
+	MemberSince     *time.Time `gorm:"column:member_since"`
+	FirstSeenInChat *time.Time `gorm:"column:first_seen_in_chat"`
+	TierLevel       int        `gorm:"column:tier_level"`
+	Points          int        `gorm:"column:points"`
+	ChatMessages    int64      `gorm:"column:chat_messages"`
+}
+
+// GetReputationSignals собирает сигналы одним запросом. Эффективный тир —
+// как в SubscriptionUser.EffectiveTierID: manual, если не истёк, иначе resolved.
+func (r *ModerationRepository) GetReputationSignals(chatID, userID int64, messagesSince time.Time) (*ReputationSignals, error) {
+	var sig ReputationSignals
+	err := database.DB.Raw(`
+		SELECT
+			(SELECT m.created_at FROM members m WHERE m.telegram_id = ? ORDER BY m.id LIMIT 1) AS member_since,
+			(SELECT MIN(cm.sent_at) FROM chat_messages cm
+			  WHERE cm.chat_id = ? AND cm.telegram_user_id = ?) AS first_seen_in_chat,
+			COALESCE((
+				SELECT t.level FROM subscription_users su
+				JOIN subscription_tiers t ON t.id = CASE
+					WHEN su.manual_tier_id IS NOT NULL
+					 AND (su.manual_tier_expires_at IS NULL OR su.manual_tier_expires_at > NOW())
+					THEN su.manual_tier_id
+					ELSE su.resolved_tier_id
+				END
+				WHERE su.id = ?
+			), 0) AS tier_level,
+			COALESCE((
+				SELECT b.balance FROM member_point_balances b
+				JOIN members m ON m.id = b.member_id
+				WHERE m.telegram_id = ?
+			), 0) AS points,
+			(SELECT COUNT(*) FROM chat_messages cm
+			  WHERE cm.chat_id = ? AND cm.telegram_user_id = ? AND cm.sent_at >= ?) AS chat_messages
+	`, userID, chatID, userID, userID, userID, chatID, userID, messagesSince).Scan(&sig).Error
+	if err != nil {
+		return nil, err
+	}
+	return &sig, nil
+}
+
+// --- Chat settings / moderators ---
+
+// GetChatSettings — настройки чата или (nil, nil), если их не задавали.
+func (r *ModerationRepository) GetChatSettings(chatID int64) (*models.ChatModerationSettings, error) {
+	var s models.ChatModerationSettings
+	err := database.DB.Where("chat_id = ?", chatID).First(&s).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &s, nil
+}
+
+// ListChatSettings — все чаты с переопределёнными настройками.
+func (r *ModerationRepository) ListChatSettings() ([]models.ChatModerationSettings, error) {
+	var list []models.ChatModerationSettings
+	err := database.DB.Order("chat_id").Find(&list).Error
+	return list, err
+}
+
+// UpsertChatSettings сохраняет настройки чата целиком.
+func (r *ModerationRepository) UpsertChatSettings(s *models.ChatModerationSettings) error {
+	s.UpdatedAt = time.Now()
+	return database.DB.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "chat_id"}},
+		UpdateAll: true,
+	}).Create(s).Error
+}
+
+// DeleteChatSettings сбрасывает чат на дефолты.
+func (r *ModerationRepository) DeleteChatSettings(chatID int64) error {
+	return database.DB.Where("chat_id = ?", chatID).Delete(&models.ChatModerationSettings{}).Error
+}
+
+// GetChatModerator — волонтёр-модератор чата или (nil, nil).
+func (r *ModerationRepository) GetChatModerator(chatID, userID int64) (*models.ChatModerator, error) {
+	var m models.ChatModerator
+	err := database.DB.Where("chat_id = ? AND user_id = ?", chatID, userID).First(&m).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &m, nil
+}
+
+// ListChatModerators — модераторы чата; chatID=0 — по всем чатам.
+func (r *ModerationRepository) ListChatModerators(chatID int64) ([]models.ChatModerator, error) {
+	var list []models.ChatModerator
+	q := database.DB.Order("chat_id, created_at")
+	if chatID != 0 {
+		q = q.Where("chat_id = ?", chatID)
+	}
+	err := q.Find(&list).Error
+	return list, err
+}
+
+// UpsertChatModerator выдаёт / меняет полномочия. created_at и added_by при
+// повторной выдаче не трогаем — видно, кто и когда назначил изначально.
+func (r *ModerationRepository) UpsertChatModerator(m *models.ChatModerator) error {
+	now := time.Now()
+	m.CreatedAt = now
+	m.UpdatedAt = now
+	return database.DB.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "chat_id"}, {Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"username", "can_mute", "can_ban", "can_cleanup", "updated_at"}),
+	}).Create(m).Error
+}
+
+// DeleteChatModerator снимает модератора. false — его и не было.
+func (r *ModerationRepository) DeleteChatModerator(chatID, userID int64) (bool, error) {
+	res := database.DB.Where("chat_id = ? AND user_id = ?", chatID, userID).Delete(&models.ChatModerator{})
+	return res.RowsAffected > 0, res.Error
+}
+
+// --- Chat joins / restriction policies ---
+
+// RecordChatJoin фиксирует вступление: first_joined_at ставится один раз,
+// last_joined_at обновляется при каждом входе.
+func (r *ModerationRepository) RecordChatJoin(chatID, userID int64, at time.Time) error {
+	return database.DB.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "chat_id"}, {Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"last_joined_at"}),
+	}).Create(&models.ChatJoin{
+		ChatID:        chatID,
+		UserID:        userID,
+		FirstJoinedAt: at,
+		LastJoinedAt:  at,
+	}).Error
+}
+
+// GetChatJoin — запись о вступлении или (nil, nil), если бот его не видел.
+func (r *ModerationRepository) GetChatJoin(chatID, userID int64) (*models.ChatJoin, error) {
+	var j models.ChatJoin
+	err := database.DB.Where("chat_id = ? AND user_id = ?", chatID, userID).First(&j).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &j, nil
+}
+
+// GetSubscriberTierLevel — уровень действующего тира подписчика (ручной тир
+// приоритетнее вычисленного, как в GetReputationSignals); 0 — без подписки.
+func (r *ModerationRepository) GetSubscriberTierLevel(userID int64) (int, error) {
+	var level int
+	err := database.DB.Raw(`
+		SELECT COALESCE((
+			SELECT t.level FROM subscription_users su
+			JOIN subscription_tiers t ON t.id = CASE
+				WHEN su.manual_tier_id IS NOT NULL
+				 AND (su.manual_tier_expires_at IS NULL OR su.manual_tier_expires_at > NOW())
+				THEN su.manual_tier_id
+				ELSE su.resolved_tier_id
+			END
+			WHERE su.id = ?
+		), 0)
+	`, userID).Scan(&level).Error
+	return level, err
+}
+
+// GetRestrictionPolicy — политика чата или (nil, nil).
+func (r *ModerationRepository) GetRestrictionPolicy(chatID int64) (*models.ChatRestrictionPolicy, error) {
+	var p models.ChatRestrictionPolicy
+	err := database.DB.Where("chat_id = ?", chatID).First(&p).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &p, nil
+}
+
+// ListRestrictionPolicies — все чаты с заданными политиками.
+func (r *ModerationRepository) ListRestrictionPolicies() ([]models.ChatRestrictionPolicy, error) {
+	var list []models.ChatRestrictionPolicy
+	err := database.DB.Order("chat_id").Find(&list).Error
+	return list, err
+}
+
+// UpsertRestrictionPolicy создаёт или перезаписывает политику чата.
+func (r *ModerationRepository) UpsertRestrictionPolicy(p *models.ChatRestrictionPolicy) error {
+	p.UpdatedAt = time.Now()
+	return database.DB.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "chat_id"}},
+		UpdateAll: true,
+	}).Create(p).Error
+}
+
+// DeleteRestrictionPolicy снимает все ограничения в чате.
+func (r *ModerationRepository) DeleteRestrictionPolicy(chatID int64) error {
+	return database.DB.Where("chat_id = ?", chatID).Delete(&models.ChatRestrictionPolicy{}).Error
+}
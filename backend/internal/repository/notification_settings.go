@@ -85,6 +85,7 @@ func (r *NotificationSettingsRepository) Upsert(settings *models.NotificationSet
 	existing.DailyEvening = settings.DailyEvening
 	existing.DailyStreak = settings.DailyStreak
 	existing.DailyRaffle = settings.DailyRaffle
+	existing.MonthlyStatement = settings.MonthlyStatement
 
 	if err := database.DB.Save(&existing).Error; err != nil {
 		return nil, err
@@ -0,0 +1,69 @@
+package repository
+
+import (
+	"time"
+
+	"ithozyeva/database"
+	"ithozyeva/internal/models"
+)
+
+// PlatformSearchRepository — короткие выборки для поиска по платформе:
+// по несколько совпадений каждого типа, без пагинации.
+type PlatformSearchRepository struct{}
+
+func NewPlatformSearchRepository() *PlatformSearchRepository {
+	return &PlatformSearchRepository{}
+}
+
+func likePattern(q string) string {
+	return "%" + escapeLike(q) + "%"
+}
+
+// SearchEvents — предстоящие события (и повторяющиеся с будущими
+// вхождениями) по названию; пустой q — все предстоящие. Эксклюзивные
+// события чата видны только тем, у кого есть доступ к этому чату.
+func (r *PlatformSearchRepository) SearchEvents(q string, viewerTelegramID int64, now time.Time, limit int) ([]models.PlatformSearchHit, error) {
+	var hits []models.PlatformSearchHit
+	db := database.DB.Table("events e").
+		Select("'"+models.PlatformSearchEvent+"' AS kind, e.id, e.title, e.place AS subtitle, e.date").
+		Where("(e.date >= ? OR (e.is_repeating AND (e.repeat_end_date IS NULL OR e.repeat_end_date >= ?)))", now, now).
+		Where(`(COALESCE(e.exclusive_chat_id, 0) = 0 OR e.exclusive_chat_id IN (
+			SELECT a.chat_id FROM subscription_user_chat_access a
+			WHERE a.user_id = ? AND a.revoked_at IS NULL))`, viewerTelegramID)
+	if q != "" {
+		db = db.Where(`e.title ILIKE ? ESCAPE '\'`, likePattern(q))
+	}
+	err := db.Order("e.date ASC").Limit(limit).Scan(&hits).Error
+	return hits, err
+}
+
+// SearchMentors — менторы по имени, username и роду занятий.
+func (r *PlatformSearchRepository) SearchMentors(q string, limit int) ([]models.PlatformSearchHit, error) {
+	var hits []models.PlatformSearchHit
+	like := likePattern(q)
+	err := database.DB.Table("mentors mt").
+		Select(`'`+models.PlatformSearchMentor+`' AS kind, mt.id,
+			COALESCE(NULLIF(TRIM(m.first_name || ' ' || m.last_name), ''), m.username) AS title, mt.occupation AS subtitle, m.avatar_url AS image_url`).
+		Joins(`JOIN members m ON m.id = mt."memberId"`).
+		Where(`m.first_name || ' ' || m.last_name ILIKE ? ESCAPE '\' OR m.username ILIKE ? ESCAPE '\' OR mt.occupation ILIKE ? ESCAPE '\'`,
+			like, like, like).
+		Order(`mt."order" ASC, mt.id ASC`).
+		Limit(limit).
+		Scan(&hits).Error
+	return hits, err
+}
+
+// SearchMembers — публичные профили участников по имени и username.
+func (r *PlatformSearchRepository) SearchMembers(q string, limit int) ([]models.PlatformSearchHit, error) {
+	var hits []models.PlatformSearchHit
+	like := likePattern(q)
+	err := database.DB.Table("members m").
+		Select(`'`+models.PlatformSearchMember+`' AS kind, m.id,
+			COALESCE(NULLIF(TRIM(m.first_name || ' ' || m.last_name), ''), m.username) AS title,
+			TRIM(BOTH ' ·' FROM m.grade || ' · ' || m.company) AS subtitle, m.avatar_url AS image_url`).
+		Where(`m.first_name || ' ' || m.last_name ILIKE ? ESCAPE '\' OR m.username ILIKE ? ESCAPE '\'`, like, like).
+		Order("m.id ASC").
+		Limit(limit).
+		Scan(&hits).Error
+	return hits, err
+}
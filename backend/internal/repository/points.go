@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type PointsRepository struct{}
@@ -34,9 +35,16 @@ func (r *PointsRepository) AwardPoints(tx *models.PointTransaction) error {
 }
 
 func (r *PointsRepository) GetBalance(memberId int64) (int, error) {
+	return r.GetBalanceTx(database.DB, memberId)
+}
+
+// GetBalanceTx — баланс из снапшота member_point_balances (его ведёт
+// триггер на INSERT в point_transactions). Для списаний вызывать под
+// pg_advisory_xact_lock(memberId) в той же транзакции.
+func (r *PointsRepository) GetBalanceTx(db *gorm.DB, memberId int64) (int, error) {
 	var balance int
-	err := database.DB.Raw(
-		`SELECT COALESCE(SUM(amount), 0) FROM point_transactions WHERE member_id = ?`,
+	err := db.Raw(
+		`SELECT COALESCE((SELECT balance FROM member_point_balances WHERE member_id = ?), 0)`,
 		memberId,
 	).Scan(&balance).Error
 	return balance, err
@@ -55,11 +63,10 @@ func (r *PointsRepository) GetTransactions(memberId int64, limit int) ([]models.
 func (r *PointsRepository) GetLeaderboard(limit int) ([]models.MemberPointsBalance, error) {
 	entries := make([]models.MemberPointsBalance, 0)
 	err := database.DB.Raw(
-		`SELECT pt.member_id, m.first_name, m.last_name, m.username, m.avatar_url,
-		        COALESCE(SUM(pt.amount), 0) as total
-		 FROM point_transactions pt
-		 JOIN members m ON m.id = pt.member_id
-		 GROUP BY pt.member_id, m.first_name, m.last_name, m.username, m.avatar_url
+		`SELECT b.member_id, m.first_name, m.last_name, m.username, m.avatar_url,
+		        b.balance as total
+		 FROM member_point_balances b
+		 JOIN members m ON m.id = b.member_id
 		 ORDER BY total DESC
 		 LIMIT ?`,
 		limit,
@@ -90,9 +97,11 @@ func (r *PointsRepository) SearchTransactions(username *string, limit, offset in
 	}
 
 	baseQuery := `SELECT pt.id, pt.member_id, m.first_name as member_first_name, m.last_name as member_last_name,
-		        m.username as member_username, pt.amount, pt.reason, pt.source_type, pt.description, pt.created_at
+		        m.username as member_username, pt.amount, pt.reason, pt.source_type, pt.description,
+		        pt.reverses_id, rv.id as reversed_by_id, pt.created_at
 		 FROM point_transactions pt
-		 JOIN members m ON m.id = pt.member_id`
+		 JOIN members m ON m.id = pt.member_id
+		 LEFT JOIN point_transactions rv ON rv.reverses_id = pt.id`
 
 	var args []interface{}
 	if username != nil {
@@ -109,8 +118,96 @@ func (r *PointsRepository) SearchTransactions(username *string, limit, offset in
 	return items, total, nil
 }
 
-func (r *PointsRepository) DeleteTransaction(id int64) error {
-	return database.DB.Delete(&models.PointTransaction{}, id).Error
+// GetTransactionForUpdateTx — транзакция журнала под блокировкой строки
+// или nil.
+func (r *PointsRepository) GetTransactionForUpdateTx(tx *gorm.DB, id int64) (*models.PointTransaction, error) {
+	var items []models.PointTransaction
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", id).Limit(1).Find(&items).Error
+	if err != nil || len(items) == 0 {
+		return nil, err
+	}
+	return &items[0], nil
+}
+
+// ReverseTx записывает сторно orig: обратная сумма и reverses_id на
+// исходную. Уникальный индекс по reverses_id не даёт отменить дважды.
+func (r *PointsRepository) ReverseTx(tx *gorm.DB, orig *models.PointTransaction, reason models.PointReason, description string) (*models.PointTransaction, error) {
+	origId := orig.Id
+	rev := &models.PointTransaction{
+		MemberId:    orig.MemberId,
+		Amount:      -orig.Amount,
+		Reason:      reason,
+		SourceType:  "point_transaction",
+		SourceId:    orig.Id,
+		Description: description,
+		ReversesId:  &origId,
+	}
+	if err := tx.Create(rev).Error; err != nil {
+		return nil, err
+	}
+	return rev, nil
+}
+
+// ReversedIdsTx — какие из ids уже отменены сторно.
+func (r *PointsRepository) ReversedIdsTx(db *gorm.DB, ids []int64) ([]int64, error) {
+	out := make([]int64, 0)
+	if len(ids) == 0 {
+		return out, nil
+	}
+	err := db.Raw(`SELECT reverses_id FROM point_transactions WHERE reverses_id IN ? ORDER BY reverses_id`, ids).Scan(&out).Error
+	return out, err
+}
+
+// CountBalances — сколько участников со снапшотом или транзакциями.
+func (r *PointsRepository) CountBalances() (int64, error) {
+	var n int64
+	err := database.DB.Raw(
+		`SELECT COUNT(*) FROM (
+			SELECT member_id FROM member_point_balances
+			UNION
+			SELECT DISTINCT member_id FROM point_transactions
+		) t`,
+	).Scan(&n).Error
+	return n, err
+}
+
+// BalanceMismatches — участники, у которых снапшот разошёлся с суммой
+// журнала (или снапшота нет вовсе).
+func (r *PointsRepository) BalanceMismatches() ([]models.PointsBalanceMismatch, error) {
+	items := make([]models.PointsBalanceMismatch, 0)
+	err := database.DB.Raw(
+		`WITH ledger AS (
+			SELECT member_id, SUM(amount) AS total FROM point_transactions GROUP BY member_id
+		)
+		SELECT COALESCE(b.member_id, l.member_id) AS member_id,
+		       COALESCE(b.balance, 0) AS snapshot, COALESCE(l.total, 0) AS ledger
+		FROM member_point_balances b
+		FULL JOIN ledger l ON l.member_id = b.member_id
+		WHERE COALESCE(b.balance, 0) <> COALESCE(l.total, 0)
+		ORDER BY 1`,
+	).Scan(&items).Error
+	return items, err
+}
+
+// RepairBalance пересчитывает снапшот участника по журналу под тем же
+// advisory-lock'ом, что и списания, — параллельная ставка не проскочит
+// между SUM и записью.
+func (r *PointsRepository) RepairBalance(memberId int64) error {
+	return database.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec(`SELECT pg_advisory_xact_lock(?)`, memberId).Error; err != nil {
+			return err
+		}
+		return tx.Exec(
+			`INSERT INTO member_point_balances (member_id, balance, last_transaction_id, updated_at)
+			 SELECT ?, COALESCE(SUM(amount), 0), MAX(id), NOW()
+			 FROM point_transactions WHERE member_id = ?
+			 ON CONFLICT (member_id) DO UPDATE
+			     SET balance = EXCLUDED.balance,
+			         last_transaction_id = EXCLUDED.last_transaction_id,
+			         updated_at = NOW()`,
+			memberId, memberId,
+		).Error
+	})
 }
 
 func (r *PointsRepository) CreateManualTransaction(tx *models.PointTransaction) error {
@@ -189,3 +286,15 @@ func (r *PointsRepository) GetMembersWithStreak(weeks int) ([]int64, error) {
 	).Scan(&memberIds).Error
 	return memberIds, err
 }
+
+// EarnedSinceTx — сколько участник заработал по reason'у с since (для
+// лимитов начислений).
+func (r *PointsRepository) EarnedSinceTx(db *gorm.DB, memberId int64, reason models.PointReason, since time.Time) (int, error) {
+	var earned int
+	err := db.Raw(
+		`SELECT COALESCE(SUM(amount), 0) FROM point_transactions
+		 WHERE member_id = ? AND reason = ? AND created_at >= ? AND amount > 0`,
+		memberId, reason, since,
+	).Scan(&earned).Error
+	return earned, err
+}
@@ -0,0 +1,200 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"ithozyeva/database"
+	"ithozyeva/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type PointsFlagRepository struct{}
+
+func NewPointsFlagRepository() *PointsFlagRepository {
+	return &PointsFlagRepository{}
+}
+
+// KudosRingPair — пара участников, которые часто благодарят друг друга.
+type KudosRingPair struct {
+	MemberId        int64
+	RelatedMemberId int64
+	Sent            int
+	Received        int
+}
+
+// EarningBurst — заработанное участником за окно.
+type EarningBurst struct {
+	MemberId int64
+	Total    int
+	Count    int
+}
+
+// CasinoStreak — серия выигрышей подряд.
+type CasinoStreak struct {
+	MemberId   int64
+	Streak     int
+	Won        int
+	FirstBetId int64
+	StartedAt  time.Time
+	EndedAt    time.Time
+}
+
+// KudosRings — пары с не менее чем atLeast kudos в каждую сторону с since.
+func (r *PointsFlagRepository) KudosRings(since time.Time, atLeast int) ([]KudosRingPair, error) {
+	pairs := make([]KudosRingPair, 0)
+	err := database.DB.Raw(
+		`WITH pairs AS (
+			SELECT from_id, to_id, COUNT(*) AS n
+			FROM kudos
+			WHERE created_at >= ?
+			GROUP BY from_id, to_id
+		)
+		SELECT p.from_id AS member_id, p.to_id AS related_member_id, p.n AS sent, q.n AS received
+		FROM pairs p
+		JOIN pairs q ON q.from_id = p.to_id AND q.to_id = p.from_id
+		WHERE p.from_id < p.to_id AND p.n >= ? AND q.n >= ?`,
+		since, atLeast, atLeast,
+	).Scan(&pairs).Error
+	return pairs, err
+}
+
+// EarningBursts — участники, заработавшие в [since, until) не меньше atLeast
+// без ignored reason'ов. ignored не должен быть пустым.
+func (r *PointsFlagRepository) EarningBursts(since, until time.Time, atLeast int, ignored []string) ([]EarningBurst, error) {
+	bursts := make([]EarningBurst, 0)
+	err := database.DB.Raw(
+		`SELECT member_id, SUM(amount) AS total, COUNT(*) AS count
+		 FROM point_transactions
+		 WHERE created_at >= ? AND created_at < ? AND amount > 0 AND reason NOT IN ?
+		 GROUP BY member_id
+		 HAVING SUM(amount) >= ?`,
+		since, until, ignored, atLeast,
+	).Scan(&bursts).Error
+	return bursts, err
+}
+
+// CasinoStreaks — серии из atLeast и более выигрышных ставок подряд с since
+// (gaps-and-islands по id ставок участника).
+func (r *PointsFlagRepository) CasinoStreaks(since time.Time, atLeast int) ([]CasinoStreak, error) {
+	streaks := make([]CasinoStreak, 0)
+	err := database.DB.Raw(
+		`WITH b AS (
+			SELECT member_id, id, profit, created_at, profit > 0 AS win,
+			       ROW_NUMBER() OVER (PARTITION BY member_id ORDER BY id)
+			     - ROW_NUMBER() OVER (PARTITION BY member_id, profit > 0 ORDER BY id) AS grp
+			FROM casino_bets
+			WHERE created_at >= ?
+		)
+		SELECT member_id, COUNT(*) AS streak, SUM(profit) AS won, MIN(id) AS first_bet_id,
+		       MIN(created_at) AS started_at, MAX(created_at) AS ended_at
+		FROM b
+		WHERE win
+		GROUP BY member_id, grp
+		HAVING COUNT(*) >= ?`,
+		since, atLeast,
+	).Scan(&streaks).Error
+	return streaks, err
+}
+
+// Create заводит флаг; false — такой сигнал (dedup_key) уже есть.
+func (r *PointsFlagRepository) Create(flag *models.PointsFlag) (bool, error) {
+	res := database.DB.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "dedup_key"}},
+		DoNothing: true,
+	}).Create(flag)
+	return res.RowsAffected > 0, res.Error
+}
+
+const adminPointsFlagSelect = `f.*, m.first_name AS member_first_name, m.last_name AS member_last_name,
+	m.username AS member_username, COALESCE(rm.username, '') AS related_username`
+
+func adminPointsFlagQuery() *gorm.DB {
+	return database.DB.Table("points_flags f").
+		Joins("JOIN members m ON m.id = f.member_id").
+		Joins("LEFT JOIN members rm ON rm.id = f.related_member_id")
+}
+
+// Search — очередь флагов; status="" — все.
+func (r *PointsFlagRepository) Search(status string, limit, offset int) ([]models.AdminPointsFlag, int64, error) {
+	items := make([]models.AdminPointsFlag, 0)
+	var total int64
+	q := adminPointsFlagQuery()
+	if status != "" {
+		q = q.Where("f.status = ?", status)
+	}
+	if err := q.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	err := q.Select(adminPointsFlagSelect).
+		Order("f.created_at DESC").
+		Limit(limit).Offset(offset).
+		Scan(&items).Error
+	return items, total, err
+}
+
+// Get — флаг с участниками или nil.
+func (r *PointsFlagRepository) Get(id int64) (*models.AdminPointsFlag, error) {
+	var items []models.AdminPointsFlag
+	err := adminPointsFlagQuery().Select(adminPointsFlagSelect).Where("f.id = ?", id).Scan(&items).Error
+	if err != nil || len(items) == 0 {
+		return nil, err
+	}
+	return &items[0], nil
+}
+
+// GetForUpdateTx — флаг под блокировкой строки или nil.
+func (r *PointsFlagRepository) GetForUpdateTx(tx *gorm.DB, id int64) (*models.PointsFlag, error) {
+	var flag models.PointsFlag
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&flag, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &flag, nil
+}
+
+// ResolveTx закрывает флаг со статусом status.
+func (r *PointsFlagRepository) ResolveTx(tx *gorm.DB, flag *models.PointsFlag, status string, actorId int64, actorName, note string, at time.Time) error {
+	flag.Status = status
+	flag.ResolvedBy = &actorId
+	flag.ResolvedByName = actorName
+	flag.ResolutionNote = note
+	flag.ResolvedAt = &at
+	return tx.Model(flag).Updates(map[string]interface{}{
+		"status":           status,
+		"resolved_by":      actorId,
+		"resolved_by_name": actorName,
+		"resolution_note":  note,
+		"resolved_at":      at,
+	}).Error
+}
+
+// WindowTransactions — транзакции участников за [start, end], новые первыми.
+func (r *PointsFlagRepository) WindowTransactions(memberIds []int64, start, end time.Time) ([]models.AdminPointTransaction, error) {
+	items := make([]models.AdminPointTransaction, 0)
+	err := database.DB.Raw(
+		`SELECT pt.id, pt.member_id, m.first_name AS member_first_name, m.last_name AS member_last_name,
+		        m.username AS member_username, pt.amount, pt.reason, pt.source_type, pt.description,
+		        pt.reverses_id, rv.id AS reversed_by_id, pt.created_at
+		 FROM point_transactions pt
+		 JOIN members m ON m.id = pt.member_id
+		 LEFT JOIN point_transactions rv ON rv.reverses_id = pt.id
+		 WHERE pt.member_id IN ? AND pt.created_at BETWEEN ? AND ?
+		 ORDER BY pt.created_at DESC
+		 LIMIT 500`,
+		memberIds, start, end,
+	).Scan(&items).Error
+	return items, err
+}
+
+// TransactionsTx — транзакции по id (для списания).
+func (r *PointsFlagRepository) TransactionsTx(tx *gorm.DB, ids []int64) ([]models.PointTransaction, error) {
+	items := make([]models.PointTransaction, 0)
+	err := tx.Where("id IN ?", ids).Order("id").Find(&items).Error
+	return items, err
+}
@@ -0,0 +1,120 @@
+package repository
+
+import (
+	"time"
+
+	"ithozyeva/database"
+	"ithozyeva/internal/models"
+
+	"gorm.io/gorm/clause"
+)
+
+type PointsStatementRepository struct{}
+
+func NewPointsStatementRepository() *PointsStatementRepository {
+	return &PointsStatementRepository{}
+}
+
+// ReasonTotals — сумма и число операций по причинам за [from, to),
+// крупные суммы первыми.
+func (r *PointsStatementRepository) ReasonTotals(memberId int64, from, to time.Time) ([]models.PointsReasonTotal, error) {
+	totals := make([]models.PointsReasonTotal, 0)
+	err := database.DB.Raw(
+		`SELECT reason, SUM(amount) AS amount, COUNT(*) AS count
+		 FROM point_transactions
+		 WHERE member_id = ? AND created_at >= ? AND created_at < ?
+		 GROUP BY reason
+		 ORDER BY ABS(SUM(amount)) DESC, reason`,
+		memberId, from, to,
+	).Scan(&totals).Error
+	return totals, err
+}
+
+// BalanceAt — баланс по журналу баллов на момент at (не включая).
+func (r *PointsStatementRepository) BalanceAt(memberId int64, at time.Time) (int, error) {
+	var balance int
+	err := database.DB.Raw(
+		`SELECT COALESCE(SUM(amount), 0) FROM point_transactions WHERE member_id = ? AND created_at < ?`,
+		memberId, at,
+	).Scan(&balance).Error
+	return balance, err
+}
+
+// PointTotals — начислено и списано баллов за [from, to).
+func (r *PointsStatementRepository) PointTotals(memberId int64, from, to time.Time) (earned, spent int, err error) {
+	return r.totals("point_transactions", memberId, from, to)
+}
+
+// CreditTotals — начислено и списано реферальных кредитов за [from, to).
+func (r *PointsStatementRepository) CreditTotals(memberId int64, from, to time.Time) (earned, spent int, err error) {
+	return r.totals("referral_credit_transactions", memberId, from, to)
+}
+
+func (r *PointsStatementRepository) totals(table string, memberId int64, from, to time.Time) (earned, spent int, err error) {
+	var row struct {
+		Earned int
+		Spent  int
+	}
+	err = database.DB.Raw(
+		`SELECT COALESCE(SUM(amount) FILTER (WHERE amount > 0), 0) AS earned,
+		        COALESCE(-SUM(amount) FILTER (WHERE amount < 0), 0) AS spent
+		 FROM `+table+`
+		 WHERE member_id = ? AND created_at >= ? AND created_at < ?`,
+		memberId, from, to,
+	).Scan(&row).Error
+	return row.Earned, row.Spent, err
+}
+
+// MembersDue — участники с операциями за [from, to), которым выписка за
+// month ещё не собрана и которые её не отключили (дефолт — включена, как
+// у остальных пушей в PushTargetsRepository).
+func (r *PointsStatementRepository) MembersDue(month, from, to time.Time) ([]int64, error) {
+	ids := make([]int64, 0)
+	err := database.DB.Raw(
+		`SELECT m.id
+		 FROM members m
+		 LEFT JOIN notification_settings ns ON ns.member_id = m.id
+		 WHERE m.telegram_id IS NOT NULL AND m.telegram_id > 0
+		   AND COALESCE(ns.mute_all, FALSE) = FALSE
+		   AND COALESCE(ns.monthly_statement, TRUE) = TRUE
+		   AND (EXISTS (SELECT 1 FROM point_transactions t
+		                WHERE t.member_id = m.id AND t.created_at >= ? AND t.created_at < ?)
+		        OR EXISTS (SELECT 1 FROM referral_credit_transactions t
+		                   WHERE t.member_id = m.id AND t.created_at >= ? AND t.created_at < ?))
+		   AND NOT EXISTS (SELECT 1 FROM points_statements s WHERE s.member_id = m.id AND s.month = ?)
+		 ORDER BY m.id`,
+		from, to, from, to, month,
+	).Scan(&ids).Error
+	return ids, err
+}
+
+// Create сохраняет выписку; повтор за тот же месяц — no-op (false).
+func (r *PointsStatementRepository) Create(rec *models.PointsStatementRecord) (bool, error) {
+	res := database.DB.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "member_id"}, {Name: "month"}},
+		DoNothing: true,
+	}).Create(rec)
+	return res.RowsAffected > 0, res.Error
+}
+
+// ListUnsent — собранные, но ещё не отправленные ботом выписки.
+func (r *PointsStatementRepository) ListUnsent(limit int) ([]models.PointsStatementRecord, error) {
+	list := make([]models.PointsStatementRecord, 0)
+	err := database.DB.Table("points_statements s").
+		Select("s.*, m.telegram_id").
+		Joins("JOIN members m ON m.id = s.member_id").
+		Where("s.sent_at IS NULL").
+		Order("s.created_at, s.id").
+		Limit(limit).
+		Find(&list).Error
+	return list, err
+}
+
+// MarkSent помечает выписку отправленной. false — её уже взял другой
+// инстанс бота.
+func (r *PointsStatementRepository) MarkSent(id int64, at time.Time) (bool, error) {
+	res := database.DB.Model(&models.PointsStatementRecord{}).
+		Where("id = ? AND sent_at IS NULL", id).
+		Update("sent_at", at)
+	return res.RowsAffected > 0, res.Error
+}
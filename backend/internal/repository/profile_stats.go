@@ -2,6 +2,7 @@ package repository
 
 import (
 	"ithozyeva/database"
+	"ithozyeva/internal/models"
 )
 
 type ProfileStatsRepository struct{}
@@ -26,6 +27,8 @@ type ProfileStats struct {
 	AchievementsEarned int             `json:"achievementsEarned"`
 	AchievementsTotal  int             `json:"achievementsTotal"`
 	ActivityHistory   []ActivityDay    `json:"activityHistory"`
+	// Заполняет handler из LeaderboardService.
+	LeaderboardWins []models.LeaderboardWin `json:"leaderboardWins"`
 }
 
 type PointsMonth struct {
@@ -87,7 +90,7 @@ func (r *ProfileStatsRepository) GetStats(memberId int64) (*ProfileStats, error)
 	}
 
 	// Balance
-	if err := database.DB.Raw(`SELECT COALESCE(SUM(amount), 0) FROM point_transactions WHERE member_id = ?`, memberId).Scan(&stats.PointsBalance).Error; err != nil {
+	if err := database.DB.Raw(`SELECT COALESCE((SELECT balance FROM member_point_balances WHERE member_id = ?), 0)`, memberId).Scan(&stats.PointsBalance).Error; err != nil {
 		return nil, err
 	}
 
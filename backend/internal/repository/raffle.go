@@ -5,6 +5,7 @@ import (
 	"ithozyeva/internal/models"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 const buyTicketsBatchSize = 1000
@@ -32,7 +33,8 @@ func (r *RaffleRepository) GetAll(memberId int64) ([]models.RafflePublic, error)
 	items := make([]models.RafflePublic, 0)
 	err := database.DB.Raw(`
 		SELECT r.id, r.title, r.description, r.prize, r.ticket_cost, r.max_tickets,
-			r.ends_at, r.status, r.winner_id,
+			r.ends_at, r.status, r.winner_id, COALESCE(r.seed_hash, '') as seed_hash,
+			CASE WHEN r.status = ? THEN COALESCE(r.server_seed, '') ELSE '' END as server_seed,
 			w.first_name as winner_first_name, w.last_name as winner_last_name,
 			w.username as winner_username, w.avatar_url as winner_avatar_url,
 			(SELECT COUNT(*) FROM raffle_tickets WHERE raffle_id = r.id) as total_tickets,
@@ -40,21 +42,237 @@ func (r *RaffleRepository) GetAll(memberId int64) ([]models.RafflePublic, error)
 		FROM raffles r
 		LEFT JOIN members w ON w.id = r.winner_id
 		ORDER BY r.status ASC, r.ends_at ASC
-	`, memberId).Scan(&items).Error
+	`, models.RaffleStatusFinished, memberId).Scan(&items).Error
+	if err != nil {
+		return items, err
+	}
 
+	ids := make([]int64, len(items))
 	for i := range items {
 		items[i].AfterFind(nil)
+		ids[i] = items[i].Id
 	}
-
-	return items, err
+	prizes, err := r.PrizesFor(ids)
+	if err != nil {
+		return items, err
+	}
+	winners, err := r.WinnersFor(ids)
+	if err != nil {
+		return items, err
+	}
+	for i := range items {
+		items[i].Prizes = prizes[items[i].Id]
+		items[i].Winners = winners[items[i].Id]
+	}
+	return items, nil
 }
 
 func (r *RaffleRepository) GetAllAdmin() ([]models.Raffle, error) {
 	var raffles []models.Raffle
-	err := database.DB.Order("status ASC, ends_at ASC").Find(&raffles).Error
+	if err := database.DB.Order("status ASC, ends_at ASC").Find(&raffles).Error; err != nil {
+		return raffles, err
+	}
+	ids := make([]int64, len(raffles))
+	for i := range raffles {
+		ids[i] = raffles[i].Id
+	}
+	prizes, err := r.PrizesFor(ids)
+	if err != nil {
+		return raffles, err
+	}
+	for i := range raffles {
+		raffles[i].Prizes = prizes[raffles[i].Id]
+	}
+	return raffles, nil
+}
+
+// CreateWithPrizes создаёт розыгрыш вместе с призовыми местами.
+func (r *RaffleRepository) CreateWithPrizes(raffle *models.Raffle) error {
+	return database.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(raffle).Error; err != nil {
+			return err
+		}
+		for i := range raffle.Prizes {
+			raffle.Prizes[i].Id = 0
+			raffle.Prizes[i].RaffleId = raffle.Id
+			raffle.Prizes[i].Position = i + 1
+		}
+		if len(raffle.Prizes) == 0 {
+			return nil
+		}
+		return tx.Create(&raffle.Prizes).Error
+	})
+}
+
+// Prizes — призовые места розыгрыша по порядку.
+func (r *RaffleRepository) Prizes(raffleId int64) ([]models.RafflePrize, error) {
+	prizes := make([]models.RafflePrize, 0)
+	err := database.DB.Where("raffle_id = ?", raffleId).Order("position").Find(&prizes).Error
+	return prizes, err
+}
+
+// PrizesFor — призовые места нескольких розыгрышей, сгруппированные по id.
+func (r *RaffleRepository) PrizesFor(raffleIds []int64) (map[int64][]models.RafflePrize, error) {
+	res := make(map[int64][]models.RafflePrize, len(raffleIds))
+	if len(raffleIds) == 0 {
+		return res, nil
+	}
+	var prizes []models.RafflePrize
+	if err := database.DB.Where("raffle_id IN ?", raffleIds).Order("raffle_id, position").Find(&prizes).Error; err != nil {
+		return nil, err
+	}
+	for _, p := range prizes {
+		res[p.RaffleId] = append(res[p.RaffleId], p)
+	}
+	return res, nil
+}
+
+const raffleWinnersSelect = `
+	SELECT rw.*, m.first_name AS member_first_name, m.last_name AS member_last_name,
+		m.username AS member_username
+	FROM raffle_winners rw
+	JOIN members m ON m.id = rw.member_id`
+
+// Winners — победители розыгрыша в порядке жеребьёвки.
+func (r *RaffleRepository) Winners(raffleId int64) ([]models.RaffleWinner, error) {
+	winners := make([]models.RaffleWinner, 0)
+	err := database.DB.Raw(raffleWinnersSelect+` WHERE rw.raffle_id = ? ORDER BY rw.place`, raffleId).
+		Scan(&winners).Error
+	return winners, err
+}
+
+// WinnersFor — победители нескольких розыгрышей, сгруппированные по id.
+func (r *RaffleRepository) WinnersFor(raffleIds []int64) (map[int64][]models.RaffleWinner, error) {
+	res := make(map[int64][]models.RaffleWinner, len(raffleIds))
+	if len(raffleIds) == 0 {
+		return res, nil
+	}
+	var winners []models.RaffleWinner
+	if err := database.DB.Raw(raffleWinnersSelect+` WHERE rw.raffle_id IN ? ORDER BY rw.raffle_id, rw.place`, raffleIds).
+		Scan(&winners).Error; err != nil {
+		return nil, err
+	}
+	for _, w := range winners {
+		res[w.RaffleId] = append(res[w.RaffleId], w)
+	}
+	return res, nil
+}
+
+func (r *RaffleRepository) GetWinner(id int64) (*models.RaffleWinner, error) {
+	var winners []models.RaffleWinner
+	if err := database.DB.Raw(raffleWinnersSelect+` WHERE rw.id = ?`, id).Scan(&winners).Error; err != nil {
+		return nil, err
+	}
+	if len(winners) == 0 {
+		return nil, nil
+	}
+	return &winners[0], nil
+}
+
+// UpdateFulfillment меняет статус выдачи приза; fulfilled_at ставится при
+// первой выдаче и сбрасывается при возврате в pending.
+func (r *RaffleRepository) UpdateFulfillment(id int64, status, note string) error {
+	return database.DB.Exec(
+		`UPDATE raffle_winners
+		 SET status = ?, fulfillment_note = ?,
+			 fulfilled_at = CASE WHEN ? = ? THEN COALESCE(fulfilled_at, NOW()) ELSE NULL END
+		 WHERE id = ?`,
+		status, note, status, models.RaffleWinnerFulfilled, id,
+	).Error
+}
+
+// Entrants — участники жеребьёвки с числом билетов, по возрастанию
+// member_id: этот порядок — часть алгоритма розыгрыша.
+func (r *RaffleRepository) Entrants(raffleId int64) ([]models.RaffleEntrant, error) {
+	return r.EntrantsTx(database.DB, raffleId)
+}
+
+// EntrantsTx — Entrants внутри транзакции жеребьёвки.
+func (r *RaffleRepository) EntrantsTx(db *gorm.DB, raffleId int64) ([]models.RaffleEntrant, error) {
+	entrants := make([]models.RaffleEntrant, 0)
+	err := db.Raw(`
+		SELECT member_id, COUNT(*) AS tickets FROM raffle_tickets
+		WHERE raffle_id = ?
+		GROUP BY member_id
+		ORDER BY member_id
+	`, raffleId).Scan(&entrants).Error
+	return entrants, err
+}
+
+// LockForDrawTx — активный розыгрыш под FOR UPDATE: покупки и выдача
+// билетов (FOR SHARE) ждут конца жеребьёвки, а после неё видят закрытый
+// розыгрыш. nil — розыгрыш уже разыгран.
+func (r *RaffleRepository) LockForDrawTx(tx *gorm.DB, raffleId int64) (*models.Raffle, error) {
+	var raffles []models.Raffle
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id = ? AND status = ?", raffleId, models.RaffleStatusActive).
+		Limit(1).Find(&raffles).Error
+	if err != nil || len(raffles) == 0 {
+		return nil, err
+	}
+	return &raffles[0], nil
+}
+
+// LockOpenTx — розыгрыш, ещё принимающий билеты (ACTIVE и ends_at в
+// будущем), под FOR SHARE: жеребьёвка дождётся конца покупки. exclusive —
+// FOR UPDATE, чтобы покупки с лимитом билетов шли по очереди. nil —
+// продажа закрыта.
+func (r *RaffleRepository) LockOpenTx(tx *gorm.DB, raffleId int64, exclusive bool) (*models.Raffle, error) {
+	strength := "SHARE"
+	if exclusive {
+		strength = "UPDATE"
+	}
+	var raffles []models.Raffle
+	err := tx.Clauses(clause.Locking{Strength: strength}).
+		Where("id = ? AND status = ? AND ends_at > NOW()", raffleId, models.RaffleStatusActive).
+		Limit(1).Find(&raffles).Error
+	if err != nil || len(raffles) == 0 {
+		return nil, err
+	}
+	return &raffles[0], nil
+}
+
+// FinishDrawTx закрывает активный розыгрыш и записывает победителей.
+// false — розыгрыш уже закрыт другим процессом.
+func (r *RaffleRepository) FinishDrawTx(tx *gorm.DB, raffle *models.Raffle, winners []models.RaffleWinner) (bool, error) {
+	updates := map[string]interface{}{
+		"status":      models.RaffleStatusFinished,
+		"server_seed": raffle.ServerSeed,
+		"seed_hash":   raffle.SeedHash,
+		"drawn_at":    raffle.DrawnAt,
+	}
+	if len(winners) > 0 {
+		updates["winner_id"] = winners[0].MemberId
+	}
+	res := tx.Model(&models.Raffle{}).Where("id = ? AND status = ?", raffle.Id, models.RaffleStatusActive).
+		Updates(updates)
+	if res.Error != nil || res.RowsAffected == 0 {
+		return false, res.Error
+	}
+	if len(winners) == 0 {
+		return true, nil
+	}
+	return true, tx.Create(&winners).Error
+}
+
+// Unannounced — завершённые ручные розыгрыши с победителями, о которых
+// бот ещё не написал в чат.
+func (r *RaffleRepository) Unannounced(limit int) ([]models.Raffle, error) {
+	var raffles []models.Raffle
+	err := database.DB.
+		Where("kind = ? AND status = ? AND announced_at IS NULL", models.RaffleKindManual, models.RaffleStatusFinished).
+		Where("EXISTS (SELECT 1 FROM raffle_winners w WHERE w.raffle_id = raffles.id)").
+		Order("drawn_at").Limit(limit).Find(&raffles).Error
 	return raffles, err
 }
 
+// MarkAnnounced помечает розыгрыш опубликованным; false — уже помечен.
+func (r *RaffleRepository) MarkAnnounced(id int64) (bool, error) {
+	res := database.DB.Model(&models.Raffle{}).Where("id = ? AND announced_at IS NULL", id).
+		Update("announced_at", gorm.Expr("NOW()"))
+	return res.RowsAffected > 0, res.Error
+}
+
 func (r *RaffleRepository) GetActive() ([]models.Raffle, error) {
 	var raffles []models.Raffle
 	err := database.DB.Where("status = ? AND ends_at > NOW()", models.RaffleStatusActive).Find(&raffles).Error
@@ -90,13 +308,17 @@ func (r *RaffleRepository) BuyTicketsTx(db *gorm.DB, raffleId, memberId int64, c
 // AwardTicketTx идемпотентно выдаёт один билет за конкретную активность.
 // Повторный вызов с тем же (raffleId, memberId, sourceType, sourceId) ничего
 // не делает благодаря UNIQUE-индексу uniq_raffle_ticket_source.
+// Билет выдаётся, только пока розыгрыш открыт; строка розыгрыша берётся
+// FOR SHARE, чтобы билет не проскочил мимо идущей жеребьёвки.
 // Возвращает (true, nil), если билет реально был создан.
 func (r *RaffleRepository) AwardTicketTx(db *gorm.DB, raffleId, memberId int64, sourceType string, sourceId int64) (bool, error) {
 	res := db.Exec(
 		`INSERT INTO raffle_tickets (raffle_id, member_id, source_type, source_id, bought_at)
-		 VALUES (?, ?, ?, ?, NOW())
+		 SELECT id, ?, ?, ?, NOW() FROM raffles
+		 WHERE id = ? AND status = ? AND ends_at > NOW()
+		 FOR SHARE
 		 ON CONFLICT (raffle_id, member_id, source_type, source_id) DO NOTHING`,
-		raffleId, memberId, sourceType, sourceId,
+		memberId, sourceType, sourceId, raffleId, models.RaffleStatusActive,
 	)
 	if res.Error != nil {
 		return false, res.Error
@@ -105,8 +327,13 @@ func (r *RaffleRepository) AwardTicketTx(db *gorm.DB, raffleId, memberId int64,
 }
 
 func (r *RaffleRepository) GetTicketCount(raffleId int64) (int64, error) {
+	return r.GetTicketCountTx(database.DB, raffleId)
+}
+
+// GetTicketCountTx — GetTicketCount внутри транзакции покупки.
+func (r *RaffleRepository) GetTicketCountTx(db *gorm.DB, raffleId int64) (int64, error) {
 	var count int64
-	err := database.DB.Model(&models.RaffleTicket{}).Where("raffle_id = ?", raffleId).Count(&count).Error
+	err := db.Model(&models.RaffleTicket{}).Where("raffle_id = ?", raffleId).Count(&count).Error
 	return count, err
 }
 
@@ -131,27 +358,6 @@ func (r *RaffleRepository) GetMemberTicketSources(raffleId, memberId int64) ([]s
 	return sources, err
 }
 
-func (r *RaffleRepository) PickRandomWinner(raffleId int64) (int64, error) {
-	var memberId int64
-	err := database.DB.Raw(`
-		SELECT member_id FROM raffle_tickets
-		WHERE raffle_id = ?
-		ORDER BY RANDOM() LIMIT 1
-	`, raffleId).Scan(&memberId).Error
-	return memberId, err
-}
-
-func (r *RaffleRepository) FinishRaffle(id int64, winnerId int64) error {
-	updates := map[string]interface{}{
-		"status": models.RaffleStatusFinished,
-	}
-	if winnerId != 0 {
-		updates["winner_id"] = winnerId
-	}
-	return database.DB.Model(&models.Raffle{}).Where("id = ?", id).
-		Updates(updates).Error
-}
-
 func (r *RaffleRepository) Update(raffle *models.Raffle) error {
 	return database.DB.Save(raffle).Error
 }
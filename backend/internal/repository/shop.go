@@ -0,0 +1,190 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"ithozyeva/database"
+	"ithozyeva/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ShopRepository struct{}
+
+func NewShopRepository() *ShopRepository {
+	return &ShopRepository{}
+}
+
+// ListItems — каталог; activeOnly — только то, что в продаже.
+func (r *ShopRepository) ListItems(activeOnly bool) ([]models.ShopItem, error) {
+	items := make([]models.ShopItem, 0)
+	db := database.DB.Order("sort_order ASC, id ASC")
+	if activeOnly {
+		db = db.Where("is_active")
+	}
+	err := db.Find(&items).Error
+	return items, err
+}
+
+// GetItem — позиция по id; nil — нет такой.
+func (r *ShopRepository) GetItem(id int64) (*models.ShopItem, error) {
+	var item models.ShopItem
+	err := database.DB.Take(&item, id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
+
+// GetItemForUpdateTx — позиция под блокировкой строки: остаток меняется
+// только в этой транзакции.
+func (r *ShopRepository) GetItemForUpdateTx(tx *gorm.DB, id int64) (*models.ShopItem, error) {
+	var item models.ShopItem
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Take(&item, id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
+
+func (r *ShopRepository) CreateItem(item *models.ShopItem) error {
+	return database.DB.Create(item).Error
+}
+
+// UpdateItem сохраняет все редактируемые поля, включая сброс stock в NULL.
+func (r *ShopRepository) UpdateItem(item *models.ShopItem) error {
+	return database.DB.Model(item).Select(
+		"kind", "title", "description", "image_url", "price", "quantity",
+		"stock", "per_member_limit", "is_active", "sort_order", "updated_at",
+	).Updates(item).Error
+}
+
+func (r *ShopRepository) DeleteItem(id int64) error {
+	return database.DB.Delete(&models.ShopItem{}, id).Error
+}
+
+// CountOrdersForItem — заказы позиции (любые), для защиты от удаления.
+func (r *ShopRepository) CountOrdersForItem(id int64) (int64, error) {
+	var n int64
+	err := database.DB.Model(&models.ShopOrder{}).Where("item_id = ?", id).Count(&n).Error
+	return n, err
+}
+
+// CountMemberOrdersTx — неотменённые заказы участника на позицию.
+func (r *ShopRepository) CountMemberOrdersTx(tx *gorm.DB, itemId, memberId int64) (int64, error) {
+	var n int64
+	err := tx.Model(&models.ShopOrder{}).
+		Where("item_id = ? AND member_id = ? AND status <> ?", itemId, memberId, models.ShopOrderCancelled).
+		Count(&n).Error
+	return n, err
+}
+
+// MyOrderCounts — неотменённые заказы участника по позициям.
+func (r *ShopRepository) MyOrderCounts(memberId int64) (map[int64]int, error) {
+	var rows []struct {
+		ItemId int64
+		N      int
+	}
+	err := database.DB.Model(&models.ShopOrder{}).
+		Select("item_id, COUNT(*) AS n").
+		Where("member_id = ? AND status <> ?", memberId, models.ShopOrderCancelled).
+		Group("item_id").
+		Scan(&rows).Error
+	counts := make(map[int64]int, len(rows))
+	for _, row := range rows {
+		counts[row.ItemId] = row.N
+	}
+	return counts, err
+}
+
+// AdjustStockTx меняет остаток на delta; у позиций без ограничения
+// (stock IS NULL) ничего не делает.
+func (r *ShopRepository) AdjustStockTx(tx *gorm.DB, itemId int64, delta int) error {
+	return tx.Model(&models.ShopItem{}).
+		Where("id = ? AND stock IS NOT NULL", itemId).
+		Update("stock", gorm.Expr("stock + ?", delta)).Error
+}
+
+func (r *ShopRepository) CreateOrderTx(tx *gorm.DB, order *models.ShopOrder) error {
+	return tx.Create(order).Error
+}
+
+// GetOrderForUpdateTx — заказ под блокировкой строки; nil — нет такого.
+func (r *ShopRepository) GetOrderForUpdateTx(tx *gorm.DB, id int64) (*models.ShopOrder, error) {
+	var order models.ShopOrder
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Take(&order, id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &order, nil
+}
+
+// SetOrderStatusTx переводит заказ в status и ставит отметку времени этапа.
+func (r *ShopRepository) SetOrderStatusTx(tx *gorm.DB, order *models.ShopOrder, status, adminNote string, now time.Time) error {
+	updates := map[string]interface{}{"status": status, "updated_at": now}
+	if adminNote != "" {
+		updates["admin_note"] = adminNote
+	}
+	switch status {
+	case models.ShopOrderShipped:
+		updates["shipped_at"] = now
+	case models.ShopOrderDelivered:
+		updates["delivered_at"] = now
+	case models.ShopOrderCancelled:
+		updates["cancelled_at"] = now
+	}
+	return tx.Model(order).Updates(updates).Error
+}
+
+func (r *ShopRepository) GetOrder(id int64) (*models.ShopOrder, error) {
+	var order models.ShopOrder
+	err := database.DB.Take(&order, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &order, nil
+}
+
+// MemberOrders — заказы участника, новые сверху.
+func (r *ShopRepository) MemberOrders(memberId int64, limit int) ([]models.ShopOrder, error) {
+	orders := make([]models.ShopOrder, 0)
+	err := database.DB.Where("member_id = ?", memberId).
+		Order("created_at DESC").
+		Limit(limit).
+		Find(&orders).Error
+	return orders, err
+}
+
+// SearchOrders — заказы для админки; пустой status — все.
+func (r *ShopRepository) SearchOrders(status string, limit, offset int) ([]models.AdminShopOrder, int64, error) {
+	items := make([]models.AdminShopOrder, 0)
+	var total int64
+
+	base := database.DB.Table("shop_orders o").
+		Joins("JOIN members m ON m.id = o.member_id").
+		Joins("JOIN shop_items i ON i.id = o.item_id")
+	if status != "" {
+		base = base.Where("o.status = ?", status)
+	}
+	if err := base.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	err := base.Select(`o.*, i.kind AS item_kind, m.first_name AS member_first_name,
+			m.last_name AS member_last_name, m.username AS member_username, m.telegram_id AS member_tg_id`).
+		Order("o.created_at DESC").
+		Limit(limit).
+		Offset(offset).
+		Scan(&items).Error
+	return items, total, err
+}
@@ -16,8 +16,14 @@ func NewStreakRepository() *StreakRepository {
 }
 
 func (r *StreakRepository) Get(memberId int64) (*models.MemberStreak, error) {
+	return r.GetTx(database.DB, memberId)
+}
+
+// GetTx — стрик внутри транзакции; для изменений вызывать под
+// pg_advisory_xact_lock(memberId). Записи нет — стрик по умолчанию.
+func (r *StreakRepository) GetTx(db *gorm.DB, memberId int64) (*models.MemberStreak, error) {
 	var s models.MemberStreak
-	err := database.DB.Where("member_id = ?", memberId).First(&s).Error
+	err := db.Where("member_id = ?", memberId).First(&s).Error
 	if errors.Is(err, gorm.ErrRecordNotFound) {
 		return &models.MemberStreak{MemberId: memberId, FreezesAvailable: 1}, nil
 	}
@@ -28,5 +34,9 @@ func (r *StreakRepository) Get(memberId int64) (*models.MemberStreak, error) {
 }
 
 func (r *StreakRepository) Save(s *models.MemberStreak) error {
-	return database.DB.Save(s).Error
+	return r.SaveTx(database.DB, s)
+}
+
+func (r *StreakRepository) SaveTx(db *gorm.DB, s *models.MemberStreak) error {
+	return db.Save(s).Error
 }
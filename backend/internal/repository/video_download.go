@@ -0,0 +1,115 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"ithozyeva/database"
+	"ithozyeva/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type VideoDownloadRepository struct{}
+
+func NewVideoDownloadRepository() *VideoDownloadRepository {
+	return &VideoDownloadRepository{}
+}
+
+// GetCached — file_id ролика по нормализованной ссылке; nil — не кэширован.
+func (r *VideoDownloadRepository) GetCached(sourceURL string) (*models.VideoFileCache, error) {
+	var entry models.VideoFileCache
+	err := database.DB.Where("source_url = ?", sourceURL).Take(&entry).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+// SaveCached запоминает file_id; повторная загрузка того же ролика
+// перезаписывает file_id.
+func (r *VideoDownloadRepository) SaveCached(entry *models.VideoFileCache) error {
+	return database.DB.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "source_url"}},
+		DoUpdates: clause.AssignmentColumns([]string{"file_id", "file_size", "last_used_at"}),
+	}).Create(entry).Error
+}
+
+// TouchCached — ролик переслан из кэша.
+func (r *VideoDownloadRepository) TouchCached(sourceURL string, now time.Time) error {
+	return database.DB.Model(&models.VideoFileCache{}).
+		Where("source_url = ?", sourceURL).
+		Updates(map[string]interface{}{
+			"hits":         gorm.Expr("hits + 1"),
+			"last_used_at": now,
+		}).Error
+}
+
+// DeleteCached — Telegram больше не принимает file_id.
+func (r *VideoDownloadRepository) DeleteCached(sourceURL string) error {
+	return database.DB.Where("source_url = ?", sourceURL).Delete(&models.VideoFileCache{}).Error
+}
+
+func (r *VideoDownloadRepository) Create(d *models.VideoDownload) error {
+	return database.DB.Create(d).Error
+}
+
+// StatsBySource — запросы и исходы по источникам с since.
+func (r *VideoDownloadRepository) StatsBySource(since time.Time) ([]models.VideoSourceStats, error) {
+	var rows []models.VideoSourceStats
+	err := database.DB.Raw(`
+		SELECT source,
+			COUNT(*) AS requests,
+			COUNT(*) FILTER (WHERE status = 'sent') AS downloaded,
+			COUNT(*) FILTER (WHERE status = 'cached') AS cache_hits,
+			COUNT(*) FILTER (WHERE status IN ('failed', 'too_large', 'timeout')) AS failures,
+			COUNT(*) FILTER (WHERE status IN ('rate_limited', 'queue_full')) AS rejected,
+			COALESCE(AVG(duration_ms) FILTER (WHERE status = 'sent'), 0) AS avg_duration_ms
+		FROM video_downloads
+		WHERE created_at >= ?
+		GROUP BY source
+		ORDER BY requests DESC
+	`, since).Scan(&rows).Error
+	return rows, err
+}
+
+// StatsByDay — исходы по дням (МСК) с since.
+func (r *VideoDownloadRepository) StatsByDay(since time.Time) ([]models.VideoDownloadDay, error) {
+	var rows []models.VideoDownloadDay
+	err := database.DB.Raw(`
+		SELECT (created_at AT TIME ZONE 'Europe/Moscow')::date AS day,
+			COUNT(*) FILTER (WHERE status = 'sent') AS downloaded,
+			COUNT(*) FILTER (WHERE status = 'cached') AS cache_hits,
+			COUNT(*) FILTER (WHERE status IN ('failed', 'too_large', 'timeout')) AS failures,
+			COUNT(*) FILTER (WHERE status IN ('rate_limited', 'queue_full')) AS rejected
+		FROM video_downloads
+		WHERE created_at >= ?
+		GROUP BY day
+		ORDER BY day
+	`, since).Scan(&rows).Error
+	return rows, err
+}
+
+// RecentFailures — последние неудачные скачивания с текстом ошибки.
+func (r *VideoDownloadRepository) RecentFailures(since time.Time, limit int) ([]models.VideoDownload, error) {
+	var rows []models.VideoDownload
+	err := database.DB.
+		Where("created_at >= ? AND status IN ?", since,
+			[]string{models.VideoDownloadFailed, models.VideoDownloadTooLarge, models.VideoDownloadTimeout}).
+		Order("created_at DESC").
+		Limit(limit).
+		Find(&rows).Error
+	return rows, err
+}
+
+// CacheSummary — сколько роликов в кэше и сколько раз их переслали.
+func (r *VideoDownloadRepository) CacheSummary() (models.VideoCacheSummary, error) {
+	var s models.VideoCacheSummary
+	err := database.DB.Raw(`SELECT COUNT(*) AS entries, COALESCE(SUM(hits), 0) AS hits FROM video_file_cache`).
+		Scan(&s).Error
+	return s, err
+}
@@ -2,13 +2,13 @@ package service
 
 import (
 	"errors"
-	"fmt"
 	"net/url"
 	"strings"
 	"unicode/utf8"
 
 	"gorm.io/gorm"
 
+	"ithozyeva/internal/i18n"
 	"ithozyeva/internal/models"
 	"ithozyeva/internal/repository"
 )
@@ -190,11 +190,11 @@ func (s *AIMaterialService) validateAndNormalize(req *models.CreateAIMaterialReq
 
 	titleLen := utf8.RuneCountInString(out.Title)
 	if titleLen < models.AIMaterialMinTitleLen || titleLen > models.AIMaterialMaxTitleLen {
-		return nil, nil, fmt.Errorf("длина названия должна быть от %d до %d символов", models.AIMaterialMinTitleLen, models.AIMaterialMaxTitleLen)
+		return nil, nil, i18n.Errorf("длина названия должна быть от %d до %d символов", models.AIMaterialMinTitleLen, models.AIMaterialMaxTitleLen)
 	}
 	summaryLen := utf8.RuneCountInString(out.Summary)
 	if summaryLen < models.AIMaterialMinSummaryLen || summaryLen > models.AIMaterialMaxSummaryLen {
-		return nil, nil, fmt.Errorf("длина описания должна быть от %d до %d символов", models.AIMaterialMinSummaryLen, models.AIMaterialMaxSummaryLen)
+		return nil, nil, i18n.Errorf("длина описания должна быть от %d до %d символов", models.AIMaterialMinSummaryLen, models.AIMaterialMaxSummaryLen)
 	}
 
 	if !models.IsValidAIMaterialContentType(out.ContentType) {
@@ -210,7 +210,7 @@ func (s *AIMaterialService) validateAndNormalize(req *models.CreateAIMaterialReq
 			return nil, nil, errors.New("содержимое промта обязательно")
 		}
 		if utf8.RuneCountInString(out.PromptBody) > models.AIMaterialMaxPromptBody {
-			return nil, nil, fmt.Errorf("содержимое промта не должно превышать %d символов", models.AIMaterialMaxPromptBody)
+			return nil, nil, i18n.Errorf("содержимое промта не должно превышать %d символов", models.AIMaterialMaxPromptBody)
 		}
 		out.ExternalURL = ""
 		out.AgentConfig = ""
@@ -219,7 +219,7 @@ func (s *AIMaterialService) validateAndNormalize(req *models.CreateAIMaterialReq
 			return nil, nil, errors.New("ссылка обязательна")
 		}
 		if len(out.ExternalURL) > models.AIMaterialMaxURLLen {
-			return nil, nil, fmt.Errorf("длина ссылки не должна превышать %d символов", models.AIMaterialMaxURLLen)
+			return nil, nil, i18n.Errorf("длина ссылки не должна превышать %d символов", models.AIMaterialMaxURLLen)
 		}
 		if !isValidHTTPURL(out.ExternalURL) {
 			return nil, nil, errors.New("ссылка должна начинаться с http:// или https://")
@@ -231,7 +231,7 @@ func (s *AIMaterialService) validateAndNormalize(req *models.CreateAIMaterialReq
 			return nil, nil, errors.New("конфиг агента обязателен")
 		}
 		if utf8.RuneCountInString(out.AgentConfig) > models.AIMaterialMaxAgentConfig {
-			return nil, nil, fmt.Errorf("конфиг агента не должен превышать %d символов", models.AIMaterialMaxAgentConfig)
+			return nil, nil, i18n.Errorf("конфиг агента не должен превышать %d символов", models.AIMaterialMaxAgentConfig)
 		}
 		out.PromptBody = ""
 		out.ExternalURL = ""
@@ -254,7 +254,7 @@ func normalizeTags(in []string) ([]string, error) {
 			continue
 		}
 		if utf8.RuneCountInString(t) > models.AIMaterialMaxTagLen {
-			return nil, fmt.Errorf("длина тега не должна превышать %d символов", models.AIMaterialMaxTagLen)
+			return nil, i18n.Errorf("длина тега не должна превышать %d символов", models.AIMaterialMaxTagLen)
 		}
 		if _, ok := seen[t]; ok {
 			continue
@@ -89,3 +89,29 @@ func (s *AppSettingsService) GetFloat(key string, defaultVal float64) float64 {
 	}
 	return v
 }
+
+// GetJSON разбирает значение в dst (массив, объект). false — ключа нет,
+// значение null или невалидный JSON: вызывающий берёт свой дефолт.
+func (s *AppSettingsService) GetJSON(key string, dst interface{}) bool {
+	raw := s.getRaw(key)
+	if len(raw) == 0 || string(raw) == "null" {
+		return false
+	}
+	return json.Unmarshal(raw, dst) == nil
+}
+
+// SetJSON сохраняет значение и сбрасывает кэш ключа — изменение из
+// админки видно этому процессу сразу, остальным — через TTL.
+func (s *AppSettingsService) SetJSON(key string, v interface{}) error {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	if err := s.repo.Set(key, raw); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	delete(s.cache, key)
+	s.mu.Unlock()
+	return nil
+}
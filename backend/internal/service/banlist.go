@@ -0,0 +1,371 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"ithozyeva/internal/i18n"
+	"ithozyeva/internal/models"
+)
+
+// Общий бан-лист — формат обмена известными спамерами с партнёрскими
+// сообществами. Версия 1.
+//
+// JSON:
+//
+//	{
+//	  "version": 1,
+//	  "source": "ithozyeva",
+//	  "exported_at": "2026-05-23T12:00:00Z",
+//	  "bans": [
+//	    {"user_id": 123, "reason": "spam", "expires_at": null, "banned_at": "2026-05-01T10:00:00Z"}
+//	  ]
+//	}
+//
+// Голый массив bans без обёртки тоже принимается.
+//
+// CSV (первая строка — заголовок, порядок колонок произвольный, обязательна
+// только user_id):
+//
+//	user_id,reason,expires_at,banned_at
+//	123,spam,,2026-05-01T10:00:00Z
+//
+// Даты — RFC 3339. Пустой / null expires_at — бессрочно. Записи с истёкшим
+// expires_at пропускаются, повторы user_id схлопываются (побеждает последняя).
+const (
+	BanlistFormatVersion = 1
+	BanlistFormatJSON    = "json"
+	BanlistFormatCSV     = "csv"
+
+	// BanlistMaxEntries / BanlistMaxBytes — защита от гигантского файла.
+	BanlistMaxEntries = 100000
+	BanlistMaxBytes   = 10 << 20
+)
+
+var ErrBanlistEmpty = errors.New("banlist: список пуст")
+
+// BanlistEntry — одна запись бан-листа.
+type BanlistEntry struct {
+	UserID    int64      `json:"user_id"`
+	Reason    string     `json:"reason,omitempty"`
+	ExpiresAt *time.Time `json:"expires_at"`
+	BannedAt  *time.Time `json:"banned_at,omitempty"`
+}
+
+// BanlistDocument — JSON-обёртка формата v1.
+type BanlistDocument struct {
+	Version    int            `json:"version"`
+	Source     string         `json:"source,omitempty"`
+	ExportedAt time.Time      `json:"exported_at"`
+	Bans       []BanlistEntry `json:"bans"`
+}
+
+// BanlistImportResult — итог импорта / синхронизации.
+type BanlistImportResult struct {
+	Parsed   int   `json:"parsed"`
+	Imported int64 `json:"imported"` // вставлено или обновлено
+	Removed  int64 `json:"removed"`  // только sync: убрано из внешнего списка
+}
+
+var banlistHTTPClient = &http.Client{Timeout: 30 * time.Second}
+
+// ParseBanlist разбирает файл бан-листа. format — "json", "csv" или "" для
+// автоопределения по первому значащему символу.
+func ParseBanlist(data []byte, format string, now time.Time) ([]BanlistEntry, error) {
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))
+	if format == "" {
+		format = BanlistFormatCSV
+		if t := bytes.TrimSpace(data); len(t) > 0 && (t[0] == '{' || t[0] == '[') {
+			format = BanlistFormatJSON
+		}
+	}
+
+	var raw []BanlistEntry
+	var err error
+	switch strings.ToLower(format) {
+	case BanlistFormatJSON:
+		raw, err = parseBanlistJSON(data)
+	case BanlistFormatCSV:
+		raw, err = parseBanlistCSV(data)
+	default:
+		return nil, i18n.Errorf("banlist: неизвестный формат %q (json, csv)", format)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	index := make(map[int64]int, len(raw))
+	out := make([]BanlistEntry, 0, len(raw))
+	for i, e := range raw {
+		if e.UserID <= 0 {
+			return nil, i18n.Errorf("banlist: запись %d: некорректный user_id %d", i+1, e.UserID)
+		}
+		if e.ExpiresAt != nil && !e.ExpiresAt.After(now) {
+			continue
+		}
+		e.Reason = strings.TrimSpace(e.Reason)
+		if j, ok := index[e.UserID]; ok {
+			out[j] = e
+			continue
+		}
+		if len(out) >= BanlistMaxEntries {
+			return nil, i18n.Errorf("banlist: больше %d записей", BanlistMaxEntries)
+		}
+		index[e.UserID] = len(out)
+		out = append(out, e)
+	}
+	return out, nil
+}
+
+func parseBanlistJSON(data []byte) ([]BanlistEntry, error) {
+	t := bytes.TrimSpace(data)
+	if len(t) > 0 && t[0] == '[' {
+		var list []BanlistEntry
+		if err := json.Unmarshal(t, &list); err != nil {
+			return nil, fmt.Errorf("banlist: json: %w", err)
+		}
+		return list, nil
+	}
+	var doc BanlistDocument
+	if err := json.Unmarshal(t, &doc); err != nil {
+		return nil, fmt.Errorf("banlist: json: %w", err)
+	}
+	if doc.Version != 0 && doc.Version != BanlistFormatVersion {
+		return nil, i18n.Errorf("banlist: неподдерживаемая версия формата %d", doc.Version)
+	}
+	return doc.Bans, nil
+}
+
+func parseBanlistCSV(data []byte) ([]BanlistEntry, error) {
+	r := csv.NewReader(bytes.NewReader(data))
+	r.FieldsPerRecord = -1
+	r.TrimLeadingSpace = true
+	header, err := r.Read()
+	if err == io.EOF {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("banlist: csv: %w", err)
+	}
+	cols := make(map[string]int, len(header))
+	for i, h := range header {
+		cols[strings.ToLower(strings.TrimSpace(h))] = i
+	}
+	if _, ok := cols["user_id"]; !ok {
+		return nil, fmt.Errorf("banlist: csv: нет колонки user_id")
+	}
+	field := func(rec []string, name string) string {
+		if i, ok := cols[name]; ok && i < len(rec) {
+			return strings.TrimSpace(rec[i])
+		}
+		return ""
+	}
+
+	var list []BanlistEntry
+	for line := 2; ; line++ {
+		rec, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("banlist: csv: %w", err)
+		}
+		id, err := strconv.ParseInt(field(rec, "user_id"), 10, 64)
+		if err != nil {
+			return nil, i18n.Errorf("banlist: csv строка %d: некорректный user_id", line)
+		}
+		e := BanlistEntry{UserID: id, Reason: field(rec, "reason")}
+		if e.ExpiresAt, err = parseBanlistTime(field(rec, "expires_at")); err != nil {
+			return nil, fmt.Errorf("banlist: csv строка %d: expires_at: %w", line, err)
+		}
+		if e.BannedAt, err = parseBanlistTime(field(rec, "banned_at")); err != nil {
+			return nil, fmt.Errorf("banlist: csv строка %d: banned_at: %w", line, err)
+		}
+		list = append(list, e)
+	}
+	return list, nil
+}
+
+func parseBanlistTime(s string) (*time.Time, error) {
+	if s == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+// WriteBanlistJSON пишет документ формата v1.
+func WriteBanlistJSON(w io.Writer, source string, entries []BanlistEntry, now time.Time) error {
+	if entries == nil {
+		entries = []BanlistEntry{}
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(BanlistDocument{
+		Version:    BanlistFormatVersion,
+		Source:     source,
+		ExportedAt: now.UTC(),
+		Bans:       entries,
+	})
+}
+
+// WriteBanlistCSV пишет CSV с заголовком user_id,reason,expires_at,banned_at.
+func WriteBanlistCSV(w io.Writer, entries []BanlistEntry) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"user_id", "reason", "expires_at", "banned_at"}); err != nil {
+		return err
+	}
+	formatTime := func(t *time.Time) string {
+		if t == nil {
+			return ""
+		}
+		return t.UTC().Format(time.RFC3339)
+	}
+	for _, e := range entries {
+		rec := []string{strconv.FormatInt(e.UserID, 10), e.Reason, formatTime(e.ExpiresAt), formatTime(e.BannedAt)}
+		if err := cw.Write(rec); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// FetchBanlist скачивает внешний бан-лист. Формат берётся из Content-Type,
+// затем из расширения URL, иначе — автоопределение.
+func FetchBanlist(ctx context.Context, client *http.Client, url string, now time.Time) ([]BanlistEntry, error) {
+	if client == nil {
+		client = banlistHTTPClient
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json, text/csv")
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("banlist: fetch: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("banlist: fetch: HTTP %d", resp.StatusCode)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, BanlistMaxBytes+1))
+	if err != nil {
+		return nil, fmt.Errorf("banlist: fetch: %w", err)
+	}
+	if len(data) > BanlistMaxBytes {
+		return nil, i18n.Errorf("banlist: fetch: ответ больше %d байт", BanlistMaxBytes)
+	}
+
+	format := ""
+	ct := strings.ToLower(resp.Header.Get("Content-Type"))
+	switch {
+	case strings.Contains(ct, "json"):
+		format = BanlistFormatJSON
+	case strings.Contains(ct, "csv"):
+		format = BanlistFormatCSV
+	case strings.HasSuffix(strings.ToLower(req.URL.Path), ".csv"):
+		format = BanlistFormatCSV
+	}
+	return ParseBanlist(data, format, now)
+}
+
+// ImportBanlist сохраняет записи с источником source. Ручные баны
+// (source=manual) не перетираются — см. InsertSharedBans.
+func (s *ModerationService) ImportBanlist(entries []BanlistEntry, source string, actorID int64) (*BanlistImportResult, error) {
+	res := &BanlistImportResult{Parsed: len(entries)}
+	now := time.Now()
+	bans := make([]models.GlobalBan, 0, len(entries))
+	for _, e := range entries {
+		b := models.GlobalBan{
+			UserID:    e.UserID,
+			BannedBy:  actorID,
+			ExpiresAt: e.ExpiresAt,
+			Source:    source,
+			UpdatedAt: now,
+		}
+		if e.BannedAt != nil {
+			b.CreatedAt = *e.BannedAt
+		}
+		if e.Reason != "" {
+			reason := e.Reason
+			b.Reason = &reason
+		}
+		bans = append(bans, b)
+	}
+	n, err := s.repo.InsertSharedBans(bans)
+	if err != nil {
+		return nil, err
+	}
+	res.Imported = n
+
+	action := models.ModerationActionBanlistImport
+	if source == models.GlobalBanSourceBlocklist {
+		action = models.ModerationActionBanlistSync
+	}
+	if err := s.LogActionWithMeta(&models.ModerationAction{
+		ActorUserID: actorID,
+		Action:      action,
+	}, map[string]interface{}{
+		"source":   source,
+		"parsed":   res.Parsed,
+		"imported": res.Imported,
+	}); err != nil {
+		return res, err
+	}
+	return res, nil
+}
+
+// SyncBanlist подтягивает внешний список: новые записи добавляются, записи
+// источника blocklist, которых больше нет в списке, удаляются. Пустой ответ
+// считается ошибкой — иначе сбой на стороне списка снял бы все баны.
+func (s *ModerationService) SyncBanlist(ctx context.Context, url string) (*BanlistImportResult, error) {
+	started := time.Now()
+	entries, err := FetchBanlist(ctx, nil, url, started)
+	if err != nil {
+		return nil, err
+	}
+	if len(entries) == 0 {
+		return nil, ErrBanlistEmpty
+	}
+	res, err := s.ImportBanlist(entries, models.GlobalBanSourceBlocklist, 0)
+	if err != nil {
+		return nil, err
+	}
+	if res.Removed, err = s.repo.DeleteStaleSharedBans(models.GlobalBanSourceBlocklist, started); err != nil {
+		return res, err
+	}
+	return res, nil
+}
+
+// ExportGlobalBans — активные баны в формате бан-листа; source "" — все.
+func (s *ModerationService) ExportGlobalBans(source string) ([]BanlistEntry, error) {
+	bans, err := s.repo.ListActiveGlobalBansBySource(time.Now(), source)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]BanlistEntry, 0, len(bans))
+	for _, b := range bans {
+		e := BanlistEntry{UserID: b.UserID, ExpiresAt: b.ExpiresAt}
+		if b.Reason != nil {
+			e.Reason = *b.Reason
+		}
+		bannedAt := b.CreatedAt
+		e.BannedAt = &bannedAt
+		out = append(out, e)
+	}
+	return out, nil
+}
@@ -0,0 +1,114 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+var banlistNow = time.Date(2026, 5, 23, 12, 0, 0, 0, time.UTC)
+
+func TestParseBanlistJSON(t *testing.T) {
+	data := `{"version":1,"source":"partner","exported_at":"2026-05-23T00:00:00Z","bans":[
+		{"user_id":1,"reason":" spam "},
+		{"user_id":2,"expires_at":"2026-05-01T00:00:00Z"},
+		{"user_id":3,"expires_at":"2026-06-01T00:00:00Z"},
+		{"user_id":1,"reason":"scam"}
+	]}`
+	got, err := ParseBanlist([]byte(data), "", banlistNow)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	// 2 истёк и пропущен, дубль 1 схлопнут (побеждает последняя запись).
+	if len(got) != 2 || got[0].UserID != 1 || got[1].UserID != 3 {
+		t.Fatalf("got %+v", got)
+	}
+	if got[0].Reason != "scam" {
+		t.Fatalf("reason = %q, want scam", got[0].Reason)
+	}
+}
+
+func TestParseBanlistBareArrayAndErrors(t *testing.T) {
+	got, err := ParseBanlist([]byte(`[{"user_id":5}]`), BanlistFormatJSON, banlistNow)
+	if err != nil || len(got) != 1 || got[0].UserID != 5 {
+		t.Fatalf("bare array: %+v, %v", got, err)
+	}
+	cases := map[string]string{
+		"bad id":      `[{"user_id":0}]`,
+		"bad version": `{"version":2,"bans":[]}`,
+		"no user_id":  "reason\nspam\n",
+		"bad date":    "user_id,expires_at\n1,tomorrow\n",
+	}
+	for name, data := range cases {
+		if _, err := ParseBanlist([]byte(data), "", banlistNow); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
+
+func TestBanlistCSVRoundTrip(t *testing.T) {
+	exp := time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC)
+	banned := time.Date(2026, 5, 1, 10, 0, 0, 0, time.UTC)
+	in := []BanlistEntry{
+		{UserID: 10, Reason: "spam, links", ExpiresAt: &exp, BannedAt: &banned},
+		{UserID: 11},
+	}
+	var buf bytes.Buffer
+	if err := WriteBanlistCSV(&buf, in); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	got, err := ParseBanlist(buf.Bytes(), "", banlistNow)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if len(got) != 2 || got[0].Reason != "spam, links" || !got[0].ExpiresAt.Equal(exp) ||
+		!got[0].BannedAt.Equal(banned) || got[1].ExpiresAt != nil {
+		t.Fatalf("round trip mismatch: %+v", got)
+	}
+}
+
+func TestBanlistJSONRoundTrip(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteBanlistJSON(&buf, "ithozyeva", []BanlistEntry{{UserID: 7, Reason: "bot"}}, banlistNow); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if !strings.Contains(buf.String(), `"version": 1`) {
+		t.Fatalf("version missing: %s", buf.String())
+	}
+	got, err := ParseBanlist(buf.Bytes(), "", banlistNow)
+	if err != nil || len(got) != 1 || got[0].UserID != 7 || got[0].Reason != "bot" {
+		t.Fatalf("round trip: %+v, %v", got, err)
+	}
+}
+
+func TestFetchBanlist(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/list.csv":
+			w.Header().Set("Content-Type", "application/octet-stream")
+			w.Write([]byte("user_id,reason\n100,spam\n101,\n"))
+		case "/list":
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"version":1,"bans":[{"user_id":200}]}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	got, err := FetchBanlist(context.Background(), srv.Client(), srv.URL+"/list.csv", banlistNow)
+	if err != nil || len(got) != 2 || got[0].UserID != 100 {
+		t.Fatalf("csv: %+v, %v", got, err)
+	}
+	got, err = FetchBanlist(context.Background(), srv.Client(), srv.URL+"/list", banlistNow)
+	if err != nil || len(got) != 1 || got[0].UserID != 200 {
+		t.Fatalf("json: %+v, %v", got, err)
+	}
+	if _, err := FetchBanlist(context.Background(), srv.Client(), srv.URL+"/missing", banlistNow); err == nil {
+		t.Fatal("expected error on 404")
+	}
+}
@@ -0,0 +1,172 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Счётчики и кулдауны бота по чатам. Webhook-реплики обрабатывают апдейты
+// одного чата вперемешку, поэтому окна rate limit и скачиваний, волна
+// вступлений для детектора рейдов, троттлинг подсказок и темы сообщений
+// живут в Redis, а не в памяти процесса. Каждая операция — одна команда
+// или Lua-скрипт, атомарные для всех реплик. Метки времени в окнах —
+// now вызывающего; TTL ключей окон только убирает мусор.
+//
+// Ключи одного чата несут hash tag {chat_id}: многоключевые скрипты
+// работают и в Redis Cluster.
+
+// windowAddScript — скользящее окно: выкинуть метки не новее cutoff,
+// добавить текущую и вернуть размер окна.
+var windowAddScript = redis.NewScript(`
+redis.call("ZREMRANGEBYSCORE", KEYS[1], "-inf", ARGV[1])
+redis.call("ZADD", KEYS[1], ARGV[2], ARGV[3])
+redis.call("PEXPIRE", KEYS[1], ARGV[4])
+return redis.call("ZCARD", KEYS[1])`)
+
+// windowAllowScript — то же, но метка добавляется, только если в окне
+// меньше limit: 1 — место зарезервировано, 0 — лимит исчерпан.
+var windowAllowScript = redis.NewScript(`
+redis.call("ZREMRANGEBYSCORE", KEYS[1], "-inf", ARGV[1])
+if redis.call("ZCARD", KEYS[1]) >= tonumber(ARGV[5]) then
+	return 0
+end
+redis.call("ZADD", KEYS[1], ARGV[2], ARGV[3])
+redis.call("PEXPIRE", KEYS[1], ARGV[4])
+return 1`)
+
+// cooldownScript занимает все KEYS разом, если ни у одного не идёт
+// кулдаун: ARGV[1] — now (мс), ARGV[1+i] — кулдаун KEYS[i] (мс).
+var cooldownScript = redis.NewScript(`
+local now = tonumber(ARGV[1])
+for i, key in ipairs(KEYS) do
+	local last = redis.call("GET", key)
+	if last and now - tonumber(last) < tonumber(ARGV[i + 1]) then
+		return 0
+	end
+end
+for i, key in ipairs(KEYS) do
+	redis.call("SET", key, ARGV[1], "PX", ARGV[i + 1])
+end
+return 1`)
+
+// joinWaveScript копит вступления чата: KEYS[1] — окно (юзер → время),
+// KEYS[2] — данные вступлений. Повторный вход того же юзера только
+// сдвигает его метку. Набралось threshold — окно отдаётся целиком и
+// очищается, поэтому волну получает ровно один вызывающий.
+var joinWaveScript = redis.NewScript(`
+local stale = redis.call("ZRANGEBYSCORE", KEYS[1], "-inf", ARGV[1])
+if #stale > 0 then
+	redis.call("ZREMRANGEBYSCORE", KEYS[1], "-inf", ARGV[1])
+	redis.call("HDEL", KEYS[2], unpack(stale))
+end
+redis.call("ZADD", KEYS[1], ARGV[2], ARGV[3])
+redis.call("HSET", KEYS[2], ARGV[3], ARGV[4])
+redis.call("PEXPIRE", KEYS[1], ARGV[5])
+redis.call("PEXPIRE", KEYS[2], ARGV[5])
+if redis.call("ZCARD", KEYS[1]) < tonumber(ARGV[6]) then
+	return {}
+end
+local users = redis.call("ZRANGE", KEYS[1], 0, -1)
+local wave = redis.call("HMGET", KEYS[2], unpack(users))
+redis.call("DEL", KEYS[1], KEYS[2])
+return wave`)
+
+type BotChatStateService struct {
+	redis *redis.Client
+}
+
+func NewBotChatStateService(redisClient *redis.Client) *BotChatStateService {
+	return &BotChatStateService{redis: redisClient}
+}
+
+// RecordChatMessage добавляет сообщение messageID в окно юзера и
+// возвращает число сообщений в окне.
+func (s *BotChatStateService) RecordChatMessage(ctx context.Context, chatID, userID int64, messageID int, at time.Time, window time.Duration) (int64, error) {
+	key := fmt.Sprintf("bot:rate:{%d}:%d", chatID, userID)
+	return windowAddScript.Run(ctx, s.redis, []string{key},
+		at.Add(-window).UnixMilli(), at.UnixMilli(), messageID, window.Milliseconds()).Int64()
+}
+
+// AllowVideoDownload резервирует скачивание в чате; false — за window уже
+// было limit скачиваний. jobID различает скачивания в одну миллисекунду.
+func (s *BotChatStateService) AllowVideoDownload(ctx context.Context, chatID int64, jobID string, at time.Time, window time.Duration, limit int) (bool, error) {
+	key := fmt.Sprintf("bot:video:{%d}", chatID)
+	return windowAllowScript.Run(ctx, s.redis, []string{key},
+		at.Add(-window).UnixMilli(), at.UnixMilli(), jobID, window.Milliseconds(), limit).Bool()
+}
+
+// RecordRaidJoin добавляет вступление userID (payload — его данные) и
+// возвращает payload'ы всей волны, если за window набралось threshold
+// разных юзеров; иначе nil.
+func (s *BotChatStateService) RecordRaidJoin(ctx context.Context, chatID, userID int64, payload string, at time.Time, window time.Duration, threshold int) ([]string, error) {
+	keys := []string{fmt.Sprintf("bot:raid:{%d}:joins", chatID), fmt.Sprintf("bot:raid:{%d}:info", chatID)}
+	raw, err := joinWaveScript.Run(ctx, s.redis, keys,
+		at.Add(-window).UnixMilli(), at.UnixMilli(), userID, payload, window.Milliseconds(), threshold).Slice()
+	if err != nil || len(raw) == 0 {
+		return nil, err
+	}
+	wave := make([]string, 0, len(raw))
+	for _, v := range raw {
+		if p, ok := v.(string); ok {
+			wave = append(wave, p)
+		}
+	}
+	return wave, nil
+}
+
+// TakeRestrictionNotice — true не чаще раза в cooldown на юзера, чат и
+// причину (как кулдаун FAQ-триггеров).
+func (s *BotChatStateService) TakeRestrictionNotice(ctx context.Context, chatID, userID int64, reason string, cooldown time.Duration) (bool, error) {
+	key := fmt.Sprintf("bot:restriction-notice:{%d}:%d:%s", chatID, userID, reason)
+	return s.redis.SetNX(ctx, key, 1, cooldown).Result()
+}
+
+func kbChatKey(chatID int64) string {
+	return fmt.Sprintf("bot:kb-suggest:{%d}", chatID)
+}
+
+// KBSuggestChatReady — прошёл ли кулдаун подсказок в чате (до похода в БД).
+func (s *BotChatStateService) KBSuggestChatReady(ctx context.Context, chatID int64, now time.Time, cooldown time.Duration) (bool, error) {
+	last, err := s.redis.Get(ctx, kbChatKey(chatID)).Int64()
+	if errors.Is(err, redis.Nil) {
+		return true, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return now.UnixMilli()-last >= cooldown.Milliseconds(), nil
+}
+
+// TakeKBSuggestion резервирует подсказку статьи entryID в чате: нужны и
+// кулдаун чата, и кулдаун самой статьи. false — рано.
+func (s *BotChatStateService) TakeKBSuggestion(ctx context.Context, chatID, entryID int64, now time.Time, chatCooldown, entryCooldown time.Duration) (bool, error) {
+	keys := []string{kbChatKey(chatID), fmt.Sprintf("bot:kb-suggest:{%d}:%d", chatID, entryID)}
+	return cooldownScript.Run(ctx, s.redis, keys,
+		now.UnixMilli(), chatCooldown.Milliseconds(), entryCooldown.Milliseconds()).Bool()
+}
+
+func topicKey(chatID int64, messageID int) string {
+	return fmt.Sprintf("bot:topic:{%d}:%d", chatID, messageID)
+}
+
+// RememberTopic запоминает тему форума сообщения на ttl.
+func (s *BotChatStateService) RememberTopic(ctx context.Context, chatID int64, messageID, threadID int, ttl time.Duration) error {
+	return s.redis.Set(ctx, topicKey(chatID, messageID), threadID, ttl).Err()
+}
+
+// TopicThread — тема сообщения; 0 — не тема или уже забыта.
+func (s *BotChatStateService) TopicThread(ctx context.Context, chatID int64, messageID int) (int, error) {
+	raw, err := s.redis.Get(ctx, topicKey(chatID, messageID)).Result()
+	if errors.Is(err, redis.Nil) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return strconv.Atoi(raw)
+}
@@ -0,0 +1,164 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"ithozyeva/internal/testutil"
+)
+
+func TestBotChatState_RecordChatMessage(t *testing.T) {
+	svc := NewBotChatStateService(testutil.EnsureTestRedis(t))
+	ctx := context.Background()
+	base := time.Date(2026, 5, 24, 12, 0, 0, 0, time.UTC)
+
+	for i := 0; i < 4; i++ {
+		count, err := svc.RecordChatMessage(ctx, 1, 42, i+1, base.Add(time.Duration(i)*time.Second), time.Minute)
+		if err != nil || count != int64(i+1) {
+			t.Fatalf("message %d: count = %d, %v", i+1, count, err)
+		}
+	}
+	// Другой юзер и другой чат считаются отдельно.
+	if count, _ := svc.RecordChatMessage(ctx, 1, 43, 10, base, time.Minute); count != 1 {
+		t.Fatalf("other user: count = %d", count)
+	}
+	if count, _ := svc.RecordChatMessage(ctx, 2, 42, 11, base, time.Minute); count != 1 {
+		t.Fatalf("other chat: count = %d", count)
+	}
+	// Окно сдвинулось — старые сообщения не считаются.
+	if count, _ := svc.RecordChatMessage(ctx, 1, 42, 12, base.Add(2*time.Minute), time.Minute); count != 1 {
+		t.Fatalf("stale messages counted: count = %d", count)
+	}
+}
+
+func TestBotChatState_AllowVideoDownload(t *testing.T) {
+	svc := NewBotChatStateService(testutil.EnsureTestRedis(t))
+	ctx := context.Background()
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	allow := func(chatID int64, job int, at time.Time) bool {
+		ok, err := svc.AllowVideoDownload(ctx, chatID, fmt.Sprint(job), at, 10*time.Minute, 3)
+		if err != nil {
+			t.Fatalf("AllowVideoDownload: %v", err)
+		}
+		return ok
+	}
+
+	for i := 0; i < 3; i++ {
+		if !allow(-1, i, now.Add(time.Duration(i)*time.Second)) {
+			t.Fatalf("download %d must pass", i)
+		}
+	}
+	if allow(-1, 3, now.Add(time.Minute)) {
+		t.Fatal("limit must block")
+	}
+	if !allow(-2, 4, now.Add(time.Minute)) {
+		t.Fatal("other chat is independent")
+	}
+	// Первое скачивание вышло из окна — освободилось одно место.
+	later := now.Add(10*time.Minute + time.Second/2)
+	if !allow(-1, 5, later) {
+		t.Fatal("window must slide")
+	}
+	if allow(-1, 6, later) {
+		t.Fatal("only one slot frees up")
+	}
+}
+
+func TestBotChatState_RecordRaidJoin(t *testing.T) {
+	svc := NewBotChatStateService(testutil.EnsureTestRedis(t))
+	ctx := context.Background()
+	base := time.Date(2026, 5, 20, 12, 0, 0, 0, time.UTC)
+	join := func(chatID, userID int64, at time.Time) []string {
+		wave, err := svc.RecordRaidJoin(ctx, chatID, userID, fmt.Sprint(userID), at, time.Minute, 3)
+		if err != nil {
+			t.Fatalf("RecordRaidJoin: %v", err)
+		}
+		return wave
+	}
+
+	// Повторный вход того же юзера (leave/join) не накручивает счётчик.
+	for i := 0; i < 5; i++ {
+		if wave := join(1, 42, base.Add(time.Duration(i)*time.Second)); wave != nil {
+			t.Fatal("rejoin counted as wave")
+		}
+	}
+	// Чаты считаются независимо.
+	join(1, 1, base.Add(5*time.Second))
+	if wave := join(2, 2, base); wave != nil {
+		t.Fatal("joins leaked across chats")
+	}
+	wave := join(1, 3, base.Add(6*time.Second))
+	if len(wave) != 3 || wave[0] != "42" || wave[2] != "3" {
+		t.Fatalf("wave = %v, want [42 1 3]", wave)
+	}
+	// Окно очищено: следующее вступление начинает новую волну.
+	if wave := join(1, 4, base.Add(7*time.Second)); wave != nil {
+		t.Fatalf("window not reset after trigger: %v", wave)
+	}
+	// Первое вступление выпало из окна — порог не набран.
+	join(1, 5, base.Add(30*time.Second))
+	if wave := join(1, 6, base.Add(68*time.Second)); wave != nil {
+		t.Fatalf("stale join counted: %v", wave)
+	}
+}
+
+func TestBotChatState_KBSuggestion(t *testing.T) {
+	svc := NewBotChatStateService(testutil.EnsureTestRedis(t))
+	ctx := context.Background()
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	chatCooldown, entryCooldown := 2*time.Minute, 30*time.Minute
+	take := func(chatID, entryID int64, at time.Time) bool {
+		ok, err := svc.TakeKBSuggestion(ctx, chatID, entryID, at, chatCooldown, entryCooldown)
+		if err != nil {
+			t.Fatalf("TakeKBSuggestion: %v", err)
+		}
+		return ok
+	}
+
+	if !take(-1, 10, now) {
+		t.Fatal("first suggestion must pass")
+	}
+	if ready, _ := svc.KBSuggestChatReady(ctx, -1, now.Add(time.Minute), chatCooldown); ready || take(-1, 11, now.Add(time.Minute)) {
+		t.Fatal("chat cooldown must block")
+	}
+	if !take(-2, 10, now.Add(time.Minute)) {
+		t.Fatal("other chat is independent")
+	}
+	// Чат «остыл», но та же статья — ещё рано.
+	if take(-1, 10, now.Add(chatCooldown+time.Second)) {
+		t.Fatal("entry cooldown must block")
+	}
+	if !take(-1, 11, now.Add(chatCooldown+time.Second)) {
+		t.Fatal("another entry after chat cooldown must pass")
+	}
+	if !take(-1, 10, now.Add(entryCooldown+chatCooldown*2)) {
+		t.Fatal("entry cooldown must expire")
+	}
+}
+
+func TestBotChatState_RestrictionNoticeAndTopics(t *testing.T) {
+	svc := NewBotChatStateService(testutil.EnsureTestRedis(t))
+	ctx := context.Background()
+
+	if ok, err := svc.TakeRestrictionNotice(ctx, 1, 42, "links", time.Minute); err != nil || !ok {
+		t.Fatalf("first notice must be sent: %v", err)
+	}
+	if ok, _ := svc.TakeRestrictionNotice(ctx, 1, 42, "links", time.Minute); ok {
+		t.Fatal("notice repeated within cooldown")
+	}
+	if ok, _ := svc.TakeRestrictionNotice(ctx, 1, 42, "rate", time.Minute); !ok {
+		t.Fatal("different reason must be notified separately")
+	}
+
+	if err := svc.RememberTopic(ctx, -100, 10, 3, time.Minute); err != nil {
+		t.Fatalf("RememberTopic: %v", err)
+	}
+	if got, err := svc.TopicThread(ctx, -100, 10); err != nil || got != 3 {
+		t.Fatalf("TopicThread = %d, %v", got, err)
+	}
+	if got, _ := svc.TopicThread(ctx, -100, 11); got != 0 {
+		t.Fatalf("unknown message: thread %d", got)
+	}
+}
@@ -0,0 +1,60 @@
+package service
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Координация реплик бота через Redis: апдейт от Telegram обрабатывает
+// ровно одна реплика (claim по update_id), фоновые задачи — только лидер.
+const (
+	botUpdateClaimTTL = 24 * time.Hour // Telegram ретраит webhook заметно меньше
+	BotLeaderKey      = "bot:leader"
+)
+
+// renewLeaseScript продлевает lease, только если он всё ещё наш.
+var renewLeaseScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("PEXPIRE", KEYS[1], ARGV[2])
+end
+return 0`)
+
+type BotReplicaService struct {
+	redis *redis.Client
+}
+
+func NewBotReplicaService(redisClient *redis.Client) *BotReplicaService {
+	return &BotReplicaService{redis: redisClient}
+}
+
+func botUpdateKey(updateID int) string {
+	return "bot:update:" + strconv.Itoa(updateID)
+}
+
+// ClaimUpdate — true, если апдейт ещё никто не брал. Повторная доставка
+// того же update_id (ретрай Telegram, другая реплика) вернёт false.
+func (s *BotReplicaService) ClaimUpdate(ctx context.Context, updateID int) (bool, error) {
+	return s.redis.SetNX(ctx, botUpdateKey(updateID), 1, botUpdateClaimTTL).Result()
+}
+
+// ReleaseUpdate снимает claim — апдейт не удалось принять в работу, пусть
+// Telegram доставит его снова.
+func (s *BotReplicaService) ReleaseUpdate(ctx context.Context, updateID int) error {
+	return s.redis.Del(ctx, botUpdateKey(updateID)).Err()
+}
+
+// AcquireLease берёт или продлевает lease key на ttl (см. ReplicaLease).
+func (s *BotReplicaService) AcquireLease(ctx context.Context, key, replicaID string, ttl time.Duration) (bool, error) {
+	ok, err := s.redis.SetNX(ctx, key, replicaID, ttl).Result()
+	if err != nil || ok {
+		return ok, err
+	}
+	renewed, err := renewLeaseScript.Run(ctx, s.redis, []string{key}, replicaID, ttl.Milliseconds()).Int()
+	if err != nil {
+		return false, err
+	}
+	return renewed == 1, nil
+}
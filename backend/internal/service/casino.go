@@ -1,14 +1,16 @@
 package service
 
 import (
-	"crypto/rand"
 	"errors"
 	"fmt"
 	"log"
-	"math/big"
+	"math"
+	"time"
 
 	"ithozyeva/internal/models"
 	"ithozyeva/internal/repository"
+
+	"gorm.io/gorm"
 )
 
 var wheelMultipliers = []float64{0, 0, 0, 0.5, 0.5, 0.5, 1, 1, 1.5, 1.5, 2, 3}
@@ -16,12 +18,18 @@ var wheelMultipliers = []float64{0, 0, 0, 0.5, 0.5, 0.5, 1, 1, 1.5, 1.5, 2, 3}
 type CasinoService struct {
 	repo      *repository.CasinoRepository
 	pointRepo *repository.PointsRepository
+	limits    *CasinoLimitsService
+	jackpot   *CasinoJackpotService
+	crashRepo *repository.CrashRepository
 }
 
 func NewCasinoService() *CasinoService {
 	return &CasinoService{
 		repo:      repository.NewCasinoRepository(),
 		pointRepo: repository.NewPointsRepository(),
+		limits:    NewCasinoLimitsService(),
+		jackpot:   NewCasinoJackpotService(),
+		crashRepo: repository.NewCrashRepository(),
 	}
 }
 
@@ -35,14 +43,6 @@ func (s *CasinoService) validateBet(amount int) error {
 	return nil
 }
 
-func cryptoRandInt(max int) (int, error) {
-	n, err := rand.Int(rand.Reader, big.NewInt(int64(max)))
-	if err != nil {
-		return 0, err
-	}
-	return int(n.Int64()), nil
-}
-
 func (s *CasinoService) PlayCoinFlip(memberId int64, req *models.CoinFlipRequest) (*models.CasinoBetResponse, error) {
 	if err := s.validateBet(req.BetAmount); err != nil {
 		return nil, err
@@ -51,192 +51,258 @@ func (s *CasinoService) PlayCoinFlip(memberId int64, req *models.CoinFlipRequest
 		return nil, fmt.Errorf("выберите heads или tails")
 	}
 
-	flip, err := cryptoRandInt(2)
-	if err != nil {
-		return nil, fmt.Errorf("ошибка генерации")
+	return s.placeBet(&models.CasinoBet{
+		MemberId:  memberId,
+		Game:      "coin_flip",
+		BetAmount: req.BetAmount,
+		BetChoice: req.Choice,
+	})
+}
+
+func (s *CasinoService) PlayDiceRoll(memberId int64, req *models.DiceRollRequest) (*models.CasinoBetResponse, error) {
+	if err := s.validateBet(req.BetAmount); err != nil {
+		return nil, err
+	}
+	if req.Target < 2 || req.Target > 98 {
+		return nil, fmt.Errorf("цель должна быть от 2 до 98")
+	}
+	if req.Direction != "over" && req.Direction != "under" {
+		return nil, fmt.Errorf("выберите over или under")
 	}
 
-	outcome := "heads"
-	if flip == 1 {
-		outcome = "tails"
+	return s.placeBet(&models.CasinoBet{
+		MemberId:  memberId,
+		Game:      "dice_roll",
+		BetAmount: req.BetAmount,
+		BetChoice: fmt.Sprintf("%s %d", req.Direction, req.Target),
+	})
+}
+
+func (s *CasinoService) PlayWheel(memberId int64, req *models.WheelRequest) (*models.CasinoBetResponse, error) {
+	if err := s.validateBet(req.BetAmount); err != nil {
+		return nil, err
 	}
 
-	won := outcome == req.Choice
-	var multiplier float64
-	var payout int
-	if won {
-		multiplier = 1.9
-		payout = int(float64(req.BetAmount) * multiplier)
-	}
-	profit := payout - req.BetAmount
+	return s.placeBet(&models.CasinoBet{
+		MemberId:  memberId,
+		Game:      "wheel",
+		BetAmount: req.BetAmount,
+		BetChoice: "spin",
+	})
+}
 
-	bet := &models.CasinoBet{
-		MemberId:   memberId,
-		Game:       "coin_flip",
-		BetAmount:  req.BetAmount,
-		BetChoice:  req.Choice,
-		Result:     outcome,
-		Multiplier: multiplier,
-		Payout:     payout,
-		Profit:     profit,
+// placeBet разыгрывает ставку на активной паре seed'ов участника (заводит
+// первую пару, если её ещё нет) и списывает/начисляет баллы. Лимиты
+// ответственной игры проверяются здесь, так что их не обходит ни одна игра.
+func (s *CasinoService) placeBet(bet *models.CasinoBet) (*models.CasinoBetResponse, error) {
+	if _, err := s.ensureSeed(bet.MemberId); err != nil {
+		log.Printf("casino seed error for member %d: %v", bet.MemberId, err)
+		return nil, fmt.Errorf("ошибка генерации")
 	}
 
-	balance, err := s.repo.PlaceBet(memberId, bet, won)
+	var seedHash, clientSeed string
+	var hit bool
+	var win *models.CasinoJackpotWin
+	turnoverPerWin := s.jackpot.Settings().TurnoverPerWin
+	balance, err := s.repo.PlaceBet(bet.MemberId, bet, func(tx *gorm.DB, seed *models.CasinoSeed, nonce int) (bool, error) {
+		if err := s.limits.CheckTx(tx, bet.MemberId, bet.BetAmount, time.Now()); err != nil {
+			return false, err
+		}
+		seedHash, clientSeed = seed.ServerSeedHash, seed.ClientSeed
+		hit = jackpotHit(seed.ServerSeed, seed.ClientSeed, nonce, bet.BetAmount, turnoverPerWin)
+		return settleBet(bet, seed.ServerSeed, seed.ClientSeed, nonce)
+	}, func(tx *gorm.DB) error {
+		var err error
+		win, err = s.jackpot.FeedTx(tx, bet, hit)
+		return err
+	})
 	if err != nil {
-		if errors.Is(err, repository.ErrInsufficientBalance) {
+		var limitErr *CasinoLimitError
+		if errors.Is(err, repository.ErrInsufficientBalance) || errors.As(err, &limitErr) {
 			return nil, err
 		}
-		log.Printf("casino PlaceBet error for member %d: %v", memberId, err)
+		log.Printf("casino PlaceBet error for member %d: %v", bet.MemberId, err)
 		return nil, fmt.Errorf("ошибка при размещении ставки")
 	}
+	if win != nil {
+		s.jackpot.Announce(win)
+	}
 
 	return &models.CasinoBetResponse{
-		Id:         bet.Id,
-		Game:       bet.Game,
-		BetAmount:  bet.BetAmount,
-		BetChoice:  bet.BetChoice,
-		Result:     bet.Result,
-		Multiplier: bet.Multiplier,
-		Payout:     bet.Payout,
-		Profit:     bet.Profit,
-		Balance:    balance,
-		CreatedAt:  bet.CreatedAt,
+		Id:             bet.Id,
+		Game:           bet.Game,
+		BetAmount:      bet.BetAmount,
+		BetChoice:      bet.BetChoice,
+		Result:         bet.Result,
+		Multiplier:     bet.Multiplier,
+		Payout:         bet.Payout,
+		Profit:         bet.Profit,
+		Balance:        balance,
+		ServerSeedHash: seedHash,
+		ClientSeed:     clientSeed,
+		Nonce:          *bet.Nonce,
+		JackpotWin:     jackpotWinAmount(win),
+		CreatedAt:      bet.CreatedAt,
 	}, nil
 }
 
-func (s *CasinoService) PlayDiceRoll(memberId int64, req *models.DiceRollRequest) (*models.CasinoBetResponse, error) {
-	if err := s.validateBet(req.BetAmount); err != nil {
+func jackpotWinAmount(win *models.CasinoJackpotWin) int {
+	if win == nil {
+		return 0
+	}
+	return win.Amount
+}
+
+// ensureSeed — активная пара участника; первая заводится при первой ставке
+// или первом запросе состояния.
+func (s *CasinoService) ensureSeed(memberId int64) (*models.CasinoSeed, error) {
+	seed, err := s.repo.ActiveSeed(memberId)
+	if err != nil || seed != nil {
+		return seed, err
+	}
+	seed, err = newCasinoSeed(memberId, "")
+	if err != nil {
 		return nil, err
 	}
-	if req.Target < 2 || req.Target > 98 {
-		return nil, fmt.Errorf("цель должна быть от 2 до 98")
+	created, err := s.repo.CreateSeed(seed)
+	if err != nil {
+		return nil, err
 	}
-	if req.Direction != "over" && req.Direction != "under" {
-		return nil, fmt.Errorf("выберите over или under")
+	if !created {
+		return s.repo.ActiveSeed(memberId)
 	}
+	return seed, nil
+}
 
-	roll, err := cryptoRandInt(100)
+// SeedState — хеш текущего серверного seed'а, клиентский seed, следующий
+// nonce и предыдущая раскрытая пара.
+func (s *CasinoService) SeedState(memberId int64) (*models.CasinoSeedState, error) {
+	active, err := s.ensureSeed(memberId)
 	if err != nil {
-		return nil, fmt.Errorf("ошибка генерации")
+		return nil, err
 	}
-
-	// cryptoRandInt(100) возвращает 0..99 (100 равновероятных исходов).
-	// Симметрия "over"/"under" вокруг target требует одинакового счёта
-	// исходов:
-	//   under: roll < target  → исходы {0..target-1}    = target
-	//   over:  roll >= target → исходы {target..99}     = 100-target
-	// Раньше over использовал строгое roll > target — реальная вероятность
-	// была (99-target), но winChance показывала (100-target). На этой
-	// single-off ошибке игроки на «over» получали скрытый дополнительный
-	// house edge, и симметрия с «under» нарушалась.
-	var winChance float64
-	if req.Direction == "over" {
-		winChance = float64(100 - req.Target)
-	} else {
-		winChance = float64(req.Target)
+	prev, err := s.repo.LastRevealedSeed(memberId)
+	if err != nil {
+		return nil, err
 	}
+	return &models.CasinoSeedState{Active: active, Previous: revealedSeed(prev)}, nil
+}
 
-	var won bool
-	if req.Direction == "over" {
-		won = roll >= req.Target
-	} else {
-		won = roll < req.Target
+// RotateSeed раскрывает текущий серверный seed и начинает новую пару с
+// clientSeed (пустой — случайный). После этого все ставки прошлой пары
+// можно проверить.
+func (s *CasinoService) RotateSeed(memberId int64, clientSeed string) (*models.CasinoSeedState, error) {
+	next, err := newCasinoSeed(memberId, clientSeed)
+	if err != nil {
+		return nil, err
 	}
-
-	var multiplier float64
-	var payout int
-	if won {
-		multiplier = 0.97 * (100.0 / winChance)
-		payout = int(float64(req.BetAmount) * multiplier)
+	prev, err := s.repo.RotateSeed(memberId, next)
+	if err != nil {
+		return nil, err
 	}
-	profit := payout - req.BetAmount
-
-	betChoice := fmt.Sprintf("%s %d", req.Direction, req.Target)
-	result := fmt.Sprintf("%d", roll)
+	return &models.CasinoSeedState{Active: next, Previous: revealedSeed(prev)}, nil
+}
 
-	bet := &models.CasinoBet{
-		MemberId:   memberId,
-		Game:       "dice_roll",
-		BetAmount:  req.BetAmount,
-		BetChoice:  betChoice,
-		Result:     result,
-		Multiplier: multiplier,
-		Payout:     payout,
-		Profit:     profit,
+func revealedSeed(seed *models.CasinoSeed) *models.CasinoRevealedSeed {
+	if seed == nil {
+		return nil
 	}
+	return &models.CasinoRevealedSeed{CasinoSeed: *seed, ServerSeed: seed.ServerSeed}
+}
 
-	balance, err := s.repo.PlaceBet(memberId, bet, won)
+// VerifyBet пересчитывает исход любой ставки по её раскрытой паре seed'ов.
+// Ставки crash сверяются с раундом: seed общий на раунд и раскрывается
+// после краша.
+func (s *CasinoService) VerifyBet(betId int64) (*models.CasinoBetVerification, error) {
+	bet, seed, err := s.repo.GetBetWithSeed(betId)
 	if err != nil {
-		if errors.Is(err, repository.ErrInsufficientBalance) {
-			return nil, err
-		}
-		log.Printf("casino PlaceBet error for member %d: %v", memberId, err)
-		return nil, fmt.Errorf("ошибка при размещении ставки")
+		return nil, err
+	}
+	if bet == nil {
+		return nil, ErrCasinoBetNotFound
+	}
+	if bet.Game == "crash" {
+		return s.verifyCrashBet(bet)
+	}
+	if seed == nil || bet.Nonce == nil {
+		return nil, ErrCasinoBetNotFair
+	}
+	if seed.Active {
+		return nil, ErrCasinoSeedNotRevealed
 	}
 
-	return &models.CasinoBetResponse{
-		Id:         bet.Id,
-		Game:       bet.Game,
-		BetAmount:  bet.BetAmount,
-		BetChoice:  bet.BetChoice,
-		Result:     bet.Result,
-		Multiplier: bet.Multiplier,
-		Payout:     bet.Payout,
-		Profit:     bet.Profit,
-		Balance:    balance,
-		CreatedAt:  bet.CreatedAt,
-	}, nil
-}
-
-func (s *CasinoService) PlayWheel(memberId int64, req *models.WheelRequest) (*models.CasinoBetResponse, error) {
-	if err := s.validateBet(req.BetAmount); err != nil {
+	replay := models.CasinoBet{Game: bet.Game, BetAmount: bet.BetAmount, BetChoice: bet.BetChoice}
+	if _, err := settleBet(&replay, seed.ServerSeed, seed.ClientSeed, *bet.Nonce); err != nil {
 		return nil, err
 	}
+	v := &models.CasinoBetVerification{
+		BetId:              bet.Id,
+		Game:               bet.Game,
+		BetAmount:          bet.BetAmount,
+		BetChoice:          bet.BetChoice,
+		ServerSeed:         seed.ServerSeed,
+		ServerSeedHash:     seed.ServerSeedHash,
+		ClientSeed:         seed.ClientSeed,
+		Nonce:              *bet.Nonce,
+		HashMatches:        hashServerSeed(seed.ServerSeed) == seed.ServerSeedHash,
+		RecordedResult:     bet.Result,
+		RecordedMultiplier: bet.Multiplier,
+		RecordedPayout:     bet.Payout,
+		ComputedResult:     replay.Result,
+		ComputedMultiplier: replay.Multiplier,
+		ComputedPayout:     replay.Payout,
+	}
+	v.Valid = v.HashMatches && replay.Result == bet.Result && replay.Payout == bet.Payout
+	return v, nil
+}
 
-	segment, err := cryptoRandInt(len(wheelMultipliers))
+func (s *CasinoService) verifyCrashBet(bet *models.CasinoBet) (*models.CasinoBetVerification, error) {
+	crashBet, err := s.crashRepo.GetBet(bet.Id)
 	if err != nil {
-		return nil, fmt.Errorf("ошибка генерации")
+		return nil, err
 	}
-
-	multiplier := wheelMultipliers[segment]
-	payout := int(float64(req.BetAmount) * multiplier)
-	won := payout > 0
-	profit := payout - req.BetAmount
-
-	result := fmt.Sprintf("x%.1f", multiplier)
-
-	bet := &models.CasinoBet{
-		MemberId:   memberId,
-		Game:       "wheel",
-		BetAmount:  req.BetAmount,
-		BetChoice:  "spin",
-		Result:     result,
-		Multiplier: multiplier,
-		Payout:     payout,
-		Profit:     profit,
+	if crashBet == nil {
+		return nil, ErrCasinoBetNotFair
 	}
-
-	balance, err := s.repo.PlaceBet(memberId, bet, won)
+	round, err := s.crashRepo.GetRound(crashBet.RoundId)
 	if err != nil {
-		if errors.Is(err, repository.ErrInsufficientBalance) {
-			return nil, err
-		}
-		log.Printf("casino PlaceBet error for member %d: %v", memberId, err)
-		return nil, fmt.Errorf("ошибка при размещении ставки")
+		return nil, err
 	}
+	if round == nil {
+		return nil, ErrCasinoBetNotFair
+	}
+	if round.Status != models.CrashRoundCrashed {
+		return nil, ErrCasinoSeedNotRevealed
+	}
+	return crashBetVerification(bet, crashBet, round), nil
+}
 
-	return &models.CasinoBetResponse{
-		Id:         bet.Id,
-		Game:       bet.Game,
-		BetAmount:  bet.BetAmount,
-		BetChoice:  bet.BetChoice,
-		Result:     bet.Result,
-		Multiplier: bet.Multiplier,
-		Payout:     bet.Payout,
-		Profit:     bet.Profit,
-		Balance:    balance,
-		CreatedAt:  bet.CreatedAt,
-	}, nil
+// crashBetVerification пересчитывает ставку по seed'у раунда: точка краша
+// из seed'а, вывод засчитан, только если он строго ниже неё.
+func crashBetVerification(bet *models.CasinoBet, crashBet *models.CrashBet, round *models.CrashRound) *models.CasinoBetVerification {
+	point := crashPointFor(round.ServerSeed)
+	v := &models.CasinoBetVerification{
+		BetId:              bet.Id,
+		Game:               bet.Game,
+		BetAmount:          bet.BetAmount,
+		BetChoice:          bet.BetChoice,
+		ServerSeed:         round.ServerSeed,
+		ServerSeedHash:     round.ServerSeedHash,
+		RoundId:            &round.Id,
+		HashMatches:        hashServerSeed(round.ServerSeed) == round.ServerSeedHash,
+		RecordedResult:     bet.Result,
+		RecordedMultiplier: bet.Multiplier,
+		RecordedPayout:     bet.Payout,
+		ComputedResult:     fmt.Sprintf("crash %.2f", point),
+	}
+	if m := crashBet.CashedOutAt; m != nil && *m < point {
+		v.ComputedResult = fmt.Sprintf("cashout %.2f", *m)
+		v.ComputedMultiplier = *m
+		v.ComputedPayout = bet.BetAmount * int(math.Round(*m*100)) / 100
+	}
+	v.Valid = v.HashMatches && point == round.CrashPoint &&
+		v.ComputedResult == bet.Result && v.ComputedPayout == bet.Payout
+	return v
 }
 
 func (s *CasinoService) GetGlobalFeed(limit int) ([]models.CasinoFeedItem, error) {
@@ -260,8 +326,19 @@ func (s *CasinoService) GetStats(memberId int64) (*models.CasinoStats, error) {
 	return stats, nil
 }
 
+// GetAdminStats — общая статистика, разбивка по играм, джекпот и crash.
 func (s *CasinoService) GetAdminStats() (*models.CasinoAdminStats, error) {
-	return s.repo.GetAdminStats()
+	stats, err := s.repo.GetAdminStats()
+	if err != nil {
+		return nil, err
+	}
+	if stats.Jackpot, err = s.jackpot.AdminStats(); err != nil {
+		return nil, err
+	}
+	if stats.Crash, err = s.crashRepo.AdminStats(); err != nil {
+		return nil, err
+	}
+	return stats, nil
 }
 
 func (s *CasinoService) SearchBets(username *string, game *string, limit, offset int) ([]models.CasinoAdminBet, int64, error) {
@@ -0,0 +1,440 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"sync"
+	"time"
+
+	"ithozyeva/database"
+	"ithozyeva/internal/models"
+	"ithozyeva/internal/repository"
+
+	"gorm.io/gorm"
+)
+
+const (
+	crashBettingPhase = 10 * time.Second
+	crashPausePhase   = 4 * time.Second
+	crashTick         = 100 * time.Millisecond
+	// crashGrowthRate — множитель растёт как e^(rate·t): ×2 за ~11.5 с,
+	// ×10 за ~38 с.
+	crashGrowthRate = 0.06
+	crashMaxPoint   = 1000.0
+	crashMinAuto    = 1.01
+	crashHistoryLen = 20
+)
+
+var (
+	ErrCrashBettingClosed  = errors.New("приём ставок на раунд закрыт — дождитесь следующего")
+	ErrCrashAlreadyBet     = errors.New("вы уже сделали ставку в этом раунде")
+	ErrCrashNoRunningRound = errors.New("раунд ещё не начался или уже завершён")
+	ErrCrashNoBet          = errors.New("у вас нет ставки в этом раунде")
+	ErrCrashCashedOut      = errors.New("ставка уже выведена")
+	ErrCrashTooLate        = errors.New("не успели — раунд уже разбился")
+	ErrCrashInvalidAuto    = errors.New("автовывод — от 1.01 до 1000")
+	ErrCrashRoundNotFound  = errors.New("раунд не найден")
+)
+
+// crashPointFor — точка краша раунда: 0.97 / (1 − f), где f — дробь
+// HMAC-SHA256(server_seed, "crash"). P(краш ≥ x) = 0.97 / x, т.е. 3%
+// преимущества казино при любой стратегии вывода; в 3% раундов краш сразу
+// на 1.00.
+func crashPointFor(serverSeed string) float64 {
+	f := fairFraction(serverSeed, "crash")
+	point := math.Floor(97/(1-f)) / 100
+	return math.Max(1, math.Min(point, crashMaxPoint))
+}
+
+// crashMultiplierAt — множитель через elapsed после старта, вниз до сотых.
+func crashMultiplierAt(elapsed time.Duration) float64 {
+	if elapsed <= 0 {
+		return 1
+	}
+	return math.Floor(math.Exp(crashGrowthRate*elapsed.Seconds())*100) / 100
+}
+
+// crashDuration — сколько длится полёт до точки краша.
+func crashDuration(point float64) time.Duration {
+	return time.Duration(math.Log(point) / crashGrowthRate * float64(time.Second))
+}
+
+func crashRoundView(round *models.CrashRound) *models.CrashRoundView {
+	view := &models.CrashRoundView{
+		Id:             round.Id,
+		Status:         round.Status,
+		ServerSeedHash: round.ServerSeedHash,
+		BettingEndsAt:  round.BettingEndsAt,
+		StartedAt:      round.StartedAt,
+		CrashedAt:      round.CrashedAt,
+	}
+	if round.Status == models.CrashRoundCrashed {
+		view.ServerSeed = round.ServerSeed
+		view.CrashPoint = round.CrashPoint
+	}
+	return view
+}
+
+// CrashService — мультиплеерный crash: один общий раунд для всех, фазы
+// которого крутит Run. Ставки и выводы идут через БД, поэтому вывод,
+// пришедший после точки краша, отклоняется по времени, даже если цикл
+// раунда ещё не успел отметить краш.
+type CrashService struct {
+	repo      *repository.CrashRepository
+	pointRepo *repository.PointsRepository
+	casino    *CasinoService
+	limits    *CasinoLimitsService
+	jackpot   *CasinoJackpotService
+	now       func() time.Time
+	lease     *ReplicaLease // держатель раундов; задаётся в Run
+}
+
+var (
+	crashService     *CrashService
+	crashServiceOnce sync.Once
+)
+
+// GetCrashService возвращает общий экземпляр (цикл раундов — один на
+// процесс).
+func GetCrashService() *CrashService {
+	crashServiceOnce.Do(func() {
+		crashService = &CrashService{
+			repo:      repository.NewCrashRepository(),
+			pointRepo: repository.NewPointsRepository(),
+			casino:    NewCasinoService(),
+			limits:    NewCasinoLimitsService(),
+			jackpot:   NewCasinoJackpotService(),
+			now:       time.Now,
+		}
+	})
+	return crashService
+}
+
+func (s *CrashService) broadcast(event string, round *models.CrashRound, bet *models.CrashRoundBet) {
+	ev := models.CrashEvent{Event: event, Bet: bet}
+	if round != nil {
+		ev.Round = crashRoundView(round)
+	}
+	GetSSEHub().Broadcast(SSEEvent{Type: "crash", Data: ev})
+}
+
+// errCrashLeaseLost — реплика потеряла lease посреди раунда; раунд
+// доиграет новый держатель через OpenRound.
+var errCrashLeaseLost = errors.New("crash: leader lease lost")
+
+// Run крутит раунды бесконечно: приём ставок → полёт → краш → пауза.
+// Раунды ведёт только держатель lease, остальные API-реплики ждут.
+// Незавершённый раунд после рестарта или смены держателя доигрывается.
+func (s *CrashService) Run(lease *ReplicaLease) {
+	s.lease = lease
+	for {
+		if !lease.Held() {
+			time.Sleep(ReplicaLeaseRenew)
+			continue
+		}
+		round, err := s.nextRound()
+		if err != nil {
+			log.Printf("crash: next round error: %v", err)
+			time.Sleep(5 * time.Second)
+			continue
+		}
+		if err := s.playRound(round); errors.Is(err, errCrashLeaseLost) {
+			log.Printf("crash: round %d handed over: lease lost", round.Id)
+			continue
+		} else if err != nil {
+			log.Printf("crash: round %d error: %v", round.Id, err)
+			time.Sleep(5 * time.Second)
+			continue
+		}
+		time.Sleep(crashPausePhase)
+	}
+}
+
+// nextRound — незавершённый раунд или новый с приёмом ставок.
+func (s *CrashService) nextRound() (*models.CrashRound, error) {
+	round, err := s.repo.OpenRound()
+	if err != nil || round != nil {
+		return round, err
+	}
+	round, err = s.newRound()
+	if err != nil {
+		return nil, err
+	}
+	s.broadcast("betting", round, nil)
+	return round, nil
+}
+
+func (s *CrashService) newRound() (*models.CrashRound, error) {
+	seed, err := randomHex(32)
+	if err != nil {
+		return nil, err
+	}
+	round := &models.CrashRound{
+		ServerSeed:     seed,
+		ServerSeedHash: hashServerSeed(seed),
+		CrashPoint:     crashPointFor(seed),
+		Status:         models.CrashRoundBetting,
+		BettingEndsAt:  s.now().Add(crashBettingPhase),
+	}
+	return round, s.repo.CreateRound(round)
+}
+
+func (s *CrashService) playRound(round *models.CrashRound) error {
+	if round.Status == models.CrashRoundBetting {
+		time.Sleep(time.Until(round.BettingEndsAt))
+		if !s.lease.Held() {
+			return errCrashLeaseLost
+		}
+		startedAt := s.now()
+		round.StartedAt = &startedAt
+		if err := s.repo.StartRound(round); err != nil {
+			return err
+		}
+		round.Status = models.CrashRoundRunning
+		s.broadcast("running", round, nil)
+	}
+
+	crashAt := round.StartedAt.Add(crashDuration(round.CrashPoint))
+	ticker := time.NewTicker(crashTick)
+	defer ticker.Stop()
+	for now := range ticker.C {
+		if !now.Before(crashAt) {
+			break
+		}
+		if !s.lease.Held() {
+			return errCrashLeaseLost
+		}
+		if err := s.settleAutoCashOuts(round, crashMultiplierAt(now.Sub(*round.StartedAt))); err != nil {
+			log.Printf("crash: auto cash-out error (round %d): %v", round.Id, err)
+		}
+	}
+	return s.finishRound(round)
+}
+
+// settleAutoCashOuts выводит ставки, чей автовывод достигнут к upTo (и
+// строго ниже точки краша).
+func (s *CrashService) settleAutoCashOuts(round *models.CrashRound, upTo float64) error {
+	if upTo >= round.CrashPoint {
+		upTo = round.CrashPoint - 0.01
+	}
+	var settled []int64
+	err := database.DB.Transaction(func(tx *gorm.DB) error {
+		bets, err := s.repo.DueAutoCashOutsTx(tx, round.Id, upTo)
+		if err != nil {
+			return err
+		}
+		for i := range bets {
+			if _, err := s.repo.CashOutTx(tx, &bets[i], *bets[i].AutoCashOut); err != nil {
+				return err
+			}
+			settled = append(settled, bets[i].BetId)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	for _, betId := range settled {
+		s.announceCashOut(betId)
+	}
+	return nil
+}
+
+func (s *CrashService) announceCashOut(betId int64) {
+	bet, err := s.repo.RoundBet(betId)
+	if err != nil || bet == nil {
+		return
+	}
+	s.broadcast("cashout", nil, bet)
+	GetSSEHub().Publish(bet.MemberId, SSEEvent{Type: "points"})
+}
+
+func (s *CrashService) finishRound(round *models.CrashRound) error {
+	if err := s.settleAutoCashOuts(round, round.CrashPoint); err != nil {
+		return err
+	}
+	crashedAt := s.now()
+	round.CrashedAt = &crashedAt
+	err := database.DB.Transaction(func(tx *gorm.DB) error {
+		return s.repo.FinishRoundTx(tx, round)
+	})
+	if err != nil {
+		return err
+	}
+	round.Status = models.CrashRoundCrashed
+	s.broadcast("crashed", round, nil)
+	return nil
+}
+
+// PlaceBet — ставка в текущий раунд, пока идёт приём ставок. autoCashOut
+// (необязательный) выводит ставку сам, как только множитель его достигнет.
+func (s *CrashService) PlaceBet(memberId int64, req *models.CrashBetRequest) (*models.CrashBetResponse, error) {
+	if err := s.casino.validateBet(req.BetAmount); err != nil {
+		return nil, err
+	}
+	choice := "manual"
+	if req.AutoCashOut != nil {
+		auto := math.Floor(*req.AutoCashOut*100) / 100
+		if auto < crashMinAuto || auto > crashMaxPoint {
+			return nil, ErrCrashInvalidAuto
+		}
+		req.AutoCashOut = &auto
+		choice = fmt.Sprintf("auto %.2f", auto)
+	}
+
+	bet := &models.CasinoBet{MemberId: memberId, Game: "crash", BetAmount: req.BetAmount, BetChoice: choice, Result: "pending"}
+	var round *models.CrashRound
+	var win *models.CasinoJackpotWin
+	var balance int
+	err := database.DB.Transaction(func(tx *gorm.DB) error {
+		// Тот же advisory-lock, что и у остальных игр: баланс и дневные
+		// лимиты проверяются без гонок с параллельными ставками.
+		if err := tx.Exec(`SELECT pg_advisory_xact_lock(?)`, memberId).Error; err != nil {
+			return err
+		}
+		var err error
+		if round, err = s.repo.BettingRoundTx(tx); err != nil {
+			return err
+		}
+		now := s.now()
+		if round == nil || !now.Before(round.BettingEndsAt) {
+			return ErrCrashBettingClosed
+		}
+		exists, err := s.repo.HasBetTx(tx, round.Id, memberId)
+		if err != nil {
+			return err
+		}
+		if exists {
+			return ErrCrashAlreadyBet
+		}
+		if err := s.limits.CheckTx(tx, memberId, req.BetAmount, now); err != nil {
+			return err
+		}
+		if balance, err = s.pointRepo.GetBalanceTx(tx, memberId); err != nil {
+			return err
+		}
+		if balance < req.BetAmount {
+			return repository.ErrInsufficientBalance
+		}
+
+		crashBet := &models.CrashBet{RoundId: round.Id, MemberId: memberId, AutoCashOut: req.AutoCashOut}
+		if err := s.repo.PlaceBetTx(tx, bet, crashBet); err != nil {
+			return err
+		}
+		hit := jackpotHit(round.ServerSeed, fmt.Sprintf("crash-%d", memberId), int(round.Id), req.BetAmount, s.jackpot.Settings().TurnoverPerWin)
+		if win, err = s.jackpot.FeedTx(tx, bet, hit); err != nil {
+			return err
+		}
+		balance, err = s.pointRepo.GetBalanceTx(tx, memberId)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if view, err := s.repo.RoundBet(bet.Id); err == nil && view != nil {
+		s.broadcast("bet", nil, view)
+	}
+	if win != nil {
+		s.jackpot.Announce(win)
+	}
+	return &models.CrashBetResponse{
+		BetId:       bet.Id,
+		RoundId:     round.Id,
+		BetAmount:   bet.BetAmount,
+		AutoCashOut: req.AutoCashOut,
+		Balance:     balance,
+		JackpotWin:  jackpotWinAmount(win),
+	}, nil
+}
+
+// CashOut выводит ставку участника на текущем множителе. Вывод принимается,
+// только если по времени раунд ещё не дошёл до точки краша.
+func (s *CrashService) CashOut(memberId int64) (*models.CrashCashOutResponse, error) {
+	resp := &models.CrashCashOutResponse{}
+	err := database.DB.Transaction(func(tx *gorm.DB) error {
+		round, err := s.repo.RunningRoundTx(tx)
+		if err != nil {
+			return err
+		}
+		if round == nil || round.StartedAt == nil {
+			return ErrCrashNoRunningRound
+		}
+		crashBet, err := s.repo.MemberBetForUpdateTx(tx, round.Id, memberId)
+		if err != nil {
+			return err
+		}
+		if crashBet == nil {
+			return ErrCrashNoBet
+		}
+		if crashBet.CashedOutAt != nil {
+			return ErrCrashCashedOut
+		}
+
+		multiplier := crashMultiplierAt(s.now().Sub(*round.StartedAt))
+		// Автовывод уже наступил, но цикл раунда не успел его провести —
+		// платим по нему.
+		if crashBet.AutoCashOut != nil && *crashBet.AutoCashOut <= multiplier {
+			multiplier = *crashBet.AutoCashOut
+		}
+		if multiplier >= round.CrashPoint {
+			return ErrCrashTooLate
+		}
+		if resp.Payout, err = s.repo.CashOutTx(tx, crashBet, multiplier); err != nil {
+			return err
+		}
+		resp.BetId, resp.Multiplier = crashBet.BetId, multiplier
+		resp.Balance, err = s.pointRepo.GetBalanceTx(tx, memberId)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.announceCashOut(resp.BetId)
+	return resp, nil
+}
+
+// State — текущий раунд (без точки краша), его ставки, последние точки
+// краша и пул джекпота.
+func (s *CrashService) State() (*models.CrashState, error) {
+	state := &models.CrashState{Bets: make([]models.CrashRoundBet, 0), ServerTime: s.now()}
+	round, err := s.repo.OpenRound()
+	if err != nil {
+		return nil, err
+	}
+	if round != nil {
+		state.Round = crashRoundView(round)
+		if state.Bets, err = s.repo.RoundBets(round.Id); err != nil {
+			return nil, err
+		}
+	}
+	if state.History, err = s.repo.RecentCrashPoints(crashHistoryLen); err != nil {
+		return nil, err
+	}
+	if state.Jackpot, err = s.jackpot.Amount(); err != nil {
+		return nil, err
+	}
+	return state, nil
+}
+
+func (s *CrashService) Rounds(limit, offset int) ([]models.CrashRoundSummary, int64, error) {
+	return s.repo.Rounds(limit, offset)
+}
+
+// Round — раунд со ставками; seed и точка краша — только у завершённого.
+func (s *CrashService) Round(id int64) (*models.CrashRoundDetail, error) {
+	round, err := s.repo.GetRound(id)
+	if err != nil {
+		return nil, err
+	}
+	if round == nil {
+		return nil, ErrCrashRoundNotFound
+	}
+	bets, err := s.repo.RoundBets(id)
+	if err != nil {
+		return nil, err
+	}
+	return &models.CrashRoundDetail{Round: *crashRoundView(round), Bets: bets}, nil
+}
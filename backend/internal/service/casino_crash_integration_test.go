@@ -0,0 +1,142 @@
+package service
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"ithozyeva/internal/models"
+	"ithozyeva/internal/repository"
+	"ithozyeva/internal/testutil"
+)
+
+// TestCrashService_BetCashOutAndCrash — ставки принимаются только до старта,
+// ручной вывод платит по текущему множителю, автовывод ниже точки краша
+// проводится при краше, остальные ставки проигрывают.
+func TestCrashService_BetCashOutAndCrash(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	testutil.TruncateAll(t, db, "casino_crash_bets", "casino_crash_rounds", "casino_jackpot_wins", "casino_bets", "point_transactions", "members")
+
+	manual := seedMember(t, db, 7601)
+	auto := seedMember(t, db, 7602)
+	loser := seedMember(t, db, 7603)
+	for _, m := range []*models.Member{manual, auto, loser} {
+		creditPoints(t, db, m.Id, 1000)
+	}
+
+	now := time.Now()
+	svc := &CrashService{
+		repo:      repository.NewCrashRepository(),
+		pointRepo: repository.NewPointsRepository(),
+		casino:    NewCasinoService(),
+		limits:    NewCasinoLimitsService(),
+		jackpot:   NewCasinoJackpotService(),
+		now:       func() time.Time { return now },
+	}
+	round, err := svc.newRound()
+	if err != nil {
+		t.Fatalf("newRound: %v", err)
+	}
+	round.CrashPoint = 3
+	if err := db.Model(round).Update("crash_point", 3).Error; err != nil {
+		t.Fatalf("crash_point: %v", err)
+	}
+
+	autoAt := 1.5
+	if _, err := svc.PlaceBet(manual.Id, &models.CrashBetRequest{BetAmount: 100}); err != nil {
+		t.Fatalf("PlaceBet(manual): %v", err)
+	}
+	if _, err := svc.PlaceBet(auto.Id, &models.CrashBetRequest{BetAmount: 100, AutoCashOut: &autoAt}); err != nil {
+		t.Fatalf("PlaceBet(auto): %v", err)
+	}
+	if _, err := svc.PlaceBet(loser.Id, &models.CrashBetRequest{BetAmount: 100}); err != nil {
+		t.Fatalf("PlaceBet(loser): %v", err)
+	}
+	if _, err := svc.PlaceBet(manual.Id, &models.CrashBetRequest{BetAmount: 100}); !errors.Is(err, ErrCrashAlreadyBet) {
+		t.Fatalf("повторная ставка: got %v", err)
+	}
+
+	// Старт раунда: дальше ставки не принимаются.
+	startedAt := now
+	round.StartedAt = &startedAt
+	if err := svc.repo.StartRound(round); err != nil {
+		t.Fatalf("StartRound: %v", err)
+	}
+	if _, err := svc.PlaceBet(manual.Id, &models.CrashBetRequest{BetAmount: 100}); !errors.Is(err, ErrCrashBettingClosed) {
+		t.Fatalf("ставка после старта: got %v", err)
+	}
+
+	// Через crashDuration(1.2) множитель — 1.20: выводим вручную.
+	now = startedAt.Add(crashDuration(1.2) + time.Millisecond)
+	out, err := svc.CashOut(manual.Id)
+	if err != nil {
+		t.Fatalf("CashOut: %v", err)
+	}
+	if out.Multiplier != 1.2 || out.Payout != 120 || out.Balance != 1020 {
+		t.Fatalf("CashOut: %+v", out)
+	}
+	if _, err := svc.CashOut(manual.Id); !errors.Is(err, ErrCrashCashedOut) {
+		t.Fatalf("повторный вывод: got %v", err)
+	}
+
+	// После точки краша вывод отклоняется, даже если краш ещё не отмечен.
+	now = startedAt.Add(crashDuration(3) + time.Millisecond)
+	if _, err := svc.CashOut(loser.Id); !errors.Is(err, ErrCrashTooLate) {
+		t.Fatalf("вывод после краша: got %v", err)
+	}
+
+	round.Status = models.CrashRoundRunning
+	if err := svc.finishRound(round); err != nil {
+		t.Fatalf("finishRound: %v", err)
+	}
+	if got := balanceOf(t, auto.Id); got != 1050 {
+		t.Errorf("автовывод 1.5: баланс %d, want 1050", got)
+	}
+	if got := balanceOf(t, loser.Id); got != 900 {
+		t.Errorf("проигравший: баланс %d, want 900", got)
+	}
+
+	detail, err := svc.Round(round.Id)
+	if err != nil {
+		t.Fatalf("Round: %v", err)
+	}
+	if detail.Round.CrashPoint != 3 || detail.Round.ServerSeed == "" || len(detail.Bets) != 3 {
+		t.Fatalf("раунд после краша: %+v", detail)
+	}
+}
+
+// TestCasinoJackpot_WinResetsPool — выигрыш забирает пул вместе со взносом
+// ставки, пул начинается заново с seedAmount.
+func TestCasinoJackpot_WinResetsPool(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	testutil.TruncateAll(t, db, "casino_jackpot_wins", "casino_bets", "casino_seeds", "point_transactions", "members")
+
+	settings := NewAppSettingsService()
+	if err := settings.SetJSON(casinoJackpotSettingsKey, models.CasinoJackpotSettings{
+		ContributionPercent: 1, TurnoverPerWin: 1, SeedAmount: 100,
+	}); err != nil {
+		t.Fatalf("SetJSON: %v", err)
+	}
+	t.Cleanup(func() { _ = settings.SetJSON(casinoJackpotSettingsKey, defaultCasinoJackpotSettings) })
+	if err := db.Exec(`UPDATE casino_jackpot SET pool_hundredths = 50000`).Error; err != nil {
+		t.Fatalf("pool: %v", err)
+	}
+
+	member := seedMember(t, db, 7604)
+	creditPoints(t, db, member.Id, 1000)
+
+	res, err := NewCasinoService().PlayWheel(member.Id, &models.WheelRequest{BetAmount: 100})
+	if err != nil {
+		t.Fatalf("PlayWheel: %v", err)
+	}
+	if res.JackpotWin != 501 {
+		t.Fatalf("JackpotWin = %d, want 501", res.JackpotWin)
+	}
+	if res.Balance != 1000-100+res.Payout+501 {
+		t.Fatalf("баланс %d не включает джекпот", res.Balance)
+	}
+	amount, err := NewCasinoJackpotService().Amount()
+	if err != nil || amount != 100 {
+		t.Fatalf("пул после выигрыша: %d, %v", amount, err)
+	}
+}
@@ -0,0 +1,110 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"ithozyeva/internal/models"
+)
+
+// TestCrashPointFor_KnownVectors — 0.97 / (1 − f) по HMAC-SHA256(seed,
+// "crash"), посчитано внешним калькулятором.
+func TestCrashPointFor_KnownVectors(t *testing.T) {
+	tests := []struct {
+		seed string
+		want float64
+	}{
+		{"server", 2.26},
+		{"a", 1.10},
+		{"b", 1.40},
+		{"c", 1.78},
+	}
+	for _, tt := range tests {
+		if got := crashPointFor(tt.seed); got != tt.want {
+			t.Errorf("crashPointFor(%q) = %v, want %v", tt.seed, got, tt.want)
+		}
+	}
+}
+
+// TestCrashMultiplier_ReachesPointAtDuration — за crashDuration(p) множитель
+// доходит до p, но не раньше.
+func TestCrashMultiplier_ReachesPointAtDuration(t *testing.T) {
+	if got := crashMultiplierAt(0); got != 1 {
+		t.Fatalf("множитель на старте = %v, want 1", got)
+	}
+	for _, point := range []float64{1.01, 1.5, 2, 10, 137.42, crashMaxPoint} {
+		d := crashDuration(point)
+		if before := crashMultiplierAt(d - 50*time.Millisecond); before >= point {
+			t.Errorf("точка %v: за 50 мс до краша множитель уже %v", point, before)
+		}
+		if after := crashMultiplierAt(d + time.Millisecond); after < point {
+			t.Errorf("точка %v: к моменту краша множитель только %v", point, after)
+		}
+	}
+}
+
+func TestJackpotHit_ScalesWithBet(t *testing.T) {
+	if jackpotHit("server", "client", 0, 0, 100) {
+		t.Fatal("нулевая ставка не может выиграть джекпот")
+	}
+	if !jackpotHit("server", "client", 0, 100, 100) {
+		t.Fatal("ставка не меньше turnoverPerWin выигрывает всегда")
+	}
+	if jackpotHit("server", "client", 0, 100, 0) {
+		t.Fatal("джекпот без turnoverPerWin выключен")
+	}
+	hits := 0
+	for nonce := 0; nonce < 10000; nonce++ {
+		if jackpotHit("server", "client", nonce, 10, 1000) {
+			hits++
+		}
+	}
+	// Ожидаем ~1% (10 / 1000).
+	if hits < 60 || hits > 140 {
+		t.Errorf("джекпот выпал %d раз из 10000, ожидалось около 100", hits)
+	}
+}
+
+// TestCrashBetVerification — ставка crash сверяется с раскрытым seed'ом
+// раунда: вывод ниже точки краша засчитан, невыведенная ставка проиграна.
+func TestCrashBetVerification(t *testing.T) {
+	round := &models.CrashRound{
+		Id:             7,
+		ServerSeed:     "server",
+		ServerSeedHash: hashServerSeed("server"),
+		CrashPoint:     2.26,
+		Status:         models.CrashRoundCrashed,
+	}
+	cashedOut := 1.15
+	tests := []struct {
+		name     string
+		bet      models.CasinoBet
+		crashBet models.CrashBet
+		valid    bool
+	}{
+		{
+			name:     "вывод",
+			bet:      models.CasinoBet{Id: 1, Game: "crash", BetAmount: 100, Result: "cashout 1.15", Multiplier: 1.15, Payout: 115},
+			crashBet: models.CrashBet{BetId: 1, RoundId: 7, CashedOutAt: &cashedOut},
+			valid:    true,
+		},
+		{
+			name:     "краш",
+			bet:      models.CasinoBet{Id: 2, Game: "crash", BetAmount: 100, Result: "crash 2.26"},
+			crashBet: models.CrashBet{BetId: 2, RoundId: 7},
+			valid:    true,
+		},
+		{
+			name:     "завышенная выплата",
+			bet:      models.CasinoBet{Id: 3, Game: "crash", BetAmount: 100, Result: "cashout 1.15", Multiplier: 1.15, Payout: 200},
+			crashBet: models.CrashBet{BetId: 3, RoundId: 7, CashedOutAt: &cashedOut},
+			valid:    false,
+		},
+	}
+	for _, tt := range tests {
+		v := crashBetVerification(&tt.bet, &tt.crashBet, round)
+		if v.Valid != tt.valid || !v.HashMatches || v.RoundId == nil || *v.RoundId != 7 {
+			t.Errorf("%s: verification = %+v", tt.name, v)
+		}
+	}
+}
@@ -0,0 +1,154 @@
+package service
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"ithozyeva/internal/i18n"
+	"ithozyeva/internal/models"
+)
+
+var (
+	ErrCasinoBetNotFound     = errors.New("ставка не найдена")
+	ErrCasinoBetNotFair      = errors.New("ставка сделана до provably fair — проверить её нельзя")
+	ErrCasinoSeedNotRevealed = errors.New("серверный seed ещё активен — смените пару, чтобы проверить ставку")
+	ErrInvalidClientSeed     = errors.New("клиентский seed: 1–64 символа из латиницы, цифр, «-» и «_»")
+)
+
+var clientSeedPattern = regexp.MustCompile(`^[A-Za-z0-9_-]{1,64}$`)
+
+// randomHex — n случайных байт в hex.
+func randomHex(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// hashServerSeed — sha256 серверного seed'а, который игрок видит до игры.
+func hashServerSeed(serverSeed string) string {
+	sum := sha256.Sum256([]byte(serverSeed))
+	return hex.EncodeToString(sum[:])
+}
+
+// fairRoll — равномерное число из [0, n) для ставки: первые 4 байта
+// HMAC-SHA256(server_seed, "client_seed:nonce") как дробь [0, 1),
+// умноженная на n. Схема повторяема любым HMAC-калькулятором.
+func fairRoll(serverSeed, clientSeed string, nonce, n int) int {
+	return int(fairFraction(serverSeed, fmt.Sprintf("%s:%d", clientSeed, nonce)) * float64(n))
+}
+
+// fairFraction — первые 4 байта HMAC-SHA256(server_seed, message) как дробь
+// [0, 1).
+func fairFraction(serverSeed, message string) float64 {
+	mac := hmac.New(sha256.New, []byte(serverSeed))
+	mac.Write([]byte(message))
+	sum := mac.Sum(nil)
+	return float64(binary.BigEndian.Uint32(sum[:4])) / (1 << 32)
+}
+
+// newCasinoSeed — новая пара: случайный серверный seed (32 байта) и
+// клиентский — заданный игроком или случайный.
+func newCasinoSeed(memberId int64, clientSeed string) (*models.CasinoSeed, error) {
+	clientSeed = strings.TrimSpace(clientSeed)
+	if clientSeed == "" {
+		var err error
+		if clientSeed, err = randomHex(8); err != nil {
+			return nil, err
+		}
+	} else if !clientSeedPattern.MatchString(clientSeed) {
+		return nil, ErrInvalidClientSeed
+	}
+	serverSeed, err := randomHex(32)
+	if err != nil {
+		return nil, err
+	}
+	return &models.CasinoSeed{
+		MemberId:       memberId,
+		ServerSeed:     serverSeed,
+		ServerSeedHash: hashServerSeed(serverSeed),
+		ClientSeed:     clientSeed,
+		Active:         true,
+	}, nil
+}
+
+// settleBet считает исход ставки по Game/BetChoice/BetAmount и seed'ам и
+// заполняет Result, Multiplier, Payout, Profit. Та же функция
+// пересчитывает исход при проверке. Возвращает, выиграна ли ставка.
+func settleBet(bet *models.CasinoBet, serverSeed, clientSeed string, nonce int) (bool, error) {
+	var won bool
+	switch bet.Game {
+	case "coin_flip":
+		bet.Result = "heads"
+		if fairRoll(serverSeed, clientSeed, nonce, 2) == 1 {
+			bet.Result = "tails"
+		}
+		won = bet.Result == bet.BetChoice
+		bet.Multiplier = 0
+		if won {
+			bet.Multiplier = 1.9
+		}
+	case "dice_roll":
+		direction, target, err := parseDiceChoice(bet.BetChoice)
+		if err != nil {
+			return false, err
+		}
+		// fairRoll(…, 100) возвращает 0..99 (100 равновероятных исходов).
+		// Симметрия "over"/"under" вокруг target требует одинакового счёта
+		// исходов:
+		//   under: roll < target  → исходы {0..target-1}    = target
+		//   over:  roll >= target → исходы {target..99}     = 100-target
+		// Раньше over использовал строгое roll > target — реальная вероятность
+		// была (99-target), но winChance показывала (100-target). На этой
+		// single-off ошибке игроки на «over» получали скрытый дополнительный
+		// house edge, и симметрия с «under» нарушалась.
+		roll := fairRoll(serverSeed, clientSeed, nonce, 100)
+		var winChance float64
+		if direction == "over" {
+			winChance = float64(100 - target)
+			won = roll >= target
+		} else {
+			winChance = float64(target)
+			won = roll < target
+		}
+		bet.Result = strconv.Itoa(roll)
+		bet.Multiplier = 0
+		if won {
+			bet.Multiplier = 0.97 * (100.0 / winChance)
+		}
+	case "wheel":
+		segment := fairRoll(serverSeed, clientSeed, nonce, len(wheelMultipliers))
+		bet.Multiplier = wheelMultipliers[segment]
+		bet.Result = fmt.Sprintf("x%.1f", bet.Multiplier)
+	default:
+		return false, i18n.Errorf("неизвестная игра %s", bet.Game)
+	}
+	bet.Payout = int(float64(bet.BetAmount) * bet.Multiplier)
+	if bet.Game == "wheel" {
+		won = bet.Payout > 0
+	}
+	bet.Profit = bet.Payout - bet.BetAmount
+	return won, nil
+}
+
+// parseDiceChoice разбирает BetChoice кости вида "over 50".
+func parseDiceChoice(choice string) (string, int, error) {
+	parts := strings.Fields(choice)
+	if len(parts) != 2 || (parts[0] != "over" && parts[0] != "under") {
+		return "", 0, i18n.Errorf("неверная ставка на кости: %s", choice)
+	}
+	target, err := strconv.Atoi(parts[1])
+	if err != nil || target < 2 || target > 98 {
+		return "", 0, i18n.Errorf("неверная ставка на кости: %s", choice)
+	}
+	return parts[0], target, nil
+}
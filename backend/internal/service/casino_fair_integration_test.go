@@ -0,0 +1,60 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"ithozyeva/internal/models"
+	"ithozyeva/internal/testutil"
+)
+
+// TestCasinoService_VerifyAfterRotate — ставки идут по nonce'ам активной
+// пары, проверка возможна только после ротации и сходится с записанным.
+func TestCasinoService_VerifyAfterRotate(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	testutil.TruncateAll(t, db, "casino_bets", "casino_seeds", "point_transactions", "members")
+
+	member := seedMember(t, db, 7401)
+	creditPoints(t, db, member.Id, 1000)
+	svc := NewCasinoService()
+
+	state, err := svc.SeedState(member.Id)
+	if err != nil {
+		t.Fatalf("SeedState: %v", err)
+	}
+	committed := state.Active.ServerSeedHash
+
+	first, err := svc.PlayDiceRoll(member.Id, &models.DiceRollRequest{BetAmount: 10, Target: 50, Direction: "over"})
+	if err != nil {
+		t.Fatalf("PlayDiceRoll: %v", err)
+	}
+	second, err := svc.PlayCoinFlip(member.Id, &models.CoinFlipRequest{BetAmount: 10, Choice: "heads"})
+	if err != nil {
+		t.Fatalf("PlayCoinFlip: %v", err)
+	}
+	if first.Nonce != 0 || second.Nonce != 1 || first.ServerSeedHash != committed {
+		t.Fatalf("nonce/hash: %d, %d, %s (want %s)", first.Nonce, second.Nonce, first.ServerSeedHash, committed)
+	}
+
+	if _, err := svc.VerifyBet(first.Id); !errors.Is(err, ErrCasinoSeedNotRevealed) {
+		t.Fatalf("до ротации: got %v", err)
+	}
+	rotated, err := svc.RotateSeed(member.Id, "my-seed")
+	if err != nil {
+		t.Fatalf("RotateSeed: %v", err)
+	}
+	if rotated.Previous == nil || rotated.Previous.ServerSeedHash != committed || rotated.Previous.Nonce != 2 ||
+		rotated.Active.ClientSeed != "my-seed" || rotated.Active.Nonce != 0 {
+		t.Fatalf("unexpected rotation: %+v / %+v", rotated.Active, rotated.Previous)
+	}
+
+	for _, id := range []int64{first.Id, second.Id} {
+		v, err := svc.VerifyBet(id)
+		if err != nil {
+			t.Fatalf("VerifyBet(%d): %v", id, err)
+		}
+		if !v.Valid || !v.HashMatches {
+			t.Errorf("ставка %d не сошлась: %+v", id, v)
+		}
+	}
+}
@@ -0,0 +1,98 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"ithozyeva/internal/models"
+)
+
+func TestFairRoll_RangeAndDeterminism(t *testing.T) {
+	for nonce := 0; nonce < 200; nonce++ {
+		for _, n := range []int{2, 12, 100} {
+			got := fairRoll("server", "client", nonce, n)
+			if got < 0 || got >= n {
+				t.Fatalf("fairRoll(nonce=%d, n=%d) = %d, want [0, %d)", nonce, n, got, n)
+			}
+			if again := fairRoll("server", "client", nonce, n); again != got {
+				t.Fatalf("fairRoll не детерминирован: %d != %d", again, got)
+			}
+		}
+	}
+}
+
+// TestFairRoll_KnownVectors — значения, посчитанные внешним HMAC-SHA256
+// (первые 4 байта как дробь [0, 1)): игрок должен получить то же самое.
+func TestFairRoll_KnownVectors(t *testing.T) {
+	tests := []struct {
+		nonce, n, want int
+	}{
+		{0, 100, 49},
+		{1, 100, 31},
+		{2, 100, 2},
+		{0, 12, 5},
+		{1, 12, 3},
+	}
+	for _, tt := range tests {
+		if got := fairRoll("server", "client", tt.nonce, tt.n); got != tt.want {
+			t.Errorf("fairRoll(nonce=%d, n=%d) = %d, want %d", tt.nonce, tt.n, got, tt.want)
+		}
+	}
+}
+
+func TestSettleBet(t *testing.T) {
+	// nonce=0 → roll 49 из 100, сегмент 5 колеса, орёл.
+	tests := []struct {
+		name       string
+		bet        models.CasinoBet
+		wantResult string
+		wantWon    bool
+		wantPayout int
+	}{
+		{"coin heads wins", models.CasinoBet{Game: "coin_flip", BetAmount: 100, BetChoice: "heads"}, "heads", true, 190},
+		{"coin tails loses", models.CasinoBet{Game: "coin_flip", BetAmount: 100, BetChoice: "tails"}, "heads", false, 0},
+		{"dice over 49 wins", models.CasinoBet{Game: "dice_roll", BetAmount: 100, BetChoice: "over 49"}, "49", true, 190},
+		{"dice under 49 loses", models.CasinoBet{Game: "dice_roll", BetAmount: 100, BetChoice: "under 49"}, "49", false, 0},
+		{"wheel segment 5", models.CasinoBet{Game: "wheel", BetAmount: 100, BetChoice: "spin"}, "x0.5", true, 50},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bet := tt.bet
+			won, err := settleBet(&bet, "server", "client", 0)
+			if err != nil {
+				t.Fatalf("settleBet: %v", err)
+			}
+			if bet.Result != tt.wantResult || won != tt.wantWon || bet.Payout != tt.wantPayout {
+				t.Errorf("got result=%s won=%v payout=%d, want %s %v %d", bet.Result, won, bet.Payout, tt.wantResult, tt.wantWon, tt.wantPayout)
+			}
+			if bet.Profit != bet.Payout-bet.BetAmount {
+				t.Errorf("profit = %d, want %d", bet.Profit, bet.Payout-bet.BetAmount)
+			}
+		})
+	}
+
+	bad := models.CasinoBet{Game: "dice_roll", BetAmount: 100, BetChoice: "sideways 50"}
+	if _, err := settleBet(&bad, "server", "client", 0); err == nil {
+		t.Error("settleBet должен отклонять неверную ставку на кости")
+	}
+}
+
+func TestNewCasinoSeed(t *testing.T) {
+	seed, err := newCasinoSeed(1, "")
+	if err != nil {
+		t.Fatalf("newCasinoSeed: %v", err)
+	}
+	if len(seed.ServerSeed) != 64 || seed.ServerSeedHash != hashServerSeed(seed.ServerSeed) || seed.ClientSeed == "" || !seed.Active {
+		t.Errorf("unexpected seed: %+v", seed)
+	}
+
+	seed, err = newCasinoSeed(1, " my-lucky_seed ")
+	if err != nil || seed.ClientSeed != "my-lucky_seed" {
+		t.Errorf("client seed = %q, err %v", seed.ClientSeed, err)
+	}
+	for _, bad := range []string{"с пробелом", "a b", string(make([]byte, 65))} {
+		if _, err := newCasinoSeed(1, bad); !errors.Is(err, ErrInvalidClientSeed) {
+			t.Errorf("newCasinoSeed(%q) = %v, want ErrInvalidClientSeed", bad, err)
+		}
+	}
+}
@@ -0,0 +1,125 @@
+package service
+
+import (
+	"fmt"
+	"log"
+	"math"
+
+	"ithozyeva/internal/models"
+	"ithozyeva/internal/repository"
+
+	"gorm.io/gorm"
+)
+
+const casinoJackpotSettingsKey = "casino_jackpot"
+
+var defaultCasinoJackpotSettings = models.CasinoJackpotSettings{
+	ContributionPercent: 1,
+	TurnoverPerWin:      200000,
+	SeedAmount:          500,
+}
+
+// CasinoJackpotService — прогрессивный джекпот мини-игр. Каждая ставка
+// отдаёт в пул ContributionPercent своей суммы и с шансом
+// bet / TurnoverPerWin забирает его целиком.
+type CasinoJackpotService struct {
+	repo     *repository.CasinoJackpotRepository
+	settings *AppSettingsService
+}
+
+func NewCasinoJackpotService() *CasinoJackpotService {
+	return &CasinoJackpotService{
+		repo:     repository.NewCasinoJackpotRepository(),
+		settings: NewAppSettingsService(),
+	}
+}
+
+func (s *CasinoJackpotService) Settings() models.CasinoJackpotSettings {
+	set := defaultCasinoJackpotSettings
+	if !s.settings.GetJSON(casinoJackpotSettingsKey, &set) || set.TurnoverPerWin <= 0 {
+		return defaultCasinoJackpotSettings
+	}
+	return set
+}
+
+// jackpotHit — выиграла ли ставка джекпот. Бросок — тот же HMAC, что и
+// исход ставки, но с суффиксом ":jackpot" у клиентской части, поэтому его
+// можно проверить по раскрытому seed'у.
+func jackpotHit(serverSeed, clientSeed string, nonce, betAmount, turnoverPerWin int) bool {
+	if turnoverPerWin <= 0 {
+		return false
+	}
+	return fairRoll(serverSeed, clientSeed+":jackpot", nonce, turnoverPerWin) < betAmount
+}
+
+// FeedTx пополняет пул взносом ставки и, если hit, выплачивает его
+// участнику. Вызывается в транзакции ставки после её записи.
+func (s *CasinoJackpotService) FeedTx(tx *gorm.DB, bet *models.CasinoBet, hit bool) (*models.CasinoJackpotWin, error) {
+	set := s.Settings()
+	// Пул — в сотых долях балла: bet × percent / 100 × 100.
+	contribution := int64(math.Round(float64(bet.BetAmount) * set.ContributionPercent))
+	if err := s.repo.ContributeTx(tx, contribution); err != nil {
+		return nil, err
+	}
+	if !hit {
+		return nil, nil
+	}
+
+	// Дробный остаток пула переносим в следующий.
+	pool, err := s.repo.TakeTx(tx, int64(set.SeedAmount)*100)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.ContributeTx(tx, pool%100); err != nil {
+		return nil, err
+	}
+	amount := int(pool / 100)
+	if amount <= 0 {
+		return nil, nil
+	}
+	if err := tx.Create(&models.PointTransaction{
+		MemberId:    bet.MemberId,
+		Amount:      amount,
+		Reason:      models.PointReasonCasinoJackpot,
+		SourceType:  "casino",
+		SourceId:    bet.Id,
+		Description: "Джекпот: " + bet.Game,
+	}).Error; err != nil {
+		return nil, err
+	}
+	win := &models.CasinoJackpotWin{MemberId: bet.MemberId, BetId: &bet.Id, Game: bet.Game, Amount: amount}
+	if err := s.repo.CreateWinTx(tx, win); err != nil {
+		return nil, err
+	}
+	return win, nil
+}
+
+// Announce сообщает о выигрыше после коммита ставки: всем — новый пул,
+// победителю — уведомление.
+func (s *CasinoJackpotService) Announce(win *models.CasinoJackpotWin) {
+	log.Printf("casino jackpot: member %d won %d on %s", win.MemberId, win.Amount, win.Game)
+	GetSSEHub().Broadcast(SSEEvent{Type: "jackpot", Data: win})
+	go CreateNotification(win.MemberId, "casino_jackpot", "Джекпот!",
+		fmt.Sprintf("Вы сорвали джекпот мини-игр: +%d баллов.", win.Amount))
+}
+
+func (s *CasinoJackpotService) Amount() (int, error) {
+	pool, err := s.repo.Pool()
+	return int(pool / 100), err
+}
+
+func (s *CasinoJackpotService) State() (*models.CasinoJackpotState, error) {
+	amount, err := s.Amount()
+	if err != nil {
+		return nil, err
+	}
+	wins, err := s.repo.RecentWins(10)
+	if err != nil {
+		return nil, err
+	}
+	return &models.CasinoJackpotState{Amount: amount, RecentWins: wins, Settings: s.Settings()}, nil
+}
+
+func (s *CasinoJackpotService) AdminStats() (models.CasinoJackpotAdminStats, error) {
+	return s.repo.AdminStats()
+}
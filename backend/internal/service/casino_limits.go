@@ -0,0 +1,413 @@
+package service
+
+import (
+	"time"
+
+	"ithozyeva/database"
+	"ithozyeva/internal/i18n"
+	"ithozyeva/internal/models"
+	"ithozyeva/internal/repository"
+	"ithozyeva/internal/utils"
+
+	"gorm.io/gorm"
+)
+
+const casinoLimitsSettingsKey = "casino_limits"
+
+var defaultCasinoGlobalLimits = models.CasinoGlobalLimits{
+	DailyLossLimit:      3000,
+	DailyBetLimit:       300,
+	CooldownAfterLosses: 10,
+	CooldownMinutes:     10,
+	IncreaseDelayHours:  24,
+}
+
+// CasinoLimitError — ставка или изменение лимита отклонены; текст
+// показывается пользователю.
+type CasinoLimitError struct{ i18n.Message }
+
+func (e *CasinoLimitError) Error() string { return e.String() }
+
+func casinoLimitRefused(format string, args ...interface{}) error {
+	return &CasinoLimitError{i18n.Msg(format, args...)}
+}
+
+// casinoLimitField описывает одно поле личных лимитов.
+type casinoLimitField struct {
+	name     string
+	ref      func(l *models.CasinoLimits) **int
+	min, max int
+	rangeMsg string
+	// looser — новое значение мягче старого: такое изменение вступает в силу
+	// только через IncreaseDelayHours.
+	looser func(old, next *int) bool
+}
+
+var casinoLimitFields = []casinoLimitField{
+	{
+		name:     models.CasinoLimitDailyLoss,
+		ref:      func(l *models.CasinoLimits) **int { return &l.DailyLossLimit },
+		min:      10,
+		max:      1000000,
+		rangeMsg: "дневной лимит проигрыша — от %d до %d баллов",
+		looser:   capLooser,
+	},
+	{
+		name:     models.CasinoLimitDailyBets,
+		ref:      func(l *models.CasinoLimits) **int { return &l.DailyBetLimit },
+		min:      1,
+		max:      10000,
+		rangeMsg: "дневной лимит ставок — от %d до %d",
+		looser:   capLooser,
+	},
+	{
+		name:     models.CasinoLimitCooldownLosses,
+		ref:      func(l *models.CasinoLimits) **int { return &l.CooldownAfterLosses },
+		min:      2,
+		max:      100,
+		rangeMsg: "пауза включается после серии от %d до %d проигрышей",
+		looser:   capLooser,
+	},
+	{
+		name:     models.CasinoLimitCooldownMinutes,
+		ref:      func(l *models.CasinoLimits) **int { return &l.CooldownMinutes },
+		min:      1,
+		max:      1440,
+		rangeMsg: "длительность паузы — от %d до %d минут",
+		looser:   pauseLooser,
+	},
+}
+
+func casinoLimitFieldByName(name string) *casinoLimitField {
+	for i := range casinoLimitFields {
+		if casinoLimitFields[i].name == name {
+			return &casinoLimitFields[i]
+		}
+	}
+	return nil
+}
+
+// capLooser — потолок снят или поднят.
+func capLooser(old, next *int) bool {
+	return old != nil && (next == nil || *next > *old)
+}
+
+// pauseLooser — пауза снята или укорочена.
+func pauseLooser(old, next *int) bool {
+	return old != nil && (next == nil || *next < *old)
+}
+
+func sameLimit(a, b *int) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// stricterCap — меньший из заданных потолков; 0 — не задан ни один.
+func stricterCap(self *int, global int) int {
+	if self == nil || *self <= 0 {
+		return global
+	}
+	if global <= 0 || *self < global {
+		return *self
+	}
+	return global
+}
+
+// longerPause — более длинная из пауз.
+func longerPause(self *int, global int) int {
+	if self != nil && *self > global {
+		return *self
+	}
+	return global
+}
+
+// effectiveCasinoLimits — личные лимиты могут только ужесточить глобальные.
+func effectiveCasinoLimits(self *models.CasinoLimits, global models.CasinoGlobalLimits) models.CasinoEffectiveLimits {
+	return models.CasinoEffectiveLimits{
+		DailyLossLimit:      stricterCap(self.DailyLossLimit, global.DailyLossLimit),
+		DailyBetLimit:       stricterCap(self.DailyBetLimit, global.DailyBetLimit),
+		CooldownAfterLosses: stricterCap(self.CooldownAfterLosses, global.CooldownAfterLosses),
+		CooldownMinutes:     longerPause(self.CooldownMinutes, global.CooldownMinutes),
+	}
+}
+
+type CasinoLimitsService struct {
+	repo     *repository.CasinoLimitsRepository
+	settings *AppSettingsService
+}
+
+func NewCasinoLimitsService() *CasinoLimitsService {
+	return &CasinoLimitsService{
+		repo:     repository.NewCasinoLimitsRepository(),
+		settings: NewAppSettingsService(),
+	}
+}
+
+// Global — глобальные лимиты; без настройки — значения по умолчанию.
+func (s *CasinoLimitsService) Global() models.CasinoGlobalLimits {
+	g := defaultCasinoGlobalLimits
+	if !s.settings.GetJSON(casinoLimitsSettingsKey, &g) {
+		return defaultCasinoGlobalLimits
+	}
+	return g
+}
+
+func (s *CasinoLimitsService) SetGlobal(g models.CasinoGlobalLimits) (models.CasinoGlobalLimits, error) {
+	if g.DailyLossLimit < 0 || g.DailyBetLimit < 0 || g.CooldownAfterLosses < 0 || g.CooldownMinutes < 0 || g.IncreaseDelayHours < 0 {
+		return g, casinoLimitRefused("лимиты не могут быть отрицательными")
+	}
+	if g.CooldownAfterLosses == 1 {
+		return g, casinoLimitRefused("пауза включается после серии минимум из 2 проигрышей")
+	}
+	if g.IncreaseDelayHours > 720 {
+		return g, casinoLimitRefused("задержка ослабления лимитов — не больше %d часов", 720)
+	}
+	return g, s.settings.SetJSON(casinoLimitsSettingsKey, g)
+}
+
+// currentTx — личные лимиты с применёнными ожидающими изменениями, срок
+// которых наступил.
+func (s *CasinoLimitsService) currentTx(tx *gorm.DB, memberId int64, now time.Time) (*models.CasinoLimits, error) {
+	due, err := s.repo.DueChangesTx(tx, memberId, now)
+	if err != nil {
+		return nil, err
+	}
+	if len(due) == 0 {
+		return s.repo.GetTx(tx, memberId)
+	}
+	limits, err := s.repo.GetForUpdateTx(tx, memberId)
+	if err != nil {
+		return nil, err
+	}
+	return limits, s.applyDueTx(tx, limits, now)
+}
+
+func (s *CasinoLimitsService) applyDueTx(tx *gorm.DB, limits *models.CasinoLimits, now time.Time) error {
+	due, err := s.repo.DueChangesTx(tx, limits.MemberId, now)
+	if err != nil || len(due) == 0 {
+		return err
+	}
+	ids := make([]int64, 0, len(due))
+	for _, change := range due {
+		if f := casinoLimitFieldByName(change.Field); f != nil {
+			*f.ref(limits) = change.NewValue
+		}
+		ids = append(ids, change.Id)
+	}
+	if err := s.repo.MarkAppliedTx(tx, ids); err != nil {
+		return err
+	}
+	return s.repo.SaveTx(tx, limits)
+}
+
+// State — личные, глобальные и действующие лимиты, расход за сегодня и
+// пауза, если она идёт.
+func (s *CasinoLimitsService) State(memberId int64) (*models.CasinoLimitsState, error) {
+	now := time.Now()
+	state := &models.CasinoLimitsState{Global: s.Global()}
+	err := database.DB.Transaction(func(tx *gorm.DB) error {
+		limits, err := s.currentTx(tx, memberId, now)
+		if err != nil {
+			return err
+		}
+		state.Self = *limits
+		state.Effective = effectiveCasinoLimits(limits, state.Global)
+		if state.Today, err = s.repo.DayUsage(tx, memberId, utils.MSKDay(now)); err != nil {
+			return err
+		}
+		state.CooldownUntil, err = s.cooldownUntil(tx, memberId, state.Effective, now)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if state.Pending, err = s.repo.Pending(memberId); err != nil {
+		return nil, err
+	}
+	return state, nil
+}
+
+// Update задаёт личные лимиты целиком. Ужесточение действует сразу,
+// ослабление — через IncreaseDelayHours: так решение «отыграться» нельзя
+// принять сгоряча.
+func (s *CasinoLimitsService) Update(memberId int64, req *models.CasinoLimitsRequest) (*models.CasinoLimitsState, error) {
+	values := map[string]*int{
+		models.CasinoLimitDailyLoss:       req.DailyLossLimit,
+		models.CasinoLimitDailyBets:       req.DailyBetLimit,
+		models.CasinoLimitCooldownLosses:  req.CooldownAfterLosses,
+		models.CasinoLimitCooldownMinutes: req.CooldownMinutes,
+	}
+	for _, f := range casinoLimitFields {
+		if v := values[f.name]; v != nil && (*v < f.min || *v > f.max) {
+			return nil, casinoLimitRefused(f.rangeMsg, f.min, f.max)
+		}
+	}
+	global := s.Global()
+	if req.CooldownAfterLosses != nil && req.CooldownMinutes == nil && global.CooldownMinutes == 0 {
+		return nil, casinoLimitRefused("укажите длительность паузы")
+	}
+
+	now := time.Now()
+	delay := time.Duration(global.IncreaseDelayHours) * time.Hour
+	err := database.DB.Transaction(func(tx *gorm.DB) error {
+		limits, err := s.repo.GetForUpdateTx(tx, memberId)
+		if err != nil {
+			return err
+		}
+		if err := s.applyDueTx(tx, limits, now); err != nil {
+			return err
+		}
+		pending, err := s.repo.PendingTx(tx, memberId)
+		if err != nil {
+			return err
+		}
+		pendingByField := make(map[string]models.CasinoLimitChange, len(pending))
+		for _, change := range pending {
+			pendingByField[change.Field] = change
+		}
+
+		changed := false
+		for _, f := range casinoLimitFields {
+			cur, next := f.ref(limits), values[f.name]
+			if p, ok := pendingByField[f.name]; ok {
+				// Повторная отправка того же ослабления не сбрасывает отсчёт.
+				if sameLimit(p.NewValue, next) {
+					continue
+				}
+				if err := s.repo.CancelPendingTx(tx, memberId, f.name); err != nil {
+					return err
+				}
+			}
+			if sameLimit(*cur, next) {
+				continue
+			}
+			change := &models.CasinoLimitChange{
+				MemberId:    memberId,
+				Field:       f.name,
+				OldValue:    *cur,
+				NewValue:    next,
+				Status:      models.CasinoLimitChangeApplied,
+				EffectiveAt: now,
+			}
+			if f.looser(*cur, next) && delay > 0 {
+				change.Status = models.CasinoLimitChangePending
+				change.EffectiveAt = now.Add(delay)
+			} else {
+				*cur = next
+				changed = true
+			}
+			if err := s.repo.CreateChangeTx(tx, change); err != nil {
+				return err
+			}
+		}
+		if changed {
+			return s.repo.SaveTx(tx, limits)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.State(memberId)
+}
+
+// SelfExclude закрывает мини-игры на days дней. Самоисключение можно только
+// продлить — ни сократить, ни снять его нельзя.
+func (s *CasinoLimitsService) SelfExclude(memberId int64, days int) (*models.CasinoLimitsState, error) {
+	if days < 1 || days > models.CasinoSelfExclusionMaxDays {
+		return nil, casinoLimitRefused("самоисключение — от 1 до %d дней", models.CasinoSelfExclusionMaxDays)
+	}
+	now := time.Now()
+	err := database.DB.Transaction(func(tx *gorm.DB) error {
+		limits, err := s.repo.GetForUpdateTx(tx, memberId)
+		if err != nil {
+			return err
+		}
+		until := now.AddDate(0, 0, days)
+		if limits.ExcludedUntil != nil && limits.ExcludedUntil.After(until) {
+			return casinoLimitRefused("самоисключение уже действует до %s МСК — сократить его нельзя", mskDateTime(*limits.ExcludedUntil))
+		}
+		limits.ExcludedUntil = &until
+		if err := s.repo.CreateChangeTx(tx, &models.CasinoLimitChange{
+			MemberId:    memberId,
+			Field:       models.CasinoLimitSelfExclusion,
+			NewValue:    &days,
+			Status:      models.CasinoLimitChangeApplied,
+			EffectiveAt: now,
+		}); err != nil {
+			return err
+		}
+		return s.repo.SaveTx(tx, limits)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.State(memberId)
+}
+
+func (s *CasinoLimitsService) History(memberId int64, limit, offset int) ([]models.CasinoLimitChange, int64, error) {
+	return s.repo.History(memberId, limit, offset)
+}
+
+// CheckTx проверяет, можно ли сделать ставку betAmount. Вызывается внутри
+// транзакции ставки под advisory-lock'ом участника, поэтому параллельные
+// ставки не обходят дневные лимиты.
+func (s *CasinoLimitsService) CheckTx(tx *gorm.DB, memberId int64, betAmount int, now time.Time) error {
+	limits, err := s.currentTx(tx, memberId, now)
+	if err != nil {
+		return err
+	}
+	if limits.ExcludedUntil != nil && limits.ExcludedUntil.After(now) {
+		return casinoLimitRefused("мини-игры закрыты самоисключением до %s МСК", mskDateTime(*limits.ExcludedUntil))
+	}
+
+	eff := effectiveCasinoLimits(limits, s.Global())
+	if eff.DailyBetLimit > 0 || eff.DailyLossLimit > 0 {
+		usage, err := s.repo.DayUsage(tx, memberId, utils.MSKDay(now))
+		if err != nil {
+			return err
+		}
+		if eff.DailyBetLimit > 0 && usage.Bets >= eff.DailyBetLimit {
+			return casinoLimitRefused("дневной лимит ставок (%d) исчерпан, продолжить можно завтра", eff.DailyBetLimit)
+		}
+		// Ставка допускается, только если даже её проигрыш не выведет за лимит.
+		if eff.DailyLossLimit > 0 && usage.Loss+betAmount > eff.DailyLossLimit {
+			left := eff.DailyLossLimit - usage.Loss
+			if left < 0 {
+				left = 0
+			}
+			return casinoLimitRefused("ставка превышает дневной лимит проигрыша %d баллов: осталось %d", eff.DailyLossLimit, left)
+		}
+	}
+
+	until, err := s.cooldownUntil(tx, memberId, eff, now)
+	if err != nil {
+		return err
+	}
+	if until != nil {
+		return casinoLimitRefused("пауза после %d проигрышей подряд — до %s МСК", eff.CooldownAfterLosses, mskDateTime(*until))
+	}
+	return nil
+}
+
+// cooldownUntil — конец паузы после серии проигрышей; nil — паузы нет.
+func (s *CasinoLimitsService) cooldownUntil(db *gorm.DB, memberId int64, eff models.CasinoEffectiveLimits, now time.Time) (*time.Time, error) {
+	if eff.CooldownAfterLosses <= 0 || eff.CooldownMinutes <= 0 {
+		return nil, nil
+	}
+	streak, last, err := s.repo.LossStreak(db, memberId, eff.CooldownAfterLosses)
+	if err != nil || streak < eff.CooldownAfterLosses {
+		return nil, err
+	}
+	until := last.Add(time.Duration(eff.CooldownMinutes) * time.Minute)
+	if !until.After(now) {
+		return nil, nil
+	}
+	return &until, nil
+}
+
+func mskDateTime(t time.Time) string {
+	return t.In(utils.MSKLocation()).Format("02.01.2006 15:04")
+}
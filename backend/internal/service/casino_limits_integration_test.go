@@ -0,0 +1,76 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"ithozyeva/internal/models"
+	"ithozyeva/internal/testutil"
+)
+
+// TestCasinoLimits_DailyBetsAndDelayedIncrease — ужесточение действует сразу
+// и останавливает ставки, ослабление ждёт задержки.
+func TestCasinoLimits_DailyBetsAndDelayedIncrease(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	testutil.TruncateAll(t, db, "casino_limit_changes", "casino_limits", "casino_bets", "casino_seeds", "point_transactions", "members")
+
+	member := seedMember(t, db, 7501)
+	creditPoints(t, db, member.Id, 1000)
+	casino := NewCasinoService()
+	limits := NewCasinoLimitsService()
+
+	if _, err := limits.Update(member.Id, &models.CasinoLimitsRequest{DailyBetLimit: ptrInt(2)}); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	for i := 0; i < 2; i++ {
+		if _, err := casino.PlayCoinFlip(member.Id, &models.CoinFlipRequest{BetAmount: 10, Choice: "heads"}); err != nil {
+			t.Fatalf("ставка %d: %v", i+1, err)
+		}
+	}
+	before := balanceOf(t, member.Id)
+	var limitErr *CasinoLimitError
+	if _, err := casino.PlayWheel(member.Id, &models.WheelRequest{BetAmount: 10}); !errors.As(err, &limitErr) {
+		t.Fatalf("третья ставка: want CasinoLimitError, got %v", err)
+	}
+	if after := balanceOf(t, member.Id); after != before {
+		t.Fatalf("отказ списал баллы: %d → %d", before, after)
+	}
+
+	state, err := limits.Update(member.Id, &models.CasinoLimitsRequest{DailyBetLimit: ptrInt(100)})
+	if err != nil {
+		t.Fatalf("Update (ослабление): %v", err)
+	}
+	if state.Self.DailyBetLimit == nil || *state.Self.DailyBetLimit != 2 || len(state.Pending) != 1 {
+		t.Fatalf("ослабление применилось сразу: %+v", state)
+	}
+
+	history, total, err := limits.History(member.Id, 10, 0)
+	if err != nil || total != 2 || history[0].Status != models.CasinoLimitChangePending {
+		t.Fatalf("History: %v, %d, %+v", err, total, history)
+	}
+}
+
+// TestCasinoLimits_SelfExclusion — самоисключение закрывает игры и не
+// сокращается.
+func TestCasinoLimits_SelfExclusion(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	testutil.TruncateAll(t, db, "casino_limit_changes", "casino_limits", "casino_bets", "casino_seeds", "point_transactions", "members")
+
+	member := seedMember(t, db, 7502)
+	creditPoints(t, db, member.Id, 1000)
+	limits := NewCasinoLimitsService()
+
+	if _, err := limits.SelfExclude(member.Id, 7); err != nil {
+		t.Fatalf("SelfExclude: %v", err)
+	}
+	var limitErr *CasinoLimitError
+	if _, err := NewCasinoService().PlayCoinFlip(member.Id, &models.CoinFlipRequest{BetAmount: 10, Choice: "tails"}); !errors.As(err, &limitErr) {
+		t.Fatalf("ставка при самоисключении: got %v", err)
+	}
+	if _, err := limits.SelfExclude(member.Id, 1); !errors.As(err, &limitErr) {
+		t.Fatalf("сокращение самоисключения: got %v", err)
+	}
+	if _, err := limits.SelfExclude(member.Id, 30); err != nil {
+		t.Fatalf("продление: %v", err)
+	}
+}
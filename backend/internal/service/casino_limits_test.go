@@ -0,0 +1,51 @@
+package service
+
+import (
+	"testing"
+
+	"ithozyeva/internal/models"
+)
+
+func TestEffectiveCasinoLimits(t *testing.T) {
+	global := models.CasinoGlobalLimits{DailyLossLimit: 3000, DailyBetLimit: 0, CooldownAfterLosses: 10, CooldownMinutes: 10}
+
+	got := effectiveCasinoLimits(&models.CasinoLimits{}, global)
+	want := models.CasinoEffectiveLimits{DailyLossLimit: 3000, DailyBetLimit: 0, CooldownAfterLosses: 10, CooldownMinutes: 10}
+	if got != want {
+		t.Fatalf("без личных лимитов: got %+v, want %+v", got, want)
+	}
+
+	// Личные лимиты ужесточают глобальные, но не ослабляют.
+	got = effectiveCasinoLimits(&models.CasinoLimits{
+		DailyLossLimit:      ptrInt(5000),
+		DailyBetLimit:       ptrInt(50),
+		CooldownAfterLosses: ptrInt(3),
+		CooldownMinutes:     ptrInt(5),
+	}, global)
+	want = models.CasinoEffectiveLimits{DailyLossLimit: 3000, DailyBetLimit: 50, CooldownAfterLosses: 3, CooldownMinutes: 10}
+	if got != want {
+		t.Fatalf("с личными лимитами: got %+v, want %+v", got, want)
+	}
+}
+
+func TestCasinoLimitLooser(t *testing.T) {
+	cases := []struct {
+		name      string
+		looser    func(old, next *int) bool
+		old, next *int
+		want      bool
+	}{
+		{"потолок впервые", capLooser, nil, ptrInt(100), false},
+		{"потолок снижен", capLooser, ptrInt(100), ptrInt(50), false},
+		{"потолок поднят", capLooser, ptrInt(100), ptrInt(150), true},
+		{"потолок снят", capLooser, ptrInt(100), nil, true},
+		{"пауза удлинена", pauseLooser, ptrInt(10), ptrInt(30), false},
+		{"пауза укорочена", pauseLooser, ptrInt(30), ptrInt(10), true},
+		{"пауза снята", pauseLooser, ptrInt(30), nil, true},
+	}
+	for _, tc := range cases {
+		if got := tc.looser(tc.old, tc.next); got != tc.want {
+			t.Errorf("%s: got %v, want %v", tc.name, got, tc.want)
+		}
+	}
+}
@@ -34,30 +34,6 @@ func TestValidateBet(t *testing.T) {
 	}
 }
 
-func TestCryptoRandInt(t *testing.T) {
-	// Test that cryptoRandInt returns values in range [0, max)
-	for i := 0; i < 100; i++ {
-		n, err := cryptoRandInt(10)
-		if err != nil {
-			t.Fatalf("cryptoRandInt(10) returned error: %v", err)
-		}
-		if n < 0 || n >= 10 {
-			t.Errorf("cryptoRandInt(10) = %d, want [0, 10)", n)
-		}
-	}
-
-	// Test with max=2 (coin flip)
-	for i := 0; i < 50; i++ {
-		n, err := cryptoRandInt(2)
-		if err != nil {
-			t.Fatalf("cryptoRandInt(2) returned error: %v", err)
-		}
-		if n != 0 && n != 1 {
-			t.Errorf("cryptoRandInt(2) = %d, want 0 or 1", n)
-		}
-	}
-}
-
 func TestWheelMultipliers(t *testing.T) {
 	if len(wheelMultipliers) != 12 {
 		t.Errorf("wheelMultipliers has %d segments, want 12", len(wheelMultipliers))
@@ -79,8 +79,9 @@ func (s *ChatActivityService) IsTrackedChat(chatID int64) bool {
 	return s.trackedChatIDs[chatID]
 }
 
-// TrackMessage проверяет, что чат отслеживается, и сохраняет сообщение
-func (s *ChatActivityService) TrackMessage(message *tgbotapi.Message) {
+// TrackMessage проверяет, что чат отслеживается, и сохраняет сообщение.
+// threadID — тема форума (0 — вне темы), её бот достаёт из сырого апдейта.
+func (s *ChatActivityService) TrackMessage(message *tgbotapi.Message, threadID int) {
 	if message == nil || message.From == nil {
 		return
 	}
@@ -105,8 +106,12 @@ func (s *ChatActivityService) TrackMessage(message *tgbotapi.Message) {
 		MemberID:          memberID,
 		MessageText:       message.Text,
 		TelegramMessageID: &tgMessageID,
+		ReplyToMessageID:  ReplyToMessageID(message, threadID),
 		SentAt:            time.Unix(int64(message.Date), 0),
 	}
+	if threadID != 0 {
+		msg.MessageThreadID = &threadID
+	}
 
 	if err := s.repo.SaveMessage(msg); err != nil {
 		log.Printf("Error saving chat message: %v", err)
@@ -117,6 +122,30 @@ func (s *ChatActivityService) TrackMessage(message *tgbotapi.Message) {
 	go s.questService.ProcessMessage(message, memberID)
 }
 
+// ReplyToMessageID — на какое сообщение это ответ. В теме форума Telegram
+// подставляет в reply_to_message служебное сообщение создания темы (его id
+// равен id темы) — это не ответ, а принадлежность к теме.
+func ReplyToMessageID(message *tgbotapi.Message, threadID int) *int {
+	if message.ReplyToMessage == nil {
+		return nil
+	}
+	id := message.ReplyToMessage.MessageID
+	if threadID != 0 && id == threadID {
+		return nil
+	}
+	return &id
+}
+
+// FindMessages — сообщения с текстом по фильтру (см. ChatMessageQuery).
+func (s *ChatActivityService) FindMessages(q repository.ChatMessageQuery) ([]models.ChatMessage, error) {
+	return s.repo.FindMessages(q)
+}
+
+// GetThreadMessages — ветка обсуждения вокруг сообщения messageID.
+func (s *ChatActivityService) GetThreadMessages(chatID int64, messageID, limit int) ([]models.ChatMessage, error) {
+	return s.repo.GetThreadMessages(chatID, messageID, limit)
+}
+
 // GetRecentMessages возвращает последние N сообщений с текстом из чата
 func (s *ChatActivityService) GetRecentMessages(chatID int64, limit int) ([]models.ChatMessage, error) {
 	return s.repo.GetRecentMessages(chatID, limit)
@@ -0,0 +1,24 @@
+package service
+
+import (
+	"testing"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+func TestReplyToMessageID(t *testing.T) {
+	if ReplyToMessageID(&tgbotapi.Message{MessageID: 5}, 0) != nil {
+		t.Fatal("plain message is not a reply")
+	}
+	reply := &tgbotapi.Message{MessageID: 5, ReplyToMessage: &tgbotapi.Message{MessageID: 3}}
+	if got := ReplyToMessageID(reply, 0); got == nil || *got != 3 {
+		t.Fatalf("reply: %v", got)
+	}
+	// В теме форума reply_to на сообщение создания темы — не ответ.
+	if ReplyToMessageID(reply, 3) != nil {
+		t.Fatal("topic root must not count as reply")
+	}
+	if got := ReplyToMessageID(reply, 2); got == nil || *got != 3 {
+		t.Fatalf("reply inside topic: %v", got)
+	}
+}
@@ -0,0 +1,190 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	"ithozyeva/config"
+	"ithozyeva/internal/models"
+	"ithozyeva/internal/repository"
+	"ithozyeva/internal/utils"
+)
+
+// Еженедельный дайджест: неделя — с понедельника 00:00 до следующего
+// понедельника 00:00 МСК. Генерируем в понедельник с 10:00 МСК (чтобы пост
+// не пришёлся на ночь), публикует бот.
+const (
+	digestPublishHourMSK = 10
+	digestMinMessages    = 30   // тихие чаты без дайджеста
+	digestMaxMessages    = 1500 // потолок лога для модели
+	digestTopMembers     = 5
+	digestHighlights     = 3
+)
+
+type ChatDigestService struct {
+	repo         *repository.ChatDigestRepository
+	activityRepo *repository.ChatActivityRepository
+}
+
+func NewChatDigestService() *ChatDigestService {
+	return &ChatDigestService{
+		repo:         repository.NewChatDigestRepository(),
+		activityRepo: repository.NewChatActivityRepository(),
+	}
+}
+
+// DigestWeek — последняя завершённая на now неделя [start, end) по МСК.
+func DigestWeek(now time.Time) (start, end time.Time) {
+	day := utils.MSKDay(now)
+	// Weekday: Sunday=0 — приводим к «дней с понедельника».
+	sinceMonday := (int(day.Weekday()) + 6) % 7
+	end = day.AddDate(0, 0, -sinceMonday)
+	start = end.AddDate(0, 0, -7)
+	return start, end
+}
+
+// DigestDue — пора ли генерировать дайджесты за DigestWeek(now): в
+// понедельник — только после digestPublishHourMSK, в остальные дни — всегда
+// (догоняем, если бэкенд лежал в понедельник).
+func DigestDue(now time.Time) bool {
+	msk := now.In(utils.MSKLocation())
+	return msk.Weekday() != time.Monday || msk.Hour() >= digestPublishHourMSK
+}
+
+// GenerateWeeklyDigests создаёт недостающие дайджесты за прошлую неделю по
+// всем активным трекаемым чатам. Идемпотентна (UNIQUE chat_id+period_start):
+// безопасно звать каждый час. Ошибка модели по одному чату не мешает
+// остальным — чат попробуем на следующем тике.
+func (s *ChatDigestService) GenerateWeeklyDigests(now time.Time) (int, error) {
+	if config.CFG.OpenAIKey == "" || !DigestDue(now) {
+		return 0, nil
+	}
+	start, end := DigestWeek(now)
+	chats, err := s.activityRepo.GetTrackedChats()
+	if err != nil {
+		return 0, err
+	}
+	created := 0
+	for _, chat := range chats {
+		ok, err := s.generateForChat(chat, start, end)
+		if err != nil {
+			log.Printf("chat digest chat=%d: %v", chat.ChatID, err)
+			continue
+		}
+		if ok {
+			created++
+		}
+	}
+	return created, nil
+}
+
+func (s *ChatDigestService) generateForChat(chat models.TrackedChat, start, end time.Time) (bool, error) {
+	exists, err := s.repo.Exists(chat.ChatID, start)
+	if err != nil || exists {
+		return false, err
+	}
+	total, authors, err := s.repo.CountMessagesBetween(chat.ChatID, start, end)
+	if err != nil {
+		return false, err
+	}
+	if total < digestMinMessages {
+		return false, nil
+	}
+
+	messages, err := s.repo.GetMessagesBetween(chat.ChatID, start, end, digestMaxMessages)
+	if err != nil {
+		return false, err
+	}
+	summary, model, err := CallOpenAIWithRetry(LLMRequest{
+		Feature:      models.LLMFeatureDigest,
+		SystemPrompt: ChatSummarySystemPrompt,
+		UserContent: "Это переписка чата за неделю. Составь дайджест недели: 5–7 главных тем, " +
+			"по каждой — одна-две строки о сути и выводах.\n\n" + FormatChatLog(messages),
+		MaxTokens: 1500,
+	})
+	if err != nil {
+		return false, fmt.Errorf("llm: %w", err)
+	}
+
+	top, err := s.repo.TopMembersBetween(chat.ChatID, start, end, digestTopMembers)
+	if err != nil {
+		return false, err
+	}
+	hl, err := s.repo.HighlightsBetween(chat.ChatID, start, end, digestHighlights)
+	if err != nil {
+		return false, err
+	}
+	highlights := make([]models.DigestHighlight, 0, len(hl))
+	for _, h := range hl {
+		highlights = append(highlights, models.DigestHighlight{
+			Id:              h.Id,
+			MessageID:       h.MessageID,
+			AuthorUsername:  h.AuthorUsername,
+			AuthorFirstName: h.AuthorFirstName,
+			MessageText:     h.MessageText,
+		})
+	}
+	topJSON, err := json.Marshal(nonNil(top))
+	if err != nil {
+		return false, err
+	}
+	hlJSON, err := json.Marshal(highlights)
+	if err != nil {
+		return false, err
+	}
+
+	return s.repo.Create(&models.ChatDigest{
+		ChatID:        chat.ChatID,
+		ChatTitle:     chat.Title,
+		PeriodStart:   start,
+		PeriodEnd:     end,
+		MessageCount:  int(total),
+		ActiveMembers: int(authors),
+		Summary:       summary,
+		Model:         model,
+		TopMembers:    string(topJSON),
+		Highlights:    string(hlJSON),
+	})
+}
+
+func nonNil(list []models.DigestMember) []models.DigestMember {
+	if list == nil {
+		return []models.DigestMember{}
+	}
+	return list
+}
+
+// GetDigest — дайджест или nil.
+func (s *ChatDigestService) GetDigest(id int64) (*models.ChatDigest, error) {
+	return s.repo.GetByID(id)
+}
+
+// ListDigests — архив; chatID=0 — все чаты.
+func (s *ChatDigestService) ListDigests(chatID int64, limit, offset int) ([]models.ChatDigest, int64, error) {
+	return s.repo.List(chatID, limit, offset)
+}
+
+// ListUnposted — для бота: сгенерированные, но не опубликованные.
+func (s *ChatDigestService) ListUnposted(limit int) ([]models.ChatDigest, error) {
+	return s.repo.ListUnposted(limit)
+}
+
+// MarkPosted — см. ChatDigestRepository.MarkPosted.
+func (s *ChatDigestService) MarkPosted(id int64) (bool, error) {
+	return s.repo.MarkPosted(id, time.Now())
+}
+
+// DecodeDigestMembers / DecodeDigestHighlights разбирают JSON-поля дайджеста.
+func DecodeDigestMembers(d *models.ChatDigest) []models.DigestMember {
+	var list []models.DigestMember
+	_ = json.Unmarshal([]byte(d.TopMembers), &list)
+	return list
+}
+
+func DecodeDigestHighlights(d *models.ChatDigest) []models.DigestHighlight {
+	var list []models.DigestHighlight
+	_ = json.Unmarshal([]byte(d.Highlights), &list)
+	return list
+}
@@ -0,0 +1,47 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"ithozyeva/internal/utils"
+)
+
+func TestDigestWeek(t *testing.T) {
+	msk := utils.MSKLocation()
+	wantStart := time.Date(2026, 5, 18, 0, 0, 0, 0, msk) // понедельник
+	wantEnd := time.Date(2026, 5, 25, 0, 0, 0, 0, msk)
+	for _, now := range []time.Time{
+		time.Date(2026, 5, 25, 10, 0, 0, 0, msk),  // понедельник утром
+		time.Date(2026, 5, 27, 15, 0, 0, 0, msk),  // среда
+		time.Date(2026, 5, 31, 23, 59, 0, 0, msk), // воскресенье
+	} {
+		start, end := DigestWeek(now)
+		if !start.Equal(wantStart) || !end.Equal(wantEnd) {
+			t.Errorf("now=%v: got [%v, %v), want [%v, %v)", now, start, end, wantStart, wantEnd)
+		}
+	}
+	// Понедельник 00:30 MSK — это ещё воскресенье 21:30 UTC: неделя всё равно
+	// считается по МСК.
+	start, _ := DigestWeek(time.Date(2026, 5, 24, 21, 30, 0, 0, time.UTC))
+	if !start.Equal(wantStart) {
+		t.Errorf("UTC boundary: start = %v, want %v", start, wantStart)
+	}
+}
+
+func TestDigestDue(t *testing.T) {
+	msk := utils.MSKLocation()
+	cases := []struct {
+		now  time.Time
+		want bool
+	}{
+		{time.Date(2026, 5, 25, 9, 59, 0, 0, msk), false},
+		{time.Date(2026, 5, 25, 10, 0, 0, 0, msk), true},
+		{time.Date(2026, 5, 26, 3, 0, 0, 0, msk), true},
+	}
+	for _, c := range cases {
+		if got := DigestDue(c.now); got != c.want {
+			t.Errorf("%v: got %v, want %v", c.now, got, c.want)
+		}
+	}
+}
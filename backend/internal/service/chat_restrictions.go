@@ -0,0 +1,131 @@
+package service
+
+import (
+	"fmt"
+	"time"
+
+	"ithozyeva/internal/i18n"
+	"ithozyeva/internal/models"
+)
+
+// Пределы политики ограничений. Больше 90 дней «новичка» не бывает — это
+// уже просто запрет; окно rate limit — от 5 секунд до часа.
+const (
+	maxNewMemberDays       = 90
+	maxRateLimitMessages   = 100
+	minRateLimitWindow     = 5 * time.Second
+	maxRateLimitWindow     = time.Hour
+	defaultRateLimitWindow = time.Minute
+)
+
+// DefaultChatRestrictionPolicy — политика чата без строки в БД: ничего не
+// ограничено, но при включении новичков блокируется всё (ссылки, медиа,
+// форварды) — типичный набор спамера.
+func DefaultChatRestrictionPolicy(chatID int64) *models.ChatRestrictionPolicy {
+	return &models.ChatRestrictionPolicy{
+		ChatID:                 chatID,
+		BlockLinks:             true,
+		BlockMedia:             true,
+		BlockForwards:          true,
+		RateLimitWindowSeconds: int(defaultRateLimitWindow.Seconds()),
+	}
+}
+
+// ValidateChatRestrictionPolicy проверяет диапазоны.
+func ValidateChatRestrictionPolicy(p *models.ChatRestrictionPolicy) error {
+	switch {
+	case p.NewMemberDays < 0 || p.NewMemberDays > maxNewMemberDays:
+		return i18n.Errorf("срок новичка — от 0 (выключено) до %d дней", maxNewMemberDays)
+	case p.RateLimitMessages < 0 || p.RateLimitMessages > maxRateLimitMessages:
+		return i18n.Errorf("rate limit — от 0 (выключено) до %d сообщений", maxRateLimitMessages)
+	case p.RateLimitWindowSeconds < int(minRateLimitWindow.Seconds()) ||
+		p.RateLimitWindowSeconds > int(maxRateLimitWindow.Seconds()):
+		return fmt.Errorf("окно rate limit — от 5s до 1h")
+	case p.ExemptTierLevel < 0:
+		return fmt.Errorf("уровень тира не может быть отрицательным")
+	}
+	return nil
+}
+
+// GetRestrictionPolicy возвращает политику чата (дефолт, если не задавали).
+func (s *ModerationService) GetRestrictionPolicy(chatID int64) (*models.ChatRestrictionPolicy, error) {
+	p, err := s.repo.GetRestrictionPolicy(chatID)
+	if err != nil {
+		return nil, err
+	}
+	if p == nil {
+		return DefaultChatRestrictionPolicy(chatID), nil
+	}
+	return p, nil
+}
+
+// ListRestrictionPolicies — чаты с заданными политиками (для админки).
+func (s *ModerationService) ListRestrictionPolicies() ([]models.ChatRestrictionPolicy, error) {
+	return s.repo.ListRestrictionPolicies()
+}
+
+// SaveRestrictionPolicy валидирует, сохраняет и логирует изменение.
+func (s *ModerationService) SaveRestrictionPolicy(p *models.ChatRestrictionPolicy, actorID int64) error {
+	if err := ValidateChatRestrictionPolicy(p); err != nil {
+		return err
+	}
+	p.UpdatedBy = actorID
+	if err := s.repo.UpsertRestrictionPolicy(p); err != nil {
+		return err
+	}
+	return s.LogActionWithMeta(&models.ModerationAction{
+		ChatID:      p.ChatID,
+		ActorUserID: actorID,
+		Action:      models.ModerationActionSettings,
+	}, map[string]interface{}{
+		"policy":                    "set",
+		"new_member_days":           p.NewMemberDays,
+		"block_links":               p.BlockLinks,
+		"block_media":               p.BlockMedia,
+		"block_forwards":            p.BlockForwards,
+		"rate_limit_messages":       p.RateLimitMessages,
+		"rate_limit_window_seconds": p.RateLimitWindowSeconds,
+		"exempt_tier_level":         p.ExemptTierLevel,
+	})
+}
+
+// ResetRestrictionPolicy снимает все ограничения в чате.
+func (s *ModerationService) ResetRestrictionPolicy(chatID, actorID int64) error {
+	if err := s.repo.DeleteRestrictionPolicy(chatID); err != nil {
+		return err
+	}
+	return s.LogActionWithMeta(&models.ModerationAction{
+		ChatID:      chatID,
+		ActorUserID: actorID,
+		Action:      models.ModerationActionSettings,
+	}, map[string]interface{}{"policy": "reset"})
+}
+
+// RecordChatJoin — вступление участника, увиденное ботом.
+func (s *ModerationService) RecordChatJoin(chatID, userID int64) error {
+	return s.repo.RecordChatJoin(chatID, userID, time.Now())
+}
+
+// IsNewMember — вступил ли userID в чат меньше days дней назад. Участники,
+// чьё вступление бот не видел (были в чате до начала трекинга), новичками
+// не считаются.
+func (s *ModerationService) IsNewMember(chatID, userID int64, days int, now time.Time) (bool, error) {
+	if days <= 0 {
+		return false, nil
+	}
+	j, err := s.repo.GetChatJoin(chatID, userID)
+	if err != nil || j == nil {
+		return false, err
+	}
+	return IsWithinNewMemberPeriod(j.FirstJoinedAt, days, now), nil
+}
+
+// IsWithinNewMemberPeriod — чистая часть IsNewMember.
+func IsWithinNewMemberPeriod(joinedAt time.Time, days int, now time.Time) bool {
+	return days > 0 && now.Before(joinedAt.Add(time.Duration(days)*24*time.Hour))
+}
+
+// GetSubscriberTierLevel — уровень текущего тира подписчика; 0 — без подписки.
+func (s *ModerationService) GetSubscriberTierLevel(userID int64) (int, error) {
+	return s.repo.GetSubscriberTierLevel(userID)
+}
@@ -0,0 +1,51 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"ithozyeva/internal/models"
+)
+
+func TestValidateChatRestrictionPolicy(t *testing.T) {
+	if err := ValidateChatRestrictionPolicy(DefaultChatRestrictionPolicy(1)); err != nil {
+		t.Fatalf("defaults must be valid: %v", err)
+	}
+	cases := []struct {
+		name   string
+		mutate func(p *models.ChatRestrictionPolicy)
+	}{
+		{"negative days", func(p *models.ChatRestrictionPolicy) { p.NewMemberDays = -1 }},
+		{"too many days", func(p *models.ChatRestrictionPolicy) { p.NewMemberDays = 91 }},
+		{"rate too high", func(p *models.ChatRestrictionPolicy) { p.RateLimitMessages = 101 }},
+		{"window too short", func(p *models.ChatRestrictionPolicy) { p.RateLimitWindowSeconds = 1 }},
+		{"window too long", func(p *models.ChatRestrictionPolicy) { p.RateLimitWindowSeconds = 7200 }},
+		{"negative tier", func(p *models.ChatRestrictionPolicy) { p.ExemptTierLevel = -1 }},
+	}
+	for _, c := range cases {
+		p := DefaultChatRestrictionPolicy(1)
+		c.mutate(p)
+		if err := ValidateChatRestrictionPolicy(p); err == nil {
+			t.Errorf("%s: want error", c.name)
+		}
+	}
+}
+
+func TestIsWithinNewMemberPeriod(t *testing.T) {
+	joined := time.Date(2026, 5, 20, 12, 0, 0, 0, time.UTC)
+	cases := []struct {
+		now  time.Time
+		days int
+		want bool
+	}{
+		{joined.Add(time.Hour), 3, true},
+		{joined.Add(71 * time.Hour), 3, true},
+		{joined.Add(72 * time.Hour), 3, false},
+		{joined.Add(time.Hour), 0, false},
+	}
+	for _, c := range cases {
+		if got := IsWithinNewMemberPeriod(joined, c.days, c.now); got != c.want {
+			t.Errorf("days=%d now=%v: got %v, want %v", c.days, c.now, got, c.want)
+		}
+	}
+}
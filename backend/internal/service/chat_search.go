@@ -0,0 +1,137 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"ithozyeva/internal/i18n"
+	"ithozyeva/internal/models"
+	"ithozyeva/internal/repository"
+	"ithozyeva/internal/utils"
+)
+
+const (
+	chatSearchMinQuery = 2
+	chatSearchMaxQuery = 200
+)
+
+// ErrChatSearchForbidden — фильтр по чату, к которому у участника нет доступа.
+var ErrChatSearchForbidden = errors.New("нет доступа к этому чату")
+
+// ChatSearchParams — параметры поиска из запроса платформы.
+type ChatSearchParams struct {
+	Query  string
+	Author string // @username, username или telegram id
+	From   string // ГГГГ-ММ-ДД по МСК, включительно
+	To     string // ГГГГ-ММ-ДД по МСК, включительно
+	Sort   string // relevance (по умолчанию) | date
+	Limit  int
+	Offset int
+}
+
+type ChatSearchService struct {
+	repo *repository.ChatSearchRepository
+}
+
+func NewChatSearchService() *ChatSearchService {
+	return &ChatSearchService{repo: repository.NewChatSearchRepository()}
+}
+
+// SearchableChats — чаты, по которым участнику доступен поиск.
+func (s *ChatSearchService) SearchableChats(telegramUserID int64) ([]models.SearchableChat, error) {
+	return s.repo.ListSearchableChats(telegramUserID)
+}
+
+// Search ищет по сообщениям чатов, к которым у участника есть доступ через
+// подписку; chatID — фильтр по одному чату (0 — все доступные). q —
+// результат BuildChatSearchQuery.
+func (s *ChatSearchService) Search(telegramUserID, chatID int64, q repository.ChatSearchQuery) ([]models.ChatSearchResult, int64, error) {
+	chats, err := s.repo.ListSearchableChats(telegramUserID)
+	if err != nil {
+		return nil, 0, err
+	}
+	for _, c := range chats {
+		if chatID == 0 || c.ChatID == chatID {
+			q.ChatIDs = append(q.ChatIDs, c.ChatID)
+		}
+	}
+	if chatID != 0 && len(q.ChatIDs) == 0 {
+		return nil, 0, ErrChatSearchForbidden
+	}
+
+	results, total, err := s.repo.Search(q)
+	if err != nil {
+		return nil, 0, err
+	}
+	for i := range results {
+		if results[i].TelegramMessageID != nil {
+			results[i].Link = TelegramMessageLink(results[i].ChatID, results[i].MessageThreadID, *results[i].TelegramMessageID)
+		}
+	}
+	return results, total, nil
+}
+
+// BuildChatSearchQuery валидирует параметры и переводит их в запрос
+// репозитория (без списка чатов). Текст ошибки — для пользователя.
+func BuildChatSearchQuery(p ChatSearchParams) (repository.ChatSearchQuery, error) {
+	text := strings.TrimSpace(p.Query)
+	switch n := utf8.RuneCountInString(text); {
+	case n < chatSearchMinQuery:
+		return repository.ChatSearchQuery{}, i18n.Errorf("запрос короче %d символов", chatSearchMinQuery)
+	case n > chatSearchMaxQuery:
+		return repository.ChatSearchQuery{}, i18n.Errorf("запрос длиннее %d символов", chatSearchMaxQuery)
+	}
+	q := repository.ChatSearchQuery{
+		Text:       text,
+		SortByDate: p.Sort == "date",
+		Limit:      p.Limit,
+		Offset:     p.Offset,
+	}
+
+	if author := strings.TrimPrefix(strings.TrimSpace(p.Author), "@"); author != "" {
+		if id, err := strconv.ParseInt(author, 10, 64); err == nil {
+			q.AuthorID = id
+		} else {
+			q.AuthorUsername = author
+		}
+	}
+
+	loc := utils.MSKLocation()
+	if p.From != "" {
+		from, err := time.ParseInLocation("2006-01-02", p.From, loc)
+		if err != nil {
+			return repository.ChatSearchQuery{}, fmt.Errorf("дата from — в формате ГГГГ-ММ-ДД")
+		}
+		q.Since = from
+	}
+	if p.To != "" {
+		to, err := time.ParseInLocation("2006-01-02", p.To, loc)
+		if err != nil {
+			return repository.ChatSearchQuery{}, fmt.Errorf("дата to — в формате ГГГГ-ММ-ДД")
+		}
+		q.Until = to.AddDate(0, 0, 1)
+	}
+	if !q.Since.IsZero() && !q.Until.IsZero() && !q.Since.Before(q.Until) {
+		return repository.ChatSearchQuery{}, fmt.Errorf("дата from позже to")
+	}
+	return q, nil
+}
+
+// TelegramMessageLink — ссылка t.me/c/… на сообщение супергруппы (id вида
+// -100XXXXXXXXXX). Для обычных групп ссылок на сообщения нет — пустая
+// строка. В теме форума ссылка ведёт внутрь темы.
+func TelegramMessageLink(chatID int64, threadID *int, messageID int) string {
+	const supergroupPrefix = -1000000000000
+	if chatID > supergroupPrefix || messageID <= 0 {
+		return ""
+	}
+	internalID := -chatID + supergroupPrefix
+	if threadID != nil && *threadID != 0 {
+		return fmt.Sprintf("https://t.me/c/%d/%d/%d", internalID, *threadID, messageID)
+	}
+	return fmt.Sprintf("https://t.me/c/%d/%d", internalID, messageID)
+}
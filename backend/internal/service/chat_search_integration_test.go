@@ -0,0 +1,80 @@
+package service
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"ithozyeva/internal/models"
+	"ithozyeva/internal/testutil"
+)
+
+func TestChatSearchService_RespectsChatAccess(t *testing.T) {
+	db := testutil.EnsureTestDB(t)
+	subTablesTruncate(t, db)
+	testutil.TruncateAll(t, db, "chat_messages", "tracked_chats")
+
+	const (
+		userID       int64 = 7001
+		openChat     int64 = -1001000000001
+		revokedChat  int64 = -1001000000002
+		unlistedChat int64 = -1001000000003
+	)
+	master := mustTier(t, db, "master")
+	seedSubUser(t, db, userID, &master.ID, nil)
+	for _, id := range []int64{openChat, revokedChat, unlistedChat} {
+		seedSubChat(t, db, id, "chat", nil)
+		if err := db.Create(&models.TrackedChat{ChatID: id, Title: "chat", IsActive: true}).Error; err != nil {
+			t.Fatalf("seed tracked chat: %v", err)
+		}
+	}
+	revokedAt := time.Now()
+	for _, a := range []models.SubscriptionUserChatAccess{
+		{UserID: userID, ChatID: openChat},
+		{UserID: userID, ChatID: revokedChat, RevokedAt: &revokedAt},
+	} {
+		if err := db.Create(&a).Error; err != nil {
+			t.Fatalf("seed access: %v", err)
+		}
+	}
+
+	msgID := 100
+	for _, chatID := range []int64{openChat, revokedChat, unlistedChat} {
+		msgID++
+		id := msgID
+		if err := db.Create(&models.ChatMessage{
+			ChatID:            chatID,
+			TelegramUserID:    1,
+			TelegramUsername:  "author",
+			MessageText:       "Как настроить <docker> контейнеры для деплоя?",
+			TelegramMessageID: &id,
+			SentAt:            time.Now(),
+		}).Error; err != nil {
+			t.Fatalf("seed message: %v", err)
+		}
+	}
+
+	svc := NewChatSearchService()
+	// Русская морфология: «контейнер» находит «контейнеры».
+	q, err := BuildChatSearchQuery(ChatSearchParams{Query: "контейнер", Limit: 10})
+	if err != nil {
+		t.Fatalf("build: %v", err)
+	}
+	items, total, err := svc.Search(userID, 0, q)
+	if err != nil {
+		t.Fatalf("Search: %v", err)
+	}
+	if total != 1 || len(items) != 1 || items[0].ChatID != openChat {
+		t.Fatalf("only the accessible chat must match: total=%d items=%+v", total, items)
+	}
+	if items[0].Link != "https://t.me/c/1000000001/101" {
+		t.Errorf("link = %q", items[0].Link)
+	}
+	if !strings.Contains(items[0].Snippet, "<mark>") || strings.Contains(items[0].Snippet, "<docker>") {
+		t.Errorf("snippet must be escaped with marks: %q", items[0].Snippet)
+	}
+
+	if _, _, err := svc.Search(userID, revokedChat, q); err != ErrChatSearchForbidden {
+		t.Fatalf("revoked chat filter: err = %v, want ErrChatSearchForbidden", err)
+	}
+}
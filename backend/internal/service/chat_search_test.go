@@ -0,0 +1,57 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"ithozyeva/internal/utils"
+)
+
+func TestBuildChatSearchQuery(t *testing.T) {
+	q, err := BuildChatSearchQuery(ChatSearchParams{
+		Query:  "  docker compose ",
+		Author: "@Vasya",
+		From:   "2026-10-01",
+		To:     "2026-10-07",
+		Sort:   "date",
+		Limit:  20,
+	})
+	if err != nil {
+		t.Fatalf("build: %v", err)
+	}
+	if q.Text != "docker compose" || q.AuthorUsername != "Vasya" || q.AuthorID != 0 || !q.SortByDate {
+		t.Fatalf("got %+v", q)
+	}
+	if !q.Since.Equal(time.Date(2026, 10, 1, 0, 0, 0, 0, utils.MSKLocation())) ||
+		!q.Until.Equal(time.Date(2026, 10, 8, 0, 0, 0, 0, utils.MSKLocation())) {
+		t.Fatalf("range: %v..%v", q.Since, q.Until)
+	}
+
+	q, err = BuildChatSearchQuery(ChatSearchParams{Query: "го", Author: "12345"})
+	if err != nil || q.AuthorID != 12345 || q.AuthorUsername != "" {
+		t.Fatalf("author id: %+v, %v", q, err)
+	}
+
+	for name, p := range map[string]ChatSearchParams{
+		"short":    {Query: "a"},
+		"bad from": {Query: "docker", From: "01.10.2026"},
+		"reversed": {Query: "docker", From: "2026-10-07", To: "2026-10-01"},
+	} {
+		if _, err := BuildChatSearchQuery(p); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
+
+func TestTelegramMessageLink(t *testing.T) {
+	if got := TelegramMessageLink(-1001847344728, nil, 42); got != "https://t.me/c/1847344728/42" {
+		t.Fatalf("supergroup: %q", got)
+	}
+	thread := 7
+	if got := TelegramMessageLink(-1001847344728, &thread, 42); got != "https://t.me/c/1847344728/7/42" {
+		t.Fatalf("topic: %q", got)
+	}
+	if got := TelegramMessageLink(-4512345, nil, 42); got != "" {
+		t.Fatalf("basic group must have no link: %q", got)
+	}
+}
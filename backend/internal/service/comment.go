@@ -8,6 +8,7 @@ import (
 
 	"gorm.io/gorm"
 
+	"ithozyeva/internal/i18n"
 	"ithozyeva/internal/models"
 	"ithozyeva/internal/repository"
 )
@@ -46,7 +47,7 @@ func NewCommentService(checkers map[models.CommentEntityType]EntityVisibilityChe
 func (s *CommentService) ensureVisible(entityType models.CommentEntityType, entityID int64, member *models.Member) error {
 	checker, ok := s.checkers[entityType]
 	if !ok {
-		return fmt.Errorf("неизвестный тип сущности: %s", entityType)
+		return i18n.Errorf("неизвестный тип сущности: %s", entityType)
 	}
 	return checker(entityID, member)
 }
@@ -70,7 +71,7 @@ func (s *CommentService) Create(entityType models.CommentEntityType, entityID in
 	}
 	body = strings.TrimSpace(body)
 	if l := utf8.RuneCountInString(body); l < models.CommentMinLen || l > models.CommentMaxLen {
-		return nil, fmt.Errorf("длина комментария должна быть от %d до %d символов",
+		return nil, i18n.Errorf("длина комментария должна быть от %d до %d символов",
 			models.CommentMinLen, models.CommentMaxLen)
 	}
 	return s.repo.Create(&models.Comment{
@@ -94,7 +95,7 @@ func (s *CommentService) Update(commentID, memberID int64, body string, isAdmin
 	}
 	body = strings.TrimSpace(body)
 	if l := utf8.RuneCountInString(body); l < models.CommentMinLen || l > models.CommentMaxLen {
-		return nil, fmt.Errorf("длина комментария должна быть от %d до %d символов",
+		return nil, i18n.Errorf("длина комментария должна быть от %d до %d символов",
 			models.CommentMinLen, models.CommentMaxLen)
 	}
 	if err := s.repo.Update(commentID, body); err != nil {
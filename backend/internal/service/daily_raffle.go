@@ -42,6 +42,10 @@ func (s *DailyRaffleService) EnsureTodayRaffle() (*models.Raffle, error) {
 	}
 
 	endsAt := utils.MSKEndOfDay(day)
+	serverSeed, err := randomHex(32)
+	if err != nil {
+		return nil, err
+	}
 	raffle := &models.Raffle{
 		Title:       dailyRaffleTitle,
 		Description: dailyRaffleDescription,
@@ -53,6 +57,8 @@ func (s *DailyRaffleService) EnsureTodayRaffle() (*models.Raffle, error) {
 		Kind:        models.RaffleKindDaily,
 		EntryRule:   models.RaffleEntryRuleAutoCheckIn,
 		DayKey:      &day,
+		ServerSeed:  serverSeed,
+		SeedHash:    hashServerSeed(serverSeed),
 	}
 
 	// Используем raw INSERT с ON CONFLICT, чтобы UNIQUE-индекс
@@ -60,12 +66,13 @@ func (s *DailyRaffleService) EnsureTodayRaffle() (*models.Raffle, error) {
 	// и watchdog'ом. GORM Create на ON CONFLICT не предоставляет
 	// одной строкой через partial-unique.
 	res := database.DB.Exec(
-		`INSERT INTO raffles (title, description, prize, ticket_cost, max_tickets, ends_at, status, kind, entry_rule, day_key)
-		 VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		`INSERT INTO raffles (title, description, prize, ticket_cost, max_tickets, ends_at, status, kind, entry_rule, day_key, server_seed, seed_hash)
+		 VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 		 ON CONFLICT (day_key) WHERE kind = 'daily' DO NOTHING`,
 		raffle.Title, raffle.Description, raffle.Prize,
 		raffle.TicketCost, raffle.MaxTickets, raffle.EndsAt,
 		raffle.Status, raffle.Kind, raffle.EntryRule, raffle.DayKey,
+		raffle.ServerSeed, raffle.SeedHash,
 	)
 	if res.Error != nil {
 		return nil, res.Error
@@ -135,6 +142,7 @@ func (s *DailyRaffleService) GetTodayPublic(memberId int64) (*models.RafflePubli
 		TotalTickets: int(total),
 		MyTickets:    int(myTickets),
 		WinnerId:     raffle.WinnerId,
+		SeedHash:     raffle.SeedHash,
 		MySources:    mySources,
 	}
 	return pub, nil
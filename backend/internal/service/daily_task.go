@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"ithozyeva/database"
+	"ithozyeva/internal/i18n"
 	"ithozyeva/internal/models"
 	"ithozyeva/internal/repository"
 	"ithozyeva/internal/utils"
@@ -76,7 +77,7 @@ func (s *DailyTaskService) GenerateTodaySet(day time.Time) error {
 		return fmt.Errorf("get active tasks: %w", err)
 	}
 	if len(tasks) < dailyTasksPerDay {
-		return fmt.Errorf("в пуле меньше %d активных задач", dailyTasksPerDay)
+		return i18n.Errorf("в пуле меньше %d активных задач", dailyTasksPerDay)
 	}
 
 	// Группировка по tier для квот.
@@ -0,0 +1,272 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"html"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"ithozyeva/internal/i18n"
+	"ithozyeva/internal/models"
+	"ithozyeva/internal/repository"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Пределы триггеров автоответчика.
+const (
+	faqNameMax         = 100
+	faqPatternMax      = 1000
+	faqResponseMax     = 4000 // лимит сообщения Telegram — 4096
+	faqMaxCooldown     = 24 * time.Hour
+	faqDefaultCooldown = 5 * time.Minute
+	// Похожесть: доля значимых слов образца, найденных в сообщении.
+	faqSimilarityMinScore = 0.7
+)
+
+// ErrFAQTriggerNotFound — триггера нет.
+var ErrFAQTriggerNotFound = errors.New("триггер не найден")
+
+// FAQValidationError — некорректный триггер (текст — для пользователя).
+type FAQValidationError struct{ i18n.Message }
+
+func (e *FAQValidationError) Error() string { return e.String() }
+
+func faqInvalid(format string, args ...interface{}) error {
+	return &FAQValidationError{i18n.Msg(format, args...)}
+}
+
+// FAQMatcher — скомпилированный триггер.
+type FAQMatcher struct {
+	Trigger models.FAQTrigger
+	re      *regexp.Regexp
+	phrases [][]string // keywords: слова каждой фразы
+}
+
+// CompileFAQTrigger проверяет триггер и готовит его к сопоставлению.
+func CompileFAQTrigger(t models.FAQTrigger) (*FAQMatcher, error) {
+	m := &FAQMatcher{Trigger: t}
+	switch t.MatchType {
+	case models.FAQMatchRegex:
+		re, err := regexp.Compile("(?i)" + t.Pattern)
+		if err != nil {
+			return nil, faqInvalid("некорректная регулярка: %v", err)
+		}
+		m.re = re
+	case models.FAQMatchKeywords, models.FAQMatchSimilarity:
+		for _, line := range strings.FieldsFunc(t.Pattern, func(r rune) bool {
+			// В keywords фразы можно перечислять и через запятую.
+			return r == '\n' || (r == ',' && t.MatchType == models.FAQMatchKeywords)
+		}) {
+			if strings.TrimSpace(line) == "" {
+				continue
+			}
+			words := QuestionWords(line)
+			if len(words) == 0 {
+				return nil, faqInvalid("во фразе %q нет значимых слов", strings.TrimSpace(line))
+			}
+			m.phrases = append(m.phrases, words)
+		}
+		if len(m.phrases) == 0 {
+			return nil, faqInvalid("укажите хотя бы одну фразу")
+		}
+	default:
+		return nil, faqInvalid("тип срабатывания — keywords, regex или similarity")
+	}
+	return m, nil
+}
+
+// Match — срабатывает ли триггер на текст.
+func (m *FAQMatcher) Match(text string) bool {
+	if m.re != nil {
+		return m.re.MatchString(text)
+	}
+	msgStems := stems(QuestionWords(text))
+	for _, words := range m.phrases {
+		matched := countStemMatches(words, msgStems)
+		switch m.Trigger.MatchType {
+		case models.FAQMatchKeywords:
+			if matched == len(words) {
+				return true
+			}
+		case models.FAQMatchSimilarity:
+			// Короткий образец из одного слова — только полное совпадение.
+			need := 2
+			if len(words) < need {
+				need = len(words)
+			}
+			if matched >= need && float64(matched)/float64(len(words)) >= faqSimilarityMinScore {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// AppliesTo — действует ли триггер в чате.
+func (m *FAQMatcher) AppliesTo(chatID int64) bool {
+	return m.Trigger.Enabled && (m.Trigger.ChatID == 0 || m.Trigger.ChatID == chatID)
+}
+
+// CompileFAQTriggers компилирует триггеры в порядке проверки: приоритет,
+// затем триггеры конкретного чата раньше общих. Битые пропускаются.
+func CompileFAQTriggers(triggers []models.FAQTrigger) []*FAQMatcher {
+	out := make([]*FAQMatcher, 0, len(triggers))
+	for _, t := range triggers {
+		if m, err := CompileFAQTrigger(t); err == nil {
+			out = append(out, m)
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		a, b := out[i].Trigger, out[j].Trigger
+		if a.Priority != b.Priority {
+			return a.Priority > b.Priority
+		}
+		return a.ChatID != 0 && b.ChatID == 0
+	})
+	return out
+}
+
+// MatchFAQ — первый сработавший в чате триггер или nil.
+func MatchFAQ(matchers []*FAQMatcher, chatID int64, text string) *FAQMatcher {
+	for _, m := range matchers {
+		if m.AppliesTo(chatID) && m.Match(text) {
+			return m
+		}
+	}
+	return nil
+}
+
+// RenderFAQResponse подставляет {name} и {chat} (экранируя их — ответ
+// отправляется как HTML).
+func RenderFAQResponse(tmpl, name, chat string) string {
+	return strings.NewReplacer(
+		"{name}", html.EscapeString(name),
+		"{chat}", html.EscapeString(chat),
+	).Replace(tmpl)
+}
+
+// NormalizeFAQTrigger чистит поля и проверяет их.
+func NormalizeFAQTrigger(t *models.FAQTrigger) error {
+	t.Name = strings.TrimSpace(t.Name)
+	t.Pattern = strings.TrimSpace(t.Pattern)
+	t.Response = strings.TrimSpace(t.Response)
+	switch {
+	case t.Name == "" || utf8.RuneCountInString(t.Name) > faqNameMax:
+		return faqInvalid("название — от 1 до %d символов", faqNameMax)
+	case t.Pattern == "" || utf8.RuneCountInString(t.Pattern) > faqPatternMax:
+		return faqInvalid("шаблон срабатывания — от 1 до %d символов", faqPatternMax)
+	case t.Response == "" || utf8.RuneCountInString(t.Response) > faqResponseMax:
+		return faqInvalid("ответ — от 1 до %d символов", faqResponseMax)
+	case t.CooldownSeconds < 0 || t.CooldownSeconds > int(faqMaxCooldown.Seconds()):
+		return faqInvalid("кулдаун — от 0 до %d секунд", int(faqMaxCooldown.Seconds()))
+	}
+	if t.CooldownSeconds == 0 {
+		t.CooldownSeconds = int(faqDefaultCooldown.Seconds())
+	}
+	_, err := CompileFAQTrigger(*t)
+	return err
+}
+
+type FAQService struct {
+	repo  *repository.FAQRepository
+	redis *redis.Client
+}
+
+func NewFAQService(redisClient *redis.Client) *FAQService {
+	return &FAQService{repo: repository.NewFAQRepository(), redis: redisClient}
+}
+
+// List — триггеры для админки (chatID=0 — все).
+func (s *FAQService) List(chatID int64) ([]models.FAQTrigger, error) {
+	return s.repo.List(chatID)
+}
+
+// LoadMatchers — включённые триггеры, готовые к проверке (для бота).
+func (s *FAQService) LoadMatchers() ([]*FAQMatcher, error) {
+	triggers, err := s.repo.ListEnabled()
+	if err != nil {
+		return nil, err
+	}
+	return CompileFAQTriggers(triggers), nil
+}
+
+// Create — новый триггер от имени memberID.
+func (s *FAQService) Create(t *models.FAQTrigger, memberID int64) error {
+	if err := NormalizeFAQTrigger(t); err != nil {
+		return err
+	}
+	t.Id = 0
+	t.CreatedBy, t.UpdatedBy = memberID, memberID
+	return s.repo.Create(t)
+}
+
+// Update — правка триггера id.
+func (s *FAQService) Update(id int64, t *models.FAQTrigger, memberID int64) (*models.FAQTrigger, error) {
+	if err := NormalizeFAQTrigger(t); err != nil {
+		return nil, err
+	}
+	t.Id = id
+	t.UpdatedBy = memberID
+	found, err := s.repo.Update(t)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, ErrFAQTriggerNotFound
+	}
+	return s.repo.GetByID(id)
+}
+
+// Delete удаляет триггер.
+func (s *FAQService) Delete(id int64) error {
+	found, err := s.repo.Delete(id)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return ErrFAQTriggerNotFound
+	}
+	return nil
+}
+
+// Stats — срабатывания за последние days дней.
+func (s *FAQService) Stats(days int) ([]models.FAQTriggerStats, error) {
+	return s.repo.Stats(time.Now().AddDate(0, 0, -days))
+}
+
+// TestMatch — какой триггер ответил бы на text в чате (проверка из админки).
+func (s *FAQService) TestMatch(chatID int64, text string) (*models.FAQTrigger, error) {
+	matchers, err := s.LoadMatchers()
+	if err != nil {
+		return nil, err
+	}
+	if m := MatchFAQ(matchers, chatID, text); m != nil {
+		return &m.Trigger, nil
+	}
+	return nil, nil
+}
+
+func faqCooldownKey(triggerID, chatID int64) string {
+	return "faq:cooldown:" + strconv.FormatInt(triggerID, 10) + ":" + strconv.FormatInt(chatID, 10)
+}
+
+// TryStartCooldown — можно ли ответить триггером в чате сейчас; при true
+// кулдаун запущен. Ключ в Redis, чтобы реплики бота не ответили дважды.
+func (s *FAQService) TryStartCooldown(ctx context.Context, t *models.FAQTrigger, chatID int64) (bool, error) {
+	cooldown := time.Duration(t.CooldownSeconds) * time.Second
+	if cooldown <= 0 {
+		cooldown = faqDefaultCooldown
+	}
+	return s.redis.SetNX(ctx, faqCooldownKey(t.Id, chatID), 1, cooldown).Result()
+}
+
+// RecordHit — учёт срабатывания.
+func (s *FAQService) RecordHit(triggerID, chatID, userID int64, messageID int) error {
+	return s.repo.RecordHit(&models.FAQHit{TriggerID: triggerID, ChatID: chatID, UserID: userID, MessageID: messageID})
+}
@@ -0,0 +1,103 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"ithozyeva/internal/models"
+)
+
+func TestNormalizeFAQTrigger(t *testing.T) {
+	tr := models.FAQTrigger{
+		Name:      "  Доступ в чаты ",
+		MatchType: models.FAQMatchKeywords,
+		Pattern:   " доступ чат, подписка ",
+		Response:  " Оформите подписку ",
+	}
+	if err := NormalizeFAQTrigger(&tr); err != nil {
+		t.Fatalf("normalize: %v", err)
+	}
+	if tr.Name != "Доступ в чаты" || tr.Response != "Оформите подписку" {
+		t.Fatalf("not trimmed: %+v", tr)
+	}
+	if tr.CooldownSeconds != int(faqDefaultCooldown.Seconds()) {
+		t.Fatalf("default cooldown = %d", tr.CooldownSeconds)
+	}
+
+	for name, bad := range map[string]models.FAQTrigger{
+		"unknown type":  {Name: "a", MatchType: "exact", Pattern: "p", Response: "r"},
+		"bad regex":     {Name: "a", MatchType: models.FAQMatchRegex, Pattern: "(", Response: "r"},
+		"stopwords":     {Name: "a", MatchType: models.FAQMatchKeywords, Pattern: "как и что", Response: "r"},
+		"empty reply":   {Name: "a", MatchType: models.FAQMatchRegex, Pattern: "x", Response: " "},
+		"long cooldown": {Name: "a", MatchType: models.FAQMatchRegex, Pattern: "x", Response: "r", CooldownSeconds: 86401},
+	} {
+		err := NormalizeFAQTrigger(&bad)
+		var v *FAQValidationError
+		if !errors.As(err, &v) {
+			t.Errorf("%s: expected validation error, got %v", name, err)
+		}
+	}
+}
+
+func TestFAQMatcher(t *testing.T) {
+	cases := []struct {
+		name    string
+		trigger models.FAQTrigger
+		text    string
+		want    bool
+	}{
+		{"keywords all words", models.FAQTrigger{MatchType: models.FAQMatchKeywords, Pattern: "доступ чат"},
+			"Как получить доступ в закрытые чаты?", true},
+		{"keywords missing word", models.FAQTrigger{MatchType: models.FAQMatchKeywords, Pattern: "доступ чат"},
+			"Как получить доступ к курсу?", false},
+		{"keywords second phrase", models.FAQTrigger{MatchType: models.FAQMatchKeywords, Pattern: "доступ чат\nоплата картой"},
+			"Проходит ли оплата картой МИР?", true},
+		{"regex case-insensitive", models.FAQTrigger{MatchType: models.FAQMatchRegex, Pattern: `\bзум\b|zoom`},
+			"Где ссылка на ZOOM?", true},
+		{"regex no match", models.FAQTrigger{MatchType: models.FAQMatchRegex, Pattern: `^!правила$`},
+			"расскажите правила", false},
+		{"similarity close", models.FAQTrigger{MatchType: models.FAQMatchSimilarity, Pattern: "где найти запись прошлого митапа"},
+			"подскажите, где запись митапа прошлого?", true},
+		{"similarity far", models.FAQTrigger{MatchType: models.FAQMatchSimilarity, Pattern: "где найти запись прошлого митапа"},
+			"когда следующий митап?", false},
+	}
+	for _, c := range cases {
+		m, err := CompileFAQTrigger(c.trigger)
+		if err != nil {
+			t.Fatalf("%s: compile: %v", c.name, err)
+		}
+		if got := m.Match(c.text); got != c.want {
+			t.Errorf("%s: Match(%q) = %v, want %v", c.name, c.text, got, c.want)
+		}
+	}
+}
+
+func TestMatchFAQOrder(t *testing.T) {
+	matchers := CompileFAQTriggers([]models.FAQTrigger{
+		{Id: 1, Enabled: true, MatchType: models.FAQMatchRegex, Pattern: "правила"},
+		{Id: 2, Enabled: true, ChatID: 42, MatchType: models.FAQMatchRegex, Pattern: "правила"},
+		{Id: 3, Enabled: true, Priority: 10, ChatID: 7, MatchType: models.FAQMatchRegex, Pattern: "правила"},
+		{Id: 4, Enabled: false, Priority: 100, MatchType: models.FAQMatchRegex, Pattern: "правила"},
+		{Id: 5, Enabled: true, MatchType: models.FAQMatchRegex, Pattern: "("},
+	})
+	if len(matchers) != 4 {
+		t.Fatalf("broken trigger must be skipped, got %d matchers", len(matchers))
+	}
+	for chatID, want := range map[int64]int64{42: 2, 7: 3, 100: 1} {
+		m := MatchFAQ(matchers, chatID, "где правила?")
+		if m == nil || m.Trigger.Id != want {
+			t.Errorf("chat %d: got %+v, want trigger %d", chatID, m, want)
+		}
+	}
+	if m := MatchFAQ(matchers, 42, "привет"); m != nil {
+		t.Errorf("unexpected match %d", m.Trigger.Id)
+	}
+}
+
+func TestRenderFAQResponse(t *testing.T) {
+	got := RenderFAQResponse("Привет, {name}! Добро пожаловать в <b>{chat}</b>", "<Вася>", "Go & Rust")
+	want := "Привет, &lt;Вася&gt;! Добро пожаловать в <b>Go &amp; Rust</b>"
+	if got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+}
@@ -2,6 +2,7 @@ package service
 
 import (
 	"fmt"
+	"ithozyeva/internal/i18n"
 	"ithozyeva/internal/models"
 	"ithozyeva/internal/repository"
 	"strings"
@@ -41,7 +42,7 @@ func (s *FeedbackService) Create(member *models.Member, req models.CreateFeedbac
 	if req.Comment != nil {
 		trimmed := strings.TrimSpace(*req.Comment)
 		if len(trimmed) > maxFeedbackCommentLen {
-			return nil, fmt.Errorf("комментарий слишком длинный (макс. %d символов)", maxFeedbackCommentLen)
+			return nil, i18n.Errorf("комментарий слишком длинный (макс. %d символов)", maxFeedbackCommentLen)
 		}
 		if trimmed != "" {
 			feedback.Comment = &trimmed
@@ -58,11 +58,12 @@ func resolvePushTarget(memberId int64) (*pushTarget, error) {
 	if err := database.DB.Where("member_id = ?", memberId).First(&ns).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			ns = models.NotificationSettings{
-				MemberId:     memberId,
-				DailyMorning: true,
-				DailyEvening: true,
-				DailyStreak:  true,
-				DailyRaffle:  true,
+				MemberId:         memberId,
+				DailyMorning:     true,
+				DailyEvening:     true,
+				DailyStreak:      true,
+				DailyRaffle:      true,
+				MonthlyStatement: true,
 			}
 		} else {
 			return nil, fmt.Errorf("get notification_settings: %w", err)
@@ -0,0 +1,286 @@
+package service
+
+import (
+	"errors"
+	"log"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"ithozyeva/internal/i18n"
+	"ithozyeva/internal/models"
+	"ithozyeva/internal/repository"
+)
+
+const (
+	kbTitleMin          = 3
+	kbTitleMax          = 200
+	kbAnswerMax         = 10000
+	kbMaxHighlights     = 20
+	kbSuggestCandidates = 5
+	kbStemRunes         = 5 // грубый стемминг: общий префикс словоформ
+	// Подсказка — если статья покрывает не меньше kbSuggestMinScore значимых
+	// слов вопроса и совпало хотя бы kbSuggestMinWords.
+	kbSuggestMinScore = 0.6
+	kbSuggestMinWords = 2
+)
+
+// ErrKBEntryNotFound — статьи нет.
+var ErrKBEntryNotFound = errors.New("статья не найдена")
+
+// KBValidationError — запрос некорректен (текст — для пользователя): не
+// прошёл ValidateKBEntryRequest или ссылается на несуществующие хайлайты/теги.
+type KBValidationError struct{ i18n.Message }
+
+func (e *KBValidationError) Error() string { return e.String() }
+
+type KnowledgeBaseService struct {
+	repo *repository.KnowledgeBaseRepository
+}
+
+func NewKnowledgeBaseService() *KnowledgeBaseService {
+	return &KnowledgeBaseService{repo: repository.NewKnowledgeBaseRepository()}
+}
+
+// ValidateKBEntryRequest нормализует и проверяет запрос. Текст ошибки — для
+// пользователя.
+func ValidateKBEntryRequest(req *models.KBEntryRequest) error {
+	req.Title = strings.TrimSpace(req.Title)
+	req.Question = strings.TrimSpace(req.Question)
+	req.Answer = strings.TrimSpace(req.Answer)
+	req.HighlightIDs = uniqueIDs(req.HighlightIDs)
+	req.TagIDs = uniqueIDs(req.TagIDs)
+	switch n := utf8.RuneCountInString(req.Title); {
+	case n < kbTitleMin || n > kbTitleMax:
+		return i18n.Errorf("заголовок — от %d до %d символов", kbTitleMin, kbTitleMax)
+	}
+	switch {
+	case req.Answer == "":
+		return i18n.Errorf("ответ не может быть пустым")
+	case utf8.RuneCountInString(req.Answer) > kbAnswerMax:
+		return i18n.Errorf("ответ длиннее %d символов", kbAnswerMax)
+	case len(req.HighlightIDs) == 0:
+		return i18n.Errorf("выберите хотя бы один хайлайт")
+	case len(req.HighlightIDs) > kbMaxHighlights:
+		return i18n.Errorf("не больше %d хайлайтов в статье", kbMaxHighlights)
+	}
+	return nil
+}
+
+func uniqueIDs(ids []int64) []int64 {
+	seen := make(map[int64]bool, len(ids))
+	out := make([]int64, 0, len(ids))
+	for _, id := range ids {
+		if id > 0 && !seen[id] {
+			seen[id] = true
+			out = append(out, id)
+		}
+	}
+	return out
+}
+
+// Get — статья или ErrKBEntryNotFound.
+func (s *KnowledgeBaseService) Get(id int64) (*models.KBEntry, error) {
+	e, err := s.repo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if e == nil {
+		return nil, ErrKBEntryNotFound
+	}
+	return e, nil
+}
+
+// Search — поиск по базе знаний (tagID=0 — все теги).
+func (s *KnowledgeBaseService) Search(text string, tagID int64, limit, offset int) ([]models.KBEntry, int64, error) {
+	return s.repo.Search(strings.TrimSpace(text), tagID, limit, offset)
+}
+
+// Create создаёт статью от имени модератора memberID.
+func (s *KnowledgeBaseService) Create(req models.KBEntryRequest, memberID int64) (*models.KBEntry, error) {
+	if err := s.validateRefs(&req); err != nil {
+		return nil, err
+	}
+	e := &models.KBEntry{
+		Title:     req.Title,
+		Question:  req.Question,
+		Answer:    req.Answer,
+		CreatedBy: memberID,
+		UpdatedBy: memberID,
+	}
+	if _, err := s.repo.Save(e, req.HighlightIDs, req.TagIDs); err != nil {
+		return nil, err
+	}
+	return s.repo.GetByID(e.Id)
+}
+
+// Update редактирует статью и заменяет её хайлайты и теги.
+func (s *KnowledgeBaseService) Update(id int64, req models.KBEntryRequest, memberID int64) (*models.KBEntry, error) {
+	if err := s.validateRefs(&req); err != nil {
+		return nil, err
+	}
+	e := &models.KBEntry{
+		Id:        id,
+		Title:     req.Title,
+		Question:  req.Question,
+		Answer:    req.Answer,
+		UpdatedBy: memberID,
+	}
+	found, err := s.repo.Save(e, req.HighlightIDs, req.TagIDs)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, ErrKBEntryNotFound
+	}
+	return s.repo.GetByID(id)
+}
+
+// Delete удаляет статью.
+func (s *KnowledgeBaseService) Delete(id int64) error {
+	found, err := s.repo.Delete(id)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return ErrKBEntryNotFound
+	}
+	return nil
+}
+
+// validateRefs — валидация + проверка, что хайлайты и теги существуют.
+// Пустой вопрос заполняется текстом самого раннего хайлайта.
+func (s *KnowledgeBaseService) validateRefs(req *models.KBEntryRequest) error {
+	if err := ValidateKBEntryRequest(req); err != nil {
+		return &KBValidationError{i18n.MessageOf(err)}
+	}
+	n, err := s.repo.CountExisting(models.ChatHighlight{}.TableName(), req.HighlightIDs)
+	if err != nil {
+		return err
+	}
+	if int(n) != len(req.HighlightIDs) {
+		return &KBValidationError{i18n.Msg("часть хайлайтов не найдена")}
+	}
+	n, err = s.repo.CountExisting(models.ProfTag{}.TableName(), req.TagIDs)
+	if err != nil {
+		return err
+	}
+	if int(n) != len(req.TagIDs) {
+		return &KBValidationError{i18n.Msg("часть тегов не найдена")}
+	}
+	if req.Question == "" {
+		req.Question, err = s.repo.FirstHighlightText(req.HighlightIDs)
+	}
+	return err
+}
+
+// --- Подсказки в чате ---
+
+// kbStopWords — частые слова вопросов, не несущие темы.
+var kbStopWords = map[string]bool{
+	"как": true, "что": true, "где": true, "кто": true, "это": true, "для": true,
+	"или": true, "при": true, "все": true, "всем": true, "так": true, "уже": true,
+	"если": true, "есть": true, "мне": true, "чем": true, "его": true, "они": true,
+	"она": true, "оно": true, "вас": true, "нас": true, "там": true, "тут": true,
+	"когда": true, "можно": true, "нужно": true, "какой": true, "какие": true,
+	"какая": true, "какое": true, "подскажите": true, "ребята": true, "привет": true,
+	"кто-нибудь": true, "почему": true, "зачем": true, "куда": true, "надо": true,
+	"the": true, "and": true, "how": true, "what": true, "can": true, "for": true,
+	"you": true, "with": true, "are": true, "does": true, "any": true, "anyone": true,
+}
+
+// QuestionWords — значимые слова текста: нижний регистр, без стоп-слов и
+// слов короче трёх букв. Только буквы/цифры — безопасно для to_tsquery.
+func QuestionWords(text string) []string {
+	fields := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '-'
+	})
+	seen := make(map[string]bool, len(fields))
+	words := make([]string, 0, len(fields))
+	for _, f := range fields {
+		f = strings.Trim(f, "-")
+		if utf8.RuneCountInString(f) < 3 || kbStopWords[f] || seen[f] {
+			continue
+		}
+		seen[f] = true
+		words = append(words, strings.ReplaceAll(f, "-", ""))
+	}
+	return words
+}
+
+func stem(word string) string {
+	r := []rune(word)
+	if len(r) > kbStemRunes {
+		r = r[:kbStemRunes]
+	}
+	return string(r)
+}
+
+// QuestionMatchScore — доля значимых слов вопроса, встречающихся в
+// заголовке или вопросе статьи, и число совпавших слов.
+func QuestionMatchScore(questionWords []string, e *models.KBEntry) (float64, int) {
+	if len(questionWords) == 0 {
+		return 0, 0
+	}
+	matched := countStemMatches(questionWords, stems(QuestionWords(e.Title+" "+e.Question)))
+	return float64(matched) / float64(len(questionWords)), matched
+}
+
+func stems(words []string) []string {
+	out := make([]string, 0, len(words))
+	for _, w := range words {
+		out = append(out, stem(w))
+	}
+	return out
+}
+
+// countStemMatches — сколько слов words встречается среди основ haystack.
+// Слова сравниваются по основе: одна обрезанная основа — префикс другой
+// («чат» ~ «чаты»).
+func countStemMatches(words, haystack []string) int {
+	matched := 0
+	for _, w := range words {
+		ws := stem(w)
+		for _, hs := range haystack {
+			if strings.HasPrefix(hs, ws) || strings.HasPrefix(ws, hs) {
+				matched++
+				break
+			}
+		}
+	}
+	return matched
+}
+
+// LooksLikeQuestion — сообщение похоже на вопрос, который стоит сверить с
+// базой: есть «?» и хотя бы kbSuggestMinWords значимых слова.
+func LooksLikeQuestion(text string) bool {
+	return strings.Contains(text, "?") && len(QuestionWords(text)) >= kbSuggestMinWords
+}
+
+// SuggestForQuestion — лучшая статья для вопроса из чата или nil.
+func (s *KnowledgeBaseService) SuggestForQuestion(text string) (*models.KBEntry, error) {
+	words := QuestionWords(text)
+	if len(words) < kbSuggestMinWords {
+		return nil, nil
+	}
+	candidates, err := s.repo.FindCandidates(words, kbSuggestCandidates)
+	if err != nil {
+		return nil, err
+	}
+	var best *models.KBEntry
+	bestScore := 0.0
+	for i := range candidates {
+		score, matched := QuestionMatchScore(words, &candidates[i])
+		if score >= kbSuggestMinScore && matched >= kbSuggestMinWords && score > bestScore {
+			best, bestScore = &candidates[i], score
+		}
+	}
+	return best, nil
+}
+
+// MarkSuggested — учёт подсказки (ошибка только логируется).
+func (s *KnowledgeBaseService) MarkSuggested(id int64) {
+	if err := s.repo.IncrementSuggested(id); err != nil {
+		log.Printf("kb: increment suggested id=%d: %v", id, err)
+	}
+}
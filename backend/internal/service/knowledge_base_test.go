@@ -0,0 +1,74 @@
+package service
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"ithozyeva/internal/models"
+)
+
+func TestValidateKBEntryRequest(t *testing.T) {
+	req := models.KBEntryRequest{
+		Title:        "  Доступ в закрытые чаты ",
+		Answer:       " Оформите подписку ",
+		HighlightIDs: []int64{3, 3, 0, 5},
+		TagIDs:       []int64{1, 1},
+	}
+	if err := ValidateKBEntryRequest(&req); err != nil {
+		t.Fatalf("validate: %v", err)
+	}
+	if req.Title != "Доступ в закрытые чаты" || req.Answer != "Оформите подписку" {
+		t.Fatalf("not trimmed: %+v", req)
+	}
+	if !reflect.DeepEqual(req.HighlightIDs, []int64{3, 5}) || !reflect.DeepEqual(req.TagIDs, []int64{1}) {
+		t.Fatalf("ids not deduplicated: %+v", req)
+	}
+
+	for name, r := range map[string]models.KBEntryRequest{
+		"short title":   {Title: "ok", Answer: "a", HighlightIDs: []int64{1}},
+		"empty answer":  {Title: "Заголовок", Answer: "  ", HighlightIDs: []int64{1}},
+		"no highlights": {Title: "Заголовок", Answer: "a"},
+		"long answer":   {Title: "Заголовок", Answer: strings.Repeat("я", kbAnswerMax+1), HighlightIDs: []int64{1}},
+	} {
+		if err := ValidateKBEntryRequest(&r); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
+
+func TestQuestionWords(t *testing.T) {
+	got := QuestionWords("Привет! Как попасть в закрытые чаты, кто-нибудь знает? Чаты закрытые?")
+	want := []string{"попасть", "закрытые", "чаты", "знает"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+}
+
+func TestQuestionMatchScore(t *testing.T) {
+	entry := &models.KBEntry{Title: "Как попасть в закрытые чаты", Question: "Где взять доступ к закрытому чату?"}
+
+	// Другие словоформы («закрытый чат», «доступа») совпадают по основе.
+	score, matched := QuestionMatchScore(QuestionWords("подскажите, где получить доступ в закрытый чат?"), entry)
+	if matched != 3 || score < kbSuggestMinScore {
+		t.Fatalf("similar question: score=%.2f matched=%d", score, matched)
+	}
+
+	score, _ = QuestionMatchScore(QuestionWords("какой ноутбук купить для разработки?"), entry)
+	if score >= kbSuggestMinScore {
+		t.Fatalf("unrelated question matched: %.2f", score)
+	}
+}
+
+func TestLooksLikeQuestion(t *testing.T) {
+	cases := map[string]bool{
+		"как попасть в закрытые чаты?": true,
+		"что?": false,
+		"попасть в закрытые чаты": false,
+	}
+	for text, want := range cases {
+		if got := LooksLikeQuestion(text); got != want {
+			t.Errorf("%q: got %v", text, got)
+		}
+	}
+}
@@ -0,0 +1,304 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"ithozyeva/database"
+	"ithozyeva/internal/models"
+	"ithozyeva/internal/repository"
+	"ithozyeva/internal/utils"
+
+	"gorm.io/gorm"
+)
+
+const (
+	// leaderboardSnapshotSize — сколько мест замораживаем в снапшоте.
+	leaderboardSnapshotSize = 50
+	// leaderboardPodium — места, которые попадают в историю профиля даже
+	// без приза.
+	leaderboardPodium = 3
+	// leaderboardFinalizeGrace — снапшот делаем через час после конца
+	// периода: фоновые начисления за последние минуты успевают лечь.
+	leaderboardFinalizeGrace = time.Hour
+	// leaderboardCatchUpMax — сколько периодов одного вида FinalizeEnded
+	// догоняет за прогон после простоя; остальные — на следующих.
+	leaderboardCatchUpMax = 12
+	leaderboardHistoryMax = 20
+	leaderboardHistoryTop = 10
+)
+
+// defaultLeaderboardExcluded — reason'ы вне зачёта, если в app_settings
+// нет leaderboard_excluded_reasons: игры и траты.
+var defaultLeaderboardExcluded = []string{
+	string(models.PointReasonCasinoBet),
+	string(models.PointReasonCasinoWin),
+	string(models.PointReasonCasinoJackpot),
+	string(models.PointReasonRaffleSpend),
+	string(models.PointReasonShopPurchase),
+	string(models.PointReasonShopRefund),
+	string(models.PointReasonStreakFreeze),
+	string(models.PointReasonStreakRepair),
+}
+
+var ErrUnknownLeaderboardPeriod = errors.New("неизвестный период рейтинга")
+
+// LeaderboardPeriodAt — период вида period (неделя ISO, месяц, квартал по
+// МСК), в который попадает t.
+func LeaderboardPeriodAt(period string, t time.Time) (models.LeaderboardPeriod, error) {
+	t = t.In(utils.MSKLocation())
+	p := models.LeaderboardPeriod{Period: period}
+	switch period {
+	case models.LeaderboardWeekly:
+		p.StartsAt = startOfISOWeekMSK(t)
+		p.EndsAt = p.StartsAt.AddDate(0, 0, 7)
+		year, week := t.ISOWeek()
+		p.PeriodKey = fmt.Sprintf("%d-W%02d", year, week)
+	case models.LeaderboardMonthly:
+		p.StartsAt = time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, utils.MSKLocation())
+		p.EndsAt = p.StartsAt.AddDate(0, 1, 0)
+		p.PeriodKey = fmt.Sprintf("%d-%02d", t.Year(), int(t.Month()))
+	case models.LeaderboardQuarterly:
+		quarter := (int(t.Month()) - 1) / 3
+		p.StartsAt = time.Date(t.Year(), time.Month(quarter*3+1), 1, 0, 0, 0, 0, utils.MSKLocation())
+		p.EndsAt = p.StartsAt.AddDate(0, 3, 0)
+		p.PeriodKey = fmt.Sprintf("%d-Q%d", t.Year(), quarter+1)
+	default:
+		return p, ErrUnknownLeaderboardPeriod
+	}
+	return p, nil
+}
+
+// LeaderboardService — рейтинги за неделю, месяц и квартал. Текущий период
+// считается из point_transactions на лету, завершённые замораживаются в
+// снапшоты с призами топу.
+type LeaderboardService struct {
+	repo      *repository.LeaderboardRepository
+	pointsSvc *PointsService
+	settings  *AppSettingsService
+}
+
+func NewLeaderboardService() *LeaderboardService {
+	return &LeaderboardService{
+		repo:      repository.NewLeaderboardRepository(),
+		pointsSvc: NewPointsService(),
+		settings:  NewAppSettingsService(),
+	}
+}
+
+// excludedReasons — leaderboard_excluded_reasons из настроек; сами призы
+// в зачёт не идут никогда, иначе победитель получает фору на следующий период.
+func (s *LeaderboardService) excludedReasons() []string {
+	var reasons []string
+	if !s.settings.GetJSON("leaderboard_excluded_reasons", &reasons) {
+		reasons = append(reasons, defaultLeaderboardExcluded...)
+	}
+	return append(reasons, string(models.PointReasonLeaderboardPrize))
+}
+
+// prizes — баллы за места 1..N (leaderboard_prizes_<period>).
+func (s *LeaderboardService) prizes(period string) []int {
+	var prizes []int
+	if !s.settings.GetJSON("leaderboard_prizes_"+period, &prizes) {
+		return []int{}
+	}
+	out := make([]int, 0, len(prizes))
+	for _, p := range prizes {
+		if p < 0 {
+			p = 0
+		}
+		out = append(out, p)
+	}
+	return out
+}
+
+// Current — рейтинг текущего периода и место участника memberId.
+func (s *LeaderboardService) Current(period string, memberId int64, limit int) (*models.LeaderboardResponse, error) {
+	if limit <= 0 || limit > 100 {
+		limit = 20
+	}
+	p, err := LeaderboardPeriodAt(period, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	excluded := s.excludedReasons()
+	items, err := s.repo.Ranking(p.StartsAt, p.EndsAt, excluded, limit)
+	if err != nil {
+		return nil, err
+	}
+	resp := &models.LeaderboardResponse{LeaderboardPeriod: p, Items: items, Prizes: s.prizes(period)}
+	if memberId != 0 {
+		if resp.Me, err = s.repo.MemberRank(p.StartsAt, p.EndsAt, excluded, memberId); err != nil {
+			return nil, err
+		}
+	}
+	return resp, nil
+}
+
+// History — прошлые периоды с топом каждого, новые первыми.
+func (s *LeaderboardService) History(period string, limit int) ([]models.LeaderboardSnapshot, error) {
+	if _, err := LeaderboardPeriodAt(period, time.Now()); err != nil {
+		return nil, err
+	}
+	if limit <= 0 || limit > leaderboardHistoryMax {
+		limit = leaderboardHistoryMax
+	}
+	snaps, err := s.repo.ListSnapshots(period, limit)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]int64, 0, len(snaps))
+	for _, snap := range snaps {
+		ids = append(ids, snap.Id)
+	}
+	entries, err := s.repo.Entries(ids, leaderboardHistoryTop)
+	if err != nil {
+		return nil, err
+	}
+	byId := make(map[int64][]models.LeaderboardSnapshotEntry, len(snaps))
+	for _, e := range entries {
+		byId[e.SnapshotId] = append(byId[e.SnapshotId], e)
+	}
+	for i := range snaps {
+		snaps[i].Entries = byId[snaps[i].Id]
+		if snaps[i].Entries == nil {
+			snaps[i].Entries = []models.LeaderboardSnapshotEntry{}
+		}
+	}
+	return snaps, nil
+}
+
+// MemberWins — призовые места и подиумы участника для профиля.
+func (s *LeaderboardService) MemberWins(memberId int64) ([]models.LeaderboardWin, error) {
+	return s.repo.MemberWins(memberId, leaderboardPodium)
+}
+
+// FinalizeEnded замораживает завершившиеся периоды каждого вида и
+// начисляет призы. Идемпотентно: снапшот уникален по (period, period_key),
+// приз — AwardIdempotent с source_id снапшота, поэтому призы доначисляются
+// на каждом прогоне, если процесс упал между снапшотом и начислением.
+// Периоды, пропущенные из-за простоя, догоняются по порядку.
+func (s *LeaderboardService) FinalizeEnded(now time.Time) {
+	for _, period := range models.LeaderboardPeriods {
+		last, err := s.repo.LatestSnapshot(period)
+		if err != nil {
+			log.Printf("leaderboard latest snapshot %s: %v", period, err)
+			continue
+		}
+		for _, p := range leaderboardPeriodsToFinalize(period, now, last) {
+			snap, created, err := s.snapshot(p)
+			if err != nil {
+				// Следующие периоды не трогаем, чтобы не оставить дыру в истории.
+				log.Printf("leaderboard snapshot %s %s: %v", period, p.PeriodKey, err)
+				break
+			}
+			if snap != nil {
+				s.awardPrizes(snap, created)
+			}
+		}
+	}
+}
+
+// leaderboardPeriodsToFinalize — периоды вида period, закончившиеся не
+// позже now - leaderboardFinalizeGrace, начиная с последнего снапшота last
+// (он повторяется ради доначисления призов), по порядку и не больше
+// leaderboardCatchUpMax за прогон. Без снапшотов — только последний
+// закончившийся: историю до запуска рейтинга не разыгрываем.
+func leaderboardPeriodsToFinalize(period string, now time.Time, last *models.LeaderboardSnapshot) []models.LeaderboardPeriod {
+	cur, err := LeaderboardPeriodAt(period, now.Add(-leaderboardFinalizeGrace))
+	if err != nil {
+		return nil
+	}
+	ended, _ := LeaderboardPeriodAt(period, cur.StartsAt.Add(-time.Second))
+	if last == nil {
+		return []models.LeaderboardPeriod{ended}
+	}
+	var out []models.LeaderboardPeriod
+	p, _ := LeaderboardPeriodAt(period, last.StartsAt)
+	for !p.StartsAt.After(ended.StartsAt) && len(out) < leaderboardCatchUpMax {
+		out = append(out, p)
+		p, _ = LeaderboardPeriodAt(period, p.EndsAt)
+	}
+	return out
+}
+
+// snapshot возвращает снапшот периода, создавая его при первом вызове.
+func (s *LeaderboardService) snapshot(p models.LeaderboardPeriod) (*models.LeaderboardSnapshot, bool, error) {
+	existing, err := s.repo.GetSnapshot(p.Period, p.PeriodKey)
+	if err != nil || existing != nil {
+		return existing, false, err
+	}
+
+	excluded := s.excludedReasons()
+	prizes := s.prizes(p.Period)
+	snap := &models.LeaderboardSnapshot{
+		Period:    p.Period,
+		PeriodKey: p.PeriodKey,
+		StartsAt:  p.StartsAt,
+		EndsAt:    p.EndsAt,
+	}
+	created := false
+	err = database.DB.Transaction(func(tx *gorm.DB) error {
+		rows, err := s.repo.RankingTx(tx, p.StartsAt, p.EndsAt, excluded, leaderboardSnapshotSize)
+		if err != nil {
+			return err
+		}
+		if snap.Participants, err = s.repo.Participants(tx, p.StartsAt, p.EndsAt, excluded); err != nil {
+			return err
+		}
+		if created, err = s.repo.CreateSnapshotTx(tx, snap); err != nil || !created {
+			return err
+		}
+		entries := make([]models.LeaderboardSnapshotEntry, 0, len(rows))
+		for _, row := range rows {
+			e := models.LeaderboardSnapshotEntry{SnapshotId: snap.Id, MemberId: row.MemberId, Rank: row.Rank, Total: row.Total}
+			if row.Rank <= len(prizes) {
+				e.Prize = prizes[row.Rank-1]
+			}
+			entries = append(entries, e)
+		}
+		return s.repo.CreateEntriesTx(tx, entries)
+	})
+	if err != nil {
+		return nil, false, err
+	}
+	if !created {
+		// Параллельный инстанс успел раньше — берём его снапшот.
+		existing, err := s.repo.GetSnapshot(p.Period, p.PeriodKey)
+		return existing, false, err
+	}
+	log.Printf("leaderboard snapshot %s %s: %d participants", p.Period, p.PeriodKey, snap.Participants)
+	return snap, true, nil
+}
+
+// leaderboardPeriodTitles — подписи периодов в описаниях транзакций и
+// уведомлениях (хранятся в БД как есть).
+var leaderboardPeriodTitles = map[string]string{
+	models.LeaderboardWeekly:    "недели",
+	models.LeaderboardMonthly:   "месяца",
+	models.LeaderboardQuarterly: "квартала",
+}
+
+// awardPrizes начисляет призы снапшота; notify — только на первом прогоне,
+// чтобы доначисление после сбоя не дублировало уведомления.
+func (s *LeaderboardService) awardPrizes(snap *models.LeaderboardSnapshot, notify bool) {
+	entries, err := s.repo.PrizeEntries(snap.Id)
+	if err != nil {
+		log.Printf("leaderboard prizes %s %s: %v", snap.Period, snap.PeriodKey, err)
+		return
+	}
+	title := leaderboardPeriodTitles[snap.Period]
+	for _, e := range entries {
+		desc := fmt.Sprintf("%d место в рейтинге %s %s", e.Rank, title, snap.PeriodKey)
+		s.pointsSvc.AwardIdempotentAmount(e.MemberId, e.Prize, models.PointReasonLeaderboardPrize,
+			"leaderboard_"+snap.Period, snap.Id, desc)
+		if notify {
+			GetSSEHub().Publish(e.MemberId, SSEEvent{Type: "points"})
+			go CreateNotification(e.MemberId, "leaderboard_prize",
+				fmt.Sprintf("%d место в рейтинге %s!", e.Rank, title),
+				fmt.Sprintf("%s: %d баллов за период, приз — %d баллов", snap.PeriodKey, e.Total, e.Prize))
+		}
+	}
+}
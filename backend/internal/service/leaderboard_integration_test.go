@@ -0,0 +1,110 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"gorm.io/gorm"
+
+	"ithozyeva/internal/models"
+	"ithozyeva/internal/testutil"
+)
+
+func seedPointsAt(t *testing.T, db *gorm.DB, memberId int64, amount int, reason models.PointReason, at time.Time) {
+	t.Helper()
+	if err := db.Create(&models.PointTransaction{
+		MemberId:   memberId,
+		Amount:     amount,
+		Reason:     reason,
+		SourceType: "test",
+		CreatedAt:  at,
+	}).Error; err != nil {
+		t.Fatalf("seed points: %v", err)
+	}
+}
+
+// TestLeaderboardService_FinalizeEnded — прошлая неделя замораживается
+// без исключённых reason'ов, призы начисляются один раз.
+func TestLeaderboardService_FinalizeEnded(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	testutil.TruncateAll(t, db, "leaderboard_snapshot_entries", "leaderboard_snapshots", "point_transactions", "members")
+
+	now := time.Now()
+	cur, _ := LeaderboardPeriodAt(models.LeaderboardWeekly, now)
+	inPrev := cur.StartsAt.Add(-24 * time.Hour)
+
+	first := seedMember(t, db, 7201)
+	second := seedMember(t, db, 7202)
+	seedPointsAt(t, db, first.Id, 30, models.PointReasonEventHost, inPrev)
+	seedPointsAt(t, db, second.Id, 20, models.PointReasonEventAttend, inPrev)
+	// Выигрыш в казино вне зачёта, иначе second был бы первым.
+	seedPointsAt(t, db, second.Id, 500, models.PointReasonCasinoWin, inPrev)
+	// Баллы текущей недели в снапшот прошлой не попадают.
+	seedPointsAt(t, db, second.Id, 100, models.PointReasonEventHost, cur.StartsAt.Add(time.Minute))
+
+	svc := NewLeaderboardService()
+	svc.FinalizeEnded(cur.StartsAt.Add(2 * time.Hour))
+	svc.FinalizeEnded(cur.StartsAt.Add(3 * time.Hour))
+
+	snaps, err := svc.History(models.LeaderboardWeekly, 5)
+	if err != nil {
+		t.Fatalf("History: %v", err)
+	}
+	if len(snaps) != 1 {
+		t.Fatalf("ожидали 1 снапшот недели, получили %d", len(snaps))
+	}
+	entries := snaps[0].Entries
+	if len(entries) != 2 || entries[0].MemberId != first.Id || entries[0].Total != 30 || entries[1].Total != 20 {
+		t.Fatalf("неверный топ: %+v", entries)
+	}
+
+	var prizes int64
+	if err := db.Model(&models.PointTransaction{}).
+		Where("reason = ? AND source_type = ?", models.PointReasonLeaderboardPrize, "leaderboard_weekly").
+		Count(&prizes).Error; err != nil {
+		t.Fatalf("count prizes: %v", err)
+	}
+	if prizes != int64(len(entries)) {
+		t.Errorf("призов = %d, ожидали по одному на призовое место (%d)", prizes, len(entries))
+	}
+
+	wins, err := svc.MemberWins(first.Id)
+	if err != nil {
+		t.Fatalf("MemberWins: %v", err)
+	}
+	if len(wins) == 0 || wins[0].Rank != 1 {
+		t.Errorf("в профиле нет победы: %+v", wins)
+	}
+}
+
+// TestLeaderboardService_FinalizeEndedCatchUp — после простоя замораживаются
+// все пропущенные недели, а не только последняя.
+func TestLeaderboardService_FinalizeEndedCatchUp(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	testutil.TruncateAll(t, db, "leaderboard_snapshot_entries", "leaderboard_snapshots", "point_transactions", "members")
+
+	cur, _ := LeaderboardPeriodAt(models.LeaderboardWeekly, time.Now())
+	member := seedMember(t, db, 7211)
+	// Четыре прошлые недели с баллами; первую заморозил прошлый прогон.
+	for weeks := 4; weeks >= 1; weeks-- {
+		seedPointsAt(t, db, member.Id, 10*weeks, models.PointReasonEventHost, cur.StartsAt.AddDate(0, 0, -7*weeks+1))
+	}
+	svc := NewLeaderboardService()
+	svc.FinalizeEnded(cur.StartsAt.AddDate(0, 0, -21).Add(2 * time.Hour))
+
+	svc.FinalizeEnded(cur.StartsAt.Add(2 * time.Hour))
+
+	snaps, err := svc.History(models.LeaderboardWeekly, 10)
+	if err != nil {
+		t.Fatalf("History: %v", err)
+	}
+	if len(snaps) != 4 {
+		t.Fatalf("ожидали 4 снапшота недель, получили %d", len(snaps))
+	}
+	for i, snap := range snaps {
+		want := 10 * (i + 1)
+		if len(snap.Entries) != 1 || snap.Entries[0].Total != want {
+			t.Errorf("снапшот %s: %+v, ожидали total %d", snap.PeriodKey, snap.Entries, want)
+		}
+	}
+}
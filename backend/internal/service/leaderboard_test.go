@@ -0,0 +1,114 @@
+package service
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"ithozyeva/internal/models"
+	"ithozyeva/internal/utils"
+)
+
+func TestLeaderboardPeriodAt(t *testing.T) {
+	msk := utils.MSKLocation()
+	cases := []struct {
+		period string
+		at     time.Time
+		key    string
+		start  time.Time
+		end    time.Time
+	}{
+		{
+			models.LeaderboardWeekly, time.Date(2026, 10, 18, 23, 30, 0, 0, msk), "2026-W42",
+			time.Date(2026, 10, 12, 0, 0, 0, 0, msk), time.Date(2026, 10, 19, 0, 0, 0, 0, msk),
+		},
+		{
+			// 31 декабря 2026 — четверг 53-й ISO-недели 2026 года.
+			models.LeaderboardWeekly, time.Date(2026, 12, 31, 12, 0, 0, 0, msk), "2026-W53",
+			time.Date(2026, 12, 28, 0, 0, 0, 0, msk), time.Date(2027, 1, 4, 0, 0, 0, 0, msk),
+		},
+		{
+			models.LeaderboardMonthly, time.Date(2026, 2, 28, 10, 0, 0, 0, msk), "2026-02",
+			time.Date(2026, 2, 1, 0, 0, 0, 0, msk), time.Date(2026, 3, 1, 0, 0, 0, 0, msk),
+		},
+		{
+			models.LeaderboardQuarterly, time.Date(2026, 12, 31, 23, 59, 0, 0, msk), "2026-Q4",
+			time.Date(2026, 10, 1, 0, 0, 0, 0, msk), time.Date(2027, 1, 1, 0, 0, 0, 0, msk),
+		},
+		{
+			// 21:30 UTC 31 марта — уже 1 апреля по МСК.
+			models.LeaderboardQuarterly, time.Date(2026, 3, 31, 21, 30, 0, 0, time.UTC), "2026-Q2",
+			time.Date(2026, 4, 1, 0, 0, 0, 0, msk), time.Date(2026, 7, 1, 0, 0, 0, 0, msk),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.period+"/"+tc.key, func(t *testing.T) {
+			p, err := LeaderboardPeriodAt(tc.period, tc.at)
+			if err != nil {
+				t.Fatalf("LeaderboardPeriodAt: %v", err)
+			}
+			if p.PeriodKey != tc.key {
+				t.Errorf("key = %s, want %s", p.PeriodKey, tc.key)
+			}
+			if !p.StartsAt.Equal(tc.start) || !p.EndsAt.Equal(tc.end) {
+				t.Errorf("bounds = [%s, %s), want [%s, %s)", p.StartsAt, p.EndsAt, tc.start, tc.end)
+			}
+		})
+	}
+}
+
+func TestLeaderboardPeriodAt_Unknown(t *testing.T) {
+	if _, err := LeaderboardPeriodAt("yearly", time.Now()); !errors.Is(err, ErrUnknownLeaderboardPeriod) {
+		t.Fatalf("want ErrUnknownLeaderboardPeriod, got %v", err)
+	}
+}
+
+func TestLeaderboardPeriodsToFinalize(t *testing.T) {
+	msk := utils.MSKLocation()
+	// Понедельник 19 октября 2026, 02:00 МСК — неделя W42 закончилась два часа назад.
+	now := time.Date(2026, 10, 19, 2, 0, 0, 0, msk)
+	keys := func(ps []models.LeaderboardPeriod) []string {
+		out := make([]string, 0, len(ps))
+		for _, p := range ps {
+			out = append(out, p.PeriodKey)
+		}
+		return out
+	}
+	snapshotOf := func(key string, at time.Time) *models.LeaderboardSnapshot {
+		p, _ := LeaderboardPeriodAt(models.LeaderboardWeekly, at)
+		if p.PeriodKey != key {
+			t.Fatalf("fixture: %s != %s", p.PeriodKey, key)
+		}
+		return &models.LeaderboardSnapshot{Period: p.Period, PeriodKey: p.PeriodKey, StartsAt: p.StartsAt, EndsAt: p.EndsAt}
+	}
+
+	cases := []struct {
+		name string
+		now  time.Time
+		last *models.LeaderboardSnapshot
+		want []string
+	}{
+		{"без снапшотов — только прошлая неделя", now, nil, []string{"2026-W42"}},
+		{"прошлая уже заморожена — повтор ради призов", now, snapshotOf("2026-W42", now.AddDate(0, 0, -3)), []string{"2026-W42"}},
+		{"простой три недели — догоняем по порядку", now, snapshotOf("2026-W39", now.AddDate(0, 0, -24)),
+			[]string{"2026-W39", "2026-W40", "2026-W41", "2026-W42"}},
+		{"меньше часа после конца — неделю не трогаем", time.Date(2026, 10, 19, 0, 30, 0, 0, msk),
+			snapshotOf("2026-W40", now.AddDate(0, 0, -17)), []string{"2026-W40", "2026-W41"}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := keys(leaderboardPeriodsToFinalize(models.LeaderboardWeekly, tc.now, tc.last))
+			if strings.Join(got, ",") != strings.Join(tc.want, ",") {
+				t.Errorf("periods = %v, want %v", got, tc.want)
+			}
+		})
+	}
+
+	t.Run("догоняем не больше leaderboardCatchUpMax за прогон", func(t *testing.T) {
+		got := leaderboardPeriodsToFinalize(models.LeaderboardWeekly, now, snapshotOf("2025-W42", now.AddDate(-1, 0, -3)))
+		if len(got) != leaderboardCatchUpMax || got[0].PeriodKey != "2025-W42" {
+			t.Errorf("periods = %v", keys(got))
+		}
+	})
+}
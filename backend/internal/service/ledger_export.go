@@ -0,0 +1,216 @@
+package service
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"ithozyeva/internal/i18n"
+	"ithozyeva/internal/models"
+	"ithozyeva/internal/repository"
+	"ithozyeva/internal/utils"
+)
+
+// Выгрузка журналов баллов и реферальных кредитов. Строки идут из курсора
+// БД прямо в ответ, кусками по ledgerFlushRows, — большой журнал не
+// собирается в памяти.
+const (
+	LedgerFormatCSV  = "csv"
+	LedgerFormatJSON = "json"
+
+	ledgerFlushRows  = 500
+	ledgerMaxReasons = 50
+)
+
+var ledgerReasonRe = regexp.MustCompile(`^[a-z0-9_]{1,50}$`)
+
+// LedgerExportError — некорректный фильтр выгрузки; текст показывается
+// пользователю.
+type LedgerExportError struct{ i18n.Message }
+
+func (e *LedgerExportError) Error() string { return e.String() }
+
+func ledgerRefused(format string, args ...interface{}) error {
+	return &LedgerExportError{i18n.Msg(format, args...)}
+}
+
+type LedgerExportService struct {
+	repo *repository.LedgerExportRepository
+}
+
+func NewLedgerExportService() *LedgerExportService {
+	return &LedgerExportService{repo: repository.NewLedgerExportRepository()}
+}
+
+// ParseLedgerExportFilter разбирает параметры выгрузки: from/to — дни
+// YYYY-MM-DD по МСК включительно, reasons — через запятую.
+func ParseLedgerExportFilter(ledger, from, to, reasons string) (models.LedgerExportFilter, error) {
+	f := models.LedgerExportFilter{Ledger: ledger}
+	if ledger != models.LedgerPoints && ledger != models.LedgerCredits {
+		return f, ledgerRefused("неизвестный журнал: %s", ledger)
+	}
+	if from != "" {
+		d, err := time.ParseInLocation("2006-01-02", from, utils.MSKLocation())
+		if err != nil {
+			return f, ledgerRefused("неверная дата начала: %s", from)
+		}
+		f.From = &d
+	}
+	if to != "" {
+		d, err := time.ParseInLocation("2006-01-02", to, utils.MSKLocation())
+		if err != nil {
+			return f, ledgerRefused("неверная дата конца: %s", to)
+		}
+		d = d.AddDate(0, 0, 1)
+		f.To = &d
+	}
+	if f.From != nil && f.To != nil && !f.From.Before(*f.To) {
+		return f, ledgerRefused("дата начала позже даты конца")
+	}
+	for _, r := range strings.Split(reasons, ",") {
+		r = strings.TrimSpace(r)
+		if r == "" {
+			continue
+		}
+		if !ledgerReasonRe.MatchString(r) {
+			return f, ledgerRefused("неверная причина: %s", r)
+		}
+		f.Reasons = append(f.Reasons, r)
+	}
+	if len(f.Reasons) > ledgerMaxReasons {
+		return f, ledgerRefused("не больше %d причин в фильтре", ledgerMaxReasons)
+	}
+	return f, nil
+}
+
+// LedgerExportFilename — имя файла выгрузки: points-2026-09-01_2026-09-30.csv.
+func LedgerExportFilename(f models.LedgerExportFilter, format string) string {
+	name := f.Ledger
+	if f.From != nil {
+		name += "-" + f.From.In(utils.MSKLocation()).Format("2006-01-02")
+	}
+	if f.To != nil {
+		name += "_" + f.To.AddDate(0, 0, -1).In(utils.MSKLocation()).Format("2006-01-02")
+	}
+	return name + "." + format
+}
+
+// Export пишет журнал в w. Если w умеет Flush (bufio.Writer стрима
+// ответа), он сбрасывается каждые ledgerFlushRows строк.
+func (s *LedgerExportService) Export(w io.Writer, format string, f models.LedgerExportFilter) error {
+	enc := newLedgerEncoder(w, format)
+	flusher, _ := w.(interface{ Flush() error })
+	n := 0
+	err := s.repo.Stream(f, func(row *models.LedgerExportRow) error {
+		if err := enc.Write(row); err != nil {
+			return err
+		}
+		n++
+		if flusher != nil && n%ledgerFlushRows == 0 {
+			if err := enc.Flush(); err != nil {
+				return err
+			}
+			return flusher.Flush()
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	return enc.Close()
+}
+
+// ledgerEncoder — построчная запись выгрузки в одном из форматов.
+type ledgerEncoder interface {
+	Write(row *models.LedgerExportRow) error
+	Flush() error
+	Close() error
+}
+
+func newLedgerEncoder(w io.Writer, format string) ledgerEncoder {
+	if format == LedgerFormatCSV {
+		return &ledgerCSVEncoder{w: csv.NewWriter(w)}
+	}
+	return &ledgerJSONEncoder{w: w}
+}
+
+var ledgerCSVHeader = []string{"id", "member_id", "member_username", "created_at", "amount", "reason", "source_type", "source_id", "description"}
+
+type ledgerCSVEncoder struct {
+	w      *csv.Writer
+	header bool
+}
+
+func (e *ledgerCSVEncoder) Write(row *models.LedgerExportRow) error {
+	if !e.header {
+		e.header = true
+		if err := e.w.Write(ledgerCSVHeader); err != nil {
+			return err
+		}
+	}
+	return e.w.Write([]string{
+		strconv.FormatInt(row.Id, 10),
+		strconv.FormatInt(row.MemberId, 10),
+		row.MemberUsername,
+		row.CreatedAt.UTC().Format(time.RFC3339),
+		strconv.Itoa(row.Amount),
+		row.Reason,
+		row.SourceType,
+		strconv.FormatInt(row.SourceId, 10),
+		row.Description,
+	})
+}
+
+func (e *ledgerCSVEncoder) Flush() error {
+	e.w.Flush()
+	return e.w.Error()
+}
+
+// Close — пустая выгрузка всё равно получает заголовок.
+func (e *ledgerCSVEncoder) Close() error {
+	if !e.header {
+		e.header = true
+		if err := e.w.Write(ledgerCSVHeader); err != nil {
+			return err
+		}
+	}
+	return e.Flush()
+}
+
+// ledgerJSONEncoder пишет JSON-массив по элементу, не держа его целиком.
+type ledgerJSONEncoder struct {
+	w     io.Writer
+	count int
+}
+
+func (e *ledgerJSONEncoder) Write(row *models.LedgerExportRow) error {
+	sep := ",\n"
+	if e.count == 0 {
+		sep = "[\n"
+	}
+	data, err := json.Marshal(row)
+	if err != nil {
+		return err
+	}
+	e.count++
+	if _, err := io.WriteString(e.w, sep); err != nil {
+		return err
+	}
+	_, err = e.w.Write(data)
+	return err
+}
+
+func (e *ledgerJSONEncoder) Flush() error { return nil }
+
+func (e *ledgerJSONEncoder) Close() error {
+	end := "\n]\n"
+	if e.count == 0 {
+		end = "[]\n"
+	}
+	_, err := io.WriteString(e.w, end)
+	return err
+}
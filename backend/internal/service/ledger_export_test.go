@@ -0,0 +1,143 @@
+package service
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"ithozyeva/internal/models"
+	"ithozyeva/internal/utils"
+)
+
+func TestParseLedgerExportFilter(t *testing.T) {
+	f, err := ParseLedgerExportFilter(models.LedgerPoints, "2026-09-01", "2026-09-30", "casino_win, kudos_received,")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	wantFrom := time.Date(2026, 9, 1, 0, 0, 0, 0, utils.MSKLocation())
+	wantTo := time.Date(2026, 10, 1, 0, 0, 0, 0, utils.MSKLocation())
+	if !f.From.Equal(wantFrom) || !f.To.Equal(wantTo) {
+		t.Errorf("range = [%v, %v), want [%v, %v)", f.From, f.To, wantFrom, wantTo)
+	}
+	if strings.Join(f.Reasons, ",") != "casino_win,kudos_received" {
+		t.Errorf("reasons = %v", f.Reasons)
+	}
+	if got := LedgerExportFilename(f, LedgerFormatCSV); got != "points-2026-09-01_2026-09-30.csv" {
+		t.Errorf("filename = %q", got)
+	}
+
+	for _, tc := range []struct{ ledger, from, to, reasons string }{
+		{"coins", "", "", ""},
+		{models.LedgerCredits, "01.09.2026", "", ""},
+		{models.LedgerCredits, "2026-09-10", "2026-09-01", ""},
+		{models.LedgerPoints, "", "", "casino_win;drop table"},
+	} {
+		_, err := ParseLedgerExportFilter(tc.ledger, tc.from, tc.to, tc.reasons)
+		var refused *LedgerExportError
+		if !errors.As(err, &refused) {
+			t.Errorf("%+v: want LedgerExportError, got %v", tc, err)
+		}
+	}
+}
+
+func ledgerRows() []models.LedgerExportRow {
+	at := time.Date(2026, 9, 3, 12, 0, 0, 0, time.UTC)
+	return []models.LedgerExportRow{
+		{Id: 1, MemberId: 7, MemberUsername: "alice", Amount: 10, Reason: "event_attend", SourceType: "event", SourceId: 3, Description: "Митап, \"осень\"", CreatedAt: at},
+		{Id: 2, MemberId: 7, MemberUsername: "alice", Amount: -5, Reason: "casino_bet", SourceType: "casino", CreatedAt: at.Add(time.Hour)},
+	}
+}
+
+func TestLedgerCSVEncoder(t *testing.T) {
+	var buf bytes.Buffer
+	enc := newLedgerEncoder(&buf, LedgerFormatCSV)
+	for _, row := range ledgerRows() {
+		row := row
+		if err := enc.Write(&row); err != nil {
+			t.Fatalf("Write: %v", err)
+		}
+	}
+	if err := enc.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	want := "id,member_id,member_username,created_at,amount,reason,source_type,source_id,description\n" +
+		"1,7,alice,2026-09-03T12:00:00Z,10,event_attend,event,3,\"Митап, \"\"осень\"\"\"\n" +
+		"2,7,alice,2026-09-03T13:00:00Z,-5,casino_bet,casino,0,\n"
+	if buf.String() != want {
+		t.Errorf("csv =\n%s\nwant\n%s", buf.String(), want)
+	}
+
+	// Пустая выгрузка — только заголовок.
+	buf.Reset()
+	if err := newLedgerEncoder(&buf, LedgerFormatCSV).Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	if !strings.HasPrefix(buf.String(), "id,member_id") || strings.Count(buf.String(), "\n") != 1 {
+		t.Errorf("empty csv = %q", buf.String())
+	}
+}
+
+func TestLedgerJSONEncoder(t *testing.T) {
+	var buf bytes.Buffer
+	enc := newLedgerEncoder(&buf, LedgerFormatJSON)
+	for _, row := range ledgerRows() {
+		row := row
+		if err := enc.Write(&row); err != nil {
+			t.Fatalf("Write: %v", err)
+		}
+	}
+	if err := enc.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	var got []models.LedgerExportRow
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("invalid json %q: %v", buf.String(), err)
+	}
+	if len(got) != 2 || got[0].Description != "Митап, \"осень\"" || got[1].Amount != -5 {
+		t.Errorf("decoded = %+v", got)
+	}
+
+	buf.Reset()
+	if err := newLedgerEncoder(&buf, LedgerFormatJSON).Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil || len(got) != 0 {
+		t.Errorf("empty json = %q (%v)", buf.String(), err)
+	}
+}
+
+func TestStatementMonth(t *testing.T) {
+	msk := utils.MSKLocation()
+	// 1 октября 01:00 МСК — ещё 30 сентября по UTC.
+	now := time.Date(2026, 10, 1, 1, 0, 0, 0, msk)
+	start, end := StatementMonth(now)
+	if !start.Equal(time.Date(2026, 9, 1, 0, 0, 0, 0, msk)) || !end.Equal(time.Date(2026, 10, 1, 0, 0, 0, 0, msk)) {
+		t.Errorf("month = [%v, %v)", start, end)
+	}
+	if StatementDue(now) {
+		t.Error("1-го числа до 12:00 МСК выписки рано собирать")
+	}
+	if !StatementDue(time.Date(2026, 10, 1, 12, 0, 0, 0, msk)) || !StatementDue(time.Date(2026, 10, 2, 3, 0, 0, 0, msk)) {
+		t.Error("после 12:00 1-го числа выписки должны собираться")
+	}
+
+	start, end = StatementMonth(time.Date(2026, 1, 15, 0, 0, 0, 0, msk))
+	if start.Year() != 2025 || start.Month() != time.December || end.Month() != time.January {
+		t.Errorf("january: [%v, %v)", start, end)
+	}
+
+	if m, err := ParseStatementMonth("2026-09", now); err != nil || !m.Equal(time.Date(2026, 9, 1, 0, 0, 0, 0, msk)) {
+		t.Errorf("ParseStatementMonth(2026-09) = %v, %v", m, err)
+	}
+	if m, err := ParseStatementMonth("", now); err != nil || m.Month() != time.October {
+		t.Errorf("ParseStatementMonth(\"\") = %v, %v", m, err)
+	}
+	for _, bad := range []string{"2026-11", "09.2026"} {
+		if _, err := ParseStatementMonth(bad, now); !errors.Is(err, ErrStatementMonth) {
+			t.Errorf("ParseStatementMonth(%q) = %v, want ErrStatementMonth", bad, err)
+		}
+	}
+}
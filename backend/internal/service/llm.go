@@ -0,0 +1,224 @@
+package service
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"ithozyeva/config"
+	"ithozyeva/internal/models"
+	"ithozyeva/internal/repository"
+)
+
+// OpenAI-совместимый клиент (по умолчанию — cloud.ru Foundation Models).
+// Живёт в service, а не в bot: им пользуются и /summarize в боте, и
+// фоновые задачи бэкенда (еженедельный дайджест).
+
+var openAIClient = &http.Client{Timeout: 120 * time.Second}
+
+// fallbackModels — цепочка моделей для retry: от лучшей к запасным.
+var fallbackModels = []string{
+	"Qwen/Qwen3-235B-A22B-Instruct-2507",
+	"zai-org/GLM-4.7",
+	"ai-sage/GigaChat3-10B-A1.8B",
+	"zai-org/GLM-4.7-Flash",
+	"t-tech/T-pro-it-2.0",
+	"t-tech/T-pro-it-2.1",
+}
+
+// ChatSummarySystemPrompt — системный промпт суммаризации переписки.
+const ChatSummarySystemPrompt = `Ты — помощник, который суммаризирует переписки из Telegram-чатов IT-сообщества.
+Твоя задача — кратко и структурировано изложить основные темы обсуждений, ключевые мнения и выводы.
+Формат: маркированный список основных тем с краткими пояснениями.
+Пиши на русском языке. Будь лаконичен.
+ВАЖНО: Используй HTML-разметку для форматирования (Telegram HTML). Доступные теги: <b>жирный</b>, <i>курсив</i>, <code>код</code>. НЕ используй Markdown (**, __, #, -). Для списков используй символ • в начале строки.`
+
+// LLMRequest — один запрос к модели. Feature (models.LLMFeature*) попадает
+// в журнал llm_usage.
+type LLMRequest struct {
+	Feature      string
+	SystemPrompt string
+	UserContent  string
+	MaxTokens    int
+}
+
+type openAIRequest struct {
+	Model       string          `json:"model"`
+	Messages    []openAIMessage `json:"messages"`
+	MaxTokens   int             `json:"max_tokens,omitempty"`
+	Temperature float64         `json:"temperature,omitempty"`
+}
+
+type openAIMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type openAIUsage struct {
+	PromptTokens     int `json:"prompt_tokens"`
+	CompletionTokens int `json:"completion_tokens"`
+	TotalTokens      int `json:"total_tokens"`
+}
+
+type openAIResponse struct {
+	Choices []struct {
+		Message openAIMessage `json:"message"`
+	} `json:"choices"`
+	Usage openAIUsage `json:"usage"`
+}
+
+// recordLLMUsage пишет попытку в llm_usage. Переменная — чтобы тесты могли
+// подменить запись в БД. Ошибка журнала не должна ронять сам вызов.
+var recordLLMUsage = func(u *models.LLMUsage) {
+	if err := repository.NewLLMUsageRepository().Create(u); err != nil {
+		log.Printf("llm usage: record failed: %v", err)
+	}
+}
+
+// SummarizeChatLog — суммаризация переписки по цепочке моделей.
+func SummarizeChatLog(chatLog string) (summary string, model string, err error) {
+	return CallOpenAIWithRetry(LLMRequest{
+		SystemPrompt: ChatSummarySystemPrompt,
+		UserContent:  "Суммаризируй эту переписку:\n\n" + chatLog,
+		Feature:      models.LLMFeatureSummarize,
+		MaxTokens:    2500,
+	})
+}
+
+// FormatChatLog превращает сообщения (в порядке sent_at DESC, как их отдаёт
+// репозиторий) в хронологический лог для промпта.
+func FormatChatLog(messages []models.ChatMessage) string {
+	var sb strings.Builder
+	for i := len(messages) - 1; i >= 0; i-- {
+		m := messages[i]
+		name := m.TelegramUsername
+		if name == "" {
+			name = m.TelegramFirstName
+		}
+		sb.WriteString(fmt.Sprintf("[%s] %s: %s\n", m.SentAt.Format("02.01 15:04"), name, m.MessageText))
+	}
+	return sb.String()
+}
+
+// CallOpenAIWithRetry пробует модели по цепочке: сначала из конфига, потом fallback.
+func CallOpenAIWithRetry(r LLMRequest) (content string, model string, err error) {
+	chain := buildModelChain()
+
+	var lastErr error
+	for _, m := range chain {
+		started := time.Now()
+		content, usage, err := callOpenAI(r, m)
+		entry := &models.LLMUsage{
+			Feature:          r.Feature,
+			Model:            m,
+			Success:          err == nil,
+			PromptTokens:     usage.PromptTokens,
+			CompletionTokens: usage.CompletionTokens,
+			TotalTokens:      usage.TotalTokens,
+			LatencyMs:        int(time.Since(started).Milliseconds()),
+		}
+		if err != nil {
+			entry.Error = truncateError(err.Error(), 1000)
+		}
+		recordLLMUsage(entry)
+		if err == nil {
+			return content, m, nil
+		}
+		log.Printf("Model %s failed: %v, trying next...", m, err)
+		lastErr = err
+	}
+
+	return "", "", fmt.Errorf("all %d models failed, last error: %w", len(chain), lastErr)
+}
+
+// LLMModelChain — текущая цепочка моделей (для админки).
+func LLMModelChain() []string {
+	return buildModelChain()
+}
+
+func truncateError(s string, n int) string {
+	r := []rune(s)
+	if len(r) <= n {
+		return s
+	}
+	return string(r[:n]) + "…"
+}
+
+func buildModelChain() []string {
+	primary := config.CFG.OpenAIModel
+	if primary == "" {
+		return fallbackModels
+	}
+
+	chain := []string{primary}
+	for _, m := range fallbackModels {
+		if m != primary {
+			chain = append(chain, m)
+		}
+	}
+	return chain
+}
+
+// callOpenAI — один запрос к модели. usage заполнен, если провайдер его
+// вернул (в том числе при ответе без choices).
+func callOpenAI(r LLMRequest, model string) (string, openAIUsage, error) {
+	var usage openAIUsage
+	baseURL := config.CFG.OpenAIBaseURL
+	if baseURL == "" {
+		baseURL = "https://foundation-models.api.cloud.ru/v1"
+	}
+
+	reqBody := openAIRequest{
+		Model:       model,
+		MaxTokens:   r.MaxTokens,
+		Temperature: 0.5,
+		Messages: []openAIMessage{
+			{Role: "system", Content: r.SystemPrompt},
+			{Role: "user", Content: r.UserContent},
+		},
+	}
+
+	body, err := json.Marshal(reqBody)
+	if err != nil {
+		return "", usage, fmt.Errorf("marshal request: %w", err)
+	}
+
+	req, err := http.NewRequest("POST", baseURL+"/chat/completions", bytes.NewReader(body))
+	if err != nil {
+		return "", usage, fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+config.CFG.OpenAIKey)
+
+	resp, err := openAIClient.Do(req)
+	if err != nil {
+		return "", usage, fmt.Errorf("do request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", usage, fmt.Errorf("read response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return "", usage, fmt.Errorf("API error %d: %s", resp.StatusCode, string(respBody))
+	}
+
+	var result openAIResponse
+	if err := json.Unmarshal(respBody, &result); err != nil {
+		return "", usage, fmt.Errorf("unmarshal response: %w", err)
+	}
+
+	usage = result.Usage
+	if len(result.Choices) == 0 {
+		return "", usage, fmt.Errorf("no choices in response")
+	}
+
+	return result.Choices[0].Message.Content, usage, nil
+}
@@ -0,0 +1,77 @@
+package service
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"ithozyeva/config"
+	"ithozyeva/internal/models"
+)
+
+func TestCallOpenAIWithRetryRecordsUsage(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/chat/completions" {
+			http.NotFound(w, r)
+			return
+		}
+		// Первая модель цепочки падает, вторая отвечает.
+		var body struct {
+			Model string `json:"model"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("decode request: %v", err)
+		}
+		if body.Model == "primary" {
+			http.Error(w, "overloaded", http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"choices":[{"message":{"role":"assistant","content":"ok"}}],
+			"usage":{"prompt_tokens":120,"completion_tokens":30,"total_tokens":150}}`))
+	}))
+	defer srv.Close()
+
+	prevCfg, prevRecord := config.CFG, recordLLMUsage
+	defer func() { config.CFG, recordLLMUsage = prevCfg, prevRecord }()
+	config.CFG = &config.Config{OpenAIBaseURL: srv.URL, OpenAIModel: "primary"}
+	var recorded []*models.LLMUsage
+	recordLLMUsage = func(u *models.LLMUsage) { recorded = append(recorded, u) }
+
+	content, model, err := CallOpenAIWithRetry(LLMRequest{Feature: models.LLMFeatureSummarize, UserContent: "hi"})
+	if err != nil || content != "ok" || model != fallbackModels[0] {
+		t.Fatalf("got %q, %q, %v", content, model, err)
+	}
+	if len(recorded) != 2 {
+		t.Fatalf("recorded %d attempts, want 2", len(recorded))
+	}
+	failed, ok := recorded[0], recorded[1]
+	if failed.Success || failed.Model != "primary" || failed.Error == "" {
+		t.Errorf("failed attempt: %+v", failed)
+	}
+	if !ok.Success || ok.Feature != models.LLMFeatureSummarize || ok.PromptTokens != 120 ||
+		ok.CompletionTokens != 30 || ok.TotalTokens != 150 {
+		t.Errorf("successful attempt: %+v", ok)
+	}
+}
+
+func TestMergeModelChain(t *testing.T) {
+	stats := []models.LLMModelStats{
+		{Model: "old", Calls: 4},
+		{Model: "b", Calls: 2, Failures: 1},
+	}
+	got := mergeModelChain(stats, []string{"a", "b"})
+	if len(got) != 3 {
+		t.Fatalf("got %+v", got)
+	}
+	if got[0].Model != "a" || !got[0].InChain || got[0].Calls != 0 {
+		t.Errorf("unused chain model: %+v", got[0])
+	}
+	if got[1].Model != "b" || !got[1].InChain || got[1].Failures != 1 {
+		t.Errorf("chain model: %+v", got[1])
+	}
+	if got[2].Model != "old" || got[2].InChain {
+		t.Errorf("model outside chain: %+v", got[2])
+	}
+}
@@ -0,0 +1,81 @@
+package service
+
+import (
+	"time"
+
+	"ithozyeva/internal/models"
+	"ithozyeva/internal/repository"
+)
+
+const llmUsageRecentFailures = 20
+
+// LLMUsageReport — сводка для админки.
+type LLMUsageReport struct {
+	Since          time.Time                `json:"since"`
+	Chain          []string                 `json:"chain"`
+	Models         []models.LLMModelStats   `json:"models"`
+	Features       []models.LLMFeatureStats `json:"features"`
+	RecentFailures []models.LLMUsage        `json:"recentFailures"`
+}
+
+type LLMUsageService struct {
+	repo *repository.LLMUsageRepository
+}
+
+func NewLLMUsageService() *LLMUsageService {
+	return &LLMUsageService{repo: repository.NewLLMUsageRepository()}
+}
+
+// Report — использование LLM с since.
+func (s *LLMUsageService) Report(since time.Time) (*LLMUsageReport, error) {
+	stats, err := s.repo.StatsByModel(since)
+	if err != nil {
+		return nil, err
+	}
+	features, err := s.repo.StatsByFeature(since)
+	if err != nil {
+		return nil, err
+	}
+	failures, err := s.repo.RecentFailures(since, llmUsageRecentFailures)
+	if err != nil {
+		return nil, err
+	}
+	chain := LLMModelChain()
+	return &LLMUsageReport{
+		Since:          since,
+		Chain:          chain,
+		Models:         mergeModelChain(stats, chain),
+		Features:       features,
+		RecentFailures: failures,
+	}, nil
+}
+
+// mergeModelChain — модели цепочки в её порядке (включая ни разу не
+// вызванные — видно, что до запасных дело не доходило), затем модели вне
+// цепочки (старые, сменённые в конфиге).
+func mergeModelChain(stats []models.LLMModelStats, chain []string) []models.LLMModelStats {
+	byModel := make(map[string]models.LLMModelStats, len(stats))
+	for _, st := range stats {
+		byModel[st.Model] = st
+	}
+	out := make([]models.LLMModelStats, 0, len(chain)+len(stats))
+	seen := make(map[string]bool, len(chain))
+	for _, m := range chain {
+		if seen[m] {
+			continue
+		}
+		seen[m] = true
+		st, ok := byModel[m]
+		if !ok {
+			st = models.LLMModelStats{Model: m}
+		}
+		st.InChain = true
+		out = append(out, st)
+	}
+	for _, st := range stats {
+		if !seen[st.Model] {
+			out = append(out, st)
+		}
+	}
+	return out
+}
@@ -7,6 +7,7 @@ import (
 	"strings"
 	"time"
 
+	"ithozyeva/internal/i18n"
 	"ithozyeva/internal/models"
 	"ithozyeva/internal/repository"
 	"ithozyeva/internal/utils"
@@ -329,6 +330,35 @@ func (s *MemberService) GetByTelegramID(telegramID int64) (*models.Member, error
 	return s.repo.GetByTelegramID(telegramID)
 }
 
+// GetLanguage — язык участника с telegramID: выбранный, иначе посеянный из
+// languageCode Telegram (и сохранённый, если участник уже есть).
+func (s *MemberService) GetLanguage(telegramID int64, languageCode string) (i18n.Lang, error) {
+	saved, found, err := s.repo.GetLanguageByTelegramID(telegramID)
+	if err != nil {
+		return i18n.Default, err
+	}
+	if l, ok := i18n.Parse(saved); ok {
+		return l, nil
+	}
+	lang := i18n.FromTelegram(languageCode)
+	if found && languageCode != "" {
+		err = s.repo.SeedLanguage(telegramID, string(lang))
+	}
+	return lang, err
+}
+
+// ErrUnsupportedLanguage — языка нет в i18n.Supported.
+var ErrUnsupportedLanguage = i18n.Errorf("язык не поддерживается")
+
+// SetLanguage — выбор языка участником (из бота или платформы).
+func (s *MemberService) SetLanguage(memberID int64, code string) (i18n.Lang, error) {
+	lang, ok := i18n.Parse(code)
+	if !ok {
+		return "", ErrUnsupportedLanguage
+	}
+	return lang, s.repo.SetLanguage(memberID, string(lang))
+}
+
 // GetByReferralCode — lookup для бот-deeplink-обработки.
 func (s *MemberService) GetByReferralCode(code string) (*models.Member, error) {
 	return s.repo.GetByReferralCode(code)
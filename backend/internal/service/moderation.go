@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"ithozyeva/internal/i18n"
 	"ithozyeva/internal/models"
 	"ithozyeva/internal/repository"
 	"log"
@@ -107,7 +108,7 @@ func ParseHumanDuration(s string) (time.Duration, error) {
 			j++
 		}
 		if j == i || j >= len(s) {
-			return 0, fmt.Errorf("неверный формат длительности: %q", s)
+			return 0, i18n.Errorf("неверный формат длительности: %q", s)
 		}
 		num := s[i:j]
 		unit := s[j]
@@ -116,7 +117,7 @@ func ParseHumanDuration(s string) (time.Duration, error) {
 		case 'd':
 			n, err := strconv.ParseFloat(num, 64)
 			if err != nil {
-				return 0, fmt.Errorf("неверное число: %q", num)
+				return 0, i18n.Errorf("неверное число: %q", num)
 			}
 			sb.WriteString(strconv.FormatFloat(n*24, 'f', -1, 64))
 			sb.WriteByte('h')
@@ -124,7 +125,7 @@ func ParseHumanDuration(s string) (time.Duration, error) {
 			sb.WriteString(num)
 			sb.WriteByte(unit)
 		default:
-			return 0, fmt.Errorf("неизвестная единица %q", string(unit))
+			return 0, i18n.Errorf("неизвестная единица %q", string(unit))
 		}
 	}
 
@@ -228,13 +229,16 @@ func (s *ModerationService) GetVoteban(id int64) (*models.Voteban, error) {
 type CastVoteResult struct {
 	Tally            models.VotebanTally
 	Voteban          *models.Voteban
-	Threshold        bool // достигнут ли порог "за" — пора финализировать как passed
-	ThresholdAgainst bool // достигнут ли порог "против" — пора финализировать как cancelled
-	Changed          bool // изменился ли голос (false — повторный тот же)
+	Threshold        bool    // достигнут ли порог "за" — пора финализировать как passed
+	ThresholdAgainst bool    // достигнут ли порог "против" — пора финализировать как cancelled
+	Changed          bool    // изменился ли голос (false — повторный тот же)
+	Weight           float64 // вес голоса по репутации голосующего
 }
 
-// CastVote ставит/обновляет голос. Если порог достигнут, возвращает Threshold=true
-// (финализация — на стороне вызывающего, чтобы он мог сделать Telegram-действие).
+// CastVote ставит/обновляет голос с весом по репутации голосующего. Если
+// взвешенный порог достигнут, возвращает Threshold=true (финализация — на
+// стороне вызывающего, чтобы он мог сделать Telegram-действие). Голосующих
+// без стажа отсекает ErrVoterIneligible.
 func (s *ModerationService) CastVote(votebanID, voterID int64, vote int16) (*CastVoteResult, error) {
 	if vote != models.VotebanVoteFor && vote != models.VotebanVoteAgainst {
 		return nil, fmt.Errorf("неверное значение голоса")
@@ -256,7 +260,15 @@ func (s *ModerationService) CastVote(votebanID, voterID int64, vote int16) (*Cas
 	}
 	changed := prev == nil || *prev != vote
 
-	if err := s.repo.UpsertVote(votebanID, voterID, vote); err != nil {
+	rep, err := s.GetReputation(v.ChatID, voterID)
+	if err != nil {
+		return nil, err
+	}
+	if !rep.Eligible {
+		return &CastVoteResult{Voteban: v}, ErrVoterIneligible
+	}
+
+	if err := s.repo.UpsertVote(votebanID, voterID, vote, rep.Weight); err != nil {
 		return nil, err
 	}
 	tally, err := s.repo.CountVotes(votebanID)
@@ -266,9 +278,10 @@ func (s *ModerationService) CastVote(votebanID, voterID int64, vote int16) (*Cas
 	return &CastVoteResult{
 		Tally:            tally,
 		Voteban:          v,
-		Threshold:        tally.For >= v.RequiredVotes,
-		ThresholdAgainst: tally.Against >= v.RequiredVotes,
+		Threshold:        tally.ForReached(v.RequiredVotes),
+		ThresholdAgainst: tally.AgainstReached(v.RequiredVotes),
 		Changed:          changed,
+		Weight:           rep.Weight,
 	}, nil
 }
 
@@ -338,6 +351,7 @@ func (s *ModerationService) UpsertGlobalBan(userID, bannedBy int64, reason *stri
 		UserID:   userID,
 		BannedBy: bannedBy,
 		Reason:   reason,
+		Source:   models.GlobalBanSourceManual,
 	}
 	if duration > 0 {
 		t := time.Now().Add(duration)
@@ -415,3 +429,99 @@ func (s *ModerationService) CancelOpenVoteban(id int64) (*models.Voteban, bool,
 	}
 	return v, true, nil
 }
+
+// --- Raid mode ---
+
+var ErrRaidAlreadyActive = errors.New("raid: в чате уже включён режим рейда")
+
+// RaidStartParams описывает входные данные включения режима рейда.
+type RaidStartParams struct {
+	ChatID           int64
+	ChatTitle        string
+	TriggerJoins     int
+	StartedBy        int64 // 0 — автодетект по волне вступлений
+	SavedPermissions string
+	Duration         time.Duration
+}
+
+// StartRaid включает режим рейда. Если в чате уже есть активный рейд,
+// возвращает его и ErrRaidAlreadyActive — вызывающий просто дописывает
+// вступивших в существующую запись.
+func (s *ModerationService) StartRaid(p RaidStartParams) (*models.Raid, error) {
+	existing, err := s.repo.FindActiveRaid(p.ChatID)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return existing, ErrRaidAlreadyActive
+	}
+	raid := &models.Raid{
+		ChatID:           p.ChatID,
+		ChatTitle:        p.ChatTitle,
+		Status:           models.RaidStatusActive,
+		TriggerJoins:     p.TriggerJoins,
+		StartedBy:        p.StartedBy,
+		SavedPermissions: p.SavedPermissions,
+		ExpiresAt:        time.Now().Add(p.Duration),
+	}
+	if err := s.repo.CreateRaid(raid); err != nil {
+		// Гонка двух горутин handleChatMemberUpdated: unique-индекс пропустил
+		// только одну — отдаём её запись.
+		if existing, findErr := s.repo.FindActiveRaid(p.ChatID); findErr == nil && existing != nil {
+			return existing, ErrRaidAlreadyActive
+		}
+		return nil, err
+	}
+	return raid, nil
+}
+
+// GetRaid возвращает рейд по id.
+func (s *ModerationService) GetRaid(id int64) (*models.Raid, error) {
+	return s.repo.GetRaid(id)
+}
+
+// FindActiveRaid — активный рейд в чате или nil.
+func (s *ModerationService) FindActiveRaid(chatID int64) (*models.Raid, error) {
+	return s.repo.FindActiveRaid(chatID)
+}
+
+// FindLatestUnresolvedRaid — последний рейд в чате, по которому ещё можно
+// сделать откат или снять ограничения.
+func (s *ModerationService) FindLatestUnresolvedRaid(chatID int64) (*models.Raid, error) {
+	return s.repo.FindLatestUnresolvedRaid(chatID)
+}
+
+// ExtendRaid продлевает активный рейд до until (если until позже текущего).
+func (s *ModerationService) ExtendRaid(id int64, until time.Time) error {
+	return s.repo.ExtendRaid(id, until)
+}
+
+// TransitionRaid — атомарная смена статуса рейда, см. repository.TransitionRaid.
+func (s *ModerationService) TransitionRaid(id int64, from []string, to string, endedBy *int64) (bool, error) {
+	return s.repo.TransitionRaid(id, from, to, endedBy)
+}
+
+// ListExpiredActiveRaids — для watcher'а: рейды, у которых истекло окно.
+func (s *ModerationService) ListExpiredActiveRaids(now time.Time) ([]models.Raid, error) {
+	return s.repo.ListExpiredActiveRaids(now)
+}
+
+// AddRaidJoin фиксирует вступившего в окно рейда.
+func (s *ModerationService) AddRaidJoin(j *models.RaidJoin) error {
+	return s.repo.AddRaidJoin(j)
+}
+
+// ListRaidJoins — вступившие в окно рейда.
+func (s *ModerationService) ListRaidJoins(raidID int64) ([]models.RaidJoin, error) {
+	return s.repo.ListRaidJoins(raidID)
+}
+
+// MarkRaidJoinBanned — после успешного бана при откате.
+func (s *ModerationService) MarkRaidJoinBanned(raidID, userID int64) error {
+	return s.repo.MarkRaidJoinBanned(raidID, userID)
+}
+
+// ListRecentRaidsView — последние рейды с числом вступивших для админки.
+func (s *ModerationService) ListRecentRaidsView() ([]repository.RaidView, error) {
+	return s.repo.ListRecentRaids()
+}
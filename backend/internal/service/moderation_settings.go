@@ -0,0 +1,193 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"ithozyeva/internal/i18n"
+	"ithozyeva/internal/models"
+)
+
+// Дефолты модерации — действуют в чатах без строки в bot_chat_moderation_settings.
+const (
+	DefaultVotebanWindow  = 15 * time.Minute   // окно сбора голосов
+	DefaultVotebanKick    = time.Hour          // длительность kick-санкции
+	DefaultMuteDuration   = time.Duration(0)   // /mute без аргумента — бессрочно
+	DefaultCleanupPeriod  = 24 * time.Hour     // /cleanup без периода
+	DefaultCleanupMax     = 7 * 24 * time.Hour // потолок периода /cleanup
+	maxModerationDuration = 30 * 24 * time.Hour
+)
+
+// DefaultChatModerationSettings — настройки «как было в константах».
+func DefaultChatModerationSettings(chatID int64) *models.ChatModerationSettings {
+	return &models.ChatModerationSettings{
+		ChatID:                chatID,
+		VotebanWindowSeconds:  int(DefaultVotebanWindow.Seconds()),
+		VotebanKickSeconds:    int(DefaultVotebanKick.Seconds()),
+		MuteDefaultSeconds:    int(DefaultMuteDuration.Seconds()),
+		CleanupDefaultSeconds: int(DefaultCleanupPeriod.Seconds()),
+		CleanupMaxSeconds:     int(DefaultCleanupMax.Seconds()),
+	}
+}
+
+// ValidateChatModerationSettings проверяет диапазоны. Окно голосования
+// ограничено сверху, чтобы закреп не висел сутками; потолок чистки — те же
+// 7 дней, что были в константе.
+func ValidateChatModerationSettings(s *models.ChatModerationSettings) error {
+	sec := func(d time.Duration) int { return int(d.Seconds()) }
+	switch {
+	case s.VotebanWindowSeconds < sec(time.Minute) || s.VotebanWindowSeconds > sec(2*time.Hour):
+		return fmt.Errorf("окно голосования — от 1m до 2h")
+	case s.VotebanKickSeconds < sec(time.Minute) || s.VotebanKickSeconds > sec(maxModerationDuration):
+		return fmt.Errorf("санкция voteban — от 1m до 30d")
+	case s.MuteDefaultSeconds < 0 || s.MuteDefaultSeconds > sec(maxModerationDuration):
+		return fmt.Errorf("мут по умолчанию — от 0 (бессрочно) до 30d")
+	case s.CleanupMaxSeconds < sec(time.Minute) || s.CleanupMaxSeconds > sec(DefaultCleanupMax):
+		return fmt.Errorf("максимальный период чистки — от 1m до 7d")
+	case s.CleanupDefaultSeconds < sec(time.Minute) || s.CleanupDefaultSeconds > s.CleanupMaxSeconds:
+		return fmt.Errorf("период чистки по умолчанию — от 1m до максимального")
+	}
+	return nil
+}
+
+// GetChatSettings возвращает настройки чата с подстановкой дефолтов.
+func (s *ModerationService) GetChatSettings(chatID int64) (*models.ChatModerationSettings, error) {
+	st, err := s.repo.GetChatSettings(chatID)
+	if err != nil {
+		return nil, err
+	}
+	if st == nil {
+		return DefaultChatModerationSettings(chatID), nil
+	}
+	return st, nil
+}
+
+// ListChatSettings — чаты с переопределёнными настройками (для админки).
+func (s *ModerationService) ListChatSettings() ([]models.ChatModerationSettings, error) {
+	return s.repo.ListChatSettings()
+}
+
+// SaveChatSettings валидирует и сохраняет настройки, логируя изменение в
+// журнал модерации (actor — telegram id; 0, если из админки).
+func (s *ModerationService) SaveChatSettings(st *models.ChatModerationSettings, actorID int64) error {
+	if err := ValidateChatModerationSettings(st); err != nil {
+		return err
+	}
+	st.UpdatedBy = actorID
+	if err := s.repo.UpsertChatSettings(st); err != nil {
+		return err
+	}
+	return s.LogActionWithMeta(&models.ModerationAction{
+		ChatID:      st.ChatID,
+		ActorUserID: actorID,
+		Action:      models.ModerationActionSettings,
+	}, map[string]interface{}{
+		"voteban_window_seconds":  st.VotebanWindowSeconds,
+		"voteban_kick_seconds":    st.VotebanKickSeconds,
+		"mute_default_seconds":    st.MuteDefaultSeconds,
+		"cleanup_default_seconds": st.CleanupDefaultSeconds,
+		"cleanup_max_seconds":     st.CleanupMaxSeconds,
+	})
+}
+
+// ResetChatSettings возвращает чат на дефолты.
+func (s *ModerationService) ResetChatSettings(chatID, actorID int64) error {
+	if err := s.repo.DeleteChatSettings(chatID); err != nil {
+		return err
+	}
+	return s.LogActionWithMeta(&models.ModerationAction{
+		ChatID:      chatID,
+		ActorUserID: actorID,
+		Action:      models.ModerationActionSettings,
+	}, map[string]interface{}{"reset": true})
+}
+
+// --- Chat moderators ---
+
+// HasModeratorPower — выдано ли userID полномочие power в чате.
+func (s *ModerationService) HasModeratorPower(chatID, userID int64, power string) (bool, error) {
+	m, err := s.repo.GetChatModerator(chatID, userID)
+	if err != nil || m == nil {
+		return false, err
+	}
+	return m.Has(power), nil
+}
+
+// GetChatModerator — запись модератора или nil.
+func (s *ModerationService) GetChatModerator(chatID, userID int64) (*models.ChatModerator, error) {
+	return s.repo.GetChatModerator(chatID, userID)
+}
+
+// ListChatModerators — модераторы чата; chatID=0 — все.
+func (s *ModerationService) ListChatModerators(chatID int64) ([]models.ChatModerator, error) {
+	return s.repo.ListChatModerators(chatID)
+}
+
+// SetChatModerator выдаёт / меняет полномочия. Без единого полномочия запись
+// не имеет смысла — для снятия есть RemoveChatModerator.
+func (s *ModerationService) SetChatModerator(m *models.ChatModerator, actorID int64) error {
+	if m.ChatID == 0 || m.UserID == 0 {
+		return fmt.Errorf("нужны chat_id и user_id")
+	}
+	if !m.CanMute && !m.CanBan && !m.CanCleanup {
+		return fmt.Errorf("выберите хотя бы одно полномочие: mute, ban, cleanup")
+	}
+	m.AddedBy = actorID
+	if err := s.repo.UpsertChatModerator(m); err != nil {
+		return err
+	}
+	return s.LogActionWithMeta(&models.ModerationAction{
+		ChatID:       m.ChatID,
+		TargetUserID: m.UserID,
+		ActorUserID:  actorID,
+		Action:       models.ModerationActionSettings,
+	}, map[string]interface{}{
+		"moderator":   "set",
+		"can_mute":    m.CanMute,
+		"can_ban":     m.CanBan,
+		"can_cleanup": m.CanCleanup,
+	})
+}
+
+// RemoveChatModerator снимает модератора. false — его не было.
+func (s *ModerationService) RemoveChatModerator(chatID, userID, actorID int64) (bool, error) {
+	ok, err := s.repo.DeleteChatModerator(chatID, userID)
+	if err != nil || !ok {
+		return ok, err
+	}
+	return true, s.LogActionWithMeta(&models.ModerationAction{
+		ChatID:       chatID,
+		TargetUserID: userID,
+		ActorUserID:  actorID,
+		Action:       models.ModerationActionSettings,
+	}, map[string]interface{}{"moderator": "removed"})
+}
+
+// ParseModeratorPowers разбирает «mute,ban» / «all» в флаги.
+func ParseModeratorPowers(raw string) (mute, ban, cleanup bool, err error) {
+	for _, p := range splitPowers(raw) {
+		switch p {
+		case models.ModeratorPowerMute:
+			mute = true
+		case models.ModeratorPowerBan:
+			ban = true
+		case models.ModeratorPowerCleanup:
+			cleanup = true
+		case "all":
+			mute, ban, cleanup = true, true, true
+		default:
+			return false, false, false, i18n.Errorf("неизвестное полномочие %q (mute, ban, cleanup, all)", p)
+		}
+	}
+	if !mute && !ban && !cleanup {
+		return false, false, false, fmt.Errorf("укажите полномочия: mute, ban, cleanup или all")
+	}
+	return mute, ban, cleanup, nil
+}
+
+func splitPowers(raw string) []string {
+	return strings.FieldsFunc(strings.ToLower(raw), func(r rune) bool {
+		return r == ',' || r == ' ' || r == '+'
+	})
+}
@@ -0,0 +1,72 @@
+package service
+
+import (
+	"testing"
+
+	"ithozyeva/internal/models"
+)
+
+func TestValidateChatModerationSettings(t *testing.T) {
+	if err := ValidateChatModerationSettings(DefaultChatModerationSettings(1)); err != nil {
+		t.Fatalf("defaults must be valid: %v", err)
+	}
+	cases := []struct {
+		name   string
+		mutate func(s *models.ChatModerationSettings)
+	}{
+		{"window too short", func(s *models.ChatModerationSettings) { s.VotebanWindowSeconds = 30 }},
+		{"window too long", func(s *models.ChatModerationSettings) { s.VotebanWindowSeconds = 3 * 3600 }},
+		{"kick zero", func(s *models.ChatModerationSettings) { s.VotebanKickSeconds = 0 }},
+		{"mute negative", func(s *models.ChatModerationSettings) { s.MuteDefaultSeconds = -1 }},
+		{"cleanup above max", func(s *models.ChatModerationSettings) {
+			s.CleanupMaxSeconds = 3600
+			s.CleanupDefaultSeconds = 7200
+		}},
+		{"cleanup max above 7d", func(s *models.ChatModerationSettings) { s.CleanupMaxSeconds = 8 * 24 * 3600 }},
+	}
+	for _, c := range cases {
+		st := DefaultChatModerationSettings(1)
+		c.mutate(st)
+		if err := ValidateChatModerationSettings(st); err == nil {
+			t.Errorf("%s: want error", c.name)
+		}
+	}
+}
+
+func TestParseModeratorPowers(t *testing.T) {
+	cases := []struct {
+		in                 string
+		mute, ban, cleanup bool
+		bad                bool
+	}{
+		{"mute", true, false, false, false},
+		{"Mute,Cleanup", true, false, true, false},
+		{"ban+cleanup", false, true, true, false},
+		{"all", true, true, true, false},
+		{"", false, false, false, true},
+		{"kick", false, false, false, true},
+	}
+	for _, c := range cases {
+		mute, ban, cleanup, err := ParseModeratorPowers(c.in)
+		if c.bad {
+			if err == nil {
+				t.Errorf("ParseModeratorPowers(%q): want error", c.in)
+			}
+			continue
+		}
+		if err != nil || mute != c.mute || ban != c.ban || cleanup != c.cleanup {
+			t.Errorf("ParseModeratorPowers(%q) = %v,%v,%v,%v", c.in, mute, ban, cleanup, err)
+		}
+	}
+}
+
+func TestChatModeratorHas(t *testing.T) {
+	banOnly := &models.ChatModerator{CanBan: true}
+	if !banOnly.Has(models.ModeratorPowerMute) || !banOnly.Has(models.ModeratorPowerBan) || banOnly.Has(models.ModeratorPowerCleanup) {
+		t.Errorf("ban must imply mute only: %+v", banOnly)
+	}
+	muteOnly := &models.ChatModerator{CanMute: true}
+	if muteOnly.Has(models.ModeratorPowerBan) {
+		t.Errorf("mute must not imply ban")
+	}
+}
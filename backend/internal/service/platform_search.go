@@ -0,0 +1,128 @@
+package service
+
+import (
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"ithozyeva/config"
+	"ithozyeva/internal/models"
+	"ithozyeva/internal/repository"
+)
+
+const (
+	platformSearchMinQuery = 2
+	platformSearchMaxQuery = 100
+	platformSearchPerKind  = 5
+)
+
+// PlatformSearchService — общий поиск по событиям, менторам, участникам и
+// AI-материалам: для REST (/api/platform/search) и inline-режима бота.
+// Видимость та же, что на платформе: менторы и профили участников открыты
+// любому авторизованному, события и AI-материалы — подписчикам.
+type PlatformSearchService struct {
+	repo             *repository.PlatformSearchRepository
+	aiMaterials      *repository.AIMaterialRepository
+	subscriptionRepo *repository.SubscriptionRepository
+}
+
+func NewPlatformSearchService() *PlatformSearchService {
+	return &PlatformSearchService{
+		repo:             repository.NewPlatformSearchRepository(),
+		aiMaterials:      repository.NewAIMaterialRepository(),
+		subscriptionRepo: repository.NewSubscriptionRepository(),
+	}
+}
+
+// NormalizePlatformSearchQuery обрезает пробелы и слишком длинный запрос.
+func NormalizePlatformSearchQuery(q string) string {
+	q = strings.TrimSpace(q)
+	if utf8.RuneCountInString(q) > platformSearchMaxQuery {
+		q = string([]rune(q)[:platformSearchMaxQuery])
+	}
+	return q
+}
+
+// Search — до platformSearchPerKind совпадений каждого типа. Запрос короче
+// platformSearchMinQuery ищет только ближайшие события: пустой inline-запрос
+// показывает афишу.
+func (s *PlatformSearchService) Search(viewer *models.Member, q string, now time.Time) ([]models.PlatformSearchHit, error) {
+	q = NormalizePlatformSearchQuery(q)
+	full := utf8.RuneCountInString(q) >= platformSearchMinQuery
+	subscribed := s.isSubscribed(viewer)
+
+	var hits []models.PlatformSearchHit
+	if subscribed {
+		events, err := s.repo.SearchEvents(q, viewer.TelegramID, now, platformSearchPerKind)
+		if err != nil {
+			return nil, err
+		}
+		hits = append(hits, events...)
+	}
+	if !full {
+		return nonNilHits(hits), nil
+	}
+
+	mentors, err := s.repo.SearchMentors(q, platformSearchPerKind)
+	if err != nil {
+		return nil, err
+	}
+	hits = append(hits, mentors...)
+
+	members, err := s.repo.SearchMembers(q, platformSearchPerKind)
+	if err != nil {
+		return nil, err
+	}
+	hits = append(hits, members...)
+
+	if subscribed {
+		materials, _, err := s.aiMaterials.Search(repository.AIMaterialFilter{
+			Query:    q,
+			ViewerID: viewer.Id,
+			Sort:     "popular",
+			Limit:    platformSearchPerKind,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, m := range materials {
+			hits = append(hits, models.PlatformSearchHit{
+				Kind:     models.PlatformSearchAIMaterial,
+				ID:       m.Id,
+				Title:    m.Title,
+				Subtitle: m.Summary,
+			})
+		}
+	}
+	return nonNilHits(hits), nil
+}
+
+// isSubscribed — та же проверка, что в RequireSubscription: при
+// выключенном гейте подписки доступно всё.
+func (s *PlatformSearchService) isSubscribed(viewer *models.Member) bool {
+	if !config.CFG.SubscriptionGateEnabled {
+		return true
+	}
+	user, err := s.subscriptionRepo.GetUser(viewer.TelegramID)
+	return err == nil && user != nil && user.EffectiveTierID() != nil
+}
+
+func nonNilHits(hits []models.PlatformSearchHit) []models.PlatformSearchHit {
+	if hits == nil {
+		return []models.PlatformSearchHit{}
+	}
+	return hits
+}
+
+// PlatformStartParam — параметр startapp для deep link в Mini App:
+// «<kind>-<id>», например event-12 или ai_material-7.
+func PlatformStartParam(kind string, id int64) string {
+	return kind + "-" + strconv.FormatInt(id, 10)
+}
+
+// MiniAppLink — ссылка, открывающая Mini App бота botUsername с параметром
+// startParam (приходит в initData как start_param).
+func MiniAppLink(botUsername, startParam string) string {
+	return "https://t.me/" + botUsername + "?startapp=" + startParam
+}
@@ -0,0 +1,89 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"ithozyeva/config"
+	"ithozyeva/internal/models"
+	"ithozyeva/internal/testutil"
+)
+
+func TestPlatformSearchService_RespectsAccess(t *testing.T) {
+	db := testutil.EnsureTestDB(t)
+	subTablesTruncate(t, db)
+	eventTablesTruncate(t, db)
+	testutil.TruncateAll(t, db, "mentors", "ai_materials")
+
+	prevCfg := config.CFG
+	defer func() { config.CFG = prevCfg }()
+	config.CFG = &config.Config{SubscriptionGateEnabled: true}
+
+	const (
+		ownChat    int64 = -1002000000001
+		closedChat int64 = -1002000000002
+	)
+	master := mustTier(t, db, "master")
+	subscriber := seedMemberWithRoles(t, db, 12001, "subscriber", nil)
+	outsider := seedMemberWithRoles(t, db, 12002, "outsider", nil)
+	seedSubUser(t, db, subscriber.TelegramID, &master.ID, nil)
+	seedSubChat(t, db, ownChat, "own", nil)
+	seedSubChat(t, db, closedChat, "closed", nil)
+	if err := db.Create(&models.SubscriptionUserChatAccess{UserID: subscriber.TelegramID, ChatID: ownChat}).Error; err != nil {
+		t.Fatalf("seed access: %v", err)
+	}
+
+	now := time.Now()
+	for _, ev := range []*models.Event{
+		{Title: "Docker митап", Date: now.Add(24 * time.Hour)},
+		{Title: "Docker для своих", Date: now.Add(48 * time.Hour), ExclusiveChatID: &[]int64{ownChat}[0]},
+		{Title: "Docker закрытый", Date: now.Add(48 * time.Hour), ExclusiveChatID: &[]int64{closedChat}[0]},
+		{Title: "Docker прошлый", Date: now.Add(-48 * time.Hour)},
+	} {
+		seedEvent(t, db, ev)
+	}
+	mentorMember := seedMemberWithRoles(t, db, 12003, "docker_guru", nil)
+	if err := db.Create(&models.MentorDbShortModel{MemberId: mentorMember.Id, Occupation: "DevOps"}).Error; err != nil {
+		t.Fatalf("seed mentor: %v", err)
+	}
+
+	svc := NewPlatformSearchService()
+	count := func(hits []models.PlatformSearchHit) map[string]int {
+		n := map[string]int{}
+		for _, h := range hits {
+			n[h.Kind]++
+		}
+		return n
+	}
+
+	hits, err := svc.Search(subscriber, "docker", now)
+	if err != nil {
+		t.Fatalf("search: %v", err)
+	}
+	got := count(hits)
+	// Открытое и эксклюзивное своего чата; закрытый чат и прошлое — мимо.
+	if got[models.PlatformSearchEvent] != 2 {
+		t.Errorf("subscriber events = %d, want 2 (%+v)", got[models.PlatformSearchEvent], hits)
+	}
+	if got[models.PlatformSearchMentor] != 1 || got[models.PlatformSearchMember] != 1 {
+		t.Errorf("subscriber mentors/members = %+v", got)
+	}
+
+	hits, err = svc.Search(outsider, "docker", now)
+	if err != nil {
+		t.Fatalf("search: %v", err)
+	}
+	got = count(hits)
+	if got[models.PlatformSearchEvent] != 0 || got[models.PlatformSearchMentor] != 1 {
+		t.Errorf("outsider sees %+v, want only mentor and member", got)
+	}
+
+	// Пустой запрос — афиша ближайших событий.
+	hits, err = svc.Search(subscriber, " ", now)
+	if err != nil {
+		t.Fatalf("search: %v", err)
+	}
+	if len(hits) != 2 || hits[0].Title != "Docker митап" {
+		t.Errorf("empty query = %+v", hits)
+	}
+}
@@ -1,32 +1,43 @@
 package service
 
 import (
+	"errors"
 	"fmt"
 	"ithozyeva/database"
 	"ithozyeva/internal/models"
 	"ithozyeva/internal/repository"
+	"ithozyeva/internal/utils"
 	"log"
+	"strings"
 	"time"
 
 	"gorm.io/gorm"
 )
 
 type PointsService struct {
-	repo *repository.PointsRepository
+	repo     *repository.PointsRepository
+	settings *AppSettingsService
 }
 
 func NewPointsService() *PointsService {
 	return &PointsService{
-		repo: repository.NewPointsRepository(),
+		repo:     repository.NewPointsRepository(),
+		settings: NewAppSettingsService(),
 	}
 }
 
 // AwardIdempotent начисляет баллы с защитой от дублирования (ON CONFLICT DO NOTHING).
 // Используется для планировщика и одноразовых наград.
 func (s *PointsService) AwardIdempotent(memberId int64, reason models.PointReason, sourceType string, sourceId int64, description string) {
+	s.AwardIdempotentAmount(memberId, models.PointValues[reason], reason, sourceType, sourceId, description)
+}
+
+// AwardIdempotentAmount — AwardIdempotent с суммой не из PointValues
+// (призы за места, где сумма зависит от места и настроек).
+func (s *PointsService) AwardIdempotentAmount(memberId int64, amount int, reason models.PointReason, sourceType string, sourceId int64, description string) {
 	tx := &models.PointTransaction{
 		MemberId:    memberId,
-		Amount:      models.PointValues[reason],
+		Amount:      amount,
 		Reason:      reason,
 		SourceType:  sourceType,
 		SourceId:    sourceId,
@@ -43,6 +54,7 @@ func (s *PointsService) AwardIdempotent(memberId int64, reason models.PointReaso
 
 // GiveForAction начисляет баллы за действие пользователя (обычный INSERT).
 // Используется в хендлерах, где каждый вызов = одно уникальное действие.
+// Сумма урезается лимитами points_caps.
 func (s *PointsService) GiveForAction(memberId int64, reason models.PointReason, sourceType string, sourceId int64, description string) {
 	tx := &models.PointTransaction{
 		MemberId:    memberId,
@@ -52,16 +64,18 @@ func (s *PointsService) GiveForAction(memberId int64, reason models.PointReason,
 		SourceId:    sourceId,
 		Description: description,
 	}
-	if err := s.repo.GivePoints(tx); err != nil {
+	granted, err := s.giveCapped(tx)
+	if err != nil {
 		log.Printf("Error giving points (reason=%s, member=%d): %v", reason, memberId, err)
 		return
 	}
-	if tx.Amount > 0 {
+	if granted && tx.Amount > 0 {
 		TrackChallengeMetric(memberId, "points_earned", tx.Amount)
 	}
 }
 
 // GiveCustomPoints начисляет произвольное количество баллов (для квестов чатов)
+// с теми же лимитами, что GiveForAction.
 func (s *PointsService) GiveCustomPoints(memberId int64, amount int, reason models.PointReason, sourceType string, sourceId int64, description string) {
 	tx := &models.PointTransaction{
 		MemberId:    memberId,
@@ -71,11 +85,71 @@ func (s *PointsService) GiveCustomPoints(memberId int64, amount int, reason mode
 		SourceId:    sourceId,
 		Description: description,
 	}
-	if err := s.repo.GivePoints(tx); err != nil {
+	if _, err := s.giveCapped(tx); err != nil {
 		log.Printf("Error giving custom points (reason=%s, member=%d, amount=%d): %v", reason, memberId, amount, err)
 	}
 }
 
+// capFor — лимит reason'а из app_settings points_caps.
+func (s *PointsService) capFor(reason models.PointReason) (models.PointsCap, bool) {
+	var caps map[string]models.PointsCap
+	if !s.settings.GetJSON("points_caps", &caps) {
+		return models.PointsCap{}, false
+	}
+	c, ok := caps[string(reason)]
+	return c, ok && (c.Daily > 0 || c.Weekly > 0)
+}
+
+// giveCapped вставляет начисление, урезая сумму до остатка дневного
+// (сутки МСК) и недельного (ISO-неделя МСК) лимита reason'а. Подсчёт и
+// вставка — под pg_advisory_xact_lock(memberId), иначе параллельные
+// горутины начислений проскакивают лимит. false — лимит уже выбран,
+// ничего не вставлено; tx.Amount после вызова — фактическая сумма.
+func (s *PointsService) giveCapped(tx *models.PointTransaction) (bool, error) {
+	limit, ok := s.capFor(tx.Reason)
+	if !ok || tx.Amount <= 0 {
+		return true, s.repo.GivePoints(tx)
+	}
+	requested := tx.Amount
+	granted := false
+	err := database.DB.Transaction(func(db *gorm.DB) error {
+		if err := db.Exec(`SELECT pg_advisory_xact_lock(?)`, tx.MemberId).Error; err != nil {
+			return err
+		}
+		now := time.Now()
+		amount := tx.Amount
+		if limit.Daily > 0 {
+			earned, err := s.repo.EarnedSinceTx(db, tx.MemberId, tx.Reason, utils.MSKDay(now))
+			if err != nil {
+				return err
+			}
+			amount = min(amount, limit.Daily-earned)
+		}
+		if limit.Weekly > 0 {
+			earned, err := s.repo.EarnedSinceTx(db, tx.MemberId, tx.Reason, startOfISOWeekMSK(now))
+			if err != nil {
+				return err
+			}
+			amount = min(amount, limit.Weekly-earned)
+		}
+		if amount <= 0 {
+			return nil
+		}
+		tx.Amount = amount
+		granted = true
+		return db.Create(tx).Error
+	})
+	if err != nil {
+		return false, err
+	}
+	if !granted {
+		log.Printf("points cap reached (reason=%s, member=%d): %d not granted", tx.Reason, tx.MemberId, requested)
+	} else if tx.Amount < requested {
+		log.Printf("points cap (reason=%s, member=%d): granted %d of %d", tx.Reason, tx.MemberId, tx.Amount, requested)
+	}
+	return granted, nil
+}
+
 // awardIdempotentTx — внутренний помощник для атомарного начисления внутри транзакции.
 func (s *PointsService) awardIdempotentTx(db *gorm.DB, memberId int64, reason models.PointReason, sourceType string, sourceId int64, description string) error {
 	tx := &models.PointTransaction{
@@ -189,8 +263,70 @@ func (s *PointsService) AdminAwardPoints(memberId int64, amount int, description
 	return s.repo.CreateManualTransaction(tx)
 }
 
-func (s *PointsService) DeleteTransaction(id int64) error {
-	return s.repo.DeleteTransaction(id)
+var (
+	ErrPointTransactionNotFound = errors.New("транзакция не найдена")
+	ErrPointTransactionReversed = errors.New("транзакция уже отменена")
+	ErrPointReversalOfReversal  = errors.New("сторно нельзя отменить")
+	ErrPointReversalReason      = errors.New("укажите причину отмены")
+)
+
+// Reverse отменяет транзакцию журнала сторно-записью с обратной суммой.
+// Журнал append-only: исходная строка остаётся, баланс меняется только
+// через новую транзакцию, поэтому история правок видна целиком.
+func (s *PointsService) Reverse(id int64, reason string) (*models.PointTransaction, error) {
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return nil, ErrPointReversalReason
+	}
+	var rev *models.PointTransaction
+	err := database.DB.Transaction(func(tx *gorm.DB) error {
+		orig, err := s.repo.GetTransactionForUpdateTx(tx, id)
+		if err != nil {
+			return err
+		}
+		if orig == nil {
+			return ErrPointTransactionNotFound
+		}
+		if orig.ReversesId != nil {
+			return ErrPointReversalOfReversal
+		}
+		reversed, err := s.repo.ReversedIdsTx(tx, []int64{orig.Id})
+		if err != nil {
+			return err
+		}
+		if len(reversed) > 0 {
+			return ErrPointTransactionReversed
+		}
+		rev, err = s.repo.ReverseTx(tx, orig, models.PointReasonReversal,
+			fmt.Sprintf("Отмена транзакции #%d: %s", orig.Id, reason))
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	GetSSEHub().Publish(rev.MemberId, SSEEvent{Type: "points"})
+	return rev, nil
+}
+
+// ReconcileBalances сверяет снапшоты балансов с журналом и чинит
+// расхождения в пользу журнала. Расхождение — признак бага (запись в
+// обход триггера), поэтому каждое пишется в лог.
+func (s *PointsService) ReconcileBalances() (*models.PointsReconcileReport, error) {
+	checked, err := s.repo.CountBalances()
+	if err != nil {
+		return nil, err
+	}
+	mismatches, err := s.repo.BalanceMismatches()
+	if err != nil {
+		return nil, err
+	}
+	for _, m := range mismatches {
+		log.Printf("points reconcile: member %d snapshot %d, ledger %d", m.MemberId, m.Snapshot, m.Ledger)
+		if err := s.repo.RepairBalance(m.MemberId); err != nil {
+			return nil, err
+		}
+	}
+	return &models.PointsReconcileReport{Checked: checked, Mismatches: mismatches}, nil
 }
 
 // isoWeekMonday возвращает дату понедельника ISO-недели (UTC 00:00:00).
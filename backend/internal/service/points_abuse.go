@@ -0,0 +1,298 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"ithozyeva/database"
+	"ithozyeva/internal/i18n"
+	"ithozyeva/internal/models"
+	"ithozyeva/internal/repository"
+
+	"gorm.io/gorm"
+)
+
+const (
+	kudosRingWindow    = 7 * 24 * time.Hour
+	earningBurstWindow = time.Hour
+	casinoStreakWindow = 24 * time.Hour
+	pointsFlagPageMax  = 100
+)
+
+// earningBurstIgnored — крупные разовые начисления, которые всплеском не
+// считаем: выигрыши казино ловит отдельный детектор, остальное выдаёт
+// система или админ.
+var earningBurstIgnored = []string{
+	string(models.PointReasonCasinoWin),
+	string(models.PointReasonCasinoJackpot),
+	string(models.PointReasonShopRefund),
+	string(models.PointReasonLeaderboardPrize),
+	string(models.PointReasonAdminManual),
+	string(models.PointReasonDailyRaffleWin),
+	string(models.PointReasonDailyStreak30),
+}
+
+var ErrPointsFlagNotFound = errors.New("флаг не найден")
+
+// PointsAbuseError — отказ в списании (текст — для админа).
+type PointsAbuseError struct{ i18n.Message }
+
+func (e *PointsAbuseError) Error() string { return e.String() }
+
+func abuseRefused(format string, args ...interface{}) error {
+	return &PointsAbuseError{i18n.Msg(format, args...)}
+}
+
+// PointsAbuseService — детектор подозрительных начислений и очередь флагов
+// для админов со списанием (clawback) выбранных транзакций.
+type PointsAbuseService struct {
+	repo      *repository.PointsFlagRepository
+	pointRepo *repository.PointsRepository
+	settings  *AppSettingsService
+}
+
+func NewPointsAbuseService() *PointsAbuseService {
+	return &PointsAbuseService{
+		repo:      repository.NewPointsFlagRepository(),
+		pointRepo: repository.NewPointsRepository(),
+		settings:  NewAppSettingsService(),
+	}
+}
+
+// Scan прогоняет детекторы и заводит новые флаги. Повторный прогон в том
+// же окне флаги не дублирует (dedup_key). Возвращает число новых флагов.
+func (s *PointsAbuseService) Scan(now time.Time) int {
+	created := 0
+	add := func(flag *models.PointsFlag) {
+		ok, err := s.repo.Create(flag)
+		if err != nil {
+			log.Printf("points flag %s: %v", flag.DedupKey, err)
+			return
+		}
+		if ok {
+			created++
+		}
+	}
+
+	// Взаимные kudos: ключ — пара и ISO-неделя, чтобы устойчивая пара
+	// всплывала раз в неделю, а не каждый час.
+	year, week := now.ISOWeek()
+	rings, err := s.repo.KudosRings(now.Add(-kudosRingWindow), s.settings.GetInt("anomaly_kudos_ring_min", 3))
+	if err != nil {
+		log.Printf("points anomaly kudos rings: %v", err)
+	}
+	for _, p := range rings {
+		related := p.RelatedMemberId
+		add(&models.PointsFlag{
+			Kind:            models.PointsFlagKudosRing,
+			MemberId:        p.MemberId,
+			RelatedMemberId: &related,
+			Score:           p.Sent + p.Received,
+			Details:         fmt.Sprintf("Взаимные благодарности за 7 дней: %d туда, %d обратно", p.Sent, p.Received),
+			DedupKey:        fmt.Sprintf("%s:%d:%d:%d-W%02d", models.PointsFlagKudosRing, p.MemberId, related, year, week),
+			WindowStart:     now.Add(-kudosRingWindow),
+			WindowEnd:       now,
+		})
+	}
+
+	// Всплеск заработка: полный прошедший час.
+	end := now.Truncate(time.Hour)
+	start := end.Add(-earningBurstWindow)
+	bursts, err := s.repo.EarningBursts(start, end, s.settings.GetInt("anomaly_burst_points", 300), earningBurstIgnored)
+	if err != nil {
+		log.Printf("points anomaly bursts: %v", err)
+	}
+	for _, b := range bursts {
+		add(&models.PointsFlag{
+			Kind:        models.PointsFlagEarningBurst,
+			MemberId:    b.MemberId,
+			Score:       b.Total,
+			Details:     fmt.Sprintf("%d баллов за час (%d начислений)", b.Total, b.Count),
+			DedupKey:    fmt.Sprintf("%s:%d:%d", models.PointsFlagEarningBurst, b.MemberId, start.Unix()),
+			WindowStart: start,
+			WindowEnd:   end,
+		})
+	}
+
+	// Серия выигрышей казино: одна серия — один флаг (по первой ставке).
+	streaks, err := s.repo.CasinoStreaks(now.Add(-casinoStreakWindow), s.settings.GetInt("anomaly_casino_streak", 8))
+	if err != nil {
+		log.Printf("points anomaly casino streaks: %v", err)
+	}
+	for _, st := range streaks {
+		add(&models.PointsFlag{
+			Kind:        models.PointsFlagCasinoStreak,
+			MemberId:    st.MemberId,
+			Score:       st.Streak,
+			Details:     fmt.Sprintf("%d выигрышей подряд, +%d баллов", st.Streak, st.Won),
+			DedupKey:    fmt.Sprintf("%s:%d:%d", models.PointsFlagCasinoStreak, st.MemberId, st.FirstBetId),
+			WindowStart: st.StartedAt,
+			WindowEnd:   st.EndedAt,
+		})
+	}
+
+	if created > 0 {
+		log.Printf("points anomaly scan: %d new flags", created)
+	}
+	return created
+}
+
+func (s *PointsAbuseService) Search(status string, limit, offset int) ([]models.AdminPointsFlag, int64, error) {
+	if limit <= 0 || limit > pointsFlagPageMax {
+		limit = 20
+	}
+	if offset < 0 {
+		offset = 0
+	}
+	return s.repo.Search(status, limit, offset)
+}
+
+// flagMembers — участники флага: сам и второй в паре.
+func flagMembers(flag *models.PointsFlag) []int64 {
+	ids := []int64{flag.MemberId}
+	if flag.RelatedMemberId != nil {
+		ids = append(ids, *flag.RelatedMemberId)
+	}
+	return ids
+}
+
+// Detail — флаг и транзакции его участников за окно сигнала.
+func (s *PointsAbuseService) Detail(id int64) (*models.PointsFlagDetail, error) {
+	flag, err := s.repo.Get(id)
+	if err != nil {
+		return nil, err
+	}
+	if flag == nil {
+		return nil, ErrPointsFlagNotFound
+	}
+	txs, err := s.repo.WindowTransactions(flagMembers(&flag.PointsFlag), flag.WindowStart, flag.WindowEnd)
+	if err != nil {
+		return nil, err
+	}
+	return &models.PointsFlagDetail{Flag: *flag, Transactions: txs}, nil
+}
+
+// Clawback списывает выбранные начисления участников флага сторно-записями
+// (PointReasonClawback, reverses_id — исходная транзакция) и закрывает
+// флаг. Всё или ничего: одна неподходящая транзакция — отказ.
+func (s *PointsAbuseService) Clawback(flagId int64, ids []int64, reason string, actorId int64, actorName string) ([]models.PointsClawback, error) {
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return nil, abuseRefused("укажите причину списания")
+	}
+	ids = uniqueInt64(ids)
+	if len(ids) == 0 {
+		return nil, abuseRefused("выберите транзакции для списания")
+	}
+
+	var out []models.PointsClawback
+	err := database.DB.Transaction(func(tx *gorm.DB) error {
+		flag, err := s.repo.GetForUpdateTx(tx, flagId)
+		if err != nil {
+			return err
+		}
+		if flag == nil {
+			return ErrPointsFlagNotFound
+		}
+		if flag.Status != models.PointsFlagOpen {
+			return abuseRefused("флаг уже закрыт")
+		}
+		txs, err := s.repo.TransactionsTx(tx, ids)
+		if err != nil {
+			return err
+		}
+		found := make(map[int64]bool, len(txs))
+		for _, t := range txs {
+			found[t.Id] = true
+		}
+		for _, id := range ids {
+			if !found[id] {
+				return abuseRefused("транзакция #%d не найдена", id)
+			}
+		}
+		reversed, err := s.pointRepo.ReversedIdsTx(tx, ids)
+		if err != nil {
+			return err
+		}
+		if len(reversed) > 0 {
+			return abuseRefused("транзакция #%d уже отменена", reversed[0])
+		}
+
+		members := flagMembers(flag)
+		for _, t := range txs {
+			if !containsInt64(members, t.MemberId) {
+				return abuseRefused("транзакция #%d не относится к участникам флага", t.Id)
+			}
+			if t.Amount <= 0 {
+				return abuseRefused("списать можно только начисление (#%d)", t.Id)
+			}
+			if t.ReversesId != nil {
+				return abuseRefused("списать можно только начисление (#%d)", t.Id)
+			}
+			if _, err := s.pointRepo.ReverseTx(tx, &t, models.PointReasonClawback,
+				fmt.Sprintf("Списание начисления #%d: %s", t.Id, reason)); err != nil {
+				return err
+			}
+			out = append(out, models.PointsClawback{TransactionId: t.Id, MemberId: t.MemberId, Amount: t.Amount, Reason: t.Reason})
+		}
+		return s.repo.ResolveTx(tx, flag, models.PointsFlagClawedBack, actorId, actorName, reason, time.Now())
+	})
+	if err != nil {
+		return nil, err
+	}
+	notified := make(map[int64]bool)
+	for _, c := range out {
+		if !notified[c.MemberId] {
+			notified[c.MemberId] = true
+			GetSSEHub().Publish(c.MemberId, SSEEvent{Type: "points"})
+		}
+	}
+	return out, nil
+}
+
+// Dismiss закрывает флаг как ложный.
+func (s *PointsAbuseService) Dismiss(flagId int64, note string, actorId int64, actorName string) (*models.PointsFlag, error) {
+	var flag *models.PointsFlag
+	err := database.DB.Transaction(func(tx *gorm.DB) error {
+		var err error
+		flag, err = s.repo.GetForUpdateTx(tx, flagId)
+		if err != nil {
+			return err
+		}
+		if flag == nil {
+			return ErrPointsFlagNotFound
+		}
+		if flag.Status != models.PointsFlagOpen {
+			return abuseRefused("флаг уже закрыт")
+		}
+		return s.repo.ResolveTx(tx, flag, models.PointsFlagDismissed, actorId, actorName, strings.TrimSpace(note), time.Now())
+	})
+	if err != nil {
+		return nil, err
+	}
+	return flag, nil
+}
+
+func uniqueInt64(in []int64) []int64 {
+	seen := make(map[int64]bool, len(in))
+	out := make([]int64, 0, len(in))
+	for _, v := range in {
+		if v > 0 && !seen[v] {
+			seen[v] = true
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+func containsInt64(list []int64, v int64) bool {
+	for _, x := range list {
+		if x == v {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,86 @@
+package service
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"ithozyeva/internal/models"
+	"ithozyeva/internal/testutil"
+)
+
+// TestPointsService_DailyCap — kudos_received режется дневным лимитом
+// points_caps (25 в сутки при 5 за благодарность).
+func TestPointsService_DailyCap(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	testutil.TruncateAll(t, db, "point_transactions", "members")
+
+	member := seedMember(t, db, 7301)
+	svc := NewPointsService()
+	for i := int64(1); i <= 7; i++ {
+		svc.GiveForAction(member.Id, models.PointReasonKudosReceived, "kudos", i, "Благодарность от участника")
+	}
+	if got := balanceOf(t, member.Id); got != 25 {
+		t.Errorf("balance = %d, want capped 25", got)
+	}
+	// Reason без лимита не режется.
+	svc.GiveForAction(member.Id, models.PointReasonEventAttend, "event", 1, "Участие")
+	if got := balanceOf(t, member.Id); got != 35 {
+		t.Errorf("balance = %d, want 35", got)
+	}
+}
+
+// TestPointsAbuseService_KudosRingAndClawback — взаимные kudos попадают в
+// очередь, списание возвращает баланс и закрывает флаг.
+func TestPointsAbuseService_KudosRingAndClawback(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	testutil.TruncateAll(t, db, "points_flags", "kudos", "point_transactions", "members")
+
+	a := seedMember(t, db, 7311)
+	b := seedMember(t, db, 7312)
+	for i := 0; i < 3; i++ {
+		for _, k := range []models.Kudos{{FromId: a.Id, ToId: b.Id, Message: "спасибо"}, {FromId: b.Id, ToId: a.Id, Message: "и тебе"}} {
+			if err := db.Omit("From", "To").Create(&k).Error; err != nil {
+				t.Fatalf("create kudos: %v", err)
+			}
+		}
+	}
+	tx := &models.PointTransaction{MemberId: b.Id, Amount: 5, Reason: models.PointReasonKudosReceived, SourceType: "kudos", SourceId: 1}
+	if err := db.Create(tx).Error; err != nil {
+		t.Fatalf("seed points: %v", err)
+	}
+
+	svc := NewPointsAbuseService()
+	if n := svc.Scan(time.Now()); n != 1 {
+		t.Fatalf("Scan created %d flags, want 1", n)
+	}
+	if n := svc.Scan(time.Now()); n != 0 {
+		t.Fatalf("повторный Scan создал %d флагов", n)
+	}
+	flags, _, err := svc.Search(models.PointsFlagOpen, 10, 0)
+	if err != nil || len(flags) != 1 {
+		t.Fatalf("Search: %v, %d flags", err, len(flags))
+	}
+	flag := flags[0]
+	if flag.Kind != models.PointsFlagKudosRing || flag.MemberId != a.Id || flag.RelatedMemberId == nil || *flag.RelatedMemberId != b.Id {
+		t.Fatalf("unexpected flag: %+v", flag)
+	}
+
+	var refused *PointsAbuseError
+	if _, err := svc.Clawback(flag.Id, []int64{tx.Id}, "", 1, "admin"); !errors.As(err, &refused) {
+		t.Fatalf("без причины списание должно быть отклонено, got %v", err)
+	}
+	clawbacks, err := svc.Clawback(flag.Id, []int64{tx.Id}, "накрутка kudos", 1, "admin")
+	if err != nil {
+		t.Fatalf("Clawback: %v", err)
+	}
+	if len(clawbacks) != 1 || clawbacks[0].Amount != 5 {
+		t.Fatalf("unexpected clawbacks: %+v", clawbacks)
+	}
+	if got := balanceOf(t, b.Id); got != 0 {
+		t.Errorf("balance after clawback = %d, want 0", got)
+	}
+	if _, err := svc.Clawback(flag.Id, []int64{tx.Id}, "ещё раз", 1, "admin"); !errors.As(err, &refused) {
+		t.Fatalf("закрытый флаг должен отклонять списание, got %v", err)
+	}
+}
@@ -1,6 +1,7 @@
 package service
 
 import (
+	"errors"
 	"testing"
 	"time"
 
@@ -174,3 +175,83 @@ func TestGetBalance_SumsTransactions(t *testing.T) {
 		t.Errorf("balance = %d, want 40", got)
 	}
 }
+
+// TestPointsService_Reverse — сторно возвращает баланс, исходная запись
+// остаётся, повторная отмена и отмена сторно отклоняются.
+func TestPointsService_Reverse(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	testutil.TruncateAll(t, db, "point_transactions", "members")
+
+	m := seedMember(t, db, 8011)
+	svc := NewPointsService()
+	if err := svc.AdminAwardPoints(m.Id, 30, "test"); err != nil {
+		t.Fatalf("AdminAwardPoints: %v", err)
+	}
+	var orig models.PointTransaction
+	if err := db.Where("member_id = ?", m.Id).First(&orig).Error; err != nil {
+		t.Fatalf("load transaction: %v", err)
+	}
+
+	if _, err := svc.Reverse(orig.Id, "  "); !errors.Is(err, ErrPointReversalReason) {
+		t.Fatalf("без причины: got %v", err)
+	}
+	rev, err := svc.Reverse(orig.Id, "ошибочное начисление")
+	if err != nil {
+		t.Fatalf("Reverse: %v", err)
+	}
+	if rev.Amount != -30 || rev.ReversesId == nil || *rev.ReversesId != orig.Id {
+		t.Fatalf("unexpected reversal: %+v", rev)
+	}
+	if got := balanceOf(t, m.Id); got != 0 {
+		t.Errorf("balance after reverse = %d, want 0", got)
+	}
+	if n := countTransactions(t, db); n != 2 {
+		t.Errorf("transactions = %d, want 2 (исходная + сторно)", n)
+	}
+	if _, err := svc.Reverse(orig.Id, "ещё раз"); !errors.Is(err, ErrPointTransactionReversed) {
+		t.Errorf("повторная отмена: got %v", err)
+	}
+	if _, err := svc.Reverse(rev.Id, "отмена отмены"); !errors.Is(err, ErrPointReversalOfReversal) {
+		t.Errorf("отмена сторно: got %v", err)
+	}
+	if _, err := svc.Reverse(999999, "нет такой"); !errors.Is(err, ErrPointTransactionNotFound) {
+		t.Errorf("несуществующая: got %v", err)
+	}
+	if err := db.Delete(&models.PointTransaction{}, orig.Id).Error; err == nil {
+		t.Error("журнал должен запрещать DELETE")
+	}
+}
+
+// TestPointsService_ReconcileBalances — разошедшийся снапшот чинится по
+// журналу.
+func TestPointsService_ReconcileBalances(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	testutil.TruncateAll(t, db, "point_transactions", "members")
+
+	a := seedMember(t, db, 8021)
+	b := seedMember(t, db, 8022)
+	svc := NewPointsService()
+	for _, id := range []int64{a.Id, b.Id} {
+		if err := svc.AdminAwardPoints(id, 15, "test"); err != nil {
+			t.Fatalf("AdminAwardPoints: %v", err)
+		}
+	}
+	if err := db.Exec(`UPDATE member_point_balances SET balance = 999 WHERE member_id = ?`, b.Id).Error; err != nil {
+		t.Fatalf("corrupt snapshot: %v", err)
+	}
+
+	report, err := svc.ReconcileBalances()
+	if err != nil {
+		t.Fatalf("ReconcileBalances: %v", err)
+	}
+	if report.Checked != 2 || len(report.Mismatches) != 1 || report.Mismatches[0].MemberId != b.Id ||
+		report.Mismatches[0].Snapshot != 999 || report.Mismatches[0].Ledger != 15 {
+		t.Fatalf("unexpected report: %+v", report)
+	}
+	if got := balanceOf(t, b.Id); got != 15 {
+		t.Errorf("balance after reconcile = %d, want 15", got)
+	}
+	if report, err = svc.ReconcileBalances(); err != nil || len(report.Mismatches) != 0 {
+		t.Errorf("повторная сверка: %v, %+v", err, report)
+	}
+}
@@ -0,0 +1,138 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"time"
+
+	"ithozyeva/internal/models"
+	"ithozyeva/internal/repository"
+	"ithozyeva/internal/utils"
+)
+
+// Ежемесячная выписка по баллам: в первый день месяца с
+// statementPublishHourMSK бэкенд собирает выписки за прошлый месяц всем,
+// у кого были операции, бот рассылает их в личку.
+const statementPublishHourMSK = 12
+
+// ErrStatementMonth — неверный или ещё не начавшийся месяц выписки.
+var ErrStatementMonth = errors.New("неверный месяц выписки")
+
+type PointsStatementService struct {
+	repo *repository.PointsStatementRepository
+}
+
+func NewPointsStatementService() *PointsStatementService {
+	return &PointsStatementService{repo: repository.NewPointsStatementRepository()}
+}
+
+// StatementMonth — последний завершённый на now месяц [start, end) по МСК.
+func StatementMonth(now time.Time) (start, end time.Time) {
+	msk := now.In(utils.MSKLocation())
+	end = time.Date(msk.Year(), msk.Month(), 1, 0, 0, 0, 0, utils.MSKLocation())
+	return end.AddDate(0, -1, 0), end
+}
+
+// StatementDue — пора ли собирать выписки за StatementMonth(now): первого
+// числа — только после statementPublishHourMSK, дальше — всегда (догоняем,
+// если бэкенд лежал).
+func StatementDue(now time.Time) bool {
+	msk := now.In(utils.MSKLocation())
+	return msk.Day() != 1 || msk.Hour() >= statementPublishHourMSK
+}
+
+// ParseStatementMonth — начало месяца YYYY-MM по МСК; "" — текущий месяц.
+// Будущие месяцы — ErrStatementMonth.
+func ParseStatementMonth(month string, now time.Time) (time.Time, error) {
+	_, current := StatementMonth(now)
+	if month == "" {
+		return current, nil
+	}
+	start, err := time.ParseInLocation("2006-01", month, utils.MSKLocation())
+	if err != nil || start.After(current) {
+		return time.Time{}, ErrStatementMonth
+	}
+	return start, nil
+}
+
+// Build считает выписку участника за месяц, начинающийся в start.
+func (s *PointsStatementService) Build(memberId int64, start time.Time) (*models.PointsStatement, error) {
+	end := start.AddDate(0, 1, 0)
+	st := &models.PointsStatement{
+		MemberId: memberId,
+		Month:    start.Format("2006-01"),
+		From:     start,
+		To:       end,
+	}
+	var err error
+	if st.ByReason, err = s.repo.ReasonTotals(memberId, start, end); err != nil {
+		return nil, err
+	}
+	if st.Earned, st.Spent, err = s.repo.PointTotals(memberId, start, end); err != nil {
+		return nil, err
+	}
+	st.Net = st.Earned - st.Spent
+	if st.Balance, err = s.repo.BalanceAt(memberId, end); err != nil {
+		return nil, err
+	}
+	if st.CreditsEarned, st.CreditsSpent, err = s.repo.CreditTotals(memberId, start, end); err != nil {
+		return nil, err
+	}
+	return st, nil
+}
+
+// GenerateMonthly собирает недостающие выписки за прошлый месяц.
+// Идемпотентна (UNIQUE member_id+month): безопасно звать каждый час.
+func (s *PointsStatementService) GenerateMonthly(now time.Time) (int, error) {
+	if !StatementDue(now) {
+		return 0, nil
+	}
+	start, end := StatementMonth(now)
+	ids, err := s.repo.MembersDue(start, start, end)
+	if err != nil {
+		return 0, err
+	}
+	created := 0
+	for _, id := range ids {
+		st, err := s.Build(id, start)
+		if err != nil {
+			log.Printf("points statement member=%d: %v", id, err)
+			continue
+		}
+		payload, err := json.Marshal(st)
+		if err != nil {
+			return created, err
+		}
+		ok, err := s.repo.Create(&models.PointsStatementRecord{
+			MemberId: id,
+			Month:    start,
+			Payload:  string(payload),
+		})
+		if err != nil {
+			log.Printf("points statement save member=%d: %v", id, err)
+			continue
+		}
+		if ok {
+			created++
+		}
+	}
+	return created, nil
+}
+
+func (s *PointsStatementService) ListUnsent(limit int) ([]models.PointsStatementRecord, error) {
+	return s.repo.ListUnsent(limit)
+}
+
+func (s *PointsStatementService) MarkSent(id int64) (bool, error) {
+	return s.repo.MarkSent(id, time.Now())
+}
+
+// DecodeStatement — выписка из payload записи; nil — битый payload.
+func DecodeStatement(rec *models.PointsStatementRecord) *models.PointsStatement {
+	var st models.PointsStatement
+	if err := json.Unmarshal([]byte(rec.Payload), &st); err != nil {
+		return nil
+	}
+	return &st
+}
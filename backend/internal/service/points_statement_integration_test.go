@@ -0,0 +1,83 @@
+package service
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"ithozyeva/internal/models"
+	"ithozyeva/internal/testutil"
+	"ithozyeva/internal/utils"
+)
+
+// TestPointsStatement_GenerateAndExport — выписка за прошлый месяц
+// собирается один раз, выгрузка фильтрует по участнику, датам и причинам.
+func TestPointsStatement_GenerateAndExport(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	testutil.TruncateAll(t, db, "points_statements", "point_transactions", "referral_credit_transactions", "notification_settings", "members")
+
+	now := time.Date(2026, 10, 2, 15, 0, 0, 0, utils.MSKLocation())
+	start, end := StatementMonth(now)
+
+	alice := seedMember(t, db, 7301)
+	bob := seedMember(t, db, 7302)
+	seedPointsAt(t, db, alice.Id, 50, models.PointReasonEventHost, start.Add(-time.Hour))
+	seedPointsAt(t, db, alice.Id, 10, models.PointReasonEventAttend, start.Add(time.Hour))
+	seedPointsAt(t, db, alice.Id, 10, models.PointReasonEventAttend, start.Add(48*time.Hour))
+	seedPointsAt(t, db, alice.Id, -15, models.PointReasonCasinoBet, start.Add(72*time.Hour))
+	seedPointsAt(t, db, alice.Id, 99, models.PointReasonEventHost, end.Add(time.Hour))
+	// bob отключил выписку.
+	seedPointsAt(t, db, bob.Id, 10, models.PointReasonEventAttend, start.Add(time.Hour))
+	if err := db.Create(&models.NotificationSettings{MemberId: bob.Id}).Error; err != nil {
+		t.Fatalf("seed settings: %v", err)
+	}
+	if err := db.Exec("UPDATE notification_settings SET monthly_statement = FALSE WHERE member_id = ?", bob.Id).Error; err != nil {
+		t.Fatalf("opt out: %v", err)
+	}
+
+	svc := NewPointsStatementService()
+	n, err := svc.GenerateMonthly(now)
+	if err != nil || n != 1 {
+		t.Fatalf("GenerateMonthly = %d, %v; want 1", n, err)
+	}
+	if n, _ := svc.GenerateMonthly(now.Add(time.Hour)); n != 0 {
+		t.Fatalf("повторная генерация создала %d выписок", n)
+	}
+
+	recs, err := svc.ListUnsent(10)
+	if err != nil || len(recs) != 1 || recs[0].MemberId != alice.Id || recs[0].TelegramID != 7301 {
+		t.Fatalf("ListUnsent = %+v, %v", recs, err)
+	}
+	st := DecodeStatement(&recs[0])
+	if st == nil || st.Earned != 20 || st.Spent != 15 || st.Net != 5 || st.Balance != 55 {
+		t.Fatalf("statement = %+v", st)
+	}
+	if len(st.ByReason) != 2 || st.ByReason[0].Reason != models.PointReasonEventAttend || st.ByReason[0].Count != 2 {
+		t.Fatalf("by reason = %+v", st.ByReason)
+	}
+	if ok, _ := svc.MarkSent(recs[0].Id); !ok {
+		t.Fatal("MarkSent должен взять выписку")
+	}
+	if ok, _ := svc.MarkSent(recs[0].Id); ok {
+		t.Fatal("повторный MarkSent должен вернуть false")
+	}
+
+	filter, err := ParseLedgerExportFilter(models.LedgerPoints,
+		start.Format("2006-01-02"), end.AddDate(0, 0, -1).Format("2006-01-02"), "event_attend")
+	if err != nil {
+		t.Fatalf("filter: %v", err)
+	}
+	filter.MemberId = alice.Id
+	var buf bytes.Buffer
+	if err := NewLedgerExportService().Export(&buf, LedgerFormatJSON, filter); err != nil {
+		t.Fatalf("Export: %v", err)
+	}
+	var rows []models.LedgerExportRow
+	if err := json.Unmarshal(buf.Bytes(), &rows); err != nil {
+		t.Fatalf("export json: %v", err)
+	}
+	if len(rows) != 2 || rows[0].Amount != 10 || rows[0].MemberId != alice.Id || !rows[0].CreatedAt.Before(rows[1].CreatedAt) {
+		t.Fatalf("export rows = %+v", rows)
+	}
+}
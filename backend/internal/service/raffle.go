@@ -1,11 +1,15 @@
 package service
 
 import (
+	"errors"
 	"fmt"
 	"ithozyeva/database"
+	"ithozyeva/internal/i18n"
 	"ithozyeva/internal/models"
 	"ithozyeva/internal/repository"
 	"log"
+	"strings"
+	"time"
 
 	"gorm.io/gorm"
 )
@@ -15,6 +19,28 @@ import (
 // БД сотней тысяч раундтрипов и оставлял зомби-транзакции.
 const MaxBuyTicketsPerRequest = 10_000
 
+// Ограничения призовых мест одного розыгрыша.
+const (
+	maxRafflePrizes  = 20
+	maxRaffleWinners = 100
+)
+
+var (
+	ErrRaffleNotFound       = errors.New("розыгрыш не найден")
+	ErrRaffleNotDrawn       = errors.New("розыгрыш ещё не проведён")
+	ErrRaffleWinnerNotFound = errors.New("победитель не найден")
+)
+
+// RaffleError — розыгрыш или выдача приза отклонены; текст показывается
+// админу.
+type RaffleError struct{ i18n.Message }
+
+func (e *RaffleError) Error() string { return e.String() }
+
+func raffleRefused(format string, args ...interface{}) error {
+	return &RaffleError{i18n.Msg(format, args...)}
+}
+
 type RaffleService struct {
 	repo      *repository.RaffleRepository
 	pointRepo *repository.PointsRepository
@@ -35,8 +61,49 @@ func (s *RaffleService) GetAllAdmin() ([]models.Raffle, error) {
 	return s.repo.GetAllAdmin()
 }
 
+// Create создаёт розыгрыш с призовыми местами и фиксирует seed
+// жеребьёвки. Без списка призов единственным призом считается Prize.
 func (s *RaffleService) Create(raffle *models.Raffle) error {
-	return s.repo.Create(raffle)
+	prizes := make([]models.RafflePrize, 0, len(raffle.Prizes))
+	for _, p := range raffle.Prizes {
+		p.Title = strings.TrimSpace(p.Title)
+		if p.Title == "" {
+			return raffleRefused("у приза должно быть название")
+		}
+		if p.WinnersCount <= 0 {
+			p.WinnersCount = 1
+		}
+		prizes = append(prizes, p)
+	}
+	if len(prizes) == 0 {
+		title := strings.TrimSpace(raffle.Prize)
+		if title == "" {
+			return raffleRefused("укажите приз")
+		}
+		prizes = append(prizes, models.RafflePrize{Title: title, WinnersCount: 1})
+	}
+	if len(prizes) > maxRafflePrizes {
+		return raffleRefused("не больше %d призовых мест", maxRafflePrizes)
+	}
+	if len(raffleSlots(prizes)) > maxRaffleWinners {
+		return raffleRefused("не больше %d победителей в розыгрыше", maxRaffleWinners)
+	}
+	if strings.TrimSpace(raffle.Prize) == "" {
+		titles := make([]string, len(prizes))
+		for i, p := range prizes {
+			titles[i] = p.Title
+		}
+		raffle.Prize = strings.Join(titles, ", ")
+	}
+
+	serverSeed, err := randomHex(32)
+	if err != nil {
+		return err
+	}
+	raffle.ServerSeed = serverSeed
+	raffle.SeedHash = hashServerSeed(serverSeed)
+	raffle.Prizes = prizes
+	return s.repo.CreateWithPrizes(raffle)
 }
 
 func (s *RaffleService) Update(raffle *models.Raffle) error {
@@ -52,7 +119,7 @@ func (s *RaffleService) BuyTickets(raffleId, memberId int64, count int) error {
 		count = 1
 	}
 	if count > MaxBuyTicketsPerRequest {
-		return fmt.Errorf("за один запрос нельзя купить больше %d билетов", MaxBuyTicketsPerRequest)
+		return i18n.Errorf("за один запрос нельзя купить больше %d билетов", MaxBuyTicketsPerRequest)
 	}
 
 	raffle, err := s.repo.GetById(raffleId)
@@ -70,13 +137,6 @@ func (s *RaffleService) BuyTickets(raffleId, memberId int64, count int) error {
 		return fmt.Errorf("этот розыгрыш не поддерживает покупку билетов")
 	}
 
-	if raffle.MaxTickets > 0 {
-		total, _ := s.repo.GetTicketCount(raffleId)
-		if int(total)+count > raffle.MaxTickets {
-			return fmt.Errorf("превышен лимит билетов")
-		}
-	}
-
 	totalCost := raffle.TicketCost * count
 
 	// Балансовая проверка ВНУТРИ tx с pg_advisory_xact_lock(memberId) —
@@ -88,15 +148,33 @@ func (s *RaffleService) BuyTickets(raffleId, memberId int64, count int) error {
 		if err := tx.Exec(`SELECT pg_advisory_xact_lock(?)`, memberId).Error; err != nil {
 			return err
 		}
-		var balance int
-		if err := tx.Raw(
-			`SELECT COALESCE(SUM(amount), 0) FROM point_transactions WHERE member_id = ?`,
-			memberId,
-		).Scan(&balance).Error; err != nil {
+		// Статус, срок и лимит — по заблокированной строке: жеребьёвка
+		// (FOR UPDATE) не начнётся, пока покупка не закоммитится, а после
+		// неё или после ends_at билеты уже не продаются. С лимитом покупки
+		// одного розыгрыша идут по очереди, иначе две параллельные
+		// посчитают одинаковый остаток.
+		open, err := s.repo.LockOpenTx(tx, raffleId, raffle.MaxTickets > 0)
+		if err != nil {
+			return err
+		}
+		if open == nil {
+			return fmt.Errorf("розыгрыш завершён")
+		}
+		if open.MaxTickets > 0 {
+			total, err := s.repo.GetTicketCountTx(tx, raffleId)
+			if err != nil {
+				return err
+			}
+			if int(total)+count > open.MaxTickets {
+				return fmt.Errorf("превышен лимит билетов")
+			}
+		}
+		balance, err := s.pointRepo.GetBalanceTx(tx, memberId)
+		if err != nil {
 			return err
 		}
 		if balance < totalCost {
-			return fmt.Errorf("недостаточно баллов (нужно %d, доступно %d)", totalCost, balance)
+			return i18n.Errorf("недостаточно баллов (нужно %d, доступно %d)", totalCost, balance)
 		}
 		pt := &models.PointTransaction{
 			MemberId:    memberId,
@@ -130,39 +208,174 @@ func (s *RaffleService) DrawExpiredRaffles() {
 		return
 	}
 
-	dailySvc := NewDailyRaffleService()
-
-	for _, raffle := range raffles {
-		ticketCount, _ := s.repo.GetTicketCount(raffle.Id)
-		if ticketCount == 0 {
-			s.repo.FinishRaffle(raffle.Id, 0)
-			log.Printf("Raffle %d finished with no participants", raffle.Id)
-			continue
+	for i := range raffles {
+		if err := s.draw(&raffles[i]); err != nil {
+			log.Printf("Error drawing raffle %d: %v", raffles[i].Id, err)
 		}
+	}
+}
 
-		winnerId, err := s.repo.PickRandomWinner(raffle.Id)
+// prizesOf — призовые места розыгрыша; у daily-раффлов их нет, приз один.
+func (s *RaffleService) prizesOf(raffle *models.Raffle) ([]models.RafflePrize, error) {
+	prizes, err := s.repo.Prizes(raffle.Id)
+	if err != nil {
+		return nil, err
+	}
+	if len(prizes) == 0 {
+		prizes = []models.RafflePrize{{RaffleId: raffle.Id, Position: 1, Title: raffle.Prize, WinnersCount: 1}}
+	}
+	return prizes, nil
+}
+
+// draw проводит жеребьёвку одного истёкшего розыгрыша. Строка розыгрыша
+// блокируется FOR UPDATE, и участники читаются, победители считаются и
+// записываются в той же транзакции: билет, купленный параллельно, либо
+// попадает в жеребьёвку, либо не продаётся вовсе, поэтому DrawProof
+// воспроизводится по итоговому набору билетов.
+func (s *RaffleService) draw(expired *models.Raffle) error {
+	prizes, err := s.prizesOf(expired)
+	if err != nil {
+		return err
+	}
+
+	var raffle *models.Raffle
+	var winners []models.RaffleWinner
+	err = database.DB.Transaction(func(tx *gorm.DB) error {
+		if raffle, err = s.repo.LockForDrawTx(tx, expired.Id); err != nil || raffle == nil {
+			return err
+		}
+		entrants, err := s.repo.EntrantsTx(tx, raffle.Id)
 		if err != nil {
-			log.Printf("Error picking winner for raffle %d: %v", raffle.Id, err)
-			continue
+			return err
 		}
-
-		if err := s.repo.FinishRaffle(raffle.Id, winnerId); err != nil {
-			log.Printf("Error finishing raffle %d: %v", raffle.Id, err)
-			continue
+		// Розыгрыши, созданные до коммита seed'ов, разыгрываем на свежем.
+		if raffle.ServerSeed == "" {
+			if raffle.ServerSeed, err = randomHex(32); err != nil {
+				return err
+			}
+			raffle.SeedHash = hashServerSeed(raffle.ServerSeed)
 		}
+		now := time.Now()
+		raffle.DrawnAt = &now
 
-		log.Printf("Raffle %d winner: member %d", raffle.Id, winnerId)
+		winners = raffleWinners(raffle, prizes, entrants)
+		// Приз daily-раффла — баллы, они начисляются сразу.
+		if raffle.Kind == models.RaffleKindDaily {
+			for i := range winners {
+				winners[i].Status = models.RaffleWinnerFulfilled
+				winners[i].FulfilledAt = &now
+			}
+		}
+		finished, err := s.repo.FinishDrawTx(tx, raffle, winners)
+		if err == nil && !finished {
+			raffle = nil
+		}
+		return err
+	})
+	if err != nil || raffle == nil {
+		// nil — розыгрыш уже разыграл другой процесс.
+		return err
+	}
 
-		// Daily-раффл — выдаём приз победителю автоматически.
-		// Manual-розыгрыши обрабатываются админом отдельно (через UI/manual).
+	if len(winners) == 0 {
+		log.Printf("Raffle %d finished with no participants", raffle.Id)
+	}
+	for _, w := range winners {
+		log.Printf("Raffle %d place %d: member %d (%s)", raffle.Id, w.Place, w.MemberId, w.PrizeTitle)
 		if raffle.Kind == models.RaffleKindDaily {
-			if err := dailySvc.AwardWinPoints(winnerId, raffle.Id); err != nil {
+			if err := NewDailyRaffleService().AwardWinPoints(w.MemberId, raffle.Id); err != nil {
 				log.Printf("award daily-raffle win points (raffle=%d, member=%d): %v",
-					raffle.Id, winnerId, err)
+					raffle.Id, w.MemberId, err)
 			}
-			GetSSEHub().Publish(winnerId, SSEEvent{Type: "points"})
-			go PushDailyRaffleWin(winnerId, raffle.Prize)
+			GetSSEHub().Publish(w.MemberId, SSEEvent{Type: "points"})
+			go PushDailyRaffleWin(w.MemberId, raffle.Prize)
+			continue
+		}
+		go CreateNotification(w.MemberId, "raffle_win", "Вы выиграли в розыгрыше",
+			fmt.Sprintf("«%s»: %s. Организаторы свяжутся с вами для выдачи приза.", raffle.Title, w.PrizeTitle))
+	}
+	GetSSEHub().Broadcast(SSEEvent{Type: "raffles"})
+	return nil
+}
+
+// Winners — победители розыгрыша со статусом выдачи призов.
+func (s *RaffleService) Winners(raffleId int64) ([]models.RaffleWinner, error) {
+	return s.repo.Winners(raffleId)
+}
+
+// UpdateFulfillment отмечает выдачу приза победителю или возвращает её в
+// ожидание.
+func (s *RaffleService) UpdateFulfillment(winnerId int64, req *models.RaffleFulfillmentRequest) (*models.RaffleWinner, error) {
+	if req.Status != models.RaffleWinnerPending && req.Status != models.RaffleWinnerFulfilled {
+		return nil, raffleRefused("неизвестный статус выдачи: %s", req.Status)
+	}
+	winner, err := s.repo.GetWinner(winnerId)
+	if err != nil {
+		return nil, err
+	}
+	if winner == nil {
+		return nil, ErrRaffleWinnerNotFound
+	}
+	if err := s.repo.UpdateFulfillment(winnerId, req.Status, strings.TrimSpace(req.Note)); err != nil {
+		return nil, err
+	}
+	return s.repo.GetWinner(winnerId)
+}
+
+// Unannounced — розыгрыши, победителей которых бот ещё не опубликовал.
+func (s *RaffleService) Unannounced(limit int) ([]models.Raffle, error) {
+	return s.repo.Unannounced(limit)
+}
+
+// MarkAnnounced помечает розыгрыш опубликованным; false — его уже взял
+// другой инстанс бота.
+func (s *RaffleService) MarkAnnounced(id int64) (bool, error) {
+	return s.repo.MarkAnnounced(id)
+}
+
+// DrawProof раскрывает данные жеребьёвки и пересчитывает её.
+func (s *RaffleService) DrawProof(raffleId int64) (*models.RaffleDrawProof, error) {
+	raffle, err := s.repo.GetById(raffleId)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrRaffleNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if raffle.Status != models.RaffleStatusFinished || raffle.DrawnAt == nil || raffle.ServerSeed == "" {
+		return nil, ErrRaffleNotDrawn
+	}
+	prizes, err := s.prizesOf(raffle)
+	if err != nil {
+		return nil, err
+	}
+	entrants, err := s.repo.Entrants(raffleId)
+	if err != nil {
+		return nil, err
+	}
+	winners, err := s.repo.Winners(raffleId)
+	if err != nil {
+		return nil, err
+	}
+
+	computed := raffleWinners(raffle, prizes, entrants)
+	proof := &models.RaffleDrawProof{
+		RaffleId:    raffle.Id,
+		ServerSeed:  raffle.ServerSeed,
+		SeedHash:    raffle.SeedHash,
+		ClientSeed:  raffleClientSeed(raffle.Id),
+		HashMatches: hashServerSeed(raffle.ServerSeed) == raffle.SeedHash,
+		Entrants:    entrants,
+		Prizes:      prizes,
+		Winners:     winners,
+		Computed:    make([]int64, len(computed)),
+	}
+	proof.Valid = proof.HashMatches && len(computed) == len(winners)
+	for i, w := range computed {
+		proof.Computed[i] = w.MemberId
+		if proof.Valid && (winners[i].MemberId != w.MemberId || winners[i].PrizeTitle != w.PrizeTitle) {
+			proof.Valid = false
 		}
-		GetSSEHub().Broadcast(SSEEvent{Type: "raffles"})
 	}
+	return proof, nil
 }
@@ -0,0 +1,84 @@
+package service
+
+import (
+	"fmt"
+
+	"ithozyeva/internal/models"
+)
+
+// Жеребьёвка розыгрыша проверяема так же, как ставки мини-игр: server_seed
+// фиксируется при создании розыгрыша (наружу — только sha256), а после
+// розыгрыша раскрывается вместе со списком участников.
+//
+// Алгоритм: участники упорядочены по member_id, у каждого — число билетов.
+// Слот k (с нуля) разыгрывается числом fairRoll(server_seed,
+// "raffle:<id>", k, N), где N — билеты ещё не выигравших участников;
+// побеждает участник, в чей отрезок билетов попало число. Победитель
+// выбывает, призы раздаются слотам по порядку призовых мест.
+
+// raffleClientSeed — клиентская часть HMAC для жеребьёвки розыгрыша.
+func raffleClientSeed(raffleId int64) string {
+	return fmt.Sprintf("raffle:%d", raffleId)
+}
+
+// drawRaffleWinners вытягивает до slots победителей без возвращения,
+// с вероятностью пропорционально билетам. Возвращает их в порядке
+// вытягивания.
+func drawRaffleWinners(serverSeed string, raffleId int64, entrants []models.RaffleEntrant, slots int) []models.RaffleEntrant {
+	pool := make([]models.RaffleEntrant, 0, len(entrants))
+	total := 0
+	for _, e := range entrants {
+		if e.Tickets > 0 {
+			pool = append(pool, e)
+			total += e.Tickets
+		}
+	}
+	clientSeed := raffleClientSeed(raffleId)
+	winners := make([]models.RaffleEntrant, 0, slots)
+	for k := 0; k < slots && len(pool) > 0; k++ {
+		roll := fairRoll(serverSeed, clientSeed, k, total)
+		i := 0
+		for ; i < len(pool)-1 && roll >= pool[i].Tickets; i++ {
+			roll -= pool[i].Tickets
+		}
+		winners = append(winners, pool[i])
+		total -= pool[i].Tickets
+		pool = append(pool[:i], pool[i+1:]...)
+	}
+	return winners
+}
+
+// raffleSlots — призы по слотам: каждый приз повторяется WinnersCount раз.
+func raffleSlots(prizes []models.RafflePrize) []models.RafflePrize {
+	var slots []models.RafflePrize
+	for _, p := range prizes {
+		for i := 0; i < p.WinnersCount; i++ {
+			slots = append(slots, p)
+		}
+	}
+	return slots
+}
+
+// raffleWinners раздаёт призы вытянутым победителям.
+func raffleWinners(raffle *models.Raffle, prizes []models.RafflePrize, entrants []models.RaffleEntrant) []models.RaffleWinner {
+	slots := raffleSlots(prizes)
+	drawn := drawRaffleWinners(raffle.ServerSeed, raffle.Id, entrants, len(slots))
+	winners := make([]models.RaffleWinner, len(drawn))
+	for k, e := range drawn {
+		prize := slots[k]
+		w := models.RaffleWinner{
+			RaffleId:   raffle.Id,
+			PrizeTitle: prize.Title,
+			Place:      k + 1,
+			MemberId:   e.MemberId,
+			Tickets:    e.Tickets,
+			Status:     models.RaffleWinnerPending,
+		}
+		if prize.Id != 0 {
+			id := prize.Id
+			w.PrizeId = &id
+		}
+		winners[k] = w
+	}
+	return winners
+}
@@ -0,0 +1,73 @@
+package service
+
+import (
+	"testing"
+
+	"ithozyeva/internal/models"
+)
+
+func TestDrawRaffleWinners_WithoutReplacement(t *testing.T) {
+	entrants := []models.RaffleEntrant{
+		{MemberId: 1, Tickets: 5},
+		{MemberId: 2, Tickets: 1},
+		{MemberId: 3, Tickets: 0},
+		{MemberId: 4, Tickets: 3},
+	}
+	got := drawRaffleWinners("seed", 7, entrants, 10)
+	if len(got) != 3 {
+		t.Fatalf("winners = %d, want 3 (участник без билетов не участвует)", len(got))
+	}
+	seen := map[int64]bool{}
+	for _, w := range got {
+		if w.MemberId == 3 {
+			t.Fatalf("победил участник без билетов")
+		}
+		if seen[w.MemberId] {
+			t.Fatalf("участник %d выиграл дважды", w.MemberId)
+		}
+		seen[w.MemberId] = true
+	}
+
+	// Тот же seed — тот же результат: жеребьёвку можно пересчитать.
+	again := drawRaffleWinners("seed", 7, entrants, 10)
+	for i := range got {
+		if got[i] != again[i] {
+			t.Fatalf("жеребьёвка не детерминирована: %v vs %v", got, again)
+		}
+	}
+}
+
+func TestDrawRaffleWinners_WeightedByTickets(t *testing.T) {
+	entrants := []models.RaffleEntrant{{MemberId: 1, Tickets: 9}, {MemberId: 2, Tickets: 1}}
+	first := 0
+	const runs = 2000
+	for i := 0; i < runs; i++ {
+		seed, _ := randomHex(16)
+		if drawRaffleWinners(seed, 1, entrants, 1)[0].MemberId == 1 {
+			first++
+		}
+	}
+	// Ожидаем ~90%; допуск широкий, чтобы тест не мигал.
+	if first < runs*85/100 || first > runs*95/100 {
+		t.Fatalf("участник с 9 из 10 билетов победил в %d из %d", first, runs)
+	}
+}
+
+func TestRaffleWinners_AssignsPrizesInOrder(t *testing.T) {
+	raffle := &models.Raffle{Id: 3, ServerSeed: "s"}
+	prizes := []models.RafflePrize{
+		{Id: 10, Position: 1, Title: "Худи", WinnersCount: 1},
+		{Id: 11, Position: 2, Title: "Стикеры", WinnersCount: 2},
+	}
+	entrants := []models.RaffleEntrant{{MemberId: 1, Tickets: 1}, {MemberId: 2, Tickets: 2}}
+	got := raffleWinners(raffle, prizes, entrants)
+	if len(got) != 2 {
+		t.Fatalf("winners = %d, want 2: участников меньше, чем мест", len(got))
+	}
+	if got[0].PrizeTitle != "Худи" || *got[0].PrizeId != 10 || got[0].Place != 1 {
+		t.Errorf("первое место: %+v", got[0])
+	}
+	if got[1].PrizeTitle != "Стикеры" || got[1].Place != 2 || got[1].Status != models.RaffleWinnerPending {
+		t.Errorf("второе место: %+v", got[1])
+	}
+}
@@ -0,0 +1,149 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"ithozyeva/internal/models"
+	"ithozyeva/internal/testutil"
+)
+
+// TestRaffleService_MultiPrizeDraw — розыгрыш с двумя призами: победители
+// различны, жеребьёвка пересчитывается по раскрытому seed'у, выдача приза
+// отмечается админом.
+func TestRaffleService_MultiPrizeDraw(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	testutil.TruncateAll(t, db, "raffle_winners", "raffle_prizes", "raffle_tickets", "raffles", "point_transactions", "members")
+
+	svc := NewRaffleService()
+	raffle := &models.Raffle{
+		Title:      "Мерч",
+		TicketCost: 10,
+		EndsAt:     time.Now().Add(time.Hour),
+		Status:     models.RaffleStatusActive,
+		Kind:       models.RaffleKindManual,
+		EntryRule:  models.RaffleEntryRulePurchase,
+		Prizes: []models.RafflePrize{
+			{Title: "Худи", WinnersCount: 1},
+			{Title: "Стикеры", WinnersCount: 2},
+		},
+	}
+	if err := svc.Create(raffle); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if raffle.SeedHash == "" || raffle.Prize != "Худи, Стикеры" {
+		t.Fatalf("после создания: hash=%q prize=%q", raffle.SeedHash, raffle.Prize)
+	}
+
+	for i, tickets := range []int{3, 1, 2, 5} {
+		m := seedMember(t, db, int64(7701+i))
+		creditPoints(t, db, m.Id, 100)
+		if err := svc.BuyTickets(raffle.Id, m.Id, tickets); err != nil {
+			t.Fatalf("BuyTickets: %v", err)
+		}
+	}
+
+	if _, err := svc.DrawProof(raffle.Id); err != ErrRaffleNotDrawn {
+		t.Fatalf("проверка до розыгрыша: got %v", err)
+	}
+
+	db.Model(&models.Raffle{}).Where("id = ?", raffle.Id).Update("ends_at", time.Now().Add(-time.Minute))
+	svc.DrawExpiredRaffles()
+	// Повторный запуск не переигрывает завершённый розыгрыш.
+	svc.DrawExpiredRaffles()
+
+	winners, err := svc.Winners(raffle.Id)
+	if err != nil {
+		t.Fatalf("Winners: %v", err)
+	}
+	if len(winners) != 3 {
+		t.Fatalf("winners = %d, want 3", len(winners))
+	}
+	if winners[0].PrizeTitle != "Худи" || winners[1].PrizeTitle != "Стикеры" || winners[2].PrizeTitle != "Стикеры" {
+		t.Fatalf("призы по местам: %+v", winners)
+	}
+	if winners[0].MemberId == winners[1].MemberId || winners[1].MemberId == winners[2].MemberId || winners[0].MemberId == winners[2].MemberId {
+		t.Fatalf("участник выиграл дважды: %+v", winners)
+	}
+
+	proof, err := svc.DrawProof(raffle.Id)
+	if err != nil {
+		t.Fatalf("DrawProof: %v", err)
+	}
+	if !proof.Valid || !proof.HashMatches || proof.SeedHash != raffle.SeedHash || len(proof.Entrants) != 4 {
+		t.Fatalf("proof: %+v", proof)
+	}
+
+	updated, err := svc.UpdateFulfillment(winners[0].Id, &models.RaffleFulfillmentRequest{
+		Status: models.RaffleWinnerFulfilled, Note: "отправлено почтой",
+	})
+	if err != nil {
+		t.Fatalf("UpdateFulfillment: %v", err)
+	}
+	if updated.Status != models.RaffleWinnerFulfilled || updated.FulfilledAt == nil || updated.FulfillmentNote != "отправлено почтой" {
+		t.Fatalf("выдача: %+v", updated)
+	}
+
+	pending, err := svc.Unannounced(10)
+	if err != nil || len(pending) != 1 {
+		t.Fatalf("Unannounced: %v, %v", pending, err)
+	}
+	if ok, _ := svc.MarkAnnounced(raffle.Id); !ok {
+		t.Fatal("MarkAnnounced: false")
+	}
+	if ok, _ := svc.MarkAnnounced(raffle.Id); ok {
+		t.Fatal("повторный MarkAnnounced должен вернуть false")
+	}
+}
+
+// TestRaffleService_BuyTicketsClosed — после ends_at и после жеребьёвки
+// билеты не продаются и баллы не списываются; лимит билетов соблюдается.
+func TestRaffleService_BuyTicketsClosed(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	testutil.TruncateAll(t, db, "raffle_winners", "raffle_prizes", "raffle_tickets", "raffles", "point_transactions", "members")
+
+	svc := NewRaffleService()
+	raffle := &models.Raffle{
+		Title:      "Худи",
+		TicketCost: 10,
+		MaxTickets: 3,
+		EndsAt:     time.Now().Add(time.Hour),
+		Status:     models.RaffleStatusActive,
+		Kind:       models.RaffleKindManual,
+		EntryRule:  models.RaffleEntryRulePurchase,
+		Prizes:     []models.RafflePrize{{Title: "Худи", WinnersCount: 1}},
+	}
+	if err := svc.Create(raffle); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	m := seedMember(t, db, 7801)
+	creditPoints(t, db, m.Id, 100)
+
+	if err := svc.BuyTickets(raffle.Id, m.Id, 2); err != nil {
+		t.Fatalf("BuyTickets: %v", err)
+	}
+	if err := svc.BuyTickets(raffle.Id, m.Id, 2); err == nil {
+		t.Fatal("лимит билетов не сработал")
+	}
+
+	// Срок вышел, но cron ещё не разыграл — продажа закрыта.
+	db.Model(&models.Raffle{}).Where("id = ?", raffle.Id).Update("ends_at", time.Now().Add(-time.Minute))
+	if err := svc.BuyTickets(raffle.Id, m.Id, 1); err == nil {
+		t.Fatal("билет продан после ends_at")
+	}
+	if awarded, err := svc.repo.AwardTicketTx(db, raffle.Id, m.Id, models.RaffleTicketSourceCheckIn, raffle.Id); err != nil || awarded {
+		t.Fatalf("билет выдан после ends_at: %v, %v", awarded, err)
+	}
+	if count, _ := svc.repo.GetTicketCount(raffle.Id); count != 2 {
+		t.Fatalf("билетов = %d, want 2", count)
+	}
+	if balance, _ := svc.pointRepo.GetBalance(m.Id); balance != 80 {
+		t.Fatalf("баланс = %d, want 80", balance)
+	}
+
+	svc.DrawExpiredRaffles()
+	proof, err := svc.DrawProof(raffle.Id)
+	if err != nil || !proof.Valid || len(proof.Entrants) != 1 || proof.Entrants[0].Tickets != 2 {
+		t.Fatalf("proof: %+v, %v", proof, err)
+	}
+}
@@ -0,0 +1,101 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"sync"
+	"time"
+)
+
+// Lease в Redis для задач, которые должны идти в одном экземпляре на все
+// реплики: фоновые задачи бота, цикл раундов crash. Держатель продлевает
+// lease каждые ReplicaLeaseRenew; упавший отдаёт его через ReplicaLeaseTTL.
+const (
+	ReplicaLeaseTTL   = 30 * time.Second
+	ReplicaLeaseRenew = 10 * time.Second
+
+	// CrashLeaderKey — раунды crash крутит одна API-реплика.
+	CrashLeaderKey = "casino:crash:leader"
+)
+
+type ReplicaLease struct {
+	name      string
+	key       string
+	replicaID string
+	acquire   func(ctx context.Context, key, replicaID string, ttl time.Duration) (bool, error)
+
+	mu    sync.Mutex
+	until time.Time // держим, пока не истёк подтверждённый lease
+}
+
+// NewReplicaLease — lease на key; name — для логов.
+func NewReplicaLease(replicas *BotReplicaService, key, name string) *ReplicaLease {
+	host, _ := os.Hostname()
+	return &ReplicaLease{
+		name:      name,
+		key:       key,
+		replicaID: fmt.Sprintf("%s-%d-%d", host, os.Getpid(), time.Now().UnixNano()),
+		acquire:   replicas.AcquireLease,
+	}
+}
+
+// Held — держит ли реплика lease прямо сейчас.
+func (l *ReplicaLease) Held() bool {
+	return l.heldAt(time.Now())
+}
+
+func (l *ReplicaLease) heldAt(now time.Time) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return now.Before(l.until)
+}
+
+// update фиксирует результат попытки, начатой в startedAt. При ошибке Redis
+// lease не трогаем: он ещё действителен до until, а дальше его заберёт
+// другая реплика — и мы перестанем считать себя держателем сами.
+func (l *ReplicaLease) update(ok bool, err error, startedAt time.Time) (changed bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	was := startedAt.Before(l.until)
+	switch {
+	case err != nil:
+		return false
+	case ok:
+		l.until = startedAt.Add(ReplicaLeaseTTL)
+	default:
+		l.until = time.Time{}
+	}
+	return was != ok
+}
+
+func (l *ReplicaLease) renew() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	startedAt := time.Now()
+	ok, err := l.acquire(ctx, l.key, l.replicaID, ReplicaLeaseTTL)
+	if err != nil {
+		log.Printf("%s: renew lease: %v", l.name, err)
+	}
+	if l.update(ok, err, startedAt) {
+		if ok {
+			log.Printf("%s: replica %s is now the leader", l.name, l.replicaID)
+		} else {
+			log.Printf("%s: replica %s lost leadership", l.name, l.replicaID)
+		}
+	}
+}
+
+// Start делает первую попытку синхронно — чтобы задачи одиночной реплики
+// не пропустили стартовый прогон — и дальше продлевает lease в фоне.
+func (l *ReplicaLease) Start() {
+	l.renew()
+	go func() {
+		ticker := time.NewTicker(ReplicaLeaseRenew)
+		defer ticker.Stop()
+		for range ticker.C {
+			l.renew()
+		}
+	}()
+}
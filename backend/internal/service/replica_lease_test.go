@@ -0,0 +1,29 @@
+package service
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestReplicaLease(t *testing.T) {
+	l := &ReplicaLease{}
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	if l.heldAt(now) {
+		t.Fatal("new replica is not a leader")
+	}
+	if !l.update(true, nil, now) || !l.heldAt(now.Add(ReplicaLeaseRenew)) {
+		t.Fatal("acquired lease must make the replica leader")
+	}
+	// Ошибка Redis: лидерство держится до конца подтверждённого lease.
+	later := now.Add(ReplicaLeaseRenew)
+	if l.update(false, errors.New("timeout"), later) || !l.heldAt(later) {
+		t.Fatal("redis error must not drop a valid lease")
+	}
+	if l.heldAt(now.Add(ReplicaLeaseTTL)) {
+		t.Fatal("expired lease must not be held")
+	}
+	if !l.update(false, nil, later) || l.heldAt(later) {
+		t.Fatal("lease taken by another replica must drop leadership")
+	}
+}
@@ -0,0 +1,96 @@
+package service
+
+import (
+	"errors"
+	"math"
+	"time"
+
+	"ithozyeva/internal/repository"
+)
+
+// Репутация участника в чате — основа веса голоса в voteban. Шкала 0..100:
+//
+//	стаж      — до 30 баллов (линейно до 180 дней)
+//	тир       — 10 за уровень, до 30
+//	баллы     — до 20 (линейно до 500 баллов)
+//	сообщения — до 20 (линейно до 200 сообщений в этом чате за 90 дней)
+//
+// Вес голоса = 0.5 + 1.5 × score/100, т.е. от 0.5 (новичок без подписки) до
+// 2.0. Пара свежих аккаунтов таким образом весит как один старожил.
+const (
+	reputationAgeFull      = 180 * 24 * time.Hour
+	reputationAgeMax       = 30
+	reputationTierPerLevel = 10
+	reputationTierMax      = 30
+	reputationPointsFull   = 500
+	reputationPointsMax    = 20
+	reputationMsgsFull     = 200
+	reputationMsgsMax      = 20
+
+	// ReputationMessagesWindow — окно подсчёта сообщений; совпадает с
+	// retention chat_messages (CleanupOldMessages(90)).
+	ReputationMessagesWindow = 90 * 24 * time.Hour
+
+	VoteWeightMin = 0.5
+	VoteWeightMax = 2.0
+
+	// VoterMinTenure — голосовать и запускать /voteban можно только спустя
+	// 3 дня после первого появления (регистрация или первое сообщение в чате).
+	VoterMinTenure = 72 * time.Hour
+
+	// VotebanProtectedScore — с такой репутацией участника нельзя вынести
+	// на голосование: спорные случаи со старожилами решают модераторы.
+	VotebanProtectedScore = 70
+)
+
+var ErrVoterIneligible = errors.New("voteban: голосующий не проходит по стажу")
+
+// Reputation — итог расчёта для одного участника в одном чате.
+type Reputation struct {
+	Score     int     `json:"score"`
+	Weight    float64 `json:"weight"`
+	Eligible  bool    `json:"eligible"`  // может голосовать / запускать voteban
+	Protected bool    `json:"protected"` // защищён от voteban
+}
+
+// ComputeReputation — чистая функция от сигналов, чтобы формулу можно было
+// проверить без БД.
+func ComputeReputation(sig repository.ReputationSignals, now time.Time) Reputation {
+	var firstSeen *time.Time
+	for _, t := range []*time.Time{sig.MemberSince, sig.FirstSeenInChat} {
+		if t != nil && (firstSeen == nil || t.Before(*firstSeen)) {
+			firstSeen = t
+		}
+	}
+	var tenure time.Duration
+	if firstSeen != nil && now.After(*firstSeen) {
+		tenure = now.Sub(*firstSeen)
+	}
+
+	score := 0.0
+	score += math.Min(float64(tenure)/float64(reputationAgeFull), 1) * reputationAgeMax
+	score += math.Min(float64(sig.TierLevel*reputationTierPerLevel), reputationTierMax)
+	if sig.Points > 0 {
+		score += math.Min(float64(sig.Points)/reputationPointsFull, 1) * reputationPointsMax
+	}
+	score += math.Min(float64(sig.ChatMessages)/reputationMsgsFull, 1) * reputationMsgsMax
+
+	s := int(math.Round(score))
+	weight := VoteWeightMin + (VoteWeightMax-VoteWeightMin)*float64(s)/100
+	return Reputation{
+		Score:     s,
+		Weight:    math.Round(weight*10) / 10,
+		Eligible:  tenure >= VoterMinTenure,
+		Protected: s >= VotebanProtectedScore,
+	}
+}
+
+// GetReputation считает репутацию telegram-юзера в чате.
+func (s *ModerationService) GetReputation(chatID, userID int64) (Reputation, error) {
+	now := time.Now()
+	sig, err := s.repo.GetReputationSignals(chatID, userID, now.Add(-ReputationMessagesWindow))
+	if err != nil {
+		return Reputation{}, err
+	}
+	return ComputeReputation(*sig, now), nil
+}
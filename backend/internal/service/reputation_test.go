@@ -0,0 +1,43 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"ithozyeva/internal/repository"
+)
+
+func TestComputeReputation(t *testing.T) {
+	now := time.Date(2026, 5, 21, 12, 0, 0, 0, time.UTC)
+	ago := func(d time.Duration) *time.Time {
+		t := now.Add(-d)
+		return &t
+	}
+	day := 24 * time.Hour
+
+	cases := []struct {
+		name          string
+		sig           repository.ReputationSignals
+		wantScore     int
+		wantWeight    float64
+		wantEligible  bool
+		wantProtected bool
+	}{
+		{"unknown account", repository.ReputationSignals{}, 0, 0.5, false, false},
+		{"fresh chat member", repository.ReputationSignals{FirstSeenInChat: ago(day), ChatMessages: 5}, 1, 0.5, false, false},
+		// 30 дней стажа = 5, 40 сообщений = 4.
+		{"month in chat", repository.ReputationSignals{FirstSeenInChat: ago(30 * day), ChatMessages: 40}, 9, 0.6, true, false},
+		// Стаж берётся по самой ранней отметке: регистрация раньше первого сообщения.
+		{"old member, quiet", repository.ReputationSignals{MemberSince: ago(365 * day), FirstSeenInChat: ago(10 * day)}, 30, 1.0, true, false},
+		{"old subscriber", repository.ReputationSignals{MemberSince: ago(365 * day), TierLevel: 2, Points: 250, ChatMessages: 100}, 70, 1.6, true, true},
+		{"max", repository.ReputationSignals{MemberSince: ago(1000 * day), TierLevel: 5, Points: 10000, ChatMessages: 5000}, 100, 2.0, true, true},
+		{"negative points ignored", repository.ReputationSignals{MemberSince: ago(4 * day), Points: -300}, 1, 0.5, true, false},
+	}
+	for _, c := range cases {
+		got := ComputeReputation(c.sig, now)
+		if got.Score != c.wantScore || got.Weight != c.wantWeight || got.Eligible != c.wantEligible || got.Protected != c.wantProtected {
+			t.Errorf("%s: got %+v, want score=%d weight=%v eligible=%v protected=%v",
+				c.name, got, c.wantScore, c.wantWeight, c.wantEligible, c.wantProtected)
+		}
+	}
+}
@@ -0,0 +1,392 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"ithozyeva/database"
+	"ithozyeva/internal/i18n"
+	"ithozyeva/internal/models"
+	"ithozyeva/internal/repository"
+
+	"github.com/redis/go-redis/v9"
+	"gorm.io/gorm"
+)
+
+// ShopOrderChannel — новые заказы магазина: API публикует, бот (NL)
+// присылает админам.
+const ShopOrderChannel = "shop:order"
+
+const (
+	shopTitleMax     = 200
+	shopCommentMax   = 1000
+	shopMyOrdersMax  = 50
+	shopAdminPageMax = 100
+)
+
+var shopItemKinds = map[string]bool{
+	models.ShopItemMerch:            true,
+	models.ShopItemMentorSession:    true,
+	models.ShopItemSubscriptionDays: true,
+	models.ShopItemStreakFreeze:     true,
+	models.ShopItemCosmetic:         true,
+}
+
+var (
+	ErrShopItemNotFound  = errors.New("товар не найден")
+	ErrShopOrderNotFound = errors.New("заказ не найден")
+	ErrShopItemHasOrders = errors.New("по товару уже есть заказы — снимите его с продажи")
+)
+
+// ShopError — отказ в покупке или смене статуса (текст — для пользователя).
+type ShopError struct{ i18n.Message }
+
+func (e *ShopError) Error() string { return e.String() }
+
+func shopRefused(format string, args ...interface{}) error {
+	return &ShopError{i18n.Msg(format, args...)}
+}
+
+// ShopOrderEvent — payload ShopOrderChannel.
+type ShopOrderEvent struct {
+	OrderID   int64  `json:"orderId"`
+	ItemTitle string `json:"itemTitle"`
+	ItemKind  string `json:"itemKind"`
+	Price     int    `json:"price"`
+	Comment   string `json:"comment"`
+	MemberID  int64  `json:"memberId"`
+	Username  string `json:"username"`
+	FullName  string `json:"fullName"`
+}
+
+// ShopService — магазин наград за баллы. Покупка списывает баллы
+// (PointReasonShopPurchase) и создаёт заказ в одной транзакции; отмена
+// заказа возвращает баллы (PointReasonShopRefund) и остаток.
+type ShopService struct {
+	repo      *repository.ShopRepository
+	pointRepo *repository.PointsRepository
+	streakSvc *StreakService
+	redis     *redis.Client
+}
+
+func NewShopService(redisClient *redis.Client) *ShopService {
+	return &ShopService{
+		repo:      repository.NewShopRepository(),
+		pointRepo: repository.NewPointsRepository(),
+		streakSvc: NewStreakService(),
+		redis:     redisClient,
+	}
+}
+
+// ValidateShopItem проверяет позицию каталога перед сохранением.
+func ValidateShopItem(item *models.ShopItem) error {
+	item.Title = strings.TrimSpace(item.Title)
+	switch {
+	case !shopItemKinds[item.Kind]:
+		return shopRefused("неизвестный вид награды: %s", item.Kind)
+	case item.Title == "":
+		return shopRefused("укажите название")
+	case utf8.RuneCountInString(item.Title) > shopTitleMax:
+		return shopRefused("название длиннее %d символов", shopTitleMax)
+	case item.Price <= 0:
+		return shopRefused("цена должна быть больше нуля")
+	case item.Stock != nil && *item.Stock < 0:
+		return shopRefused("остаток не может быть отрицательным")
+	case item.PerMemberLimit < 0:
+		return shopRefused("лимит на участника не может быть отрицательным")
+	case item.Kind == models.ShopItemStreakFreeze && item.Quantity > StreakFreezeMax:
+		return shopRefused("в позиции не больше %d заморозок", StreakFreezeMax)
+	}
+	if item.Quantity <= 0 {
+		item.Quantity = 1
+	}
+	return nil
+}
+
+// Items — витрина: позиции в продаже и сколько их уже у участника.
+func (s *ShopService) Items(memberId int64) ([]models.ShopItemPublic, error) {
+	items, err := s.repo.ListItems(true)
+	if err != nil {
+		return nil, err
+	}
+	mine, err := s.repo.MyOrderCounts(memberId)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]models.ShopItemPublic, 0, len(items))
+	for _, item := range items {
+		out = append(out, models.ShopItemPublic{ShopItem: item, MyOrders: mine[item.Id]})
+	}
+	return out, nil
+}
+
+func (s *ShopService) AdminItems() ([]models.ShopItem, error) {
+	return s.repo.ListItems(false)
+}
+
+func (s *ShopService) CreateItem(item *models.ShopItem) error {
+	if err := ValidateShopItem(item); err != nil {
+		return err
+	}
+	return s.repo.CreateItem(item)
+}
+
+func (s *ShopService) UpdateItem(id int64, item *models.ShopItem) (*models.ShopItem, error) {
+	if err := ValidateShopItem(item); err != nil {
+		return nil, err
+	}
+	existing, err := s.repo.GetItem(id)
+	if err != nil {
+		return nil, err
+	}
+	if existing == nil {
+		return nil, ErrShopItemNotFound
+	}
+	item.Id = id
+	item.CreatedAt = existing.CreatedAt
+	item.UpdatedAt = time.Now()
+	if err := s.repo.UpdateItem(item); err != nil {
+		return nil, err
+	}
+	return item, nil
+}
+
+// DeleteItem удаляет позицию без заказов; с заказами — только снять с
+// продажи, иначе потеряется история выдачи.
+func (s *ShopService) DeleteItem(id int64) error {
+	n, err := s.repo.CountOrdersForItem(id)
+	if err != nil {
+		return err
+	}
+	if n > 0 {
+		return ErrShopItemHasOrders
+	}
+	return s.repo.DeleteItem(id)
+}
+
+// Purchase покупает позицию itemId. Баланс проверяется под тем же
+// pg_advisory_xact_lock(memberId), что у казино и розыгрышей, остаток —
+// под блокировкой строки позиции. Заморозки стрика зачисляются в той же
+// транзакции, а заказ на них сразу выдан — админам выдавать нечего.
+func (s *ShopService) Purchase(member *models.Member, itemId int64, comment string) (*models.ShopOrder, error) {
+	comment = strings.TrimSpace(comment)
+	if utf8.RuneCountInString(comment) > shopCommentMax {
+		return nil, shopRefused("комментарий длиннее %d символов", shopCommentMax)
+	}
+
+	now := time.Now()
+	var order *models.ShopOrder
+	var item *models.ShopItem
+	err := database.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec(`SELECT pg_advisory_xact_lock(?)`, member.Id).Error; err != nil {
+			return err
+		}
+		var err error
+		item, err = s.repo.GetItemForUpdateTx(tx, itemId)
+		if err != nil {
+			return err
+		}
+		if item == nil || !item.IsActive {
+			return ErrShopItemNotFound
+		}
+		if item.Stock != nil && *item.Stock <= 0 {
+			return shopRefused("товар закончился")
+		}
+		if item.PerMemberLimit > 0 {
+			n, err := s.repo.CountMemberOrdersTx(tx, item.Id, member.Id)
+			if err != nil {
+				return err
+			}
+			if int(n) >= item.PerMemberLimit {
+				return shopRefused("лимит покупок этого товара — %d в одни руки", item.PerMemberLimit)
+			}
+		}
+
+		balance, err := s.pointRepo.GetBalanceTx(tx, member.Id)
+		if err != nil {
+			return err
+		}
+		if balance < item.Price {
+			return shopRefused("недостаточно баллов (нужно %d, доступно %d)", item.Price, balance)
+		}
+
+		order = &models.ShopOrder{
+			ItemId:    item.Id,
+			MemberId:  member.Id,
+			ItemTitle: item.Title,
+			Price:     item.Price,
+			Status:    models.ShopOrderPending,
+			Comment:   comment,
+		}
+		if item.Kind == models.ShopItemStreakFreeze {
+			if _, err := s.streakSvc.CreditFreezesTx(tx, member.Id, item.Quantity, now); err != nil {
+				var streakErr *StreakError
+				if errors.As(err, &streakErr) {
+					return &ShopError{streakErr.Message}
+				}
+				return err
+			}
+			order.Status = models.ShopOrderDelivered
+			order.DeliveredAt = &now
+		}
+		if err := s.repo.CreateOrderTx(tx, order); err != nil {
+			return err
+		}
+		if err := tx.Create(&models.PointTransaction{
+			MemberId:    member.Id,
+			Amount:      -item.Price,
+			Reason:      models.PointReasonShopPurchase,
+			SourceType:  "shop_order",
+			SourceId:    order.Id,
+			Description: fmt.Sprintf("Магазин: %s", item.Title),
+		}).Error; err != nil {
+			return err
+		}
+		return s.repo.AdjustStockTx(tx, item.Id, -1)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	GetSSEHub().Publish(member.Id, SSEEvent{Type: "points"})
+	if order.Status == models.ShopOrderDelivered {
+		GetSSEHub().Publish(member.Id, SSEEvent{Type: "streak"})
+		return order, nil
+	}
+	s.publishOrder(ShopOrderEvent{
+		OrderID:   order.Id,
+		ItemTitle: order.ItemTitle,
+		ItemKind:  item.Kind,
+		Price:     order.Price,
+		Comment:   order.Comment,
+		MemberID:  member.Id,
+		Username:  member.Username,
+		FullName:  strings.TrimSpace(member.FirstName + " " + member.LastName),
+	})
+	return order, nil
+}
+
+func (s *ShopService) publishOrder(ev ShopOrderEvent) {
+	if s.redis == nil {
+		return
+	}
+	payload, err := json.Marshal(ev)
+	if err != nil {
+		return
+	}
+	if err := s.redis.Publish(context.Background(), ShopOrderChannel, payload).Err(); err != nil {
+		log.Printf("shop: publish order %d: %v", ev.OrderID, err)
+	}
+}
+
+// SubscribeOrders — для бота: новые заказы из ShopOrderChannel.
+func (s *ShopService) SubscribeOrders(ctx context.Context, handler func(ShopOrderEvent)) {
+	if s.redis == nil {
+		log.Printf("shop: SubscribeOrders called without redis client — noop")
+		return
+	}
+	pubsub := s.redis.Subscribe(ctx, ShopOrderChannel)
+	go func() {
+		defer pubsub.Close()
+		for msg := range pubsub.Channel() {
+			var ev ShopOrderEvent
+			if err := json.Unmarshal([]byte(msg.Payload), &ev); err != nil {
+				log.Printf("shop: bad order payload: %v", err)
+				continue
+			}
+			handler(ev)
+		}
+	}()
+}
+
+func (s *ShopService) MyOrders(memberId int64) ([]models.ShopOrder, error) {
+	return s.repo.MemberOrders(memberId, shopMyOrdersMax)
+}
+
+func (s *ShopService) SearchOrders(status string, limit, offset int) ([]models.AdminShopOrder, int64, error) {
+	if limit <= 0 || limit > shopAdminPageMax {
+		limit = 20
+	}
+	if offset < 0 {
+		offset = 0
+	}
+	return s.repo.SearchOrders(status, limit, offset)
+}
+
+// shopTransitions — допустимые переходы статуса заказа.
+var shopTransitions = map[string][]string{
+	models.ShopOrderPending: {models.ShopOrderShipped, models.ShopOrderDelivered, models.ShopOrderCancelled},
+	models.ShopOrderShipped: {models.ShopOrderDelivered, models.ShopOrderCancelled},
+}
+
+// CanTransitionShopOrder — можно ли перевести заказ из from в to.
+func CanTransitionShopOrder(from, to string) bool {
+	for _, s := range shopTransitions[from] {
+		if s == to {
+			return true
+		}
+	}
+	return false
+}
+
+// SetOrderStatus — смена статуса админом.
+func (s *ShopService) SetOrderStatus(orderId int64, status, adminNote string) (*models.ShopOrder, error) {
+	return s.setStatus(orderId, 0, status, strings.TrimSpace(adminNote))
+}
+
+// CancelMyOrder — участник отменяет свой заказ, пока его не отправили.
+func (s *ShopService) CancelMyOrder(memberId, orderId int64) (*models.ShopOrder, error) {
+	return s.setStatus(orderId, memberId, models.ShopOrderCancelled, "")
+}
+
+// setStatus меняет статус под блокировкой заказа; ownerId != 0 — только
+// свой заказ и только из pending. Отмена возвращает баллы и остаток.
+func (s *ShopService) setStatus(orderId, ownerId int64, status, adminNote string) (*models.ShopOrder, error) {
+	var order *models.ShopOrder
+	err := database.DB.Transaction(func(tx *gorm.DB) error {
+		var err error
+		order, err = s.repo.GetOrderForUpdateTx(tx, orderId)
+		if err != nil {
+			return err
+		}
+		if order == nil || (ownerId != 0 && order.MemberId != ownerId) {
+			return ErrShopOrderNotFound
+		}
+		if ownerId != 0 && order.Status != models.ShopOrderPending {
+			return shopRefused("заказ уже отправлен — отменить его может только админ")
+		}
+		if !CanTransitionShopOrder(order.Status, status) {
+			return shopRefused("нельзя перевести заказ из «%s» в «%s»", order.Status, status)
+		}
+		if err := s.repo.SetOrderStatusTx(tx, order, status, adminNote, time.Now()); err != nil {
+			return err
+		}
+		if status != models.ShopOrderCancelled {
+			return nil
+		}
+		if err := s.pointRepo.AwardPointsTx(tx, &models.PointTransaction{
+			MemberId:    order.MemberId,
+			Amount:      order.Price,
+			Reason:      models.PointReasonShopRefund,
+			SourceType:  "shop_order",
+			SourceId:    order.Id,
+			Description: fmt.Sprintf("Возврат за отменённый заказ: %s", order.ItemTitle),
+		}); err != nil {
+			return err
+		}
+		return s.repo.AdjustStockTx(tx, order.ItemId, 1)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if status == models.ShopOrderCancelled {
+		GetSSEHub().Publish(order.MemberId, SSEEvent{Type: "points"})
+	}
+	return s.repo.GetOrder(order.Id)
+}
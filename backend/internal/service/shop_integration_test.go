@@ -0,0 +1,170 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"gorm.io/gorm"
+
+	"ithozyeva/internal/models"
+	"ithozyeva/internal/repository"
+	"ithozyeva/internal/testutil"
+)
+
+func seedShopItem(t *testing.T, db *gorm.DB, price int, stock *int, limit int) *models.ShopItem {
+	t.Helper()
+	item := &models.ShopItem{
+		Kind:           models.ShopItemMerch,
+		Title:          "Стикерпак",
+		Price:          price,
+		Quantity:       1,
+		Stock:          stock,
+		PerMemberLimit: limit,
+		IsActive:       true,
+	}
+	if err := db.Create(item).Error; err != nil {
+		t.Fatalf("create shop item: %v", err)
+	}
+	return item
+}
+
+func creditPoints(t *testing.T, db *gorm.DB, memberId int64, amount int) {
+	t.Helper()
+	if err := db.Create(&models.PointTransaction{
+		MemberId:   memberId,
+		Amount:     amount,
+		Reason:     models.PointReasonAdminManual,
+		SourceType: "manual",
+	}).Error; err != nil {
+		t.Fatalf("credit points: %v", err)
+	}
+}
+
+func balanceOf(t *testing.T, memberId int64) int {
+	t.Helper()
+	b, err := repository.NewPointsRepository().GetBalance(memberId)
+	if err != nil {
+		t.Fatalf("GetBalance: %v", err)
+	}
+	return b
+}
+
+// TestShopService_PurchaseAndCancel — покупка списывает баллы и остаток,
+// отмена возвращает и то и другое.
+func TestShopService_PurchaseAndCancel(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	testutil.TruncateAll(t, db, "shop_orders", "shop_items", "point_transactions", "members")
+
+	member := seedMember(t, db, 7101)
+	creditPoints(t, db, member.Id, 300)
+	item := seedShopItem(t, db, 200, ptrInt(1), 0)
+
+	svc := NewShopService(nil)
+	order, err := svc.Purchase(member, item.Id, "размер M")
+	if err != nil {
+		t.Fatalf("Purchase: %v", err)
+	}
+	if got := balanceOf(t, member.Id); got != 100 {
+		t.Errorf("balance after purchase = %d, want 100", got)
+	}
+
+	// Остаток кончился — вторая покупка (даже с деньгами) отклоняется.
+	creditPoints(t, db, member.Id, 500)
+	var shopErr *ShopError
+	if _, err := svc.Purchase(member, item.Id, ""); !errors.As(err, &shopErr) {
+		t.Fatalf("want out-of-stock refusal, got %v", err)
+	}
+
+	if _, err := svc.CancelMyOrder(member.Id, order.Id); err != nil {
+		t.Fatalf("CancelMyOrder: %v", err)
+	}
+	if got := balanceOf(t, member.Id); got != 800 {
+		t.Errorf("balance after refund = %d, want 800", got)
+	}
+	reloaded, err := svc.repo.GetItem(item.Id)
+	if err != nil {
+		t.Fatalf("GetItem: %v", err)
+	}
+	if reloaded.Stock == nil || *reloaded.Stock != 1 {
+		t.Errorf("stock after cancel = %v, want 1", reloaded.Stock)
+	}
+
+	// Повторная отмена не возвращает баллы второй раз.
+	if _, err := svc.SetOrderStatus(order.Id, models.ShopOrderCancelled, ""); err == nil {
+		t.Fatal("повторная отмена должна быть отклонена")
+	}
+	if got := balanceOf(t, member.Id); got != 800 {
+		t.Errorf("balance after second cancel = %d, want 800", got)
+	}
+}
+
+// TestShopService_PurchaseLimits — нехватка баллов и лимит в одни руки.
+func TestShopService_PurchaseLimits(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	testutil.TruncateAll(t, db, "shop_orders", "shop_items", "point_transactions", "members")
+
+	member := seedMember(t, db, 7102)
+	item := seedShopItem(t, db, 100, nil, 1)
+	svc := NewShopService(nil)
+
+	var shopErr *ShopError
+	if _, err := svc.Purchase(member, item.Id, ""); !errors.As(err, &shopErr) {
+		t.Fatalf("want insufficient points refusal, got %v", err)
+	}
+
+	creditPoints(t, db, member.Id, 500)
+	if _, err := svc.Purchase(member, item.Id, ""); err != nil {
+		t.Fatalf("Purchase: %v", err)
+	}
+	if _, err := svc.Purchase(member, item.Id, ""); !errors.As(err, &shopErr) {
+		t.Fatalf("want per-member limit refusal, got %v", err)
+	}
+	if got := balanceOf(t, member.Id); got != 400 {
+		t.Errorf("balance = %d, want 400", got)
+	}
+}
+
+// TestShopService_PurchaseStreakFreeze — покупка заморозок в магазине сразу
+// зачисляет их на стрик и закрывает заказ; сверх лимита заморозок покупка
+// отклоняется без списания.
+func TestShopService_PurchaseStreakFreeze(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	testutil.TruncateAll(t, db, "shop_orders", "shop_items", "member_streaks", "point_transactions", "members")
+
+	member := seedMember(t, db, 7103)
+	creditPoints(t, db, member.Id, 500)
+	item := seedShopItem(t, db, 100, nil, 0)
+	if err := db.Model(item).Updates(map[string]interface{}{"kind": models.ShopItemStreakFreeze, "quantity": 3}).Error; err != nil {
+		t.Fatalf("make freeze item: %v", err)
+	}
+
+	svc := NewShopService(nil)
+	order, err := svc.Purchase(member, item.Id, "")
+	if err != nil {
+		t.Fatalf("Purchase: %v", err)
+	}
+	if order.Status != models.ShopOrderDelivered || order.DeliveredAt == nil {
+		t.Errorf("order = %+v, want delivered", order)
+	}
+	streak, err := repository.NewStreakRepository().Get(member.Id)
+	if err != nil || streak.PurchasedFreezes != 3 {
+		t.Fatalf("streak = %+v, %v; want 3 purchased freezes", streak, err)
+	}
+
+	// 3 + 3 > StreakFreezeMax — отказ, баллы и заморозки не меняются.
+	var shopErr *ShopError
+	if _, err := svc.Purchase(member, item.Id, ""); !errors.As(err, &shopErr) {
+		t.Fatalf("want freeze limit refusal, got %v", err)
+	}
+	if got := balanceOf(t, member.Id); got != 400 {
+		t.Errorf("balance = %d, want 400", got)
+	}
+	if streak, _ := repository.NewStreakRepository().Get(member.Id); streak.PurchasedFreezes != 3 {
+		t.Errorf("purchased freezes = %d, want 3", streak.PurchasedFreezes)
+	}
+
+	// Выданный заказ не отменить — заморозки уже на стрике.
+	if _, err := svc.CancelMyOrder(member.Id, order.Id); !errors.As(err, &shopErr) {
+		t.Fatalf("want cancel refusal, got %v", err)
+	}
+}
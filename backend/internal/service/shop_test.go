@@ -0,0 +1,82 @@
+package service
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"ithozyeva/internal/models"
+)
+
+func TestValidateShopItem(t *testing.T) {
+	valid := func() *models.ShopItem {
+		return &models.ShopItem{Kind: models.ShopItemMerch, Title: "  Футболка  ", Price: 500}
+	}
+	cases := []struct {
+		name   string
+		mutate func(*models.ShopItem)
+		ok     bool
+	}{
+		{"valid", func(*models.ShopItem) {}, true},
+		{"unknown kind", func(i *models.ShopItem) { i.Kind = "car" }, false},
+		{"empty title", func(i *models.ShopItem) { i.Title = "   " }, false},
+		{"long title", func(i *models.ShopItem) { i.Title = strings.Repeat("я", shopTitleMax+1) }, false},
+		{"zero price", func(i *models.ShopItem) { i.Price = 0 }, false},
+		{"negative stock", func(i *models.ShopItem) { i.Stock = ptrInt(-1) }, false},
+		{"zero stock", func(i *models.ShopItem) { i.Stock = ptrInt(0) }, true},
+		{"negative limit", func(i *models.ShopItem) { i.PerMemberLimit = -1 }, false},
+		{"freeze pack", func(i *models.ShopItem) { i.Kind, i.Quantity = models.ShopItemStreakFreeze, StreakFreezeMax }, true},
+		{"oversized freeze pack", func(i *models.ShopItem) { i.Kind, i.Quantity = models.ShopItemStreakFreeze, StreakFreezeMax+1 }, false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			item := valid()
+			tc.mutate(item)
+			err := ValidateShopItem(item)
+			if tc.ok && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !tc.ok {
+				var shopErr *ShopError
+				if !errors.As(err, &shopErr) {
+					t.Fatalf("want *ShopError, got %v", err)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateShopItem_Normalizes(t *testing.T) {
+	item := &models.ShopItem{Kind: models.ShopItemStreakFreeze, Title: "  Заморозка ", Price: 100}
+	if err := ValidateShopItem(item); err != nil {
+		t.Fatalf("ValidateShopItem: %v", err)
+	}
+	if item.Title != "Заморозка" {
+		t.Errorf("title = %q, want trimmed", item.Title)
+	}
+	if item.Quantity != 1 {
+		t.Errorf("quantity = %d, want default 1", item.Quantity)
+	}
+}
+
+func TestCanTransitionShopOrder(t *testing.T) {
+	cases := []struct {
+		from, to string
+		want     bool
+	}{
+		{models.ShopOrderPending, models.ShopOrderShipped, true},
+		{models.ShopOrderPending, models.ShopOrderDelivered, true},
+		{models.ShopOrderPending, models.ShopOrderCancelled, true},
+		{models.ShopOrderShipped, models.ShopOrderDelivered, true},
+		{models.ShopOrderShipped, models.ShopOrderCancelled, true},
+		{models.ShopOrderShipped, models.ShopOrderPending, false},
+		{models.ShopOrderDelivered, models.ShopOrderCancelled, false},
+		{models.ShopOrderCancelled, models.ShopOrderPending, false},
+		{models.ShopOrderPending, "lost", false},
+	}
+	for _, tc := range cases {
+		if got := CanTransitionShopOrder(tc.from, tc.to); got != tc.want {
+			t.Errorf("CanTransitionShopOrder(%s, %s) = %v, want %v", tc.from, tc.to, got, tc.want)
+		}
+	}
+}
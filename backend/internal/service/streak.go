@@ -4,11 +4,43 @@ import (
 	"fmt"
 	"time"
 
+	"ithozyeva/database"
+	"ithozyeva/internal/i18n"
 	"ithozyeva/internal/models"
 	"ithozyeva/internal/repository"
 	"ithozyeva/internal/utils"
+
+	"gorm.io/gorm"
+)
+
+const (
+	// StreakFreezePrice — цена одной заморозки в баллах.
+	StreakFreezePrice = 50
+	// StreakFreezeMax — сколько купленных заморозок можно держать разом.
+	StreakFreezeMax = 5
+	// streakRepairWindow — сколько после обрыва стрик можно выкупить.
+	streakRepairWindow = 48 * time.Hour
+	// Цена восстановления растёт с длиной стрика, но не выше streakRepairMaxPrice.
+	streakRepairBasePrice = 50
+	streakRepairDayPrice  = 10
+	streakRepairMaxPrice  = 1000
 )
 
+// StreakError — отказ в покупке заморозки или восстановлении (текст — для
+// пользователя).
+type StreakError struct{ i18n.Message }
+
+func (e *StreakError) Error() string { return e.String() }
+
+func streakRefused(format string, args ...interface{}) error {
+	return &StreakError{i18n.Msg(format, args...)}
+}
+
+// StreakRepairPrice — цена восстановления стрика длиной lost.
+func StreakRepairPrice(lost int) int {
+	return min(streakRepairBasePrice+streakRepairDayPrice*lost, streakRepairMaxPrice)
+}
+
 // StreakThreshold описывает порог стрика и связанный PointReason.
 type StreakThreshold struct {
 	Days   int
@@ -37,21 +69,31 @@ func NewStreakService() *StreakService {
 }
 
 // ApplyCheckIn пересчитывает стрик после факта успешного check-in за day.
-// Возвращает (старый_streak, новый_streak, пересечённые_пороги).
+// Возвращает (старый_streak, новый_streak, пересечённые_пороги). Стрик
+// меняется под pg_advisory_xact_lock(memberId), чтобы не затереть
+// параллельную покупку заморозки.
 func (s *StreakService) ApplyCheckIn(memberId int64, day time.Time) (int, int, []StreakThreshold, error) {
-	streak, err := s.repo.Get(memberId)
-	if err != nil {
-		return 0, 0, nil, err
-	}
-
 	day = utils.MSKDay(day)
-	prev := streak.CurrentStreak
 
-	streak, crossed := recalcStreak(streak, day, StreakThresholds)
-	streak.MemberId = memberId
-
-	if err := s.repo.Save(streak); err != nil {
-		return prev, streak.CurrentStreak, nil, err
+	var prev int
+	var streak *models.MemberStreak
+	var crossed []StreakThreshold
+	err := database.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec(`SELECT pg_advisory_xact_lock(?)`, memberId).Error; err != nil {
+			return err
+		}
+		var err error
+		streak, err = s.repo.GetTx(tx, memberId)
+		if err != nil {
+			return err
+		}
+		prev = streak.CurrentStreak
+		streak, crossed = recalcStreak(streak, day, StreakThresholds)
+		streak.MemberId = memberId
+		return s.repo.SaveTx(tx, streak)
+	})
+	if err != nil {
+		return prev, prev, nil, err
 	}
 
 	if err := s.awardThresholdBonuses(memberId, day, crossed); err != nil {
@@ -68,33 +110,19 @@ func (s *StreakService) ApplyCheckIn(memberId int64, day time.Time) (int, int, [
 func recalcStreak(streak *models.MemberStreak, day time.Time, thresholds []StreakThreshold) (*models.MemberStreak, []StreakThreshold) {
 	prev := streak.CurrentStreak
 
-	// Перевыдача freeze в начале новой ISO-недели.
-	yr, wk := day.ISOWeek()
-	if streak.FreezeWeekYear == nil || streak.FreezeWeekNum == nil ||
-		*streak.FreezeWeekYear != yr || *streak.FreezeWeekNum != wk {
-		streak.FreezesAvailable = 1
-		streak.FreezeWeekYear = &yr
-		streak.FreezeWeekNum = &wk
-	}
+	settleStreak(streak, day)
 
 	switch {
-	case streak.LastCheckInDate == nil:
-		streak.CurrentStreak = 1
-	case utils.MSKDay(*streak.LastCheckInDate).Equal(day):
+	case streak.LastCheckInDate != nil && utils.MSKDay(*streak.LastCheckInDate).Equal(day):
 		// уже был check-in сегодня — ничего не пересчитываем
 		return streak, nil
+	case streak.LastCheckInDate == nil || streak.CurrentStreak == 0:
+		// первый check-in или стрик оборвался — начинаем заново
+		streak.CurrentStreak = 1
 	default:
-		gap := utils.DaysBetweenMSK(*streak.LastCheckInDate, day)
-		switch {
-		case gap == 1:
-			streak.CurrentStreak++
-		case gap == 2 && streak.FreezesAvailable > 0:
-			// одна "заморозка" — пропуск 1 дня не сбрасывает стрик
-			streak.CurrentStreak++
-			streak.FreezesAvailable--
-		default:
-			streak.CurrentStreak = 1
-		}
+		// пропуски, если были, покрыты заморозками — это проверил settleStreak
+		consumeFreezes(streak, missedDays(streak, day))
+		streak.CurrentStreak++
 	}
 
 	if streak.CurrentStreak > streak.LongestStreak {
@@ -102,13 +130,214 @@ func recalcStreak(streak *models.MemberStreak, day time.Time, thresholds []Strea
 	}
 	streak.LastCheckInDate = &day
 
+	return streak, crossedThresholds(prev, streak.CurrentStreak, thresholds)
+}
+
+// settleStreak приводит стрик к дню day без check-in: в новой ISO-неделе
+// перевыдаёт бесплатную заморозку, а если пропущенных дней больше, чем
+// заморозок, фиксирует обрыв. Оборвавшийся стрик уходит в LostStreak, а
+// LostAt — полночь после последнего дня, который заморозки ещё покрывали;
+// от неё отсчитывается окно восстановления. Неиспользованные заморозки при
+// обрыве не сгорают.
+func settleStreak(streak *models.MemberStreak, day time.Time) {
+	yr, wk := day.ISOWeek()
+	if streak.FreezeWeekYear == nil || streak.FreezeWeekNum == nil ||
+		*streak.FreezeWeekYear != yr || *streak.FreezeWeekNum != wk {
+		streak.FreezesAvailable = 1
+		streak.FreezeWeekYear = &yr
+		streak.FreezeWeekNum = &wk
+	}
+
+	freezes := streak.FreezesAvailable + streak.PurchasedFreezes
+	if streak.CurrentStreak == 0 || missedDays(streak, day) <= freezes {
+		return
+	}
+	lostAt := utils.MSKDay(*streak.LastCheckInDate).AddDate(0, 0, freezes+2)
+	streak.LostStreak = streak.CurrentStreak
+	streak.LostAt = &lostAt
+	streak.CurrentStreak = 0
+}
+
+// missedDays — дни без check-in строго между последним check-in и day.
+func missedDays(streak *models.MemberStreak, day time.Time) int {
+	if streak.LastCheckInDate == nil {
+		return 0
+	}
+	return max(utils.DaysBetweenMSK(*streak.LastCheckInDate, day)-1, 0)
+}
+
+// consumeFreezes списывает n заморозок: сначала бесплатную недельную,
+// потом купленные.
+func consumeFreezes(streak *models.MemberStreak, n int) {
+	free := min(n, streak.FreezesAvailable)
+	streak.FreezesAvailable -= free
+	streak.PurchasedFreezes -= n - free
+}
+
+// crossedThresholds — пороги, которые стрик прошёл, вырастая с from до to.
+func crossedThresholds(from, to int, thresholds []StreakThreshold) []StreakThreshold {
 	crossed := make([]StreakThreshold, 0)
 	for _, th := range thresholds {
-		if prev < th.Days && streak.CurrentStreak >= th.Days {
+		if from < th.Days && to >= th.Days {
 			crossed = append(crossed, th)
 		}
 	}
-	return streak, crossed
+	return crossed
+}
+
+// repairOffer — предложение выкупить оборвавшийся стрик; nil — нечего
+// восстанавливать или окно уже закрылось.
+func repairOffer(streak *models.MemberStreak, now time.Time) *models.StreakRepairOffer {
+	if streak.LostStreak == 0 || streak.LostAt == nil {
+		return nil
+	}
+	deadline := streak.LostAt.Add(streakRepairWindow)
+	if !now.Before(deadline) {
+		return nil
+	}
+	return &models.StreakRepairOffer{
+		LostStreak: streak.LostStreak,
+		Price:      StreakRepairPrice(streak.LostStreak),
+		Deadline:   deadline,
+	}
+}
+
+// BuyFreezes покупает quantity заморозок за баллы. Баланс и стрик меняются
+// под pg_advisory_xact_lock(memberId), как у магазина и казино. Стрик
+// сперва приводится к сегодняшнему дню: уже оборвавшийся стрик купленными
+// задним числом заморозками не спасти — для этого есть Repair.
+func (s *StreakService) BuyFreezes(memberId int64, quantity int, now time.Time) (models.StreakResponse, error) {
+	if quantity <= 0 || quantity > StreakFreezeMax {
+		return models.StreakResponse{}, streakRefused("можно купить от 1 до %d заморозок", StreakFreezeMax)
+	}
+	price := StreakFreezePrice * quantity
+
+	var streak *models.MemberStreak
+	err := database.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec(`SELECT pg_advisory_xact_lock(?)`, memberId).Error; err != nil {
+			return err
+		}
+		var err error
+		streak, err = s.CreditFreezesTx(tx, memberId, quantity, now)
+		if err != nil {
+			return err
+		}
+
+		balance, err := s.pointRepo.GetBalanceTx(tx, memberId)
+		if err != nil {
+			return err
+		}
+		if balance < price {
+			return streakRefused("недостаточно баллов (нужно %d, доступно %d)", price, balance)
+		}
+		return tx.Create(&models.PointTransaction{
+			MemberId:    memberId,
+			Amount:      -price,
+			Reason:      models.PointReasonStreakFreeze,
+			SourceType:  "streak_freeze",
+			Description: fmt.Sprintf("Заморозка стрика ×%d", quantity),
+		}).Error
+	})
+	if err != nil {
+		return models.StreakResponse{}, err
+	}
+
+	GetSSEHub().Publish(memberId, SSEEvent{Type: "points"})
+	GetSSEHub().Publish(memberId, SSEEvent{Type: "streak"})
+	return streakResponse(streak, now), nil
+}
+
+// CreditFreezesTx зачисляет quantity купленных заморозок; баллы списывает
+// вызывающий (BuyFreezes, покупка в магазине) в той же транзакции и под тем
+// же pg_advisory_xact_lock(memberId). Стрик сперва приводится к дню now.
+func (s *StreakService) CreditFreezesTx(tx *gorm.DB, memberId int64, quantity int, now time.Time) (*models.MemberStreak, error) {
+	streak, err := s.repo.GetTx(tx, memberId)
+	if err != nil {
+		return nil, err
+	}
+	settleStreak(streak, utils.MSKDay(now))
+	if streak.PurchasedFreezes+quantity > StreakFreezeMax {
+		return nil, streakRefused("можно держать не больше %d купленных заморозок", StreakFreezeMax)
+	}
+	streak.PurchasedFreezes += quantity
+	if err := s.repo.SaveTx(tx, streak); err != nil {
+		return nil, err
+	}
+	return streak, nil
+}
+
+// Repair выкупает стрик, оборвавшийся не раньше чем streakRepairWindow
+// назад. Восстановленный стрик продолжает тот, что участник успел набрать
+// после обрыва; если check-in после обрыва не было, сегодняшний его
+// продолжит. Пороги, впервые пройденные при восстановлении, награждаются.
+func (s *StreakService) Repair(memberId int64, now time.Time) (models.StreakResponse, error) {
+	today := utils.MSKDay(now)
+
+	var streak *models.MemberStreak
+	var crossed []StreakThreshold
+	err := database.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec(`SELECT pg_advisory_xact_lock(?)`, memberId).Error; err != nil {
+			return err
+		}
+		var err error
+		streak, err = s.repo.GetTx(tx, memberId)
+		if err != nil {
+			return err
+		}
+		settleStreak(streak, today)
+		offer := repairOffer(streak, now)
+		if offer == nil {
+			return streakRefused("восстанавливать нечего: стрик не обрывался или прошло больше 48 часов")
+		}
+
+		balance, err := s.pointRepo.GetBalanceTx(tx, memberId)
+		if err != nil {
+			return err
+		}
+		if balance < offer.Price {
+			return streakRefused("недостаточно баллов (нужно %d, доступно %d)", offer.Price, balance)
+		}
+
+		lostAt := *streak.LostAt
+		since := streak.CurrentStreak
+		streak.CurrentStreak = offer.LostStreak + since
+		if since == 0 {
+			yesterday := today.AddDate(0, 0, -1)
+			streak.LastCheckInDate = &yesterday
+		}
+		if streak.CurrentStreak > streak.LongestStreak {
+			streak.LongestStreak = streak.CurrentStreak
+		}
+		streak.LostStreak = 0
+		streak.LostAt = nil
+		crossed = crossedThresholds(offer.LostStreak, streak.CurrentStreak, StreakThresholds)
+
+		if err := s.repo.SaveTx(tx, streak); err != nil {
+			return err
+		}
+		return tx.Create(&models.PointTransaction{
+			MemberId:    memberId,
+			Amount:      -offer.Price,
+			Reason:      models.PointReasonStreakRepair,
+			SourceType:  "streak_repair",
+			SourceId:    lostAt.Unix(),
+			Description: fmt.Sprintf("Восстановление стрика %d дн.", offer.LostStreak),
+		}).Error
+	})
+	if err != nil {
+		return models.StreakResponse{}, err
+	}
+
+	if err := s.awardThresholdBonuses(memberId, today, crossed); err != nil {
+		return streakResponse(streak, now), err
+	}
+	for _, th := range crossed {
+		go PushStreakThreshold(memberId, th.Days, th.Reward)
+	}
+
+	GetSSEHub().Publish(memberId, SSEEvent{Type: "points"})
+	GetSSEHub().Publish(memberId, SSEEvent{Type: "streak"})
+	return streakResponse(streak, now), nil
 }
 
 // awardThresholdBonuses идемпотентно выдаёт баллы за пересечённые пороги.
@@ -140,11 +369,28 @@ func (s *StreakService) BuildResponse(memberId int64) (models.StreakResponse, er
 	if err != nil {
 		return models.StreakResponse{}, err
 	}
+	return streakResponse(streak, time.Now()), nil
+}
+
+// streakResponse показывает стрик на момент now, не сохраняя его: обрыв
+// уже виден, а заморозки, которые уйдут на пропуски при следующем
+// check-in, не считаются доступными.
+func streakResponse(saved *models.MemberStreak, now time.Time) models.StreakResponse {
+	streak := *saved
+	today := utils.MSKDay(now)
+	settleStreak(&streak, today)
+	if streak.CurrentStreak > 0 {
+		consumeFreezes(&streak, missedDays(&streak, today))
+	}
 
 	resp := models.StreakResponse{
 		Current:          streak.CurrentStreak,
 		Longest:          streak.LongestStreak,
-		FreezesAvailable: streak.FreezesAvailable,
+		FreezesAvailable: streak.FreezesAvailable + streak.PurchasedFreezes,
+		PurchasedFreezes: streak.PurchasedFreezes,
+		FreezePrice:      StreakFreezePrice,
+		FreezeMax:        StreakFreezeMax,
+		Repair:           repairOffer(&streak, now),
 		LastCheckIn:      streak.LastCheckInDate,
 		Milestones:       make([]models.StreakMilestone, 0, len(StreakThresholds)),
 	}
@@ -163,5 +409,5 @@ func (s *StreakService) BuildResponse(memberId int64) (models.StreakResponse, er
 		}
 	}
 
-	return resp, nil
+	return resp
 }
@@ -0,0 +1,78 @@
+package service
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"ithozyeva/internal/models"
+	"ithozyeva/internal/testutil"
+	"ithozyeva/internal/utils"
+)
+
+// TestStreakService_BuyFreezesAndRepair — заморозки покупаются за баллы в
+// пределах лимита, оборвавшийся стрик выкупается один раз и продолжает
+// набранный после обрыва.
+func TestStreakService_BuyFreezesAndRepair(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	testutil.TruncateAll(t, db, "member_streaks", "point_transactions", "members")
+
+	member := seedMember(t, db, 7401)
+	seedPointsAt(t, db, member.Id, 400, models.PointReasonAdminManual, time.Now().Add(-time.Hour))
+
+	svc := NewStreakService()
+	now := time.Now()
+	today := utils.MSKDay(now)
+
+	resp, err := svc.BuyFreezes(member.Id, 2, now)
+	if err != nil || resp.PurchasedFreezes != 2 {
+		t.Fatalf("BuyFreezes = %+v, %v", resp, err)
+	}
+	var streakErr *StreakError
+	if _, err := svc.BuyFreezes(member.Id, StreakFreezeMax, now); !errors.As(err, &streakErr) {
+		t.Fatalf("покупка сверх лимита: err = %v", err)
+	}
+	if balance, _ := svc.pointRepo.GetBalance(member.Id); balance != 400-2*StreakFreezePrice {
+		t.Fatalf("balance = %d, want %d", balance, 400-2*StreakFreezePrice)
+	}
+
+	// Стрик 8 дней, последний check-in пять дней назад: три заморозки
+	// покрывают три дня из четырёх, обрыв — в эту полночь.
+	streak, _ := svc.repo.Get(member.Id)
+	last := today.AddDate(0, 0, -5)
+	streak.CurrentStreak = 8
+	streak.LongestStreak = 8
+	streak.LastCheckInDate = &last
+	if err := svc.repo.Save(streak); err != nil {
+		t.Fatalf("seed streak: %v", err)
+	}
+
+	resp, err = svc.BuildResponse(member.Id)
+	if err != nil || resp.Current != 0 || resp.Repair == nil || resp.Repair.LostStreak != 8 {
+		t.Fatalf("BuildResponse = %+v, %v", resp, err)
+	}
+
+	resp, err = svc.Repair(member.Id, now)
+	if err != nil {
+		t.Fatalf("Repair: %v", err)
+	}
+	if resp.Current != 8 || resp.Repair != nil {
+		t.Fatalf("after repair = %+v", resp)
+	}
+	if _, err := svc.Repair(member.Id, now); !errors.As(err, &streakErr) {
+		t.Fatalf("повторное восстановление: err = %v", err)
+	}
+
+	// Сегодняшний check-in продолжает восстановленный стрик.
+	_, current, _, err := svc.ApplyCheckIn(member.Id, now)
+	if err != nil || current != 9 {
+		t.Fatalf("ApplyCheckIn = %d, %v; want 9", current, err)
+	}
+
+	var spent []models.PointTransaction
+	db.Where("member_id = ? AND amount < 0", member.Id).Order("id").Find(&spent)
+	if len(spent) != 2 || spent[0].Reason != models.PointReasonStreakFreeze || spent[1].Reason != models.PointReasonStreakRepair ||
+		spent[1].Amount != -StreakRepairPrice(8) {
+		t.Fatalf("spend transactions = %+v", spent)
+	}
+}
@@ -1,6 +1,7 @@
 package service
 
 import (
+	"errors"
 	"testing"
 	"time"
 
@@ -228,3 +229,134 @@ func equalIntSlice(a, b []int) bool {
 	}
 	return true
 }
+
+func TestRecalcStreak_PurchasedFreezes(t *testing.T) {
+	day := mskDay(2026, 5, 6) // среда, та же ISO-неделя 19
+	yr, wk := day.ISOWeek()
+
+	t.Run("multi-day gap covered by weekly and purchased freezes", func(t *testing.T) {
+		streak := &models.MemberStreak{
+			CurrentStreak:    6,
+			LongestStreak:    6,
+			LastCheckInDate:  ptrTime(mskDay(2026, 5, 3)),
+			FreezesAvailable: 1,
+			FreezeWeekYear:   ptrInt(yr),
+			FreezeWeekNum:    ptrInt(wk),
+			PurchasedFreezes: 2,
+		}
+		got, crossed := recalcStreak(streak, day, fixedThresholds)
+		if got.CurrentStreak != 7 || got.FreezesAvailable != 0 || got.PurchasedFreezes != 1 {
+			t.Fatalf("streak = %d, freezes = %d/%d; want 7, 0/1", got.CurrentStreak, got.FreezesAvailable, got.PurchasedFreezes)
+		}
+		if len(crossed) != 1 || crossed[0].Days != 7 {
+			t.Fatalf("crossed = %v, want [7]", crossed)
+		}
+		if got.LostAt != nil {
+			t.Fatalf("LostAt = %v, want nil", got.LostAt)
+		}
+	})
+
+	t.Run("gap longer than freezes is remembered for repair", func(t *testing.T) {
+		streak := &models.MemberStreak{
+			CurrentStreak:    12,
+			LongestStreak:    12,
+			LastCheckInDate:  ptrTime(mskDay(2026, 5, 1)),
+			FreezesAvailable: 1,
+			FreezeWeekYear:   ptrInt(yr),
+			FreezeWeekNum:    ptrInt(wk),
+			PurchasedFreezes: 1,
+		}
+		got, _ := recalcStreak(streak, day, fixedThresholds)
+		if got.CurrentStreak != 1 || got.LostStreak != 12 {
+			t.Fatalf("current = %d, lost = %d; want 1, 12", got.CurrentStreak, got.LostStreak)
+		}
+		// 2 и 3 мая покрыты заморозками, 4 мая — уже нет: обрыв в полночь на 5-е.
+		if got.LostAt == nil || !got.LostAt.Equal(mskDay(2026, 5, 5)) {
+			t.Fatalf("LostAt = %v, want 2026-05-05", got.LostAt)
+		}
+		if got.FreezesAvailable != 1 || got.PurchasedFreezes != 1 {
+			t.Fatalf("freezes = %d/%d, want 1/1 (не сгорают при обрыве)", got.FreezesAvailable, got.PurchasedFreezes)
+		}
+	})
+
+	t.Run("weekly reset keeps purchased freezes", func(t *testing.T) {
+		streak := &models.MemberStreak{
+			CurrentStreak:    3,
+			LongestStreak:    3,
+			LastCheckInDate:  ptrTime(mskDay(2026, 5, 5)),
+			FreezesAvailable: 0,
+			FreezeWeekYear:   ptrInt(yr),
+			FreezeWeekNum:    ptrInt(wk - 1),
+			PurchasedFreezes: 4,
+		}
+		got, _ := recalcStreak(streak, day, fixedThresholds)
+		if got.FreezesAvailable != 1 || got.PurchasedFreezes != 4 {
+			t.Fatalf("freezes = %d/%d, want 1/4", got.FreezesAvailable, got.PurchasedFreezes)
+		}
+	})
+}
+
+func TestStreakResponse(t *testing.T) {
+	yr, wk := mskDay(2026, 5, 6).ISOWeek()
+
+	t.Run("pending gap reserves freezes", func(t *testing.T) {
+		streak := &models.MemberStreak{
+			CurrentStreak:    5,
+			LongestStreak:    5,
+			LastCheckInDate:  ptrTime(mskDay(2026, 5, 4)),
+			FreezesAvailable: 1,
+			FreezeWeekYear:   ptrInt(yr),
+			FreezeWeekNum:    ptrInt(wk),
+			PurchasedFreezes: 2,
+		}
+		resp := streakResponse(streak, mskDay(2026, 5, 6).Add(10*time.Hour))
+		if resp.Current != 5 || resp.FreezesAvailable != 2 || resp.PurchasedFreezes != 2 || resp.Repair != nil {
+			t.Fatalf("resp = %+v", resp)
+		}
+		if streak.FreezesAvailable != 1 {
+			t.Fatal("streakResponse не должен менять сохранённый стрик")
+		}
+	})
+
+	t.Run("broken streak offers repair until deadline", func(t *testing.T) {
+		streak := &models.MemberStreak{
+			CurrentStreak:    10,
+			LongestStreak:    10,
+			LastCheckInDate:  ptrTime(mskDay(2026, 5, 3)),
+			FreezesAvailable: 0,
+			FreezeWeekYear:   ptrInt(yr),
+			FreezeWeekNum:    ptrInt(wk),
+		}
+		now := mskDay(2026, 5, 6).Add(10 * time.Hour)
+		resp := streakResponse(streak, now)
+		if resp.Current != 0 || resp.Repair == nil {
+			t.Fatalf("resp = %+v, want broken streak with repair offer", resp)
+		}
+		if resp.Repair.LostStreak != 10 || resp.Repair.Price != StreakRepairPrice(10) ||
+			!resp.Repair.Deadline.Equal(mskDay(2026, 5, 7)) {
+			t.Fatalf("repair = %+v", resp.Repair)
+		}
+		if late := streakResponse(streak, mskDay(2026, 5, 7)); late.Repair != nil {
+			t.Fatalf("repair after deadline = %+v", late.Repair)
+		}
+	})
+}
+
+func TestStreakRepairPrice(t *testing.T) {
+	if got := StreakRepairPrice(3); got != 80 {
+		t.Errorf("StreakRepairPrice(3) = %d, want 80", got)
+	}
+	if got := StreakRepairPrice(500); got != streakRepairMaxPrice {
+		t.Errorf("StreakRepairPrice(500) = %d, want %d", got, streakRepairMaxPrice)
+	}
+}
+
+func TestStreakService_BuyFreezesRejectsQuantity(t *testing.T) {
+	svc := NewStreakService()
+	for _, q := range []int{0, -1, StreakFreezeMax + 1} {
+		var streakErr *StreakError
+		if _, err := svc.BuyFreezes(1, q, time.Now()); !errors.As(err, &streakErr) {
+			t.Errorf("BuyFreezes(quantity=%d) err = %v, want *StreakError", q, err)
+		}
+	}
+}
@@ -0,0 +1,151 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"ithozyeva/internal/models"
+	"ithozyeva/internal/repository"
+	"ithozyeva/internal/utils"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Квоты /summarize живут в Redis (общие для реплик бота, переживают
+// рестарт) и сбрасываются в полночь по МСК. Лимит зависит от тира подписки.
+const (
+	summarizeQuotaTTL = 48 * time.Hour // с запасом на сдвиг суток
+	summaryCacheTTL   = 6 * time.Hour
+)
+
+// summarizeTierLimits — запросов в день по уровню тира; 0 — без подписки.
+var summarizeTierLimits = []int{3, 5, 10, 20, 50}
+
+// SummarizeDailyLimit — дневной лимит для уровня тира (выше известных —
+// как у старшего).
+func SummarizeDailyLimit(tierLevel int) int {
+	if tierLevel < 0 {
+		tierLevel = 0
+	}
+	if tierLevel >= len(summarizeTierLimits) {
+		tierLevel = len(summarizeTierLimits) - 1
+	}
+	return summarizeTierLimits[tierLevel]
+}
+
+func summarizeQuotaKey(userID int64, now time.Time) string {
+	return fmt.Sprintf("summarize:quota:%d:%s", userID, utils.MSKDay(now).Format("2006-01-02"))
+}
+
+// SummaryCacheKey — ключ кэша саммари: чат + диапазон сообщений (первый и
+// последний id и их число). Новое сообщение в окне даёт новый ключ, так
+// что кэш не отдаёт устаревшее саммари. messages — в порядке sent_at DESC.
+func SummaryCacheKey(chatID int64, messages []models.ChatMessage) string {
+	if len(messages) == 0 {
+		return ""
+	}
+	first, last := messages[len(messages)-1].Id, messages[0].Id
+	return fmt.Sprintf("summarize:cache:%d:%d:%d:%d", chatID, first, last, len(messages))
+}
+
+// CachedSummary — готовое саммари в кэше.
+type CachedSummary struct {
+	Summary   string    `json:"summary"`
+	Model     string    `json:"model"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// SummarizeQuota — состояние квоты после списания.
+type SummarizeQuota struct {
+	Allowed   bool
+	Used      int
+	Limit     int
+	Remaining int
+}
+
+type SummarizeService struct {
+	redis   *redis.Client
+	modRepo *repository.ModerationRepository
+}
+
+func NewSummarizeService(redisClient *redis.Client) *SummarizeService {
+	return &SummarizeService{
+		redis:   redisClient,
+		modRepo: repository.NewModerationRepository(),
+	}
+}
+
+// DailyLimit — лимит пользователя по его текущему тиру.
+func (s *SummarizeService) DailyLimit(userID int64) (int, error) {
+	level, err := s.modRepo.GetSubscriberTierLevel(userID)
+	if err != nil {
+		return SummarizeDailyLimit(0), err
+	}
+	return SummarizeDailyLimit(level), nil
+}
+
+// ConsumeQuota списывает один запрос. При превышении списание откатывается
+// и Allowed=false. INCR атомарен, поэтому параллельные запросы с разных
+// реплик не проскочат лимит.
+func (s *SummarizeService) ConsumeQuota(ctx context.Context, userID int64, now time.Time) (SummarizeQuota, error) {
+	limit, err := s.DailyLimit(userID)
+	if err != nil {
+		return SummarizeQuota{}, err
+	}
+	key := summarizeQuotaKey(userID, now)
+	used, err := s.redis.Incr(ctx, key).Result()
+	if err != nil {
+		return SummarizeQuota{}, err
+	}
+	if used == 1 {
+		s.redis.Expire(ctx, key, summarizeQuotaTTL)
+	}
+	if int(used) > limit {
+		s.redis.Decr(ctx, key)
+		return SummarizeQuota{Allowed: false, Used: limit, Limit: limit}, nil
+	}
+	return SummarizeQuota{Allowed: true, Used: int(used), Limit: limit, Remaining: limit - int(used)}, nil
+}
+
+// RefundQuota возвращает запрос, если модель так и не ответила.
+func (s *SummarizeService) RefundQuota(ctx context.Context, userID int64, now time.Time) error {
+	key := summarizeQuotaKey(userID, now)
+	used, err := s.redis.Decr(ctx, key).Result()
+	if err == nil && used < 0 {
+		return s.redis.Set(ctx, key, 0, summarizeQuotaTTL).Err()
+	}
+	return err
+}
+
+// GetCachedSummary — саммари из кэша или nil.
+func (s *SummarizeService) GetCachedSummary(ctx context.Context, key string) (*CachedSummary, error) {
+	if key == "" {
+		return nil, nil
+	}
+	raw, err := s.redis.Get(ctx, key).Bytes()
+	if err == redis.Nil {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var cached CachedSummary
+	if err := json.Unmarshal(raw, &cached); err != nil {
+		return nil, err
+	}
+	return &cached, nil
+}
+
+// CacheSummary кладёт саммари в кэш на summaryCacheTTL.
+func (s *SummarizeService) CacheSummary(ctx context.Context, key string, cached CachedSummary) error {
+	if key == "" {
+		return nil
+	}
+	raw, err := json.Marshal(cached)
+	if err != nil {
+		return err
+	}
+	return s.redis.Set(ctx, key, raw, summaryCacheTTL).Err()
+}
@@ -0,0 +1,42 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"ithozyeva/internal/models"
+)
+
+func TestSummarizeDailyLimit(t *testing.T) {
+	cases := map[int]int{-1: 3, 0: 3, 1: 5, 2: 10, 3: 20, 4: 50, 7: 50}
+	for level, want := range cases {
+		if got := SummarizeDailyLimit(level); got != want {
+			t.Errorf("level %d: got %d, want %d", level, got, want)
+		}
+	}
+}
+
+func TestSummarizeQuotaKeyUsesMSKDay(t *testing.T) {
+	// 22:30 UTC — уже следующие сутки по МСК.
+	now := time.Date(2026, 5, 26, 22, 30, 0, 0, time.UTC)
+	if got, want := summarizeQuotaKey(42, now), "summarize:quota:42:2026-05-27"; got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+}
+
+func TestSummaryCacheKey(t *testing.T) {
+	if SummaryCacheKey(1, nil) != "" {
+		t.Fatal("empty window must not be cached")
+	}
+	// DESC, как отдаёт репозиторий.
+	msgs := []models.ChatMessage{{Id: 30}, {Id: 20}, {Id: 10}}
+	key := SummaryCacheKey(-100, msgs)
+	if key != "summarize:cache:-100:10:30:3" {
+		t.Fatalf("key = %q", key)
+	}
+	// Новое сообщение в окне — другой ключ.
+	grown := append([]models.ChatMessage{{Id: 31}}, msgs...)
+	if SummaryCacheKey(-100, grown) == key {
+		t.Fatal("new message must change the key")
+	}
+}
@@ -0,0 +1,239 @@
+package service
+
+import (
+	"log"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"ithozyeva/config"
+	"ithozyeva/internal/models"
+	"ithozyeva/internal/repository"
+)
+
+const (
+	// MaxVideoLinksPerMessage — больше ссылок из одного сообщения не качаем.
+	MaxVideoLinksPerMessage  = 3
+	videoDownloadRecentFails = 20
+)
+
+// videoURLTail — хвост ссылки после распознанного начала (query, якорь).
+const videoURLTail = `[\w\-._~:/?#\[\]@!$&'()*+,;=%]*`
+
+// defaultVideoSources — встроенные источники: имя (оно же разрез
+// статистики) и начало ссылки без схемы.
+var defaultVideoSources = []struct{ name, pattern string }{
+	{"instagram", `(?:www\.)?instagram\.com/reels?/[\w-]+`},
+	{"tiktok", `(?:www\.)?tiktok\.com/@[\w.]+/video/\d+|(?:vm|vt)\.tiktok\.com/[\w-]+`},
+	{"youtube", `(?:www\.|m\.)?youtube\.com/shorts/[\w-]+|youtu\.be/[\w-]+`},
+	{"vk", `(?:(?:www\.|m\.)?vk\.(?:com|ru)|vkvideo\.ru)/clip-?\d+_\d+`},
+	{"x", `(?:www\.|mobile\.)?(?:x|twitter)\.com/\w+/status/\d+`},
+	{"reddit", `(?:(?:www|old|new)\.)?reddit\.com/r/\w+/(?:comments|s)/\w+|v\.redd\.it/\w+`},
+}
+
+// VideoSource — источник коротких видео, который умеет yt-dlp.
+type VideoSource struct {
+	Name    string
+	Pattern *regexp.Regexp
+}
+
+// VideoLink — найденная в сообщении ссылка.
+type VideoLink struct {
+	URL    string // как в сообщении — её и отдаём yt-dlp
+	Key    string // NormalizeVideoURL — ключ кэша
+	Source string
+}
+
+// VideoSources — встроенные источники и дополнительные из
+// VIDEO_URL_PATTERNS: «имя=regex;имя=regex», regex — вся ссылка со схемой.
+// Битые шаблоны пропускаем с предупреждением в лог.
+func VideoSources(extra string) []VideoSource {
+	sources := make([]VideoSource, 0, len(defaultVideoSources))
+	for _, s := range defaultVideoSources {
+		sources = append(sources, VideoSource{
+			Name:    s.name,
+			Pattern: regexp.MustCompile(`https?://(?:` + s.pattern + `)` + videoURLTail),
+		})
+	}
+	for _, item := range strings.Split(extra, ";") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		name, pattern, ok := strings.Cut(item, "=")
+		name, pattern = strings.TrimSpace(name), strings.TrimSpace(pattern)
+		if !ok || name == "" || pattern == "" {
+			log.Printf("VIDEO_URL_PATTERNS: skip %q: want name=regex", item)
+			continue
+		}
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			log.Printf("VIDEO_URL_PATTERNS: skip %q: %v", name, err)
+			continue
+		}
+		sources = append(sources, VideoSource{Name: name, Pattern: re})
+	}
+	return sources
+}
+
+// ExtractVideoLinks — до MaxVideoLinksPerMessage ссылок в порядке
+// появления в тексте; один ролик дважды не берём.
+func ExtractVideoLinks(sources []VideoSource, text string) []VideoLink {
+	type found struct {
+		pos  int
+		link VideoLink
+	}
+	var all []found
+	for _, s := range sources {
+		for _, loc := range s.Pattern.FindAllStringIndex(text, MaxVideoLinksPerMessage) {
+			// Знак препинания после ссылки в конце фразы — не её часть.
+			raw := strings.TrimRight(text[loc[0]:loc[1]], ".,;:!?")
+			all = append(all, found{loc[0], VideoLink{URL: raw, Key: NormalizeVideoURL(raw), Source: s.Name}})
+		}
+	}
+	sort.SliceStable(all, func(i, j int) bool { return all[i].pos < all[j].pos })
+
+	var links []VideoLink
+	seen := make(map[string]bool)
+	for _, f := range all {
+		if len(links) == MaxVideoLinksPerMessage {
+			break
+		}
+		if seen[f.link.Key] {
+			continue
+		}
+		seen[f.link.Key] = true
+		links = append(links, f.link)
+	}
+	return links
+}
+
+// videoHostAliases — зеркала одного сайта, чтобы кэш был общий.
+var videoHostAliases = map[string]string{
+	"twitter.com": "x.com",
+	"vk.ru":       "vk.com",
+}
+
+// NormalizeVideoURL — ключ кэша: https, хост без www./m. и зеркал, путь
+// без завершающего «/»; query и якорь отбрасываем — у поддерживаемых
+// источников ролик определяется путём, а в query трекинг (?igsh=, ?si=).
+func NormalizeVideoURL(raw string) string {
+	u, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil || u.Host == "" {
+		return raw
+	}
+	host := strings.ToLower(u.Hostname())
+	for _, prefix := range []string{"www.", "m.", "mobile.", "old.", "new."} {
+		host = strings.TrimPrefix(host, prefix)
+	}
+	if alias, ok := videoHostAliases[host]; ok {
+		host = alias
+	}
+	return "https://" + host + strings.TrimRight(u.EscapedPath(), "/")
+}
+
+// VideoDownloadReport — сводка для админки.
+type VideoDownloadReport struct {
+	Since          time.Time                 `json:"since"`
+	Sources        []models.VideoSourceStats `json:"sources"`
+	Days           []models.VideoDownloadDay `json:"days"`
+	Cache          models.VideoCacheSummary  `json:"cache"`
+	RecentFailures []models.VideoDownload    `json:"recentFailures"`
+}
+
+// VideoDownloadService — кэш file_id и журнал скачиваний. Само скачивание
+// (yt-dlp, очередь, лимиты) живёт в боте.
+type VideoDownloadService struct {
+	repo    *repository.VideoDownloadRepository
+	sources []VideoSource
+}
+
+func NewVideoDownloadService() *VideoDownloadService {
+	return &VideoDownloadService{
+		repo:    repository.NewVideoDownloadRepository(),
+		sources: VideoSources(config.CFG.VideoURLPatterns),
+	}
+}
+
+// ExtractLinks — ссылки на видео из текста сообщения.
+func (s *VideoDownloadService) ExtractLinks(text string) []VideoLink {
+	return ExtractVideoLinks(s.sources, text)
+}
+
+// CachedFileID — file_id уже загруженного ролика; ошибка БД — как промах.
+func (s *VideoDownloadService) CachedFileID(link VideoLink) (string, bool) {
+	entry, err := s.repo.GetCached(link.Key)
+	if err != nil {
+		log.Printf("video cache get %s: %v", link.Key, err)
+		return "", false
+	}
+	if entry == nil {
+		return "", false
+	}
+	return entry.FileID, true
+}
+
+// CacheHit — ролик переслан из кэша.
+func (s *VideoDownloadService) CacheHit(link VideoLink) {
+	if err := s.repo.TouchCached(link.Key, time.Now()); err != nil {
+		log.Printf("video cache touch %s: %v", link.Key, err)
+	}
+}
+
+// Remember — ролик загружен в Telegram, дальше шлём по fileID.
+func (s *VideoDownloadService) Remember(link VideoLink, fileID string, size int64) {
+	err := s.repo.SaveCached(&models.VideoFileCache{
+		SourceURL:  link.Key,
+		Source:     link.Source,
+		FileID:     fileID,
+		FileSize:   size,
+		LastUsedAt: time.Now(),
+	})
+	if err != nil {
+		log.Printf("video cache save %s: %v", link.Key, err)
+	}
+}
+
+// Forget — Telegram отверг file_id (файл удалён или бот сменился).
+func (s *VideoDownloadService) Forget(link VideoLink) {
+	if err := s.repo.DeleteCached(link.Key); err != nil {
+		log.Printf("video cache delete %s: %v", link.Key, err)
+	}
+}
+
+// Record пишет исход запроса в журнал; ошибка журнала скачивание не ломает.
+func (s *VideoDownloadService) Record(d *models.VideoDownload) {
+	d.Error = truncateError(d.Error, 500)
+	if err := s.repo.Create(d); err != nil {
+		log.Printf("video download log: %v", err)
+	}
+}
+
+// Report — скачивания с since.
+func (s *VideoDownloadService) Report(since time.Time) (*VideoDownloadReport, error) {
+	sources, err := s.repo.StatsBySource(since)
+	if err != nil {
+		return nil, err
+	}
+	days, err := s.repo.StatsByDay(since)
+	if err != nil {
+		return nil, err
+	}
+	cache, err := s.repo.CacheSummary()
+	if err != nil {
+		return nil, err
+	}
+	failures, err := s.repo.RecentFailures(since, videoDownloadRecentFails)
+	if err != nil {
+		return nil, err
+	}
+	return &VideoDownloadReport{
+		Since:          since,
+		Sources:        sources,
+		Days:           days,
+		Cache:          cache,
+		RecentFailures: failures,
+	}, nil
+}
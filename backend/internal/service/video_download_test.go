@@ -0,0 +1,57 @@
+package service
+
+import "testing"
+
+func TestNormalizeVideoURL(t *testing.T) {
+	cases := map[string]string{
+		"https://www.instagram.com/reel/Cabc123/?igsh=xyz": "https://instagram.com/reel/Cabc123",
+		"http://instagram.com/reel/Cabc123":                "https://instagram.com/reel/Cabc123",
+		"https://youtu.be/dQw4w9WgXcQ?si=track":            "https://youtu.be/dQw4w9WgXcQ",
+		"https://m.youtube.com/shorts/abc_DEF-1":           "https://youtube.com/shorts/abc_DEF-1",
+		"https://twitter.com/jack/status/20":               "https://x.com/jack/status/20",
+		"https://mobile.x.com/jack/status/20#reply":        "https://x.com/jack/status/20",
+		"https://old.reddit.com/r/golang/comments/abc/":    "https://reddit.com/r/golang/comments/abc",
+		"https://vk.ru/clip-123_456":                       "https://vk.com/clip-123_456",
+	}
+	for in, want := range cases {
+		if got := NormalizeVideoURL(in); got != want {
+			t.Errorf("NormalizeVideoURL(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestExtractVideoLinks(t *testing.T) {
+	sources := VideoSources("")
+	text := "смотри https://x.com/jack/status/20 и https://vkvideo.ru/clip-1_2, " +
+		"а ещё https://twitter.com/jack/status/20?s=46 https://v.redd.it/abc https://youtu.be/q"
+	links := ExtractVideoLinks(sources, text)
+
+	want := []struct{ source, url string }{
+		{"x", "https://x.com/jack/status/20"},
+		{"vk", "https://vkvideo.ru/clip-1_2"},
+		{"reddit", "https://v.redd.it/abc"},
+	}
+	if len(links) != len(want) {
+		t.Fatalf("got %d links (%+v), want %d", len(links), links, len(want))
+	}
+	for i, w := range want {
+		if links[i].Source != w.source || links[i].URL != w.url {
+			t.Errorf("link %d = %+v, want %s %s", i, links[i], w.source, w.url)
+		}
+	}
+
+	if got := ExtractVideoLinks(sources, "https://example.com/video/1"); len(got) != 0 {
+		t.Errorf("unknown host matched: %+v", got)
+	}
+}
+
+func TestVideoSourcesExtraPatterns(t *testing.T) {
+	sources := VideoSources(`dzen=https?://dzen\.ru/shorts/\w+; broken=([; noeq`)
+	if len(sources) != len(defaultVideoSources)+1 {
+		t.Fatalf("got %d sources, want defaults + dzen", len(sources))
+	}
+	links := ExtractVideoLinks(sources, "https://dzen.ru/shorts/abc")
+	if len(links) != 1 || links[0].Source != "dzen" {
+		t.Errorf("extra pattern not applied: %+v", links)
+	}
+}
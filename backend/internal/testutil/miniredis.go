@@ -0,0 +1,19 @@
+package testutil
+
+import (
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+// NewMiniRedis — Redis в памяти процесса для юнит-тестов: Lua-скрипты и
+// TTL работают, Docker не нужен. Время для TTL стоит, пока тест не сдвинет
+// его через mr.FastForward. Сервер и клиент закрываются в t.Cleanup.
+func NewMiniRedis(t *testing.T) (*miniredis.Miniredis, *redis.Client) {
+	t.Helper()
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+	return mr, client
+}
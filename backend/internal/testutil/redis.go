@@ -0,0 +1,77 @@
+package testutil
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+const redisImage = "redis:7-alpine"
+
+var (
+	redisOnce       sync.Once
+	sharedRedis     *redis.Client
+	sharedRedisErr  error
+	redisSkipReason string
+)
+
+// EnsureTestRedis — как EnsureTestDB, но для Redis: один контейнер на
+// тестовый процесс, перед каждым тестом база очищается (FLUSHDB). Если
+// Docker недоступен или TEST_SKIP_DB=1 — t.Skip.
+func EnsureTestRedis(t *testing.T) *redis.Client {
+	t.Helper()
+
+	if os.Getenv("TEST_SKIP_DB") == "1" {
+		t.Skip("TEST_SKIP_DB=1, пропускаю интеграционный тест")
+	}
+
+	redisOnce.Do(initSharedRedis)
+
+	if redisSkipReason != "" {
+		t.Skip(redisSkipReason)
+	}
+	if sharedRedisErr != nil {
+		t.Fatalf("init shared redis: %v", sharedRedisErr)
+	}
+	if err := sharedRedis.FlushDB(context.Background()).Err(); err != nil {
+		t.Fatalf("flush redis: %v", err)
+	}
+	return sharedRedis
+}
+
+func initSharedRedis() {
+	ctx := context.Background()
+
+	container, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
+		ContainerRequest: testcontainers.ContainerRequest{
+			Image:        redisImage,
+			ExposedPorts: []string{"6379/tcp"},
+			WaitingFor:   wait.ForLog("Ready to accept connections").WithStartupTimeout(60 * time.Second),
+		},
+		Started: true,
+	})
+	if err != nil {
+		redisSkipReason = fmt.Sprintf("не удалось поднять redis-контейнер (Docker недоступен?): %v", err)
+		return
+	}
+
+	endpoint, err := container.Endpoint(ctx, "")
+	if err != nil {
+		sharedRedisErr = fmt.Errorf("redis endpoint: %w", err)
+		return
+	}
+
+	client := redis.NewClient(&redis.Options{Addr: endpoint})
+	if err := client.Ping(ctx).Err(); err != nil {
+		sharedRedisErr = fmt.Errorf("redis ping: %w", err)
+		return
+	}
+	sharedRedis = client
+}
@@ -175,7 +175,29 @@ func SetupAdminRoutes(app *fiber.App, db *gorm.DB, redisClient *redis.Client) {
 	points := protected.Group("/points", authMiddleware.RequirePermission(models.PermissionCanViewAdminPoints))
 	points.Get("/", pointsHandler.AdminSearch)
 	points.Post("/", authMiddleware.RequirePermission(models.PermissionCanEditAdminPoints), pointsHandler.AdminAward)
-	points.Delete("/:id", authMiddleware.RequirePermission(models.PermissionCanEditAdminPoints), pointsHandler.AdminDelete)
+	points.Post("/reconcile", authMiddleware.RequirePermission(models.PermissionCanEditAdminPoints), pointsHandler.AdminReconcile)
+	points.Post("/:id/reverse", authMiddleware.RequirePermission(models.PermissionCanEditAdminPoints), pointsHandler.AdminReverse)
+
+	// Потоковая выгрузка журналов баллов и кредитов (CSV/JSON).
+	adminLedgerExportHandler := handler.NewLedgerExportHandler()
+	points.Get("/export", adminLedgerExportHandler.AdminExportPoints)
+
+	// Анти-абуз: очередь подозрительных начислений и списания.
+	pointsAbuseHandler := handler.NewPointsAbuseHandler()
+	points.Get("/flags", pointsAbuseHandler.Flags)
+	points.Get("/flags/:id", pointsAbuseHandler.Flag)
+	points.Post("/flags/:id/clawback", authMiddleware.RequirePermission(models.PermissionCanEditAdminPoints), pointsAbuseHandler.Clawback)
+	points.Post("/flags/:id/dismiss", authMiddleware.RequirePermission(models.PermissionCanEditAdminPoints), pointsAbuseHandler.Dismiss)
+
+	// Магазин наград за баллы: каталог и выдача заказов.
+	shopHandler := handler.NewShopHandler(redisClient)
+	adminShop := protected.Group("/shop", authMiddleware.RequirePermission(models.PermissionCanViewAdminPoints))
+	adminShop.Get("/items", shopHandler.AdminItems)
+	adminShop.Post("/items", authMiddleware.RequirePermission(models.PermissionCanEditAdminPoints), shopHandler.CreateItem)
+	adminShop.Put("/items/:id", authMiddleware.RequirePermission(models.PermissionCanEditAdminPoints), shopHandler.UpdateItem)
+	adminShop.Delete("/items/:id", authMiddleware.RequirePermission(models.PermissionCanEditAdminPoints), shopHandler.DeleteItem)
+	adminShop.Get("/orders", shopHandler.AdminOrders)
+	adminShop.Patch("/orders/:id", authMiddleware.RequirePermission(models.PermissionCanEditAdminPoints), shopHandler.SetOrderStatus)
 
 	// Маршруты для реферальных кредитов (админ). Переиспользуем permission
 	// от points: семантика близкая, плодить отдельный CanView/EditCredits
@@ -184,6 +206,7 @@ func SetupAdminRoutes(app *fiber.App, db *gorm.DB, redisClient *redis.Client) {
 	credits := protected.Group("/credits", authMiddleware.RequirePermission(models.PermissionCanViewAdminPoints))
 	credits.Get("/", creditsHandler.AdminSearch)
 	credits.Post("/", authMiddleware.RequirePermission(models.PermissionCanEditAdminPoints), creditsHandler.AdminAward)
+	credits.Get("/export", adminLedgerExportHandler.AdminExportCredits)
 
 	// Маршруты для журнала действий
 	auditLogHandler := handler.NewAuditLogHandler()
@@ -205,6 +228,7 @@ func SetupAdminRoutes(app *fiber.App, db *gorm.DB, redisClient *redis.Client) {
 	chatActivity.Get("/chats", chatActivityHandler.GetChats)
 	chatActivity.Get("/user-stats", chatActivityHandler.GetUserStats)
 	chatActivity.Get("/export", chatActivityHandler.ExportCSV)
+	chatActivity.Get("/llm-usage", handler.NewLLMUsageHandler().GetReport)
 
 	// Маршруты для заданий чатов (админ)
 	chatQuestHandler := handler.NewChatQuestHandler()
@@ -220,6 +244,8 @@ func SetupAdminRoutes(app *fiber.App, db *gorm.DB, redisClient *redis.Client) {
 	adminRaffles.Get("/", raffleHandler.GetAllAdmin)
 	adminRaffles.Post("/", raffleHandler.Create)
 	adminRaffles.Delete("/:id", raffleHandler.Delete)
+	adminRaffles.Get("/:id/winners", raffleHandler.GetWinners)
+	adminRaffles.Put("/winners/:id", raffleHandler.UpdateFulfillment)
 
 	// Геймификация (админ): пул дейликов и шаблоны челленджей
 	adminDailyTasks := protected.Group("/daily-tasks", authMiddleware.RequirePermission(models.PermissionCanViewAdminPoints))
@@ -243,6 +269,11 @@ func SetupAdminRoutes(app *fiber.App, db *gorm.DB, redisClient *redis.Client) {
 	adminCasino := protected.Group("/minigames")
 	adminCasino.Get("/stats", casinoHandler.GetAdminStats)
 	adminCasino.Get("/bets", casinoHandler.GetAdminBets)
+	adminCasino.Get("/limits", casinoHandler.GetGlobalLimits)
+	adminCasino.Put("/limits", authMiddleware.RequirePermission(models.PermissionCanEditAdminPoints), casinoHandler.UpdateGlobalLimits)
+	adminCasino.Get("/limits/members/:id", casinoHandler.GetMemberLimits)
+	adminCasino.Get("/crash/rounds", casinoHandler.GetCrashRounds)
+	adminCasino.Get("/crash/rounds/:id", casinoHandler.GetCrashRound)
 
 	// Маршруты для тегов ивентов
 	eventTagHandler := handler.NewEventTagHandler()
@@ -272,6 +303,16 @@ func SetupAdminRoutes(app *fiber.App, db *gorm.DB, redisClient *redis.Client) {
 		subs.Delete("/users/:id/access/:chatId", authMiddleware.RequirePermission(models.PermissionCanEditAdminSubscriptions), subscriptionHandler.RevokeAccess)
 	}
 
+	// База знаний: модераторы собирают статьи из хайлайтов
+	kbHandler := handler.NewKnowledgeBaseHandler()
+	knowledgeBase := protected.Group("/knowledge-base", authMiddleware.RequirePermission(models.PermissionCanViewAdminModeration))
+	knowledgeBase.Get("/", kbHandler.Search)
+	knowledgeBase.Get("/highlights", handler.NewChatHighlightHandler().Search)
+	knowledgeBase.Get("/:id", kbHandler.GetById)
+	knowledgeBase.Post("/", authMiddleware.RequirePermission(models.PermissionCanEditAdminModeration), kbHandler.Create)
+	knowledgeBase.Put("/:id", authMiddleware.RequirePermission(models.PermissionCanEditAdminModeration), kbHandler.Update)
+	knowledgeBase.Delete("/:id", authMiddleware.RequirePermission(models.PermissionCanEditAdminModeration), kbHandler.Delete)
+
 	// Маршруты для обратной связи (NPS)
 	feedbackHandler := handler.NewFeedbackHandler()
 	feedback := protected.Group("/feedback", authMiddleware.RequirePermission(models.PermissionCanViewAdminFeedback))
@@ -285,16 +326,61 @@ func SetupAdminRoutes(app *fiber.App, db *gorm.DB, redisClient *redis.Client) {
 		moderation.Get("/sanctions", moderationHandler.GetActiveSanctions)
 		moderation.Get("/actions", moderationHandler.GetRecentActions)
 		moderation.Get("/global-bans", moderationHandler.GetGlobalBans)
+		moderation.Get("/global-bans/export", moderationHandler.ExportGlobalBans)
 		moderation.Get("/votebans", moderationHandler.GetOpenVotebans)
+		moderation.Get("/raids", moderationHandler.GetRaids)
 		moderation.Post("/sanctions/:id/revoke",
 			authMiddleware.RequirePermission(models.PermissionCanEditAdminModeration),
 			moderationHandler.RevokeSanction)
+		moderation.Post("/global-bans/import",
+			authMiddleware.RequirePermission(models.PermissionCanEditAdminModeration),
+			moderationHandler.ImportGlobalBans)
+		moderation.Post("/global-bans/sync",
+			authMiddleware.RequirePermission(models.PermissionCanEditAdminModeration),
+			moderationHandler.SyncGlobalBans)
 		moderation.Delete("/global-bans/:user_id",
 			authMiddleware.RequirePermission(models.PermissionCanEditAdminModeration),
 			moderationHandler.RevokeGlobalBan)
 		moderation.Post("/votebans/:id/cancel",
 			authMiddleware.RequirePermission(models.PermissionCanEditAdminModeration),
 			moderationHandler.CancelVoteban)
+		moderation.Get("/settings", moderationHandler.GetChatSettingsList)
+		moderation.Get("/chats/:chat_id/settings", moderationHandler.GetChatSettings)
+		moderation.Put("/chats/:chat_id/settings",
+			authMiddleware.RequirePermission(models.PermissionCanEditAdminModeration),
+			moderationHandler.UpdateChatSettings)
+		moderation.Delete("/chats/:chat_id/settings",
+			authMiddleware.RequirePermission(models.PermissionCanEditAdminModeration),
+			moderationHandler.ResetChatSettings)
+		moderation.Put("/chats/:chat_id/moderators/:user_id",
+			authMiddleware.RequirePermission(models.PermissionCanEditAdminModeration),
+			moderationHandler.SetChatModerator)
+		moderation.Delete("/chats/:chat_id/moderators/:user_id",
+			authMiddleware.RequirePermission(models.PermissionCanEditAdminModeration),
+			moderationHandler.RemoveChatModerator)
+		moderation.Get("/policies", moderationHandler.GetRestrictionPolicies)
+		moderation.Get("/chats/:chat_id/policy", moderationHandler.GetRestrictionPolicy)
+		moderation.Put("/chats/:chat_id/policy",
+			authMiddleware.RequirePermission(models.PermissionCanEditAdminModeration),
+			moderationHandler.UpdateRestrictionPolicy)
+		moderation.Delete("/chats/:chat_id/policy",
+			authMiddleware.RequirePermission(models.PermissionCanEditAdminModeration),
+			moderationHandler.ResetRestrictionPolicy)
+
+		// Автоответчик бота: кулдауны триггеров живут в Redis.
+		faqHandler := handler.NewFAQHandler(redisClient)
+		faq := protected.Group("/faq", authMiddleware.RequirePermission(models.PermissionCanViewAdminModeration))
+		faq.Get("/", faqHandler.List)
+		faq.Get("/stats", faqHandler.Stats)
+		faq.Post("/test", faqHandler.Test)
+		faq.Post("/", authMiddleware.RequirePermission(models.PermissionCanEditAdminModeration), faqHandler.Create)
+		faq.Put("/:id", authMiddleware.RequirePermission(models.PermissionCanEditAdminModeration), faqHandler.Update)
+		faq.Delete("/:id", authMiddleware.RequirePermission(models.PermissionCanEditAdminModeration), faqHandler.Delete)
+
+		// Скачивание видео ботом: кэш file_id и исходы запросов.
+		protected.Get("/video-downloads/stats",
+			authMiddleware.RequirePermission(models.PermissionCanViewAdminModeration),
+			handler.NewVideoDownloadHandler().Stats)
 	}
 }
 
@@ -339,6 +425,7 @@ func SetupPlatformRoutes(app *fiber.App, db *gorm.DB, redisClient *redis.Client)
 	members := protected.Group("/members")
 	members.Get("/me", memberHandler.Me)
 	members.Patch("/me", memberHandler.UpdateProfile)
+	members.Put("/me/language", memberHandler.SetMyLanguage)
 	members.Post("/me/avatar", memberHandler.UploadAvatar)
 	// Реф-кабинет: персональный код, deeplink, статистика приглашённых,
 	// баланс кредитов и история. Доступно UNSUBSCRIBER'у тоже —
@@ -360,6 +447,11 @@ func SetupPlatformRoutes(app *fiber.App, db *gorm.DB, redisClient *redis.Client)
 	// упираться в гейт подписки и улетать на /tariffs прямо с профиля.
 	members.Get("/:id", memberHandler.GetPublicProfile)
 
+	// Поиск по платформе — открыт всем авторизованным: сервис сам отсекает
+	// события и AI-материалы для UNSUBSCRIBER'а. Тот же поиск отвечает на
+	// inline-запросы бота.
+	protected.Get("/search", handler.NewPlatformSearchHandler().Search)
+
 	// Уведомления и их настройки — нужны и UNSUBSCRIBER'у (например, подписка
 	// истекла → push-уведомление, или анонс снижения цены).
 	notificationHandler := handler.NewNotificationHandler()
@@ -387,6 +479,8 @@ func SetupPlatformRoutes(app *fiber.App, db *gorm.DB, redisClient *redis.Client)
 	// юзер должен видеть, хватает ли ему кредитов до покупки подписки.
 	platformCreditsHandler := handler.NewReferralCreditHandler()
 	protected.Get("/credits/me", platformCreditsHandler.GetMine)
+	ledgerExportHandler := handler.NewLedgerExportHandler()
+	protected.Get("/credits/me/export", ledgerExportHandler.ExportMyCredits)
 
 	// Обратная связь о платформе — должен мочь оставить кто угодно.
 	feedbackHandler := handler.NewFeedbackHandler()
@@ -449,7 +543,10 @@ func SetupPlatformRoutes(app *fiber.App, db *gorm.DB, redisClient *redis.Client)
 	pointsHandler := handler.NewPointsHandler()
 	points := subscribed.Group("/points")
 	points.Get("/me", pointsHandler.GetMyPoints)
+	points.Get("/me/export", ledgerExportHandler.ExportMyPoints)
+	points.Get("/me/statement", ledgerExportHandler.GetMyStatement)
 	points.Get("/leaderboard", pointsHandler.GetLeaderboard)
+	points.Get("/leaderboard/history", pointsHandler.GetLeaderboardHistory)
 
 	// Чат-квесты
 	chatQuestHandler := handler.NewChatQuestHandler()
@@ -484,6 +581,24 @@ func SetupPlatformRoutes(app *fiber.App, db *gorm.DB, redisClient *redis.Client)
 	highlights.Get("/recent", highlightHandler.GetRecent)
 	highlights.Get("/", highlightHandler.Search)
 
+	// Архив еженедельных AI-дайджестов чатов
+	digestHandler := handler.NewChatDigestHandler()
+	digests := subscribed.Group("/digests")
+	digests.Get("/", digestHandler.List)
+	digests.Get("/:id", digestHandler.GetById)
+
+	// База знаний (чтение)
+	platformKBHandler := handler.NewKnowledgeBaseHandler()
+	platformKB := subscribed.Group("/knowledge-base")
+	platformKB.Get("/", platformKBHandler.Search)
+	platformKB.Get("/:id", platformKBHandler.GetById)
+
+	// Поиск по истории чатов (только чаты, доступные по подписке)
+	chatSearchHandler := handler.NewChatSearchHandler()
+	chatSearch := subscribed.Group("/chat-search")
+	chatSearch.Get("/", chatSearchHandler.Search)
+	chatSearch.Get("/chats", chatSearchHandler.GetChats)
+
 	// AI-материалы — открыты любому подписчику. Раньше были master+,
 	// но раздел оказался полезным как точка притяжения для всей платной
 	// аудитории, поэтому перенесли с tierMaster на subscribed.
@@ -530,6 +645,15 @@ func SetupPlatformRoutes(app *fiber.App, db *gorm.DB, redisClient *redis.Client)
 	raffles.Get("/", raffleHandler.GetAll)
 	raffles.Get("/daily/today", raffleHandler.DailyToday)
 	raffles.Post("/:id/buy", raffleHandler.BuyTickets)
+	raffles.Get("/:id/draw", raffleHandler.GetDrawProof)
+
+	// Магазин наград за баллы
+	shopHandler := handler.NewShopHandler(redisClient)
+	shop := subscribed.Group("/shop")
+	shop.Get("/items", shopHandler.Items)
+	shop.Post("/items/:id/buy", shopHandler.Buy)
+	shop.Get("/orders", shopHandler.MyOrders)
+	shop.Post("/orders/:id/cancel", shopHandler.CancelMyOrder)
 
 	// Казино
 	casinoHandler := handler.NewCasinoHandler()
@@ -540,6 +664,19 @@ func SetupPlatformRoutes(app *fiber.App, db *gorm.DB, redisClient *redis.Client)
 	casino.Get("/history", casinoHandler.GetHistory)
 	casino.Get("/feed", casinoHandler.GetGlobalFeed)
 	casino.Get("/stats", casinoHandler.GetStats)
+	casino.Get("/seed", casinoHandler.GetSeed)
+	casino.Post("/seed/rotate", casinoHandler.RotateSeed)
+	casino.Get("/verify/:id", casinoHandler.VerifyBet)
+	casino.Get("/limits", casinoHandler.GetLimits)
+	casino.Put("/limits", casinoHandler.UpdateLimits)
+	casino.Post("/limits/exclude", casinoHandler.SelfExclude)
+	casino.Get("/limits/history", casinoHandler.GetLimitsHistory)
+	casino.Get("/crash", casinoHandler.GetCrash)
+	casino.Post("/crash/bet", casinoHandler.PlaceCrashBet)
+	casino.Post("/crash/cashout", casinoHandler.CrashCashOut)
+	casino.Get("/crash/rounds", casinoHandler.GetCrashRounds)
+	casino.Get("/crash/rounds/:id", casinoHandler.GetCrashRound)
+	casino.Get("/jackpot", casinoHandler.GetJackpot)
 
 	// Статистика профиля
 	profileStatsHandler := handler.NewProfileStatsHandler()
@@ -554,6 +691,8 @@ func SetupPlatformRoutes(app *fiber.App, db *gorm.DB, redisClient *redis.Client)
 	dailies.Post("/check-in", dailiesHandler.CheckIn)
 	streak := subscribed.Group("/streak")
 	streak.Get("/me", dailiesHandler.MyStreak)
+	streak.Post("/freezes", dailiesHandler.BuyFreezes)
+	streak.Post("/repair", dailiesHandler.RepairStreak)
 
 	// Челленджи (еженедельные + ежемесячные)
 	challengesHandler := handler.NewChallengesHandler()
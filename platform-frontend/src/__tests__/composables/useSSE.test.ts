@@ -75,6 +75,19 @@ describe('useSSE', () => {
     expect(callback).toHaveBeenCalledOnce()
   })
 
+  it('passes event data to the callback', async () => {
+    localStorage.setItem('tg_token', 'token')
+    const { useSSE } = await import('@/composables/useSSE')
+    const callback = vi.fn()
+
+    withSetup(() => useSSE('crash', callback))
+
+    const es = MockEventSource.instances[0]
+    es.simulateMessage(JSON.stringify({ type: 'crash', data: { event: 'running', round: { id: 7 } } }))
+
+    expect(callback).toHaveBeenCalledWith({ event: 'running', round: { id: 7 } })
+  })
+
   it('does not call callback for non-matching event type', async () => {
     localStorage.setItem('tg_token', 'token')
     const { useSSE } = await import('@/composables/useSSE')
@@ -0,0 +1,40 @@
+import { afterEach, describe, expect, it } from 'vitest'
+import { getStartParam, startParamRoute } from '@/composables/useTelegramWebApp'
+
+describe('startParamRoute', () => {
+  it.each([
+    ['event-12', '/events'],
+    ['mentor-3', '/mentors/3'],
+    ['member-42', '/members/42'],
+    ['ai_material-7', '/ai-materials/7'],
+  ])('routes %s to %s', (param, route) => {
+    expect(startParamRoute(param)).toBe(route)
+  })
+
+  it.each([null, '', 'event', 'event-abc', 'unknown-1', 'from_site'])('ignores %s', (param) => {
+    expect(startParamRoute(param)).toBeNull()
+  })
+})
+
+describe('getStartParam', () => {
+  afterEach(() => {
+    delete window.Telegram
+    window.history.replaceState({}, '', '/')
+  })
+
+  it('reads start_param from initDataUnsafe', () => {
+    window.Telegram = { WebApp: { initData: 'x', initDataUnsafe: { start_param: 'member-5' } } as any }
+
+    expect(getStartParam()).toBe('member-5')
+  })
+
+  it('falls back to tgWebAppStartParam in the URL', () => {
+    window.history.replaceState({}, '', '/?tgWebAppStartParam=mentor-2')
+
+    expect(getStartParam()).toBe('mentor-2')
+  })
+
+  it('returns null without a parameter', () => {
+    expect(getStartParam()).toBeNull()
+  })
+})
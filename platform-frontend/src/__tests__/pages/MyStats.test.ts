@@ -14,6 +14,7 @@ vi.mock('lucide-vue-next', () => ({
   ClipboardList: { template: '<span />' },
   Heart: { template: '<span />' },
   Loader2: { template: '<span class="loader" />' },
+  Medal: { template: '<span />' },
   MessageSquare: { template: '<span />' },
   Mic: { template: '<span />' },
   Minus: { template: '<span class="arrow-same" />' },
@@ -149,6 +150,31 @@ describe('MyStats page', () => {
     expect(wrapper.text()).not.toContain('Место в рейтинге')
   })
 
+  it('shows leaderboard wins from profile stats', async () => {
+    mockGetMyStats.mockResolvedValue({
+      ...sampleStats,
+      leaderboardWins: [
+        { period: 'weekly', periodKey: '2026-W42', endsAt: '2026-10-19T00:00:00Z', rank: 1, total: 320, prize: 100 },
+        { period: 'monthly', periodKey: '2026-09', endsAt: '2026-10-01T00:00:00Z', rank: 3, total: 900, prize: 0 },
+      ],
+    })
+    mockGetLeaderboard.mockResolvedValue({ items: [] })
+    const wrapper = mount(MyStats)
+    await flushPromises()
+    expect(wrapper.text()).toContain('Победы в рейтингах')
+    expect(wrapper.text()).toContain('Неделя 2026-W42')
+    expect(wrapper.text()).toContain('приз 100')
+    expect(wrapper.text()).toContain('Месяц 2026-09')
+  })
+
+  it('hides leaderboard wins when there are none', async () => {
+    mockGetMyStats.mockResolvedValue({ ...sampleStats, leaderboardWins: [] })
+    mockGetLeaderboard.mockResolvedValue({ items: [] })
+    const wrapper = mount(MyStats)
+    await flushPromises()
+    expect(wrapper.text()).not.toContain('Победы в рейтингах')
+  })
+
   it('shows points chart when history exists', async () => {
     mockGetMyStats.mockResolvedValue(sampleStats)
     mockGetLeaderboard.mockResolvedValue({ items: [] })
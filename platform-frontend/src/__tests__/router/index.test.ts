@@ -5,6 +5,10 @@ import { createPinia, setActivePinia } from 'pinia'
 vi.mock('@/pages/AutoApplyBot.vue', () => ({ default: { template: '<div />' } }))
 vi.mock('@/pages/Content.vue', () => ({ default: { template: '<div />' } }))
 vi.mock('@/pages/Dashboard.vue', () => ({ default: { template: '<div />' } }))
+vi.mock('@/pages/Digests.vue', () => ({ default: { template: '<div />' } }))
+vi.mock('@/pages/KnowledgeBase.vue', () => ({ default: { template: '<div />' } }))
+vi.mock('@/pages/KnowledgeBaseEntry.vue', () => ({ default: { template: '<div />' } }))
+vi.mock('@/pages/Shop.vue', () => ({ default: { template: '<div />' } }))
 vi.mock('@/pages/Events.vue', () => ({ default: { template: '<div />' } }))
 vi.mock('@/pages/Marketplace.vue', () => ({ default: { template: '<div />' } }))
 vi.mock('@/pages/MemberProfile.vue', () => ({ default: { template: '<div />' } }))
@@ -40,6 +44,9 @@ describe('router', () => {
       ['/', 'dashboard'],
       ['/me', 'profile'],
       ['/events', 'events'],
+      ['/digests', 'digests'],
+      ['/knowledge-base', 'knowledgeBase'],
+      ['/knowledge-base/5', 'knowledgeBaseEntry'],
       ['/faq', 'faq'],
       ['/mentors', 'mentors'],
       ['/referals', 'referals'],
@@ -50,6 +57,7 @@ describe('router', () => {
       ['/tasks', 'taskExchange'],
       ['/auto-apply', 'autoApplyBot'],
       ['/raffles', 'raffles'],
+      ['/shop', 'shop'],
     ])('route %s has name %s', (path, name) => {
       const route = router.getRoutes().find((r: any) => r.name === name)
       expect(route).toBeDefined()
@@ -7,6 +7,7 @@ const { mockJson, mockApiClient } = vi.hoisted(() => {
     mockApiClient: {
       get: vi.fn(() => ({ json: mockJson })),
       post: vi.fn(() => ({ json: mockJson })),
+      put: vi.fn(() => ({ json: mockJson })),
     },
   }
 })
@@ -123,4 +124,132 @@ describe('casinoService', () => {
       expect(result).toEqual(stats)
     })
   })
+
+  describe('provably fair', () => {
+    it('should call GET minigames/seed', async () => {
+      const state = { active: { id: 1, serverSeedHash: 'abc', clientSeed: 'c', nonce: 3 }, previous: null }
+      mockJson.mockResolvedValue(state)
+
+      const result = await casinoService.getSeed()
+
+      expect(mockApiClient.get).toHaveBeenCalledWith('minigames/seed')
+      expect(result).toEqual(state)
+    })
+
+    it('should call POST minigames/seed/rotate with client seed', async () => {
+      mockJson.mockResolvedValue({})
+
+      await casinoService.rotateSeed('my-seed')
+
+      expect(mockApiClient.post).toHaveBeenCalledWith('minigames/seed/rotate', {
+        json: { clientSeed: 'my-seed' },
+      })
+    })
+
+    it('should call GET minigames/verify/:id', async () => {
+      mockJson.mockResolvedValue({ betId: 7, valid: true })
+
+      const result = await casinoService.verifyBet(7)
+
+      expect(mockApiClient.get).toHaveBeenCalledWith('minigames/verify/7')
+      expect(result).toEqual({ betId: 7, valid: true })
+    })
+  })
+
+  describe('limits', () => {
+    it('should call GET minigames/limits', async () => {
+      const state = { self: {}, effective: { dailyBetLimit: 300 }, pending: [] }
+      mockJson.mockResolvedValue(state)
+
+      const result = await casinoService.getLimits()
+
+      expect(mockApiClient.get).toHaveBeenCalledWith('minigames/limits')
+      expect(result).toEqual(state)
+    })
+
+    it('should call PUT minigames/limits with the full set', async () => {
+      mockJson.mockResolvedValue({})
+      const limits = { dailyLossLimit: 500, dailyBetLimit: null, cooldownAfterLosses: 5, cooldownMinutes: 30 }
+
+      await casinoService.updateLimits(limits)
+
+      expect(mockApiClient.put).toHaveBeenCalledWith('minigames/limits', { json: limits })
+    })
+
+    it('should call POST minigames/limits/exclude with days', async () => {
+      mockJson.mockResolvedValue({})
+
+      await casinoService.selfExclude(7)
+
+      expect(mockApiClient.post).toHaveBeenCalledWith('minigames/limits/exclude', { json: { days: 7 } })
+    })
+
+    it('should return limits history items', async () => {
+      mockJson.mockResolvedValue({ items: [{ id: 1, field: 'daily_bet_limit' }], total: 1 })
+
+      const result = await casinoService.getLimitsHistory()
+
+      expect(mockApiClient.get).toHaveBeenCalledWith('minigames/limits/history?limit=20')
+      expect(result).toEqual([{ id: 1, field: 'daily_bet_limit' }])
+    })
+  })
+
+  describe('crash', () => {
+    it('should call GET minigames/crash', async () => {
+      const state = { round: { id: 3, status: 'betting' }, bets: [], history: [1.5], jackpot: 500 }
+      mockJson.mockResolvedValue(state)
+
+      const result = await casinoService.getCrash()
+
+      expect(mockApiClient.get).toHaveBeenCalledWith('minigames/crash')
+      expect(result).toEqual(state)
+    })
+
+    it('should call POST minigames/crash/bet with amount and auto cash-out', async () => {
+      mockJson.mockResolvedValue({ betId: 1 })
+
+      await casinoService.crashBet(100, 2.5)
+
+      expect(mockApiClient.post).toHaveBeenCalledWith('minigames/crash/bet', {
+        json: { betAmount: 100, autoCashOut: 2.5 },
+      })
+    })
+
+    it('should call POST minigames/crash/cashout', async () => {
+      mockJson.mockResolvedValue({ betId: 1, multiplier: 1.8, payout: 180 })
+
+      const result = await casinoService.crashCashOut()
+
+      expect(mockApiClient.post).toHaveBeenCalledWith('minigames/crash/cashout')
+      expect(result.payout).toBe(180)
+    })
+
+    it('should return crash round history items', async () => {
+      mockJson.mockResolvedValue({ items: [{ id: 2, crashPoint: 1.3 }], total: 1 })
+
+      const result = await casinoService.getCrashRounds()
+
+      expect(mockApiClient.get).toHaveBeenCalledWith('minigames/crash/rounds?limit=20')
+      expect(result).toEqual([{ id: 2, crashPoint: 1.3 }])
+    })
+
+    it('should call GET minigames/crash/rounds/:id', async () => {
+      mockJson.mockResolvedValue({ round: { id: 2 }, bets: [] })
+
+      await casinoService.getCrashRound(2)
+
+      expect(mockApiClient.get).toHaveBeenCalledWith('minigames/crash/rounds/2')
+    })
+  })
+
+  describe('jackpot', () => {
+    it('should call GET minigames/jackpot', async () => {
+      mockJson.mockResolvedValue({ amount: 1200, recentWins: [] })
+
+      const result = await casinoService.getJackpot()
+
+      expect(mockApiClient.get).toHaveBeenCalledWith('minigames/jackpot')
+      expect(result.amount).toBe(1200)
+    })
+  })
 })
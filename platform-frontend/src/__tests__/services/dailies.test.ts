@@ -0,0 +1,56 @@
+import { describe, expect, it, vi } from 'vitest'
+
+const { mockJson, mockApiClient } = vi.hoisted(() => {
+  const mockJson = vi.fn()
+  return {
+    mockJson,
+    mockApiClient: {
+      get: vi.fn(() => ({ json: mockJson })),
+      post: vi.fn(() => ({ json: mockJson })),
+    },
+  }
+})
+
+vi.mock('@/services/api', () => ({
+  apiClient: mockApiClient,
+}))
+
+import { dailiesService } from '@/services/dailies'
+
+describe('dailiesService', () => {
+  beforeEach(() => {
+    vi.clearAllMocks()
+  })
+
+  describe('buyFreezes', () => {
+    it('should buy one freeze by default', async () => {
+      const streak = { current: 4, purchasedFreezes: 1, repair: null }
+      mockJson.mockResolvedValue(streak)
+
+      const result = await dailiesService.buyFreezes()
+
+      expect(mockApiClient.post).toHaveBeenCalledWith('streak/freezes', { json: { quantity: 1 } })
+      expect(result).toEqual(streak)
+    })
+
+    it('should pass quantity', async () => {
+      mockJson.mockResolvedValue({})
+
+      await dailiesService.buyFreezes(3)
+
+      expect(mockApiClient.post).toHaveBeenCalledWith('streak/freezes', { json: { quantity: 3 } })
+    })
+  })
+
+  describe('repairStreak', () => {
+    it('should call POST streak/repair', async () => {
+      const streak = { current: 9, repair: null }
+      mockJson.mockResolvedValue(streak)
+
+      const result = await dailiesService.repairStreak()
+
+      expect(mockApiClient.post).toHaveBeenCalledWith('streak/repair')
+      expect(result).toEqual(streak)
+    })
+  })
+})
@@ -0,0 +1,64 @@
+import { describe, expect, it, vi } from 'vitest'
+
+const { mockJson, mockApiClient } = vi.hoisted(() => {
+  const mockJson = vi.fn()
+  return {
+    mockJson,
+    mockApiClient: {
+      get: vi.fn(() => ({ json: mockJson })),
+    },
+  }
+})
+
+vi.mock('@/services/api', () => ({
+  apiClient: mockApiClient,
+}))
+
+import { digestHighlights, digestMembers, digestsService } from '@/services/digests'
+
+describe('digestsService', () => {
+  beforeEach(() => {
+    vi.clearAllMocks()
+  })
+
+  describe('list', () => {
+    it('should call GET digests with paging', async () => {
+      const page = { items: [{ id: 1 }], total: 1 }
+      mockJson.mockResolvedValue(page)
+
+      const result = await digestsService.list(10, 20)
+
+      expect(mockApiClient.get).toHaveBeenCalledWith('digests', { searchParams: { limit: 10, offset: 20 } })
+      expect(result).toEqual(page)
+    })
+  })
+
+  describe('getById', () => {
+    it('should call GET digests/:id', async () => {
+      mockJson.mockResolvedValue({ id: 5 })
+
+      await digestsService.getById(5)
+
+      expect(mockApiClient.get).toHaveBeenCalledWith('digests/5')
+    })
+  })
+})
+
+describe('digest JSON fields', () => {
+  it('parses top members and highlights', () => {
+    const digest = {
+      topMembers: '[{"telegramUserId":1,"telegramUsername":"ann","telegramFirstName":"Ann","count":12}]',
+      highlights: '[{"id":3,"messageId":7,"authorUsername":"","authorFirstName":"Bob","messageText":"hi"}]',
+    } as any
+
+    expect(digestMembers(digest)).toHaveLength(1)
+    expect(digestHighlights(digest)[0].authorFirstName).toBe('Bob')
+  })
+
+  it('returns empty lists for broken JSON', () => {
+    const digest = { topMembers: 'oops', highlights: '{}' } as any
+
+    expect(digestMembers(digest)).toEqual([])
+    expect(digestHighlights(digest)).toEqual([])
+  })
+})
@@ -0,0 +1,54 @@
+import { describe, expect, it, vi } from 'vitest'
+
+const { mockJson, mockApiClient } = vi.hoisted(() => {
+  const mockJson = vi.fn()
+  return {
+    mockJson,
+    mockApiClient: {
+      get: vi.fn(() => ({ json: mockJson })),
+    },
+  }
+})
+
+vi.mock('@/services/api', () => ({
+  apiClient: mockApiClient,
+}))
+
+import { knowledgeBaseService } from '@/services/knowledgeBase'
+
+describe('knowledgeBaseService', () => {
+  beforeEach(() => {
+    vi.clearAllMocks()
+  })
+
+  describe('search', () => {
+    it('should call GET knowledge-base with query and paging', async () => {
+      const page = { items: [{ id: 1 }], total: 1 }
+      mockJson.mockResolvedValue(page)
+
+      const result = await knowledgeBaseService.search('docker', 20, 40)
+
+      expect(mockApiClient.get).toHaveBeenCalledWith('knowledge-base', { searchParams: { q: 'docker', limit: 20, offset: 40 } })
+      expect(result).toEqual(page)
+    })
+
+    it('should default to the first page without a query', async () => {
+      mockJson.mockResolvedValue({ items: [], total: 0 })
+
+      await knowledgeBaseService.search()
+
+      expect(mockApiClient.get).toHaveBeenCalledWith('knowledge-base', { searchParams: { q: '', limit: 20, offset: 0 } })
+    })
+  })
+
+  describe('getById', () => {
+    it('should call GET knowledge-base/:id', async () => {
+      mockJson.mockResolvedValue({ id: 5 })
+
+      const result = await knowledgeBaseService.getById(5)
+
+      expect(mockApiClient.get).toHaveBeenCalledWith('knowledge-base/5')
+      expect(result).toEqual({ id: 5 })
+    })
+  })
+})
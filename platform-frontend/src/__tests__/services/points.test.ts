@@ -1,11 +1,13 @@
 import { describe, expect, it, vi } from 'vitest'
 
-const { mockJson, mockApiClient } = vi.hoisted(() => {
+const { mockJson, mockBlob, mockApiClient } = vi.hoisted(() => {
   const mockJson = vi.fn()
+  const mockBlob = vi.fn()
   return {
     mockJson,
+    mockBlob,
     mockApiClient: {
-      get: vi.fn(() => ({ json: mockJson })),
+      get: vi.fn(() => ({ json: mockJson, blob: mockBlob })),
     },
   }
 })
@@ -54,4 +56,43 @@ describe('pointsService', () => {
       expect(result).toEqual(leaderboard)
     })
   })
+
+  describe('getStatement', () => {
+    it('should call GET points/me/statement with month', async () => {
+      const statement = { month: '2026-09', earned: 120, spent: 30, byReason: [] }
+      mockJson.mockResolvedValue(statement)
+
+      const result = await pointsService.getStatement('2026-09')
+
+      expect(mockApiClient.get).toHaveBeenCalledWith('points/me/statement', { searchParams: { month: '2026-09' } })
+      expect(result).toEqual(statement)
+    })
+
+    it('should omit month for the current month', async () => {
+      mockJson.mockResolvedValue({})
+
+      await pointsService.getStatement()
+
+      expect(mockApiClient.get).toHaveBeenCalledWith('points/me/statement', { searchParams: {} })
+    })
+  })
+
+  describe('exportMine', () => {
+    it('should download filtered export as a file', async () => {
+      mockBlob.mockResolvedValue(new Blob(['id,amount']))
+      global.URL.createObjectURL = vi.fn(() => 'blob:points')
+      global.URL.revokeObjectURL = vi.fn()
+      const link = { href: '', download: '', click: vi.fn() } as any
+      vi.spyOn(document, 'createElement').mockReturnValueOnce(link)
+
+      await pointsService.exportMine({ format: 'csv', from: '2026-09-01', to: '2026-09-30', reasons: ['casino_bet', 'casino_win'] })
+
+      expect(mockApiClient.get).toHaveBeenCalledWith('points/me/export', {
+        searchParams: { format: 'csv', from: '2026-09-01', to: '2026-09-30', reason: 'casino_bet,casino_win' },
+      })
+      expect(link.download).toBe('points.csv')
+      expect(link.click).toHaveBeenCalled()
+      expect(global.URL.revokeObjectURL).toHaveBeenCalledWith('blob:points')
+    })
+  })
 })
@@ -51,4 +51,16 @@ describe('raffleService', () => {
       expect(mockApiClient.post).toHaveBeenCalledWith('raffles/10/buy', { json: { count: 5 } })
     })
   })
+
+  describe('getDrawProof', () => {
+    it('should call GET raffles/:id/draw', async () => {
+      const proof = { raffleId: 4, valid: true, computed: [7, 3] }
+      mockJson.mockResolvedValue(proof)
+
+      const result = await raffleService.getDrawProof(4)
+
+      expect(mockApiClient.get).toHaveBeenCalledWith('raffles/4/draw')
+      expect(result).toEqual(proof)
+    })
+  })
 })
@@ -0,0 +1,75 @@
+import { describe, expect, it, vi } from 'vitest'
+
+const { mockJson, mockApiClient } = vi.hoisted(() => {
+  const mockJson = vi.fn()
+  return {
+    mockJson,
+    mockApiClient: {
+      get: vi.fn(() => ({ json: mockJson })),
+      post: vi.fn(() => ({ json: mockJson })),
+    },
+  }
+})
+
+vi.mock('@/services/api', () => ({
+  apiClient: mockApiClient,
+}))
+
+import { shopService } from '@/services/shop'
+
+describe('shopService', () => {
+  beforeEach(() => {
+    vi.clearAllMocks()
+  })
+
+  describe('getItems', () => {
+    it('should call GET shop/items', async () => {
+      const data = { items: [{ id: 1 }] }
+      mockJson.mockResolvedValue(data)
+
+      const result = await shopService.getItems()
+
+      expect(mockApiClient.get).toHaveBeenCalledWith('shop/items')
+      expect(result).toEqual(data)
+    })
+  })
+
+  describe('buy', () => {
+    it('should call POST shop/items/:id/buy with the comment', async () => {
+      mockJson.mockResolvedValue({ id: 7 })
+
+      await shopService.buy(3, 'размер M')
+
+      expect(mockApiClient.post).toHaveBeenCalledWith('shop/items/3/buy', { json: { comment: 'размер M' } })
+    })
+
+    it('should send an empty comment by default', async () => {
+      mockJson.mockResolvedValue({ id: 7 })
+
+      await shopService.buy(3)
+
+      expect(mockApiClient.post).toHaveBeenCalledWith('shop/items/3/buy', { json: { comment: '' } })
+    })
+  })
+
+  describe('getMyOrders', () => {
+    it('should call GET shop/orders', async () => {
+      mockJson.mockResolvedValue({ items: [] })
+
+      await shopService.getMyOrders()
+
+      expect(mockApiClient.get).toHaveBeenCalledWith('shop/orders')
+    })
+  })
+
+  describe('cancelOrder', () => {
+    it('should call POST shop/orders/:id/cancel', async () => {
+      mockJson.mockResolvedValue({ id: 7, status: 'cancelled' })
+
+      const result = await shopService.cancelOrder(7)
+
+      expect(mockApiClient.post).toHaveBeenCalledWith('shop/orders/7/cancel')
+      expect(result).toEqual({ id: 7, status: 'cancelled' })
+    })
+  })
+})
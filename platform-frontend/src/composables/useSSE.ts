@@ -1,13 +1,15 @@
 import { onUnmounted, ref } from 'vue'
 
-export type SSEEventType = 'notifications' | 'tasks' | 'raffles' | 'kudos' | 'points' | 'quests' | 'dailies' | 'streak' | 'challenges' | 'connected'
+export type SSEEventType = 'notifications' | 'tasks' | 'raffles' | 'kudos' | 'points' | 'quests' | 'dailies' | 'streak' | 'challenges' | 'crash' | 'jackpot' | 'connected'
 
 interface SSEMessage {
   type: SSEEventType
   data?: unknown
 }
 
-const listeners = new Map<SSEEventType, Set<() => void>>()
+type SSECallback = (data?: unknown) => void
+
+const listeners = new Map<SSEEventType, Set<SSECallback>>()
 let eventSource: EventSource | null = null
 let reconnectTimer: ReturnType<typeof setTimeout> | null = null
 let consecutiveErrors = 0
@@ -33,7 +35,7 @@ function connect() {
       }
       const callbacks = listeners.get(msg.type)
       if (callbacks) {
-        callbacks.forEach(cb => cb())
+        callbacks.forEach(cb => cb(msg.data))
       }
     }
     catch {
@@ -76,7 +78,9 @@ export function stopSSE() {
   listeners.clear()
 }
 
-export function useSSE(eventType: SSEEventType, callback: () => void) {
+// callback получает data события — большинству подписчиков достаточно
+// самого факта события, crash и jackpot несут в нём состояние.
+export function useSSE(eventType: SSEEventType, callback: SSECallback) {
   if (!listeners.has(eventType))
     listeners.set(eventType, new Set())
 
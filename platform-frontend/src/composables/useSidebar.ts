@@ -1,6 +1,6 @@
 import type { Component } from 'vue'
 import type { SubscriptionTierSlug } from '@/models/profile'
-import { Calendar, ClipboardList, Crown, Dices, Gift, HelpCircle, Home, Share2, Sparkles, Sprout, User, Users } from 'lucide-vue-next'
+import { BookOpen, Calendar, ClipboardList, Crown, Dices, Gift, HelpCircle, Home, Newspaper, Share2, ShoppingBag, Sparkles, Sprout, User, Users } from 'lucide-vue-next'
 import { ref } from 'vue'
 
 export interface SidebarItem {
@@ -43,12 +43,14 @@ const state = {
       items: [
         { title: 'События', path: '/events', icon: Calendar, indicator: true, dataOnboarding: 'events', requiresSubscription: true },
         { title: 'Менторы', path: '/mentors', icon: Users },
+        { title: 'Дайджесты', path: '/digests', icon: Newspaper, requiresSubscription: true },
       ],
     },
     {
       label: 'Знания',
       items: [
         { title: 'AI-материалы', path: '/ai-materials', icon: Sparkles, requiresSubscription: true },
+        { title: 'База знаний', path: '/knowledge-base', icon: BookOpen, requiresSubscription: true },
       ],
     },
     {
@@ -66,6 +68,7 @@ const state = {
         // включая UNSUBSCRIBER'ам — приглашать может каждый.
         { title: 'Пригласить в IT-X', path: '/referral', icon: Share2 },
         { title: 'Розыгрыши', path: '/raffles', icon: Gift, requiresSubscription: true },
+        { title: 'Магазин наград', path: '/shop', icon: ShoppingBag, requiresSubscription: true },
         { title: 'Мини-игры', path: '/minigames', icon: Dices, requiresSubscription: true },
       ],
     },
@@ -8,6 +8,9 @@ import { onUnmounted, watch } from 'vue'
 
 interface TelegramWebApp {
   initData: string
+  // Разобранный initData без проверки подписи — только для UI. start_param
+  // — параметр startapp из ссылки t.me/<bot>?startapp=…
+  initDataUnsafe?: { start_param?: string }
   ready: () => void
   expand: () => void
   close: () => void
@@ -58,6 +61,38 @@ export function isMiniApp(): boolean {
   return !!tg && typeof tg.initData === 'string' && tg.initData.length > 0
 }
 
+// getStartParam — параметр startapp, с которым открыли Mini App. Старые
+// клиенты не кладут его в initDataUnsafe, но передают в URL как
+// tgWebAppStartParam.
+export function getStartParam(): string | null {
+  const fromInitData = getTelegramWebApp()?.initDataUnsafe?.start_param
+  if (fromInitData)
+    return fromInitData
+  return new URLSearchParams(window.location.search).get('tgWebAppStartParam')
+}
+
+// Deep link'и бота (inline-поиск, ссылки в сообщениях): «<kind>-<id>», см.
+// service.PlatformStartParam на бэке. Отдельной страницы события нет —
+// ведём в список событий.
+const START_PARAM_ROUTES: Record<string, (id: string) => string> = {
+  event: () => '/events',
+  mentor: id => `/mentors/${id}`,
+  member: id => `/members/${id}`,
+  ai_material: id => `/ai-materials/${id}`,
+}
+
+const START_PARAM_RE = /^([a-z_]+)-(\d+)$/
+
+// startParamRoute — маршрут платформы для start_param; null — параметр
+// пустой или не наш.
+export function startParamRoute(param: string | null): string | null {
+  const m = param ? START_PARAM_RE.exec(param) : null
+  if (!m)
+    return null
+  const route = START_PARAM_ROUTES[m[1]]
+  return route ? route(m[2]) : null
+}
+
 const RGB_RE = /^rgba?\((\d+),\s*(\d+),\s*(\d+)(?:,\s*([\d.]+))?\s*\)/i
 
 // rgbToHex — Telegram.setHeaderColor принимает только hex (#rrggbb), а
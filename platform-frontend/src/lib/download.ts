@@ -0,0 +1,23 @@
+import type { LedgerExportParams } from '@/models/points'
+
+// Параметры выгрузки журнала в query-строку бэкенда.
+export function ledgerSearchParams(params: LedgerExportParams): Record<string, string> {
+  const searchParams: Record<string, string> = { format: params.format }
+  if (params.from)
+    searchParams.from = params.from
+  if (params.to)
+    searchParams.to = params.to
+  if (params.reasons?.length)
+    searchParams.reason = params.reasons.join(',')
+  return searchParams
+}
+
+// Сохраняет полученный файл через временную ссылку.
+export function saveBlob(blob: Blob, filename: string) {
+  const url = URL.createObjectURL(blob)
+  const a = document.createElement('a')
+  a.href = url
+  a.download = filename
+  a.click()
+  URL.revokeObjectURL(url)
+}
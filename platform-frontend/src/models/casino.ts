@@ -8,6 +8,10 @@ export interface CasinoBetResult {
   payout: number
   profit: number
   balance: number
+  serverSeedHash: string
+  clientSeed: string
+  nonce: number
+  jackpotWin: number
   createdAt: string
 }
 
@@ -32,3 +36,175 @@ export interface CasinoStats {
   totalWon: number
   totalProfit: number
 }
+
+export interface CasinoSeed {
+  id: number
+  serverSeedHash: string
+  clientSeed: string
+  nonce: number
+  active: boolean
+  createdAt: string
+  revealedAt: string | null
+}
+
+export interface CasinoRevealedSeed extends CasinoSeed {
+  serverSeed: string
+}
+
+export interface CasinoSeedState {
+  active: CasinoSeed
+  previous: CasinoRevealedSeed | null
+}
+
+export interface CasinoBetVerification {
+  betId: number
+  game: string
+  betAmount: number
+  betChoice: string
+  serverSeed: string
+  serverSeedHash: string
+  clientSeed: string
+  nonce: number
+  hashMatches: boolean
+  recordedResult: string
+  recordedMultiplier: number
+  recordedPayout: number
+  computedResult: string
+  computedMultiplier: number
+  computedPayout: number
+  valid: boolean
+}
+
+export interface CasinoLimits {
+  dailyLossLimit: number | null
+  dailyBetLimit: number | null
+  cooldownAfterLosses: number | null
+  cooldownMinutes: number | null
+  excludedUntil: string | null
+  updatedAt: string
+}
+
+export interface CasinoEffectiveLimits {
+  dailyLossLimit: number
+  dailyBetLimit: number
+  cooldownAfterLosses: number
+  cooldownMinutes: number
+}
+
+export interface CasinoGlobalLimits extends CasinoEffectiveLimits {
+  increaseDelayHours: number
+}
+
+export interface CasinoLimitChange {
+  id: number
+  memberId: number
+  field: string
+  oldValue: number | null
+  newValue: number | null
+  status: 'applied' | 'pending' | 'cancelled'
+  effectiveAt: string
+  createdAt: string
+}
+
+export interface CasinoLimitsState {
+  self: CasinoLimits
+  global: CasinoGlobalLimits
+  effective: CasinoEffectiveLimits
+  pending: CasinoLimitChange[]
+  today: { bets: number, loss: number }
+  cooldownUntil: string | null
+}
+
+export type CasinoLimitsRequest = Pick<CasinoLimits, 'dailyLossLimit' | 'dailyBetLimit' | 'cooldownAfterLosses' | 'cooldownMinutes'>
+
+export type CrashRoundStatus = 'betting' | 'running' | 'crashed'
+
+export interface CrashRound {
+  id: number
+  status: CrashRoundStatus
+  serverSeedHash: string
+  serverSeed?: string
+  crashPoint?: number
+  bettingEndsAt: string
+  startedAt: string | null
+  crashedAt: string | null
+}
+
+export interface CrashRoundBet {
+  betId: number
+  memberId: number
+  memberFirstName: string
+  memberUsername: string
+  betAmount: number
+  autoCashOut: number | null
+  cashedOutAt: number | null
+  payout: number
+}
+
+export interface CrashState {
+  round: CrashRound | null
+  bets: CrashRoundBet[]
+  history: number[]
+  serverTime: string
+  jackpot: number
+}
+
+export interface CrashRoundSummary {
+  id: number
+  crashPoint: number
+  serverSeed: string
+  serverSeedHash: string
+  players: number
+  totalWagered: number
+  totalPayout: number
+  crashedAt: string
+}
+
+export interface CrashRoundDetail {
+  round: CrashRound
+  bets: CrashRoundBet[]
+}
+
+// SSE-событие "crash": смена фазы раунда несёт round, ставка и вывод — bet.
+export interface CrashEvent {
+  event: 'betting' | 'running' | 'crashed' | 'bet' | 'cashout'
+  round?: CrashRound
+  bet?: CrashRoundBet
+}
+
+export interface CrashBetResult {
+  betId: number
+  roundId: number
+  betAmount: number
+  autoCashOut: number | null
+  balance: number
+  jackpotWin: number
+}
+
+export interface CrashCashOutResult {
+  betId: number
+  multiplier: number
+  payout: number
+  balance: number
+}
+
+export interface CasinoJackpotWin {
+  id: number
+  memberId: number
+  betId: number | null
+  game: string
+  amount: number
+  createdAt: string
+  memberFirstName: string
+  memberUsername: string
+}
+
+export interface CasinoJackpotState {
+  amount: number
+  recentWins: CasinoJackpotWin[]
+  settings: {
+    contributionPercent: number
+    turnoverPerWin: number
+    seedAmount: number
+  }
+}
@@ -4,10 +4,21 @@ export interface StreakMilestone {
   reached: boolean
 }
 
+export interface StreakRepairOffer {
+  lostStreak: number
+  price: number
+  deadline: string
+}
+
 export interface StreakResponse {
   current: number
   longest: number
+  // Недельная и купленные заморозки за вычетом ушедших на пропуски.
   freezesAvailable: number
+  purchasedFreezes: number
+  freezePrice: number
+  freezeMax: number
+  repair: StreakRepairOffer | null
   lastCheckIn: string | null
   nextThreshold: number | null
   daysToNext: number | null
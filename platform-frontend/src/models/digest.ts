@@ -0,0 +1,36 @@
+export interface ChatDigest {
+  id: number
+  chatId: number
+  chatTitle: string
+  periodStart: string
+  periodEnd: string
+  messageCount: number
+  activeMembers: number
+  // summary — HTML от модели (теги Telegram: b, i, a…).
+  summary: string
+  // topMembers / highlights — JSON-строки с DigestMember[] / DigestHighlight[].
+  topMembers: string
+  highlights: string
+  postedAt: string | null
+  createdAt: string
+}
+
+export interface DigestMember {
+  telegramUserId: number
+  telegramUsername: string
+  telegramFirstName: string
+  count: number
+}
+
+export interface DigestHighlight {
+  id: number
+  messageId: number
+  authorUsername: string
+  authorFirstName: string
+  messageText: string
+}
+
+export interface DigestList {
+  items: ChatDigest[]
+  total: number
+}
@@ -0,0 +1,19 @@
+import type { ChatHighlight } from './highlight'
+import type { ProfTag } from './profile'
+
+export interface KBEntry {
+  id: number
+  title: string
+  question: string
+  answer: string
+  suggestedCount: number
+  createdAt: string
+  updatedAt: string
+  highlights: ChatHighlight[]
+  profTags: ProfTag[]
+}
+
+export interface KBSearchResult {
+  items: KBEntry[]
+  total: number
+}
@@ -22,3 +22,33 @@ export interface LeaderboardEntry {
   avatarUrl: string
   total: number
 }
+
+export type LedgerExportFormat = 'csv' | 'json'
+
+// Фильтр выгрузки журнала: даты — YYYY-MM-DD по МСК включительно.
+export interface LedgerExportParams {
+  format: LedgerExportFormat
+  from?: string
+  to?: string
+  reasons?: string[]
+}
+
+export interface PointsReasonTotal {
+  reason: string
+  amount: number
+  count: number
+}
+
+export interface PointsStatement {
+  memberId: number
+  month: string
+  from: string
+  to: string
+  earned: number
+  spent: number
+  net: number
+  balance: number
+  byReason: PointsReasonTotal[]
+  creditsEarned: number
+  creditsSpent: number
+}
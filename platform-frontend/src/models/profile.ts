@@ -73,6 +73,7 @@ export interface NotificationSettings {
   dailyEvening: boolean
   dailyStreak: boolean
   dailyRaffle: boolean
+  monthlyStatement: boolean
 }
 
 export const SUBSCRIPTION_LEVELS = [
@@ -13,6 +13,18 @@ export interface ActivityDay {
   count: number
 }
 
+export type LeaderboardPeriod = 'weekly' | 'monthly' | 'quarterly'
+
+// Призовое место или подиум в рейтинге завершённого периода.
+export interface LeaderboardWin {
+  period: LeaderboardPeriod
+  periodKey: string
+  endsAt: string
+  rank: number
+  total: number
+  prize: number
+}
+
 export interface ProfileStats {
   eventsAttended: number
   eventsHosted: number
@@ -29,4 +41,5 @@ export interface ProfileStats {
   achievementsEarned: number
   achievementsTotal: number
   activityHistory: ActivityDay[]
+  leaderboardWins?: LeaderboardWin[]
 }
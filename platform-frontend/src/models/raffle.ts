@@ -29,5 +29,46 @@ export interface RaffleItem {
   winnerLastName: string
   winnerUsername: string
   winnerAvatarUrl: string
+  // sha256 от seed'а жеребьёвки; сам seed раскрывается после розыгрыша.
+  seedHash: string
+  serverSeed?: string
+  prizes?: RafflePrize[]
+  winners?: RaffleWinner[]
   mySources?: RaffleTicketSource[]
 }
+
+export interface RafflePrize {
+  id: number
+  raffleId: number
+  position: number
+  title: string
+  winnersCount: number
+}
+
+export interface RaffleWinner {
+  id: number
+  raffleId: number
+  prizeId: number | null
+  prizeTitle: string
+  place: number
+  memberId: number
+  tickets: number
+  status: 'pending' | 'fulfilled'
+  fulfilledAt: string | null
+  memberFirstName: string
+  memberLastName: string
+  memberUsername: string
+}
+
+export interface RaffleDrawProof {
+  raffleId: number
+  serverSeed: string
+  seedHash: string
+  clientSeed: string
+  hashMatches: boolean
+  entrants: { memberId: number, tickets: number }[]
+  prizes: RafflePrize[]
+  winners: RaffleWinner[]
+  computed: number[]
+  valid: boolean
+}
@@ -0,0 +1,38 @@
+export type ShopItemKind = 'merch' | 'mentor_session' | 'subscription_days' | 'streak_freeze' | 'cosmetic'
+export type ShopOrderStatus = 'pending' | 'shipped' | 'delivered' | 'cancelled'
+
+// stock null — без ограничения, perMemberLimit 0 — сколько угодно в одни руки.
+export interface ShopItem {
+  id: number
+  kind: ShopItemKind
+  title: string
+  description: string
+  imageUrl: string
+  price: number
+  quantity: number
+  stock: number | null
+  perMemberLimit: number
+  isActive: boolean
+  sortOrder: number
+  // Покупки текущего участника без отменённых.
+  myOrders: number
+  createdAt: string
+  updatedAt: string
+}
+
+// itemTitle и price — на момент покупки.
+export interface ShopOrder {
+  id: number
+  itemId: number
+  memberId: number
+  itemTitle: string
+  price: number
+  status: ShopOrderStatus
+  comment: string
+  adminNote: string
+  createdAt: string
+  updatedAt: string
+  shippedAt: string | null
+  deliveredAt: string | null
+  cancelledAt: string | null
+}
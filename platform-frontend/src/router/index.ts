@@ -35,6 +35,7 @@ const routes: RouteRecordRaw[] = [
   { path: '/events', component: () => import('@/pages/Events.vue'), name: 'events', meta: { breadcrumb: [{ label: 'События' }], requiresSubscription: true } },
   { path: '/content', redirect: '/events?tab=content' },
   { path: '/members/:id', component: () => import('@/pages/MemberProfile.vue'), name: 'memberProfile', meta: { breadcrumb: [{ label: 'Рейтинг', to: '/progress?tab=leaderboard' }, { label: 'Профиль участника' }] } },
+  { path: '/digests', component: () => import('@/pages/Digests.vue'), name: 'digests', meta: { breadcrumb: [{ label: 'Дайджесты' }], requiresSubscription: true } },
   { path: '/mentors', component: () => import('@/pages/Mentors.vue'), name: 'mentors', meta: { breadcrumb: [{ label: 'Менторы' }] } },
   { path: '/mentors/:id', component: () => import('@/pages/MentorProfile.vue'), name: 'mentorProfile', meta: { breadcrumb: [{ label: 'Менторы', to: '/mentors' }, { label: 'Профиль ментора' }] } },
   { path: '/referals', component: () => import('@/pages/ReferalLinks.vue'), name: 'referals', meta: { breadcrumb: [{ label: 'Рефералы' }], requiresSubscription: true } },
@@ -52,10 +53,13 @@ const routes: RouteRecordRaw[] = [
   { path: '/marketplace', component: () => import('@/pages/Marketplace.vue'), name: 'marketplace', meta: { breadcrumb: [{ label: 'Барахолка' }], requiresSubscription: true } },
   { path: '/ai-materials', component: () => import('@/pages/AIMaterials.vue'), name: 'aiMaterials', meta: { breadcrumb: [{ label: 'AI-материалы' }], requiresSubscription: true } },
   { path: '/ai-materials/:id', component: () => import('@/pages/AIMaterialDetail.vue'), name: 'aiMaterialDetail', meta: { breadcrumb: [{ label: 'AI-материалы', to: '/ai-materials' }, { label: 'Материал' }], requiresSubscription: true } },
+  { path: '/knowledge-base', component: () => import('@/pages/KnowledgeBase.vue'), name: 'knowledgeBase', meta: { breadcrumb: [{ label: 'База знаний' }], requiresSubscription: true } },
+  { path: '/knowledge-base/:id', component: () => import('@/pages/KnowledgeBaseEntry.vue'), name: 'knowledgeBaseEntry', meta: { breadcrumb: [{ label: 'База знаний', to: '/knowledge-base' }, { label: 'Статья' }], requiresSubscription: true } },
   { path: '/tasks', component: () => import('@/pages/TaskExchange.vue'), name: 'taskExchange', meta: { breadcrumb: [{ label: 'Биржа заданий' }], requiresSubscription: true } },
   { path: '/auto-apply', component: () => import('@/pages/AutoApplyBot.vue'), name: 'autoApplyBot', meta: { breadcrumb: [{ label: 'Автоотклики' }], requiresSubscription: true } },
   { path: '/kudos', redirect: '/progress?tab=kudos' },
   { path: '/raffles', component: () => import('@/pages/Raffles.vue'), name: 'raffles', meta: { breadcrumb: [{ label: 'Розыгрыши' }], requiresSubscription: true } },
+  { path: '/shop', component: () => import('@/pages/Shop.vue'), name: 'shop', meta: { breadcrumb: [{ label: 'Магазин наград' }], requiresSubscription: true } },
   { path: '/minigames', component: () => import('@/pages/Casino.vue'), name: 'minigames', meta: { breadcrumb: [{ label: 'Мини-игры' }], requiresSubscription: true } },
   { path: '/my-stats', redirect: '/progress?tab=stats' },
   { path: '/notifications', redirect: '/me' },
@@ -1,4 +1,4 @@
-import type { CasinoBetResult, CasinoFeedItem, CasinoStats } from '@/models/casino'
+import type { CasinoBetResult, CasinoBetVerification, CasinoFeedItem, CasinoJackpotState, CasinoLimitChange, CasinoLimitsRequest, CasinoLimitsState, CasinoSeedState, CasinoStats, CrashBetResult, CrashCashOutResult, CrashRoundDetail, CrashRoundSummary, CrashState } from '@/models/casino'
 import { apiClient } from './api'
 
 export const casinoService = {
@@ -27,4 +27,58 @@ export const casinoService = {
   async getStats() {
     return apiClient.get('minigames/stats').json<CasinoStats>()
   },
+
+  async getSeed() {
+    return apiClient.get('minigames/seed').json<CasinoSeedState>()
+  },
+
+  async rotateSeed(clientSeed = '') {
+    return apiClient.post('minigames/seed/rotate', { json: { clientSeed } }).json<CasinoSeedState>()
+  },
+
+  async verifyBet(betId: number) {
+    return apiClient.get(`minigames/verify/${betId}`).json<CasinoBetVerification>()
+  },
+
+  async getLimits() {
+    return apiClient.get('minigames/limits').json<CasinoLimitsState>()
+  },
+
+  async updateLimits(limits: CasinoLimitsRequest) {
+    return apiClient.put('minigames/limits', { json: limits }).json<CasinoLimitsState>()
+  },
+
+  async selfExclude(days: number) {
+    return apiClient.post('minigames/limits/exclude', { json: { days } }).json<CasinoLimitsState>()
+  },
+
+  async getLimitsHistory(limit = 20) {
+    const res = await apiClient.get(`minigames/limits/history?limit=${limit}`).json<{ items: CasinoLimitChange[], total: number }>()
+    return res.items ?? []
+  },
+
+  async getCrash() {
+    return apiClient.get('minigames/crash').json<CrashState>()
+  },
+
+  async crashBet(betAmount: number, autoCashOut: number | null) {
+    return apiClient.post('minigames/crash/bet', { json: { betAmount, autoCashOut } }).json<CrashBetResult>()
+  },
+
+  async crashCashOut() {
+    return apiClient.post('minigames/crash/cashout').json<CrashCashOutResult>()
+  },
+
+  async getCrashRounds(limit = 20) {
+    const res = await apiClient.get(`minigames/crash/rounds?limit=${limit}`).json<{ items: CrashRoundSummary[], total: number }>()
+    return res.items ?? []
+  },
+
+  async getCrashRound(id: number) {
+    return apiClient.get(`minigames/crash/rounds/${id}`).json<CrashRoundDetail>()
+  },
+
+  async getJackpot() {
+    return apiClient.get('minigames/jackpot').json<CasinoJackpotState>()
+  },
 }
@@ -1,3 +1,5 @@
+import type { LedgerExportParams } from '@/models/points'
+import { ledgerSearchParams, saveBlob } from '@/lib/download'
 import { apiClient } from './api'
 
 export interface CreditTransaction {
@@ -31,6 +33,11 @@ export const creditsService = {
     return apiClient.get('credits/me').json<CreditsSummary>()
   },
 
+  async exportMine(params: LedgerExportParams) {
+    const blob = await apiClient.get('credits/me/export', { searchParams: ledgerSearchParams(params) }).blob()
+    saveBlob(blob, `credits.${params.format}`)
+  },
+
   async purchaseTier(slug: string) {
     return apiClient.post('subscriptions/purchase', {
       json: { tier_slug: slug },
@@ -15,6 +15,14 @@ export const dailiesService = {
     return apiClient.get('streak/me').json<StreakResponse>()
   },
 
+  async buyFreezes(quantity = 1) {
+    return apiClient.post('streak/freezes', { json: { quantity } }).json<StreakResponse>()
+  },
+
+  async repairStreak() {
+    return apiClient.post('streak/repair').json<StreakResponse>()
+  },
+
   async getDailyRaffle() {
     return apiClient.get('raffles/daily/today').json<RaffleItem | { raffle: null }>()
   },
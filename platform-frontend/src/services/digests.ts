@@ -0,0 +1,30 @@
+import type { ChatDigest, DigestHighlight, DigestList, DigestMember } from '@/models/digest'
+import { apiClient } from './api'
+
+function parseList<T>(raw: string): T[] {
+  try {
+    const parsed = JSON.parse(raw)
+    return Array.isArray(parsed) ? parsed : []
+  }
+  catch {
+    return []
+  }
+}
+
+export function digestMembers(digest: ChatDigest): DigestMember[] {
+  return parseList<DigestMember>(digest.topMembers)
+}
+
+export function digestHighlights(digest: ChatDigest): DigestHighlight[] {
+  return parseList<DigestHighlight>(digest.highlights)
+}
+
+export const digestsService = {
+  async list(limit = 10, offset = 0) {
+    return apiClient.get('digests', { searchParams: { limit, offset } }).json<DigestList>()
+  },
+
+  async getById(id: number) {
+    return apiClient.get(`digests/${id}`).json<ChatDigest>()
+  },
+}
@@ -0,0 +1,12 @@
+import type { KBEntry, KBSearchResult } from '@/models/knowledgeBase'
+import { apiClient } from './api'
+
+export const knowledgeBaseService = {
+  async search(q = '', limit = 20, offset = 0) {
+    return apiClient.get('knowledge-base', { searchParams: { q, limit, offset } }).json<KBSearchResult>()
+  },
+
+  async getById(id: number) {
+    return apiClient.get(`knowledge-base/${id}`).json<KBEntry>()
+  },
+}
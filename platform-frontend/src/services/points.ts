@@ -1,4 +1,5 @@
-import type { LeaderboardEntry, PointsSummary } from '@/models/points'
+import type { LeaderboardEntry, LedgerExportParams, PointsStatement, PointsSummary } from '@/models/points'
+import { ledgerSearchParams, saveBlob } from '@/lib/download'
 import { apiClient } from './api'
 
 export const pointsService = {
@@ -9,4 +10,16 @@ export const pointsService = {
   async getLeaderboard(limit = 20) {
     return apiClient.get('points/leaderboard', { searchParams: { limit } }).json<{ items: LeaderboardEntry[] }>()
   },
+
+  // Выписка за месяц YYYY-MM; без month — текущий месяц.
+  async getStatement(month?: string) {
+    return apiClient.get('points/me/statement', {
+      searchParams: month ? { month } : {},
+    }).json<PointsStatement>()
+  },
+
+  async exportMine(params: LedgerExportParams) {
+    const blob = await apiClient.get('points/me/export', { searchParams: ledgerSearchParams(params) }).blob()
+    saveBlob(blob, `points.${params.format}`)
+  },
 }
@@ -1,4 +1,4 @@
-import type { RaffleItem } from '@/models/raffle'
+import type { RaffleDrawProof, RaffleItem } from '@/models/raffle'
 import { apiClient } from './api'
 
 export const raffleService = {
@@ -9,4 +9,8 @@ export const raffleService = {
   async buyTickets(id: number, count = 1) {
     return apiClient.post(`raffles/${id}/buy`, { json: { count } }).json()
   },
+
+  async getDrawProof(id: number) {
+    return apiClient.get(`raffles/${id}/draw`).json<RaffleDrawProof>()
+  },
 }
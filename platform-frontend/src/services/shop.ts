@@ -0,0 +1,21 @@
+import type { ShopItem, ShopOrder } from '@/models/shop'
+import { apiClient } from './api'
+
+export const shopService = {
+  async getItems() {
+    return apiClient.get('shop/items').json<{ items: ShopItem[] }>()
+  },
+
+  // comment — контакты, размер и прочее, что нужно для выдачи.
+  async buy(id: number, comment = '') {
+    return apiClient.post(`shop/items/${id}/buy`, { json: { comment } }).json<ShopOrder>()
+  },
+
+  async getMyOrders() {
+    return apiClient.get('shop/orders').json<{ items: ShopOrder[] }>()
+  },
+
+  async cancelOrder(id: number) {
+    return apiClient.post(`shop/orders/${id}/cancel`).json<ShopOrder>()
+  },
+}